@@ -40,12 +40,28 @@ type CpuStats struct {
 	UsageTotal  uint64
 	UsageUser   uint64
 	UsageSystem uint64
+
+	NrPeriods     uint64
+	NrThrottled   uint64
+	ThrottledTime uint64
 }
 
 type MemoryStats struct {
 	InactiveFileTotal uint64
 }
 
+type MemoryEvents struct {
+	// OOM is the number of times the memory limit was reached and allocations failed
+	OOM uint64
+	// OOMKill is the number of processes killed by the OOM killer
+	OOMKill uint64
+}
+
+type IOStats struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+}
+
 func ReadSingleValue(path string) (uint64, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
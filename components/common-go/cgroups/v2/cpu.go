@@ -19,6 +19,10 @@ const (
 	StatUsageTotal  = "usage_usec"
 	StatUsageUser   = "user_usec"
 	StatUsageSystem = "system_usec"
+
+	StatNrPeriods     = "nr_periods"
+	StatNrThrottled   = "nr_throttled"
+	StatThrottledTime = "throttled_usec"
 )
 
 type Cpu struct {
@@ -68,7 +72,7 @@ func (c *Cpu) Max() (quota uint64, period uint64, err error) {
 	return quota, period, nil
 }
 
-// Stat returns cpu statistics (all values are in microseconds)
+// Stat returns cpu statistics (all times are in microseconds)
 func (c *Cpu) Stat() (*cgroups.CpuStats, error) {
 	path := filepath.Join(c.path, "cpu.stat")
 	statMap, err := cgroups.ReadFlatKeyedFile(path)
@@ -80,6 +84,10 @@ func (c *Cpu) Stat() (*cgroups.CpuStats, error) {
 		UsageTotal:  statMap[StatUsageTotal],
 		UsageUser:   statMap[StatUsageUser],
 		UsageSystem: statMap[StatUsageSystem],
+
+		NrPeriods:     statMap[StatNrPeriods],
+		NrThrottled:   statMap[StatNrThrottled],
+		ThrottledTime: statMap[StatThrottledTime],
 	}
 
 	return &stats, nil
@@ -5,7 +5,11 @@
 package cgroups_v2
 
 import (
+	"bufio"
+	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/gitpod-io/gitpod/common-go/cgroups"
 )
@@ -31,3 +35,42 @@ func (io *IO) PSI() (cgroups.PSI, error) {
 	path := filepath.Join(io.path, "io.pressure")
 	return cgroups.ReadPSIValue(path)
 }
+
+// Stat returns the bytes read and written by the cgroup, summed up over all devices
+func (io *IO) Stat() (*cgroups.IOStats, error) {
+	file, err := os.Open(filepath.Join(io.path, "io.stat"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var stats cgroups.IOStats
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// each line looks like "8:0 rbytes=1459200 wbytes=314773504 rios=192 wios=353 dbytes=0 dios=0"
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "rbytes":
+				stats.ReadBytes += v
+			case "wbytes":
+				stats.WriteBytes += v
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
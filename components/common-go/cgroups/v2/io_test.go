@@ -0,0 +1,40 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cgroups_v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gitpod-io/gitpod/common-go/cgroups"
+)
+
+func TestIOStat(t *testing.T) {
+	mountPoint := t.TempDir()
+	cgroupPath := filepath.Join(mountPoint, "cgroup")
+	if err := os.MkdirAll(cgroupPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "8:0 rbytes=1459200 wbytes=314773504 rios=192 wios=353 dbytes=0 dios=0\n" +
+		"253:1 rbytes=800 wbytes=496 rios=2 wios=1 dbytes=0 dios=0\n"
+	if err := os.WriteFile(filepath.Join(cgroupPath, "io.stat"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := NewIOControllerWithMount(mountPoint, "cgroup").Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &cgroups.IOStats{ReadBytes: 1460000, WriteBytes: 314774000}, stats)
+}
+
+func TestIOStatNotExist(t *testing.T) {
+	_, err := NewIOControllerWithMount("/this/does/not", "exist").Stat()
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -64,6 +64,20 @@ func (m *Memory) Stat() (*cgroups.MemoryStats, error) {
 	}, nil
 }
 
+// Events returns how often the cgroup ran out of memory and how many processes were killed as a consequence.
+func (m *Memory) Events() (*cgroups.MemoryEvents, error) {
+	path := filepath.Join(m.path, "memory.events")
+	events, err := cgroups.ReadFlatKeyedFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cgroups.MemoryEvents{
+		OOM:     events["oom"],
+		OOMKill: events["oom_kill"],
+	}, nil
+}
+
 func (m *Memory) PSI() (cgroups.PSI, error) {
 	path := filepath.Join(m.path, "memory.pressure")
 	return cgroups.ReadPSIValue(path)
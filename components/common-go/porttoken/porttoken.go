@@ -0,0 +1,86 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Package porttoken mints and verifies tokens which grant access to a protected workspace port.
+// supervisor mints the tokens and ws-proxy verifies them, both using the workspace owner token as secret.
+// Tokens are bound to the token epoch of the port, which changes whenever the port becomes protected,
+// s.t. making a port private again invalidates all tokens minted for it.
+package porttoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// QueryParam is the query parameter which carries a token in signed URLs.
+	QueryParam = "gitpod_port_token"
+
+	// prefix identifies port tokens, e.g. to tell them apart from other bearer tokens.
+	prefix = "gpport1"
+)
+
+var (
+	// ErrInvalid means the token is malformed, or was not minted for the port.
+	ErrInvalid = errors.New("invalid port token")
+	// ErrExpired means the token was valid, but has expired.
+	ErrExpired = errors.New("port token expired")
+)
+
+// IsToken returns true if s looks like a port token.
+func IsToken(s string) bool {
+	return strings.HasPrefix(s, prefix+".")
+}
+
+// Mint produces a token which grants access to the port of a workspace instance until expiry,
+// as long as the port's token epoch does not change.
+func Mint(secret, instanceID string, port uint32, epoch uint64, expiry time.Time) string {
+	exp := strconv.FormatInt(expiry.Unix(), 10)
+	return fmt.Sprintf("%s.%s.%s", prefix, exp, sign(secret, instanceID, port, epoch, exp))
+}
+
+// Verify checks that the token grants access to the port of a workspace instance with the given token epoch at the given time.
+// Ports without token epoch are not protected, hence no token grants access to them.
+func Verify(secret, instanceID string, port uint32, epoch uint64, token string, now time.Time) error {
+	segs := strings.Split(token, ".")
+	if len(segs) != 3 || segs[0] != prefix || secret == "" || epoch == 0 {
+		return ErrInvalid
+	}
+	exp, err := strconv.ParseInt(segs[1], 10, 64)
+	if err != nil {
+		return ErrInvalid
+	}
+	if !hmac.Equal([]byte(segs[2]), []byte(sign(secret, instanceID, port, epoch, segs[1]))) {
+		return ErrInvalid
+	}
+	if now.Unix() >= exp {
+		return ErrExpired
+	}
+	return nil
+}
+
+// ExpiresAt returns the expiry encoded in a token. It does not verify the token.
+func ExpiresAt(token string) (time.Time, error) {
+	segs := strings.Split(token, ".")
+	if len(segs) != 3 || segs[0] != prefix {
+		return time.Time{}, ErrInvalid
+	}
+	exp, err := strconv.ParseInt(segs[1], 10, 64)
+	if err != nil {
+		return time.Time{}, ErrInvalid
+	}
+	return time.Unix(exp, 0), nil
+}
+
+func sign(secret, instanceID string, port uint32, epoch uint64, exp string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s\x00%s\x00%d\x00%d\x00%s", prefix, instanceID, port, epoch, exp)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package porttoken_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/porttoken"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1660000000, 0)
+	token := porttoken.Mint("secret", "instance", 3000, 1, now.Add(time.Hour))
+
+	tests := []struct {
+		Desc        string
+		Secret      string
+		InstanceID  string
+		Port        uint32
+		Epoch       uint64
+		Token       string
+		Now         time.Time
+		Expectation error
+	}{
+		{Desc: "valid", Secret: "secret", InstanceID: "instance", Port: 3000, Epoch: 1, Token: token, Now: now},
+		{Desc: "expired", Secret: "secret", InstanceID: "instance", Port: 3000, Epoch: 1, Token: token, Now: now.Add(2 * time.Hour), Expectation: porttoken.ErrExpired},
+		{Desc: "other port", Secret: "secret", InstanceID: "instance", Port: 3001, Epoch: 1, Token: token, Now: now, Expectation: porttoken.ErrInvalid},
+		{Desc: "other instance", Secret: "secret", InstanceID: "other", Port: 3000, Epoch: 1, Token: token, Now: now, Expectation: porttoken.ErrInvalid},
+		{Desc: "other secret", Secret: "other", InstanceID: "instance", Port: 3000, Epoch: 1, Token: token, Now: now, Expectation: porttoken.ErrInvalid},
+		{Desc: "empty secret", InstanceID: "instance", Port: 3000, Epoch: 1, Token: porttoken.Mint("", "instance", 3000, 1, now.Add(time.Hour)), Now: now, Expectation: porttoken.ErrInvalid},
+		{Desc: "other epoch", Secret: "secret", InstanceID: "instance", Port: 3000, Epoch: 2, Token: token, Now: now, Expectation: porttoken.ErrInvalid},
+		{Desc: "unprotected port", Secret: "secret", InstanceID: "instance", Port: 3000, Token: token, Now: now, Expectation: porttoken.ErrInvalid},
+		{Desc: "malformed", Secret: "secret", InstanceID: "instance", Port: 3000, Epoch: 1, Token: "foobar", Now: now, Expectation: porttoken.ErrInvalid},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			err := porttoken.Verify(test.Secret, test.InstanceID, test.Port, test.Epoch, test.Token, test.Now)
+			if err != test.Expectation {
+				t.Errorf("unexpected error: want %v, got %v", test.Expectation, err)
+			}
+		})
+	}
+	if !porttoken.IsToken(token) {
+		t.Errorf("IsToken(%q) = false", token)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"github.com/gitpod-io/gitpod/common-go/baseserver"
+	"github.com/gitpod-io/gitpod/common-go/util"
 )
 
 // StorageConfig configures the remote storage we use
@@ -27,7 +28,62 @@ type StorageConfig struct {
 	// S3Config configures the S3 remote storage
 	S3Config *S3Config `json:"s3,omitempty"`
 
+	// LocalConfig configures the local filesystem storage
+	LocalConfig *LocalConfig `json:"local,omitempty"`
+
+	// AzureConfig configures the Azure Blob remote storage
+	AzureConfig *AzureConfig `json:"azure,omitempty"`
+
 	BlobQuota int64 `json:"blobQuota"`
+
+	// Encryption enables client-side envelope encryption of workspace content. Disabled if nil.
+	Encryption *EncryptionConfig `json:"encryption,omitempty"`
+
+	// Download configures the downloads the content-service serves itself, i.e. the plaintext of encrypted objects
+	// and chunked backups as a single tarball. Those cannot be downloaded from the storage directly. Disabled if nil.
+	Download *DownloadConfig `json:"download,omitempty"`
+}
+
+// DownloadConfig configures the downloads the content-service serves
+type DownloadConfig struct {
+	// URL is the base URL under which the content-service serves downloads
+	URL string `json:"url"`
+
+	// SigningKeyFile contains the secret download URLs are signed with
+	SigningKeyFile string `json:"signingKeyFile"`
+}
+
+// EncryptionConfig configures the envelope encryption of workspace content.
+// Each workspace gets its own data key, which is wrapped by a key from the KMS.
+// Full workspace backups are served as image layers straight from the storage and hence are never encrypted.
+// Content layers must not carry data keys, hence encrypted content can only be restored from content layers
+// if the content-service serves downloads (see StorageConfig.Download).
+type EncryptionConfig struct {
+	// KMS determines which key management system wraps the data keys
+	KMS KMSType `json:"kms"`
+
+	// RotationInterval is the time between two runs which re-wrap all data keys with the current key encryption key.
+	// Retired key encryption keys can be removed once a run succeeded. If zero, data keys are only re-wrapped when
+	// their workspace is backed up.
+	RotationInterval util.Duration `json:"rotationInterval,omitempty"`
+
+	// File configures the local file-based KMS
+	File *FileKMSConfig `json:"file,omitempty"`
+}
+
+// KMSType is a kind of key management system
+type KMSType string
+
+const (
+	// FileKMS reads key encryption keys from a local keyring file
+	FileKMS KMSType = "file"
+)
+
+// FileKMSConfig configures the local file-based KMS
+type FileKMSConfig struct {
+	// KeyringFile is a JSON file of the form {"current": "<key ID>", "keys": {"<key ID>": "<base64 encoded 256 bit key>"}}.
+	// To rotate keys, add a new key and make it the current one.
+	KeyringFile string `json:"keyringFile"`
 }
 
 // Stage represents the deployment environment in which we're operating
@@ -58,6 +114,13 @@ const (
 	// exist in the environment. See https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/config#LoadDefaultConfig for more details.
 	S3Storage RemoteStorageType = "s3"
 
+	// LocalStorage stores workspaces in a local directory, e.g. an NFS mount shared by all nodes.
+	// Presigned URLs are served by the content-service.
+	LocalStorage RemoteStorageType = "local"
+
+	// AzureStorage stores workspaces in Azure Blob storage containers
+	AzureStorage RemoteStorageType = "azure"
+
 	// NullStorage does not synchronize workspaces at all
 	NullStorage RemoteStorageType = ""
 )
@@ -116,6 +179,79 @@ type S3Config struct {
 	CredentialsFile string `json:"credentialsFile"`
 }
 
+// LocalConfig configures the local filesystem storage backend
+type LocalConfig struct {
+	// Directory is the root of the storage. Every bucket is a directory within.
+	Directory string `json:"directory"`
+
+	// URL is the base URL under which the content-service serves presigned up- and downloads
+	URL string `json:"url"`
+
+	// SigningKeyFile contains the secret presigned URLs are signed with
+	SigningKeyFile string `json:"signingKeyFile"`
+}
+
+// AzureConfig configures the Azure Blob storage backend
+type AzureConfig struct {
+	AccountName    string `json:"accountName"`
+	AccountKey     string `json:"accountKey"`
+	AccountKeyFile string `json:"accountKeyFile"`
+
+	// Endpoint is the blob service URL. Defaults to https://<accountName>.blob.core.windows.net/
+	Endpoint string `json:"endpoint,omitempty"`
+
+	ParallelUpload int `json:"parallelUpload,omitempty"`
+
+	// Container stores all content in a single container. If empty, every user gets their own container.
+	Container string `json:"container,omitempty"`
+}
+
+// RetentionConfig configures which workspace content is deleted once it is no longer needed.
+// Content of workspaces with instances which have not stopped yet and of available prebuilds is never deleted,
+// neither are the layers the current content manifests reference.
+type RetentionConfig struct {
+	// Interval is the time between two sweeps. If zero, nothing is deleted and only the dry-run report is available.
+	Interval util.Duration `json:"interval,omitempty"`
+
+	// IDEPluginBucket is the bucket IDE plugins are uploaded to. Required for the IDE plugin policy.
+	IDEPluginBucket string `json:"idePluginBucket,omitempty"`
+
+	// Policies determine how long objects of each class are kept. Classes without a policy are kept forever.
+	Policies map[ObjectClass]RetentionPolicy `json:"policies"`
+}
+
+// ObjectClass is a kind of object in the remote storage which is subject to retention
+type ObjectClass string
+
+const (
+	// ObjectClassBackup are full workspace backups (wsfull-*.tar)
+	ObjectClassBackup ObjectClass = "backup"
+
+	// ObjectClassSnapshot are workspace snapshots (snapshot-*.tar)
+	ObjectClassSnapshot ObjectClass = "snapshot"
+
+	// ObjectClassPrebuildLog are the logs uploaded by headless workspace instances
+	ObjectClassPrebuildLog ObjectClass = "prebuildLog"
+
+	// ObjectClassIDEPlugin are objects in the IDE plugin bucket. All uploads of a plugin share a directory.
+	ObjectClassIDEPlugin ObjectClass = "idePlugin"
+
+	// ObjectClassBackupChunk are chunks of chunked backups. They are deleted once no chunk manifest of their
+	// workspace references them. MaxAge is required and acts as grace period: workspaces which have written chunks
+	// or chunk manifests within MaxAge keep all their chunks, because backups which are still being uploaded
+	// reuse the chunks which are present already.
+	ObjectClassBackupChunk ObjectClass = "backupChunk"
+)
+
+// RetentionPolicy determines which objects of a class are kept. An object is deleted once it violates any of the rules.
+type RetentionPolicy struct {
+	// KeepLatest keeps the N most recently modified objects per workspace, or per plugin for IDE plugins. Zero disables the rule.
+	KeepLatest int `json:"keepLatest,omitempty"`
+
+	// MaxAge keeps objects which were modified within this duration. Zero disables the rule.
+	MaxAge util.Duration `json:"maxAge,omitempty"`
+}
+
 type PProf struct {
 	Addr string `json:"address"`
 }
@@ -127,7 +263,12 @@ type UsageReportConfig struct {
 
 type ServiceConfig struct {
 	Service baseserver.ServerConfiguration `json:"service"`
-	Storage StorageConfig                  `json:"storage"`
+	// HTTP serves presigned URLs of the local storage and the downloads of the content-service.
+	// Required if the storage kind is local or downloads are configured.
+	HTTP    *baseserver.ServerConfiguration `json:"http,omitempty"`
+	Storage StorageConfig                   `json:"storage"`
+	// Retention configures the garbage collection of workspace content. Disabled if nil.
+	Retention *RetentionConfig `json:"retention,omitempty"`
 	// Deprecated
 	_ UsageReportConfig `json:"usageReport"`
 }
@@ -5,6 +5,8 @@
 package api
 
 import (
+	"time"
+
 	digest "github.com/opencontainers/go-digest"
 	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
 )
@@ -13,8 +15,20 @@ const (
 	// ContentTypeManifest manifest is the content type for a JSON serialized WorkspaceContentManifest
 	ContentTypeManifest = "application/vnd.gitpod.ws.manifest.v1+json"
 
+	// ContentTypeChunkedBackup is the content type for a JSON serialized ChunkedBackupManifest
+	ContentTypeChunkedBackup = "application/vnd.gitpod.ws.backup.chunked.v1+json"
+
+	// ContentTypeBackupHistory is the content type for a JSON serialized BackupHistory
+	ContentTypeBackupHistory = "application/vnd.gitpod.ws.backup.history.v1+json"
+
 	// MediaTypeUncompressedLayer is a valid OCIv1 media type for uncompressed layer archives
 	MediaTypeUncompressedLayer = ociv1.MediaTypeImageLayer
+
+	// MediaTypeGzipLayer is a valid OCIv1 media type for gzip compressed layer archives
+	MediaTypeGzipLayer = ociv1.MediaTypeImageLayerGzip
+
+	// MediaTypeZstdLayer is a valid OCIv1 media type for zstd compressed layer archives
+	MediaTypeZstdLayer = "application/vnd.oci.image.layer.v1.tar+zstd"
 )
 
 // WorkspaceContentManifest describes the content that makes up a workspace
@@ -46,3 +60,55 @@ type WorkspaceContentLayer struct {
 	// Workspace instance ID this content layer came from
 	InstanceID string `json:"instanceID"`
 }
+
+// ChunkedBackupManifest describes a workspace backup which is stored as content-defined chunks.
+// Concatenating the chunks in order yields the uncompressed backup tarball.
+type ChunkedBackupManifest struct {
+	// Digest is the digest of the complete tarball.
+	Digest digest.Digest `json:"digest"`
+	// Size is the size of the complete tarball in bytes.
+	Size int64 `json:"size"`
+	// Created is the time the backup was made.
+	Created time.Time `json:"created"`
+
+	Chunks []BackupChunk `json:"chunks"`
+}
+
+// BackupChunk references a single chunk of a chunked backup.
+type BackupChunk struct {
+	// Digest is the digest of the chunk content which also determines its object name.
+	Digest digest.Digest `json:"digest"`
+	// Size is the size of the chunk in bytes.
+	Size int64 `json:"size"`
+}
+
+// BackupHistory lists the previous backups of a workspace which can still be restored.
+type BackupHistory struct {
+	// Backups are ordered from newest to oldest.
+	Backups []BackupVersion `json:"backups"`
+}
+
+// BackupVersion describes a single backup kept in the history of a workspace.
+type BackupVersion struct {
+	// ID identifies the backup within the history of its workspace.
+	ID string `json:"id"`
+	// Created is the time the backup was made.
+	Created time.Time `json:"created"`
+	// Object is the name of the backup relative to the workspace's backup location.
+	// Depending on Chunked, this is either a backup tarball or a ChunkedBackupManifest.
+	Object string `json:"object"`
+	// Chunked is true if Object is a ChunkedBackupManifest.
+	Chunked bool `json:"chunked,omitempty"`
+	// Size is the size of Object in bytes, or of the assembled tarball for chunked backups.
+	Size int64 `json:"size"`
+}
+
+// Find returns the backup with the given ID.
+func (h *BackupHistory) Find(id string) (v BackupVersion, ok bool) {
+	for _, b := range h.Backups {
+		if b.ID == id {
+			return b, true
+		}
+	}
+	return v, false
+}
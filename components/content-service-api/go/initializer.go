@@ -141,6 +141,8 @@ func WalkInitializer(path []string, init *WorkspaceInitializer, visitor func(pat
 		return visitor(append(path, "download"), init)
 	case *WorkspaceInitializer_Backup:
 		return visitor(append(path, "backup"), init)
+	case *WorkspaceInitializer_Oci:
+		return visitor(append(path, "oci"), init)
 
 	default:
 		return fmt.Errorf("unsupported workspace initializer in walkInitializer - this is a bug in Gitpod")
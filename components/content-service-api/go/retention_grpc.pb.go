@@ -0,0 +1,111 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: retention.proto
+
+package api
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// RetentionServiceClient is the client API for RetentionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RetentionServiceClient interface {
+	// Report lists the objects the retention policies would delete without deleting anything
+	Report(ctx context.Context, in *RetentionReportRequest, opts ...grpc.CallOption) (*RetentionReportResponse, error)
+}
+
+type retentionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRetentionServiceClient(cc grpc.ClientConnInterface) RetentionServiceClient {
+	return &retentionServiceClient{cc}
+}
+
+func (c *retentionServiceClient) Report(ctx context.Context, in *RetentionReportRequest, opts ...grpc.CallOption) (*RetentionReportResponse, error) {
+	out := new(RetentionReportResponse)
+	err := c.cc.Invoke(ctx, "/contentservice.RetentionService/Report", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RetentionServiceServer is the server API for RetentionService service.
+// All implementations must embed UnimplementedRetentionServiceServer
+// for forward compatibility
+type RetentionServiceServer interface {
+	// Report lists the objects the retention policies would delete without deleting anything
+	Report(context.Context, *RetentionReportRequest) (*RetentionReportResponse, error)
+	mustEmbedUnimplementedRetentionServiceServer()
+}
+
+// UnimplementedRetentionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRetentionServiceServer struct {
+}
+
+func (UnimplementedRetentionServiceServer) Report(context.Context, *RetentionReportRequest) (*RetentionReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Report not implemented")
+}
+func (UnimplementedRetentionServiceServer) mustEmbedUnimplementedRetentionServiceServer() {}
+
+// UnsafeRetentionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RetentionServiceServer will
+// result in compilation errors.
+type UnsafeRetentionServiceServer interface {
+	mustEmbedUnimplementedRetentionServiceServer()
+}
+
+func RegisterRetentionServiceServer(s grpc.ServiceRegistrar, srv RetentionServiceServer) {
+	s.RegisterService(&RetentionService_ServiceDesc, srv)
+}
+
+func _RetentionService_Report_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetentionReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RetentionServiceServer).Report(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/contentservice.RetentionService/Report",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RetentionServiceServer).Report(ctx, req.(*RetentionReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RetentionService_ServiceDesc is the grpc.ServiceDesc for RetentionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RetentionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "contentservice.RetentionService",
+	HandlerType: (*RetentionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Report",
+			Handler:    _RetentionService_Report_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "retention.proto",
+}
@@ -32,6 +32,8 @@ type WorkspaceServiceClient interface {
 	DeleteWorkspace(ctx context.Context, in *DeleteWorkspaceRequest, opts ...grpc.CallOption) (*DeleteWorkspaceResponse, error)
 	// WorkspaceSnapshotExists checks whether the snapshot exists or not
 	WorkspaceSnapshotExists(ctx context.Context, in *WorkspaceSnapshotExistsRequest, opts ...grpc.CallOption) (*WorkspaceSnapshotExistsResponse, error)
+	// ListWorkspaceBackups lists the previous backups of a workspace which can be restored
+	ListWorkspaceBackups(ctx context.Context, in *ListWorkspaceBackupsRequest, opts ...grpc.CallOption) (*ListWorkspaceBackupsResponse, error)
 }
 
 type workspaceServiceClient struct {
@@ -69,6 +71,15 @@ func (c *workspaceServiceClient) WorkspaceSnapshotExists(ctx context.Context, in
 	return out, nil
 }
 
+func (c *workspaceServiceClient) ListWorkspaceBackups(ctx context.Context, in *ListWorkspaceBackupsRequest, opts ...grpc.CallOption) (*ListWorkspaceBackupsResponse, error) {
+	out := new(ListWorkspaceBackupsResponse)
+	err := c.cc.Invoke(ctx, "/contentservice.WorkspaceService/ListWorkspaceBackups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkspaceServiceServer is the server API for WorkspaceService service.
 // All implementations must embed UnimplementedWorkspaceServiceServer
 // for forward compatibility
@@ -79,6 +90,8 @@ type WorkspaceServiceServer interface {
 	DeleteWorkspace(context.Context, *DeleteWorkspaceRequest) (*DeleteWorkspaceResponse, error)
 	// WorkspaceSnapshotExists checks whether the snapshot exists or not
 	WorkspaceSnapshotExists(context.Context, *WorkspaceSnapshotExistsRequest) (*WorkspaceSnapshotExistsResponse, error)
+	// ListWorkspaceBackups lists the previous backups of a workspace which can be restored
+	ListWorkspaceBackups(context.Context, *ListWorkspaceBackupsRequest) (*ListWorkspaceBackupsResponse, error)
 	mustEmbedUnimplementedWorkspaceServiceServer()
 }
 
@@ -95,6 +108,9 @@ func (UnimplementedWorkspaceServiceServer) DeleteWorkspace(context.Context, *Del
 func (UnimplementedWorkspaceServiceServer) WorkspaceSnapshotExists(context.Context, *WorkspaceSnapshotExistsRequest) (*WorkspaceSnapshotExistsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WorkspaceSnapshotExists not implemented")
 }
+func (UnimplementedWorkspaceServiceServer) ListWorkspaceBackups(context.Context, *ListWorkspaceBackupsRequest) (*ListWorkspaceBackupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkspaceBackups not implemented")
+}
 func (UnimplementedWorkspaceServiceServer) mustEmbedUnimplementedWorkspaceServiceServer() {}
 
 // UnsafeWorkspaceServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -162,6 +178,24 @@ func _WorkspaceService_WorkspaceSnapshotExists_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkspaceService_ListWorkspaceBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkspaceBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).ListWorkspaceBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/contentservice.WorkspaceService/ListWorkspaceBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).ListWorkspaceBackups(ctx, req.(*ListWorkspaceBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkspaceService_ServiceDesc is the grpc.ServiceDesc for WorkspaceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -181,6 +215,10 @@ var WorkspaceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "WorkspaceSnapshotExists",
 			Handler:    _WorkspaceService_WorkspaceSnapshotExists_Handler,
 		},
+		{
+			MethodName: "ListWorkspaceBackups",
+			Handler:    _WorkspaceService_ListWorkspaceBackups_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "workspace.proto",
@@ -42,6 +42,9 @@ export class UploadUrlResponse extends jspb.Message {
     getUrl(): string;
     setUrl(value: string): UploadUrlResponse;
 
+    getHeadersMap(): jspb.Map<string, string>;
+    clearHeadersMap(): void;
+
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): UploadUrlResponse.AsObject;
     static toObject(includeInstance: boolean, msg: UploadUrlResponse): UploadUrlResponse.AsObject;
@@ -55,6 +58,8 @@ export class UploadUrlResponse extends jspb.Message {
 export namespace UploadUrlResponse {
     export type AsObject = {
         url: string,
+
+        headersMap: Array<[string, string]>,
     }
 }
 
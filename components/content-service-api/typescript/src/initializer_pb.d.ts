@@ -49,6 +49,11 @@ export class WorkspaceInitializer extends jspb.Message {
     getBackup(): FromBackupInitializer | undefined;
     setBackup(value?: FromBackupInitializer): WorkspaceInitializer;
 
+    hasOci(): boolean;
+    clearOci(): void;
+    getOci(): OCIInitializer | undefined;
+    setOci(value?: OCIInitializer): WorkspaceInitializer;
+
     getSpecCase(): WorkspaceInitializer.SpecCase;
 
     serializeBinary(): Uint8Array;
@@ -70,6 +75,7 @@ export namespace WorkspaceInitializer {
         composite?: CompositeInitializer.AsObject,
         download?: FileDownloadInitializer.AsObject,
         backup?: FromBackupInitializer.AsObject,
+        oci?: OCIInitializer.AsObject,
     }
 
     export enum SpecCase {
@@ -81,6 +87,7 @@ export namespace WorkspaceInitializer {
         COMPOSITE = 5,
         DOWNLOAD = 6,
         BACKUP = 7,
+        OCI = 8,
     }
 
 }
@@ -139,6 +146,8 @@ export namespace FileDownloadInitializer {
         setFilePath(value: string): FileInfo;
         getDigest(): string;
         setDigest(value: string): FileInfo;
+        getExtractTo(): string;
+        setExtractTo(value: string): FileInfo;
 
         serializeBinary(): Uint8Array;
         toObject(includeInstance?: boolean): FileInfo.AsObject;
@@ -155,11 +164,35 @@ export namespace FileDownloadInitializer {
             url: string,
             filePath: string,
             digest: string,
+            extractTo: string,
         }
     }
 
 }
 
+export class OCIInitializer extends jspb.Message {
+    getRef(): string;
+    setRef(value: string): OCIInitializer;
+    getTargetLocation(): string;
+    setTargetLocation(value: string): OCIInitializer;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): OCIInitializer.AsObject;
+    static toObject(includeInstance: boolean, msg: OCIInitializer): OCIInitializer.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: OCIInitializer, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): OCIInitializer;
+    static deserializeBinaryFromReader(message: OCIInitializer, reader: jspb.BinaryReader): OCIInitializer;
+}
+
+export namespace OCIInitializer {
+    export type AsObject = {
+        ref: string,
+        targetLocation: string,
+    }
+}
+
 export class EmptyInitializer extends jspb.Message {
 
     serializeBinary(): Uint8Array;
@@ -193,6 +226,17 @@ export class GitInitializer extends jspb.Message {
     clearConfig(): void;
     getConfig(): GitConfig | undefined;
     setConfig(value?: GitConfig): GitInitializer;
+    getPartialCloneFilter(): string;
+    setPartialCloneFilter(value: string): GitInitializer;
+    clearSparseCheckoutList(): void;
+    getSparseCheckoutList(): Array<string>;
+    setSparseCheckoutList(value: Array<string>): GitInitializer;
+    addSparseCheckout(value: string, index?: number): string;
+
+    hasLfs(): boolean;
+    clearLfs(): void;
+    getLfs(): GitLFSConfig | undefined;
+    setLfs(value?: GitLFSConfig): GitInitializer;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): GitInitializer.AsObject;
@@ -212,6 +256,36 @@ export namespace GitInitializer {
         cloneTaget: string,
         checkoutLocation: string,
         config?: GitConfig.AsObject,
+        partialCloneFilter: string,
+        sparseCheckoutList: Array<string>,
+        lfs?: GitLFSConfig.AsObject,
+    }
+}
+
+export class GitLFSConfig extends jspb.Message {
+    clearIncludeList(): void;
+    getIncludeList(): Array<string>;
+    setIncludeList(value: Array<string>): GitLFSConfig;
+    addInclude(value: string, index?: number): string;
+    clearExcludeList(): void;
+    getExcludeList(): Array<string>;
+    setExcludeList(value: Array<string>): GitLFSConfig;
+    addExclude(value: string, index?: number): string;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): GitLFSConfig.AsObject;
+    static toObject(includeInstance: boolean, msg: GitLFSConfig): GitLFSConfig.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: GitLFSConfig, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): GitLFSConfig;
+    static deserializeBinaryFromReader(message: GitLFSConfig, reader: jspb.BinaryReader): GitLFSConfig;
+}
+
+export namespace GitLFSConfig {
+    export type AsObject = {
+        includeList: Array<string>,
+        excludeList: Array<string>,
     }
 }
 
@@ -305,6 +379,8 @@ export class FromBackupInitializer extends jspb.Message {
     setCheckoutLocation(value: string): FromBackupInitializer;
     getFromVolumeSnapshot(): boolean;
     setFromVolumeSnapshot(value: boolean): FromBackupInitializer;
+    getBackupId(): string;
+    setBackupId(value: string): FromBackupInitializer;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): FromBackupInitializer.AsObject;
@@ -320,6 +396,7 @@ export namespace FromBackupInitializer {
     export type AsObject = {
         checkoutLocation: string,
         fromVolumeSnapshot: boolean,
+        backupId: string,
     }
 }
 
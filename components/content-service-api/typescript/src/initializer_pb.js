@@ -30,7 +30,9 @@ goog.exportSymbol('proto.contentservice.FromBackupInitializer', null, global);
 goog.exportSymbol('proto.contentservice.GitAuthMethod', null, global);
 goog.exportSymbol('proto.contentservice.GitConfig', null, global);
 goog.exportSymbol('proto.contentservice.GitInitializer', null, global);
+goog.exportSymbol('proto.contentservice.GitLFSConfig', null, global);
 goog.exportSymbol('proto.contentservice.GitStatus', null, global);
+goog.exportSymbol('proto.contentservice.OCIInitializer', null, global);
 goog.exportSymbol('proto.contentservice.PrebuildInitializer', null, global);
 goog.exportSymbol('proto.contentservice.SnapshotInitializer', null, global);
 goog.exportSymbol('proto.contentservice.WorkspaceInitializer', null, global);
@@ -119,6 +121,27 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.contentservice.FileDownloadInitializer.FileInfo.displayName = 'proto.contentservice.FileDownloadInitializer.FileInfo';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.contentservice.OCIInitializer = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.contentservice.OCIInitializer, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.contentservice.OCIInitializer.displayName = 'proto.contentservice.OCIInitializer';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -151,7 +174,7 @@ if (goog.DEBUG && !COMPILED) {
  * @constructor
  */
 proto.contentservice.GitInitializer = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.contentservice.GitInitializer.repeatedFields_, null);
 };
 goog.inherits(proto.contentservice.GitInitializer, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
@@ -161,6 +184,27 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.contentservice.GitInitializer.displayName = 'proto.contentservice.GitInitializer';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.contentservice.GitLFSConfig = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.contentservice.GitLFSConfig.repeatedFields_, null);
+};
+goog.inherits(proto.contentservice.GitLFSConfig, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.contentservice.GitLFSConfig.displayName = 'proto.contentservice.GitLFSConfig';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -275,7 +319,7 @@ if (goog.DEBUG && !COMPILED) {
  * @private {!Array<!Array<number>>}
  * @const
  */
-proto.contentservice.WorkspaceInitializer.oneofGroups_ = [[1,2,3,4,5,6,7]];
+proto.contentservice.WorkspaceInitializer.oneofGroups_ = [[1,2,3,4,5,6,7,8]];
 
 /**
  * @enum {number}
@@ -288,7 +332,8 @@ proto.contentservice.WorkspaceInitializer.SpecCase = {
   PREBUILD: 4,
   COMPOSITE: 5,
   DOWNLOAD: 6,
-  BACKUP: 7
+  BACKUP: 7,
+  OCI: 8
 };
 
 /**
@@ -335,7 +380,8 @@ proto.contentservice.WorkspaceInitializer.toObject = function(includeInstance, m
     prebuild: (f = msg.getPrebuild()) && proto.contentservice.PrebuildInitializer.toObject(includeInstance, f),
     composite: (f = msg.getComposite()) && proto.contentservice.CompositeInitializer.toObject(includeInstance, f),
     download: (f = msg.getDownload()) && proto.contentservice.FileDownloadInitializer.toObject(includeInstance, f),
-    backup: (f = msg.getBackup()) && proto.contentservice.FromBackupInitializer.toObject(includeInstance, f)
+    backup: (f = msg.getBackup()) && proto.contentservice.FromBackupInitializer.toObject(includeInstance, f),
+    oci: (f = msg.getOci()) && proto.contentservice.OCIInitializer.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -407,6 +453,11 @@ proto.contentservice.WorkspaceInitializer.deserializeBinaryFromReader = function
       reader.readMessage(value,proto.contentservice.FromBackupInitializer.deserializeBinaryFromReader);
       msg.setBackup(value);
       break;
+    case 8:
+      var value = new proto.contentservice.OCIInitializer;
+      reader.readMessage(value,proto.contentservice.OCIInitializer.deserializeBinaryFromReader);
+      msg.setOci(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -492,6 +543,14 @@ proto.contentservice.WorkspaceInitializer.serializeBinaryToWriter = function(mes
       proto.contentservice.FromBackupInitializer.serializeBinaryToWriter
     );
   }
+  f = message.getOci();
+  if (f != null) {
+    writer.writeMessage(
+      8,
+      f,
+      proto.contentservice.OCIInitializer.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -754,6 +813,43 @@ proto.contentservice.WorkspaceInitializer.prototype.hasBackup = function() {
 };
 
 
+/**
+ * optional OCIInitializer oci = 8;
+ * @return {?proto.contentservice.OCIInitializer}
+ */
+proto.contentservice.WorkspaceInitializer.prototype.getOci = function() {
+  return /** @type{?proto.contentservice.OCIInitializer} */ (
+    jspb.Message.getWrapperField(this, proto.contentservice.OCIInitializer, 8));
+};
+
+
+/**
+ * @param {?proto.contentservice.OCIInitializer|undefined} value
+ * @return {!proto.contentservice.WorkspaceInitializer} returns this
+*/
+proto.contentservice.WorkspaceInitializer.prototype.setOci = function(value) {
+  return jspb.Message.setOneofWrapperField(this, 8, proto.contentservice.WorkspaceInitializer.oneofGroups_[0], value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.contentservice.WorkspaceInitializer} returns this
+ */
+proto.contentservice.WorkspaceInitializer.prototype.clearOci = function() {
+  return this.setOci(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.contentservice.WorkspaceInitializer.prototype.hasOci = function() {
+  return jspb.Message.getField(this, 8) != null;
+};
+
+
 
 /**
  * List of repeated fields within this message type.
@@ -1082,7 +1178,8 @@ proto.contentservice.FileDownloadInitializer.FileInfo.toObject = function(includ
   var f, obj = {
     url: jspb.Message.getFieldWithDefault(msg, 1, ""),
     filePath: jspb.Message.getFieldWithDefault(msg, 2, ""),
-    digest: jspb.Message.getFieldWithDefault(msg, 3, "")
+    digest: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    extractTo: jspb.Message.getFieldWithDefault(msg, 4, "")
   };
 
   if (includeInstance) {
@@ -1131,6 +1228,10 @@ proto.contentservice.FileDownloadInitializer.FileInfo.deserializeBinaryFromReade
       var value = /** @type {string} */ (reader.readString());
       msg.setDigest(value);
       break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setExtractTo(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1181,6 +1282,13 @@ proto.contentservice.FileDownloadInitializer.FileInfo.serializeBinaryToWriter =
       f
     );
   }
+  f = message.getExtractTo();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
 };
 
 
@@ -1238,6 +1346,24 @@ proto.contentservice.FileDownloadInitializer.FileInfo.prototype.setDigest = func
 };
 
 
+/**
+ * optional string extract_to = 4;
+ * @return {string}
+ */
+proto.contentservice.FileDownloadInitializer.FileInfo.prototype.getExtractTo = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.FileDownloadInitializer.FileInfo} returns this
+ */
+proto.contentservice.FileDownloadInitializer.FileInfo.prototype.setExtractTo = function(value) {
+  return jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
 /**
  * repeated FileInfo files = 1;
  * @return {!Array<!proto.contentservice.FileDownloadInitializer.FileInfo>}
@@ -1297,6 +1423,166 @@ proto.contentservice.FileDownloadInitializer.prototype.setTargetLocation = funct
 
 
 
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.contentservice.OCIInitializer.prototype.toObject = function(opt_includeInstance) {
+  return proto.contentservice.OCIInitializer.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.contentservice.OCIInitializer} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.OCIInitializer.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    ref: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    targetLocation: jspb.Message.getFieldWithDefault(msg, 2, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.contentservice.OCIInitializer}
+ */
+proto.contentservice.OCIInitializer.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.contentservice.OCIInitializer;
+  return proto.contentservice.OCIInitializer.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.contentservice.OCIInitializer} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.contentservice.OCIInitializer}
+ */
+proto.contentservice.OCIInitializer.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setRef(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setTargetLocation(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.contentservice.OCIInitializer.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.contentservice.OCIInitializer.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.contentservice.OCIInitializer} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.OCIInitializer.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getRef();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getTargetLocation();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string ref = 1;
+ * @return {string}
+ */
+proto.contentservice.OCIInitializer.prototype.getRef = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.OCIInitializer} returns this
+ */
+proto.contentservice.OCIInitializer.prototype.setRef = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string target_location = 2;
+ * @return {string}
+ */
+proto.contentservice.OCIInitializer.prototype.getTargetLocation = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.OCIInitializer} returns this
+ */
+proto.contentservice.OCIInitializer.prototype.setTargetLocation = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+
+
+
 if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
  * Creates an object representation of this proto.
@@ -1396,6 +1682,13 @@ proto.contentservice.EmptyInitializer.serializeBinaryToWriter = function(message
 
 
 
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.contentservice.GitInitializer.repeatedFields_ = [8];
+
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -1432,7 +1725,10 @@ proto.contentservice.GitInitializer.toObject = function(includeInstance, msg) {
     targetMode: jspb.Message.getFieldWithDefault(msg, 3, 0),
     cloneTaget: jspb.Message.getFieldWithDefault(msg, 4, ""),
     checkoutLocation: jspb.Message.getFieldWithDefault(msg, 5, ""),
-    config: (f = msg.getConfig()) && proto.contentservice.GitConfig.toObject(includeInstance, f)
+    config: (f = msg.getConfig()) && proto.contentservice.GitConfig.toObject(includeInstance, f),
+    partialCloneFilter: jspb.Message.getFieldWithDefault(msg, 7, ""),
+    sparseCheckoutList: (f = jspb.Message.getRepeatedField(msg, 8)) == null ? undefined : f,
+    lfs: (f = msg.getLfs()) && proto.contentservice.GitLFSConfig.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -1494,6 +1790,19 @@ proto.contentservice.GitInitializer.deserializeBinaryFromReader = function(msg,
       reader.readMessage(value,proto.contentservice.GitConfig.deserializeBinaryFromReader);
       msg.setConfig(value);
       break;
+    case 7:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPartialCloneFilter(value);
+      break;
+    case 8:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addSparseCheckout(value);
+      break;
+    case 9:
+      var value = new proto.contentservice.GitLFSConfig;
+      reader.readMessage(value,proto.contentservice.GitLFSConfig.deserializeBinaryFromReader);
+      msg.setLfs(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1566,6 +1875,28 @@ proto.contentservice.GitInitializer.serializeBinaryToWriter = function(message,
       proto.contentservice.GitConfig.serializeBinaryToWriter
     );
   }
+  f = message.getPartialCloneFilter();
+  if (f.length > 0) {
+    writer.writeString(
+      7,
+      f
+    );
+  }
+  f = message.getSparseCheckoutList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      8,
+      f
+    );
+  }
+  f = message.getLfs();
+  if (f != null) {
+    writer.writeMessage(
+      9,
+      f,
+      proto.contentservice.GitLFSConfig.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -1696,6 +2027,303 @@ proto.contentservice.GitInitializer.prototype.hasConfig = function() {
 };
 
 
+/**
+ * optional string partial_clone_filter = 7;
+ * @return {string}
+ */
+proto.contentservice.GitInitializer.prototype.getPartialCloneFilter = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 7, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.setPartialCloneFilter = function(value) {
+  return jspb.Message.setProto3StringField(this, 7, value);
+};
+
+
+/**
+ * repeated string sparse_checkout = 8;
+ * @return {!Array<string>}
+ */
+proto.contentservice.GitInitializer.prototype.getSparseCheckoutList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 8));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.setSparseCheckoutList = function(value) {
+  return jspb.Message.setField(this, 8, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.addSparseCheckout = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 8, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.clearSparseCheckoutList = function() {
+  return this.setSparseCheckoutList([]);
+};
+
+
+/**
+ * optional GitLFSConfig lfs = 9;
+ * @return {?proto.contentservice.GitLFSConfig}
+ */
+proto.contentservice.GitInitializer.prototype.getLfs = function() {
+  return /** @type{?proto.contentservice.GitLFSConfig} */ (
+    jspb.Message.getWrapperField(this, proto.contentservice.GitLFSConfig, 9));
+};
+
+
+/**
+ * @param {?proto.contentservice.GitLFSConfig|undefined} value
+ * @return {!proto.contentservice.GitInitializer} returns this
+*/
+proto.contentservice.GitInitializer.prototype.setLfs = function(value) {
+  return jspb.Message.setWrapperField(this, 9, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.contentservice.GitInitializer} returns this
+ */
+proto.contentservice.GitInitializer.prototype.clearLfs = function() {
+  return this.setLfs(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.contentservice.GitInitializer.prototype.hasLfs = function() {
+  return jspb.Message.getField(this, 9) != null;
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.contentservice.GitLFSConfig.repeatedFields_ = [1,2];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.contentservice.GitLFSConfig.prototype.toObject = function(opt_includeInstance) {
+  return proto.contentservice.GitLFSConfig.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.contentservice.GitLFSConfig} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.GitLFSConfig.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    includeList: (f = jspb.Message.getRepeatedField(msg, 1)) == null ? undefined : f,
+    excludeList: (f = jspb.Message.getRepeatedField(msg, 2)) == null ? undefined : f
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.contentservice.GitLFSConfig}
+ */
+proto.contentservice.GitLFSConfig.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.contentservice.GitLFSConfig;
+  return proto.contentservice.GitLFSConfig.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.contentservice.GitLFSConfig} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.contentservice.GitLFSConfig}
+ */
+proto.contentservice.GitLFSConfig.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addInclude(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addExclude(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.contentservice.GitLFSConfig.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.contentservice.GitLFSConfig.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.contentservice.GitLFSConfig} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.GitLFSConfig.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getIncludeList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      1,
+      f
+    );
+  }
+  f = message.getExcludeList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * repeated string include = 1;
+ * @return {!Array<string>}
+ */
+proto.contentservice.GitLFSConfig.prototype.getIncludeList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 1));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.contentservice.GitLFSConfig} returns this
+ */
+proto.contentservice.GitLFSConfig.prototype.setIncludeList = function(value) {
+  return jspb.Message.setField(this, 1, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.contentservice.GitLFSConfig} returns this
+ */
+proto.contentservice.GitLFSConfig.prototype.addInclude = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 1, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.contentservice.GitLFSConfig} returns this
+ */
+proto.contentservice.GitLFSConfig.prototype.clearIncludeList = function() {
+  return this.setIncludeList([]);
+};
+
+
+/**
+ * repeated string exclude = 2;
+ * @return {!Array<string>}
+ */
+proto.contentservice.GitLFSConfig.prototype.getExcludeList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 2));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.contentservice.GitLFSConfig} returns this
+ */
+proto.contentservice.GitLFSConfig.prototype.setExcludeList = function(value) {
+  return jspb.Message.setField(this, 2, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.contentservice.GitLFSConfig} returns this
+ */
+proto.contentservice.GitLFSConfig.prototype.addExclude = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 2, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.contentservice.GitLFSConfig} returns this
+ */
+proto.contentservice.GitLFSConfig.prototype.clearExcludeList = function() {
+  return this.setExcludeList([]);
+};
+
+
 
 
 
@@ -2353,7 +2981,8 @@ proto.contentservice.FromBackupInitializer.prototype.toObject = function(opt_inc
 proto.contentservice.FromBackupInitializer.toObject = function(includeInstance, msg) {
   var f, obj = {
     checkoutLocation: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    fromVolumeSnapshot: jspb.Message.getBooleanFieldWithDefault(msg, 2, false)
+    fromVolumeSnapshot: jspb.Message.getBooleanFieldWithDefault(msg, 2, false),
+    backupId: jspb.Message.getFieldWithDefault(msg, 3, "")
   };
 
   if (includeInstance) {
@@ -2398,6 +3027,10 @@ proto.contentservice.FromBackupInitializer.deserializeBinaryFromReader = functio
       var value = /** @type {boolean} */ (reader.readBool());
       msg.setFromVolumeSnapshot(value);
       break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setBackupId(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -2441,6 +3074,13 @@ proto.contentservice.FromBackupInitializer.serializeBinaryToWriter = function(me
       f
     );
   }
+  f = message.getBackupId();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
 };
 
 
@@ -2480,6 +3120,24 @@ proto.contentservice.FromBackupInitializer.prototype.setFromVolumeSnapshot = fun
 };
 
 
+/**
+ * optional string backup_id = 3;
+ * @return {string}
+ */
+proto.contentservice.FromBackupInitializer.prototype.getBackupId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.FromBackupInitializer} returns this
+ */
+proto.contentservice.FromBackupInitializer.prototype.setBackupId = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
 
 /**
  * List of repeated fields within this message type.
@@ -0,0 +1,48 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// package: contentservice
+// file: retention.proto
+
+/* tslint:disable */
+/* eslint-disable */
+
+import * as grpc from "@grpc/grpc-js";
+import * as retention_pb from "./retention_pb";
+import * as google_protobuf_timestamp_pb from "google-protobuf/google/protobuf/timestamp_pb";
+
+interface IRetentionServiceService extends grpc.ServiceDefinition<grpc.UntypedServiceImplementation> {
+    report: IRetentionServiceService_IReport;
+}
+
+interface IRetentionServiceService_IReport extends grpc.MethodDefinition<retention_pb.RetentionReportRequest, retention_pb.RetentionReportResponse> {
+    path: "/contentservice.RetentionService/Report";
+    requestStream: false;
+    responseStream: false;
+    requestSerialize: grpc.serialize<retention_pb.RetentionReportRequest>;
+    requestDeserialize: grpc.deserialize<retention_pb.RetentionReportRequest>;
+    responseSerialize: grpc.serialize<retention_pb.RetentionReportResponse>;
+    responseDeserialize: grpc.deserialize<retention_pb.RetentionReportResponse>;
+}
+
+export const RetentionServiceService: IRetentionServiceService;
+
+export interface IRetentionServiceServer extends grpc.UntypedServiceImplementation {
+    report: grpc.handleUnaryCall<retention_pb.RetentionReportRequest, retention_pb.RetentionReportResponse>;
+}
+
+export interface IRetentionServiceClient {
+    report(request: retention_pb.RetentionReportRequest, callback: (error: grpc.ServiceError | null, response: retention_pb.RetentionReportResponse) => void): grpc.ClientUnaryCall;
+    report(request: retention_pb.RetentionReportRequest, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: retention_pb.RetentionReportResponse) => void): grpc.ClientUnaryCall;
+    report(request: retention_pb.RetentionReportRequest, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: retention_pb.RetentionReportResponse) => void): grpc.ClientUnaryCall;
+}
+
+export class RetentionServiceClient extends grpc.Client implements IRetentionServiceClient {
+    constructor(address: string, credentials: grpc.ChannelCredentials, options?: Partial<grpc.ClientOptions>);
+    public report(request: retention_pb.RetentionReportRequest, callback: (error: grpc.ServiceError | null, response: retention_pb.RetentionReportResponse) => void): grpc.ClientUnaryCall;
+    public report(request: retention_pb.RetentionReportRequest, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: retention_pb.RetentionReportResponse) => void): grpc.ClientUnaryCall;
+    public report(request: retention_pb.RetentionReportRequest, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: retention_pb.RetentionReportResponse) => void): grpc.ClientUnaryCall;
+}
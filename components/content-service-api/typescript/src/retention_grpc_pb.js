@@ -0,0 +1,51 @@
+// GENERATED CODE -- DO NOT EDIT!
+
+// Original file comments:
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+//
+'use strict';
+var grpc = require('@grpc/grpc-js');
+var retention_pb = require('./retention_pb.js');
+var google_protobuf_timestamp_pb = require('google-protobuf/google/protobuf/timestamp_pb.js');
+
+function serialize_contentservice_RetentionReportRequest(arg) {
+  if (!(arg instanceof retention_pb.RetentionReportRequest)) {
+    throw new Error('Expected argument of type contentservice.RetentionReportRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_contentservice_RetentionReportRequest(buffer_arg) {
+  return retention_pb.RetentionReportRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_contentservice_RetentionReportResponse(arg) {
+  if (!(arg instanceof retention_pb.RetentionReportResponse)) {
+    throw new Error('Expected argument of type contentservice.RetentionReportResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_contentservice_RetentionReportResponse(buffer_arg) {
+  return retention_pb.RetentionReportResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+
+var RetentionServiceService = exports.RetentionServiceService = {
+  // Report lists the objects the retention policies would delete without deleting anything
+report: {
+    path: '/contentservice.RetentionService/Report',
+    requestStream: false,
+    responseStream: false,
+    requestType: retention_pb.RetentionReportRequest,
+    responseType: retention_pb.RetentionReportResponse,
+    requestSerialize: serialize_contentservice_RetentionReportRequest,
+    requestDeserialize: deserialize_contentservice_RetentionReportRequest,
+    responseSerialize: serialize_contentservice_RetentionReportResponse,
+    responseDeserialize: deserialize_contentservice_RetentionReportResponse,
+  },
+};
+
+exports.RetentionServiceClient = grpc.makeGenericClientConstructor(RetentionServiceService);
@@ -0,0 +1,97 @@
+/**
+ * Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+ * Licensed under the GNU Affero General Public License (AGPL).
+ * See License.AGPL.txt in the project root for license information.
+ */
+
+// package: contentservice
+// file: retention.proto
+
+/* tslint:disable */
+/* eslint-disable */
+
+import * as jspb from "google-protobuf";
+import * as google_protobuf_timestamp_pb from "google-protobuf/google/protobuf/timestamp_pb";
+
+export class RetentionReportRequest extends jspb.Message {
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): RetentionReportRequest.AsObject;
+    static toObject(includeInstance: boolean, msg: RetentionReportRequest): RetentionReportRequest.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: RetentionReportRequest, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): RetentionReportRequest;
+    static deserializeBinaryFromReader(message: RetentionReportRequest, reader: jspb.BinaryReader): RetentionReportRequest;
+}
+
+export namespace RetentionReportRequest {
+    export type AsObject = {
+    }
+}
+
+export class RetentionReportResponse extends jspb.Message {
+    clearCandidatesList(): void;
+    getCandidatesList(): Array<RetentionCandidate>;
+    setCandidatesList(value: Array<RetentionCandidate>): RetentionReportResponse;
+    addCandidates(value?: RetentionCandidate, index?: number): RetentionCandidate;
+    getTotalSize(): number;
+    setTotalSize(value: number): RetentionReportResponse;
+    getProtectedCount(): number;
+    setProtectedCount(value: number): RetentionReportResponse;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): RetentionReportResponse.AsObject;
+    static toObject(includeInstance: boolean, msg: RetentionReportResponse): RetentionReportResponse.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: RetentionReportResponse, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): RetentionReportResponse;
+    static deserializeBinaryFromReader(message: RetentionReportResponse, reader: jspb.BinaryReader): RetentionReportResponse;
+}
+
+export namespace RetentionReportResponse {
+    export type AsObject = {
+        candidatesList: Array<RetentionCandidate.AsObject>,
+        totalSize: number,
+        protectedCount: number,
+    }
+}
+
+export class RetentionCandidate extends jspb.Message {
+    getBucket(): string;
+    setBucket(value: string): RetentionCandidate;
+    getObject(): string;
+    setObject(value: string): RetentionCandidate;
+    getClass(): string;
+    setClass(value: string): RetentionCandidate;
+    getSize(): number;
+    setSize(value: number): RetentionCandidate;
+
+    hasLastModified(): boolean;
+    clearLastModified(): void;
+    getLastModified(): google_protobuf_timestamp_pb.Timestamp | undefined;
+    setLastModified(value?: google_protobuf_timestamp_pb.Timestamp): RetentionCandidate;
+    getReason(): string;
+    setReason(value: string): RetentionCandidate;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): RetentionCandidate.AsObject;
+    static toObject(includeInstance: boolean, msg: RetentionCandidate): RetentionCandidate.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: RetentionCandidate, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): RetentionCandidate;
+    static deserializeBinaryFromReader(message: RetentionCandidate, reader: jspb.BinaryReader): RetentionCandidate;
+}
+
+export namespace RetentionCandidate {
+    export type AsObject = {
+        bucket: string,
+        object: string,
+        pb_class: string,
+        size: number,
+        lastModified?: google_protobuf_timestamp_pb.Timestamp.AsObject,
+        reason: string,
+    }
+}
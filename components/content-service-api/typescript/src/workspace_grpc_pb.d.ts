@@ -12,11 +12,13 @@
 
 import * as grpc from "@grpc/grpc-js";
 import * as workspace_pb from "./workspace_pb";
+import * as google_protobuf_timestamp_pb from "google-protobuf/google/protobuf/timestamp_pb";
 
 interface IWorkspaceServiceService extends grpc.ServiceDefinition<grpc.UntypedServiceImplementation> {
     workspaceDownloadURL: IWorkspaceServiceService_IWorkspaceDownloadURL;
     deleteWorkspace: IWorkspaceServiceService_IDeleteWorkspace;
     workspaceSnapshotExists: IWorkspaceServiceService_IWorkspaceSnapshotExists;
+    listWorkspaceBackups: IWorkspaceServiceService_IListWorkspaceBackups;
 }
 
 interface IWorkspaceServiceService_IWorkspaceDownloadURL extends grpc.MethodDefinition<workspace_pb.WorkspaceDownloadURLRequest, workspace_pb.WorkspaceDownloadURLResponse> {
@@ -46,6 +48,15 @@ interface IWorkspaceServiceService_IWorkspaceSnapshotExists extends grpc.MethodD
     responseSerialize: grpc.serialize<workspace_pb.WorkspaceSnapshotExistsResponse>;
     responseDeserialize: grpc.deserialize<workspace_pb.WorkspaceSnapshotExistsResponse>;
 }
+interface IWorkspaceServiceService_IListWorkspaceBackups extends grpc.MethodDefinition<workspace_pb.ListWorkspaceBackupsRequest, workspace_pb.ListWorkspaceBackupsResponse> {
+    path: "/contentservice.WorkspaceService/ListWorkspaceBackups";
+    requestStream: false;
+    responseStream: false;
+    requestSerialize: grpc.serialize<workspace_pb.ListWorkspaceBackupsRequest>;
+    requestDeserialize: grpc.deserialize<workspace_pb.ListWorkspaceBackupsRequest>;
+    responseSerialize: grpc.serialize<workspace_pb.ListWorkspaceBackupsResponse>;
+    responseDeserialize: grpc.deserialize<workspace_pb.ListWorkspaceBackupsResponse>;
+}
 
 export const WorkspaceServiceService: IWorkspaceServiceService;
 
@@ -53,6 +64,7 @@ export interface IWorkspaceServiceServer extends grpc.UntypedServiceImplementati
     workspaceDownloadURL: grpc.handleUnaryCall<workspace_pb.WorkspaceDownloadURLRequest, workspace_pb.WorkspaceDownloadURLResponse>;
     deleteWorkspace: grpc.handleUnaryCall<workspace_pb.DeleteWorkspaceRequest, workspace_pb.DeleteWorkspaceResponse>;
     workspaceSnapshotExists: grpc.handleUnaryCall<workspace_pb.WorkspaceSnapshotExistsRequest, workspace_pb.WorkspaceSnapshotExistsResponse>;
+    listWorkspaceBackups: grpc.handleUnaryCall<workspace_pb.ListWorkspaceBackupsRequest, workspace_pb.ListWorkspaceBackupsResponse>;
 }
 
 export interface IWorkspaceServiceClient {
@@ -65,6 +77,9 @@ export interface IWorkspaceServiceClient {
     workspaceSnapshotExists(request: workspace_pb.WorkspaceSnapshotExistsRequest, callback: (error: grpc.ServiceError | null, response: workspace_pb.WorkspaceSnapshotExistsResponse) => void): grpc.ClientUnaryCall;
     workspaceSnapshotExists(request: workspace_pb.WorkspaceSnapshotExistsRequest, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: workspace_pb.WorkspaceSnapshotExistsResponse) => void): grpc.ClientUnaryCall;
     workspaceSnapshotExists(request: workspace_pb.WorkspaceSnapshotExistsRequest, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: workspace_pb.WorkspaceSnapshotExistsResponse) => void): grpc.ClientUnaryCall;
+    listWorkspaceBackups(request: workspace_pb.ListWorkspaceBackupsRequest, callback: (error: grpc.ServiceError | null, response: workspace_pb.ListWorkspaceBackupsResponse) => void): grpc.ClientUnaryCall;
+    listWorkspaceBackups(request: workspace_pb.ListWorkspaceBackupsRequest, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: workspace_pb.ListWorkspaceBackupsResponse) => void): grpc.ClientUnaryCall;
+    listWorkspaceBackups(request: workspace_pb.ListWorkspaceBackupsRequest, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: workspace_pb.ListWorkspaceBackupsResponse) => void): grpc.ClientUnaryCall;
 }
 
 export class WorkspaceServiceClient extends grpc.Client implements IWorkspaceServiceClient {
@@ -78,4 +93,7 @@ export class WorkspaceServiceClient extends grpc.Client implements IWorkspaceSer
     public workspaceSnapshotExists(request: workspace_pb.WorkspaceSnapshotExistsRequest, callback: (error: grpc.ServiceError | null, response: workspace_pb.WorkspaceSnapshotExistsResponse) => void): grpc.ClientUnaryCall;
     public workspaceSnapshotExists(request: workspace_pb.WorkspaceSnapshotExistsRequest, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: workspace_pb.WorkspaceSnapshotExistsResponse) => void): grpc.ClientUnaryCall;
     public workspaceSnapshotExists(request: workspace_pb.WorkspaceSnapshotExistsRequest, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: workspace_pb.WorkspaceSnapshotExistsResponse) => void): grpc.ClientUnaryCall;
+    public listWorkspaceBackups(request: workspace_pb.ListWorkspaceBackupsRequest, callback: (error: grpc.ServiceError | null, response: workspace_pb.ListWorkspaceBackupsResponse) => void): grpc.ClientUnaryCall;
+    public listWorkspaceBackups(request: workspace_pb.ListWorkspaceBackupsRequest, metadata: grpc.Metadata, callback: (error: grpc.ServiceError | null, response: workspace_pb.ListWorkspaceBackupsResponse) => void): grpc.ClientUnaryCall;
+    public listWorkspaceBackups(request: workspace_pb.ListWorkspaceBackupsRequest, metadata: grpc.Metadata, options: Partial<grpc.CallOptions>, callback: (error: grpc.ServiceError | null, response: workspace_pb.ListWorkspaceBackupsResponse) => void): grpc.ClientUnaryCall;
 }
@@ -8,6 +8,7 @@
 'use strict';
 var grpc = require('@grpc/grpc-js');
 var workspace_pb = require('./workspace_pb.js');
+var google_protobuf_timestamp_pb = require('google-protobuf/google/protobuf/timestamp_pb.js');
 
 function serialize_contentservice_DeleteWorkspaceRequest(arg) {
   if (!(arg instanceof workspace_pb.DeleteWorkspaceRequest)) {
@@ -31,6 +32,28 @@ function deserialize_contentservice_DeleteWorkspaceResponse(buffer_arg) {
   return workspace_pb.DeleteWorkspaceResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_contentservice_ListWorkspaceBackupsRequest(arg) {
+  if (!(arg instanceof workspace_pb.ListWorkspaceBackupsRequest)) {
+    throw new Error('Expected argument of type contentservice.ListWorkspaceBackupsRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_contentservice_ListWorkspaceBackupsRequest(buffer_arg) {
+  return workspace_pb.ListWorkspaceBackupsRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_contentservice_ListWorkspaceBackupsResponse(arg) {
+  if (!(arg instanceof workspace_pb.ListWorkspaceBackupsResponse)) {
+    throw new Error('Expected argument of type contentservice.ListWorkspaceBackupsResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_contentservice_ListWorkspaceBackupsResponse(buffer_arg) {
+  return workspace_pb.ListWorkspaceBackupsResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_contentservice_WorkspaceDownloadURLRequest(arg) {
   if (!(arg instanceof workspace_pb.WorkspaceDownloadURLRequest)) {
     throw new Error('Expected argument of type contentservice.WorkspaceDownloadURLRequest');
@@ -113,6 +136,18 @@ workspaceSnapshotExists: {
     responseSerialize: serialize_contentservice_WorkspaceSnapshotExistsResponse,
     responseDeserialize: deserialize_contentservice_WorkspaceSnapshotExistsResponse,
   },
+  // ListWorkspaceBackups lists the previous backups of a workspace which can be restored
+listWorkspaceBackups: {
+    path: '/contentservice.WorkspaceService/ListWorkspaceBackups',
+    requestStream: false,
+    responseStream: false,
+    requestType: workspace_pb.ListWorkspaceBackupsRequest,
+    responseType: workspace_pb.ListWorkspaceBackupsResponse,
+    requestSerialize: serialize_contentservice_ListWorkspaceBackupsRequest,
+    requestDeserialize: deserialize_contentservice_ListWorkspaceBackupsRequest,
+    responseSerialize: serialize_contentservice_ListWorkspaceBackupsResponse,
+    responseDeserialize: deserialize_contentservice_ListWorkspaceBackupsResponse,
+  },
 };
 
 exports.WorkspaceServiceClient = grpc.makeGenericClientConstructor(WorkspaceServiceService);
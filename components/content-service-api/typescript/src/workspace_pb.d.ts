@@ -11,12 +11,15 @@
 /* eslint-disable */
 
 import * as jspb from "google-protobuf";
+import * as google_protobuf_timestamp_pb from "google-protobuf/google/protobuf/timestamp_pb";
 
 export class WorkspaceDownloadURLRequest extends jspb.Message {
     getOwnerId(): string;
     setOwnerId(value: string): WorkspaceDownloadURLRequest;
     getWorkspaceId(): string;
     setWorkspaceId(value: string): WorkspaceDownloadURLRequest;
+    getBackupId(): string;
+    setBackupId(value: string): WorkspaceDownloadURLRequest;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): WorkspaceDownloadURLRequest.AsObject;
@@ -32,6 +35,7 @@ export namespace WorkspaceDownloadURLRequest {
     export type AsObject = {
         ownerId: string,
         workspaceId: string,
+        backupId: string,
     }
 }
 
@@ -143,3 +147,80 @@ export namespace WorkspaceSnapshotExistsResponse {
         exists: boolean,
     }
 }
+
+export class ListWorkspaceBackupsRequest extends jspb.Message {
+    getOwnerId(): string;
+    setOwnerId(value: string): ListWorkspaceBackupsRequest;
+    getWorkspaceId(): string;
+    setWorkspaceId(value: string): ListWorkspaceBackupsRequest;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): ListWorkspaceBackupsRequest.AsObject;
+    static toObject(includeInstance: boolean, msg: ListWorkspaceBackupsRequest): ListWorkspaceBackupsRequest.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: ListWorkspaceBackupsRequest, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): ListWorkspaceBackupsRequest;
+    static deserializeBinaryFromReader(message: ListWorkspaceBackupsRequest, reader: jspb.BinaryReader): ListWorkspaceBackupsRequest;
+}
+
+export namespace ListWorkspaceBackupsRequest {
+    export type AsObject = {
+        ownerId: string,
+        workspaceId: string,
+    }
+}
+
+export class ListWorkspaceBackupsResponse extends jspb.Message {
+    clearBackupsList(): void;
+    getBackupsList(): Array<WorkspaceBackup>;
+    setBackupsList(value: Array<WorkspaceBackup>): ListWorkspaceBackupsResponse;
+    addBackups(value?: WorkspaceBackup, index?: number): WorkspaceBackup;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): ListWorkspaceBackupsResponse.AsObject;
+    static toObject(includeInstance: boolean, msg: ListWorkspaceBackupsResponse): ListWorkspaceBackupsResponse.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: ListWorkspaceBackupsResponse, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): ListWorkspaceBackupsResponse;
+    static deserializeBinaryFromReader(message: ListWorkspaceBackupsResponse, reader: jspb.BinaryReader): ListWorkspaceBackupsResponse;
+}
+
+export namespace ListWorkspaceBackupsResponse {
+    export type AsObject = {
+        backupsList: Array<WorkspaceBackup.AsObject>,
+    }
+}
+
+export class WorkspaceBackup extends jspb.Message {
+    getId(): string;
+    setId(value: string): WorkspaceBackup;
+
+    hasCreated(): boolean;
+    clearCreated(): void;
+    getCreated(): google_protobuf_timestamp_pb.Timestamp | undefined;
+    setCreated(value?: google_protobuf_timestamp_pb.Timestamp): WorkspaceBackup;
+    getSize(): number;
+    setSize(value: number): WorkspaceBackup;
+    getChunked(): boolean;
+    setChunked(value: boolean): WorkspaceBackup;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): WorkspaceBackup.AsObject;
+    static toObject(includeInstance: boolean, msg: WorkspaceBackup): WorkspaceBackup.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: WorkspaceBackup, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): WorkspaceBackup;
+    static deserializeBinaryFromReader(message: WorkspaceBackup, reader: jspb.BinaryReader): WorkspaceBackup;
+}
+
+export namespace WorkspaceBackup {
+    export type AsObject = {
+        id: string,
+        created?: google_protobuf_timestamp_pb.Timestamp.AsObject,
+        size: number,
+        chunked: boolean,
+    }
+}
@@ -21,8 +21,13 @@ var jspb = require('google-protobuf');
 var goog = jspb;
 var global = (function() { return this || window || global || self || Function('return this')(); }).call(null);
 
+var google_protobuf_timestamp_pb = require('google-protobuf/google/protobuf/timestamp_pb.js');
+goog.object.extend(proto, google_protobuf_timestamp_pb);
 goog.exportSymbol('proto.contentservice.DeleteWorkspaceRequest', null, global);
 goog.exportSymbol('proto.contentservice.DeleteWorkspaceResponse', null, global);
+goog.exportSymbol('proto.contentservice.ListWorkspaceBackupsRequest', null, global);
+goog.exportSymbol('proto.contentservice.ListWorkspaceBackupsResponse', null, global);
+goog.exportSymbol('proto.contentservice.WorkspaceBackup', null, global);
 goog.exportSymbol('proto.contentservice.WorkspaceDownloadURLRequest', null, global);
 goog.exportSymbol('proto.contentservice.WorkspaceDownloadURLResponse', null, global);
 goog.exportSymbol('proto.contentservice.WorkspaceSnapshotExistsRequest', null, global);
@@ -153,6 +158,69 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.contentservice.WorkspaceSnapshotExistsResponse.displayName = 'proto.contentservice.WorkspaceSnapshotExistsResponse';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.contentservice.ListWorkspaceBackupsRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.contentservice.ListWorkspaceBackupsRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.contentservice.ListWorkspaceBackupsRequest.displayName = 'proto.contentservice.ListWorkspaceBackupsRequest';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.contentservice.ListWorkspaceBackupsResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.contentservice.ListWorkspaceBackupsResponse.repeatedFields_, null);
+};
+goog.inherits(proto.contentservice.ListWorkspaceBackupsResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.contentservice.ListWorkspaceBackupsResponse.displayName = 'proto.contentservice.ListWorkspaceBackupsResponse';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.contentservice.WorkspaceBackup = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.contentservice.WorkspaceBackup, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.contentservice.WorkspaceBackup.displayName = 'proto.contentservice.WorkspaceBackup';
+}
 
 
 
@@ -186,7 +254,8 @@ proto.contentservice.WorkspaceDownloadURLRequest.prototype.toObject = function(o
 proto.contentservice.WorkspaceDownloadURLRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
     ownerId: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    workspaceId: jspb.Message.getFieldWithDefault(msg, 2, "")
+    workspaceId: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    backupId: jspb.Message.getFieldWithDefault(msg, 3, "")
   };
 
   if (includeInstance) {
@@ -231,6 +300,10 @@ proto.contentservice.WorkspaceDownloadURLRequest.deserializeBinaryFromReader = f
       var value = /** @type {string} */ (reader.readString());
       msg.setWorkspaceId(value);
       break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setBackupId(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -274,6 +347,13 @@ proto.contentservice.WorkspaceDownloadURLRequest.serializeBinaryToWriter = funct
       f
     );
   }
+  f = message.getBackupId();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
 };
 
 
@@ -313,6 +393,24 @@ proto.contentservice.WorkspaceDownloadURLRequest.prototype.setWorkspaceId = func
 };
 
 
+/**
+ * optional string backup_id = 3;
+ * @return {string}
+ */
+proto.contentservice.WorkspaceDownloadURLRequest.prototype.getBackupId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.WorkspaceDownloadURLRequest} returns this
+ */
+proto.contentservice.WorkspaceDownloadURLRequest.prototype.setBackupId = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
 
 
 
@@ -1054,4 +1152,565 @@ proto.contentservice.WorkspaceSnapshotExistsResponse.prototype.setExists = funct
 };
 
 
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.contentservice.ListWorkspaceBackupsRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.contentservice.ListWorkspaceBackupsRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.contentservice.ListWorkspaceBackupsRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.ListWorkspaceBackupsRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    ownerId: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    workspaceId: jspb.Message.getFieldWithDefault(msg, 2, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.contentservice.ListWorkspaceBackupsRequest}
+ */
+proto.contentservice.ListWorkspaceBackupsRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.contentservice.ListWorkspaceBackupsRequest;
+  return proto.contentservice.ListWorkspaceBackupsRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.contentservice.ListWorkspaceBackupsRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.contentservice.ListWorkspaceBackupsRequest}
+ */
+proto.contentservice.ListWorkspaceBackupsRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setOwnerId(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setWorkspaceId(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.contentservice.ListWorkspaceBackupsRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.contentservice.ListWorkspaceBackupsRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.contentservice.ListWorkspaceBackupsRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.ListWorkspaceBackupsRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getOwnerId();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getWorkspaceId();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string owner_id = 1;
+ * @return {string}
+ */
+proto.contentservice.ListWorkspaceBackupsRequest.prototype.getOwnerId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.ListWorkspaceBackupsRequest} returns this
+ */
+proto.contentservice.ListWorkspaceBackupsRequest.prototype.setOwnerId = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string workspace_id = 2;
+ * @return {string}
+ */
+proto.contentservice.ListWorkspaceBackupsRequest.prototype.getWorkspaceId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.ListWorkspaceBackupsRequest} returns this
+ */
+proto.contentservice.ListWorkspaceBackupsRequest.prototype.setWorkspaceId = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.contentservice.ListWorkspaceBackupsResponse.repeatedFields_ = [1];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.contentservice.ListWorkspaceBackupsResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.contentservice.ListWorkspaceBackupsResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.contentservice.ListWorkspaceBackupsResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.ListWorkspaceBackupsResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    backupsList: jspb.Message.toObjectList(msg.getBackupsList(),
+    proto.contentservice.WorkspaceBackup.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.contentservice.ListWorkspaceBackupsResponse}
+ */
+proto.contentservice.ListWorkspaceBackupsResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.contentservice.ListWorkspaceBackupsResponse;
+  return proto.contentservice.ListWorkspaceBackupsResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.contentservice.ListWorkspaceBackupsResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.contentservice.ListWorkspaceBackupsResponse}
+ */
+proto.contentservice.ListWorkspaceBackupsResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.contentservice.WorkspaceBackup;
+      reader.readMessage(value,proto.contentservice.WorkspaceBackup.deserializeBinaryFromReader);
+      msg.addBackups(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.contentservice.ListWorkspaceBackupsResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.contentservice.ListWorkspaceBackupsResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.contentservice.ListWorkspaceBackupsResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.ListWorkspaceBackupsResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getBackupsList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      1,
+      f,
+      proto.contentservice.WorkspaceBackup.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * repeated WorkspaceBackup backups = 1;
+ * @return {!Array<!proto.contentservice.WorkspaceBackup>}
+ */
+proto.contentservice.ListWorkspaceBackupsResponse.prototype.getBackupsList = function() {
+  return /** @type{!Array<!proto.contentservice.WorkspaceBackup>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.contentservice.WorkspaceBackup, 1));
+};
+
+
+/**
+ * @param {!Array<!proto.contentservice.WorkspaceBackup>} value
+ * @return {!proto.contentservice.ListWorkspaceBackupsResponse} returns this
+*/
+proto.contentservice.ListWorkspaceBackupsResponse.prototype.setBackupsList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 1, value);
+};
+
+
+/**
+ * @param {!proto.contentservice.WorkspaceBackup=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.contentservice.WorkspaceBackup}
+ */
+proto.contentservice.ListWorkspaceBackupsResponse.prototype.addBackups = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 1, opt_value, proto.contentservice.WorkspaceBackup, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.contentservice.ListWorkspaceBackupsResponse} returns this
+ */
+proto.contentservice.ListWorkspaceBackupsResponse.prototype.clearBackupsList = function() {
+  return this.setBackupsList([]);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.contentservice.WorkspaceBackup.prototype.toObject = function(opt_includeInstance) {
+  return proto.contentservice.WorkspaceBackup.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.contentservice.WorkspaceBackup} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.WorkspaceBackup.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    id: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    created: (f = msg.getCreated()) && google_protobuf_timestamp_pb.Timestamp.toObject(includeInstance, f),
+    size: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    chunked: jspb.Message.getBooleanFieldWithDefault(msg, 4, false)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.contentservice.WorkspaceBackup}
+ */
+proto.contentservice.WorkspaceBackup.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.contentservice.WorkspaceBackup;
+  return proto.contentservice.WorkspaceBackup.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.contentservice.WorkspaceBackup} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.contentservice.WorkspaceBackup}
+ */
+proto.contentservice.WorkspaceBackup.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setId(value);
+      break;
+    case 2:
+      var value = new google_protobuf_timestamp_pb.Timestamp;
+      reader.readMessage(value,google_protobuf_timestamp_pb.Timestamp.deserializeBinaryFromReader);
+      msg.setCreated(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setSize(value);
+      break;
+    case 4:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setChunked(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.contentservice.WorkspaceBackup.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.contentservice.WorkspaceBackup.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.contentservice.WorkspaceBackup} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.contentservice.WorkspaceBackup.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getId();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getCreated();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      google_protobuf_timestamp_pb.Timestamp.serializeBinaryToWriter
+    );
+  }
+  f = message.getSize();
+  if (f !== 0) {
+    writer.writeInt64(
+      3,
+      f
+    );
+  }
+  f = message.getChunked();
+  if (f) {
+    writer.writeBool(
+      4,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string id = 1;
+ * @return {string}
+ */
+proto.contentservice.WorkspaceBackup.prototype.getId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.contentservice.WorkspaceBackup} returns this
+ */
+proto.contentservice.WorkspaceBackup.prototype.setId = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional google.protobuf.Timestamp created = 2;
+ * @return {?proto.google.protobuf.Timestamp}
+ */
+proto.contentservice.WorkspaceBackup.prototype.getCreated = function() {
+  return /** @type{?proto.google.protobuf.Timestamp} */ (
+    jspb.Message.getWrapperField(this, google_protobuf_timestamp_pb.Timestamp, 2));
+};
+
+
+/**
+ * @param {?proto.google.protobuf.Timestamp|undefined} value
+ * @return {!proto.contentservice.WorkspaceBackup} returns this
+*/
+proto.contentservice.WorkspaceBackup.prototype.setCreated = function(value) {
+  return jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.contentservice.WorkspaceBackup} returns this
+ */
+proto.contentservice.WorkspaceBackup.prototype.clearCreated = function() {
+  return this.setCreated(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.contentservice.WorkspaceBackup.prototype.hasCreated = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+/**
+ * optional int64 size = 3;
+ * @return {number}
+ */
+proto.contentservice.WorkspaceBackup.prototype.getSize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.contentservice.WorkspaceBackup} returns this
+ */
+proto.contentservice.WorkspaceBackup.prototype.setSize = function(value) {
+  return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional bool chunked = 4;
+ * @return {boolean}
+ */
+proto.contentservice.WorkspaceBackup.prototype.getChunked = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 4, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.contentservice.WorkspaceBackup} returns this
+ */
+proto.contentservice.WorkspaceBackup.prototype.setChunked = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 4, value);
+};
+
+
 goog.object.extend(exports, proto.contentservice);
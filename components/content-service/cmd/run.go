@@ -5,10 +5,17 @@
 package cmd
 
 import (
+	"context"
+	"time"
+
 	"github.com/gitpod-io/gitpod/common-go/baseserver"
 	"github.com/gitpod-io/gitpod/common-go/log"
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
 	"github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/retention"
 	"github.com/gitpod-io/gitpod/content-service/pkg/service"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -20,14 +27,40 @@ var runCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg := getConfig()
 
-		srv, err := baseserver.New("content-service",
+		opts := []baseserver.Option{
 			baseserver.WithGRPC(&cfg.Service),
 			baseserver.WithVersion(Version),
-		)
+		}
+		if cfg.Storage.Kind == config.LocalStorage || cfg.Storage.Download != nil {
+			if cfg.HTTP == nil {
+				log.Fatal("local storage and downloads require an HTTP server config")
+			}
+			opts = append(opts, baseserver.WithHTTP(cfg.HTTP))
+		}
+
+		srv, err := baseserver.New("content-service", opts...)
 		if err != nil {
 			log.WithError(err).Fatal("Failed to create server.")
 		}
 
+		if cfg.Storage.Kind == config.LocalStorage {
+			if cfg.Storage.LocalConfig == nil {
+				log.Fatal("missing local storage config")
+			}
+			handler, err := storage.NewLocalStorageHandler(*cfg.Storage.LocalConfig)
+			if err != nil {
+				log.WithError(err).Fatal("Cannot create local storage handler")
+			}
+			srv.HTTPMux().Handle(storage.LocalStoragePath, handler)
+		}
+		if cfg.Storage.Download != nil {
+			handler, err := storage.NewDownloadHandler(&cfg.Storage)
+			if err != nil {
+				log.WithError(err).Fatal("Cannot create download handler")
+			}
+			srv.HTTPMux().Handle(storage.DownloadPath, handler)
+		}
+
 		contentService, err := service.NewContentService(cfg.Storage)
 		if err != nil {
 			log.WithError(err).Fatalf("Cannot create content service")
@@ -58,6 +91,36 @@ var runCmd = &cobra.Command{
 		}
 		api.RegisterIDEPluginServiceServer(srv.GRPC(), idePluginService)
 
+		var retentionEngine *retention.Engine
+		if cfg.Retention != nil {
+			ps, err := storage.NewPresignedAccess(&cfg.Storage)
+			if err != nil {
+				log.WithError(err).Fatal("Cannot create storage for retention")
+			}
+			conn, err := db.Connect(db.ConnectionParamsFromEnv())
+			if err != nil {
+				log.WithError(err).Fatal("Cannot connect to the database for retention")
+			}
+			retentionEngine, err = retention.NewEngine(ps, retention.DBReferenceSource{Conn: conn}, *cfg.Retention)
+			if err != nil {
+				log.WithError(err).Fatal("Cannot create retention engine")
+			}
+			go retentionEngine.Start(context.Background())
+		}
+		api.RegisterRetentionServiceServer(srv.GRPC(), service.NewRetentionService(retentionEngine))
+
+		if cfg.Storage.Encryption != nil && cfg.Storage.Encryption.RotationInterval > 0 {
+			ps, err := storage.NewPresignedAccess(&cfg.Storage)
+			if err != nil {
+				log.WithError(err).Fatal("Cannot create storage for data key rotation")
+			}
+			rotator, ok := ps.(storage.DataKeyRotator)
+			if !ok {
+				log.Fatal("storage does not support data key rotation")
+			}
+			go storage.StartDataKeyRotation(context.Background(), rotator, time.Duration(cfg.Storage.Encryption.RotationInterval))
+		}
+
 		err = srv.ListenAndServe()
 		if err != nil {
 			log.WithError(err).Fatal("Cannot start server")
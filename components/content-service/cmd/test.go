@@ -145,6 +145,9 @@ var presignedUploadCmd = &cobra.Command{
 		}
 
 		fmt.Printf("%s\n", info.URL)
+		for k, v := range info.Headers {
+			fmt.Printf("%s: %s\n", k, v)
+		}
 		return nil
 	},
 }
@@ -0,0 +1,121 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"golang.org/x/xerrors"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+)
+
+// Compression is the algorithm a tarbal is compressed with
+type Compression string
+
+const (
+	// CompressionNone leaves tarbals uncompressed
+	CompressionNone Compression = ""
+	// CompressionGzip compresses tarbals using gzip
+	CompressionGzip Compression = "gzip"
+	// CompressionZstd compresses tarbals using zstd
+	CompressionZstd Compression = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Validate returns an error if the compression is unknown
+func (c Compression) Validate() error {
+	switch c {
+	case CompressionNone, CompressionGzip, CompressionZstd:
+		return nil
+	default:
+		return xerrors.Errorf("unknown compression: %s", c)
+	}
+}
+
+// MediaType returns the OCI media type of a layer compressed with c
+func (c Compression) MediaType() string {
+	switch c {
+	case CompressionGzip:
+		return csapi.MediaTypeGzipLayer
+	case CompressionZstd:
+		return csapi.MediaTypeZstdLayer
+	default:
+		return csapi.MediaTypeUncompressedLayer
+	}
+}
+
+// ContentType returns the mime type of a tarbal compressed with c
+func (c Compression) ContentType() string {
+	switch c {
+	case CompressionGzip:
+		return "application/gzip"
+	case CompressionZstd:
+		return "application/zstd"
+	default:
+		return "application/x-tar"
+	}
+}
+
+// Compress returns a writer which compresses everything written to it into dst.
+// The level is algorithm specific, zero selects the default level. Closing the writer does not close dst.
+func Compress(dst io.Writer, c Compression, level int) (io.WriteCloser, error) {
+	switch c {
+	case CompressionNone:
+		return nopWriteCloser{dst}, nil
+	case CompressionGzip:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		return gzip.NewWriterLevel(dst, level)
+	case CompressionZstd:
+		var opts []zstd.EOption
+		if level != 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}
+		return zstd.NewWriter(dst, opts...)
+	default:
+		return nil, xerrors.Errorf("unknown compression: %s", c)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// Decompress detects the compression of src from its magic bytes and returns a reader
+// which produces the uncompressed content. Uncompressed content is passed through unchanged.
+func Decompress(src io.Reader) (io.ReadCloser, Compression, error) {
+	br := bufio.NewReader(src)
+	// a short read only means the content is too small to be compressed
+	magic, _ := br.Peek(len(zstdMagic))
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		r, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, CompressionGzip, err
+		}
+		return r, CompressionGzip, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		r, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, CompressionZstd, err
+		}
+		return r.IOReadCloser(), CompressionZstd, nil
+	default:
+		return io.NopCloser(br), CompressionNone, nil
+	}
+}
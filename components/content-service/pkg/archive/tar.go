@@ -54,7 +54,8 @@ func WithGIDMapping(mappings []IDMapping) TarOption {
 	}
 }
 
-// ExtractTarbal extracts an OCI compatible tar file src to the folder dst, expecting the overlay whiteout format
+// ExtractTarbal extracts an OCI compatible tar file src to the folder dst, expecting the overlay whiteout format.
+// Gzip and zstd compressed tar files are decompressed transparently.
 func ExtractTarbal(ctx context.Context, src io.Reader, dst string, opts ...TarOption) (err error) {
 	type Info struct {
 		UID, GID  int
@@ -73,8 +74,15 @@ func ExtractTarbal(ctx context.Context, src io.Reader, dst string, opts ...TarOp
 		opt(&cfg)
 	}
 
+	uncompressed, compression, err := Decompress(src)
+	if err != nil {
+		return xerrors.Errorf("cannot decompress tar stream: %w", err)
+	}
+	defer uncompressed.Close()
+	span.LogKV("compression", compression)
+
 	pipeReader, pipeWriter := io.Pipe()
-	teeReader := io.TeeReader(src, pipeWriter)
+	teeReader := io.TeeReader(uncompressed, pipeWriter)
 
 	tarReader := tar.NewReader(pipeReader)
 
@@ -142,8 +150,8 @@ func ExtractTarbal(ctx context.Context, src io.Reader, dst string, opts ...TarOp
 			continue
 		}
 
-		uid := toHostID(v.UID, cfg.UIDMaps)
-		gid := toHostID(v.GID, cfg.GIDMaps)
+		uid := ToHostID(v.UID, cfg.UIDMaps)
+		gid := ToHostID(v.GID, cfg.GIDMaps)
 
 		err = remapFile(path.Join(dst, p), uid, gid, v.Xattrs)
 		if err != nil {
@@ -156,7 +164,8 @@ func ExtractTarbal(ctx context.Context, src io.Reader, dst string, opts ...TarOp
 	return nil
 }
 
-func toHostID(containerID int, idMap []IDMapping) int {
+// ToHostID maps a user or group ID of the container to the host. IDs without mapping are returned unchanged.
+func ToHostID(containerID int, idMap []IDMapping) int {
 	for _, m := range idMap {
 		if (containerID >= m.ContainerID) && (containerID <= (m.ContainerID + m.Size - 1)) {
 			hostID := m.HostID + (containerID - m.ContainerID)
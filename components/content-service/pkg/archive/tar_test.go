@@ -66,46 +66,65 @@ func TestExtractTarbal(t *testing.T) {
 			tw.Flush()
 			tw.Close()
 
-			wd, err := os.MkdirTemp("", "")
-			defer os.RemoveAll(wd)
-			if err != nil {
-				t.Fatalf("cannot prepare test: %v", err)
-			}
-			targetFolder := filepath.Join(wd, "target")
-			err = os.MkdirAll(targetFolder, 0777)
-			if err != nil {
-				t.Fatalf("cannot extract tar content: %v", err)
-			}
+			for name, compression := range map[string]Compression{"uncompressed": CompressionNone, "gzip": CompressionGzip, "zstd": CompressionZstd} {
+				compression := compression
+				t.Run(name, func(t *testing.T) {
+					src := bytes.NewBuffer(nil)
+					cw, err := Compress(src, compression, 0)
+					if err != nil {
+						t.Fatalf("cannot compress archive: %v", err)
+					}
+					_, err = cw.Write(buf.Bytes())
+					if err != nil {
+						t.Fatalf("cannot compress archive: %v", err)
+					}
+					err = cw.Close()
+					if err != nil {
+						t.Fatalf("cannot compress archive: %v", err)
+					}
 
-			err = ExtractTarbal(context.Background(), buf, targetFolder)
-			if err != nil {
-				t.Fatalf("cannot extract tar content: %v", err)
-			}
+					wd, err := os.MkdirTemp("", "")
+					defer os.RemoveAll(wd)
+					if err != nil {
+						t.Fatalf("cannot prepare test: %v", err)
+					}
+					targetFolder := filepath.Join(wd, "target")
+					err = os.MkdirAll(targetFolder, 0777)
+					if err != nil {
+						t.Fatalf("cannot extract tar content: %v", err)
+					}
 
-			for _, file := range test.Files {
-				stat, err := os.Stat(filepath.Join(targetFolder, file.Name))
-				if err != nil {
-					t.Errorf("expected %s", file.Name)
-					continue
-				}
-				uid := stat.Sys().(*syscall.Stat_t).Uid
-				if uid != uint32(file.UID) {
-					t.Errorf("expected uid %d", file.UID)
-					continue
-				}
-				gid := stat.Sys().(*syscall.Stat_t).Gid
-				if gid != uint32(file.UID) {
-					t.Errorf("expected gid %d", file.UID)
-					continue
-				}
+					err = ExtractTarbal(context.Background(), src, targetFolder)
+					if err != nil {
+						t.Fatalf("cannot extract tar content: %v", err)
+					}
 
-				expectedMode := stat.Mode()
-				testMode := fs.FileMode(file.Mode)
-				if expectedMode.String() != testMode.String() {
-					t.Errorf("expected fileMode %d but returned %v", testMode, expectedMode)
-					continue
-				}
+					for _, file := range test.Files {
+						stat, err := os.Stat(filepath.Join(targetFolder, file.Name))
+						if err != nil {
+							t.Errorf("expected %s", file.Name)
+							continue
+						}
+						uid := stat.Sys().(*syscall.Stat_t).Uid
+						if uid != uint32(file.UID) {
+							t.Errorf("expected uid %d", file.UID)
+							continue
+						}
+						gid := stat.Sys().(*syscall.Stat_t).Gid
+						if gid != uint32(file.UID) {
+							t.Errorf("expected gid %d", file.UID)
+							continue
+						}
 
+						expectedMode := stat.Mode()
+						testMode := fs.FileMode(file.Mode)
+						if expectedMode.String() != testMode.String() {
+							t.Errorf("expected fileMode %d but returned %v", testMode, expectedMode)
+							continue
+						}
+
+					}
+				})
 			}
 		})
 	}
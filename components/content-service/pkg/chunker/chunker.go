@@ -0,0 +1,158 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Package chunker splits streams into content-defined chunks using FastCDC,
+// so that local changes to a stream only affect the chunks around them.
+package chunker
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+
+	"golang.org/x/xerrors"
+)
+
+// Options configure the chunk sizes
+type Options struct {
+	// MinSize is the minimum size of a chunk. Only the last chunk of a stream can be smaller.
+	MinSize int
+	// AvgSize is the size chunks are normalized to.
+	AvgSize int
+	// MaxSize is the maximum size of a chunk.
+	MaxSize int
+}
+
+// DefaultOptions are the chunk sizes used for workspace backups.
+// Changing them changes the chunk boundaries and hence prevents deduplication against existing chunks.
+var DefaultOptions = Options{
+	MinSize: 512 * 1024,
+	AvgSize: 2 * 1024 * 1024,
+	MaxSize: 8 * 1024 * 1024,
+}
+
+// gear maps each byte to a pseudo-random value for the rolling hash.
+// The table must never change, as it determines the chunk boundaries.
+var gear [256]uint64
+
+func init() {
+	for i := range gear {
+		sum := sha256.Sum256([]byte{byte(i)})
+		gear[i] = binary.BigEndian.Uint64(sum[:8])
+	}
+}
+
+// Chunker splits a stream into content-defined chunks
+type Chunker struct {
+	r    io.Reader
+	opts Options
+
+	// maskS is used before reaching the average size and makes cutting less likely,
+	// maskL is used afterwards and makes cutting more likely. Both select the upper bits
+	// of the hash which depend on the last 64 bytes.
+	maskS uint64
+	maskL uint64
+
+	buf   []byte
+	start int
+	end   int
+	eof   bool
+}
+
+// New creates a chunker reading from r
+func New(r io.Reader, opts Options) (*Chunker, error) {
+	if opts.MinSize <= 0 || opts.MinSize > opts.AvgSize || opts.AvgSize > opts.MaxSize {
+		return nil, xerrors.Errorf("invalid chunk sizes: need 0 < min (%d) <= avg (%d) <= max (%d)", opts.MinSize, opts.AvgSize, opts.MaxSize)
+	}
+
+	avgBits := bits.Len(uint(opts.AvgSize)) - 1
+	return &Chunker{
+		r:     r,
+		opts:  opts,
+		maskS: topBits(avgBits + 1),
+		maskL: topBits(avgBits - 1),
+		buf:   make([]byte, opts.MaxSize),
+	}, nil
+}
+
+func topBits(n int) uint64 {
+	if n <= 0 {
+		return 0
+	}
+	return ^uint64(0) << (64 - n)
+}
+
+// Next returns the next chunk which is only valid until the next call to Next.
+// Returns io.EOF once the stream is exhausted.
+func (c *Chunker) Next() ([]byte, error) {
+	if !c.eof && c.end-c.start < c.opts.MaxSize {
+		err := c.fill()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.start == c.end {
+		return nil, io.EOF
+	}
+
+	n := c.cut(c.buf[c.start:c.end])
+	chunk := c.buf[c.start : c.start+n]
+	c.start += n
+	return chunk, nil
+}
+
+// fill moves the remaining data to the beginning of the buffer and reads until the buffer is full or the stream ends.
+func (c *Chunker) fill() error {
+	copy(c.buf, c.buf[c.start:c.end])
+	c.end -= c.start
+	c.start = 0
+
+	for c.end < len(c.buf) {
+		n, err := c.r.Read(c.buf[c.end:])
+		c.end += n
+		if errors.Is(err, io.EOF) {
+			c.eof = true
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cut returns the length of the chunk at the beginning of data
+func (c *Chunker) cut(data []byte) int {
+	n := len(data)
+	if n <= c.opts.MinSize {
+		return n
+	}
+	if n > c.opts.MaxSize {
+		n = c.opts.MaxSize
+	}
+	normal := c.opts.AvgSize
+	if normal > n {
+		normal = n
+	}
+
+	var (
+		fp uint64
+		i  = c.opts.MinSize
+	)
+	for ; i < normal; i++ {
+		fp = (fp << 1) + gear[data[i]]
+		if fp&c.maskS == 0 {
+			return i + 1
+		}
+	}
+	for ; i < n; i++ {
+		fp = (fp << 1) + gear[data[i]]
+		if fp&c.maskL == 0 {
+			return i + 1
+		}
+	}
+	return n
+}
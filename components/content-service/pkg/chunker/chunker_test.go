@@ -0,0 +1,175 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package chunker
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/opencontainers/go-digest"
+)
+
+var testOptions = Options{
+	MinSize: 256,
+	AvgSize: 1024,
+	MaxSize: 4096,
+}
+
+func chunkDigests(t *testing.T, content []byte, opts Options) []digest.Digest {
+	c, err := New(bytes.NewReader(content), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		res       []digest.Digest
+		sizes     []int
+		assembled []byte
+	)
+	for {
+		chunk, err := c.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(chunk) > opts.MaxSize {
+			t.Errorf("chunk %d exceeds max size: %d bytes", len(res), len(chunk))
+		}
+		assembled = append(assembled, chunk...)
+		res = append(res, digest.FromBytes(chunk))
+		sizes = append(sizes, len(chunk))
+	}
+	for i := 0; i < len(sizes)-1; i++ {
+		if sizes[i] < opts.MinSize {
+			t.Errorf("chunk %d is below min size: %d bytes", i, sizes[i])
+		}
+	}
+	if !bytes.Equal(assembled, content) {
+		t.Fatal("reassembled chunks differ from content")
+	}
+	return res
+}
+
+func TestChunker(t *testing.T) {
+	content := make([]byte, 256*1024)
+	rand.New(rand.NewSource(42)).Read(content)
+
+	tests := []struct {
+		Name string
+		// Modify produces the modified content
+		Modify func(content []byte) []byte
+		// MaxChanged is the number of chunks which may differ
+		MaxChanged int
+	}{
+		{
+			Name:   "unchanged",
+			Modify: func(content []byte) []byte { return content },
+		},
+		{
+			Name: "insertion in the middle",
+			Modify: func(content []byte) []byte {
+				res := append([]byte{}, content[:100000]...)
+				res = append(res, []byte("hello world")...)
+				return append(res, content[100000:]...)
+			},
+			MaxChanged: 2,
+		},
+		{
+			Name: "deletion at the beginning",
+			Modify: func(content []byte) []byte {
+				return append([]byte{}, content[10:]...)
+			},
+			MaxChanged: 2,
+		},
+		{
+			Name: "overwrite at the end",
+			Modify: func(content []byte) []byte {
+				res := append([]byte{}, content...)
+				copy(res[len(res)-50:], bytes.Repeat([]byte{0}, 50))
+				return res
+			},
+			MaxChanged: 1,
+		},
+	}
+
+	original := chunkDigests(t, content, testOptions)
+	if len(original) < 64 {
+		t.Fatalf("expected chunks around the average size, got %d chunks only", len(original))
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			modified := chunkDigests(t, test.Modify(content), testOptions)
+
+			known := make(map[digest.Digest]struct{}, len(original))
+			for _, d := range original {
+				known[d] = struct{}{}
+			}
+			var changed int
+			for _, d := range modified {
+				if _, ok := known[d]; !ok {
+					changed++
+				}
+			}
+			if changed > test.MaxChanged {
+				t.Errorf("expected at most %d changed chunks, got %d of %d", test.MaxChanged, changed, len(modified))
+			}
+		})
+	}
+}
+
+func TestChunkerSmallInput(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Content     []byte
+		Expectation []digest.Digest
+	}{
+		{
+			Name: "empty",
+		},
+		{
+			Name:        "smaller than min size",
+			Content:     []byte("hello world"),
+			Expectation: []digest.Digest{digest.FromString("hello world")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			c, err := New(bytes.NewReader(test.Content), testOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var act []digest.Digest
+			for {
+				chunk, err := c.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				act = append(act, digest.FromBytes(chunk))
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected chunks (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewInvalidOptions(t *testing.T) {
+	_, err := New(bytes.NewReader(nil), Options{MinSize: 2048, AvgSize: 1024, MaxSize: 4096})
+	if err == nil {
+		t.Error("expected an error for min size larger than avg size")
+	}
+}
@@ -17,9 +17,10 @@ import (
 )
 
 type config struct {
-	URLs       map[string]string `json:"urls,omitempty"`
-	Req        json.RawMessage   `json:"req,omitempty"`
-	FromBackup string            `json:"fromBackupURL,omitempty"`
+	URLs              map[string]string `json:"urls,omitempty"`
+	Req               json.RawMessage   `json:"req,omitempty"`
+	FromBackup        string            `json:"fromBackupURL,omitempty"`
+	FromChunkedBackup bool              `json:"fromChunkedBackup,omitempty"`
 }
 
 // PrepareFromBackup produces executor config to restore a backup
@@ -29,7 +30,17 @@ func PrepareFromBackup(url string) ([]byte, error) {
 	})
 }
 
-// Prepare writes the config required by Execute to a stream
+// PrepareFromChunkedBackup produces executor config to restore a chunked backup.
+// The URLs must contain the chunk manifest and all chunks it references.
+func PrepareFromChunkedBackup(urls map[string]string) ([]byte, error) {
+	return json.Marshal(config{
+		URLs:              urls,
+		FromChunkedBackup: true,
+	})
+}
+
+// Prepare writes the config required by Execute to a stream.
+// The config ends up in the workspace and hence never carries data keys, i.e. Execute cannot restore encrypted objects.
 func Prepare(req *csapi.WorkspaceInitializer, urls map[string]string) ([]byte, error) {
 	ilr, err := protojson.Marshal(req)
 	if err != nil {
@@ -55,7 +66,10 @@ func Execute(ctx context.Context, destination string, cfgin io.Reader, forceGitU
 		rs  storage.DirectDownloader
 		ilr initializer.Initializer
 	)
-	if cfg.FromBackup == "" {
+	if cfg.FromChunkedBackup {
+		rs = &storage.NamedURLDownloader{URLs: cfg.URLs}
+		ilr = &initializer.EmptyInitializer{}
+	} else if cfg.FromBackup == "" {
 		var req csapi.WorkspaceInitializer
 		err = protojson.Unmarshal(cfg.Req, &req)
 		if err != nil {
@@ -12,6 +12,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/opentracing/opentracing-go"
@@ -95,6 +96,27 @@ type Client struct {
 
 	// if true will run git command as gitpod user (should be executed as root that has access to sudo in this case)
 	RunAsGitpodUser bool
+
+	// Filter is the partial clone filter, e.g. "blob:none". Everything is fetched if empty.
+	Filter string
+
+	// SparseCheckout are the directories checked out in cone mode. Everything is checked out if empty.
+	SparseCheckout []string
+
+	// LFS selects the Git LFS objects which are fetched. If nil, Git LFS behaves as configured on the system.
+	LFS *LFSConfig
+
+	// ReferenceRepository is a local repository whose objects are borrowed during clone rather than fetching
+	// them from the remote. The clone is dissociated from it afterwards. Ignored if the repository does not exist.
+	ReferenceRepository string
+}
+
+// LFSConfig selects the Git LFS objects which are fetched
+type LFSConfig struct {
+	// Include are the patterns of files whose LFS objects are fetched. All objects are fetched if empty.
+	Include []string
+	// Exclude are the patterns of files whose LFS objects are not fetched
+	Exclude []string
 }
 
 // Status describes the status of a Git repo/working copy akin to "git status"
@@ -179,6 +201,10 @@ func (c *Client) GitWithOutput(ctx context.Context, ignoreErr *string, subcomman
 	}
 
 	env = append(env, "HOME=/home/gitpod")
+	if c.LFS != nil {
+		// LFS objects are fetched in a single batch by PullLFS rather than one by one during checkout
+		env = append(env, "GIT_LFS_SKIP_SMUDGE=1")
+	}
 
 	fullArgs = append(fullArgs, subcommand)
 	fullArgs = append(fullArgs, args...)
@@ -339,11 +365,30 @@ func (c *Client) Clone(ctx context.Context) (err error) {
 	}
 
 	args := []string{"--depth=1", "--shallow-submodules", c.RemoteURI}
+	if c.Filter != "" {
+		args = append(args, "--filter="+c.Filter)
+	}
+	if c.ReferenceRepository != "" {
+		args = append(args, "--reference-if-able", c.ReferenceRepository, "--dissociate")
+	}
+	if len(c.SparseCheckout) > 0 {
+		// only check out the files at the root until the sparse-checkout directories are set
+		args = append(args, "--sparse")
+	}
 
 	for key, value := range c.Config {
 		args = append(args, "--config")
 		args = append(args, strings.TrimSpace(key)+"="+strings.TrimSpace(value))
 	}
+	if c.LFS != nil {
+		// the fetch patterns also apply to all future LFS operations in the working copy
+		if len(c.LFS.Include) > 0 {
+			args = append(args, "--config", "lfs.fetchinclude="+strings.Join(c.LFS.Include, ","))
+		}
+		if len(c.LFS.Exclude) > 0 {
+			args = append(args, "--config", "lfs.fetchexclude="+strings.Join(c.LFS.Exclude, ","))
+		}
+	}
 
 	// TODO: remove workaround once https://gitlab.com/gitlab-org/gitaly/-/issues/4248 is fixed
 	if strings.Contains(c.RemoteURI, "gitlab.com") {
@@ -353,7 +398,63 @@ func (c *Client) Clone(ctx context.Context) (err error) {
 
 	args = append(args, ".")
 
-	return c.Git(ctx, "clone", args...)
+	err = c.Git(ctx, "clone", args...)
+	if err != nil {
+		return err
+	}
+
+	if len(c.SparseCheckout) > 0 {
+		err = c.Git(ctx, "sparse-checkout", append([]string{"set", "--cone"}, c.SparseCheckout...)...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PullLFS fetches the Git LFS objects selected by the LFS config and checks them out.
+// Does nothing if there is no LFS config.
+func (c *Client) PullLFS(ctx context.Context) (err error) {
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "pullLFS")
+	defer tracing.FinishSpan(span, &err)
+
+	if c.LFS == nil {
+		return nil
+	}
+
+	// the include and exclude patterns are part of the Git config set during clone
+	return c.Git(ctx, "lfs", "pull")
+}
+
+// ObjectsSize returns the size of the Git objects in the repository, i.e. what was fetched.
+// Git LFS objects are not included.
+func (c *Client) ObjectsSize(ctx context.Context) (size uint64, err error) {
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "objectsSize")
+	defer tracing.FinishSpan(span, &err)
+
+	out, err := c.GitWithOutput(ctx, nil, "count-objects", "-v")
+	if err != nil {
+		return 0, err
+	}
+	return parseObjectsSize(out)
+}
+
+// parseObjectsSize sums up the loose and packed object sizes reported by git count-objects -v
+func parseObjectsSize(out []byte) (size uint64, err error) {
+	for _, line := range strings.Split(string(out), "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || (key != "size" && key != "size-pack") {
+			continue
+		}
+		kib, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return 0, xerrors.Errorf("cannot parse %s of git count-objects: %w", key, err)
+		}
+		size += kib * 1024
+	}
+	return size, nil
 }
 
 // UpdateRemote performs a git fetch on the upstream remote URI
@@ -531,3 +531,183 @@ func initFromRemote(ctx context.Context, c *Client) error {
 
 	return nil
 }
+
+func TestClone(t *testing.T) {
+	type expectation struct {
+		Files  []string
+		Filter string
+	}
+	tests := []struct {
+		Name           string
+		Filter         string
+		SparseCheckout []string
+		// Reference is "mirror" for a mirror of the remote, "missing" for a non-existent reference repository
+		Reference   string
+		Expectation expectation
+	}{
+		{
+			Name: "full clone",
+			Expectation: expectation{
+				Files: []string{"README.md", "backend/main.go", "docs/index.md", "frontend/app.ts"},
+			},
+		},
+		{
+			Name:           "sparse checkout",
+			SparseCheckout: []string{"backend", "docs"},
+			Expectation: expectation{
+				Files: []string{"README.md", "backend/main.go", "docs/index.md"},
+			},
+		},
+		{
+			Name:           "partial clone with sparse checkout",
+			Filter:         "blob:none",
+			SparseCheckout: []string{"frontend"},
+			Expectation: expectation{
+				Files:  []string{"README.md", "frontend/app.ts"},
+				Filter: "blob:none",
+			},
+		},
+		{
+			Name:      "reference repository",
+			Reference: "mirror",
+			Expectation: expectation{
+				Files: []string{"README.md", "backend/main.go", "docs/index.md", "frontend/app.ts"},
+			},
+		},
+		{
+			Name:      "missing reference repository",
+			Reference: "missing",
+			Expectation: expectation{
+				Files: []string{"README.md", "backend/main.go", "docs/index.md", "frontend/app.ts"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			remote, err := newGitClient(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(remote.Location)
+			if err := remote.Git(ctx, "init"); err != nil {
+				t.Fatal(err)
+			}
+			if err := remote.Git(ctx, "config", "--local", "uploadpack.allowFilter", "true"); err != nil {
+				t.Fatal(err)
+			}
+			for _, fn := range []string{"README.md", "backend/main.go", "docs/index.md", "frontend/app.ts"} {
+				fn = filepath.Join(remote.Location, fn)
+				if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(fn, []byte(fn), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := remote.Git(ctx, "add", "."); err != nil {
+				t.Fatal(err)
+			}
+			if err := remote.Git(ctx, "-c", "user.email=foo@bar.com", "-c", "user.name=foo bar", "commit", "-m", "foo"); err != nil {
+				t.Fatal(err)
+			}
+
+			client, err := newGitClient(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(client.Location)
+			// partial clones are not supported for local paths
+			client.RemoteURI = "file://" + remote.Location
+			client.Filter = test.Filter
+			client.SparseCheckout = test.SparseCheckout
+			switch test.Reference {
+			case "mirror":
+				client.ReferenceRepository = filepath.Join(t.TempDir(), "mirror.git")
+				if err := remote.Git(ctx, "clone", "--mirror", remote.Location, client.ReferenceRepository); err != nil {
+					t.Fatal(err)
+				}
+			case "missing":
+				client.ReferenceRepository = filepath.Join(t.TempDir(), "missing.git")
+			}
+			if err := client.Clone(ctx); err != nil {
+				t.Fatalf("cannot clone: %v", err)
+			}
+
+			var act expectation
+			err = filepath.WalkDir(client.Location, func(path string, d os.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.IsDir() && d.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				if d.IsDir() {
+					return nil
+				}
+				rel, err := filepath.Rel(client.Location, path)
+				if err != nil {
+					return err
+				}
+				act.Files = append(act.Files, filepath.ToSlash(rel))
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			filter, err := client.GitWithOutput(ctx, nil, "config", "--default", "", "remote.origin.partialclonefilter")
+			if err != nil {
+				t.Fatal(err)
+			}
+			act.Filter = strings.TrimSpace(string(filter))
+			// the clone must not depend on the reference repository
+			if _, err := os.Stat(filepath.Join(client.Location, ".git", "objects", "info", "alternates")); !os.IsNotExist(err) {
+				t.Errorf("clone still borrows objects from the reference repository: %v", err)
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected clone (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseObjectsSize(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Input       string
+		Expectation uint64
+		Error       bool
+	}{
+		{
+			Name: "loose and packed",
+			Input: `count: 12
+size: 48
+in-pack: 3054
+packs: 1
+size-pack: 1024
+prune-packable: 0
+garbage: 0
+size-garbage: 0
+`,
+			Expectation: (48 + 1024) * 1024,
+		},
+		{Name: "empty repository", Input: "count: 0\nsize: 0\nin-pack: 0\npacks: 0\nsize-pack: 0\n"},
+		{Name: "invalid size", Input: "size: many\n", Error: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := parseObjectsSize([]byte(test.Input))
+			if (err != nil) != test.Error {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected size (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package initializer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+// chunkDownloadConcurrency is the number of backup chunks we download ahead of extracting them
+const chunkDownloadConcurrency = 8
+
+// downloadBackup restores the regular backup of a workspace. Chunked backups take precedence over full tarballs,
+// hence callers which collect the remote content must only offer the newer of both, see storage.LatestBackup.
+// If backupID is not empty, the previous backup with that ID is restored instead of the latest one.
+func downloadBackup(ctx context.Context, rs storage.DirectDownloader, location string, backupID string, mappings []archive.IDMapping) (found bool, err error) {
+	if backupID != "" {
+		return downloadBackupVersion(ctx, rs, location, backupID, mappings)
+	}
+
+	found, err = downloadChunkedBackup(ctx, rs, location, storage.DefaultChunkedBackupManifest, mappings)
+	if found || err != nil {
+		return found, err
+	}
+
+	return rs.Download(ctx, location, storage.DefaultBackup, mappings)
+}
+
+// downloadBackupVersion restores a previous backup listed in the backup history of a workspace.
+// Returns false if there is no history, but fails if the history does not contain the backup.
+func downloadBackupVersion(ctx context.Context, rs storage.DirectDownloader, location string, backupID string, mappings []archive.IDMapping) (found bool, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "downloadBackupVersion")
+	span.SetTag("backupID", backupID)
+	defer tracing.FinishSpan(span, &err)
+
+	var buf bytes.Buffer
+	found, err = rs.DownloadObject(ctx, storage.BackupHistoryManifest, &buf)
+	if !found {
+		return false, err
+	}
+	if err != nil {
+		return true, xerrors.Errorf("cannot download backup history: %w", err)
+	}
+
+	var history csapi.BackupHistory
+	err = json.Unmarshal(buf.Bytes(), &history)
+	if err != nil {
+		return true, xerrors.Errorf("cannot unmarshal backup history: %w", err)
+	}
+	version, ok := history.Find(backupID)
+	if !ok {
+		return true, xerrors.Errorf("backup %s is not part of the backup history", backupID)
+	}
+
+	if version.Chunked {
+		found, err = downloadChunkedBackup(ctx, rs, location, version.Object, mappings)
+	} else {
+		found, err = rs.Download(ctx, location, version.Object, mappings)
+	}
+	if err == nil && !found {
+		// the history references this backup, hence it missing means the history is broken
+		return true, xerrors.Errorf("backup %s is missing", backupID)
+	}
+	return true, err
+}
+
+// downloadChunkedBackup restores a backup from its chunk manifest. Returns false if there is no chunk manifest.
+func downloadChunkedBackup(ctx context.Context, rs storage.DirectDownloader, location string, manifest string, mappings []archive.IDMapping) (found bool, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "downloadChunkedBackup")
+	defer tracing.FinishSpan(span, &err)
+
+	var buf bytes.Buffer
+	found, err = rs.DownloadObject(ctx, manifest, &buf)
+	if !found {
+		return false, err
+	}
+	if err != nil {
+		return true, xerrors.Errorf("cannot download chunked backup manifest: %w", err)
+	}
+
+	var mf csapi.ChunkedBackupManifest
+	err = json.Unmarshal(buf.Bytes(), &mf)
+	if err != nil {
+		return true, xerrors.Errorf("cannot unmarshal chunked backup manifest: %w", err)
+	}
+	span.LogKV("chunks", len(mf.Chunks), "size", mf.Size)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	assembled := make(chan error, 1)
+	go func() {
+		err := assembleChunks(ctx, rs, &mf, pw)
+		pw.CloseWithError(err)
+		assembled <- err
+	}()
+
+	err = archive.ExtractTarbal(ctx, pr, location, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
+	if err != nil {
+		cancel()
+	}
+	// unblock the assembly in case the extraction stopped reading early, e.g. at the end-of-archive marker
+	pr.Close()
+	aerr := <-assembled
+	if err != nil {
+		return true, xerrors.Errorf("cannot extract chunked backup: %w", err)
+	}
+	if aerr != nil && !errors.Is(aerr, io.ErrClosedPipe) {
+		return true, xerrors.Errorf("cannot assemble chunked backup: %w", aerr)
+	}
+
+	return true, nil
+}
+
+type downloadedChunk struct {
+	Content []byte
+	Err     error
+}
+
+// assembleChunks downloads the chunks of a backup and writes them to dst in order, verifying their digests.
+func assembleChunks(ctx context.Context, rs storage.DirectDownloader, mf *csapi.ChunkedBackupManifest, dst io.Writer) error {
+	if err := mf.Digest.Validate(); err != nil {
+		return xerrors.Errorf("invalid backup digest: %w", err)
+	}
+
+	pending := make([]chan downloadedChunk, len(mf.Chunks))
+	for i := range pending {
+		pending[i] = make(chan downloadedChunk, 1)
+	}
+	slots := make(chan struct{}, chunkDownloadConcurrency)
+	go func() {
+		for i, c := range mf.Chunks {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			go func(c csapi.BackupChunk, res chan<- downloadedChunk) {
+				res <- downloadChunk(ctx, rs, c)
+			}(c, pending[i])
+		}
+	}()
+
+	var (
+		verifier = mf.Digest.Verifier()
+		w        = io.MultiWriter(dst, verifier)
+		size     int64
+	)
+	for i := range mf.Chunks {
+		var c downloadedChunk
+		select {
+		case c = <-pending[i]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		<-slots
+		if c.Err != nil {
+			return c.Err
+		}
+
+		n, err := w.Write(c.Content)
+		if err != nil {
+			return err
+		}
+		size += int64(n)
+	}
+
+	if size != mf.Size || !verifier.Verified() {
+		return xerrors.Errorf("assembled backup does not match digest %s", mf.Digest)
+	}
+	return nil
+}
+
+func downloadChunk(ctx context.Context, rs storage.DirectDownloader, c csapi.BackupChunk) downloadedChunk {
+	if err := c.Digest.Validate(); err != nil {
+		return downloadedChunk{Err: xerrors.Errorf("invalid chunk digest: %w", err)}
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, c.Size))
+	found, err := rs.DownloadObject(ctx, storage.BackupChunkName(c.Digest), buf)
+	if err != nil {
+		return downloadedChunk{Err: xerrors.Errorf("cannot download chunk %s: %w", c.Digest, err)}
+	}
+	if !found {
+		return downloadedChunk{Err: xerrors.Errorf("chunk %s is missing", c.Digest)}
+	}
+	if actual := c.Digest.Algorithm().FromBytes(buf.Bytes()); actual != c.Digest {
+		return downloadedChunk{Err: xerrors.Errorf("chunk %s has unexpected digest %s", c.Digest, actual)}
+	}
+	return downloadedChunk{Content: buf.Bytes()}
+}
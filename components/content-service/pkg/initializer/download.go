@@ -5,11 +5,16 @@
 package initializer
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
@@ -35,6 +40,10 @@ type fileInfo struct {
 	// This information is used to compute subsequent
 	// content versions, and to validate the file content was downloaded correctly.
 	Digest digest.Digest
+
+	// ExtractTo, if set, is the directory relative to the TargetLocation the file is extracted into as archive.
+	// The archive itself is removed once extracted.
+	ExtractTo string
 }
 
 type fileDownloadInitializer struct {
@@ -42,6 +51,9 @@ type fileDownloadInitializer struct {
 	TargetLocation string
 	HTTPClient     *http.Client
 	RetryTimeout   time.Duration
+
+	// Concurrency is the number of files downloaded at the same time
+	Concurrency int
 }
 
 // Run initializes the workspace
@@ -54,12 +66,30 @@ func (ws *fileDownloadInitializer) Run(ctx context.Context, mappings []archive.I
 		log.WithError(fsErr).Error("could not get disk usage")
 	}
 
-	for _, info := range ws.FilesInfos {
-		err := ws.downloadFile(ctx, info)
-		if err != nil {
-			tracing.LogError(span, xerrors.Errorf("cannot download file '%s' from '%s': %w", info.Path, info.URL, err))
-			return src, nil, err
-		}
+	files, err := dedupFileInfos(ws.FilesInfos)
+	if err != nil {
+		return src, nil, err
+	}
+
+	eg, egctx := errgroup.WithContext(ctx)
+	if ws.Concurrency > 0 {
+		eg.SetLimit(ws.Concurrency)
+	}
+	for _, info := range files {
+		info := info
+		eg.Go(func() error {
+			err := ws.downloadFile(egctx, info, mappings)
+			if err != nil {
+				err = xerrors.Errorf("cannot download file '%s' from '%s': %w", info.Path, info.URL, err)
+				tracing.LogError(span, err)
+				return err
+			}
+			return nil
+		})
+	}
+	err = eg.Wait()
+	if err != nil {
+		return src, nil, err
 	}
 
 	if fsErr == nil {
@@ -79,79 +109,350 @@ func (ws *fileDownloadInitializer) Run(ctx context.Context, mappings []archive.I
 	return
 }
 
-func (ws *fileDownloadInitializer) downloadFile(ctx context.Context, info fileInfo) (err error) {
+// dedupFileInfos removes duplicate files. Downloading the same path twice with different content is an error.
+func dedupFileInfos(infos []fileInfo) ([]fileInfo, error) {
+	var (
+		res  = make([]fileInfo, 0, len(infos))
+		seen = make(map[string]fileInfo, len(infos))
+	)
+	for _, info := range infos {
+		p := filepath.Clean(info.Path)
+		if other, ok := seen[p]; ok {
+			if other != info {
+				return nil, xerrors.Errorf("conflicting downloads for file '%s'", info.Path)
+			}
+			continue
+		}
+		seen[p] = info
+		res = append(res, info)
+	}
+	return res, nil
+}
+
+// permanentDownloadError marks download errors a retry would not resolve
+type permanentDownloadError struct {
+	error
+}
+
+func (e permanentDownloadError) Unwrap() error { return e.error }
+
+func (ws *fileDownloadInitializer) downloadFile(ctx context.Context, info fileInfo, mappings []archive.IDMapping) (err error) {
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "downloadFile")
 	defer tracing.FinishSpan(span, &err)
 	span.LogKV("url", info.URL)
 
+	var extractTo string
+	if info.ExtractTo != "" {
+		// like entries of zip files, archives must not be extracted outside of the target location
+		extractTo = filepath.Join(ws.TargetLocation, info.ExtractTo)
+		if extractTo != filepath.Clean(ws.TargetLocation) && !strings.HasPrefix(extractTo, filepath.Clean(ws.TargetLocation)+string(filepath.Separator)) {
+			return xerrors.Errorf("invalid extraction path: %s", info.ExtractTo)
+		}
+	}
+
 	fn := filepath.Join(ws.TargetLocation, info.Path)
 	err = os.MkdirAll(filepath.Dir(fn), 0755)
 	if err != nil {
 		tracing.LogError(span, xerrors.Errorf("cannot mkdir %s: %w", filepath.Dir(fn), err))
 	}
 
-	fd, err := os.OpenFile(fn, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	fd, err := os.OpenFile(fn, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0644)
 	if err != nil {
 		return err
 	}
+	defer fd.Close()
+
+	// out keeps the digest in sync with what made it to disk s.t. an interrupted download can be resumed
+	out := &digestingWriter{W: fd, D: info.Digest.Algorithm().Digester()}
+	restart := func() error {
+		out.D = info.Digest.Algorithm().Digester()
+		out.N = 0
+		err := fd.Truncate(0)
+		if err != nil {
+			return err
+		}
+		_, err = fd.Seek(0, io.SeekStart)
+		return err
+	}
 
 	dl := func() (err error) {
 		req, err := http.NewRequestWithContext(ctx, "GET", info.URL, nil)
 		if err != nil {
-			return err
+			return permanentDownloadError{err}
 		}
 		_ = opentracing.GlobalTracer().Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(req.Header))
+		resumed := out.N > 0
+		if resumed {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", out.N))
+			span.LogKV("resumeAt", out.N)
+		}
 
 		resp, err := ws.HTTPClient.Do(req)
 		if err != nil {
 			return err
 		}
 		defer resp.Body.Close()
-		if resp.StatusCode != http.StatusOK {
+
+		switch {
+		case resp.StatusCode == http.StatusPartialContent && resumed && strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", out.N)):
+			// the server continues where we left off
+		case resp.StatusCode == http.StatusOK:
+			if resumed {
+				// the server does not support range requests and sends the whole file again
+				err = restart()
+				if err != nil {
+					return permanentDownloadError{err}
+				}
+			}
+		case resumed && (resp.StatusCode == http.StatusPartialContent || resp.StatusCode == http.StatusRequestedRangeNotSatisfiable):
+			// the server does not agree with what we have already - start over
+			err = restart()
+			if err != nil {
+				return permanentDownloadError{err}
+			}
+			return xerrors.Errorf("cannot resume download: %s", resp.Status)
+		case resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests:
+			return permanentDownloadError{xerrors.Errorf("non-OK download response: %s", resp.Status)}
+		default:
 			return xerrors.Errorf("non-OK download response: %s", resp.Status)
 		}
 
-		pr, pw := io.Pipe()
-		body := io.TeeReader(resp.Body, pw)
-
-		eg, _ := errgroup.WithContext(ctx)
-		eg.Go(func() error {
-			_, err = io.Copy(fd, body)
-			pw.Close()
+		_, err = io.Copy(out, resp.Body)
+		if err != nil {
+			if out.Err != nil {
+				return permanentDownloadError{out.Err}
+			}
 			return err
-		})
-		eg.Go(func() error {
-			dgst, err := digest.FromReader(pr)
-			if err != nil {
-				return err
+		}
+
+		act := out.D.Digest()
+		if act != info.Digest {
+			err = xerrors.Errorf("digest mismatch: expected %s, got %s", info.Digest, act)
+			if !resumed {
+				return permanentDownloadError{err}
 			}
-			if dgst != info.Digest {
-				return xerrors.Errorf("digest mismatch")
+			// the resumed download did not fit what we had - try once more from scratch
+			rerr := restart()
+			if rerr != nil {
+				return permanentDownloadError{rerr}
 			}
-			return nil
-		})
-
-		return eg.Wait()
+			return err
+		}
+		return nil
 	}
 	for i := 0; i < otsDownloadAttempts; i++ {
 		span.LogKV("attempt", i)
 		if i > 0 {
-			time.Sleep(ws.RetryTimeout)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(ws.RetryTimeout):
+			}
 		}
 
 		err = dl()
-		if err == context.Canceled || err == context.DeadlineExceeded {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 			return
 		}
+		var perr permanentDownloadError
+		if errors.As(err, &perr) {
+			return perr.error
+		}
 		if err == nil {
 			break
 		}
-		log.WithError(err).WithField("attempt", i).Warn("cannot download additional content files")
+		log.WithError(err).WithField("attempt", i).WithField("offset", out.N).Warn("cannot download additional content files")
+	}
+	if err != nil {
+		return err
+	}
+
+	if extractTo == "" {
+		return nil
+	}
+
+	err = extractArchive(ctx, fd, extractTo, mappings)
+	if err != nil {
+		return xerrors.Errorf("cannot extract archive: %w", err)
 	}
+	fd.Close()
+	return os.Remove(fn)
+}
+
+// digestingWriter digests everything successfully written to W
+type digestingWriter struct {
+	W io.Writer
+	D digest.Digester
+	N int64
+
+	// Err is the last error writing to W
+	Err error
+}
+
+func (w *digestingWriter) Write(p []byte) (n int, err error) {
+	n, err = w.W.Write(p)
+	w.D.Hash().Write(p[:n])
+	w.N += int64(n)
+	if err != nil {
+		w.Err = err
+	}
+	return n, err
+}
+
+// extractArchive extracts a zip file or (compressed) tarball into dst
+func extractArchive(ctx context.Context, fd *os.File, dst string, mappings []archive.IDMapping) error {
+	err := os.MkdirAll(dst, 0755)
 	if err != nil {
 		return err
 	}
 
+	stat, err := fd.Stat()
+	if err != nil {
+		return err
+	}
+	magic := make([]byte, 4)
+	_, err = fd.ReadAt(magic, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if bytes.Equal(magic, []byte("PK\x03\x04")) || bytes.Equal(magic, []byte("PK\x05\x06")) {
+		return extractZip(fd, stat.Size(), dst, mappings)
+	}
+
+	return archive.ExtractTarbal(ctx, io.NewSectionReader(fd, 0, stat.Size()), dst, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
+}
+
+// extractZip extracts a zip file into dst. Entries which would end up outside of dst are rejected,
+// as are entries which would be written through a symlink. Zip files carry no owner information,
+// hence all entries are handed over to the container's root user like chownMapped does.
+func extractZip(r io.ReaderAt, size int64, dst string, mappings []archive.IDMapping) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+
+	dst = filepath.Clean(dst)
+	for _, f := range zr.File {
+		fn := filepath.Join(dst, f.Name)
+		if !strings.HasPrefix(fn, dst+string(filepath.Separator)) {
+			return xerrors.Errorf("invalid path in zip file: %s", f.Name)
+		}
+		err = checkNoSymlink(dst, fn)
+		if err != nil {
+			return xerrors.Errorf("invalid path in zip file: %s: %w", f.Name, err)
+		}
+
+		mode := f.Mode()
+		switch {
+		case mode.IsDir():
+			err = mkdirAllMapped(fn, mappings)
+			if err == nil {
+				err = os.Chmod(fn, mode.Perm()|0700)
+			}
+		case mode&os.ModeSymlink != 0:
+			err = extractZipSymlink(f, dst, fn, mappings)
+		case mode.IsRegular():
+			err = extractZipFile(f, fn, mappings)
+		default:
+			log.WithField("name", f.Name).WithField("mode", mode).Debug("skipping unsupported zip entry")
+		}
+		if err != nil {
+			return xerrors.Errorf("cannot extract %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkNoSymlink returns an error if fn or any of its parents below dst is a symlink
+func checkNoSymlink(dst, fn string) error {
+	for p := fn; p != dst && strings.HasPrefix(p, dst); p = filepath.Dir(p) {
+		stat, err := os.Lstat(p)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if stat.Mode()&os.ModeSymlink != 0 {
+			rel, _ := filepath.Rel(dst, p)
+			return xerrors.Errorf("%s is a symlink", rel)
+		}
+	}
+	return nil
+}
+
+// extractZipSymlink creates the symlink f at fn. Its target must be relative and must not leave dst.
+// Targets may only ascend in their leading components: once a target descended into a directory
+// which itself might be a symlink, a ".." would be resolved relative to that symlink's target.
+func extractZipSymlink(f *zip.File, dst, fn string, mappings []archive.IDMapping) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	target, err := io.ReadAll(io.LimitReader(rc, 4096))
+	rc.Close()
+	if err != nil {
+		return err
+	}
+
+	tgt := string(target)
+	if filepath.IsAbs(tgt) {
+		return xerrors.Errorf("absolute symlink target %s", tgt)
+	}
+	var descended bool
+	for _, c := range strings.Split(tgt, string(filepath.Separator)) {
+		switch c {
+		case "", ".":
+		case "..":
+			if descended {
+				return xerrors.Errorf("invalid symlink target %s", tgt)
+			}
+		default:
+			descended = true
+		}
+	}
+	resolved := filepath.Join(filepath.Dir(fn), tgt)
+	if resolved != dst && !strings.HasPrefix(resolved, dst+string(filepath.Separator)) {
+		return xerrors.Errorf("symlink target %s is outside of the extraction path", tgt)
+	}
+
+	err = mkdirAllMapped(filepath.Dir(fn), mappings)
+	if err != nil {
+		return err
+	}
+	err = os.Symlink(tgt, fn)
+	if err != nil {
+		return err
+	}
+	chownMapped(fn, mappings)
+	return nil
+}
+
+func extractZipFile(f *zip.File, fn string, mappings []archive.IDMapping) error {
+	err := mkdirAllMapped(filepath.Dir(fn), mappings)
+	if err != nil {
+		return err
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(fn, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, f.Mode().Perm()|0600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, rc)
+	if err != nil {
+		out.Close()
+		return err
+	}
+	err = out.Close()
+	if err != nil {
+		return err
+	}
+	chownMapped(fn, mappings)
 	return nil
 }
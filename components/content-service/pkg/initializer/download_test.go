@@ -5,14 +5,24 @@
 package initializer
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
+	"testing/iotest"
 
 	"github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+	"github.com/google/go-cmp/cmp"
 	"github.com/opencontainers/go-digest"
 )
 
@@ -74,7 +84,27 @@ func TestFileDownloadInitializer(t *testing.T) {
 			ServerSide: []serverSideFile{
 				{Path: "/file1", Content: defaultContent},
 			},
-			ExpectedError: "digest mismatch",
+			ExpectedError: fmt.Sprintf("cannot download file '/level/file1' from 'http://foobar/file1': digest mismatch: expected %s, got %s", digest.FromString(defaultContent+"foobar"), digest.FromString(defaultContent)),
+		},
+		{
+			Name: "conflicting files",
+			Files: []fileInfo{
+				{
+					URL:    "/file1",
+					Path:   "/level/file1",
+					Digest: digest.FromString(defaultContent),
+				},
+				{
+					URL:    "/file2",
+					Path:   "/level/file1",
+					Digest: digest.FromString(defaultContent),
+				},
+			},
+			ServerSide: []serverSideFile{
+				{Path: "/file1", Content: defaultContent},
+				{Path: "/file2", Content: defaultContent},
+			},
+			ExpectedError: "conflicting downloads for file '/level/file1'",
 		},
 		{
 			Name: "file not found",
@@ -85,7 +115,7 @@ func TestFileDownloadInitializer(t *testing.T) {
 					Digest: digest.FromString(defaultContent + "foobar"),
 				},
 			},
-			ExpectedError: "non-OK download response: Not Found",
+			ExpectedError: "cannot download file '/level/file1' from 'http://foobar/file1': non-OK download response: Not Found",
 		},
 	}
 
@@ -145,3 +175,262 @@ func TestFileDownloadInitializer(t *testing.T) {
 		})
 	}
 }
+
+func TestFileDownloadInitializerResume(t *testing.T) {
+	content := strings.Repeat("0123456789", 1024)
+
+	tests := []struct {
+		Name           string
+		SupportsRange  bool
+		ExpectedRanges []string
+	}{
+		{Name: "range requests", SupportsRange: true, ExpectedRanges: []string{"", "bytes=4000-"}},
+		{Name: "no range requests", SupportsRange: false, ExpectedRanges: []string{"", "bytes=4000-"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var ranges []string
+			client := &http.Client{
+				Transport: RoundTripFunc(func(req *http.Request) *http.Response {
+					rng := req.Header.Get("Range")
+					ranges = append(ranges, rng)
+					if len(ranges) == 1 {
+						// the connection breaks after the first 4000 bytes
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(io.MultiReader(strings.NewReader(content[:4000]), iotest.ErrReader(errors.New("connection reset")))),
+							Header:     make(http.Header),
+						}
+					}
+					if rng == "" || !test.SupportsRange {
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(strings.NewReader(content)),
+							Header:     make(http.Header),
+						}
+					}
+
+					var offset int
+					_, err := fmt.Sscanf(rng, "bytes=%d-", &offset)
+					if err != nil {
+						t.Errorf("invalid range header %s: %v", rng, err)
+					}
+					hdr := make(http.Header)
+					hdr.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(content)-1, len(content)))
+					return &http.Response{
+						StatusCode: http.StatusPartialContent,
+						Body:       io.NopCloser(strings.NewReader(content[offset:])),
+						Header:     hdr,
+					}
+				}),
+			}
+
+			tmpdir := t.TempDir()
+			initializer := &fileDownloadInitializer{
+				FilesInfos:     []fileInfo{{URL: "http://foobar/file", Path: "file", Digest: digest.FromString(content)}},
+				TargetLocation: tmpdir,
+				HTTPClient:     client,
+			}
+			_, _, err := initializer.Run(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			act, err := os.ReadFile(filepath.Join(tmpdir, "file"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(act) != content {
+				t.Errorf("downloaded file has unexpected content")
+			}
+			if diff := cmp.Diff(test.ExpectedRanges, ranges); diff != "" {
+				t.Errorf("unexpected range requests (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFileDownloadInitializerExtract(t *testing.T) {
+	files := map[string]string{
+		"foo.txt":     "foo",
+		"bar/bar.txt": "bar",
+	}
+	tarball := func() []byte {
+		var buf bytes.Buffer
+		gz, err := archive.Compress(&buf, archive.CompressionGzip, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tw := tar.NewWriter(gz)
+		for name, content := range files {
+			err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = tw.Write([]byte(content))
+		}
+		tw.Close()
+		gz.Close()
+		return buf.Bytes()
+	}
+	zipfile := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for name, content := range files {
+			w, err := zw.Create(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = w.Write([]byte(content))
+		}
+		zw.Close()
+		return buf.Bytes()
+	}
+
+	// zipWithSymlink produces a zip file with the symlink name pointing to target, followed by the files
+	zipWithSymlink := func(name, target string, files ...string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		hdr := &zip.FileHeader{Name: name}
+		hdr.SetMode(os.ModeSymlink | 0777)
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = w.Write([]byte(target))
+		for _, fn := range files {
+			w, err := zw.Create(fn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = w.Write([]byte(fn))
+		}
+		zw.Close()
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		Name          string
+		Archive       []byte
+		ExtractTo     string
+		ExpectedError string
+		// Expectation is the extracted content. Defaults to files.
+		Expectation map[string]string
+	}{
+		{Name: "tar.gz", Archive: tarball()},
+		{Name: "zip", Archive: zipfile(files)},
+		{Name: "zip with invalid path", Archive: zipfile(map[string]string{"../escaped.txt": "escaped"}), ExpectedError: "cannot download file 'archive' from 'http://foobar/archive': cannot extract archive: invalid path in zip file: ../escaped.txt"},
+		{
+			Name:        "zip with symlink",
+			Archive:     zipWithSymlink("docs/foo.txt", "../foo.txt", "foo.txt"),
+			Expectation: map[string]string{"foo.txt": "foo.txt", "docs/foo.txt": "foo.txt"},
+		},
+		{Name: "zip with absolute symlink", Archive: zipWithSymlink("passwd", "/etc/passwd"), ExpectedError: "cannot download file 'archive' from 'http://foobar/archive': cannot extract archive: cannot extract passwd: absolute symlink target /etc/passwd"},
+		{Name: "zip with symlink outside of the extraction path", Archive: zipWithSymlink("docs/etc", "../../../etc"), ExpectedError: "cannot download file 'archive' from 'http://foobar/archive': cannot extract archive: cannot extract docs/etc: symlink target ../../../etc is outside of the extraction path"},
+		{Name: "zip with symlink ascending after descending", Archive: zipWithSymlink("etc", "docs/../../etc"), ExpectedError: "cannot download file 'archive' from 'http://foobar/archive': cannot extract archive: cannot extract etc: invalid symlink target docs/../../etc"},
+		{Name: "zip writing through a symlink", Archive: zipWithSymlink("docs", "bar", "docs/escaped.txt"), ExpectedError: "cannot download file 'archive' from 'http://foobar/archive': cannot extract archive: invalid path in zip file: docs/escaped.txt: docs is a symlink"},
+		{Name: "zip overwriting a symlink", Archive: zipWithSymlink("foo.txt", "bar.txt", "foo.txt"), ExpectedError: "cannot download file 'archive' from 'http://foobar/archive': cannot extract archive: invalid path in zip file: foo.txt: foo.txt is a symlink"},
+		{Name: "extraction outside of the target location", Archive: tarball(), ExtractTo: "../..", ExpectedError: "cannot download file 'archive' from 'http://foobar/archive': invalid extraction path: ../.."},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			client := &http.Client{
+				Transport: RoundTripFunc(func(req *http.Request) *http.Response {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewReader(test.Archive)),
+						Header:     make(http.Header),
+					}
+				}),
+			}
+
+			extractTo := test.ExtractTo
+			if extractTo == "" {
+				extractTo = "content"
+			}
+
+			tmpdir := t.TempDir()
+			initializer := &fileDownloadInitializer{
+				FilesInfos:     []fileInfo{{URL: "http://foobar/archive", Path: "archive", Digest: digest.FromBytes(test.Archive), ExtractTo: extractTo}},
+				TargetLocation: tmpdir,
+				HTTPClient:     client,
+			}
+			_, _, err := initializer.Run(context.Background(), nil)
+			if test.ExpectedError != "" {
+				if err == nil || err.Error() != test.ExpectedError {
+					t.Fatalf("unexpected error: want %s, got %v", test.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			act := make(map[string]string)
+			err = filepath.Walk(tmpdir, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return err
+				}
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				rel, _ := filepath.Rel(filepath.Join(tmpdir, "content"), path)
+				act[rel] = string(content)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectation := test.Expectation
+			if expectation == nil {
+				expectation = files
+			}
+			if diff := cmp.Diff(expectation, act); diff != "" {
+				t.Errorf("unexpected content (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExtractZipOwner(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("chowning files requires root")
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("docs/readme.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte("readme"))
+	hdr := &zip.FileHeader{Name: "readme.md"}
+	hdr.SetMode(os.ModeSymlink | 0777)
+	w, err = zw.CreateHeader(hdr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte("docs/readme.md"))
+	zw.Close()
+
+	dst := t.TempDir()
+	mappings := []archive.IDMapping{{ContainerID: 0, HostID: GitpodUID, Size: 1}}
+	err = extractZip(bytes.NewReader(buf.Bytes()), int64(buf.Len()), dst, mappings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fn := range []string{"docs", "docs/readme.md", "readme.md"} {
+		stat, err := os.Lstat(filepath.Join(dst, fn))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sys := stat.Sys().(*syscall.Stat_t)
+		if diff := cmp.Diff([2]uint32{GitpodUID, GitpodUID}, [2]uint32{sys.Uid, sys.Gid}); diff != "" {
+			t.Errorf("unexpected owner of %s (-want +got):\n%s", fn, diff)
+		}
+	}
+}
@@ -144,6 +144,8 @@ func (ws *GitInitializer) Run(ctx context.Context, mappings []archive.IDMapping)
 		err = checkGitStatus(err)
 		return src, nil, xerrors.Errorf("git initializer gitClone: %w", err)
 	}
+	fetchDuration := time.Since(start)
+	checkoutStart := time.Now()
 
 	defer func() {
 		span.SetTag("Chown", ws.Chown)
@@ -174,7 +176,11 @@ func (ws *GitInitializer) Run(ctx context.Context, mappings []archive.IDMapping)
 	if err := ws.UpdateSubmodules(ctx); err != nil {
 		log.WithError(err).Warn("error while updating submodules - continuing")
 	}
+	if err := ws.PullLFS(ctx); err != nil {
+		log.WithError(err).Warn("error while pulling LFS objects - continuing")
+	}
 
+	checkoutDuration := time.Since(checkoutStart)
 	log.WithField("stage", "init").WithField("location", ws.Location).Debug("Git operations complete")
 
 	if fsErr == nil {
@@ -183,11 +189,29 @@ func (ws *GitInitializer) Run(ctx context.Context, mappings []archive.IDMapping)
 			log.WithError(fsErr).Error("could not get disk usage")
 		}
 
+		duration := time.Since(start)
+		size := currentSize - initialSize
 		stats = csapi.InitializerMetrics{csapi.InitializerMetric{
 			Type:     "git",
-			Duration: time.Since(start),
-			Size:     currentSize - initialSize,
+			Duration: duration,
+			Size:     size,
 		}}
+
+		// With partial clones and sparse checkouts what we fetch and what we check out can differ
+		// substantially. We report both s.t. the effect of those options becomes visible.
+		// The checkout is what the clone added to the disk beyond the Git objects, including LFS objects.
+		fetched, err := ws.ObjectsSize(ctx)
+		if err != nil {
+			log.WithError(err).Warn("could not determine size of the fetched Git objects")
+		}
+		var checkedOut uint64
+		if size > fetched {
+			checkedOut = size - fetched
+		}
+		stats = append(stats,
+			csapi.InitializerMetric{Type: "git-fetch", Duration: fetchDuration, Size: fetched},
+			csapi.InitializerMetric{Type: "git-checkout", Duration: checkoutDuration, Size: checkedOut},
+		)
 	}
 	return
 }
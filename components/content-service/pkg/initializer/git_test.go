@@ -0,0 +1,118 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package initializer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/git"
+	"github.com/gitpod-io/gitpod/content-service/pkg/initializer"
+)
+
+func TestNewGitInitializerCheckoutOptions(t *testing.T) {
+	type expectation struct {
+		Filter         string
+		SparseCheckout []string
+		LFS            *git.LFSConfig
+	}
+	tests := []struct {
+		Name        string
+		Req         *csapi.GitInitializer
+		Expectation *expectation
+		Code        codes.Code
+	}{
+		{
+			Name:        "no options",
+			Req:         &csapi.GitInitializer{},
+			Expectation: &expectation{},
+		},
+		{
+			Name: "all options",
+			Req: &csapi.GitInitializer{
+				PartialCloneFilter: "blob:none",
+				SparseCheckout:     []string{"backend", "docs/api"},
+				Lfs: &csapi.GitLFSConfig{
+					Include: []string{"assets/**"},
+					Exclude: []string{"*.psd"},
+				},
+			},
+			Expectation: &expectation{
+				Filter:         "blob:none",
+				SparseCheckout: []string{"backend", "docs/api"},
+				LFS: &git.LFSConfig{
+					Include: []string{"assets/**"},
+					Exclude: []string{"*.psd"},
+				},
+			},
+		},
+		{
+			Name:        "blob size limit",
+			Req:         &csapi.GitInitializer{PartialCloneFilter: "blob:limit=1m"},
+			Expectation: &expectation{Filter: "blob:limit=1m"},
+		},
+		{
+			Name: "invalid filter",
+			Req:  &csapi.GitInitializer{PartialCloneFilter: "blob:none --upload-pack=evil"},
+			Code: codes.InvalidArgument,
+		},
+		{
+			Name: "sparse checkout path looks like a flag",
+			Req:  &csapi.GitInitializer{SparseCheckout: []string{"--no-cone"}},
+			Code: codes.InvalidArgument,
+		},
+		{
+			Name: "sparse checkout path outside of the repo",
+			Req:  &csapi.GitInitializer{SparseCheckout: []string{"docs/../../etc"}},
+			Code: codes.InvalidArgument,
+		},
+		{
+			Name: "absolute sparse checkout path",
+			Req:  &csapi.GitInitializer{SparseCheckout: []string{"/etc"}},
+			Code: codes.InvalidArgument,
+		},
+		{
+			Name: "LFS pattern with comma",
+			Req:  &csapi.GitInitializer{Lfs: &csapi.GitLFSConfig{Include: []string{"a,b"}}},
+			Code: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Req.RemoteUri = "https://github.com/gitpod-io/gitpod.git"
+			test.Req.TargetMode = csapi.CloneTargetMode_REMOTE_HEAD
+			test.Req.Config = &csapi.GitConfig{Authentication: csapi.GitAuthMethod_NO_AUTH}
+
+			init, err := initializer.NewFromRequest(context.Background(), t.TempDir(), nil, &csapi.WorkspaceInitializer{
+				Spec: &csapi.WorkspaceInitializer_Git{Git: test.Req},
+			}, initializer.NewFromRequestOpts{})
+			if code := status.Code(err); code != test.Code {
+				t.Fatalf("unexpected status code: want %v, got %v (%v)", test.Code, code, err)
+			}
+			if err != nil {
+				return
+			}
+
+			gi, ok := init.(*initializer.GitInitializer)
+			if !ok {
+				t.Fatalf("expected a Git initializer, got %T", init)
+			}
+			act := &expectation{
+				Filter:         gi.Filter,
+				SparseCheckout: gi.SparseCheckout,
+				LFS:            gi.LFS,
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected Git client (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"syscall"
 	"time"
@@ -44,6 +45,9 @@ const (
 
 	// otsDownloadAttempts is the number of times we'll attempt to download the one-time secret
 	otsDownloadAttempts = 20
+
+	// fileDownloadConcurrency is the number of files the download initializer fetches at the same time
+	fileDownloadConcurrency = 4
 )
 
 // Initializer can initialize a workspace with content
@@ -104,6 +108,13 @@ type NewFromRequestOpts struct {
 	// Git content is forced to the Gitpod user. All other content (backup, prebuild, snapshot) will already
 	// have the correct user.
 	ForceGitpodUserForGit bool
+
+	// GitReferences maps remote URIs to local repositories which Git initializers of that remote
+	// clone from using `--reference`. The clone does not depend on the local repository afterwards.
+	GitReferences map[string]string
+
+	// OCIResolver resolves and fetches the content of OCI initializers. OCI initializers cannot run without one.
+	OCIResolver OCIResolver
 }
 
 // NewFromRequest picks the initializer from the request but does not execute it.
@@ -132,7 +143,7 @@ func NewFromRequest(ctx context.Context, loc string, rs storage.DirectDownloader
 			return nil, status.Error(codes.InvalidArgument, "missing Git initializer spec")
 		}
 
-		initializer, err = newGitInitializer(ctx, loc, ir.Git, opts.ForceGitpodUserForGit)
+		initializer, err = newGitInitializer(ctx, loc, ir.Git, opts)
 	} else if ir, ok := spec.(*csapi.WorkspaceInitializer_Prebuild); ok {
 		if ir.Prebuild == nil {
 			return nil, status.Error(codes.InvalidArgument, "missing prebuild initializer spec")
@@ -146,7 +157,7 @@ func NewFromRequest(ctx context.Context, loc string, rs storage.DirectDownloader
 		}
 		var gits []*GitInitializer
 		for _, gi := range ir.Prebuild.Git {
-			gitinit, err := newGitInitializer(ctx, loc, gi, opts.ForceGitpodUserForGit)
+			gitinit, err := newGitInitializer(ctx, loc, gi, opts)
 			if err != nil {
 				return nil, err
 			}
@@ -162,6 +173,8 @@ func NewFromRequest(ctx context.Context, loc string, rs storage.DirectDownloader
 		initializer, err = newFileDownloadInitializer(loc, ir.Download)
 	} else if ir, ok := spec.(*csapi.WorkspaceInitializer_Backup); ok {
 		initializer, err = newFromBackupInitializer(loc, rs, ir.Backup)
+	} else if ir, ok := spec.(*csapi.WorkspaceInitializer_Oci); ok {
+		initializer, err = newOCIInitializer(loc, ir.Oci, opts.OCIResolver)
 	} else {
 		initializer = &EmptyInitializer{}
 	}
@@ -180,9 +193,10 @@ func newFileDownloadInitializer(loc string, req *csapi.FileDownloadInitializer)
 			return nil, xerrors.Errorf("invalid digest %s: %w", f.Digest, err)
 		}
 		fileInfos[i] = fileInfo{
-			URL:    f.Url,
-			Path:   f.FilePath,
-			Digest: dgst,
+			URL:       f.Url,
+			Path:      f.FilePath,
+			Digest:    dgst,
+			ExtractTo: f.ExtractTo,
 		}
 	}
 	initializer := &fileDownloadInitializer{
@@ -190,6 +204,7 @@ func newFileDownloadInitializer(loc string, req *csapi.FileDownloadInitializer)
 		TargetLocation: filepath.Join(loc, req.TargetLocation),
 		HTTPClient:     http.DefaultClient,
 		RetryTimeout:   1 * time.Second,
+		Concurrency:    fileDownloadConcurrency,
 	}
 	return initializer, nil
 }
@@ -200,6 +215,7 @@ func newFromBackupInitializer(loc string, rs storage.DirectDownloader, req *csap
 		Location:           loc,
 		RemoteStorage:      rs,
 		FromVolumeSnapshot: req.FromVolumeSnapshot,
+		BackupID:           req.BackupId,
 	}, nil
 }
 
@@ -207,6 +223,8 @@ type fromBackupInitializer struct {
 	Location           string
 	RemoteStorage      storage.DirectDownloader
 	FromVolumeSnapshot bool
+	// BackupID selects a previous backup from the backup history. If empty, the latest backup is restored.
+	BackupID string
 }
 
 func (bi *fromBackupInitializer) Run(ctx context.Context, mappings []archive.IDMapping) (src csapi.WorkspaceInitSource, stats csapi.InitializerMetrics, err error) {
@@ -220,7 +238,7 @@ func (bi *fromBackupInitializer) Run(ctx context.Context, mappings []archive.IDM
 		log.WithError(fsErr).Error("could not get disk usage")
 	}
 
-	hasBackup, err := bi.RemoteStorage.Download(ctx, bi.Location, storage.DefaultBackup, mappings)
+	hasBackup, err := downloadBackup(ctx, bi.RemoteStorage, bi.Location, bi.BackupID, mappings)
 	if !hasBackup {
 		if err != nil {
 			return src, nil, xerrors.Errorf("no backup found, error: %w", err)
@@ -247,9 +265,25 @@ func (bi *fromBackupInitializer) Run(ctx context.Context, mappings []archive.IDM
 	return csapi.WorkspaceInitFromBackup, stats, nil
 }
 
+// partialCloneFilterRegex matches the filter specs supported by `git clone --filter`
+var partialCloneFilterRegex = regexp.MustCompile(`^(blob:none|blob:limit=\d+[kmg]?|tree:\d+|object:type=(blob|tree|commit|tag))$`)
+
+// isValidSparseCheckoutPath returns true if p is a directory within the repository which can't be mistaken for a flag
+func isValidSparseCheckoutPath(p string) bool {
+	if p == "" || strings.HasPrefix(p, "-") || filepath.IsAbs(p) {
+		return false
+	}
+	for _, seg := range strings.Split(filepath.ToSlash(p), "/") {
+		if seg == ".." {
+			return false
+		}
+	}
+	return true
+}
+
 // newGitInitializer creates a Git initializer based on the request.
 // Returns gRPC errors.
-func newGitInitializer(ctx context.Context, loc string, req *csapi.GitInitializer, forceGitpodUser bool) (*GitInitializer, error) {
+func newGitInitializer(ctx context.Context, loc string, req *csapi.GitInitializer, opts NewFromRequestOpts) (*GitInitializer, error) {
 	if req.Config == nil {
 		return nil, status.Error(codes.InvalidArgument, "Git initializer misses config")
 	}
@@ -294,16 +328,42 @@ func newGitInitializer(ctx context.Context, loc string, req *csapi.GitInitialize
 		return
 	})
 
+	if req.PartialCloneFilter != "" && !partialCloneFilterRegex.MatchString(req.PartialCloneFilter) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid partial clone filter: %s", req.PartialCloneFilter))
+	}
+	for _, p := range req.SparseCheckout {
+		if !isValidSparseCheckoutPath(p) {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid sparse checkout path: %s", p))
+		}
+	}
+	var lfs *git.LFSConfig
+	if req.Lfs != nil {
+		for _, p := range append(append([]string{}, req.Lfs.Include...), req.Lfs.Exclude...) {
+			if p == "" || strings.Contains(p, ",") {
+				// patterns are passed to Git LFS as comma separated list
+				return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid LFS pattern: %q", p))
+			}
+		}
+		lfs = &git.LFSConfig{
+			Include: req.Lfs.Include,
+			Exclude: req.Lfs.Exclude,
+		}
+	}
+
 	log.WithField("location", loc).Debug("using Git initializer")
 	return &GitInitializer{
 		Client: git.Client{
-			Location:          filepath.Join(loc, req.CheckoutLocation),
-			RemoteURI:         req.RemoteUri,
-			UpstreamRemoteURI: req.Upstream_RemoteUri,
-			Config:            req.Config.CustomConfig,
-			AuthMethod:        authMethod,
-			AuthProvider:      authProvider,
-			RunAsGitpodUser:   forceGitpodUser,
+			Location:            filepath.Join(loc, req.CheckoutLocation),
+			RemoteURI:           req.RemoteUri,
+			UpstreamRemoteURI:   req.Upstream_RemoteUri,
+			Config:              req.Config.CustomConfig,
+			AuthMethod:          authMethod,
+			AuthProvider:        authProvider,
+			RunAsGitpodUser:     opts.ForceGitpodUserForGit,
+			Filter:              req.PartialCloneFilter,
+			SparseCheckout:      req.SparseCheckout,
+			LFS:                 lfs,
+			ReferenceRepository: opts.GitReferences[req.RemoteUri],
 		},
 		TargetMode:  targetMode,
 		CloneTarget: req.CloneTaget,
@@ -467,8 +527,12 @@ func InitializeWorkspace(ctx context.Context, location string, remoteStorage sto
 		}
 	}
 
-	// Run the initializer
-	hasBackup, err := remoteStorage.Download(ctx, location, storage.DefaultBackup, cfg.mappings)
+	// Run the initializer. A specific backup version requested by the initializer takes precedence over the latest backup.
+	var backupID string
+	if bi, ok := cfg.Initializer.(*fromBackupInitializer); ok {
+		backupID = bi.BackupID
+	}
+	hasBackup, err := downloadBackup(ctx, remoteStorage, location, backupID, cfg.mappings)
 	if err != nil {
 		return src, nil, xerrors.Errorf("cannot restore backup: %w", err)
 	}
@@ -5,13 +5,23 @@
 package initializer_test
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/opencontainers/go-digest"
+
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
 	"github.com/gitpod-io/gitpod/content-service/pkg/initializer"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 )
 
 type InitializerFunc func(ctx context.Context, mappings []archive.IDMapping) (csapi.WorkspaceInitSource, csapi.InitializerMetrics, error)
@@ -99,3 +109,268 @@ func TestCompositeInitializer(t *testing.T) {
 		})
 	}
 }
+
+// objectDownloader serves objects from memory
+type objectDownloader map[string][]byte
+
+func (d objectDownloader) Download(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (found bool, err error) {
+	content, found := d[name]
+	if !found {
+		return false, nil
+	}
+	return true, archive.ExtractTarbal(ctx, bytes.NewReader(content), destination)
+}
+
+func (d objectDownloader) DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (found bool, err error) {
+	return d.Download(ctx, destination, name, mappings)
+}
+
+func (d objectDownloader) DownloadObject(ctx context.Context, name string, dst io.Writer) (found bool, err error) {
+	content, found := d[name]
+	if !found {
+		return false, nil
+	}
+	_, err = dst.Write(content)
+	return true, err
+}
+
+func buildTestTar(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Size:     int64(len(content)),
+			Mode:     0644,
+			Typeflag: tar.TypeReg,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tw.Write([]byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := tw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// addChunkedBackup splits the tarball into a few chunks and adds them together with their manifest
+func addChunkedBackup(t *testing.T, objs objectDownloader, tarball []byte) *csapi.ChunkedBackupManifest {
+	mf := &csapi.ChunkedBackupManifest{
+		Digest: digest.FromBytes(tarball),
+		Size:   int64(len(tarball)),
+	}
+	for len(tarball) > 0 {
+		n := 1000
+		if n > len(tarball) {
+			n = len(tarball)
+		}
+		chunk := tarball[:n]
+		tarball = tarball[n:]
+
+		dgst := digest.FromBytes(chunk)
+		objs[storage.BackupChunkName(dgst)] = chunk
+		mf.Chunks = append(mf.Chunks, csapi.BackupChunk{Digest: dgst, Size: int64(n)})
+	}
+	raw, err := json.Marshal(mf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs[storage.DefaultChunkedBackupManifest] = raw
+	return mf
+}
+
+func TestInitializeWorkspaceFromBackup(t *testing.T) {
+	chunkedFiles := map[string]string{
+		"chunked.txt": "restored from chunks",
+		"large.txt":   string(bytes.Repeat([]byte("x"), 5000)),
+	}
+	fullFiles := map[string]string{
+		"full.txt": "restored from full tar",
+	}
+
+	tests := []struct {
+		Name        string
+		Objects     func(t *testing.T) objectDownloader
+		Expectation []string
+		ExpectErr   bool
+	}{
+		{
+			Name: "chunked backup",
+			Objects: func(t *testing.T) objectDownloader {
+				res := make(objectDownloader)
+				addChunkedBackup(t, res, buildTestTar(t, chunkedFiles))
+				return res
+			},
+			Expectation: []string{"chunked.txt", "large.txt"},
+		},
+		{
+			Name: "chunked backup takes precedence",
+			Objects: func(t *testing.T) objectDownloader {
+				res := make(objectDownloader)
+				addChunkedBackup(t, res, buildTestTar(t, chunkedFiles))
+				res[storage.DefaultBackup] = buildTestTar(t, fullFiles)
+				return res
+			},
+			Expectation: []string{"chunked.txt", "large.txt"},
+		},
+		{
+			Name: "full tar fallback",
+			Objects: func(t *testing.T) objectDownloader {
+				return objectDownloader{
+					storage.DefaultBackup: buildTestTar(t, fullFiles),
+				}
+			},
+			Expectation: []string{"full.txt"},
+		},
+		{
+			Name: "missing chunk",
+			Objects: func(t *testing.T) objectDownloader {
+				res := make(objectDownloader)
+				mf := addChunkedBackup(t, res, buildTestTar(t, chunkedFiles))
+				delete(res, storage.BackupChunkName(mf.Chunks[1].Digest))
+				return res
+			},
+			ExpectErr: true,
+		},
+		{
+			Name: "corrupted chunk",
+			Objects: func(t *testing.T) objectDownloader {
+				res := make(objectDownloader)
+				mf := addChunkedBackup(t, res, buildTestTar(t, chunkedFiles))
+				name := storage.BackupChunkName(mf.Chunks[0].Digest)
+				res[name] = bytes.ToUpper(res[name])
+				return res
+			},
+			ExpectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			loc := t.TempDir()
+			src, _, err := initializer.InitializeWorkspace(context.Background(), loc, test.Objects(t), initializer.WithInitializer(&initializer.EmptyInitializer{}))
+			if test.ExpectErr {
+				if err == nil {
+					t.Fatal("expected an error, got nothing")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if src != csapi.WorkspaceInitFromBackup {
+				t.Errorf("unexpected init source: %s", src)
+			}
+
+			var act []string
+			entries, err := os.ReadDir(loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range entries {
+				act = append(act, e.Name())
+				content, err := os.ReadFile(filepath.Join(loc, e.Name()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if expected, ok := chunkedFiles[e.Name()]; ok && string(content) != expected {
+					t.Errorf("unexpected content of %s", e.Name())
+				}
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected files (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeWorkspaceFromBackupVersion(t *testing.T) {
+	latestFiles := map[string]string{
+		"latest.txt": "restored from the latest backup",
+	}
+	chunkedFiles := map[string]string{
+		"chunked.txt": "restored from a chunked version",
+	}
+	fullFiles := map[string]string{
+		"full.txt": "restored from a full version",
+	}
+
+	// objects produces a workspace whose latest backup is chunked and whose history holds a chunked and a full version
+	objects := func(t *testing.T) objectDownloader {
+		res := make(objectDownloader)
+		mf := addChunkedBackup(t, res, buildTestTar(t, chunkedFiles))
+		res[storage.BackupVersionName(0, true)] = res[storage.DefaultChunkedBackupManifest]
+		res[storage.BackupVersionName(1, false)] = buildTestTar(t, fullFiles)
+		addChunkedBackup(t, res, buildTestTar(t, latestFiles))
+
+		history, err := json.Marshal(csapi.BackupHistory{
+			Backups: []csapi.BackupVersion{
+				{ID: "chunked", Object: storage.BackupVersionName(0, true), Chunked: true, Size: mf.Size},
+				{ID: "full", Object: storage.BackupVersionName(1, false)},
+				{ID: "missing", Object: storage.BackupVersionName(2, false)},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res[storage.BackupHistoryManifest] = history
+		return res
+	}
+
+	tests := []struct {
+		Name        string
+		BackupID    string
+		Expectation []string
+		ExpectErr   bool
+	}{
+		{Name: "latest", Expectation: []string{"latest.txt"}},
+		{Name: "chunked version", BackupID: "chunked", Expectation: []string{"chunked.txt"}},
+		{Name: "full version", BackupID: "full", Expectation: []string{"full.txt"}},
+		{Name: "unknown version", BackupID: "unknown", ExpectErr: true},
+		{Name: "missing version", BackupID: "missing", ExpectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			loc := t.TempDir()
+			rs := objects(t)
+			init, err := initializer.NewFromRequest(context.Background(), loc, rs, &csapi.WorkspaceInitializer{
+				Spec: &csapi.WorkspaceInitializer_Backup{
+					Backup: &csapi.FromBackupInitializer{BackupId: test.BackupID},
+				},
+			}, initializer.NewFromRequestOpts{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, _, err = initializer.InitializeWorkspace(context.Background(), loc, rs, initializer.WithInitializer(init))
+			if test.ExpectErr {
+				if err == nil {
+					t.Fatal("expected an error, got nothing")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var act []string
+			entries, err := os.ReadDir(loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range entries {
+				act = append(act, e.Name())
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected files (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
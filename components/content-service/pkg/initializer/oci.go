@@ -0,0 +1,317 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package initializer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+const (
+	// maxManifestSize limits the size of manifests and indices we're willing to read
+	maxManifestSize = 4 * 1024 * 1024
+
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeArtifactManifest   = "application/vnd.oci.artifact.manifest.v1+json"
+)
+
+// OCIResolver resolves and fetches OCI images and artifacts. Implementations authenticate with the registry
+// themselves, s.t. initializer requests never carry registry credentials.
+type OCIResolver interface {
+	// Resolve resolves a reference to the descriptor of its manifest or index
+	Resolve(ctx context.Context, ref string) (ociv1.Descriptor, error)
+
+	// Fetch fetches the content desc describes from the repository of ref
+	Fetch(ctx context.Context, ref string, desc ociv1.Descriptor) (io.ReadCloser, error)
+}
+
+// ociInitializer unpacks the layers of an OCI image or artifact into the workspace
+type ociInitializer struct {
+	Ref            string
+	TargetLocation string
+	Resolver       OCIResolver
+}
+
+// newOCIInitializer creates an OCI initializer for a request
+func newOCIInitializer(loc string, req *csapi.OCIInitializer, resolver OCIResolver) (*ociInitializer, error) {
+	if req.Ref == "" {
+		return nil, xerrors.Errorf("missing reference")
+	}
+	if resolver == nil {
+		return nil, xerrors.Errorf("OCI initializers are not supported here")
+	}
+
+	return &ociInitializer{
+		Ref:            req.Ref,
+		TargetLocation: filepath.Join(loc, req.TargetLocation),
+		Resolver:       resolver,
+	}, nil
+}
+
+// Run initializes the workspace
+func (oi *ociInitializer) Run(ctx context.Context, mappings []archive.IDMapping) (src csapi.WorkspaceInitSource, metrics csapi.InitializerMetrics, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "OCIInitializer.Run")
+	span.SetTag("ref", oi.Ref)
+	defer tracing.FinishSpan(span, &err)
+	start := time.Now()
+	initialSize, fsErr := getFsUsage()
+	if fsErr != nil {
+		log.WithError(fsErr).Error("could not get disk usage")
+	}
+
+	desc, err := oi.Resolver.Resolve(ctx, oi.Ref)
+	if err != nil {
+		return src, nil, xerrors.Errorf("cannot resolve %s: %w", oi.Ref, err)
+	}
+	manifest, err := oi.fetchManifest(ctx, desc)
+	if err != nil {
+		return src, nil, xerrors.Errorf("cannot fetch manifest of %s: %w", oi.Ref, err)
+	}
+
+	err = os.MkdirAll(oi.TargetLocation, 0755)
+	if err != nil {
+		return src, nil, xerrors.Errorf("cannot create target location: %w", err)
+	}
+	for _, layer := range manifest.Layers {
+		err = oi.unpackLayer(ctx, layer, mappings)
+		if err != nil {
+			return src, nil, xerrors.Errorf("cannot unpack layer %s of %s: %w", layer.Digest, oi.Ref, err)
+		}
+	}
+
+	if fsErr == nil {
+		currentSize, fsErr := getFsUsage()
+		if fsErr != nil {
+			log.WithError(fsErr).Error("could not get disk usage")
+		}
+
+		metrics = csapi.InitializerMetrics{csapi.InitializerMetric{
+			Type:     "oci",
+			Duration: time.Since(start),
+			Size:     currentSize - initialSize,
+		}}
+	}
+
+	src = csapi.WorkspaceInitFromOther
+	return
+}
+
+// fetchManifest fetches the manifest desc points to. For indices we pick the manifest matching our platform,
+// or the only manifest of the index if there is only one, as is common for artifacts.
+func (oi *ociInitializer) fetchManifest(ctx context.Context, desc ociv1.Descriptor) (*ociv1.Manifest, error) {
+	for {
+		raw, err := oi.fetchJSON(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+
+		switch desc.MediaType {
+		case mediaTypeDockerManifest, ociv1.MediaTypeImageManifest:
+			var mf ociv1.Manifest
+			err = json.Unmarshal(raw, &mf)
+			if err != nil {
+				return nil, err
+			}
+			return &mf, nil
+		case mediaTypeArtifactManifest:
+			// artifact manifests list their content as blobs rather than layers
+			var amf struct {
+				Blobs []ociv1.Descriptor `json:"blobs"`
+			}
+			err = json.Unmarshal(raw, &amf)
+			if err != nil {
+				return nil, err
+			}
+			return &ociv1.Manifest{Layers: amf.Blobs}, nil
+		case mediaTypeDockerManifestList, ociv1.MediaTypeImageIndex:
+			var idx ociv1.Index
+			err = json.Unmarshal(raw, &idx)
+			if err != nil {
+				return nil, err
+			}
+			next, ok := pickManifest(idx)
+			if !ok {
+				return nil, xerrors.Errorf("index has no manifest for %s/%s", runtime.GOOS, runtime.GOARCH)
+			}
+			desc = next
+		default:
+			return nil, xerrors.Errorf("unsupported media type %s", desc.MediaType)
+		}
+	}
+}
+
+func pickManifest(idx ociv1.Index) (ociv1.Descriptor, bool) {
+	if len(idx.Manifests) == 1 {
+		return idx.Manifests[0], true
+	}
+	for _, m := range idx.Manifests {
+		if m.Platform != nil && m.Platform.OS == runtime.GOOS && m.Platform.Architecture == runtime.GOARCH {
+			return m, true
+		}
+	}
+	return ociv1.Descriptor{}, false
+}
+
+func (oi *ociInitializer) fetchJSON(ctx context.Context, desc ociv1.Descriptor) ([]byte, error) {
+	if desc.Size > maxManifestSize {
+		return nil, xerrors.Errorf("manifest is too large: %d bytes", desc.Size)
+	}
+	rc, err := oi.Resolver.Fetch(ctx, oi.Ref, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	verifier := desc.Digest.Verifier()
+	raw, err := io.ReadAll(io.TeeReader(io.LimitReader(rc, maxManifestSize), verifier))
+	if err != nil {
+		return nil, err
+	}
+	if !verifier.Verified() {
+		return nil, xerrors.Errorf("digest mismatch for %s", desc.Digest)
+	}
+	return raw, nil
+}
+
+// isLayerType returns true if mediaType denotes a (possibly compressed) tar layer
+func isLayerType(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "application/vnd.oci.image.layer.") ||
+		strings.HasPrefix(mediaType, "application/vnd.docker.image.rootfs.")
+}
+
+// unpackLayer extracts a tar layer into the target location. Layers which are no tarballs but carry a title
+// annotation, as artifacts pushed using ORAS do, are placed as file of that name instead.
+//
+// Layers are downloaded and verified against their digest before anything is placed in the workspace.
+func (oi *ociInitializer) unpackLayer(ctx context.Context, layer ociv1.Descriptor, mappings []archive.IDMapping) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "unpackLayer")
+	span.SetTag("digest", layer.Digest.String())
+	span.SetTag("mediaType", layer.MediaType)
+	defer tracing.FinishSpan(span, &err)
+
+	var fn string
+	if !isLayerType(layer.MediaType) {
+		title := layer.Annotations[ociv1.AnnotationTitle]
+		if title == "" {
+			return xerrors.Errorf("unsupported media type %s without title annotation", layer.MediaType)
+		}
+		fn = filepath.Join(oi.TargetLocation, filepath.Clean("/"+title))
+		err = mkdirAllMapped(filepath.Dir(fn), mappings)
+		if err != nil {
+			return err
+		}
+	}
+
+	tmp, err := oi.download(ctx, layer)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	if fn != "" {
+		err = os.Rename(tmp, fn)
+		if err != nil {
+			return err
+		}
+		chownMapped(fn, mappings)
+		return nil
+	}
+
+	f, err := os.Open(tmp)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return archive.ExtractTarbal(ctx, f, oi.TargetLocation, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
+}
+
+// mkdirAllMapped creates dir like os.MkdirAll and chowns the directories it creates like chownMapped does
+func mkdirAllMapped(dir string, mappings []archive.IDMapping) error {
+	var missing []string
+	for d := dir; ; d = filepath.Dir(d) {
+		_, err := os.Lstat(d)
+		if err == nil {
+			break
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+		missing = append(missing, d)
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+	for _, d := range missing {
+		chownMapped(d, mappings)
+	}
+	return nil
+}
+
+// chownMapped hands a file without owner information over to the container's root user,
+// the same way archive.ExtractTarbal maps the owners of the files it extracts.
+func chownMapped(fn string, mappings []archive.IDMapping) {
+	uid, gid := archive.ToHostID(0, mappings), archive.ToHostID(0, mappings)
+	err := os.Lchown(fn, uid, gid)
+	if err != nil {
+		log.WithError(err).WithField("uid", uid).WithField("gid", gid).WithField("path", fn).Debug("cannot chown")
+	}
+}
+
+// download fetches a layer into a temporary file in the target location and verifies its digest
+func (oi *ociInitializer) download(ctx context.Context, layer ociv1.Descriptor) (fn string, err error) {
+	rc, err := oi.Resolver.Fetch(ctx, oi.Ref, layer)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	f, err := os.CreateTemp(oi.TargetLocation, ".oci-layer-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		f.Close()
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+
+	verifier := layer.Digest.Verifier()
+	n, err := io.Copy(io.MultiWriter(f, verifier), io.LimitReader(rc, layer.Size+1))
+	if err != nil {
+		return "", err
+	}
+	if n != layer.Size || !verifier.Verified() {
+		return "", xerrors.Errorf("digest mismatch for %s", layer.Digest)
+	}
+	err = f.Chmod(0644)
+	if err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package initializer
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+func TestOCIInitializer(t *testing.T) {
+	tarLayer := func(t *testing.T, files map[string]string) []byte {
+		var buf bytes.Buffer
+		gz, err := archive.Compress(&buf, archive.CompressionGzip, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tw := tar.NewWriter(gz)
+		for name, content := range files {
+			err = tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = tw.Write([]byte(content))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := tw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := gz.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		Name          string
+		Prep          func(t *testing.T, reg *testRegistry) ociv1.Descriptor
+		Expectation   map[string]string
+		ExpectedError string
+	}{
+		{
+			Name: "image",
+			Prep: func(t *testing.T, reg *testRegistry) ociv1.Descriptor {
+				return reg.manifest(t, ociv1.MediaTypeImageManifest,
+					reg.blob(ociv1.MediaTypeImageLayerGzip, tarLayer(t, map[string]string{"data/a.txt": "a"}), nil),
+					reg.blob(ociv1.MediaTypeImageLayerGzip, tarLayer(t, map[string]string{"data/b.txt": "b"}), nil),
+				)
+			},
+			Expectation: map[string]string{"data/a.txt": "a", "data/b.txt": "b"},
+		},
+		{
+			Name: "index with a single manifest",
+			Prep: func(t *testing.T, reg *testRegistry) ociv1.Descriptor {
+				mf := reg.manifest(t, ociv1.MediaTypeImageManifest,
+					reg.blob(ociv1.MediaTypeImageLayerGzip, tarLayer(t, map[string]string{"a.txt": "a"}), nil),
+				)
+				return reg.json(t, ociv1.MediaTypeImageIndex, ociv1.Index{
+					Versioned: specs.Versioned{SchemaVersion: 2},
+					MediaType: ociv1.MediaTypeImageIndex,
+					Manifests: []ociv1.Descriptor{mf},
+				})
+			},
+			Expectation: map[string]string{"a.txt": "a"},
+		},
+		{
+			Name: "artifact files",
+			Prep: func(t *testing.T, reg *testRegistry) ociv1.Descriptor {
+				return reg.manifest(t, ociv1.MediaTypeImageManifest,
+					reg.blob("application/vnd.example.dataset", []byte("1,2,3"), map[string]string{ociv1.AnnotationTitle: "datasets/numbers.csv"}),
+					reg.blob("application/vnd.example.dataset", []byte("escaped"), map[string]string{ociv1.AnnotationTitle: "../../escaped.csv"}),
+				)
+			},
+			Expectation: map[string]string{"datasets/numbers.csv": "1,2,3", "escaped.csv": "escaped"},
+		},
+		{
+			Name: "artifact file without title",
+			Prep: func(t *testing.T, reg *testRegistry) ociv1.Descriptor {
+				return reg.manifest(t, ociv1.MediaTypeImageManifest,
+					reg.blob("application/vnd.example.dataset", []byte("1,2,3"), nil),
+				)
+			},
+			Expectation:   map[string]string{},
+			ExpectedError: "without title annotation",
+		},
+		{
+			Name: "digest mismatch",
+			Prep: func(t *testing.T, reg *testRegistry) ociv1.Descriptor {
+				layer := reg.blob(ociv1.MediaTypeImageLayerGzip, tarLayer(t, map[string]string{"a.txt": "a"}), nil)
+				reg.blobs[layer.Digest] = tarLayer(t, map[string]string{"a.txt": "tampered"})
+				return reg.manifest(t, ociv1.MediaTypeImageManifest, layer)
+			},
+			Expectation:   map[string]string{},
+			ExpectedError: "digest mismatch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			reg := &testRegistry{blobs: make(map[digest.Digest][]byte)}
+			reg.root = test.Prep(t, reg)
+
+			loc := t.TempDir()
+			init := &ociInitializer{
+				Ref:            "registry.example.com/seed:latest",
+				TargetLocation: filepath.Join(loc, "seed"),
+				Resolver:       reg,
+			}
+			_, _, err := init.Run(context.Background(), nil)
+			if test.ExpectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), test.ExpectedError) {
+					t.Fatalf("expected error containing %q, got %v", test.ExpectedError, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			act := make(map[string]string)
+			err = filepath.Walk(loc, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return err
+				}
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				rel, _ := filepath.Rel(init.TargetLocation, path)
+				act[rel] = string(content)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected workspace content (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewOCIInitializer(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Req           *csapi.OCIInitializer
+		Resolver      OCIResolver
+		ExpectedError bool
+	}{
+		{Name: "valid", Req: &csapi.OCIInitializer{Ref: "registry.example.com/seed:v1"}, Resolver: &testRegistry{}},
+		{Name: "missing reference", Req: &csapi.OCIInitializer{}, Resolver: &testRegistry{}, ExpectedError: true},
+		{Name: "missing resolver", Req: &csapi.OCIInitializer{Ref: "registry.example.com/seed:v1"}, ExpectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := newOCIInitializer("/workspace", test.Req, test.Resolver)
+			if test.ExpectedError && err == nil {
+				t.Fatal("expected an error, got nothing")
+			}
+			if !test.ExpectedError && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// testRegistry serves blobs from memory. It implements OCIResolver.
+type testRegistry struct {
+	root  ociv1.Descriptor
+	blobs map[digest.Digest][]byte
+}
+
+func (reg *testRegistry) blob(mediaType string, content []byte, annotations map[string]string) ociv1.Descriptor {
+	dgst := digest.FromBytes(content)
+	reg.blobs[dgst] = content
+	return ociv1.Descriptor{
+		MediaType:   mediaType,
+		Digest:      dgst,
+		Size:        int64(len(content)),
+		Annotations: annotations,
+	}
+}
+
+func (reg *testRegistry) json(t *testing.T, mediaType string, obj interface{}) ociv1.Descriptor {
+	content, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reg.blob(mediaType, content, nil)
+}
+
+func (reg *testRegistry) manifest(t *testing.T, mediaType string, layers ...ociv1.Descriptor) ociv1.Descriptor {
+	cfg := reg.json(t, ociv1.MediaTypeImageConfig, ociv1.Image{})
+	return reg.json(t, mediaType, ociv1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: mediaType,
+		Config:    cfg,
+		Layers:    layers,
+	})
+}
+
+func (reg *testRegistry) Resolve(ctx context.Context, ref string) (ociv1.Descriptor, error) {
+	return reg.root, nil
+}
+
+func (reg *testRegistry) Fetch(ctx context.Context, ref string, desc ociv1.Descriptor) (io.ReadCloser, error) {
+	content, ok := reg.blobs[desc.Digest]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func TestOCIInitializerTitleOwner(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("chowning files requires root")
+	}
+
+	reg := &testRegistry{blobs: make(map[digest.Digest][]byte)}
+	reg.root = reg.manifest(t, ociv1.MediaTypeImageManifest,
+		reg.blob("application/vnd.example.dataset", []byte("1,2,3"), map[string]string{ociv1.AnnotationTitle: "datasets/numbers.csv"}),
+	)
+	init := &ociInitializer{
+		Ref:            "registry.example.com/seed:latest",
+		TargetLocation: t.TempDir(),
+		Resolver:       reg,
+	}
+	mappings := []archive.IDMapping{{ContainerID: 0, HostID: GitpodUID, Size: 1}}
+	_, _, err := init.Run(context.Background(), mappings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fn := range []string{"datasets", "datasets/numbers.csv"} {
+		stat, err := os.Lstat(filepath.Join(init.TargetLocation, fn))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sys := stat.Sys().(*syscall.Stat_t)
+		if diff := cmp.Diff([2]uint32{GitpodUID, GitpodUID}, [2]uint32{sys.Uid, sys.Gid}); diff != "" {
+			t.Errorf("unexpected owner of %s (-want +got):\n%s", fn, diff)
+		}
+	}
+}
@@ -181,6 +181,10 @@ func runGitInit(ctx context.Context, gInit *GitInitializer) (commitChanged bool,
 		if err != nil {
 			log.WithError(err).Warn("error while updating submodules from prebuild initializer - continuing")
 		}
+		err = gInit.PullLFS(ctx)
+		if err != nil {
+			log.WithError(err).Warn("error while pulling LFS objects from prebuild initializer - continuing")
+		}
 
 		// If any of these cleanup operations fail that's no reason to fail ws initialization.
 		// It just results in a slightly degraded state.
@@ -52,7 +52,10 @@ type Provider struct {
 	Client  *http.Client
 }
 
-var errUnsupportedContentType = xerrors.Errorf("unsupported workspace content type")
+var (
+	errUnsupportedContentType = xerrors.Errorf("unsupported workspace content type")
+	errEncryptedContent       = xerrors.Errorf("encrypted workspace content cannot be restored from a content layer unless the content-service serves downloads")
+)
 
 func (s *Provider) downloadContentManifest(ctx context.Context, bkt, obj string) (manifest *csapi.WorkspaceContentManifest, info *storage.DownloadInfo, err error) {
 	//nolint:ineffassign
@@ -97,7 +100,11 @@ func (s *Provider) downloadContentManifest(ctx context.Context, bkt, obj string)
 	}
 	defer mfresp.Body.Close()
 
-	mfr, err := io.ReadAll(mfresp.Body)
+	mfsrc, err := storage.Decrypt(mfresp.Body, info.DataKey)
+	if err != nil {
+		return
+	}
+	mfr, err := io.ReadAll(mfsrc)
 	if err != nil {
 		return
 	}
@@ -117,6 +124,151 @@ func (s *Provider) downloadContentManifest(ctx context.Context, bkt, obj string)
 	return
 }
 
+// chunkedBackupContentDescriptor produces a content descriptor which restores the chunked backup of a workspace.
+// Returns storage.ErrNotFound if the workspace has no chunked backup.
+func (s *Provider) chunkedBackupContentDescriptor(ctx context.Context, owner, workspaceID string) (cdesc []byte, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "chunkedBackupContentDescriptor")
+	defer func() {
+		lerr := err
+		if lerr == storage.ErrNotFound {
+			span.LogKV("found", false)
+			lerr = nil
+		}
+		tracing.FinishSpan(span, &lerr)
+	}()
+
+	urls := make(map[string]string)
+	err = s.signChunkedBackup(ctx, owner, workspaceID, storage.DefaultChunkedBackupManifest, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	return executor.PrepareFromChunkedBackup(urls)
+}
+
+// backupVersionContentDescriptor produces a content descriptor which restores a previous backup from the backup history of a workspace
+func (s *Provider) backupVersionContentDescriptor(ctx context.Context, owner, workspaceID string, initializer *csapi.WorkspaceInitializer) (cdesc []byte, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "backupVersionContentDescriptor")
+	defer tracing.FinishSpan(span, &err)
+
+	backupID := initializer.GetBackup().GetBackupId()
+	span.SetTag("backupID", backupID)
+
+	bucket := s.Storage.Bucket(owner)
+	info, err := s.Storage.SignDownload(ctx, bucket, s.Storage.BackupObject(owner, workspaceID, storage.BackupHistoryManifest), &storage.SignedURLOptions{})
+	if err != nil {
+		return nil, xerrors.Errorf("cannot find backup history: %w", err)
+	}
+	var history csapi.BackupHistory
+	err = s.downloadJSON(ctx, info, &history)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot download backup history: %w", err)
+	}
+	version, ok := history.Find(backupID)
+	if !ok {
+		return nil, xerrors.Errorf("backup %s is not part of the backup history", backupID)
+	}
+
+	historyURL, err := contentLayerURL(info)
+	if err != nil {
+		return nil, err
+	}
+	urls := map[string]string{
+		storage.BackupHistoryManifest: historyURL,
+	}
+	if version.Chunked {
+		err = s.signChunkedBackup(ctx, owner, workspaceID, version.Object, urls)
+	} else {
+		var vinfo *storage.DownloadInfo
+		vinfo, err = s.Storage.SignDownload(ctx, bucket, s.Storage.BackupObject(owner, workspaceID, version.Object), &storage.SignedURLOptions{})
+		if err == nil {
+			urls[version.Object], err = contentLayerURL(vinfo)
+		}
+	}
+	if err != nil {
+		// the history references this backup, hence we must not pass on ErrNotFound
+		return nil, xerrors.Errorf("cannot sign download of backup %s: %v", backupID, err)
+	}
+
+	return executor.Prepare(initializer, urls)
+}
+
+// signChunkedBackup adds the chunk manifest and all chunks it references to the URLs.
+// Returns storage.ErrNotFound if the chunk manifest does not exist.
+func (s *Provider) signChunkedBackup(ctx context.Context, owner, workspaceID, manifest string, urls map[string]string) error {
+	bucket := s.Storage.Bucket(owner)
+	info, err := s.Storage.SignDownload(ctx, bucket, s.Storage.BackupObject(owner, workspaceID, manifest), &storage.SignedURLOptions{})
+	if err != nil {
+		return err
+	}
+
+	var mf csapi.ChunkedBackupManifest
+	err = s.downloadJSON(ctx, info, &mf)
+	if err != nil {
+		return xerrors.Errorf("cannot download chunked backup manifest: %w", err)
+	}
+
+	urls[manifest], err = contentLayerURL(info)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, c := range mf.Chunks {
+		name := storage.BackupChunkName(c.Digest)
+		if _, exists := urls[name]; exists {
+			continue
+		}
+		urls[name] = ""
+		names = append(names, name)
+	}
+
+	objs := make([]string, len(names))
+	for i, name := range names {
+		objs[i] = s.Storage.BackupObject(owner, workspaceID, name)
+	}
+	infos, err := storage.SignDownloads(ctx, s.Storage, bucket, objs, &storage.SignedURLOptions{})
+	if err != nil {
+		// a missing chunk means the backup is broken, not that there is no backup - hence we must not wrap ErrNotFound
+		return xerrors.Errorf("cannot sign download of backup chunks: %v", err)
+	}
+	for i, name := range names {
+		urls[name], err = contentLayerURL(infos[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// downloadJSON downloads, decrypts and unmarshals a JSON object
+func (s *Provider) downloadJSON(ctx context.Context, info *storage.DownloadInfo, dst interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", info.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return xerrors.Errorf("cannot get %s: status %d", info.URL, resp.StatusCode)
+	}
+
+	src, err := storage.Decrypt(resp.Body, info.DataKey)
+	if err != nil {
+		return xerrors.Errorf("cannot decrypt: %w", err)
+	}
+	err = json.NewDecoder(src).Decode(dst)
+	if err != nil {
+		return xerrors.Errorf("cannot unmarshal: %w", err)
+	}
+	return nil
+}
+
 // GetContentLayer provides the content layer for a workspace
 func (s *Provider) GetContentLayer(ctx context.Context, owner, workspaceID string, initializer *csapi.WorkspaceInitializer) (l []Layer, manifest *csapi.WorkspaceContentManifest, err error) {
 	span, ctx := tracing.FromContext(ctx, "GetContentLayer")
@@ -132,6 +284,21 @@ func (s *Provider) GetContentLayer(ctx context.Context, owner, workspaceID strin
 		}
 	}()
 
+	// a specific backup version takes precedence over the latest backup
+	if initializer.GetBackup().GetBackupId() != "" {
+		span.LogKV("restoring", "backup version")
+
+		cdesc, err := s.backupVersionContentDescriptor(ctx, owner, workspaceID, initializer)
+		if err != nil {
+			return nil, nil, err
+		}
+		layer, err := contentDescriptorToLayer(cdesc)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []Layer{*layer}, nil, nil
+	}
+
 	// check if workspace has an FWB
 	var (
 		bucket = s.Storage.Bucket(owner)
@@ -149,8 +316,31 @@ func (s *Provider) GetContentLayer(ctx context.Context, owner, workspaceID strin
 		return l, manifest, err
 	}
 
-	// check if legacy workspace backup is present
+	// check if a chunked workspace backup is present, which takes precedence over the legacy backup unless that one is newer
 	var layer *Layer
+	latest, err := storage.LatestBackup(ctx, s.Storage, bucket, s.Storage.BackupObject(owner, workspaceID, storage.DefaultBackup), s.Storage.BackupObject(owner, workspaceID, storage.DefaultChunkedBackupManifest))
+	if err != nil {
+		return nil, nil, err
+	}
+	if latest == storage.DefaultChunkedBackupManifest {
+		cdesc, err := s.chunkedBackupContentDescriptor(ctx, owner, workspaceID)
+		if err != nil && !xerrors.Is(err, storage.ErrNotFound) {
+			return nil, nil, err
+		}
+		if err == nil {
+			span.LogKV("backup found", "chunked workspace backup")
+
+			layer, err = contentDescriptorToLayer(cdesc)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			l = []Layer{*layer}
+			return l, manifest, nil
+		}
+	}
+
+	// check if legacy workspace backup is present
 	info, err := s.Storage.SignDownload(ctx, bucket, fmt.Sprintf(fmtLegacyBackupName, workspaceID), &storage.SignedURLOptions{})
 	if err != nil && !xerrors.Is(err, storage.ErrNotFound) {
 		return nil, nil, err
@@ -158,7 +348,11 @@ func (s *Provider) GetContentLayer(ctx context.Context, owner, workspaceID strin
 	if err == nil {
 		span.LogKV("backup found", "legacy workspace backup")
 
-		cdesc, err := executor.PrepareFromBackup(info.URL)
+		url, err := contentLayerURL(info)
+		if err != nil {
+			return nil, nil, err
+		}
+		cdesc, err := executor.PrepareFromBackup(url)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -255,7 +449,11 @@ func (s *Provider) GetContentLayerPVC(ctx context.Context, owner, workspaceID st
 	if err == nil {
 		span.LogKV("backup found", "legacy workspace backup")
 
-		cdesc, err := executor.PrepareFromBackup(info.URL)
+		url, err := contentLayerURL(info)
+		if err != nil {
+			return nil, nil, err
+		}
+		cdesc, err := executor.PrepareFromBackup(url)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -392,8 +590,12 @@ func (s *Provider) getSnapshotContentLayer(ctx context.Context, sp *csapi.Snapsh
 
 	if manifest == nil {
 		// we've found a legacy snapshot
+		url, err := contentLayerURL(info)
+		if err != nil {
+			return nil, nil, err
+		}
 		cdesc, err := executor.Prepare(&csapi.WorkspaceInitializer{Spec: &csapi.WorkspaceInitializer_Snapshot{Snapshot: sp}}, map[string]string{
-			sp.Snapshot: info.URL,
+			sp.Snapshot: url,
 		})
 		if err != nil {
 			return nil, nil, err
@@ -437,8 +639,13 @@ func (s *Provider) getPrebuildContentLayer(ctx context.Context, pb *csapi.Prebui
 	var cdesc []byte
 	if manifest == nil {
 		// legacy prebuild - resort to in-workspace content init
+		var url string
+		url, err = contentLayerURL(info)
+		if err != nil {
+			return nil, nil, err
+		}
 		cdesc, err = executor.Prepare(&csapi.WorkspaceInitializer{Spec: &csapi.WorkspaceInitializer_Prebuild{Prebuild: pb}}, map[string]string{
-			pb.Prebuild.Snapshot: info.URL,
+			pb.Prebuild.Snapshot: url,
 		})
 		if err != nil {
 			return nil, nil, err
@@ -478,6 +685,19 @@ func (s *Provider) getPrebuildContentLayer(ctx context.Context, pb *csapi.Prebui
 	return l, manifest, nil
 }
 
+// contentLayerURL returns the URL a content descriptor downloads an object from.
+// Content descriptors end up in the workspace image and hence must never carry data keys.
+// Encrypted objects are downloaded through the content-service, which decrypts them.
+func contentLayerURL(info *storage.DownloadInfo) (string, error) {
+	if info.DataKey == nil {
+		return info.URL, nil
+	}
+	if info.DecryptedURL == "" {
+		return "", errEncryptedContent
+	}
+	return info.DecryptedURL, nil
+}
+
 func (s *Provider) layerFromContentManifest(ctx context.Context, mf *csapi.WorkspaceContentManifest, initsrc csapi.WorkspaceInitSource, ready bool) (l []Layer, err error) {
 	// we have a valid full workspace backup
 	l = make([]Layer, len(mf.Layers))
@@ -486,6 +706,10 @@ func (s *Provider) layerFromContentManifest(ctx context.Context, mf *csapi.Works
 		if err != nil {
 			return nil, err
 		}
+		if info.DataKey != nil {
+			// the registry-facade serves the layer straight from the storage URL and cannot decrypt it
+			return nil, xerrors.Errorf("cannot serve encrypted layer %s/%s", mfl.Bucket, mfl.Object)
+		}
 		if info.Meta.Digest != mfl.Digest.String() {
 			return nil, xerrors.Errorf("digest mismatch for %s/%s: expected %s, got %s", mfl.Bucket, mfl.Object, mfl.Digest, info.Meta.Digest)
 		}
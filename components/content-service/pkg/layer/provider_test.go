@@ -7,6 +7,7 @@ package layer
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"flag"
@@ -14,7 +15,9 @@ import (
 	"io"
 	"io/fs"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -23,6 +26,7 @@ import (
 	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
 
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/api/config"
 	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 )
 
@@ -42,6 +46,8 @@ func TestGetContentLayer(t *testing.T) {
 		ContentManifestType string
 		ContentManifest     *csapi.WorkspaceContentManifest
 		Backup              *storage.DownloadInfo
+		ChunkedBackup       *csapi.ChunkedBackupManifest
+		BackupHistory       *csapi.BackupHistory
 		Initializer         *csapi.WorkspaceInitializer
 	}{
 		{
@@ -66,6 +72,51 @@ func TestGetContentLayer(t *testing.T) {
 				URL: "https://somewhere-else.com/backup.tar",
 			},
 		},
+		{
+			Name: "chunked backup",
+			Backup: &storage.DownloadInfo{
+				URL: "https://somewhere-else.com/backup.tar",
+			},
+			ChunkedBackup: &csapi.ChunkedBackupManifest{
+				Digest: digest.NewDigestFromHex("sha256", "606c898987d799dd1fed7e39fa59c2adfd6fb1a4635a060ba6fab00f86bc050d"),
+				Size:   3072,
+				Chunks: []csapi.BackupChunk{
+					{Digest: digest.NewDigestFromHex("sha256", "0263829989b6fd954f72baaf2fc64bc2e2f01d692d4de72986ea808f6e99813f"), Size: 1024},
+					{Digest: digest.NewDigestFromHex("sha256", "a8ed3b2fc6a6c42d2fbc5c4e2b7b8b5f6e1a0a4a58f1e1f7b7d5c1e2b0a3c4d5"), Size: 1024},
+					{Digest: digest.NewDigestFromHex("sha256", "0263829989b6fd954f72baaf2fc64bc2e2f01d692d4de72986ea808f6e99813f"), Size: 1024},
+				},
+			},
+		},
+		{
+			Name: "backup version",
+			Backup: &storage.DownloadInfo{
+				URL: "https://somewhere-else.com/backup.tar",
+			},
+			BackupHistory: &csapi.BackupHistory{
+				Backups: []csapi.BackupVersion{
+					{ID: "latest", Object: storage.BackupVersionName(1, false), Size: 2048},
+					{ID: "previous", Object: storage.BackupVersionName(0, false), Size: 1024},
+				},
+			},
+			Initializer: &csapi.WorkspaceInitializer{
+				Spec: &csapi.WorkspaceInitializer_Backup{
+					Backup: &csapi.FromBackupInitializer{BackupId: "previous"},
+				},
+			},
+		},
+		{
+			Name: "unknown backup version",
+			BackupHistory: &csapi.BackupHistory{
+				Backups: []csapi.BackupVersion{
+					{ID: "latest", Object: storage.BackupVersionName(0, false), Size: 1024},
+				},
+			},
+			Initializer: &csapi.WorkspaceInitializer{
+				Spec: &csapi.WorkspaceInitializer_Backup{
+					Backup: &csapi.FromBackupInitializer{BackupId: "previous"},
+				},
+			},
+		},
 		{
 			Name:                "full workspace backup",
 			ContentManifestType: csapi.ContentTypeManifest,
@@ -123,8 +174,42 @@ func TestGetContentLayer(t *testing.T) {
 			if test.Backup != nil {
 				objs[fmt.Sprintf(fmtLegacyBackupName, workspaceID)] = test.Backup
 			}
-
 			s := &testStorage{Objs: objs}
+
+			var cmf []byte
+			if test.ChunkedBackup != nil {
+				cmf, err = json.Marshal(test.ChunkedBackup)
+				if err != nil {
+					t.Fatal(err)
+				}
+				objs[s.BackupObject(ownerID, workspaceID, storage.DefaultChunkedBackupManifest)] = &storage.DownloadInfo{
+					URL: "http://chunked-backup-manifest",
+				}
+				for _, c := range test.ChunkedBackup.Chunks {
+					obj := s.BackupObject(ownerID, workspaceID, storage.BackupChunkName(c.Digest))
+					objs[obj] = &storage.DownloadInfo{
+						URL: "http://some-storage-system/" + obj,
+					}
+				}
+			}
+
+			var history []byte
+			if test.BackupHistory != nil {
+				history, err = json.Marshal(test.BackupHistory)
+				if err != nil {
+					t.Fatal(err)
+				}
+				objs[s.BackupObject(ownerID, workspaceID, storage.BackupHistoryManifest)] = &storage.DownloadInfo{
+					URL: "http://backup-history",
+				}
+				for _, v := range test.BackupHistory.Backups {
+					obj := s.BackupObject(ownerID, workspaceID, v.Object)
+					objs[obj] = &storage.DownloadInfo{
+						URL: "http://some-storage-system/" + obj,
+					}
+				}
+			}
+
 			p := &Provider{
 				Storage: s,
 				Client: &http.Client{
@@ -136,6 +221,18 @@ func TestGetContentLayer(t *testing.T) {
 								Header:     make(http.Header),
 								Body:       io.NopCloser(bytes.NewReader(mf)),
 							}
+						case "http://chunked-backup-manifest":
+							return &http.Response{
+								StatusCode: http.StatusOK,
+								Header:     make(http.Header),
+								Body:       io.NopCloser(bytes.NewReader(cmf)),
+							}
+						case "http://backup-history":
+							return &http.Response{
+								StatusCode: http.StatusOK,
+								Header:     make(http.Header),
+								Body:       io.NopCloser(bytes.NewReader(history)),
+							}
 						default:
 							return &http.Response{
 								StatusCode: http.StatusNotFound,
@@ -205,6 +302,168 @@ func TestGetContentLayer(t *testing.T) {
 	}
 }
 
+func TestEncryptedWorkspaceContent(t *testing.T) {
+	var handler http.Handler
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	var (
+		tmpdir     = t.TempDir()
+		keyFile    = filepath.Join(tmpdir, "signing-key")
+		keyring    = filepath.Join(tmpdir, "keyring.json")
+		layerFile  = filepath.Join(tmpdir, "layer.tar")
+		layerBytes = []byte("full workspace backup layer")
+		layerDgst  = digest.FromBytes(layerBytes)
+	)
+	for fn, content := range map[string][]byte{
+		keyFile:   []byte("secret\n"),
+		keyring:   []byte(`{"current": "k1", "keys": {"k1": "` + base64.StdEncoding.EncodeToString(make([]byte, 32)) + `"}}`),
+		layerFile: layerBytes,
+	} {
+		err := os.WriteFile(fn, content, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := &config.StorageConfig{
+		Kind: config.LocalStorage,
+		LocalConfig: &config.LocalConfig{
+			Directory:      filepath.Join(tmpdir, "storage"),
+			URL:            srv.URL,
+			SigningKeyFile: keyFile,
+		},
+		Encryption: &config.EncryptionConfig{
+			KMS:  config.FileKMS,
+			File: &config.FileKMSConfig{KeyringFile: keyring},
+		},
+	}
+	var err error
+	handler, err = storage.NewLocalStorageHandler(*cfg.LocalConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	upload := func(workspaceID string, f func(da storage.DirectAccess) error) {
+		da, err := storage.NewDirectAccess(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = da.Init(ctx, ownerID, workspaceID, "instance")
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = da.EnsureExists(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = f(da)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// full workspace backups are served straight from the storage and hence must remain restorable
+	upload("fwb", func(da storage.DirectAccess) error {
+		bkt, obj, err := da.Upload(ctx, layerFile, fmt.Sprintf(storage.FmtFullWorkspaceBackup, 1), storage.WithAnnotations(map[string]string{
+			storage.ObjectAnnotationDigest: layerDgst.String(),
+		}))
+		if err != nil {
+			return err
+		}
+		mf, err := json.Marshal(csapi.WorkspaceContentManifest{
+			Type: csapi.TypeFullWorkspaceContentV1,
+			Layers: []csapi.WorkspaceContentLayer{{
+				Descriptor: ociv1.Descriptor{
+					MediaType: csapi.MediaTypeUncompressedLayer,
+					Digest:    layerDgst,
+					Size:      int64(len(layerBytes)),
+				},
+				Bucket: bkt,
+				Object: obj,
+				DiffID: layerDgst,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+		_, _, err = da.UploadStream(ctx, bytes.NewReader(mf), storage.DefaultBackupManifest, storage.WithContentType(csapi.ContentTypeManifest))
+		return err
+	})
+	// content layers end up in the workspace image and hence must not carry data keys
+	upload("legacy", func(da storage.DirectAccess) error {
+		_, _, err := da.Upload(ctx, layerFile, storage.DefaultBackup)
+		return err
+	})
+
+	p, err := NewProvider(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, _, err := p.GetContentLayer(ctx, ownerID, "fwb", &csapi.WorkspaceInitializer{})
+	if err != nil {
+		t.Fatalf("cannot get content layer of full workspace backup: %v", err)
+	}
+	if len(l) == 0 || l[0].Digest != layerDgst.String() {
+		t.Fatalf("unexpected layers: %v", l)
+	}
+	resp, err := http.Get(l[0].URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	served, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act := digest.FromBytes(served); act != layerDgst {
+		t.Errorf("served layer does not match its digest: expected %s, got %s", layerDgst, act)
+	}
+
+	_, _, err = p.GetContentLayer(ctx, ownerID, "legacy", &csapi.WorkspaceInitializer{})
+	if !errors.Is(err, errEncryptedContent) {
+		t.Errorf("expected errEncryptedContent for encrypted backup, got %v", err)
+	}
+
+	// encrypted content is restorable if the content-service serves its plaintext
+	cfg.Download = &config.DownloadConfig{URL: srv.URL, SigningKeyFile: keyFile}
+	p, err = NewProvider(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = p.GetContentLayer(ctx, ownerID, "legacy", &csapi.WorkspaceInitializer{})
+	if err != nil {
+		t.Errorf("cannot get content layer of encrypted backup: %v", err)
+	}
+}
+
+func TestContentLayerURL(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Info          storage.DownloadInfo
+		Expectation   string
+		ExpectedError error
+	}{
+		{Name: "plaintext", Info: storage.DownloadInfo{URL: "http://storage/obj"}, Expectation: "http://storage/obj"},
+		{Name: "encrypted", Info: storage.DownloadInfo{URL: "http://storage/obj", DataKey: []byte("key"), DecryptedURL: "http://content-service/obj"}, Expectation: "http://content-service/obj"},
+		{Name: "encrypted without downloads", Info: storage.DownloadInfo{URL: "http://storage/obj", DataKey: []byte("key")}, ExpectedError: errEncryptedContent},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := contentLayerURL(&test.Info)
+			if !errors.Is(err, test.ExpectedError) {
+				t.Fatalf("unexpected error: want %v, got %v", test.ExpectedError, err)
+			}
+			if act != test.Expectation {
+				t.Errorf("unexpected URL: want %s, got %s", test.Expectation, act)
+			}
+		})
+	}
+}
+
 type testStorage struct {
 	Objs map[string]*storage.DownloadInfo
 }
@@ -247,7 +506,7 @@ func (s *testStorage) DeleteBucket(ctx context.Context, userID, bucket string) e
 }
 
 func (*testStorage) BackupObject(ownerID string, workspaceID string, name string) string {
-	return ""
+	return fmt.Sprintf("workspaces/%s/%s", workspaceID, name)
 }
 
 func (*testStorage) InstanceObject(ownerID string, workspaceID string, instanceID string, name string) string {
@@ -0,0 +1,91 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package retention
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+	"gorm.io/gorm"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+)
+
+// References are the workspace content which is still in use and must not be deleted
+type References struct {
+	// Workspaces are the IDs of workspaces with running instances or which back a prebuild.
+	// None of their content is deleted.
+	Workspaces []string `json:"workspaces"`
+
+	// Snapshots are qualified snapshot names (<object>@<bucket>) referenced by prebuilds
+	Snapshots []string `json:"snapshots"`
+}
+
+// ReferenceSource provides the references which protect content from deletion
+type ReferenceSource interface {
+	// References returns the current references. Nothing must be deleted if this returns an error.
+	References(ctx context.Context) (*References, error)
+}
+
+// DBReferenceSource queries the references from the database
+type DBReferenceSource struct {
+	Conn *gorm.DB
+}
+
+// References lists the workspaces with instances that have not stopped yet, and the prebuilds new workspaces can start from
+func (d DBReferenceSource) References(ctx context.Context) (*References, error) {
+	running, err := db.ListNotStoppedWorkspaceIDs(ctx, d.Conn)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list running workspaces: %w", err)
+	}
+	prebuilds, err := db.FindAvailablePrebuiltWorkspaces(ctx, d.Conn)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list prebuilds: %w", err)
+	}
+
+	res := &References{Workspaces: running}
+	for _, pb := range prebuilds {
+		res.Workspaces = append(res.Workspaces, pb.BuildWorkspaceID)
+		res.Snapshots = append(res.Snapshots, pb.Snapshot)
+	}
+	return res, nil
+}
+
+// protection answers whether an object is referenced
+type protection struct {
+	workspaces map[string]struct{}
+	snapshots  map[string]struct{}
+
+	// objects are the <bucket>/<object> names of layers content manifests reference
+	objects map[string]struct{}
+}
+
+func newProtection(refs *References) *protection {
+	res := &protection{
+		workspaces: make(map[string]struct{}, len(refs.Workspaces)),
+		snapshots:  make(map[string]struct{}, len(refs.Snapshots)),
+		objects:    make(map[string]struct{}),
+	}
+	for _, ws := range refs.Workspaces {
+		res.workspaces[ws] = struct{}{}
+	}
+	for _, s := range refs.Snapshots {
+		res.snapshots[s] = struct{}{}
+	}
+	return res
+}
+
+func (p *protection) IsProtected(bucket string, obj classifiedObject) bool {
+	if _, ok := p.workspaces[obj.WorkspaceID]; ok && obj.WorkspaceID != "" {
+		return true
+	}
+	if _, ok := p.snapshots[obj.Name+"@"+bucket]; ok {
+		return true
+	}
+	if _, ok := p.objects[bucket+"/"+obj.Name]; ok {
+		return true
+	}
+	return false
+}
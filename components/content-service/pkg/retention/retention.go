@@ -0,0 +1,472 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package retention
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/logs"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+var (
+	// workspaceObjectRegex matches all objects of a workspace, no matter if the bucket is shared between users
+	workspaceObjectRegex = regexp.MustCompile(`^(?:.+/)?workspaces/([^/]+)/(.+)$`)
+	backupRegex          = regexp.MustCompile(`^wsfull-\d+\.tar$`)
+	snapshotRegex        = regexp.MustCompile(`^snapshot-\d+\.tar$`)
+	prebuildLogRegex     = regexp.MustCompile(`^instances/[^/]+/` + regexp.QuoteMeta(logs.UploadedHeadlessLogPathPrefix) + `/`)
+	chunkRegex           = regexp.MustCompile(`^` + regexp.QuoteMeta(storage.BackupChunkPrefix) + `[^/]+/[^/]+$`)
+	chunkManifestRegex   = regexp.MustCompile(`^(?:` + regexp.QuoteMeta(storage.DefaultChunkedBackupManifest) + `|` + regexp.QuoteMeta(storage.BackupVersionPrefix) + `\d+\.chunks\.json)$`)
+	contentManifestRegex = regexp.MustCompile(`^(?:` + regexp.QuoteMeta(storage.DefaultBackupManifest) + `|snapshot-\d+\.mf\.json)$`)
+)
+
+// Engine finds objects which violate the retention policies and deletes them
+type Engine struct {
+	Config     config.RetentionConfig
+	References ReferenceSource
+
+	storage storage.PresignedAccess
+	lister  storage.ObjectLister
+	client  *http.Client
+
+	// mu prevents concurrent sweeps
+	mu sync.Mutex
+}
+
+// NewEngine creates a new retention engine. The storage must implement storage.ObjectLister.
+func NewEngine(s storage.PresignedAccess, refs ReferenceSource, cfg config.RetentionConfig) (*Engine, error) {
+	err := validateConfig(cfg)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid retention config: %w", err)
+	}
+	lister, ok := s.(storage.ObjectLister)
+	if !ok {
+		return nil, xerrors.Errorf("storage does not support listing objects")
+	}
+
+	return &Engine{
+		Config:     cfg,
+		References: refs,
+		storage:    s,
+		lister:     lister,
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func validateConfig(cfg config.RetentionConfig) error {
+	for class, policy := range cfg.Policies {
+		switch class {
+		case config.ObjectClassBackup, config.ObjectClassSnapshot, config.ObjectClassPrebuildLog:
+		case config.ObjectClassIDEPlugin:
+			if cfg.IDEPluginBucket == "" {
+				return xerrors.Errorf("idePluginBucket is required for the %s policy", class)
+			}
+		case config.ObjectClassBackupChunk:
+			if policy.KeepLatest != 0 || policy.MaxAge <= 0 {
+				return xerrors.Errorf("the %s policy supports maxAge only, which is required", class)
+			}
+		default:
+			return xerrors.Errorf("unknown object class: %s", class)
+		}
+		if policy.KeepLatest < 0 || policy.MaxAge < 0 {
+			return xerrors.Errorf("%s policy must not be negative", class)
+		}
+	}
+	return nil
+}
+
+// Report lists the objects which violate the retention policies
+type Report struct {
+	Candidates []Candidate
+
+	// Protected is the number of objects which violate a policy but are still referenced
+	Protected int
+}
+
+// TotalSize is the size of all candidates in bytes
+func (r *Report) TotalSize() (size int64) {
+	for _, c := range r.Candidates {
+		size += c.Size
+	}
+	return size
+}
+
+// Candidate is an object which violates its retention policy
+type Candidate struct {
+	storage.ObjectInfo
+
+	Bucket string
+	Class  config.ObjectClass
+	Reason string
+}
+
+type classifiedObject struct {
+	storage.ObjectInfo
+
+	Class       config.ObjectClass
+	WorkspaceID string
+
+	// Plugin identifies the IDE plugin an object in the IDE plugin bucket belongs to
+	Plugin string
+}
+
+// pluginName identifies the IDE plugin an upload belongs to. All uploads of a plugin share a directory,
+// while objects at the root of the bucket are plugins of their own.
+func pluginName(obj string) string {
+	if !strings.Contains(obj, "/") {
+		return obj
+	}
+	return path.Dir(obj)
+}
+
+// classify determines the class of workspace objects. Objects which are not subject to retention,
+// e.g. the regular backup of a workspace, are not classified.
+func classify(obj storage.ObjectInfo) (res classifiedObject, ok bool) {
+	segs := workspaceObjectRegex.FindStringSubmatch(obj.Name)
+	if segs == nil {
+		return res, false
+	}
+
+	res = classifiedObject{ObjectInfo: obj, WorkspaceID: segs[1]}
+	switch name := segs[2]; {
+	case backupRegex.MatchString(name):
+		res.Class = config.ObjectClassBackup
+	case snapshotRegex.MatchString(name):
+		res.Class = config.ObjectClassSnapshot
+	case prebuildLogRegex.MatchString(name):
+		res.Class = config.ObjectClassPrebuildLog
+	default:
+		return res, false
+	}
+	return res, true
+}
+
+// Plan lists the objects a sweep would delete without deleting anything
+func (e *Engine) Plan(ctx context.Context) (report *Report, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "retention.Plan")
+	defer tracing.FinishSpan(span, &err)
+
+	refs, err := e.References.References(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prot := newProtection(refs)
+	now := time.Now()
+
+	buckets, err := e.lister.ListBuckets(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list buckets: %w", err)
+	}
+	listing := make(map[string][]storage.ObjectInfo, len(buckets))
+	for _, bkt := range buckets {
+		if bkt == e.Config.IDEPluginBucket {
+			continue
+		}
+
+		objs, err := e.lister.ListObjects(ctx, bkt, "")
+		if err != nil {
+			return nil, xerrors.Errorf("cannot list objects in %s: %w", bkt, err)
+		}
+		listing[bkt] = objs
+	}
+
+	// content manifests can reference layers in other buckets, hence we must know all of them before we evaluate any bucket
+	for bkt, objs := range listing {
+		e.protectManifestLayers(ctx, bkt, objs, prot)
+	}
+
+	report = &Report{}
+	for bkt, objs := range listing {
+		var classified []classifiedObject
+		for _, obj := range objs {
+			c, ok := classify(obj)
+			if !ok {
+				continue
+			}
+			if _, ok := e.Config.Policies[c.Class]; !ok {
+				continue
+			}
+			classified = append(classified, c)
+		}
+		e.evaluate(now, bkt, classified, prot, report)
+		if _, ok := e.Config.Policies[config.ObjectClassBackupChunk]; ok {
+			e.evaluateChunks(ctx, now, bkt, objs, prot, report)
+		}
+	}
+
+	if _, ok := e.Config.Policies[config.ObjectClassIDEPlugin]; ok {
+		objs, err := e.lister.ListObjects(ctx, e.Config.IDEPluginBucket, "")
+		if err != nil {
+			return nil, xerrors.Errorf("cannot list IDE plugins: %w", err)
+		}
+		classified := make([]classifiedObject, 0, len(objs))
+		for _, obj := range objs {
+			classified = append(classified, classifiedObject{ObjectInfo: obj, Class: config.ObjectClassIDEPlugin, Plugin: pluginName(obj.Name)})
+		}
+		e.evaluate(now, e.Config.IDEPluginBucket, classified, prot, report)
+	}
+
+	sort.Slice(report.Candidates, func(i, j int) bool {
+		ci, cj := report.Candidates[i], report.Candidates[j]
+		if ci.Bucket != cj.Bucket {
+			return ci.Bucket < cj.Bucket
+		}
+		return ci.Name < cj.Name
+	})
+	return report, nil
+}
+
+// protectManifestLayers protects the layers the content manifests of full workspace backups and snapshots reference,
+// e.g. the wsfull-*.tar a workspace is restored from. Workspaces whose manifests cannot be read keep all their content.
+func (e *Engine) protectManifestLayers(ctx context.Context, bucket string, objs []storage.ObjectInfo, prot *protection) {
+	for _, obj := range objs {
+		segs := workspaceObjectRegex.FindStringSubmatch(obj.Name)
+		if segs == nil || !contentManifestRegex.MatchString(segs[2]) {
+			continue
+		}
+
+		var mf csapi.WorkspaceContentManifest
+		err := e.downloadJSON(ctx, bucket, obj.Name, &mf)
+		if err != nil {
+			log.WithError(err).WithField("bucket", bucket).WithField("object", obj.Name).Warn("cannot read content manifest, keeping all content of the workspace")
+			prot.workspaces[segs[1]] = struct{}{}
+			continue
+		}
+		for _, l := range mf.Layers {
+			prot.objects[l.Bucket+"/"+l.Object] = struct{}{}
+		}
+	}
+}
+
+// evaluate applies the policies to all objects of a bucket and adds those which violate them to the report
+func (e *Engine) evaluate(now time.Time, bucket string, objs []classifiedObject, prot *protection, report *Report) {
+	type groupKey struct {
+		Class       config.ObjectClass
+		WorkspaceID string
+		Plugin      string
+	}
+	groups := make(map[groupKey][]classifiedObject)
+	for _, obj := range objs {
+		key := groupKey{Class: obj.Class, WorkspaceID: obj.WorkspaceID, Plugin: obj.Plugin}
+		groups[key] = append(groups[key], obj)
+	}
+
+	for key, group := range groups {
+		policy := e.Config.Policies[key.Class]
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].LastModified.After(group[j].LastModified)
+		})
+
+		for i, obj := range group {
+			var reason string
+			switch {
+			case policy.KeepLatest > 0 && i >= policy.KeepLatest:
+				reason = fmt.Sprintf("not among the %d latest", policy.KeepLatest)
+			case policy.MaxAge > 0 && now.Sub(obj.LastModified) > time.Duration(policy.MaxAge):
+				reason = fmt.Sprintf("older than %s", time.Duration(policy.MaxAge))
+			default:
+				continue
+			}
+
+			if prot.IsProtected(bucket, obj) {
+				report.Protected++
+				continue
+			}
+			report.Candidates = append(report.Candidates, Candidate{
+				ObjectInfo: obj.ObjectInfo,
+				Bucket:     bucket,
+				Class:      obj.Class,
+				Reason:     reason,
+			})
+		}
+	}
+}
+
+// evaluateChunks adds the backup chunks which none of the chunk manifests of their workspace references to the report.
+// Workspaces whose manifests cannot be read keep all their chunks.
+//
+// Backups reuse the chunks which are present in the storage already, no matter whether a manifest references them.
+// Such chunks are not modified by the backup, hence a chunk's age alone does not tell whether a backup which is being
+// uploaded needs it. Workspaces which have written chunks or chunk manifests within maxAge keep all their chunks.
+func (e *Engine) evaluateChunks(ctx context.Context, now time.Time, bucket string, objs []storage.ObjectInfo, prot *protection, report *Report) {
+	type chunk struct {
+		classifiedObject
+		Name string
+	}
+	var (
+		chunks       = make(map[string][]chunk)
+		manifests    = make(map[string][]string)
+		lastModified = make(map[string]time.Time)
+	)
+	for _, obj := range objs {
+		segs := workspaceObjectRegex.FindStringSubmatch(obj.Name)
+		if segs == nil {
+			continue
+		}
+		wsid, name := segs[1], segs[2]
+		switch {
+		case chunkRegex.MatchString(name):
+			chunks[wsid] = append(chunks[wsid], chunk{
+				classifiedObject: classifiedObject{ObjectInfo: obj, Class: config.ObjectClassBackupChunk, WorkspaceID: wsid},
+				Name:             name,
+			})
+		case chunkManifestRegex.MatchString(name):
+			manifests[wsid] = append(manifests[wsid], obj.Name)
+		default:
+			continue
+		}
+		if obj.LastModified.After(lastModified[wsid]) {
+			lastModified[wsid] = obj.LastModified
+		}
+	}
+
+	maxAge := time.Duration(e.Config.Policies[config.ObjectClassBackupChunk].MaxAge)
+	for wsid, wschunks := range chunks {
+		if now.Sub(lastModified[wsid]) <= maxAge {
+			// the workspace has been backed up recently and might be backed up again right now
+			continue
+		}
+
+		referenced, err := e.referencedChunks(ctx, bucket, manifests[wsid])
+		if err != nil {
+			log.WithError(err).WithField("bucket", bucket).WithField("workspaceId", wsid).Warn("cannot read chunk manifests, keeping all backup chunks of the workspace")
+			continue
+		}
+
+		for _, c := range wschunks {
+			if _, ok := referenced[c.Name]; ok {
+				continue
+			}
+
+			if prot.IsProtected(bucket, c.classifiedObject) {
+				report.Protected++
+				continue
+			}
+			report.Candidates = append(report.Candidates, Candidate{
+				ObjectInfo: c.ObjectInfo,
+				Bucket:     bucket,
+				Class:      c.Class,
+				Reason:     "not referenced by any backup",
+			})
+		}
+	}
+}
+
+// referencedChunks downloads chunk manifests and returns the names of all chunks they reference
+func (e *Engine) referencedChunks(ctx context.Context, bucket string, manifests []string) (map[string]struct{}, error) {
+	res := make(map[string]struct{})
+	for _, name := range manifests {
+		var mf csapi.ChunkedBackupManifest
+		err := e.downloadJSON(ctx, bucket, name, &mf)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range mf.Chunks {
+			res[storage.BackupChunkName(c.Digest)] = struct{}{}
+		}
+	}
+	return res, nil
+}
+
+// downloadJSON downloads, decrypts and unmarshals a JSON object
+func (e *Engine) downloadJSON(ctx context.Context, bucket, name string, dst interface{}) error {
+	info, err := e.storage.SignDownload(ctx, bucket, name, &storage.SignedURLOptions{})
+	if err != nil {
+		return xerrors.Errorf("cannot sign download of %s: %w", name, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, info.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return xerrors.Errorf("cannot download %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return xerrors.Errorf("cannot download %s: status %d", name, resp.StatusCode)
+	}
+	src, err := storage.Decrypt(resp.Body, info.DataKey)
+	if err != nil {
+		return xerrors.Errorf("cannot decrypt %s: %w", name, err)
+	}
+	err = json.NewDecoder(src).Decode(dst)
+	if err != nil {
+		return xerrors.Errorf("cannot unmarshal %s: %w", name, err)
+	}
+	return nil
+}
+
+// Sweep deletes all objects which violate the retention policies
+func (e *Engine) Sweep(ctx context.Context) (report *Report, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "retention.Sweep")
+	defer tracing.FinishSpan(span, &err)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	report, err = e.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var failed int
+	for _, c := range report.Candidates {
+		err := e.storage.DeleteObject(ctx, c.Bucket, &storage.DeleteObjectQuery{Name: c.Name})
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.WithError(err).WithField("bucket", c.Bucket).WithField("object", c.Name).Warn("cannot delete object")
+			failed++
+		}
+	}
+	if failed > 0 {
+		return report, xerrors.Errorf("cannot delete %d of %d objects", failed, len(report.Candidates))
+	}
+	return report, nil
+}
+
+// Start sweeps in the configured interval until the context is canceled
+func (e *Engine) Start(ctx context.Context) {
+	interval := time.Duration(e.Config.Interval)
+	if interval <= 0 {
+		return
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		report, err := e.Sweep(ctx)
+		if err != nil {
+			log.WithError(err).Error("retention sweep failed")
+			continue
+		}
+		log.WithField("deleted", len(report.Candidates)).
+			WithField("size", report.TotalSize()).
+			WithField("protected", report.Protected).
+			Info("retention sweep done")
+	}
+}
@@ -0,0 +1,357 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package retention
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/util"
+	"github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+const (
+	testBucket       = "gitpod-user-foo"
+	testPluginBucket = "ide-plugins"
+)
+
+type testObject struct {
+	Bucket  string
+	Name    string
+	Age     time.Duration
+	Content string
+}
+
+func TestPlan(t *testing.T) {
+	type candidate struct {
+		Bucket string
+		Name   string
+		Class  config.ObjectClass
+		Reason string
+	}
+	type expectation struct {
+		Candidates []candidate
+		Protected  int
+	}
+
+	day := 24 * time.Hour
+	tests := []struct {
+		Name        string
+		Policies    map[config.ObjectClass]config.RetentionPolicy
+		References  References
+		Objects     []testObject
+		Expectation expectation
+	}{
+		{
+			Name: "keep latest backups per workspace",
+			Policies: map[config.ObjectClass]config.RetentionPolicy{
+				config.ObjectClassBackup: {KeepLatest: 2},
+			},
+			Objects: []testObject{
+				{Bucket: testBucket, Name: "workspaces/ws1/wsfull-1.tar", Age: 4 * day},
+				{Bucket: testBucket, Name: "workspaces/ws1/wsfull-2.tar", Age: 3 * day},
+				{Bucket: testBucket, Name: "workspaces/ws1/wsfull-3.tar", Age: 2 * day},
+				{Bucket: testBucket, Name: "workspaces/ws1/wsfull-4.tar", Age: 1 * day},
+				{Bucket: testBucket, Name: "workspaces/ws2/wsfull-1.tar", Age: 4 * day},
+				{Bucket: testBucket, Name: "workspaces/ws1/full.tar", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/ws1/snapshot-1.tar", Age: 10 * day},
+			},
+			Expectation: expectation{
+				Candidates: []candidate{
+					{Bucket: testBucket, Name: "workspaces/ws1/wsfull-1.tar", Class: config.ObjectClassBackup, Reason: "not among the 2 latest"},
+					{Bucket: testBucket, Name: "workspaces/ws1/wsfull-2.tar", Class: config.ObjectClassBackup, Reason: "not among the 2 latest"},
+				},
+			},
+		},
+		{
+			Name: "max age",
+			Policies: map[config.ObjectClass]config.RetentionPolicy{
+				config.ObjectClassSnapshot:    {MaxAge: util.Duration(7 * day)},
+				config.ObjectClassPrebuildLog: {MaxAge: util.Duration(7 * day)},
+			},
+			Objects: []testObject{
+				{Bucket: testBucket, Name: "workspaces/ws1/snapshot-1.tar", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/ws1/snapshot-2.tar", Age: 1 * day},
+				{Bucket: testBucket, Name: "workspaces/ws1/instances/i1/logs/task1", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/ws1/instances/i1/logs/task2", Age: 1 * day},
+				{Bucket: testBucket, Name: "workspaces/ws1/wsfull-1.tar", Age: 10 * day},
+			},
+			Expectation: expectation{
+				Candidates: []candidate{
+					{Bucket: testBucket, Name: "workspaces/ws1/instances/i1/logs/task1", Class: config.ObjectClassPrebuildLog, Reason: "older than 168h0m0s"},
+					{Bucket: testBucket, Name: "workspaces/ws1/snapshot-1.tar", Class: config.ObjectClassSnapshot, Reason: "older than 168h0m0s"},
+				},
+			},
+		},
+		{
+			Name: "shared bucket",
+			Policies: map[config.ObjectClass]config.RetentionPolicy{
+				config.ObjectClassBackup: {KeepLatest: 1},
+			},
+			Objects: []testObject{
+				{Bucket: "gitpod-user-shared", Name: "owner1/workspaces/ws1/wsfull-1.tar", Age: 2 * day},
+				{Bucket: "gitpod-user-shared", Name: "owner1/workspaces/ws1/wsfull-2.tar", Age: 1 * day},
+				{Bucket: "gitpod-user-shared", Name: "owner2/workspaces/ws2/wsfull-1.tar", Age: 2 * day},
+			},
+			Expectation: expectation{
+				Candidates: []candidate{
+					{Bucket: "gitpod-user-shared", Name: "owner1/workspaces/ws1/wsfull-1.tar", Class: config.ObjectClassBackup, Reason: "not among the 1 latest"},
+				},
+			},
+		},
+		{
+			Name: "referenced content is protected",
+			Policies: map[config.ObjectClass]config.RetentionPolicy{
+				config.ObjectClassBackup:   {MaxAge: util.Duration(day)},
+				config.ObjectClassSnapshot: {MaxAge: util.Duration(day)},
+			},
+			References: References{
+				Workspaces: []string{"running"},
+				Snapshots:  []string{"workspaces/prebuild/snapshot-1.tar@" + testBucket},
+			},
+			Objects: []testObject{
+				{Bucket: testBucket, Name: "workspaces/running/wsfull-1.tar", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/running/snapshot-1.tar", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/prebuild/snapshot-1.tar", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/prebuild/snapshot-2.tar", Age: 10 * day},
+			},
+			Expectation: expectation{
+				Candidates: []candidate{
+					{Bucket: testBucket, Name: "workspaces/prebuild/snapshot-2.tar", Class: config.ObjectClassSnapshot, Reason: "older than 24h0m0s"},
+				},
+				Protected: 3,
+			},
+		},
+		{
+			Name: "unreferenced backup chunks",
+			Policies: map[config.ObjectClass]config.RetentionPolicy{
+				config.ObjectClassBackupChunk: {MaxAge: util.Duration(day)},
+			},
+			References: References{
+				Workspaces: []string{"running"},
+			},
+			Objects: []testObject{
+				{Bucket: testBucket, Name: "workspaces/ws1/full.chunks.json", Age: 2 * day, Content: `{"chunks":[{"digest":"sha256:c1"}]}`},
+				{Bucket: testBucket, Name: "workspaces/ws1/backups/1.chunks.json", Age: 3 * day, Content: `{"chunks":[{"digest":"sha256:c2"}]}`},
+				{Bucket: testBucket, Name: "workspaces/ws1/chunks/sha256/c1", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/ws1/chunks/sha256/c2", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/ws1/chunks/sha256/c3", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/ws2/chunks/sha256/c1", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/uploading/chunks/sha256/c1", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/uploading/chunks/sha256/c2", Age: time.Hour},
+				{Bucket: testBucket, Name: "workspaces/backedup/full.chunks.json", Age: time.Hour, Content: `{"chunks":[{"digest":"sha256:c1"}]}`},
+				{Bucket: testBucket, Name: "workspaces/backedup/chunks/sha256/c1", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/backedup/chunks/sha256/c2", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/running/chunks/sha256/c1", Age: 10 * day},
+			},
+			Expectation: expectation{
+				Candidates: []candidate{
+					{Bucket: testBucket, Name: "workspaces/ws1/chunks/sha256/c3", Class: config.ObjectClassBackupChunk, Reason: "not referenced by any backup"},
+					{Bucket: testBucket, Name: "workspaces/ws2/chunks/sha256/c1", Class: config.ObjectClassBackupChunk, Reason: "not referenced by any backup"},
+				},
+				Protected: 1,
+			},
+		},
+		{
+			Name: "layers of content manifests are protected",
+			Policies: map[config.ObjectClass]config.RetentionPolicy{
+				config.ObjectClassBackup:   {MaxAge: util.Duration(day)},
+				config.ObjectClassSnapshot: {MaxAge: util.Duration(day)},
+			},
+			Objects: []testObject{
+				{Bucket: testBucket, Name: "workspaces/ws1/wsfull.json", Age: 10 * day, Content: `{"layers":[{"bucket":"` + testBucket + `","object":"workspaces/ws1/wsfull-2.tar"}]}`},
+				{Bucket: testBucket, Name: "workspaces/ws1/wsfull-1.tar", Age: 11 * day},
+				{Bucket: testBucket, Name: "workspaces/ws1/wsfull-2.tar", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/ws2/snapshot-1.mf.json", Age: 10 * day, Content: `{"layers":[{"bucket":"gitpod-user-other","object":"workspaces/ws2/snapshot-1.tar"}]}`},
+				{Bucket: "gitpod-user-other", Name: "workspaces/ws2/snapshot-1.tar", Age: 10 * day},
+				{Bucket: testBucket, Name: "workspaces/ws3/wsfull.json", Age: 10 * day, Content: "not a manifest"},
+				{Bucket: testBucket, Name: "workspaces/ws3/wsfull-1.tar", Age: 10 * day},
+			},
+			Expectation: expectation{
+				Candidates: []candidate{
+					{Bucket: testBucket, Name: "workspaces/ws1/wsfull-1.tar", Class: config.ObjectClassBackup, Reason: "older than 24h0m0s"},
+				},
+				Protected: 3,
+			},
+		},
+		{
+			Name: "IDE plugins",
+			Policies: map[config.ObjectClass]config.RetentionPolicy{
+				config.ObjectClassIDEPlugin: {KeepLatest: 1},
+			},
+			Objects: []testObject{
+				{Bucket: testPluginBucket, Name: "plugin-a/1.vsix", Age: 2 * day},
+				{Bucket: testPluginBucket, Name: "plugin-a/2.vsix", Age: 1 * day},
+				{Bucket: testPluginBucket, Name: "plugin-b/1.vsix", Age: 3 * day},
+				{Bucket: testPluginBucket, Name: "plugin-c.vsix", Age: 4 * day},
+				{Bucket: testBucket, Name: "workspaces/ws1/wsfull-1.tar", Age: 10 * day},
+			},
+			Expectation: expectation{
+				Candidates: []candidate{
+					{Bucket: testPluginBucket, Name: "plugin-a/1.vsix", Class: config.ObjectClassIDEPlugin, Reason: "not among the 1 latest"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			engine, _ := newTestEngine(t, test.Policies, test.References, test.Objects)
+
+			report, err := engine.Plan(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			act := expectation{Protected: report.Protected}
+			for _, c := range report.Candidates {
+				act.Candidates = append(act.Candidates, candidate{
+					Bucket: c.Bucket,
+					Name:   c.Name,
+					Class:  c.Class,
+					Reason: c.Reason,
+				})
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected report (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSweep(t *testing.T) {
+	policies := map[config.ObjectClass]config.RetentionPolicy{
+		config.ObjectClassBackup: {KeepLatest: 1},
+	}
+	objects := []testObject{
+		{Bucket: testBucket, Name: "workspaces/ws1/wsfull-1.tar", Age: 2 * time.Hour},
+		{Bucket: testBucket, Name: "workspaces/ws1/wsfull-2.tar", Age: time.Hour},
+	}
+	engine, dir := newTestEngine(t, policies, References{}, objects)
+
+	report, err := engine.Sweep(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Candidates) != 1 {
+		t.Fatalf("expected one object to be deleted, got %d", len(report.Candidates))
+	}
+	if _, err := os.Stat(filepath.Join(dir, testBucket, "workspaces/ws1/wsfull-1.tar")); !os.IsNotExist(err) {
+		t.Errorf("expected old backup to be deleted: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, testBucket, "workspaces/ws1/wsfull-2.tar")); err != nil {
+		t.Errorf("expected latest backup to be kept: %v", err)
+	}
+
+	report, err = engine.Plan(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Candidates) != 0 {
+		t.Errorf("expected nothing left to delete, got %v", report.Candidates)
+	}
+}
+
+func TestSweepWithoutReferences(t *testing.T) {
+	policies := map[config.ObjectClass]config.RetentionPolicy{
+		config.ObjectClassBackup: {KeepLatest: 1},
+	}
+	objects := []testObject{
+		{Bucket: testBucket, Name: "workspaces/ws1/wsfull-1.tar", Age: 2 * time.Hour},
+		{Bucket: testBucket, Name: "workspaces/ws1/wsfull-2.tar", Age: time.Hour},
+	}
+	engine, dir := newTestEngine(t, policies, References{}, objects)
+	engine.References = failingReferences{}
+
+	_, err := engine.Sweep(context.Background())
+	if err == nil {
+		t.Fatal("expected sweep to fail without references")
+	}
+	if _, err := os.Stat(filepath.Join(dir, testBucket, "workspaces/ws1/wsfull-1.tar")); err != nil {
+		t.Errorf("expected nothing to be deleted: %v", err)
+	}
+}
+
+type staticReferences References
+
+func (r staticReferences) References(ctx context.Context) (*References, error) {
+	res := References(r)
+	return &res, nil
+}
+
+type failingReferences struct{}
+
+func (failingReferences) References(ctx context.Context) (*References, error) {
+	return nil, xerrors.Errorf("database is unavailable")
+}
+
+func newTestEngine(t *testing.T, policies map[config.ObjectClass]config.RetentionPolicy, refs References, objects []testObject) (engine *Engine, dir string) {
+	dir = t.TempDir()
+	now := time.Now()
+	for _, obj := range objects {
+		fn := filepath.Join(dir, obj.Bucket, filepath.FromSlash(obj.Name))
+		err := os.MkdirAll(filepath.Dir(fn), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content := obj.Content
+		if content == "" {
+			content = "content"
+		}
+		err = os.WriteFile(fn, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mtime := now.Add(-obj.Age)
+		err = os.Chtimes(fn, mtime, mtime)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfgDir := t.TempDir()
+	keyFile := filepath.Join(cfgDir, "signing-key")
+	err := os.WriteFile(keyFile, []byte("secret"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// manifests are downloaded using presigned URLs
+	srv := httptest.NewUnstartedServer(nil)
+	localCfg := config.LocalConfig{
+		Directory:      dir,
+		URL:            "http://" + srv.Listener.Addr().String(),
+		SigningKeyFile: keyFile,
+	}
+	srv.Config.Handler, err = storage.NewLocalStorageHandler(localCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	ps, err := storage.NewPresignedAccess(&config.StorageConfig{
+		Kind:        config.LocalStorage,
+		LocalConfig: &localCfg,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine, err = NewEngine(ps, staticReferences(refs), config.RetentionConfig{
+		IDEPluginBucket: testPluginBucket,
+		Policies:        policies,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return engine, dir
+}
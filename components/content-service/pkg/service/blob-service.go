@@ -84,7 +84,8 @@ func (cs *BlobService) UploadUrl(ctx context.Context, req *api.UploadUrlRequest)
 	}
 
 	return &api.UploadUrlResponse{
-		Url: info.URL,
+		Url:     info.URL,
+		Headers: info.Headers,
 	}, nil
 }
 
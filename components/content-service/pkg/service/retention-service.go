@@ -0,0 +1,63 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package service
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	"github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/retention"
+)
+
+// RetentionService implements RetentionServiceServer
+type RetentionService struct {
+	engine *retention.Engine
+
+	api.UnimplementedRetentionServiceServer
+}
+
+// NewRetentionService creates a new retention service. If engine is nil, retention is not configured.
+func NewRetentionService(engine *retention.Engine) *RetentionService {
+	return &RetentionService{engine: engine}
+}
+
+// Report lists the objects the retention policies would delete without deleting anything
+func (rs *RetentionService) Report(ctx context.Context, req *api.RetentionReportRequest) (resp *api.RetentionReportResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Retention.Report")
+	defer tracing.FinishSpan(span, &err)
+
+	if rs.engine == nil {
+		return nil, status.Error(codes.FailedPrecondition, "retention is not configured")
+	}
+
+	report, err := rs.engine.Plan(ctx)
+	if err != nil {
+		log.WithError(err).Error("cannot plan retention")
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
+	resp = &api.RetentionReportResponse{
+		TotalSize:      report.TotalSize(),
+		ProtectedCount: int64(report.Protected),
+	}
+	for _, c := range report.Candidates {
+		resp.Candidates = append(resp.Candidates, &api.RetentionCandidate{
+			Bucket:       c.Bucket,
+			Object:       c.Name,
+			Class:        string(c.Class),
+			Size:         c.Size,
+			LastModified: timestamppb.New(c.LastModified),
+			Reason:       c.Reason,
+		})
+	}
+	return resp, nil
+}
@@ -5,13 +5,17 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 
 	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/common-go/tracing"
@@ -22,8 +26,11 @@ import (
 
 // WorkspaceService implements WorkspaceServiceServer
 type WorkspaceService struct {
-	cfg config.StorageConfig
-	s   storage.PresignedAccess
+	cfg       config.StorageConfig
+	s         storage.PresignedAccess
+	daFactory func(cfg *config.StorageConfig) (storage.DirectAccess, error)
+	// downloads signs the downloads the content-service serves. Nil if it does not serve downloads.
+	downloads *storage.DownloadSigner
 
 	api.UnimplementedWorkspaceServiceServer
 }
@@ -34,7 +41,17 @@ func NewWorkspaceService(cfg config.StorageConfig) (res *WorkspaceService, err e
 	if err != nil {
 		return nil, err
 	}
-	return &WorkspaceService{cfg: cfg, s: s}, nil
+	daFactory := func(cfg *config.StorageConfig) (storage.DirectAccess, error) {
+		return storage.NewDirectAccess(cfg)
+	}
+	var downloads *storage.DownloadSigner
+	if cfg.Download != nil {
+		downloads, err = storage.NewDownloadSigner(*cfg.Download)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &WorkspaceService{cfg: cfg, s: s, daFactory: daFactory, downloads: downloads}, nil
 }
 
 // WorkspaceDownloadURL provides a URL from where the content of a workspace can be downloaded from
@@ -42,9 +59,41 @@ func (cs *WorkspaceService) WorkspaceDownloadURL(ctx context.Context, req *api.W
 	span, ctx := opentracing.StartSpanFromContext(ctx, "WorkspaceDownloadURL")
 	span.SetTag("user", req.OwnerId)
 	span.SetTag("workspaceId", req.WorkspaceId)
+	span.SetTag("backupId", req.BackupId)
 	defer tracing.FinishSpan(span, &err)
 
 	blobName := cs.s.BackupObject(req.OwnerId, req.WorkspaceId, storage.DefaultBackup)
+	if req.BackupId != "" {
+		history, err := cs.backupHistory(ctx, req.OwnerId, req.WorkspaceId)
+		if err != nil {
+			return nil, status.Error(codes.Unknown, err.Error())
+		}
+		version, ok := history.Find(req.BackupId)
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "backup %s not found", req.BackupId)
+		}
+		if version.Chunked {
+			return cs.chunkedBackupDownloadURL(req.OwnerId, cs.s.BackupObject(req.OwnerId, req.WorkspaceId, version.Object))
+		}
+		blobName = cs.s.BackupObject(req.OwnerId, req.WorkspaceId, version.Object)
+	} else {
+		chunkedObject := cs.s.BackupObject(req.OwnerId, req.WorkspaceId, storage.DefaultChunkedBackupManifest)
+		latest, err := storage.LatestBackup(ctx, cs.s, cs.s.Bucket(req.OwnerId), blobName, chunkedObject)
+		if err != nil {
+			return nil, status.Error(codes.Unknown, err.Error())
+		}
+		chunked := latest == storage.DefaultChunkedBackupManifest
+		if chunked {
+			chunked, err = cs.s.ObjectExists(ctx, cs.s.Bucket(req.OwnerId), chunkedObject)
+			if err != nil {
+				return nil, status.Error(codes.Unknown, err.Error())
+			}
+		}
+		if chunked {
+			// a regular backup older than the chunked one is outdated
+			return cs.chunkedBackupDownloadURL(req.OwnerId, chunkedObject)
+		}
+	}
 
 	info, err := cs.s.SignDownload(ctx, cs.s.Bucket(req.OwnerId), blobName, &storage.SignedURLOptions{})
 	if err != nil {
@@ -58,9 +107,27 @@ func (cs *WorkspaceService) WorkspaceDownloadURL(ctx context.Context, req *api.W
 		}
 		return nil, status.Error(codes.Unknown, err.Error())
 	}
+	url := info.URL
+	if info.DataKey != nil {
+		// the storage URL would only ever produce ciphertext
+		if info.DecryptedURL == "" {
+			return nil, status.Error(codes.FailedPrecondition, "workspace content is encrypted and the content-service does not serve downloads")
+		}
+		url = info.DecryptedURL
+	}
 
 	return &api.WorkspaceDownloadURLResponse{
-		Url: info.URL,
+		Url: url,
+	}, nil
+}
+
+// chunkedBackupDownloadURL provides the URL under which the content-service serves a chunked backup as a single tarball
+func (cs *WorkspaceService) chunkedBackupDownloadURL(ownerID, manifest string) (*api.WorkspaceDownloadURLResponse, error) {
+	if cs.downloads == nil {
+		return nil, status.Error(codes.FailedPrecondition, "workspace backup is chunked and the content-service does not serve downloads")
+	}
+	return &api.WorkspaceDownloadURLResponse{
+		Url: cs.downloads.SignChunkedBackup(cs.s.Bucket(ownerID), manifest),
 	}, nil
 }
 
@@ -100,6 +167,20 @@ func (cs *WorkspaceService) DeleteWorkspace(ctx context.Context, req *api.Delete
 		return nil, status.Error(codes.Unknown, err.Error())
 	}
 
+	chunkedBackup := []*storage.DeleteObjectQuery{
+		{Name: cs.s.BackupObject(req.OwnerId, req.WorkspaceId, storage.DefaultChunkedBackupManifest)},
+		{Prefix: cs.s.BackupObject(req.OwnerId, req.WorkspaceId, storage.BackupChunkPrefix)},
+		{Name: cs.s.BackupObject(req.OwnerId, req.WorkspaceId, storage.BackupHistoryManifest)},
+		{Prefix: cs.s.BackupObject(req.OwnerId, req.WorkspaceId, storage.BackupVersionPrefix)},
+	}
+	for _, query := range chunkedBackup {
+		err = cs.s.DeleteObject(ctx, cs.s.Bucket(req.OwnerId), query)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.WithError(err).Error("error deleting chunked workspace backup or backup history")
+			return nil, status.Error(codes.Unknown, err.Error())
+		}
+	}
+
 	trailPrefix := cs.s.BackupObject(req.OwnerId, req.WorkspaceId, "trail-")
 	err = cs.s.DeleteObject(ctx, cs.s.Bucket(req.OwnerId), &storage.DeleteObjectQuery{Prefix: trailPrefix})
 	if err != nil {
@@ -114,6 +195,58 @@ func (cs *WorkspaceService) DeleteWorkspace(ctx context.Context, req *api.Delete
 	return &api.DeleteWorkspaceResponse{}, nil
 }
 
+// ListWorkspaceBackups lists the previous backups of a workspace which can be restored
+func (cs *WorkspaceService) ListWorkspaceBackups(ctx context.Context, req *api.ListWorkspaceBackupsRequest) (resp *api.ListWorkspaceBackupsResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ListWorkspaceBackups")
+	span.SetTag("user", req.OwnerId)
+	span.SetTag("workspaceId", req.WorkspaceId)
+	defer tracing.FinishSpan(span, &err)
+
+	history, err := cs.backupHistory(ctx, req.OwnerId, req.WorkspaceId)
+	if err != nil {
+		log.WithFields(log.OWI(req.OwnerId, req.WorkspaceId, "")).WithError(err).Error("cannot read backup history")
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
+	resp = &api.ListWorkspaceBackupsResponse{}
+	for _, b := range history.Backups {
+		resp.Backups = append(resp.Backups, &api.WorkspaceBackup{
+			Id:      b.ID,
+			Created: timestamppb.New(b.Created),
+			Size:    b.Size,
+			Chunked: b.Chunked,
+		})
+	}
+	return resp, nil
+}
+
+// backupHistory reads the backup history of a workspace. Workspaces without history have an empty one.
+func (cs *WorkspaceService) backupHistory(ctx context.Context, owner, workspace string) (*api.BackupHistory, error) {
+	da, err := cs.daFactory(&cs.cfg)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot use configured storage: %w", err)
+	}
+	err = da.Init(ctx, owner, workspace, "")
+	if err != nil {
+		return nil, xerrors.Errorf("cannot use configured storage: %w", err)
+	}
+
+	var buf bytes.Buffer
+	found, err := da.DownloadObject(ctx, storage.BackupHistoryManifest, &buf)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot download backup history: %w", err)
+	}
+	var res api.BackupHistory
+	if !found {
+		return &res, nil
+	}
+	err = json.Unmarshal(buf.Bytes(), &res)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal backup history: %w", err)
+	}
+	return &res, nil
+}
+
 func (cs *WorkspaceService) WorkspaceSnapshotExists(ctx context.Context, req *api.WorkspaceSnapshotExistsRequest) (resp *api.WorkspaceSnapshotExistsResponse, err error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "WorkspaceObjectExists")
 	span.SetTag("user", req.OwnerId)
@@ -0,0 +1,185 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+	storagemock "github.com/gitpod-io/gitpod/content-service/pkg/storage/mock"
+)
+
+const (
+	testOwnerID     = "1234"
+	testWorkspaceID = "amber-baboon-cij4wozf"
+)
+
+var testBackupHistory = &api.BackupHistory{
+	Backups: []api.BackupVersion{
+		{ID: "chunked", Created: time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC), Object: storage.BackupVersionName(1, true), Chunked: true, Size: 2048},
+		{ID: "full", Created: time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), Object: storage.BackupVersionName(0, false), Size: 1024},
+	},
+}
+
+// newTestWorkspaceService produces a workspace service whose direct access serves the given backup history
+func newTestWorkspaceService(ctrl *gomock.Controller, history *api.BackupHistory) (*WorkspaceService, *storagemock.MockPresignedAccess) {
+	s := storagemock.NewMockPresignedAccess(ctrl)
+	da := storagemock.NewMockDirectAccess(ctrl)
+	da.EXPECT().Init(gomock.Any(), gomock.Eq(testOwnerID), gomock.Eq(testWorkspaceID), gomock.Any()).AnyTimes()
+	da.EXPECT().DownloadObject(gomock.Any(), gomock.Eq(storage.BackupHistoryManifest), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, name string, dst io.Writer) (bool, error) {
+			if history == nil {
+				return false, nil
+			}
+			return true, json.NewEncoder(dst).Encode(history)
+		}).AnyTimes()
+
+	return &WorkspaceService{
+		cfg: config.StorageConfig{
+			Stage: config.StageProduction,
+			Kind:  config.GCloudStorage, // dummy, mocked away
+		},
+		s: s,
+		daFactory: func(cfg *config.StorageConfig) (storage.DirectAccess, error) {
+			return da, nil
+		},
+	}, s
+}
+
+func TestListWorkspaceBackups(t *testing.T) {
+	tests := []struct {
+		Name        string
+		History     *api.BackupHistory
+		Expectation *api.ListWorkspaceBackupsResponse
+	}{
+		{
+			Name:        "no history",
+			Expectation: &api.ListWorkspaceBackupsResponse{},
+		},
+		{
+			Name:    "history",
+			History: testBackupHistory,
+			Expectation: &api.ListWorkspaceBackupsResponse{
+				Backups: []*api.WorkspaceBackup{
+					{Id: "chunked", Created: timestamppb.New(time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC)), Size: 2048, Chunked: true},
+					{Id: "full", Created: timestamppb.New(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)), Size: 1024},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc, _ := newTestWorkspaceService(ctrl, test.History)
+			resp, err := svc.ListWorkspaceBackups(context.Background(), &api.ListWorkspaceBackupsRequest{
+				OwnerId:     testOwnerID,
+				WorkspaceId: testWorkspaceID,
+			})
+			if err != nil {
+				t.Fatalf("ListWorkspaceBackups err: %v", err)
+			}
+			if diff := cmp.Diff(test.Expectation, resp, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected response (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWorkspaceDownloadURLWithBackupID(t *testing.T) {
+	tests := []struct {
+		Name     string
+		BackupID string
+		// Info is the download info of the full backup. Defaults to an unencrypted backup.
+		Info *storage.DownloadInfo
+		// Downloads configures the content-service to serve downloads
+		Downloads bool
+		// URL is the expected URL without its query, i.e. signature
+		URL  string
+		Code codes.Code
+	}{
+		{Name: "full backup", BackupID: "full", URL: "http://backups/0.tar"},
+		{Name: "chunked backup", BackupID: "chunked", Code: codes.FailedPrecondition},
+		{
+			Name:      "chunked backup with downloads",
+			BackupID:  "chunked",
+			Downloads: true,
+			URL:       "http://content-service/download/chunked-backup/bucket/" + storage.BackupVersionName(1, true),
+		},
+		{Name: "unknown backup", BackupID: "unknown", Code: codes.NotFound},
+		{
+			Name:     "encrypted backup",
+			BackupID: "full",
+			Info:     &storage.DownloadInfo{URL: "http://backups/0.tar", DataKey: []byte("key"), DecryptedURL: "http://content-service/download/object/bucket/backups/0.tar"},
+			URL:      "http://content-service/download/object/bucket/backups/0.tar",
+		},
+		{
+			Name:     "encrypted backup without downloads",
+			BackupID: "full",
+			Info:     &storage.DownloadInfo{URL: "http://backups/0.tar", DataKey: []byte("key")},
+			Code:     codes.FailedPrecondition,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc, s := newTestWorkspaceService(ctrl, testBackupHistory)
+			s.EXPECT().Bucket(gomock.Any()).Return("bucket").AnyTimes()
+			s.EXPECT().BackupObject(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(owner, workspace, name string) string { return name }).AnyTimes()
+			if test.Downloads {
+				keyFile := filepath.Join(t.TempDir(), "signing-key")
+				err := os.WriteFile(keyFile, []byte("secret"), 0600)
+				if err != nil {
+					t.Fatal(err)
+				}
+				svc.downloads, err = storage.NewDownloadSigner(config.DownloadConfig{URL: "http://content-service", SigningKeyFile: keyFile})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			info := test.Info
+			if info == nil {
+				info = &storage.DownloadInfo{URL: "http://" + storage.BackupVersionName(0, false)}
+			}
+			s.EXPECT().SignDownload(gomock.Any(), gomock.Eq("bucket"), gomock.Eq(storage.BackupVersionName(0, false)), gomock.Any()).
+				Return(info, nil).AnyTimes()
+
+			resp, err := svc.WorkspaceDownloadURL(context.Background(), &api.WorkspaceDownloadURLRequest{
+				OwnerId:     testOwnerID,
+				WorkspaceId: testWorkspaceID,
+				BackupId:    test.BackupID,
+			})
+			if code := status.Code(err); code != test.Code {
+				t.Fatalf("unexpected status code: want %v, got %v (%v)", test.Code, code, err)
+			}
+			url, _, _ := strings.Cut(resp.GetUrl(), "?")
+			if diff := cmp.Diff(test.URL, url); diff != "" {
+				t.Errorf("unexpected URL (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,843 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+// azureSignedURLTTL is how long SAS URLs stay valid unless the SignedURLOptions say otherwise
+const azureSignedURLTTL = 30 * time.Minute
+
+var _ DirectAccess = &DirectAzureStorage{}
+var _ PresignedAccess = &PresignedAzureStorage{}
+var _ ObjectLister = &PresignedAzureStorage{}
+
+// AzureClient is the subset of the Azure Blob client we use
+type AzureClient interface {
+	CreateContainer(ctx context.Context, containerName string, o *azblob.CreateContainerOptions) (azblob.CreateContainerResponse, error)
+	DeleteContainer(ctx context.Context, containerName string, o *azblob.DeleteContainerOptions) (azblob.DeleteContainerResponse, error)
+	DeleteBlob(ctx context.Context, containerName string, blobName string, o *azblob.DeleteBlobOptions) (azblob.DeleteBlobResponse, error)
+	NewListBlobsFlatPager(containerName string, o *azblob.ListBlobsFlatOptions) *runtime.Pager[azblob.ListBlobsFlatResponse]
+	NewListContainersPager(o *azblob.ListContainersOptions) *runtime.Pager[azblob.ListContainersResponse]
+	UploadBuffer(ctx context.Context, containerName string, blobName string, buffer []byte, o *azblob.UploadBufferOptions) (azblob.UploadBufferResponse, error)
+	UploadFile(ctx context.Context, containerName string, blobName string, file *os.File, o *azblob.UploadFileOptions) (azblob.UploadFileResponse, error)
+	UploadStream(ctx context.Context, containerName string, blobName string, body io.Reader, o *azblob.UploadStreamOptions) (azblob.UploadStreamResponse, error)
+	DownloadStream(ctx context.Context, containerName string, blobName string, o *azblob.DownloadStreamOptions) (azblob.DownloadStreamResponse, error)
+}
+
+// AzureBlobClient is the subset of the Azure client for a single blob we use
+type AzureBlobClient interface {
+	GetProperties(ctx context.Context, options *blob.GetPropertiesOptions) (blob.GetPropertiesResponse, error)
+	GetSASURL(permissions sas.BlobPermissions, expiry time.Time, o *blob.GetSASURLOptions) (string, error)
+	StartCopyFromURL(ctx context.Context, copySource string, o *blob.StartCopyFromURLOptions) (blob.StartCopyFromURLResponse, error)
+	URL() string
+}
+
+// ValidateAzureConfig checks if the Azure storage config is valid
+func ValidateAzureConfig(c *config.AzureConfig) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.AccountName, validation.Required),
+		validation.Field(&c.AccountKey, validation.Required),
+	)
+}
+
+// addAzureParamsFromMounts allows for the account key to be read from a file
+func addAzureParamsFromMounts(c *config.AzureConfig) error {
+	if c.AccountKeyFile != "" {
+		value, err := os.ReadFile(c.AccountKeyFile)
+		if err != nil {
+			return err
+		}
+		c.AccountKey = strings.TrimSpace(string(value))
+	}
+	return nil
+}
+
+// NewAzureClient produces a new Azure Blob client based on this configuration
+func NewAzureClient(c *config.AzureConfig) (*azblob.Client, error) {
+	err := addAzureParamsFromMounts(c)
+	if err != nil {
+		return nil, err
+	}
+	err = ValidateAzureConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net/", c.AccountName)
+	}
+	cred, err := azblob.NewSharedKeyCredential(c.AccountName, c.AccountKey)
+	if err != nil {
+		return nil, err
+	}
+	return azblob.NewClientWithSharedKeyCredential(endpoint, cred, nil)
+}
+
+func azureContainerName(ownerID, container string) string {
+	if container != "" {
+		return container
+	}
+
+	return fmt.Sprintf("gitpod-user-%s", ownerID)
+}
+
+func azureWorkspaceBackupObjectName(ownerID, workspaceID, name string) string {
+	return path.Join(ownerID, "workspaces", workspaceID, name)
+}
+
+// azureMetadata turns annotations into blob metadata. Metadata names must be valid C# identifiers, hence we replace dashes.
+func azureMetadata(annotations map[string]string) map[string]*string {
+	if len(annotations) == 0 {
+		return nil
+	}
+	res := make(map[string]*string, len(annotations))
+	for k, v := range annotations {
+		v := v
+		res[strings.ReplaceAll(k, "-", "_")] = &v
+	}
+	return res
+}
+
+// azureAnnotation reads an annotation from blob metadata. The service does not preserve the case of metadata names.
+func azureAnnotation(md map[string]*string, annotation string) string {
+	name := strings.ReplaceAll(annotation, "-", "_")
+	for k, v := range md {
+		if strings.EqualFold(k, name) && v != nil {
+			return *v
+		}
+	}
+	return ""
+}
+
+func translateAzureError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound, bloberror.ResourceNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// newDirectAzureAccess provides direct access to the remote storage system
+func newDirectAzureAccess(cfg config.AzureConfig) (*DirectAzureStorage, error) {
+	err := addAzureParamsFromMounts(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err = ValidateAzureConfig(&cfg); err != nil {
+		return nil, err
+	}
+	return &DirectAzureStorage{AzureConfig: cfg}, nil
+}
+
+// DirectAzureStorage implements Azure Blob as remote storage backend
+type DirectAzureStorage struct {
+	Username      string
+	WorkspaceName string
+	InstanceID    string
+	AzureConfig   config.AzureConfig
+
+	client AzureClient
+
+	// BlobClientFactory exists for testing only. DO NOT USE in production.
+	BlobClientFactory func(container, name string) AzureBlobClient
+
+	// envelope encrypts workspace content if encryption is enabled
+	envelope *envelope
+}
+
+// Validate checks if the Azure storage is configured properly
+func (rs *DirectAzureStorage) Validate() error {
+	err := ValidateAzureConfig(&rs.AzureConfig)
+	if err != nil {
+		return err
+	}
+
+	return validation.ValidateStruct(rs,
+		validation.Field(&rs.Username, validation.Required),
+		validation.Field(&rs.WorkspaceName, validation.Required),
+	)
+}
+
+// Init initializes the remote storage - call this before calling anything else on the interface
+func (rs *DirectAzureStorage) Init(ctx context.Context, owner, workspace, instance string) (err error) {
+	rs.Username = owner
+	rs.WorkspaceName = workspace
+	rs.InstanceID = instance
+
+	err = rs.Validate()
+	if err != nil {
+		return err
+	}
+
+	if rs.client == nil {
+		cl, err := NewAzureClient(&rs.AzureConfig)
+		if err != nil {
+			return err
+		}
+		rs.client = cl
+	}
+	if rs.BlobClientFactory == nil {
+		rs.BlobClientFactory = azureBlobClientFactory(rs.client)
+	}
+
+	return nil
+}
+
+func (rs *DirectAzureStorage) readObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error) {
+	if rs.client == nil {
+		return nil, xerrors.Errorf("no Azure client available - did you call Init()?")
+	}
+
+	resp, err := rs.client.DownloadStream(ctx, bkt, obj, nil)
+	if err != nil {
+		return nil, translateAzureError(err)
+	}
+	return resp.Body, nil
+}
+
+func (rs *DirectAzureStorage) writeObject(ctx context.Context, bkt, obj string, content []byte) error {
+	if rs.client == nil {
+		return xerrors.Errorf("no Azure client available - did you call Init()?")
+	}
+
+	_, err := rs.client.UploadBuffer(ctx, bkt, obj, content, nil)
+	return err
+}
+
+func (rs *DirectAzureStorage) createObject(ctx context.Context, bkt, obj string, content []byte) error {
+	if rs.client == nil {
+		return xerrors.Errorf("no Azure client available - did you call Init()?")
+	}
+
+	etagAny := azcore.ETagAny
+	_, err := rs.client.UploadBuffer(ctx, bkt, obj, content, &azblob.UploadBufferOptions{
+		AccessConditions: &blob.AccessConditions{
+			ModifiedAccessConditions: &blob.ModifiedAccessConditions{IfNoneMatch: &etagAny},
+		},
+	})
+	if bloberror.HasCode(err, bloberror.BlobAlreadyExists, bloberror.ConditionNotMet) {
+		return errObjectExists
+	}
+	return err
+}
+
+// EnsureExists makes sure that the remote storage location exists and can be up- or downloaded from
+func (rs *DirectAzureStorage) EnsureExists(ctx context.Context) (err error) {
+	return azureEnsureExists(ctx, rs.client, rs.bucketName())
+}
+
+func azureEnsureExists(ctx context.Context, client AzureClient, container string) (err error) {
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DirectEnsureExists")
+	defer tracing.FinishSpan(span, &err)
+
+	if client == nil {
+		return xerrors.Errorf("no Azure client available - did you call Init()?")
+	}
+
+	_, err = client.CreateContainer(ctx, container, nil)
+	if bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+		// container exists already - we're fine
+		return nil
+	}
+	if err != nil {
+		return xerrors.Errorf("cannot create container: %w", err)
+	}
+	return nil
+}
+
+func (rs *DirectAzureStorage) download(ctx context.Context, destination string, bkt string, obj string, mappings []archive.IDMapping) (found bool, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "download")
+	span.SetTag("bucket", bkt)
+	span.SetTag("object", obj)
+	defer tracing.FinishSpan(span, &err)
+
+	rc, err := rs.readObject(ctx, bkt, obj)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+
+	src, err := decryptDownload(ctx, rs.envelope, rs, bkt, rc)
+	if err != nil {
+		return true, err
+	}
+	err = extractTarbal(ctx, destination, src, mappings)
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// Download takes the latest state from the remote storage and downloads it to a local path
+func (rs *DirectAzureStorage) Download(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
+	return rs.download(ctx, destination, rs.bucketName(), rs.objectName(name), mappings)
+}
+
+// DownloadSnapshot downloads a snapshot. The snapshot name is expected to be one produced by Qualify
+func (rs *DirectAzureStorage) DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
+	bkt, obj, err := ParseSnapshotName(name)
+	if err != nil {
+		return false, err
+	}
+
+	return rs.download(ctx, destination, bkt, obj, mappings)
+}
+
+// DownloadObject writes the content of a single object to dst without extracting it
+func (rs *DirectAzureStorage) DownloadObject(ctx context.Context, name string, dst io.Writer) (found bool, err error) {
+	rc, err := rs.readObject(ctx, rs.bucketName(), rs.objectName(name))
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+
+	src, err := decryptDownload(ctx, rs.envelope, rs, rs.bucketName(), rc)
+	if err != nil {
+		return true, err
+	}
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// ListObjects returns all objects found with the given prefix. Returns an empty list if the bucket does not exuist (yet).
+func (rs *DirectAzureStorage) ListObjects(ctx context.Context, prefix string) (objects []string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	err = azureListBlobs(ctx, rs.client, rs.bucketName(), prefix, func(obj ObjectInfo) {
+		objects = append(objects, obj.Name)
+	})
+	if errors.Is(err, ErrNotFound) {
+		// container does not exist: nothing to list
+		return nil, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list objects: %w", err)
+	}
+	return objects, nil
+}
+
+func azureListBlobs(ctx context.Context, client AzureClient, container, prefix string, cb func(obj ObjectInfo)) error {
+	if client == nil {
+		return xerrors.Errorf("no Azure client available - did you call Init()?")
+	}
+
+	var opts azblob.ListBlobsFlatOptions
+	if prefix != "" {
+		opts.Prefix = &prefix
+	}
+	pager := client.NewListBlobsFlatPager(container, &opts)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return translateAzureError(err)
+		}
+		if page.Segment == nil {
+			continue
+		}
+		for _, item := range page.Segment.BlobItems {
+			if item == nil || item.Name == nil {
+				continue
+			}
+			obj := ObjectInfo{Name: *item.Name}
+			if item.Properties != nil && item.Properties.ContentLength != nil {
+				obj.Size = *item.Properties.ContentLength
+			}
+			if item.Properties != nil && item.Properties.LastModified != nil {
+				obj.LastModified = *item.Properties.LastModified
+			}
+			cb(obj)
+		}
+	}
+	return nil
+}
+
+// Qualify fully qualifies a snapshot name so that it can be downloaded using DownloadSnapshot
+func (rs *DirectAzureStorage) Qualify(name string) string {
+	return fmt.Sprintf("%s@%s", rs.objectName(name), rs.bucketName())
+}
+
+// UploadInstance takes all files from a local location and uploads it to the per-instance remote storage
+func (rs *DirectAzureStorage) UploadInstance(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, object string, err error) {
+	if rs.InstanceID == "" {
+		return "", "", xerrors.Errorf("instanceID is required to comput object name")
+	}
+	// instance objects, e.g. prebuild logs, are not workspace content and hence never encrypted
+	return rs.upload(ctx, source, InstanceObjectName(rs.InstanceID, name), false, opts...)
+}
+
+// Upload takes all files from a local location and uploads it to the remote storage
+func (rs *DirectAzureStorage) Upload(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, obj string, err error) {
+	return rs.upload(ctx, source, name, encryptable(name), opts...)
+}
+
+func (rs *DirectAzureStorage) upload(ctx context.Context, source string, name string, encrypt bool, opts ...UploadOption) (bucket, obj string, err error) {
+	f, err := os.Open(source)
+	if err != nil {
+		return "", "", xerrors.Errorf("cannot open file for uploading: %w", err)
+	}
+	defer f.Close()
+
+	if encrypt && rs.envelope != nil {
+		return rs.UploadStream(ctx, f, name, opts...)
+	}
+
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DirectUpload")
+	defer tracing.FinishSpan(span, &err)
+
+	options, err := GetUploadOptions(opts)
+	if err != nil {
+		err = xerrors.Errorf("cannot get options: %w", err)
+		return
+	}
+
+	if rs.client == nil {
+		err = xerrors.Errorf("no Azure client available - did you call Init()?")
+		return
+	}
+
+	bucket = rs.bucketName()
+	obj = rs.objectName(name)
+	span.LogKV("bucket", bucket)
+	span.LogKV("obj", obj)
+
+	_, err = rs.client.UploadFile(ctx, bucket, obj, f, &azblob.UploadFileOptions{
+		Concurrency: uint16(rs.AzureConfig.ParallelUpload),
+		HTTPHeaders: azureHTTPHeaders(options),
+		Metadata:    azureMetadata(options.Annotations),
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// UploadStream uploads everything read from src to the remote storage without buffering it on disk
+func (rs *DirectAzureStorage) UploadStream(ctx context.Context, src io.Reader, name string, opts ...UploadOption) (bucket, obj string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DirectUploadStream")
+	defer tracing.FinishSpan(span, &err)
+
+	options, err := GetUploadOptions(opts)
+	if err != nil {
+		err = xerrors.Errorf("cannot get options: %w", err)
+		return
+	}
+
+	if rs.client == nil {
+		err = xerrors.Errorf("no Azure client available - did you call Init()?")
+		return
+	}
+
+	bucket = rs.bucketName()
+	obj = rs.objectName(name)
+	span.LogKV("bucket", bucket)
+	span.LogKV("obj", obj)
+
+	content, err := encryptUpload(ctx, rs.envelope, rs, bucket, rs.objectName(DefaultDataKey), name, src)
+	if err != nil {
+		err = xerrors.Errorf("cannot encrypt %s: %w", obj, err)
+		return
+	}
+	defer content.Close()
+
+	// each concurrent block upload buffers a block in memory
+	_, err = rs.client.UploadStream(ctx, bucket, obj, content, &azblob.UploadStreamOptions{
+		BlockSize:   streamPartSize,
+		Concurrency: rs.AzureConfig.ParallelUpload,
+		HTTPHeaders: azureHTTPHeaders(options),
+		Metadata:    azureMetadata(options.Annotations),
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// CopyObject copies an object to another name within the remote storage without downloading it
+func (rs *DirectAzureStorage) CopyObject(ctx context.Context, src, dst string) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DirectCopyObject")
+	defer tracing.FinishSpan(span, &err)
+
+	if rs.client == nil {
+		return xerrors.Errorf("no Azure client available - did you call Init()?")
+	}
+
+	bucket := rs.bucketName()
+	srcObj, dstObj := rs.objectName(src), rs.objectName(dst)
+	span.LogKV("bucket", bucket)
+	span.LogKV("src", srcObj)
+	span.LogKV("dst", dstObj)
+
+	// the source is in the same storage account, hence the copy is authorized like any other request
+	bc := rs.BlobClientFactory(bucket, dstObj)
+	_, err = bc.StartCopyFromURL(ctx, rs.BlobClientFactory(bucket, srcObj).URL(), nil)
+	if err != nil {
+		return xerrors.Errorf("cannot copy %s to %s: %w", srcObj, dstObj, translateAzureError(err))
+	}
+
+	// copies within a storage account usually complete right away, but the service may still finish them asynchronously
+	for {
+		props, err := bc.GetProperties(ctx, nil)
+		if err != nil {
+			return xerrors.Errorf("cannot copy %s to %s: %w", srcObj, dstObj, translateAzureError(err))
+		}
+		if props.CopyStatus == nil || *props.CopyStatus == blob.CopyStatusTypeSuccess {
+			return nil
+		}
+		if *props.CopyStatus != blob.CopyStatusTypePending {
+			var desc string
+			if props.CopyStatusDescription != nil {
+				desc = *props.CopyStatusDescription
+			}
+			return xerrors.Errorf("cannot copy %s to %s: %s %s", srcObj, dstObj, *props.CopyStatus, desc)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func azureHTTPHeaders(options *UploadOptions) *blob.HTTPHeaders {
+	if options.ContentType == "" {
+		return nil
+	}
+	return &blob.HTTPHeaders{BlobContentType: &options.ContentType}
+}
+
+// Bucket provides the bucket name for a particular user
+func (rs *DirectAzureStorage) Bucket(ownerID string) string {
+	return azureContainerName(ownerID, rs.AzureConfig.Container)
+}
+
+// BackupObject returns a backup's object name that a direct downloader would download
+func (rs *DirectAzureStorage) BackupObject(name string) string {
+	return rs.objectName(name)
+}
+
+func (rs *DirectAzureStorage) bucketName() string {
+	return azureContainerName(rs.Username, rs.AzureConfig.Container)
+}
+
+func (rs *DirectAzureStorage) objectName(name string) string {
+	var username string
+	if rs.AzureConfig.Container != "" {
+		username = rs.Username
+	}
+	return azureWorkspaceBackupObjectName(username, rs.WorkspaceName, name)
+}
+
+// NewPresignedAzureAccess provides presigned access to Azure Blob storage using SAS URLs
+func NewPresignedAzureAccess(client AzureClient, cfg config.AzureConfig) *PresignedAzureStorage {
+	return &PresignedAzureStorage{
+		AzureConfig:       cfg,
+		client:            client,
+		BlobClientFactory: azureBlobClientFactory(client),
+	}
+}
+
+func azureBlobClientFactory(client AzureClient) func(container, name string) AzureBlobClient {
+	return func(container, name string) AzureBlobClient {
+		if cl, ok := client.(*azblob.Client); ok {
+			return cl.ServiceClient().NewContainerClient(container).NewBlobClient(name)
+		}
+		return nil
+	}
+}
+
+// PresignedAzureStorage provides SAS URLs to access Azure Blob storage objects
+type PresignedAzureStorage struct {
+	AzureConfig config.AzureConfig
+
+	client AzureClient
+
+	// BlobClientFactory exists for testing only. DO NOT USE in production.
+	BlobClientFactory func(container, name string) AzureBlobClient
+}
+
+// Bucket provides the bucket name for a particular user
+func (s *PresignedAzureStorage) Bucket(ownerID string) string {
+	return azureContainerName(ownerID, s.AzureConfig.Container)
+}
+
+// BlobObject returns a blob's object name
+func (s *PresignedAzureStorage) BlobObject(userID, name string) (string, error) {
+	return blobObjectName(name)
+}
+
+// BackupObject returns a backup's object name that a direct downloader would download
+func (s *PresignedAzureStorage) BackupObject(ownerID string, workspaceID string, name string) string {
+	var username string
+	if s.AzureConfig.Container != "" {
+		username = ownerID
+	}
+	return azureWorkspaceBackupObjectName(username, workspaceID, name)
+}
+
+// InstanceObject returns a instance's object name that a direct downloader would download
+func (s *PresignedAzureStorage) InstanceObject(ownerID string, workspaceID string, instanceID string, name string) string {
+	return s.BackupObject(ownerID, workspaceID, InstanceObjectName(instanceID, name))
+}
+
+// EnsureExists makes sure that the remote storage location exists and can be up- or downloaded from
+func (s *PresignedAzureStorage) EnsureExists(ctx context.Context, bucket string) error {
+	return azureEnsureExists(ctx, s.client, bucket)
+}
+
+// DiskUsage gives the total objects size of objects that have the given prefix
+func (s *PresignedAzureStorage) DiskUsage(ctx context.Context, bucket string, prefix string) (size int64, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "azure.DiskUsage")
+	defer tracing.FinishSpan(span, &err)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	err = azureListBlobs(ctx, s.client, bucket, prefix, func(obj ObjectInfo) {
+		size += obj.Size
+	})
+	if err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// ListBuckets returns the configured container or all user containers
+func (s *PresignedAzureStorage) ListBuckets(ctx context.Context) (buckets []string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "azure.ListBuckets")
+	defer tracing.FinishSpan(span, &err)
+
+	if s.AzureConfig.Container != "" {
+		return []string{s.AzureConfig.Container}, nil
+	}
+
+	prefix := azureContainerName("", "")
+	pager := s.client.NewListContainersPager(&azblob.ListContainersOptions{Prefix: &prefix})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.ContainerItems {
+			if item == nil || item.Name == nil {
+				continue
+			}
+			buckets = append(buckets, *item.Name)
+		}
+	}
+	return buckets, nil
+}
+
+// ListObjects returns all objects in the bucket whose name starts with prefix
+func (s *PresignedAzureStorage) ListObjects(ctx context.Context, bucket, prefix string) (objects []ObjectInfo, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "azure.ListObjects")
+	defer tracing.FinishSpan(span, &err)
+
+	err = azureListBlobs(ctx, s.client, bucket, prefix, func(obj ObjectInfo) {
+		objects = append(objects, obj)
+	})
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// SignDownload describes an object for download - if the object is not found, ErrNotFound is returned
+func (s *PresignedAzureStorage) SignDownload(ctx context.Context, bucket, obj string, options *SignedURLOptions) (info *DownloadInfo, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "azure.SignDownload")
+	defer func() {
+		if err == ErrNotFound {
+			span.LogKV("found", false)
+			tracing.FinishSpan(span, nil)
+			return
+		}
+
+		tracing.FinishSpan(span, &err)
+	}()
+
+	bc := s.BlobClientFactory(bucket, obj)
+	props, err := bc.GetProperties(ctx, nil)
+	if err != nil {
+		return nil, translateAzureError(err)
+	}
+	url, err := bc.GetSASURL(sas.BlobPermissions{Read: true}, time.Now().Add(options.expiry(azureSignedURLTTL)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	info = &DownloadInfo{
+		Meta: ObjectMeta{
+			OCIMediaType:       azureAnnotation(props.Metadata, ObjectAnnotationOCIContentType),
+			Digest:             azureAnnotation(props.Metadata, ObjectAnnotationDigest),
+			UncompressedDigest: azureAnnotation(props.Metadata, ObjectAnnotationUncompressedDigest),
+		},
+		URL: url,
+	}
+	if props.ContentType != nil {
+		info.Meta.ContentType = *props.ContentType
+	}
+	if props.ContentLength != nil {
+		info.Size = *props.ContentLength
+	}
+	return info, nil
+}
+
+// SignUpload describes an object for upload. Azure rejects uploads using the URL unless they carry the returned headers.
+func (s *PresignedAzureStorage) SignUpload(ctx context.Context, bucket, obj string, options *SignedURLOptions) (info *UploadInfo, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "azure.SignUpload")
+	defer tracing.FinishSpan(span, &err)
+
+	url, err := s.BlobClientFactory(bucket, obj).GetSASURL(sas.BlobPermissions{Create: true, Write: true}, time.Now().Add(options.expiry(azureSignedURLTTL)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{
+		"x-ms-blob-type": "BlockBlob",
+	}
+	if options != nil && options.ContentType != "" {
+		headers["Content-Type"] = options.ContentType
+	}
+	if options != nil && options.IfNotExists {
+		headers["If-None-Match"] = IfNotExistsHeaders["If-None-Match"]
+	}
+	return &UploadInfo{URL: url, Headers: headers}, nil
+}
+
+// DeleteObject deletes objects in the given bucket specified by the given query
+func (s *PresignedAzureStorage) DeleteObject(ctx context.Context, bucket string, query *DeleteObjectQuery) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "azure.DeleteObject")
+	defer tracing.FinishSpan(span, &err)
+
+	if query.Name != "" {
+		_, err = s.client.DeleteBlob(ctx, bucket, query.Name, nil)
+		if err != nil {
+			log.WithField("bucket", bucket).WithField("object", query.Name).Error(err)
+			return translateAzureError(err)
+		}
+		return nil
+	}
+	if query.Prefix == "" {
+		return nil
+	}
+
+	var names []string
+	err = azureListBlobs(ctx, s.client, bucket, query.Prefix, func(obj ObjectInfo) {
+		names = append(names, obj.Name)
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		_, derr := s.client.DeleteBlob(ctx, bucket, name, nil)
+		if derr != nil && !errors.Is(translateAzureError(derr), ErrNotFound) {
+			log.WithField("bucket", bucket).WithField("object", name).Error(derr)
+			err = derr
+		}
+	}
+	return translateAzureError(err)
+}
+
+// DeleteBucket deletes a bucket
+func (s *PresignedAzureStorage) DeleteBucket(ctx context.Context, userID, bucket string) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "azure.DeleteBucket")
+	defer tracing.FinishSpan(span, &err)
+
+	if s.AzureConfig.Container != "" {
+		// the container is shared with other users - we must only delete the user's content
+		if userID == "" {
+			return xerrors.Errorf("userID is required to delete from a shared container")
+		}
+		return s.DeleteObject(ctx, bucket, &DeleteObjectQuery{Prefix: userID + "/"})
+	}
+
+	_, err = s.client.DeleteContainer(ctx, bucket, nil)
+	return translateAzureError(err)
+}
+
+// ObjectHash gets a hash value of an object
+func (s *PresignedAzureStorage) ObjectHash(ctx context.Context, bucket string, obj string) (hash string, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "azure.ObjectHash")
+	defer tracing.FinishSpan(span, &err)
+
+	props, err := s.BlobClientFactory(bucket, obj).GetProperties(ctx, nil)
+	if err != nil {
+		return "", translateAzureError(err)
+	}
+	if props.ETag == nil {
+		return "", nil
+	}
+	return strings.Trim(string(*props.ETag), `"`), nil
+}
+
+// ObjectExists tells whether the given object exists or not
+func (s *PresignedAzureStorage) ObjectExists(ctx context.Context, bucket string, obj string) (exists bool, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "azure.ObjectExists")
+	defer tracing.FinishSpan(span, &err)
+
+	_, err = s.BlobClientFactory(bucket, obj).GetProperties(ctx, nil)
+	err = translateAzureError(err)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage/mock"
+)
+
+type mockedAzurePresignedAccess struct {
+	storage.PresignedAccess
+}
+
+func (m mockedAzurePresignedAccess) ForTestCreateObj(ctx context.Context, bucket, path, content string) error {
+	return nil
+}
+
+func (m mockedAzurePresignedAccess) ForTestReset(ctx context.Context) error {
+	return nil
+}
+
+func TestAzurePresignedHappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	azc := mock.NewMockAzureClient(ctrl)
+	azc.EXPECT().NewListBlobsFlatPager(gomock.Any(), gomock.Any()).Return(runtime.NewPager(runtime.PagingHandler[azblob.ListBlobsFlatResponse]{
+		More: func(azblob.ListBlobsFlatResponse) bool { return false },
+		Fetcher: func(context.Context, *azblob.ListBlobsFlatResponse) (azblob.ListBlobsFlatResponse, error) {
+			var resp azblob.ListBlobsFlatResponse
+			resp.Segment = &container.BlobFlatListSegment{
+				BlobItems: []*container.BlobItem{
+					{Name: to.Ptr("foo/bar.txt"), Properties: &container.BlobProperties{ContentLength: to.Ptr(int64(100))}},
+				},
+			}
+			return resp, nil
+		},
+	}))
+
+	bc := mock.NewMockAzureBlobClient(ctrl)
+	bc.EXPECT().GetProperties(gomock.Any(), gomock.Any()).Return(blob.GetPropertiesResponse{
+		ContentLength: to.Ptr(int64(100)),
+		ETag:          to.Ptr(azcore.ETag(`"foobar"`)),
+	}, nil).AnyTimes()
+	bc.EXPECT().GetSASURL(gomock.Any(), gomock.Any(), gomock.Any()).Return("some value", nil).AnyTimes()
+
+	dut := storage.NewPresignedAzureAccess(azc, config.AzureConfig{AccountName: "test"})
+	dut.BlobClientFactory = func(container, name string) storage.AzureBlobClient { return bc }
+
+	ps := mockedAzurePresignedAccess{
+		PresignedAccess: dut,
+	}
+
+	SuiteTestPresignedAccess(t, ps)
+}
+
+func TestAzurePresignedSignUpload(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Options     *storage.SignedURLOptions
+		Headers     map[string]string
+		ExpiryAfter time.Duration
+	}{
+		{
+			Name:        "no options",
+			Headers:     map[string]string{"x-ms-blob-type": "BlockBlob"},
+			ExpiryAfter: 30 * time.Minute,
+		},
+		{
+			Name:    "content type and if not exists",
+			Options: &storage.SignedURLOptions{ContentType: "application/json", IfNotExists: true},
+			Headers: map[string]string{
+				"x-ms-blob-type": "BlockBlob",
+				"Content-Type":   "application/json",
+				"If-None-Match":  "*",
+			},
+			ExpiryAfter: 30 * time.Minute,
+		},
+		{
+			Name:        "expiry",
+			Options:     &storage.SignedURLOptions{Expiry: 2 * time.Hour},
+			Headers:     map[string]string{"x-ms-blob-type": "BlockBlob"},
+			ExpiryAfter: 2 * time.Hour,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var expiry time.Time
+			bc := mock.NewMockAzureBlobClient(ctrl)
+			bc.EXPECT().GetSASURL(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ sas.BlobPermissions, e time.Time, _ *blob.GetSASURLOptions) (string, error) {
+				expiry = e
+				return "some value", nil
+			})
+
+			dut := storage.NewPresignedAzureAccess(mock.NewMockAzureClient(ctrl), config.AzureConfig{AccountName: "test"})
+			dut.BlobClientFactory = func(container, name string) storage.AzureBlobClient { return bc }
+
+			start := time.Now()
+			info, err := dut.SignUpload(context.Background(), "bucket", "obj", test.Options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Headers, info.Headers); diff != "" {
+				t.Errorf("unexpected headers (-want +got):\n%s", diff)
+			}
+			if expiry.Before(start.Add(test.ExpiryAfter)) || expiry.After(time.Now().Add(test.ExpiryAfter)) {
+				t.Errorf("unexpected expiry %s, expected %s from now", expiry, test.ExpiryAfter)
+			}
+		})
+	}
+}
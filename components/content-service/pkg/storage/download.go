@@ -0,0 +1,350 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+)
+
+// DownloadPath is the path under which the content-service serves downloads of workspace content
+// which cannot be downloaded from the storage directly
+const DownloadPath = "/download/"
+
+const (
+	// downloadURLTTL is the time download URLs signed by a DownloadSigner are valid for
+	downloadURLTTL = 30 * time.Minute
+
+	// downloadModeObject serves the plaintext of an object
+	downloadModeObject = "object"
+
+	// downloadModeChunkedBackup serves a chunked backup as the tarball its chunks assemble to
+	downloadModeChunkedBackup = "chunked-backup"
+
+	// chunkedBackupSignBatch is the number of chunks we sign the downloads of at once while serving a chunked backup.
+	// Signing all chunks up front would let the URLs of the last chunks expire while we serve large backups.
+	chunkedBackupSignBatch = 256
+)
+
+// chunkManifestRegex matches chunk manifests and captures the prefix the chunks of the backup share
+var chunkManifestRegex = regexp.MustCompile(`^(.*?)(?:` + regexp.QuoteMeta(DefaultChunkedBackupManifest) + `|` + regexp.QuoteMeta(BackupVersionPrefix) + `\d+\.chunks\.json)$`)
+
+// ValidateDownloadConfig checks that the download config is complete
+func ValidateDownloadConfig(c *config.DownloadConfig) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.URL, validation.Required),
+		validation.Field(&c.SigningKeyFile, validation.Required),
+	)
+}
+
+// DownloadSigner signs the URLs of the downloads the content-service serves under DownloadPath
+type DownloadSigner struct {
+	objects        *localURLSigner
+	chunkedBackups *localURLSigner
+}
+
+// NewDownloadSigner creates a new download signer
+func NewDownloadSigner(cfg config.DownloadConfig) (*DownloadSigner, error) {
+	if err := ValidateDownloadConfig(&cfg); err != nil {
+		return nil, err
+	}
+	objects, err := newURLSigner(cfg.URL, DownloadPath+downloadModeObject+"/", cfg.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	chunkedBackups, err := newURLSigner(cfg.URL, DownloadPath+downloadModeChunkedBackup+"/", cfg.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &DownloadSigner{objects: objects, chunkedBackups: chunkedBackups}, nil
+}
+
+// SignObject produces a URL which serves the plaintext of an object
+func (s *DownloadSigner) SignObject(bucket, obj string) string {
+	return s.objects.Sign(http.MethodGet, bucket, obj, "", time.Now().Add(downloadURLTTL))
+}
+
+// SignChunkedBackup produces a URL which serves the tarball the chunks of a chunked backup assemble to.
+// manifest is the object name of the chunk manifest, e.g. BackupObject(owner, workspace, DefaultChunkedBackupManifest).
+func (s *DownloadSigner) SignChunkedBackup(bucket, manifest string) string {
+	return s.chunkedBackups.Sign(http.MethodGet, bucket, manifest, "", time.Now().Add(downloadURLTTL))
+}
+
+// NewDownloadHandler serves the URLs a DownloadSigner produces. Register it under DownloadPath.
+func NewDownloadHandler(cfg *config.StorageConfig) (http.Handler, error) {
+	if cfg.Download == nil {
+		return nil, xerrors.Errorf("missing download config")
+	}
+	signer, err := NewDownloadSigner(*cfg.Download)
+	if err != nil {
+		return nil, err
+	}
+	s, err := NewPresignedAccess(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &downloadHandler{
+		signer:  signer,
+		storage: s,
+		client:  &http.Client{},
+	}, nil
+}
+
+type downloadHandler struct {
+	signer  *DownloadSigner
+	storage PresignedAccess
+	client  *http.Client
+}
+
+func (h *downloadHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	segs := strings.SplitN(strings.TrimPrefix(req.URL.Path, DownloadPath), "/", 3)
+	if len(segs) != 3 || segs[1] == "" || segs[2] == "" {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	mode, bucket, obj := segs[0], segs[1], segs[2]
+
+	var (
+		signer *localURLSigner
+		serve  func(w http.ResponseWriter, req *http.Request, bucket, obj string)
+	)
+	switch mode {
+	case downloadModeObject:
+		signer, serve = h.signer.objects, h.serveObject
+	case downloadModeChunkedBackup:
+		signer, serve = h.signer.chunkedBackups, h.serveChunkedBackup
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	err := signer.Verify(req, bucket, obj)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	serve(w, req, bucket, obj)
+}
+
+func (h *downloadHandler) serveObject(w http.ResponseWriter, req *http.Request, bucket, obj string) {
+	src, info, err := h.open(req.Context(), bucket, obj)
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("bucket", bucket).WithField("object", obj).Warn("cannot serve download")
+		http.Error(w, "cannot read object", http.StatusInternalServerError)
+		return
+	}
+	defer src.Close()
+
+	if info.Meta.ContentType != "" {
+		w.Header().Set("Content-Type", info.Meta.ContentType)
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	if req.Method == http.MethodHead {
+		return
+	}
+	fw := &flushWriter{w: w}
+	_, err = io.Copy(fw, src)
+	if err != nil {
+		log.WithError(err).WithField("bucket", bucket).WithField("object", obj).Warn("cannot serve download")
+		failDownload(w, fw.n)
+	}
+}
+
+func (h *downloadHandler) serveChunkedBackup(w http.ResponseWriter, req *http.Request, bucket, manifest string) {
+	segs := chunkManifestRegex.FindStringSubmatch(manifest)
+	if segs == nil {
+		http.Error(w, "not a chunk manifest", http.StatusNotFound)
+		return
+	}
+	prefix := segs[1]
+
+	var mf csapi.ChunkedBackupManifest
+	src, _, err := h.open(req.Context(), bucket, manifest)
+	if err == nil {
+		err = json.NewDecoder(src).Decode(&mf)
+		src.Close()
+	}
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("bucket", bucket).WithField("object", manifest).Warn("cannot serve chunked backup")
+		http.Error(w, "cannot read chunk manifest", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Length", strconv.FormatInt(mf.Size, 10))
+	if req.Method == http.MethodHead {
+		return
+	}
+	fw := &flushWriter{w: w}
+	err = h.writeChunks(req.Context(), fw, bucket, prefix, &mf)
+	if err != nil {
+		log.WithError(err).WithField("bucket", bucket).WithField("object", manifest).Warn("cannot serve chunked backup")
+		failDownload(w, fw.n)
+	}
+}
+
+// failDownload reports an error which occurred while we wrote a download. Once we have written content, the client
+// has received the headers already. We abort the response then, s.t. the client does not mistake it for the complete download.
+func failDownload(w http.ResponseWriter, written int64) {
+	if written > 0 {
+		panic(http.ErrAbortHandler)
+	}
+	w.Header().Del("Content-Length")
+	http.Error(w, "cannot read object", http.StatusInternalServerError)
+}
+
+// flushWriter flushes the response after every write. Clients retry requests whose connection is closed before
+// they received a response, hence aborted responses must have reached the client already.
+type flushWriter struct {
+	w http.ResponseWriter
+	n int64
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	f.n += int64(n)
+	if flusher, ok := f.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return n, err
+}
+
+// writeChunks writes the chunks of a chunked backup to dst in order. Every chunk is verified before we write it.
+func (h *downloadHandler) writeChunks(ctx context.Context, dst io.Writer, bucket, prefix string, mf *csapi.ChunkedBackupManifest) error {
+	if !mf.Digest.Algorithm().Available() {
+		return xerrors.Errorf("unsupported digest algorithm: %s", mf.Digest.Algorithm())
+	}
+	var (
+		digester = mf.Digest.Algorithm().Digester()
+		buf      bytes.Buffer
+	)
+	for start := 0; start < len(mf.Chunks); start += chunkedBackupSignBatch {
+		batch := mf.Chunks[start:]
+		if len(batch) > chunkedBackupSignBatch {
+			batch = batch[:chunkedBackupSignBatch]
+		}
+		objs := make([]string, len(batch))
+		for i, c := range batch {
+			objs[i] = prefix + BackupChunkName(c.Digest)
+		}
+		infos, err := SignDownloads(ctx, h.storage, bucket, objs, &SignedURLOptions{})
+		if err != nil {
+			return err
+		}
+
+		for i, c := range batch {
+			buf.Reset()
+			err := h.readChunk(ctx, &buf, infos[i], c)
+			if err != nil {
+				return xerrors.Errorf("cannot read backup chunk %s: %w", c.Digest, err)
+			}
+			_, _ = digester.Hash().Write(buf.Bytes())
+			_, err = dst.Write(buf.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if act := digester.Digest(); act != mf.Digest {
+		return xerrors.Errorf("backup digest mismatch: expected %s, got %s", mf.Digest, act)
+	}
+	return nil
+}
+
+// readChunk downloads and decrypts a backup chunk and verifies it against the manifest
+func (h *downloadHandler) readChunk(ctx context.Context, dst *bytes.Buffer, info *DownloadInfo, chunk csapi.BackupChunk) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, info.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return xerrors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	plain, err := Decrypt(resp.Body, info.DataKey)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, io.LimitReader(plain, chunk.Size+1))
+	if err != nil {
+		return err
+	}
+	if int64(dst.Len()) != chunk.Size {
+		return xerrors.Errorf("size mismatch: expected %d bytes, got %d", chunk.Size, dst.Len())
+	}
+	if act := digest.FromBytes(dst.Bytes()); act != chunk.Digest {
+		return xerrors.Errorf("digest mismatch: got %s", act)
+	}
+	return nil
+}
+
+// open downloads an object and decrypts it if it is encrypted
+func (h *downloadHandler) open(ctx context.Context, bucket, obj string) (io.ReadCloser, *DownloadInfo, error) {
+	info, err := h.storage.SignDownload(ctx, bucket, obj, &SignedURLOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, info.URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, nil, ErrNotFound
+	default:
+		resp.Body.Close()
+		return nil, nil, xerrors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	plain, err := Decrypt(resp.Body, info.DataKey)
+	if err != nil {
+		resp.Body.Close()
+		return nil, nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{plain, resp.Body}, info, nil
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+)
+
+// newTestDownloadServer serves an encrypted local storage and its downloads
+func newTestDownloadServer(t *testing.T) *config.StorageConfig {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	tmpdir := t.TempDir()
+	keyFile := filepath.Join(tmpdir, "signing-key")
+	keyring := filepath.Join(tmpdir, "keyring.json")
+	for fn, content := range map[string][]byte{
+		keyFile: []byte("secret\n"),
+		keyring: []byte(`{"current": "k1", "keys": {"k1": "` + base64.StdEncoding.EncodeToString(make([]byte, 32)) + `"}}`),
+	} {
+		err := os.WriteFile(fn, content, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := &config.StorageConfig{
+		Kind: config.LocalStorage,
+		LocalConfig: &config.LocalConfig{
+			Directory:      filepath.Join(tmpdir, "storage"),
+			URL:            srv.URL,
+			SigningKeyFile: keyFile,
+		},
+		Encryption: &config.EncryptionConfig{
+			KMS:  config.FileKMS,
+			File: &config.FileKMSConfig{KeyringFile: keyring},
+		},
+		Download: &config.DownloadConfig{
+			URL:            srv.URL,
+			SigningKeyFile: keyFile,
+		},
+	}
+
+	local, err := NewLocalStorageHandler(*cfg.LocalConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux.Handle(LocalStoragePath, local)
+	downloads, err := NewDownloadHandler(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux.Handle(DownloadPath, downloads)
+
+	return cfg
+}
+
+func TestDownloadHandler(t *testing.T) {
+	cfg := newTestDownloadServer(t)
+
+	ctx := context.Background()
+	content := []byte("hello world")
+	da, err := NewDirectAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = da.Init(ctx, "owner", "ws1", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bkt, obj, err := da.UploadStream(ctx, bytes.NewReader(content), DefaultBackup, WithContentType("application/x-tar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ps, err := NewPresignedAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := ps.SignDownload(ctx, bkt, obj, &SignedURLOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.DataKey == nil || info.DecryptedURL == "" {
+		t.Fatalf("expected an encrypted object with decrypted URL, got %+v", info)
+	}
+
+	get := func(method, url string) (int, string, []byte) {
+		req, err := http.NewRequest(method, url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, resp.Header.Get("Content-Type"), body
+	}
+
+	code, _, body := get(http.MethodGet, info.URL)
+	if code != http.StatusOK || bytes.Equal(body, content) {
+		t.Errorf("expected the storage to serve ciphertext, got status %d", code)
+	}
+
+	code, contentType, body := get(http.MethodGet, info.DecryptedURL)
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d (%s)", code, body)
+	}
+	if !bytes.Equal(body, content) {
+		t.Errorf("unexpected download: %q", body)
+	}
+	if contentType != "application/x-tar" {
+		t.Errorf("unexpected content type: %s", contentType)
+	}
+
+	code, _, _ = get(http.MethodHead, info.DecryptedURL)
+	if code != http.StatusOK {
+		t.Errorf("unexpected status code of HEAD request: %d", code)
+	}
+
+	tests := []struct {
+		Name string
+		URL  string
+		Code int
+	}{
+		{Name: "tampered object", URL: strings.Replace(info.DecryptedURL, obj, obj+"x", 1), Code: http.StatusForbidden},
+		{Name: "tampered signature", URL: strings.Replace(info.DecryptedURL, "signature=", "signature=x", 1), Code: http.StatusForbidden},
+		{Name: "local storage signature", URL: strings.Replace(info.URL, LocalStoragePath, DownloadPath+downloadModeObject+"/", 1), Code: http.StatusForbidden},
+		{Name: "unknown mode", URL: strings.Replace(info.DecryptedURL, DownloadPath+downloadModeObject, DownloadPath+"unknown", 1), Code: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			code, _, _ := get(http.MethodGet, test.URL)
+			if code != test.Code {
+				t.Errorf("unexpected status code: want %d, got %d", test.Code, code)
+			}
+		})
+	}
+}
+
+func TestDownloadChunkedBackup(t *testing.T) {
+	cfg := newTestDownloadServer(t)
+
+	ctx := context.Background()
+	da, err := NewDirectAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = da.Init(ctx, "owner", "ws1", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	upload := func(name string, content []byte) {
+		_, _, err := da.UploadStream(ctx, bytes.NewReader(content), name)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	manifest := func(chunks ...[]byte) []byte {
+		var (
+			mf  csapi.ChunkedBackupManifest
+			all []byte
+		)
+		for _, c := range chunks {
+			mf.Chunks = append(mf.Chunks, csapi.BackupChunk{Digest: digest.FromBytes(c), Size: int64(len(c))})
+			mf.Size += int64(len(c))
+			all = append(all, c...)
+		}
+		mf.Digest = digest.FromBytes(all)
+		res, err := json.Marshal(mf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	chunks := [][]byte{[]byte("first chunk,"), []byte("second chunk,"), []byte("first chunk,"), []byte("last chunk")}
+	for _, c := range chunks {
+		upload(BackupChunkName(digest.FromBytes(c)), c)
+	}
+	upload(DefaultChunkedBackupManifest, manifest(chunks...))
+	upload(BackupVersionName(1, true), manifest(chunks[1:]...))
+	tampered := []byte("tampered chunk")
+	upload(BackupChunkName(digest.FromBytes([]byte("original chunk"))), tampered)
+	upload(BackupVersionName(2, true), manifest([]byte("original chunk")))
+	upload(BackupVersionName(3, true), manifest([]byte("missing chunk")))
+	upload(BackupVersionName(5, true), manifest(chunks[0], []byte("original chunk")))
+
+	ps, err := NewPresignedAccess(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewDownloadSigner(*cfg.Download)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name          string
+		Manifest      string
+		Expectation   []byte
+		Code          int
+		ExpectedError bool
+	}{
+		{Name: "latest backup", Manifest: DefaultChunkedBackupManifest, Expectation: bytes.Join(chunks, nil), Code: http.StatusOK},
+		{Name: "backup version", Manifest: BackupVersionName(1, true), Expectation: bytes.Join(chunks[1:], nil), Code: http.StatusOK},
+		{Name: "tampered chunk", Manifest: BackupVersionName(2, true), Code: http.StatusInternalServerError},
+		{Name: "tampered later chunk", Manifest: BackupVersionName(5, true), Code: http.StatusOK, ExpectedError: true},
+		{Name: "missing chunk", Manifest: BackupVersionName(3, true), Code: http.StatusInternalServerError},
+		{Name: "missing manifest", Manifest: BackupVersionName(4, true), Code: http.StatusNotFound},
+		{Name: "not a manifest", Manifest: DefaultBackup, Code: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			resp, err := http.Get(signer.SignChunkedBackup(ps.Bucket("owner"), ps.BackupObject("owner", "ws1", test.Manifest)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != test.Code {
+				t.Fatalf("unexpected status code: want %d, got %d", test.Code, resp.StatusCode)
+			}
+			if resp.StatusCode != http.StatusOK {
+				return
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if test.ExpectedError {
+				if err == nil {
+					t.Fatalf("expected the download to fail, got %q", body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(body, test.Expectation) {
+				t.Errorf("unexpected download: want %q, got %q", test.Expectation, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,716 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"path"
+	"regexp"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// Workspace content is encrypted using envelope encryption: each workspace has its own data key which encrypts
+// all objects of that workspace. The data key itself is stored next to the workspace content, wrapped by a
+// key encryption key managed by a KMS. Rotating the key encryption key only requires re-wrapping the data keys.
+//
+// Encrypted objects start with a header which references the data key object, followed by the content sealed
+// with AES-256-GCM in segments. Each segment's nonce contains its index and whether it's the last segment,
+// so that reordering or truncating segments is detected.
+
+const (
+	// DefaultDataKey is the name of the object holding the wrapped data key of a workspace
+	DefaultDataKey = "datakey.json"
+
+	dataKeySize          = 32
+	encSegmentSize       = 64 * 1024
+	encNoncePrefixSize   = 7
+	encMaxKeyObjectSize  = 1024
+	encMaxSegmentCounter = math.MaxUint32
+
+	// dataKeyCacheSize is the number of unwrapped data keys kept in memory
+	dataKeyCacheSize = 4096
+)
+
+var (
+	encMagic = []byte("GPENC\x01")
+
+	fullWorkspaceBackupRegex = regexp.MustCompile(`^wsfull-\d+\.tar$`)
+)
+
+var (
+	// ErrMissingDataKey is returned when an object is encrypted but no data key is available to decrypt it
+	ErrMissingDataKey = xerrors.Errorf("object is encrypted but no data key is available")
+)
+
+// KMS wraps and unwraps data keys using key encryption keys it manages
+type KMS interface {
+	// CurrentKeyID returns the ID of the key encryption key new data keys are wrapped with
+	CurrentKeyID() string
+
+	// WrapKey encrypts a data key with the current key encryption key
+	WrapKey(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error)
+
+	// UnwrapKey decrypts a data key which was wrapped with the key encryption key identified by keyID
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) (dataKey []byte, err error)
+}
+
+// DataKeyRotator is implemented by presigned access to storage which encrypts workspace content
+type DataKeyRotator interface {
+	// RotateDataKeys re-wraps all data keys which are not wrapped with the current key encryption key
+	// without re-uploading any content. Once it succeeded, retired key encryption keys can be removed from the KMS.
+	RotateDataKeys(ctx context.Context) (rotated int, err error)
+}
+
+// wrappedDataKey is the content of a data key object
+type wrappedDataKey struct {
+	KeyID      string `json:"keyID"`
+	WrappedKey []byte `json:"wrappedKey"`
+}
+
+// objectStore provides raw access to objects, i.e. without encrypting or decrypting them
+type objectStore interface {
+	// readObject returns ErrNotFound if the object does not exist
+	readObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error)
+	writeObject(ctx context.Context, bkt, obj string, content []byte) error
+	// createObject writes the object unless it exists already, in which case errObjectExists is returned
+	createObject(ctx context.Context, bkt, obj string, content []byte) error
+}
+
+// envelope encrypts and decrypts objects using per-workspace data keys
+type envelope struct {
+	KMS KMS
+
+	keys *lru.Cache
+	// loads makes concurrent requests for the same data key share a single round trip to the storage and KMS
+	loads singleflight.Group
+}
+
+func newEnvelope(kms KMS) *envelope {
+	// lru.New only fails for non-positive sizes
+	keys, _ := lru.New(dataKeyCacheSize)
+	return &envelope{
+		KMS:  kms,
+		keys: keys,
+	}
+}
+
+// dataKey returns the unwrapped data key stored in the key object. If create is true, a new data key is created
+// if there is none yet, and data keys wrapped by a retired key encryption key are re-wrapped with the current one.
+func (e *envelope) dataKey(ctx context.Context, store objectStore, bkt, keyObj string, create bool) ([]byte, error) {
+	cacheKey := bkt + "/" + keyObj
+	if dk, ok := e.keys.Get(cacheKey); ok {
+		return dk.([]byte), nil
+	}
+
+	flight := "read/" + cacheKey
+	if create {
+		flight = "create/" + cacheKey
+	}
+	dk, err, _ := e.loads.Do(flight, func() (interface{}, error) {
+		return e.loadDataKey(ctx, store, bkt, keyObj, create)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dk.([]byte), nil
+}
+
+func (e *envelope) loadDataKey(ctx context.Context, store objectStore, bkt, keyObj string, create bool) ([]byte, error) {
+	cacheKey := bkt + "/" + keyObj
+
+	stored, err := readDataKey(ctx, store, bkt, keyObj)
+	if errors.Is(err, ErrNotFound) {
+		if !create {
+			return nil, xerrors.Errorf("data key %s does not exist", keyObj)
+		}
+
+		dk := make([]byte, dataKeySize)
+		_, err = rand.Read(dk)
+		if err != nil {
+			return nil, err
+		}
+		content, err := e.wrapDataKey(ctx, dk)
+		if err != nil {
+			return nil, err
+		}
+		err = store.createObject(ctx, bkt, keyObj, content)
+		if errors.Is(err, errObjectExists) {
+			// someone else created the data key in the meantime - theirs is the one content gets encrypted with
+			return e.loadDataKey(ctx, store, bkt, keyObj, false)
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("cannot write data key %s: %w", keyObj, err)
+		}
+		e.keys.Add(cacheKey, dk)
+		return dk, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dk, err := e.KMS.UnwrapKey(ctx, stored.KeyID, stored.WrappedKey)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unwrap data key %s: %w", keyObj, err)
+	}
+	if create && stored.KeyID != e.KMS.CurrentKeyID() {
+		// the key encryption key was rotated - re-wrapping the data key is all it takes
+		err = e.writeDataKey(ctx, store, bkt, keyObj, dk)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot rewrap data key %s: %w", keyObj, err)
+		}
+	}
+	e.keys.Add(cacheKey, dk)
+	return dk, nil
+}
+
+// readDataKey returns an error wrapping ErrNotFound if the key object does not exist
+func readDataKey(ctx context.Context, store objectStore, bkt, keyObj string) (*wrappedDataKey, error) {
+	rc, err := store.readObject(ctx, bkt, keyObj)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read data key %s: %w", keyObj, err)
+	}
+	defer rc.Close()
+
+	var stored wrappedDataKey
+	err = json.NewDecoder(rc).Decode(&stored)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read data key %s: %w", keyObj, err)
+	}
+	return &stored, nil
+}
+
+func (e *envelope) wrapDataKey(ctx context.Context, dk []byte) ([]byte, error) {
+	keyID, wrapped, err := e.KMS.WrapKey(ctx, dk)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot wrap data key: %w", err)
+	}
+	return json.Marshal(wrappedDataKey{KeyID: keyID, WrappedKey: wrapped})
+}
+
+func (e *envelope) writeDataKey(ctx context.Context, store objectStore, bkt, keyObj string, dk []byte) error {
+	content, err := e.wrapDataKey(ctx, dk)
+	if err != nil {
+		return err
+	}
+	return store.writeObject(ctx, bkt, keyObj, content)
+}
+
+// rotateDataKey re-wraps the data key stored in keyObj with the current key encryption key, unless it already is.
+// The objects encrypted with the data key remain unchanged.
+func (e *envelope) rotateDataKey(ctx context.Context, store objectStore, bkt, keyObj string) (rotated bool, err error) {
+	stored, err := readDataKey(ctx, store, bkt, keyObj)
+	if err != nil {
+		return false, err
+	}
+	if stored.KeyID == e.KMS.CurrentKeyID() {
+		return false, nil
+	}
+
+	dk, err := e.KMS.UnwrapKey(ctx, stored.KeyID, stored.WrappedKey)
+	if err != nil {
+		return false, xerrors.Errorf("cannot unwrap data key %s: %w", keyObj, err)
+	}
+	err = e.writeDataKey(ctx, store, bkt, keyObj, dk)
+	if err != nil {
+		return false, xerrors.Errorf("cannot rewrap data key %s: %w", keyObj, err)
+	}
+	return true, nil
+}
+
+// Encrypt returns a reader which produces src encrypted with the data key stored in keyObj.
+// The data key is created if it doesn't exist yet. Callers must close the returned reader.
+func (e *envelope) Encrypt(ctx context.Context, store objectStore, bkt, keyObj string, src io.Reader) (io.ReadCloser, error) {
+	dk, err := e.dataKey(ctx, store, bkt, keyObj, true)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := newEncryptionHeader(keyObj)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newSegmentAEAD(dk)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(sealSegments(pw, src, aead, header))
+	}()
+	return pr, nil
+}
+
+// Decrypt returns a reader which produces the plaintext of src. Objects which are not encrypted are passed through.
+func (e *envelope) Decrypt(ctx context.Context, store objectStore, bkt string, src io.Reader) (io.Reader, error) {
+	br := bufio.NewReaderSize(src, encSegmentSize)
+	hdr, encrypted, err := readEncryptionHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		return br, nil
+	}
+
+	dk, err := e.dataKey(ctx, store, bkt, hdr.KeyObject, false)
+	if err != nil {
+		return nil, err
+	}
+	return newDecryptingReader(br, dk, hdr)
+}
+
+// DataKeyFor returns the data key required to decrypt an object, or nil if the object is not encrypted.
+// header is the beginning of the object and must contain at least its encryption header.
+func (e *envelope) DataKeyFor(ctx context.Context, store objectStore, bkt string, header io.Reader) ([]byte, error) {
+	hdr, encrypted, err := readEncryptionHeader(bufio.NewReader(header))
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		return nil, nil
+	}
+	return e.dataKey(ctx, store, bkt, hdr.KeyObject, false)
+}
+
+// encryptable returns false for objects which are served straight from the storage and hence must remain plaintext,
+// i.e. full workspace backups which become image layers.
+func encryptable(name string) bool {
+	return !fullWorkspaceBackupRegex.MatchString(path.Base(name))
+}
+
+// encryptUpload encrypts src with the workspace's data key if encryption is enabled, i.e. e is not nil,
+// and the object named name is encryptable.
+func encryptUpload(ctx context.Context, e *envelope, store objectStore, bkt, keyObj, name string, src io.Reader) (io.ReadCloser, error) {
+	if e == nil || !encryptable(name) {
+		return io.NopCloser(src), nil
+	}
+	return e.Encrypt(ctx, store, bkt, keyObj, src)
+}
+
+// decryptDownload decrypts src if encryption is enabled, i.e. e is not nil
+func decryptDownload(ctx context.Context, e *envelope, store objectStore, bkt string, src io.Reader) (io.Reader, error) {
+	if e == nil {
+		return src, nil
+	}
+	return e.Decrypt(ctx, store, bkt, src)
+}
+
+// Decrypt returns a reader which produces the plaintext of src using the given data key.
+// Objects which are not encrypted are passed through. If src is encrypted but dataKey is nil, ErrMissingDataKey is returned.
+func Decrypt(src io.Reader, dataKey []byte) (io.Reader, error) {
+	br := bufio.NewReaderSize(src, encSegmentSize)
+	hdr, encrypted, err := readEncryptionHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		return br, nil
+	}
+	if dataKey == nil {
+		return nil, ErrMissingDataKey
+	}
+	return newDecryptingReader(br, dataKey, hdr)
+}
+
+type encryptionHeader struct {
+	KeyObject   string
+	NoncePrefix []byte
+
+	// raw is the serialized header which is authenticated with every segment
+	raw []byte
+}
+
+func newEncryptionHeader(keyObj string) (*encryptionHeader, error) {
+	if len(keyObj) > encMaxKeyObjectSize {
+		return nil, xerrors.Errorf("data key object name is too long: %s", keyObj)
+	}
+
+	prefix := make([]byte, encNoncePrefixSize)
+	_, err := rand.Read(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, 0, len(encMagic)+2+len(keyObj)+encNoncePrefixSize)
+	raw = append(raw, encMagic...)
+	raw = binary.BigEndian.AppendUint16(raw, uint16(len(keyObj)))
+	raw = append(raw, keyObj...)
+	raw = append(raw, prefix...)
+	return &encryptionHeader{KeyObject: keyObj, NoncePrefix: prefix, raw: raw}, nil
+}
+
+// readEncryptionHeader consumes the encryption header from r. If r does not start with an encryption header,
+// nothing is consumed and encrypted is false.
+func readEncryptionHeader(r *bufio.Reader) (hdr *encryptionHeader, encrypted bool, err error) {
+	magic, err := r.Peek(len(encMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, false, err
+	}
+	if !bytes.Equal(magic, encMagic) {
+		return nil, false, nil
+	}
+
+	raw := make([]byte, len(encMagic)+2)
+	_, err = io.ReadFull(r, raw)
+	if err != nil {
+		return nil, true, xerrors.Errorf("cannot read encryption header: %w", err)
+	}
+	l := int(binary.BigEndian.Uint16(raw[len(encMagic):]))
+	if l > encMaxKeyObjectSize {
+		return nil, true, xerrors.Errorf("invalid encryption header")
+	}
+	rest := make([]byte, l+encNoncePrefixSize)
+	_, err = io.ReadFull(r, rest)
+	if err != nil {
+		return nil, true, xerrors.Errorf("cannot read encryption header: %w", err)
+	}
+	raw = append(raw, rest...)
+
+	return &encryptionHeader{
+		KeyObject:   string(rest[:l]),
+		NoncePrefix: rest[l:],
+		raw:         raw,
+	}, true, nil
+}
+
+func newSegmentAEAD(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func segmentNonce(dst []byte, prefix []byte, idx uint32, last bool) []byte {
+	dst = append(dst[:0], prefix...)
+	dst = binary.BigEndian.AppendUint32(dst, idx)
+	if last {
+		return append(dst, 1)
+	}
+	return append(dst, 0)
+}
+
+func sealSegments(dst io.Writer, src io.Reader, aead cipher.AEAD, hdr *encryptionHeader) error {
+	_, err := dst.Write(hdr.raw)
+	if err != nil {
+		return err
+	}
+
+	var (
+		br    = bufio.NewReaderSize(src, encSegmentSize)
+		plain = make([]byte, encSegmentSize)
+		ct    = make([]byte, 0, encSegmentSize+aead.Overhead())
+		nonce = make([]byte, 0, aead.NonceSize())
+	)
+	for idx := uint32(0); ; idx++ {
+		n, err := io.ReadFull(br, plain)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+		last := err != nil
+		if !last {
+			_, perr := br.Peek(1)
+			if errors.Is(perr, io.EOF) {
+				last = true
+			} else if perr != nil {
+				return perr
+			}
+		}
+		if !last && idx == encMaxSegmentCounter {
+			return xerrors.Errorf("content is too large to be encrypted")
+		}
+
+		ct = aead.Seal(ct[:0], segmentNonce(nonce, hdr.NoncePrefix, idx, last), plain[:n], hdr.raw)
+		_, err = dst.Write(ct)
+		if err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+	}
+}
+
+type decryptingReader struct {
+	src   *bufio.Reader
+	aead  cipher.AEAD
+	hdr   *encryptionHeader
+	idx   uint32
+	ct    []byte
+	nonce []byte
+	plain []byte
+	pos   int
+	done  bool
+}
+
+func newDecryptingReader(src *bufio.Reader, dataKey []byte, hdr *encryptionHeader) (*decryptingReader, error) {
+	aead, err := newSegmentAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &decryptingReader{
+		src:   src,
+		aead:  aead,
+		hdr:   hdr,
+		ct:    make([]byte, encSegmentSize+aead.Overhead()),
+		nonce: make([]byte, 0, aead.NonceSize()),
+	}, nil
+}
+
+func (r *decryptingReader) Read(p []byte) (n int, err error) {
+	for r.pos == len(r.plain) {
+		if r.done {
+			return 0, io.EOF
+		}
+		err = r.openSegment()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n = copy(p, r.plain[r.pos:])
+	r.pos += n
+	return n, nil
+}
+
+func (r *decryptingReader) openSegment() error {
+	n, err := io.ReadFull(r.src, r.ct)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	last := err != nil
+	if !last {
+		_, perr := r.src.Peek(1)
+		if errors.Is(perr, io.EOF) {
+			last = true
+		} else if perr != nil {
+			return perr
+		}
+	}
+	if n < r.aead.Overhead() {
+		return xerrors.Errorf("encrypted content is truncated")
+	}
+
+	plain, err := r.aead.Open(r.plain[:0], segmentNonce(r.nonce, r.hdr.NoncePrefix, r.idx, last), r.ct[:n], r.hdr.raw)
+	if err != nil {
+		return xerrors.Errorf("cannot decrypt segment %d: %w", r.idx, err)
+	}
+	r.plain = plain
+	r.pos = 0
+	r.idx++
+	r.done = last
+	return nil
+}
+
+var _ DataKeyRotator = &encryptedPresignedAccess{}
+
+// encryptedPresignedAccess adds the data key to the download info of encrypted objects,
+// so that whoever downloads the object using the signed URL can decrypt it. If downloads is not nil,
+// it also adds the URL under which the content-service serves the plaintext.
+type encryptedPresignedAccess struct {
+	PresignedAccess
+
+	envelope  *envelope
+	downloads *DownloadSigner
+	client    *http.Client
+}
+
+// ListBuckets forwards to the underlying storage if it implements ObjectLister
+func (p *encryptedPresignedAccess) ListBuckets(ctx context.Context) ([]string, error) {
+	lister, ok := p.PresignedAccess.(ObjectLister)
+	if !ok {
+		return nil, xerrors.Errorf("storage does not support listing objects")
+	}
+	return lister.ListBuckets(ctx)
+}
+
+// ListObjects forwards to the underlying storage if it implements ObjectLister
+func (p *encryptedPresignedAccess) ListObjects(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error) {
+	lister, ok := p.PresignedAccess.(ObjectLister)
+	if !ok {
+		return nil, xerrors.Errorf("storage does not support listing objects")
+	}
+	return lister.ListObjects(ctx, bucket, prefix)
+}
+
+// SignDownload describes an object for download - if the object is not found, ErrNotFound is returned
+func (p *encryptedPresignedAccess) SignDownload(ctx context.Context, bucket, obj string, options *SignedURLOptions) (info *DownloadInfo, err error) {
+	info, err = p.PresignedAccess.SignDownload(ctx, bucket, obj, options)
+	if err != nil {
+		return nil, err
+	}
+
+	maxHeaderSize := len(encMagic) + 2 + encMaxKeyObjectSize + encNoncePrefixSize
+	header, err := p.get(ctx, info.URL, maxHeaderSize)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read encryption header of %s: %w", obj, err)
+	}
+	defer header.Close()
+
+	info.DataKey, err = p.envelope.DataKeyFor(ctx, p, bucket, io.LimitReader(header, int64(maxHeaderSize)))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot get data key of %s: %w", obj, err)
+	}
+	if info.DataKey != nil && p.downloads != nil {
+		info.DecryptedURL = p.downloads.SignObject(bucket, obj)
+	}
+	return info, nil
+}
+
+// get downloads the first n bytes of the object behind a signed URL. If n is zero, the whole object is downloaded.
+func (p *encryptedPresignedAccess) get(ctx context.Context, url string, n int) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", n-1))
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		return resp.Body, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		// the object is empty
+		resp.Body.Close()
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		resp.Body.Close()
+		return nil, xerrors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
+
+func (p *encryptedPresignedAccess) readObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error) {
+	info, err := p.PresignedAccess.SignDownload(ctx, bkt, obj, &SignedURLOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return p.get(ctx, info.URL, 0)
+}
+
+func (p *encryptedPresignedAccess) writeObject(ctx context.Context, bkt, obj string, content []byte) error {
+	info, err := p.PresignedAccess.SignUpload(ctx, bkt, obj, &SignedURLOptions{ContentType: "application/json"})
+	if err != nil {
+		return err
+	}
+	return putSignedURL(ctx, p.client, info, "application/json", content, false)
+}
+
+func (p *encryptedPresignedAccess) createObject(ctx context.Context, bkt, obj string, content []byte) error {
+	info, err := p.PresignedAccess.SignUpload(ctx, bkt, obj, &SignedURLOptions{ContentType: "application/json", IfNotExists: true})
+	if err != nil {
+		return err
+	}
+	return putSignedURL(ctx, p.client, info, "application/json", content, true)
+}
+
+// putSignedURL uploads content using a signed URL and the headers it requires. If ifNotExists is true, errObjectExists is returned if the object exists already.
+func putSignedURL(ctx context.Context, client *http.Client, info *UploadInfo, contentType string, content []byte, ifNotExists bool) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, info.URL, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if ifNotExists {
+		for k, v := range IfNotExistsHeaders {
+			req.Header.Set(k, v)
+		}
+	}
+	for k, v := range info.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if ifNotExists && (resp.StatusCode == http.StatusPreconditionFailed || resp.StatusCode == http.StatusConflict) {
+		return errObjectExists
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return xerrors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// RotateDataKeys implements DataKeyRotator
+func (p *encryptedPresignedAccess) RotateDataKeys(ctx context.Context) (rotated int, err error) {
+	buckets, err := p.ListBuckets(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, bkt := range buckets {
+		objs, err := p.ListObjects(ctx, bkt, "")
+		if err != nil {
+			return rotated, xerrors.Errorf("cannot list %s: %w", bkt, err)
+		}
+		for _, obj := range objs {
+			if path.Base(obj.Name) != DefaultDataKey {
+				continue
+			}
+			ok, err := p.envelope.rotateDataKey(ctx, p, bkt, obj.Name)
+			if err != nil {
+				return rotated, err
+			}
+			if ok {
+				rotated++
+			}
+		}
+	}
+	return rotated, nil
+}
+
+// StartDataKeyRotation rotates the data keys in the given interval until the context is canceled
+func StartDataKeyRotation(ctx context.Context, r DataKeyRotator, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		rotated, err := r.RotateDataKeys(ctx)
+		if err != nil {
+			log.WithError(err).WithField("rotated", rotated).Error("data key rotation failed")
+			continue
+		}
+		log.WithField("rotated", rotated).Info("data key rotation done")
+	}
+}
@@ -0,0 +1,443 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+)
+
+type memoryObjectStore struct {
+	mu   sync.Mutex
+	objs map[string][]byte
+}
+
+func (s *memoryObjectStore) readObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.objs[bkt+"/"+obj]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(c)), nil
+}
+
+func (s *memoryObjectStore) writeObject(ctx context.Context, bkt, obj string, content []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objs[bkt+"/"+obj] = content
+	return nil
+}
+
+func (s *memoryObjectStore) createObject(ctx context.Context, bkt, obj string, content []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.objs[bkt+"/"+obj]; exists {
+		return errObjectExists
+	}
+	s.objs[bkt+"/"+obj] = content
+	return nil
+}
+
+// racingObjectStore runs beforeCreate before each object creation, e.g. to create the object concurrently
+type racingObjectStore struct {
+	*memoryObjectStore
+	beforeCreate func()
+}
+
+func (s *racingObjectStore) createObject(ctx context.Context, bkt, obj string, content []byte) error {
+	s.beforeCreate()
+	return s.memoryObjectStore.createObject(ctx, bkt, obj, content)
+}
+
+func newTestKMS(t *testing.T, current string, keys ...string) *FileKMS {
+	keyring := fileKeyring{
+		Current: current,
+		Keys:    make(map[string][]byte),
+	}
+	for _, id := range keys {
+		key := sha256.Sum256([]byte(id))
+		keyring.Keys[id] = key[:]
+	}
+	fc, err := json.Marshal(keyring)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := filepath.Join(t.TempDir(), "keyring.json")
+	err = os.WriteFile(fn, fc, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kms, err := NewFileKMS(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kms
+}
+
+func encryptBytes(t *testing.T, e *envelope, store objectStore, content []byte) []byte {
+	r, err := e.Encrypt(context.Background(), store, "bucket", "workspaces/ws/"+DefaultDataKey, bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("cannot encrypt: %v", err)
+	}
+	defer r.Close()
+	ct, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("cannot encrypt: %v", err)
+	}
+	return ct
+}
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	large := make([]byte, 3*encSegmentSize+42)
+	rand.New(rand.NewSource(42)).Read(large)
+
+	tests := []struct {
+		Name    string
+		Content []byte
+	}{
+		{Name: "empty", Content: []byte{}},
+		{Name: "small", Content: []byte("hello world")},
+		{Name: "exactly one segment", Content: large[:encSegmentSize]},
+		{Name: "multiple segments", Content: large},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			store := &memoryObjectStore{objs: make(map[string][]byte)}
+			e := newEnvelope(newTestKMS(t, "k1", "k1"))
+
+			ct := encryptBytes(t, e, store, test.Content)
+			if len(test.Content) > 0 && bytes.Contains(ct, test.Content) {
+				t.Error("ciphertext contains the plaintext")
+			}
+
+			// a fresh envelope must not rely on cached data keys
+			r, err := newEnvelope(e.KMS).Decrypt(context.Background(), store, "bucket", bytes.NewReader(ct))
+			if err != nil {
+				t.Fatalf("cannot decrypt: %v", err)
+			}
+			plain, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("cannot decrypt: %v", err)
+			}
+			if !bytes.Equal(plain, test.Content) {
+				t.Error("decrypted content differs")
+			}
+
+			dk, err := e.DataKeyFor(context.Background(), store, "bucket", bytes.NewReader(ct))
+			if err != nil {
+				t.Fatalf("cannot get data key: %v", err)
+			}
+			r, err = Decrypt(bytes.NewReader(ct), dk)
+			if err != nil {
+				t.Fatalf("cannot decrypt with data key: %v", err)
+			}
+			plain, err = io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("cannot decrypt with data key: %v", err)
+			}
+			if !bytes.Equal(plain, test.Content) {
+				t.Error("content decrypted with data key differs")
+			}
+		})
+	}
+}
+
+func TestDecryptTamperedContent(t *testing.T) {
+	content := make([]byte, 2*encSegmentSize+42)
+	rand.New(rand.NewSource(42)).Read(content)
+
+	store := &memoryObjectStore{objs: make(map[string][]byte)}
+	e := newEnvelope(newTestKMS(t, "k1", "k1"))
+	ct := encryptBytes(t, e, store, content)
+	hdrSize := len(encMagic) + 2 + len("workspaces/ws/"+DefaultDataKey) + encNoncePrefixSize
+	segSize := encSegmentSize + 16
+
+	tests := []struct {
+		Name       string
+		Ciphertext []byte
+	}{
+		{
+			Name:       "truncated at segment boundary",
+			Ciphertext: ct[:hdrSize+segSize],
+		},
+		{
+			Name:       "truncated within segment",
+			Ciphertext: ct[:len(ct)-10],
+		},
+		{
+			Name: "flipped bit",
+			Ciphertext: func() []byte {
+				res := append([]byte{}, ct...)
+				res[hdrSize+segSize+100] ^= 1
+				return res
+			}(),
+		},
+		{
+			Name: "swapped segments",
+			Ciphertext: func() []byte {
+				res := append([]byte{}, ct[:hdrSize]...)
+				res = append(res, ct[hdrSize+segSize:hdrSize+2*segSize]...)
+				res = append(res, ct[hdrSize:hdrSize+segSize]...)
+				return append(res, ct[hdrSize+2*segSize:]...)
+			}(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			r, err := e.Decrypt(context.Background(), store, "bucket", bytes.NewReader(test.Ciphertext))
+			if err == nil {
+				_, err = io.ReadAll(r)
+			}
+			if err == nil {
+				t.Error("expected tampering to be detected")
+			}
+		})
+	}
+}
+
+func TestDecryptPlaintext(t *testing.T) {
+	content := []byte("not encrypted")
+
+	r, err := Decrypt(bytes.NewReader(content), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(plain, content) {
+		t.Errorf("plaintext was not passed through: %q", plain)
+	}
+
+	store := &memoryObjectStore{objs: make(map[string][]byte)}
+	e := newEnvelope(newTestKMS(t, "k1", "k1"))
+	ct := encryptBytes(t, e, store, content)
+	_, err = Decrypt(bytes.NewReader(ct), nil)
+	if !errors.Is(err, ErrMissingDataKey) {
+		t.Errorf("expected ErrMissingDataKey, got %v", err)
+	}
+}
+
+func TestDataKeyCreationRace(t *testing.T) {
+	content := []byte("hello world")
+	kms := newTestKMS(t, "k1", "k1")
+
+	mem := &memoryObjectStore{objs: make(map[string][]byte)}
+	var other []byte
+	store := &racingObjectStore{
+		memoryObjectStore: mem,
+		beforeCreate: func() {
+			if other == nil {
+				// another content-service instance creates the data key while we're about to
+				other = encryptBytes(t, newEnvelope(kms), mem, content)
+			}
+		},
+	}
+	ct := encryptBytes(t, newEnvelope(kms), store, content)
+
+	// both must have used the same data key, i.e. the one which was stored first
+	for _, c := range [][]byte{ct, other} {
+		r, err := newEnvelope(kms).Decrypt(context.Background(), mem, "bucket", bytes.NewReader(c))
+		if err != nil {
+			t.Fatalf("cannot decrypt: %v", err)
+		}
+		plain, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("cannot decrypt: %v", err)
+		}
+		if !bytes.Equal(plain, content) {
+			t.Error("decrypted content differs")
+		}
+	}
+}
+
+func TestDataKeyRotation(t *testing.T) {
+	content := []byte("hello world")
+	keyObj := "workspaces/ws/" + DefaultDataKey
+
+	store := &memoryObjectStore{objs: make(map[string][]byte)}
+	ct := encryptBytes(t, newEnvelope(newTestKMS(t, "k1", "k1")), store, content)
+
+	readKeyID := func() string {
+		var stored wrappedDataKey
+		err := json.Unmarshal(store.objs["bucket/"+keyObj], &stored)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return stored.KeyID
+	}
+	if id := readKeyID(); id != "k1" {
+		t.Fatalf("data key wrapped with unexpected key: %s", id)
+	}
+
+	// k2 becomes the current key while k1 is retained to unwrap existing data keys
+	e := newEnvelope(newTestKMS(t, "k2", "k1", "k2"))
+	rotated, err := e.rotateDataKey(context.Background(), store, "bucket", keyObj)
+	if err != nil {
+		t.Fatalf("cannot rotate data key: %v", err)
+	}
+	if !rotated {
+		t.Error("data key was not reported as rotated")
+	}
+	if id := readKeyID(); id != "k2" {
+		t.Errorf("data key was not rewrapped: %s", id)
+	}
+	rotated, err = e.rotateDataKey(context.Background(), store, "bucket", keyObj)
+	if err != nil {
+		t.Fatalf("cannot rotate data key: %v", err)
+	}
+	if rotated {
+		t.Error("data key wrapped with the current key was rotated again")
+	}
+
+	// once all data keys are rewrapped, k1 can be retired without re-encrypting the content
+	e = newEnvelope(newTestKMS(t, "k2", "k2"))
+	r, err := e.Decrypt(context.Background(), store, "bucket", bytes.NewReader(ct))
+	if err != nil {
+		t.Fatalf("cannot decrypt after rotation: %v", err)
+	}
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("cannot decrypt after rotation: %v", err)
+	}
+	if !bytes.Equal(plain, content) {
+		t.Error("decrypted content differs")
+	}
+}
+
+func TestEncryptable(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Expectation bool
+	}{
+		{Name: DefaultBackup, Expectation: true},
+		{Name: DefaultChunkedBackupManifest, Expectation: true},
+		{Name: DefaultBackupManifest, Expectation: true},
+		{Name: "wsfull-1665912345678.tar", Expectation: false},
+		{Name: "workspaces/ws/wsfull-1665912345678.tar", Expectation: false},
+		{Name: "wsfull-latest.tar", Expectation: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if act := encryptable(test.Name); act != test.Expectation {
+				t.Errorf("encryptable(%q) = %v, expected %v", test.Name, act, test.Expectation)
+			}
+		})
+	}
+}
+
+func TestRotateDataKeys(t *testing.T) {
+	var handler http.Handler
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	keyFile := filepath.Join(t.TempDir(), "signing-key")
+	err := os.WriteFile(keyFile, []byte("secret\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.LocalConfig{
+		Directory:      t.TempDir(),
+		URL:            srv.URL,
+		SigningKeyFile: keyFile,
+	}
+	handler, err = NewLocalStorageHandler(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	content := []byte("hello world")
+	for _, ws := range []string{"ws1", "ws2"} {
+		da, err := newDirectLocalAccess(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		da.envelope = newEnvelope(newTestKMS(t, "k1", "k1"))
+		err = da.Init(ctx, "owner", ws, "instance")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = da.UploadStream(ctx, bytes.NewReader(content), DefaultBackup)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	newAccess := func(kms KMS) *encryptedPresignedAccess {
+		ps, err := newPresignedLocalAccess(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &encryptedPresignedAccess{PresignedAccess: ps, envelope: newEnvelope(kms), client: srv.Client()}
+	}
+
+	// k2 becomes the current key while k1 is retained to unwrap existing data keys
+	rotated, err := newAccess(newTestKMS(t, "k2", "k1", "k2")).RotateDataKeys(ctx)
+	if err != nil {
+		t.Fatalf("cannot rotate data keys: %v", err)
+	}
+	if rotated != 2 {
+		t.Errorf("expected two data keys to be rotated, got %d", rotated)
+	}
+
+	// once all data keys are rewrapped, k1 can be retired without re-encrypting the content
+	ps := newAccess(newTestKMS(t, "k2", "k2"))
+	rotated, err = ps.RotateDataKeys(ctx)
+	if err != nil {
+		t.Fatalf("cannot rotate data keys: %v", err)
+	}
+	if rotated != 0 {
+		t.Errorf("expected no data keys to be rotated, got %d", rotated)
+	}
+	for _, ws := range []string{"ws1", "ws2"} {
+		info, err := ps.SignDownload(ctx, localBucketName("owner"), localWorkspaceBackupObjectName(ws, DefaultBackup), &SignedURLOptions{})
+		if err != nil {
+			t.Fatalf("cannot sign download after rotation: %v", err)
+		}
+		body, err := ps.get(ctx, info.URL, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := Decrypt(body, info.DataKey)
+		if err != nil {
+			t.Fatalf("cannot decrypt after rotation: %v", err)
+		}
+		plain, err := io.ReadAll(r)
+		body.Close()
+		if err != nil {
+			t.Fatalf("cannot decrypt after rotation: %v", err)
+		}
+		if !bytes.Equal(plain, content) {
+			t.Errorf("decrypted content of %s differs", ws)
+		}
+	}
+
+	// existing data keys are never replaced by creating them anew
+	err = ps.createObject(ctx, localBucketName("owner"), localWorkspaceBackupObjectName("ws1", DefaultDataKey), []byte("{}"))
+	if !errors.Is(err, errObjectExists) {
+		t.Errorf("expected errObjectExists creating an existing data key, got %v", err)
+	}
+}
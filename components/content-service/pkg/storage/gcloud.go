@@ -35,6 +35,7 @@ import (
 )
 
 var _ DirectAccess = &DirectGCPStorage{}
+var _ ObjectLister = &PresignedGCPStorage{}
 
 var validateExistsInFilesystem = validation.By(func(o interface{}) error {
 	s, ok := o.(string)
@@ -82,6 +83,9 @@ type DirectGCPStorage struct {
 
 	client *gcpstorage.Client
 
+	// envelope encrypts workspace content if encryption is enabled
+	envelope *envelope
+
 	// ObjectAccess just exists so that we can swap out the stream access during testing
 	ObjectAccess func(ctx context.Context, btk, obj string) (io.ReadCloser, bool, error)
 }
@@ -181,6 +185,43 @@ func (rs *DirectGCPStorage) defaultObjectAccess(ctx context.Context, bkt, obj st
 	return rc, false, nil
 }
 
+func (rs *DirectGCPStorage) readObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error) {
+	rc, _, err := rs.ObjectAccess(ctx, bkt, obj)
+	if errors.Is(err, gcpstorage.ErrObjectNotExist) || errors.Is(err, gcpstorage.ErrBucketNotExist) {
+		return nil, ErrNotFound
+	}
+	return rc, err
+}
+
+func (rs *DirectGCPStorage) writeObject(ctx context.Context, bkt, obj string, content []byte) error {
+	if rs.client == nil {
+		return xerrors.Errorf("no gcloud client available - did you call Init()?")
+	}
+	return writeGCPObject(ctx, rs.client.Bucket(bkt).Object(obj), content)
+}
+
+func (rs *DirectGCPStorage) createObject(ctx context.Context, bkt, obj string, content []byte) error {
+	if rs.client == nil {
+		return xerrors.Errorf("no gcloud client available - did you call Init()?")
+	}
+	err := writeGCPObject(ctx, rs.client.Bucket(bkt).Object(obj).If(gcpstorage.Conditions{DoesNotExist: true}), content)
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) && gerr.Code == http.StatusPreconditionFailed {
+		return errObjectExists
+	}
+	return err
+}
+
+func writeGCPObject(ctx context.Context, oh *gcpstorage.ObjectHandle, content []byte) error {
+	wc := oh.NewWriter(ctx)
+	_, err := wc.Write(content)
+	if err != nil {
+		_ = wc.Close()
+		return err
+	}
+	return wc.Close()
+}
+
 func (rs *DirectGCPStorage) download(ctx context.Context, destination string, bkt string, obj string, mappings []archive.IDMapping) (found bool, err error) {
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "download")
@@ -233,7 +274,11 @@ func (rs *DirectGCPStorage) download(ctx context.Context, destination string, bk
 	}
 	defer rc.Close()
 
-	err = extractTarbal(ctx, destination, rc, mappings)
+	src, err := decryptDownload(ctx, rs.envelope, rs, bkt, rc)
+	if err != nil {
+		return true, err
+	}
+	err = extractTarbal(ctx, destination, src, mappings)
 	if err != nil {
 		return true, err
 	}
@@ -301,6 +346,28 @@ func (rs *DirectGCPStorage) DownloadSnapshot(ctx context.Context, destination st
 	return rs.download(ctx, destination, bkt, obj, mappings)
 }
 
+// DownloadObject writes the content of a single object to dst without extracting it
+func (rs *DirectGCPStorage) DownloadObject(ctx context.Context, name string, dst io.Writer) (found bool, err error) {
+	rc, err := rs.readObject(ctx, rs.bucketName(), rs.objectName(name))
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+
+	src, err := decryptDownload(ctx, rs.envelope, rs, rs.bucketName(), rc)
+	if err != nil {
+		return true, err
+	}
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
 // ParseSnapshotName parses the name of a snapshot into bucket and object
 func ParseSnapshotName(name string) (bkt, obj string, err error) {
 	segments := strings.Split(name, "@")
@@ -348,11 +415,16 @@ func (rs *DirectGCPStorage) UploadInstance(ctx context.Context, source string, n
 	if rs.InstanceID == "" {
 		return "", "", xerrors.Errorf("instanceID is required to comput object name")
 	}
-	return rs.Upload(ctx, source, InstanceObjectName(rs.InstanceID, name), opts...)
+	// instance objects, e.g. prebuild logs, are not workspace content and hence never encrypted
+	return rs.upload(ctx, source, InstanceObjectName(rs.InstanceID, name), false, opts...)
 }
 
 // Upload takes all files from a local location and uploads it to the remote storage
 func (rs *DirectGCPStorage) Upload(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, object string, err error) {
+	return rs.upload(ctx, source, name, encryptable(name), opts...)
+}
+
+func (rs *DirectGCPStorage) upload(ctx context.Context, source string, name string, encrypt bool, opts ...UploadOption) (bucket, object string, err error) {
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "GCloudBucketRemotegcpStorage.Upload")
 	defer tracing.FinishSpan(span, &err)
@@ -363,6 +435,16 @@ func (rs *DirectGCPStorage) Upload(ctx context.Context, source string, name stri
 		return
 	}
 
+	if encrypt && rs.envelope != nil {
+		// gsutil cannot encrypt the content on the fly, hence we stream it through the client instead
+		f, err := os.Open(source)
+		if err != nil {
+			return "", "", xerrors.Errorf("cannot open file for uploading: %w", err)
+		}
+		defer f.Close()
+		return rs.UploadStream(ctx, f, name, opts...)
+	}
+
 	sfn, err := os.Open(source)
 	if err != nil {
 		err = xerrors.Errorf("cannot open file for uploading: %w", err)
@@ -429,6 +511,89 @@ func (rs *DirectGCPStorage) Upload(ctx context.Context, source string, name stri
 	return
 }
 
+// UploadStream uploads everything read from src to the remote storage without buffering it on disk
+func (rs *DirectGCPStorage) UploadStream(ctx context.Context, src io.Reader, name string, opts ...UploadOption) (bucket, object string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "GCloudBucketRemotegcpStorage.UploadStream")
+	defer tracing.FinishSpan(span, &err)
+
+	options, err := GetUploadOptions(opts)
+	if err != nil {
+		err = xerrors.Errorf("cannot get options: %w", err)
+		return
+	}
+
+	if rs.client == nil {
+		err = xerrors.Errorf("no gcloud client available - did you call Init()?")
+		return
+	}
+
+	bucket = rs.bucketName()
+	object = rs.objectName(name)
+	span.SetTag("bucket", bucket)
+	span.SetTag("obj", object)
+
+	err = gcpEnsureExists(ctx, rs.client, bucket, rs.GCPConfig)
+	if err != nil {
+		err = xerrors.Errorf("unexpected error: %w", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	content, err := encryptUpload(ctx, rs.envelope, rs, bucket, rs.objectName(DefaultDataKey), name, src)
+	if err != nil {
+		err = xerrors.Errorf("cannot encrypt %s: %w", object, err)
+		return
+	}
+	defer content.Close()
+
+	wc := rs.client.Bucket(bucket).Object(object).NewWriter(ctx)
+	wc.ContentType = options.ContentType
+	wc.Metadata = options.Annotations
+	n, err := io.Copy(wc, content)
+	if err != nil {
+		// cancelling the context aborts the upload and discards what we've written so far
+		cancel()
+		_ = wc.Close()
+		err = xerrors.Errorf("cannot upload %s: %w", object, err)
+		return
+	}
+	err = wc.Close()
+	if err != nil {
+		err = xerrors.Errorf("cannot upload %s: %w", object, err)
+		return
+	}
+	span.SetTag("totalSize", n)
+
+	return
+}
+
+// CopyObject copies an object to another name within the remote storage without downloading it
+func (rs *DirectGCPStorage) CopyObject(ctx context.Context, src, dst string) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "GCloudBucketRemotegcpStorage.CopyObject")
+	defer tracing.FinishSpan(span, &err)
+
+	if rs.client == nil {
+		return xerrors.Errorf("no gcloud client available - did you call Init()?")
+	}
+
+	bkt := rs.client.Bucket(rs.bucketName())
+	srcObj, dstObj := rs.objectName(src), rs.objectName(dst)
+	span.SetTag("bucket", rs.bucketName())
+	span.SetTag("src", srcObj)
+	span.SetTag("dst", dstObj)
+
+	// the copier rewrites large objects in several requests until the copy is complete
+	_, err = bkt.Object(dstObj).CopierFrom(bkt.Object(srcObj)).Run(ctx)
+	if err != nil {
+		return xerrors.Errorf("cannot copy %s to %s: %w", srcObj, dstObj, err)
+	}
+	return nil
+}
+
 func (rs *DirectGCPStorage) bucketName() string {
 	return gcpBucketName(rs.Stage, rs.Username)
 }
@@ -578,6 +743,62 @@ func (p *PresignedGCPStorage) DiskUsage(ctx context.Context, bucket string, pref
 	return total, nil
 }
 
+// ListBuckets returns all user buckets of the stage
+func (p *PresignedGCPStorage) ListBuckets(ctx context.Context) (buckets []string, err error) {
+	client, err := newGCPClient(ctx, p.config)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:staticcheck
+	defer client.Close()
+
+	it := client.Buckets(ctx, p.config.Project)
+	it.Prefix = gcpBucketName(p.stage, "")
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, attrs.Name)
+	}
+	return buckets, nil
+}
+
+// ListObjects returns all objects in the bucket whose name starts with prefix
+func (p *PresignedGCPStorage) ListObjects(ctx context.Context, bucket, prefix string) (objects []ObjectInfo, err error) {
+	client, err := newGCPClient(ctx, p.config)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:staticcheck
+	defer client.Close()
+
+	it := client.Bucket(bucket).Objects(ctx, &gcpstorage.Query{
+		Prefix: prefix,
+	})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if errors.Is(err, gcpstorage.ErrBucketNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, ObjectInfo{
+			Name:         attrs.Name,
+			Size:         attrs.Size,
+			LastModified: attrs.Updated,
+		})
+	}
+	return objects, nil
+}
+
 // SignDownload provides presigned URLs to access remote storage objects
 func (p *PresignedGCPStorage) SignDownload(ctx context.Context, bucket, object string, options *SignedURLOptions) (*DownloadInfo, error) {
 	client, err := newGCPClient(ctx, p.config)
@@ -623,7 +844,7 @@ func (p *PresignedGCPStorage) downloadInfo(ctx context.Context, client *gcpstora
 		Method:         "GET",
 		GoogleAccessID: p.accessID,
 		PrivateKey:     p.privateKey,
-		Expires:        time.Now().Add(1 * time.Hour),
+		Expires:        time.Now().Add(options.expiry(1 * time.Hour)),
 		ContentType:    options.ContentType,
 	})
 	if err != nil {
@@ -655,19 +876,31 @@ func (p *PresignedGCPStorage) SignUpload(ctx context.Context, bucket, object str
 		return nil, err
 	}
 
+	var headers []string
+	uploadHeaders := make(map[string]string)
+	if options.ContentType != "" {
+		uploadHeaders["Content-Type"] = options.ContentType
+	}
+	if options.IfNotExists {
+		// extension headers are part of the signature
+		headers = append(headers, "x-goog-if-generation-match:"+IfNotExistsHeaders["x-goog-if-generation-match"])
+		uploadHeaders["x-goog-if-generation-match"] = IfNotExistsHeaders["x-goog-if-generation-match"]
+	}
 	url, err := gcpstorage.SignedURL(bucket, object, &gcpstorage.SignedURLOptions{
 		Method:         "PUT",
 		GoogleAccessID: p.accessID,
 		PrivateKey:     p.privateKey,
-		Expires:        time.Now().Add(30 * time.Minute),
+		Expires:        time.Now().Add(options.expiry(30 * time.Minute)),
 		ContentType:    options.ContentType,
+		Headers:        headers,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &UploadInfo{
-		URL: url,
+		URL:     url,
+		Headers: uploadHeaders,
 	}, nil
 }
 
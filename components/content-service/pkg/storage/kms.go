@@ -0,0 +1,113 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+
+	"golang.org/x/xerrors"
+
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+)
+
+// NewKMS produces the KMS configured for envelope encryption
+func NewKMS(c *config.EncryptionConfig) (KMS, error) {
+	switch c.KMS {
+	case config.FileKMS:
+		if c.File == nil {
+			return nil, xerrors.Errorf("missing file KMS config")
+		}
+		return NewFileKMS(c.File.KeyringFile)
+	default:
+		return nil, xerrors.Errorf("unknown KMS: %s", c.KMS)
+	}
+}
+
+// FileKMS wraps data keys with key encryption keys read from a local keyring file.
+// To rotate keys, add a new key to the keyring and make it the current one. Retired keys
+// must remain in the keyring until all data keys they wrapped have been re-wrapped.
+type FileKMS struct {
+	current string
+	keys    map[string]cipher.AEAD
+}
+
+// fileKeyring is the content of a FileKMS keyring file
+type fileKeyring struct {
+	// Current is the ID of the key new data keys are wrapped with
+	Current string `json:"current"`
+	// Keys maps key IDs to 256 bit AES keys
+	Keys map[string][]byte `json:"keys"`
+}
+
+var _ KMS = &FileKMS{}
+
+// NewFileKMS loads the keyring from a JSON file
+func NewFileKMS(keyringFile string) (*FileKMS, error) {
+	fc, err := os.ReadFile(keyringFile)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read keyring: %w", err)
+	}
+	var keyring fileKeyring
+	err = json.Unmarshal(fc, &keyring)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal keyring: %w", err)
+	}
+
+	kms := &FileKMS{
+		current: keyring.Current,
+		keys:    make(map[string]cipher.AEAD, len(keyring.Keys)),
+	}
+	for id, key := range keyring.Keys {
+		if len(key) != 32 {
+			return nil, xerrors.Errorf("key %s is not a 256 bit key", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid key %s: %w", id, err)
+		}
+		kms.keys[id], err = cipher.NewGCM(block)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid key %s: %w", id, err)
+		}
+	}
+	if _, ok := kms.keys[kms.current]; !ok {
+		return nil, xerrors.Errorf("current key %s is not in the keyring", kms.current)
+	}
+	return kms, nil
+}
+
+// CurrentKeyID returns the ID of the key encryption key new data keys are wrapped with
+func (kms *FileKMS) CurrentKeyID() string {
+	return kms.current
+}
+
+// WrapKey encrypts a data key with the current key encryption key
+func (kms *FileKMS) WrapKey(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error) {
+	aead := kms.keys[kms.current]
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", nil, err
+	}
+	return kms.current, aead.Seal(nonce, nonce, dataKey, []byte(kms.current)), nil
+}
+
+// UnwrapKey decrypts a data key which was wrapped with the key encryption key identified by keyID
+func (kms *FileKMS) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) (dataKey []byte, err error) {
+	aead, ok := kms.keys[keyID]
+	if !ok {
+		return nil, xerrors.Errorf("unknown key %s", keyID)
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, xerrors.Errorf("invalid wrapped key")
+	}
+	nonce, ct := wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():]
+	return aead.Open(nil, nonce, ct, []byte(keyID))
+}
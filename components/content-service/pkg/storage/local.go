@@ -0,0 +1,911 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/opencontainers/go-digest"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
+)
+
+// LocalStoragePath is the path under which the content-service serves presigned URLs of the local storage
+const LocalStoragePath = "/local-storage/"
+
+const (
+	localMetaDir      = ".meta"
+	localSignedURLTTL = 30 * time.Minute
+)
+
+var _ DirectAccess = &DirectLocalStorage{}
+var _ PresignedAccess = &PresignedLocalStorage{}
+var _ ObjectLister = &PresignedLocalStorage{}
+
+// ValidateLocalConfig checks if the local storage config is valid
+func ValidateLocalConfig(c *config.LocalConfig) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Directory, validation.Required),
+		validation.Field(&c.URL, validation.Required),
+		validation.Field(&c.SigningKeyFile, validation.Required),
+	)
+}
+
+// localFS stores objects as files in <root>/<bucket>/<object>. Content type and annotations
+// of an object are stored in <root>/.meta/<bucket>/<object>.
+type localFS struct {
+	Root string
+}
+
+// localObjectMeta is the metadata stored for each object
+type localObjectMeta struct {
+	ContentType string            `json:"contentType,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+func (l localFS) bucketPath(bkt string) (string, error) {
+	if bkt == "" || strings.HasPrefix(bkt, ".") || strings.ContainsAny(bkt, `/\`) {
+		return "", xerrors.Errorf("invalid bucket name: %s", bkt)
+	}
+	return filepath.Join(l.Root, bkt), nil
+}
+
+func (l localFS) objectPath(bkt, obj string) (string, error) {
+	bp, err := l.bucketPath(bkt)
+	if err != nil {
+		return "", err
+	}
+	p := filepath.Join(bp, filepath.FromSlash(obj))
+	if !strings.HasPrefix(p, bp+string(filepath.Separator)) {
+		return "", xerrors.Errorf("invalid object name: %s", obj)
+	}
+	return p, nil
+}
+
+func (l localFS) metaPath(bkt, obj string) (string, error) {
+	return localFS{Root: filepath.Join(l.Root, localMetaDir)}.objectPath(bkt, obj)
+}
+
+// open returns ErrNotFound if the object does not exist
+func (l localFS) open(bkt, obj string) (*os.File, error) {
+	p, err := l.objectPath(bkt, obj)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// stat returns ErrNotFound if the object does not exist
+func (l localFS) stat(bkt, obj string) (fs.FileInfo, *localObjectMeta, error) {
+	p, err := l.objectPath(bkt, obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	stat, err := os.Stat(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if stat.IsDir() {
+		return nil, nil, ErrNotFound
+	}
+
+	var meta localObjectMeta
+	mp, err := l.metaPath(bkt, obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	mc, err := os.ReadFile(mp)
+	if errors.Is(err, fs.ErrNotExist) {
+		return stat, &meta, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	err = json.Unmarshal(mc, &meta)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("cannot read metadata of %s: %w", obj, err)
+	}
+	return stat, &meta, nil
+}
+
+// write atomically replaces the object with the content read from src. If exclusive is true, an existing object
+// is left untouched and errObjectExists is returned instead.
+func (l localFS) write(bkt, obj string, src io.Reader, meta *localObjectMeta, exclusive bool) (size int64, err error) {
+	p, err := l.objectPath(bkt, obj)
+	if err != nil {
+		return 0, err
+	}
+	mp, err := l.metaPath(bkt, obj)
+	if err != nil {
+		return 0, err
+	}
+	err = os.MkdirAll(filepath.Dir(p), 0755)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	size, err = io.Copy(f, src)
+	if err != nil {
+		f.Close()
+		return 0, err
+	}
+	err = f.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	if exclusive {
+		// unlike renaming, linking fails if the object exists
+		err = os.Link(f.Name(), p)
+		if errors.Is(err, fs.ErrExist) {
+			err = errObjectExists
+		}
+		if err != nil {
+			return 0, err
+		}
+		_ = os.Remove(f.Name())
+	}
+
+	if meta == nil {
+		meta = &localObjectMeta{}
+	}
+	mc, err := json.Marshal(meta)
+	if err != nil {
+		return 0, err
+	}
+	err = os.MkdirAll(filepath.Dir(mp), 0755)
+	if err != nil {
+		return 0, err
+	}
+	err = os.WriteFile(mp, mc, 0644)
+	if err != nil {
+		return 0, err
+	}
+
+	if exclusive {
+		return size, nil
+	}
+	err = os.Rename(f.Name(), p)
+	if err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// list returns all objects in the bucket with the given prefix. Returns an empty list if the bucket does not exist.
+func (l localFS) list(bkt, prefix string) ([]ObjectInfo, error) {
+	bp, err := l.bucketPath(bkt)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []ObjectInfo
+	err = filepath.WalkDir(bp, func(p string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && p == bp {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(bp, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		res = append(res, ObjectInfo{Name: name, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (l localFS) remove(bkt, obj string) error {
+	p, err := l.objectPath(bkt, obj)
+	if err != nil {
+		return err
+	}
+	mp, err := l.metaPath(bkt, obj)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	err = os.Remove(mp)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l localFS) removeBucket(bkt string) error {
+	bp, err := l.bucketPath(bkt)
+	if err != nil {
+		return err
+	}
+	mp, err := localFS{Root: filepath.Join(l.Root, localMetaDir)}.bucketPath(bkt)
+	if err != nil {
+		return err
+	}
+	err = os.RemoveAll(bp)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(mp)
+}
+
+func localBucketName(ownerID string) string {
+	return fmt.Sprintf("gitpod-user-%s", ownerID)
+}
+
+func localWorkspaceBackupObjectName(workspaceID, name string) string {
+	return path.Join("workspaces", workspaceID, name)
+}
+
+// newDirectLocalAccess provides direct access to the local storage
+func newDirectLocalAccess(cfg config.LocalConfig) (*DirectLocalStorage, error) {
+	if err := ValidateLocalConfig(&cfg); err != nil {
+		return nil, err
+	}
+	return &DirectLocalStorage{LocalConfig: cfg, fs: localFS{Root: cfg.Directory}}, nil
+}
+
+// DirectLocalStorage implements a local directory as remote storage backend
+type DirectLocalStorage struct {
+	Username      string
+	WorkspaceName string
+	InstanceID    string
+	LocalConfig   config.LocalConfig
+
+	fs localFS
+
+	// envelope encrypts workspace content if encryption is enabled
+	envelope *envelope
+}
+
+// Validate checks if the local storage is configured properly
+func (rs *DirectLocalStorage) Validate() error {
+	err := ValidateLocalConfig(&rs.LocalConfig)
+	if err != nil {
+		return err
+	}
+
+	return validation.ValidateStruct(rs,
+		validation.Field(&rs.Username, validation.Required),
+		validation.Field(&rs.WorkspaceName, validation.Required),
+	)
+}
+
+// Init initializes the remote storage - call this before calling anything else on the interface
+func (rs *DirectLocalStorage) Init(ctx context.Context, owner, workspace, instance string) error {
+	rs.Username = owner
+	rs.WorkspaceName = workspace
+	rs.InstanceID = instance
+	rs.fs = localFS{Root: rs.LocalConfig.Directory}
+
+	return rs.Validate()
+}
+
+func (rs *DirectLocalStorage) readObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error) {
+	return rs.fs.open(bkt, obj)
+}
+
+func (rs *DirectLocalStorage) writeObject(ctx context.Context, bkt, obj string, content []byte) error {
+	_, err := rs.fs.write(bkt, obj, bytes.NewReader(content), nil, false)
+	return err
+}
+
+func (rs *DirectLocalStorage) createObject(ctx context.Context, bkt, obj string, content []byte) error {
+	_, err := rs.fs.write(bkt, obj, bytes.NewReader(content), nil, true)
+	return err
+}
+
+// EnsureExists makes sure that the remote storage location exists and can be up- or downloaded from
+func (rs *DirectLocalStorage) EnsureExists(ctx context.Context) error {
+	bp, err := rs.fs.bucketPath(rs.bucketName())
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(bp, 0755)
+}
+
+func (rs *DirectLocalStorage) download(ctx context.Context, destination string, bkt string, obj string, mappings []archive.IDMapping) (found bool, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "download")
+	span.SetTag("bucket", bkt)
+	span.SetTag("object", obj)
+	defer tracing.FinishSpan(span, &err)
+
+	f, err := rs.fs.open(bkt, obj)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	src, err := decryptDownload(ctx, rs.envelope, rs, bkt, f)
+	if err != nil {
+		return true, err
+	}
+	err = extractTarbal(ctx, destination, src, mappings)
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// Download takes the latest state from the remote storage and downloads it to a local path
+func (rs *DirectLocalStorage) Download(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
+	return rs.download(ctx, destination, rs.bucketName(), rs.objectName(name), mappings)
+}
+
+// DownloadSnapshot downloads a snapshot. The snapshot name is expected to be one produced by Qualify
+func (rs *DirectLocalStorage) DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (bool, error) {
+	bkt, obj, err := ParseSnapshotName(name)
+	if err != nil {
+		return false, err
+	}
+
+	return rs.download(ctx, destination, bkt, obj, mappings)
+}
+
+// DownloadObject writes the content of a single object to dst without extracting it
+func (rs *DirectLocalStorage) DownloadObject(ctx context.Context, name string, dst io.Writer) (found bool, err error) {
+	f, err := rs.fs.open(rs.bucketName(), rs.objectName(name))
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	src, err := decryptDownload(ctx, rs.envelope, rs, rs.bucketName(), f)
+	if err != nil {
+		return true, err
+	}
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// ListObjects returns all objects found with the given prefix. Returns an empty list if the bucket does not exuist (yet).
+func (rs *DirectLocalStorage) ListObjects(ctx context.Context, prefix string) ([]string, error) {
+	objs, err := rs.fs.list(rs.bucketName(), prefix)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list objects: %w", err)
+	}
+	res := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		res = append(res, obj.Name)
+	}
+	return res, nil
+}
+
+// Qualify fully qualifies a snapshot name so that it can be downloaded using DownloadSnapshot
+func (rs *DirectLocalStorage) Qualify(name string) string {
+	return fmt.Sprintf("%s@%s", rs.objectName(name), rs.bucketName())
+}
+
+// UploadInstance takes all files from a local location and uploads it to the per-instance remote storage
+func (rs *DirectLocalStorage) UploadInstance(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, object string, err error) {
+	if rs.InstanceID == "" {
+		return "", "", xerrors.Errorf("instanceID is required to comput object name")
+	}
+	// instance objects, e.g. prebuild logs, are not workspace content and hence never encrypted
+	return rs.upload(ctx, source, InstanceObjectName(rs.InstanceID, name), false, opts...)
+}
+
+// Upload takes all files from a local location and uploads it to the remote storage
+func (rs *DirectLocalStorage) Upload(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, obj string, err error) {
+	return rs.upload(ctx, source, name, encryptable(name), opts...)
+}
+
+func (rs *DirectLocalStorage) upload(ctx context.Context, source string, name string, encrypt bool, opts ...UploadOption) (bucket, obj string, err error) {
+	f, err := os.Open(source)
+	if err != nil {
+		return "", "", xerrors.Errorf("cannot open file for uploading: %w", err)
+	}
+	defer f.Close()
+
+	return rs.uploadStream(ctx, f, name, encrypt, opts...)
+}
+
+// UploadStream uploads everything read from src to the remote storage without buffering it on disk
+func (rs *DirectLocalStorage) UploadStream(ctx context.Context, src io.Reader, name string, opts ...UploadOption) (bucket, obj string, err error) {
+	return rs.uploadStream(ctx, src, name, true, opts...)
+}
+
+func (rs *DirectLocalStorage) uploadStream(ctx context.Context, src io.Reader, name string, encrypt bool, opts ...UploadOption) (bucket, obj string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DirectUploadStream")
+	defer tracing.FinishSpan(span, &err)
+
+	options, err := GetUploadOptions(opts)
+	if err != nil {
+		err = xerrors.Errorf("cannot get options: %w", err)
+		return
+	}
+
+	bucket = rs.bucketName()
+	obj = rs.objectName(name)
+	span.LogKV("bucket", bucket)
+	span.LogKV("obj", obj)
+
+	content := io.NopCloser(src)
+	if encrypt {
+		content, err = encryptUpload(ctx, rs.envelope, rs, bucket, rs.objectName(DefaultDataKey), name, src)
+		if err != nil {
+			err = xerrors.Errorf("cannot encrypt %s: %w", obj, err)
+			return
+		}
+	}
+	defer content.Close()
+
+	_, err = rs.fs.write(bucket, obj, content, &localObjectMeta{
+		ContentType: options.ContentType,
+		Annotations: options.Annotations,
+	}, false)
+	if err != nil {
+		err = xerrors.Errorf("cannot write %s: %w", obj, err)
+		return
+	}
+	return
+}
+
+// CopyObject copies an object to another name within the remote storage without downloading it
+func (rs *DirectLocalStorage) CopyObject(ctx context.Context, src, dst string) error {
+	bucket := rs.bucketName()
+	srcObj, dstObj := rs.objectName(src), rs.objectName(dst)
+
+	_, meta, err := rs.fs.stat(bucket, srcObj)
+	if err != nil {
+		return xerrors.Errorf("cannot copy %s: %w", srcObj, err)
+	}
+	f, err := rs.fs.open(bucket, srcObj)
+	if err != nil {
+		return xerrors.Errorf("cannot copy %s: %w", srcObj, err)
+	}
+	defer f.Close()
+
+	_, err = rs.fs.write(bucket, dstObj, f, meta, false)
+	if err != nil {
+		return xerrors.Errorf("cannot copy %s to %s: %w", srcObj, dstObj, err)
+	}
+	return nil
+}
+
+// Bucket provides the bucket name for a particular user
+func (rs *DirectLocalStorage) Bucket(ownerID string) string {
+	return localBucketName(ownerID)
+}
+
+// BackupObject returns a backup's object name that a direct downloader would download
+func (rs *DirectLocalStorage) BackupObject(name string) string {
+	return rs.objectName(name)
+}
+
+func (rs *DirectLocalStorage) bucketName() string {
+	return localBucketName(rs.Username)
+}
+
+func (rs *DirectLocalStorage) objectName(name string) string {
+	return localWorkspaceBackupObjectName(rs.WorkspaceName, name)
+}
+
+// newPresignedLocalAccess provides presigned access to the local storage
+func newPresignedLocalAccess(cfg config.LocalConfig) (*PresignedLocalStorage, error) {
+	if err := ValidateLocalConfig(&cfg); err != nil {
+		return nil, err
+	}
+	signer, err := newLocalURLSigner(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &PresignedLocalStorage{
+		LocalConfig: cfg,
+		fs:          localFS{Root: cfg.Directory},
+		signer:      signer,
+	}, nil
+}
+
+// PresignedLocalStorage provides URLs to the local storage which are served by the LocalStorageHandler
+type PresignedLocalStorage struct {
+	LocalConfig config.LocalConfig
+
+	fs     localFS
+	signer *localURLSigner
+}
+
+// Bucket provides the bucket name for a particular user
+func (s *PresignedLocalStorage) Bucket(ownerID string) string {
+	return localBucketName(ownerID)
+}
+
+// BlobObject returns a blob's object name
+func (s *PresignedLocalStorage) BlobObject(userID, name string) (string, error) {
+	return blobObjectName(name)
+}
+
+// BackupObject returns a backup's object name that a direct downloader would download
+func (s *PresignedLocalStorage) BackupObject(ownerID string, workspaceID string, name string) string {
+	return localWorkspaceBackupObjectName(workspaceID, name)
+}
+
+// InstanceObject returns a instance's object name that a direct downloader would download
+func (s *PresignedLocalStorage) InstanceObject(ownerID string, workspaceID string, instanceID string, name string) string {
+	return s.BackupObject(ownerID, workspaceID, InstanceObjectName(instanceID, name))
+}
+
+// EnsureExists makes sure that the remote storage location exists and can be up- or downloaded from
+func (s *PresignedLocalStorage) EnsureExists(ctx context.Context, bucket string) error {
+	bp, err := s.fs.bucketPath(bucket)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(bp, 0755)
+}
+
+// DiskUsage gives the total objects size of objects that have the given prefix
+func (s *PresignedLocalStorage) DiskUsage(ctx context.Context, bucket string, prefix string) (size int64, err error) {
+	objs, err := s.fs.list(bucket, prefix)
+	if err != nil {
+		return 0, err
+	}
+	for _, obj := range objs {
+		size += obj.Size
+	}
+	return size, nil
+}
+
+// ListBuckets returns all user buckets in the storage directory
+func (s *PresignedLocalStorage) ListBuckets(ctx context.Context) (buckets []string, err error) {
+	entries, err := os.ReadDir(s.fs.Root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	prefix := localBucketName("")
+	for _, e := range entries {
+		if e.IsDir() && strings.HasPrefix(e.Name(), prefix) {
+			buckets = append(buckets, e.Name())
+		}
+	}
+	return buckets, nil
+}
+
+// ListObjects returns all objects in the bucket whose name starts with prefix
+func (s *PresignedLocalStorage) ListObjects(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error) {
+	return s.fs.list(bucket, prefix)
+}
+
+// SignDownload describes an object for download - if the object is not found, ErrNotFound is returned
+func (s *PresignedLocalStorage) SignDownload(ctx context.Context, bucket, obj string, options *SignedURLOptions) (info *DownloadInfo, err error) {
+	stat, meta, err := s.fs.stat(bucket, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DownloadInfo{
+		Meta: ObjectMeta{
+			ContentType:        meta.ContentType,
+			OCIMediaType:       meta.Annotations[ObjectAnnotationOCIContentType],
+			Digest:             meta.Annotations[ObjectAnnotationDigest],
+			UncompressedDigest: meta.Annotations[ObjectAnnotationUncompressedDigest],
+		},
+		Size: stat.Size(),
+		URL:  s.signer.Sign(http.MethodGet, bucket, obj, "", time.Now().Add(options.expiry(localSignedURLTTL))),
+	}, nil
+}
+
+// SignUpload describes an object for upload
+func (s *PresignedLocalStorage) SignUpload(ctx context.Context, bucket, obj string, options *SignedURLOptions) (info *UploadInfo, err error) {
+	if _, err := s.fs.objectPath(bucket, obj); err != nil {
+		return nil, err
+	}
+
+	var contentType string
+	if options != nil {
+		contentType = options.ContentType
+	}
+	info = &UploadInfo{
+		URL: s.signer.Sign(http.MethodPut, bucket, obj, contentType, time.Now().Add(options.expiry(localSignedURLTTL))),
+	}
+	if contentType != "" {
+		// the content type is part of the signature
+		info.Headers = map[string]string{"Content-Type": contentType}
+	}
+	return info, nil
+}
+
+// DeleteObject deletes objects in the given bucket specified by the given query
+func (s *PresignedLocalStorage) DeleteObject(ctx context.Context, bucket string, query *DeleteObjectQuery) error {
+	if query.Name != "" {
+		return s.fs.remove(bucket, query.Name)
+	}
+	if query.Prefix == "" {
+		return nil
+	}
+
+	objs, err := s.fs.list(bucket, query.Prefix)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		err = s.fs.remove(bucket, obj.Name)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteBucket deletes a bucket
+func (s *PresignedLocalStorage) DeleteBucket(ctx context.Context, userID, bucket string) error {
+	return s.fs.removeBucket(bucket)
+}
+
+// ObjectHash gets a hash value of an object
+func (s *PresignedLocalStorage) ObjectHash(ctx context.Context, bucket string, obj string) (string, error) {
+	f, err := s.fs.open(bucket, obj)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	dgst, err := digest.FromReader(f)
+	if err != nil {
+		return "", err
+	}
+	return dgst.Encoded(), nil
+}
+
+// ObjectExists tells whether the given object exists or not
+func (s *PresignedLocalStorage) ObjectExists(ctx context.Context, bucket string, obj string) (bool, error) {
+	_, _, err := s.fs.stat(bucket, obj)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// localURLSigner signs URLs to objects the content-service serves under Path using HMAC-SHA256
+type localURLSigner struct {
+	BaseURL string
+	Path    string
+	Key     []byte
+}
+
+func newLocalURLSigner(cfg config.LocalConfig) (*localURLSigner, error) {
+	return newURLSigner(cfg.URL, LocalStoragePath, cfg.SigningKeyFile)
+}
+
+func newURLSigner(baseURL, path, keyFile string) (*localURLSigner, error) {
+	key, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read signing key: %w", err)
+	}
+	key = bytes.TrimSpace(key)
+	if len(key) == 0 {
+		return nil, xerrors.Errorf("signing key %s is empty", keyFile)
+	}
+	return &localURLSigner{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Path:    path,
+		Key:     key,
+	}, nil
+}
+
+func (s *localURLSigner) signature(method, bucket, obj, contentType string, expires int64) string {
+	mac := hmac.New(sha256.New, s.Key)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%s\n%d", s.Path, method, bucket, obj, contentType, expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Sign produces a URL which grants access to the object using the given method until it expires.
+// If contentType is not empty, uploads must use that content type.
+func (s *localURLSigner) Sign(method, bucket, obj, contentType string, expires time.Time) string {
+	q := make(url.Values)
+	q.Set("method", method)
+	q.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+	if contentType != "" {
+		q.Set("contentType", contentType)
+	}
+	q.Set("signature", s.signature(method, bucket, obj, contentType, expires.Unix()))
+
+	return s.BaseURL + s.Path + url.PathEscape(bucket) + "/" + (&url.URL{Path: obj}).EscapedPath() + "?" + q.Encode()
+}
+
+// Verify checks that the request was made using a valid URL produced by Sign
+func (s *localURLSigner) Verify(req *http.Request, bucket, obj string) error {
+	q := req.URL.Query()
+
+	method := req.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	if q.Get("method") != method {
+		return xerrors.Errorf("URL is not valid for %s requests", req.Method)
+	}
+
+	expires, err := strconv.ParseInt(q.Get("expires"), 10, 64)
+	if err != nil {
+		return xerrors.Errorf("invalid expiry")
+	}
+	if time.Now().Unix() > expires {
+		return xerrors.Errorf("URL has expired")
+	}
+
+	contentType := q.Get("contentType")
+	if contentType != "" && req.Header.Get("Content-Type") != contentType {
+		return xerrors.Errorf("content type must be %s", contentType)
+	}
+
+	expected := s.signature(method, bucket, obj, contentType, expires)
+	if !hmac.Equal([]byte(q.Get("signature")), []byte(expected)) {
+		return xerrors.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// NewLocalStorageHandler serves the presigned URLs of the local storage. Register it under LocalStoragePath.
+func NewLocalStorageHandler(cfg config.LocalConfig) (http.Handler, error) {
+	if err := ValidateLocalConfig(&cfg); err != nil {
+		return nil, err
+	}
+	signer, err := newLocalURLSigner(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &localStorageHandler{
+		fs:     localFS{Root: cfg.Directory},
+		signer: signer,
+	}, nil
+}
+
+type localStorageHandler struct {
+	fs     localFS
+	signer *localURLSigner
+}
+
+func (h *localStorageHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	segs := strings.SplitN(strings.TrimPrefix(req.URL.Path, LocalStoragePath), "/", 2)
+	if len(segs) != 2 || segs[0] == "" || segs[1] == "" {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	bucket, obj := segs[0], segs[1]
+
+	err := h.signer.Verify(req, bucket, obj)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		h.serveDownload(w, req, bucket, obj)
+	case http.MethodPut:
+		h.serveUpload(w, req, bucket, obj)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *localStorageHandler) serveDownload(w http.ResponseWriter, req *http.Request, bucket, obj string) {
+	stat, meta, err := h.fs.stat(bucket, obj)
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("bucket", bucket).WithField("object", obj).Warn("cannot serve local storage object")
+		http.Error(w, "cannot read object", http.StatusInternalServerError)
+		return
+	}
+	f, err := h.fs.open(bucket, obj)
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("bucket", bucket).WithField("object", obj).Warn("cannot serve local storage object")
+		http.Error(w, "cannot read object", http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	if meta.ContentType != "" {
+		w.Header().Set("Content-Type", meta.ContentType)
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	http.ServeContent(w, req, "", stat.ModTime(), f)
+}
+
+func (h *localStorageHandler) serveUpload(w http.ResponseWriter, req *http.Request, bucket, obj string) {
+	_, err := h.fs.write(bucket, obj, req.Body, &localObjectMeta{
+		ContentType: req.Header.Get("Content-Type"),
+	}, req.Header.Get("If-None-Match") == IfNotExistsHeaders["If-None-Match"])
+	if errors.Is(err, errObjectExists) {
+		http.Error(w, "object exists already", http.StatusPreconditionFailed)
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("bucket", bucket).WithField("object", obj).Warn("cannot write local storage object")
+		http.Error(w, "cannot write object", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,310 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/gitpod-io/gitpod/content-service/api/config"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+type testableLocalPresignedAccess struct {
+	storage.PresignedAccess
+
+	Directory string
+}
+
+func (l testableLocalPresignedAccess) ForTestCreateObj(ctx context.Context, bucket, path, content string) error {
+	fn := filepath.Join(l.Directory, bucket, filepath.FromSlash(path))
+	err := os.MkdirAll(filepath.Dir(fn), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fn, []byte(content), 0644)
+}
+
+func (l testableLocalPresignedAccess) ForTestReset(ctx context.Context) error {
+	entries, err := os.ReadDir(l.Directory)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		err = os.RemoveAll(filepath.Join(l.Directory, e.Name()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newLocalStorageConfig(t *testing.T, url string) *config.StorageConfig {
+	keyFile := filepath.Join(t.TempDir(), "signing-key")
+	failOnErr(t, os.WriteFile(keyFile, []byte("secret\n"), 0600))
+
+	return &config.StorageConfig{
+		Kind: config.LocalStorage,
+		LocalConfig: &config.LocalConfig{
+			Directory:      t.TempDir(),
+			URL:            url,
+			SigningKeyFile: keyFile,
+		},
+	}
+}
+
+func TestLocalPresignedHappyPath(t *testing.T) {
+	cfg := newLocalStorageConfig(t, "http://localhost:8080")
+	ps, err := storage.NewPresignedAccess(cfg)
+	failOnErr(t, err)
+
+	SuiteTestPresignedAccess(t, testableLocalPresignedAccess{
+		PresignedAccess: ps,
+		Directory:       cfg.LocalConfig.Directory,
+	})
+}
+
+func TestLocalStorageHandler(t *testing.T) {
+	const (
+		bucket  = "gitpod-user-foo"
+		obj     = "workspaces/ws/full.tar"
+		content = "hello world"
+	)
+
+	var handler http.Handler
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	cfg := newLocalStorageConfig(t, srv.URL)
+	handler, err := storage.NewLocalStorageHandler(*cfg.LocalConfig)
+	failOnErr(t, err)
+	ps, err := storage.NewPresignedAccess(cfg)
+	failOnErr(t, err)
+
+	ctx := context.Background()
+	upload, err := ps.SignUpload(ctx, bucket, obj, &storage.SignedURLOptions{ContentType: "application/x-tar"})
+	failOnErr(t, err)
+
+	do := func(method, url, contentType, body string) *http.Response {
+		req, err := http.NewRequest(method, url, strings.NewReader(body))
+		failOnErr(t, err)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		failOnErr(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := do(http.MethodPut, upload.URL, "application/x-tar", content)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected upload status: %d", resp.StatusCode)
+	}
+
+	download, err := ps.SignDownload(ctx, bucket, obj, &storage.SignedURLOptions{})
+	failOnErr(t, err)
+	if download.Size != int64(len(content)) {
+		t.Errorf("unexpected size: %d", download.Size)
+	}
+	if download.Meta.ContentType != "application/x-tar" {
+		t.Errorf("unexpected content type: %s", download.Meta.ContentType)
+	}
+
+	resp = do(http.MethodGet, download.URL, "", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected download status: %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	failOnErr(t, err)
+	if string(body) != content {
+		t.Errorf("unexpected content: %q", body)
+	}
+
+	tamper := func(u, key, value string) string {
+		pu, err := url.Parse(u)
+		failOnErr(t, err)
+		q := pu.Query()
+		q.Set(key, value)
+		pu.RawQuery = q.Encode()
+		return pu.String()
+	}
+	forbidden := []struct {
+		Name        string
+		Method      string
+		URL         string
+		ContentType string
+	}{
+		{Name: "download with upload URL", Method: http.MethodGet, URL: upload.URL},
+		{Name: "upload with download URL", Method: http.MethodPut, URL: download.URL, ContentType: "application/x-tar"},
+		{Name: "upload with different content type", Method: http.MethodPut, URL: upload.URL, ContentType: "text/plain"},
+		{Name: "tampered signature", Method: http.MethodGet, URL: tamper(download.URL, "signature", "foobar")},
+		{Name: "tampered expiry", Method: http.MethodGet, URL: tamper(download.URL, "expires", "4102444800")},
+		{Name: "expired", Method: http.MethodGet, URL: tamper(download.URL, "expires", "1")},
+		{Name: "other object", Method: http.MethodGet, URL: strings.Replace(download.URL, "full.tar", "other.tar", 1)},
+	}
+	for _, test := range forbidden {
+		t.Run(test.Name, func(t *testing.T) {
+			resp := do(test.Method, test.URL, test.ContentType, "tampered")
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("expected status %d, got %d", http.StatusForbidden, resp.StatusCode)
+			}
+		})
+	}
+
+	var res bytes.Buffer
+	_, err = io.Copy(&res, do(http.MethodGet, download.URL, "", "").Body)
+	failOnErr(t, err)
+	if res.String() != content {
+		t.Errorf("object was modified by forbidden requests: %q", res.String())
+	}
+}
+
+func TestLocalDirectAccess(t *testing.T) {
+	cfg := newLocalStorageConfig(t, "http://localhost:8080")
+	da, err := storage.NewDirectAccess(cfg)
+	failOnErr(t, err)
+
+	ctx := context.Background()
+	failOnErr(t, da.Init(ctx, "owner", "workspace", "instance"))
+	failOnErr(t, da.EnsureExists(ctx))
+
+	_, _, err = da.UploadStream(ctx, strings.NewReader("hello world"), "foo.txt")
+	failOnErr(t, err)
+
+	var res bytes.Buffer
+	found, err := da.DownloadObject(ctx, "foo.txt", &res)
+	failOnErr(t, err)
+	if !found {
+		t.Fatal("uploaded object was not found")
+	}
+	if res.String() != "hello world" {
+		t.Errorf("unexpected content: %q", res.String())
+	}
+
+	failOnErr(t, da.CopyObject(ctx, "foo.txt", "copy/foo.txt"))
+	res.Reset()
+	found, err = da.DownloadObject(ctx, "copy/foo.txt", &res)
+	failOnErr(t, err)
+	if !found || res.String() != "hello world" {
+		t.Errorf("unexpected content of copy: %q", res.String())
+	}
+
+	objs, err := da.ListObjects(ctx, "")
+	failOnErr(t, err)
+	if len(objs) != 2 {
+		t.Errorf("expected two objects, got %v", objs)
+	}
+
+	found, err = da.DownloadObject(ctx, "bar.txt", io.Discard)
+	failOnErr(t, err)
+	if found {
+		t.Error("expected missing object not to be found")
+	}
+}
+
+func TestLatestBackup(t *testing.T) {
+	const (
+		bucket      = "gitpod-user-owner"
+		workspaceID = "ws1"
+	)
+	tests := []struct {
+		Name        string
+		Ages        map[string]time.Duration
+		Expectation string
+	}{
+		{
+			Name:        "no backup",
+			Expectation: storage.DefaultChunkedBackupManifest,
+		},
+		{
+			Name:        "tar backup only",
+			Ages:        map[string]time.Duration{storage.DefaultBackup: time.Hour},
+			Expectation: storage.DefaultBackup,
+		},
+		{
+			Name:        "chunked backup only",
+			Ages:        map[string]time.Duration{storage.DefaultChunkedBackupManifest: time.Hour},
+			Expectation: storage.DefaultChunkedBackupManifest,
+		},
+		{
+			Name:        "chunked backup is newer",
+			Ages:        map[string]time.Duration{storage.DefaultBackup: 2 * time.Hour, storage.DefaultChunkedBackupManifest: time.Hour},
+			Expectation: storage.DefaultChunkedBackupManifest,
+		},
+		{
+			Name:        "tar backup is newer",
+			Ages:        map[string]time.Duration{storage.DefaultBackup: time.Hour, storage.DefaultChunkedBackupManifest: 2 * time.Hour},
+			Expectation: storage.DefaultBackup,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			cfg := newLocalStorageConfig(t, "http://localhost")
+			ps, err := storage.NewPresignedAccess(cfg)
+			failOnErr(t, err)
+
+			now := time.Now()
+			for name, age := range test.Ages {
+				fn := filepath.Join(cfg.LocalConfig.Directory, bucket, filepath.FromSlash(ps.BackupObject("owner", workspaceID, name)))
+				failOnErr(t, os.MkdirAll(filepath.Dir(fn), 0755))
+				failOnErr(t, os.WriteFile(fn, []byte("backup"), 0644))
+				failOnErr(t, os.Chtimes(fn, now.Add(-age), now.Add(-age)))
+			}
+
+			act, err := storage.LatestBackup(context.Background(), ps, bucket, ps.BackupObject("owner", workspaceID, storage.DefaultBackup), ps.BackupObject("owner", workspaceID, storage.DefaultChunkedBackupManifest))
+			failOnErr(t, err)
+			if act != test.Expectation {
+				t.Errorf("unexpected latest backup: got %s, expected %s", act, test.Expectation)
+			}
+		})
+	}
+}
+
+func TestSignDownloads(t *testing.T) {
+	const bucket = "gitpod-user-owner"
+	cfg := newLocalStorageConfig(t, "http://localhost")
+	ps, err := storage.NewPresignedAccess(cfg)
+	failOnErr(t, err)
+
+	var objs []string
+	for i := 0; i < 40; i++ {
+		obj := ps.BackupObject("owner", "ws1", fmt.Sprintf("chunks/sha256/%02d", i))
+		fn := filepath.Join(cfg.LocalConfig.Directory, bucket, filepath.FromSlash(obj))
+		failOnErr(t, os.MkdirAll(filepath.Dir(fn), 0755))
+		failOnErr(t, os.WriteFile(fn, []byte(obj), 0644))
+		objs = append(objs, obj)
+	}
+
+	infos, err := storage.SignDownloads(context.Background(), ps, bucket, objs, &storage.SignedURLOptions{})
+	failOnErr(t, err)
+	if len(infos) != len(objs) {
+		t.Fatalf("expected %d download infos, got %d", len(objs), len(infos))
+	}
+	for i, info := range infos {
+		if !strings.Contains(info.URL, objs[i]) {
+			t.Errorf("download info %d does not belong to %s: %s", i, objs[i], info.URL)
+		}
+	}
+
+	_, err = storage.SignDownloads(context.Background(), ps, bucket, append(objs, ps.BackupObject("owner", "ws1", "chunks/sha256/missing")), &storage.SignedURLOptions{})
+	if !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a missing object, got %v", err)
+	}
+}
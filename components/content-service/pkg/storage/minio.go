@@ -5,7 +5,9 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -27,6 +29,7 @@ import (
 )
 
 var _ DirectAccess = &DirectMinIOStorage{}
+var _ ObjectLister = &presignedMinIOStorage{}
 
 // Validate checks if the GCloud storage MinIOconfig is valid
 func ValidateMinIOConfig(c *config.MinIOConfig) error {
@@ -108,6 +111,9 @@ type DirectMinIOStorage struct {
 
 	client *minio.Client
 
+	// envelope encrypts workspace content if encryption is enabled
+	envelope *envelope
+
 	// ObjectAccess just exists so that we can swap out the stream access during testing
 	ObjectAccess func(ctx context.Context, btk, obj string) (io.ReadCloser, error)
 }
@@ -166,6 +172,32 @@ func (rs *DirectMinIOStorage) defaultObjectAccess(ctx context.Context, bkt, obj
 	return object, nil
 }
 
+func (rs *DirectMinIOStorage) readObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error) {
+	return rs.ObjectAccess(ctx, bkt, obj)
+}
+
+func (rs *DirectMinIOStorage) writeObject(ctx context.Context, bkt, obj string, content []byte) error {
+	if rs.client == nil {
+		return xerrors.Errorf("no minio client available - did you call Init()?")
+	}
+
+	_, err := rs.client.PutObject(ctx, bkt, obj, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{})
+	return err
+}
+
+func (rs *DirectMinIOStorage) createObject(ctx context.Context, bkt, obj string, content []byte) error {
+	if rs.client == nil {
+		return xerrors.Errorf("no minio client available - did you call Init()?")
+	}
+
+	// the minio client does not support conditional writes, but MinIO honours them for signed URLs
+	url, err := rs.client.PresignedPutObject(ctx, bkt, obj, time.Minute)
+	if err != nil {
+		return err
+	}
+	return putSignedURL(ctx, http.DefaultClient, &UploadInfo{URL: url.String()}, "", content, true)
+}
+
 // EnsureExists makes sure that the remote storage location exists and can be up- or downloaded from
 func (rs *DirectMinIOStorage) EnsureExists(ctx context.Context) (err error) {
 	return minioEnsureExists(ctx, rs.client, rs.bucketName(), rs.MinIOConfig)
@@ -211,7 +243,11 @@ func (rs *DirectMinIOStorage) download(ctx context.Context, destination string,
 	}
 	defer rc.Close()
 
-	err = extractTarbal(ctx, destination, rc, mappings)
+	src, err := decryptDownload(ctx, rs.envelope, rs, bkt, rc)
+	if err != nil {
+		return true, err
+	}
+	err = extractTarbal(ctx, destination, src, mappings)
 	if err != nil {
 		return true, err
 	}
@@ -234,6 +270,28 @@ func (rs *DirectMinIOStorage) DownloadSnapshot(ctx context.Context, destination
 	return rs.download(ctx, destination, bkt, obj, mappings)
 }
 
+// DownloadObject writes the content of a single object to dst without extracting it
+func (rs *DirectMinIOStorage) DownloadObject(ctx context.Context, name string, dst io.Writer) (found bool, err error) {
+	rc, err := rs.ObjectAccess(ctx, rs.bucketName(), rs.objectName(name))
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+
+	src, err := decryptDownload(ctx, rs.envelope, rs, rs.bucketName(), rc)
+	if err != nil {
+		return true, err
+	}
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
 // ListObjects returns all objects found with the given prefix. Returns an empty list if the bucket does not exuist (yet).
 func (rs *DirectMinIOStorage) ListObjects(ctx context.Context, prefix string) (objects []string, err error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -272,11 +330,25 @@ func (rs *DirectMinIOStorage) UploadInstance(ctx context.Context, source string,
 	if rs.InstanceID == "" {
 		return "", "", xerrors.Errorf("instanceID is required to comput object name")
 	}
-	return rs.Upload(ctx, source, InstanceObjectName(rs.InstanceID, name), opts...)
+	// instance objects, e.g. prebuild logs, are not workspace content and hence never encrypted
+	return rs.upload(ctx, source, InstanceObjectName(rs.InstanceID, name), false, opts...)
 }
 
 // Upload takes all files from a local location and uploads it to the remote storage
 func (rs *DirectMinIOStorage) Upload(ctx context.Context, source string, name string, opts ...UploadOption) (bucket, obj string, err error) {
+	return rs.upload(ctx, source, name, encryptable(name), opts...)
+}
+
+func (rs *DirectMinIOStorage) upload(ctx context.Context, source string, name string, encrypt bool, opts ...UploadOption) (bucket, obj string, err error) {
+	if encrypt && rs.envelope != nil {
+		f, err := os.Open(source)
+		if err != nil {
+			return "", "", xerrors.Errorf("cannot open file for uploading: %w", err)
+		}
+		defer f.Close()
+		return rs.UploadStream(ctx, f, name, opts...)
+	}
+
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "DirectUpload")
 	defer tracing.FinishSpan(span, &err)
@@ -312,6 +384,78 @@ func (rs *DirectMinIOStorage) Upload(ctx context.Context, source string, name st
 	return
 }
 
+// UploadStream uploads everything read from src to the remote storage without buffering it on disk
+func (rs *DirectMinIOStorage) UploadStream(ctx context.Context, src io.Reader, name string, opts ...UploadOption) (bucket, obj string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DirectUploadStream")
+	defer tracing.FinishSpan(span, &err)
+
+	options, err := GetUploadOptions(opts)
+	if err != nil {
+		err = xerrors.Errorf("cannot get options: %w", err)
+		return
+	}
+
+	if rs.client == nil {
+		err = xerrors.Errorf("no minio client available - did you call Init()?")
+		return
+	}
+
+	bucket = rs.bucketName()
+	obj = rs.objectName(name)
+	span.LogKV("bucket", bucket)
+	span.LogKV("obj", obj)
+	span.LogKV("endpoint", rs.MinIOConfig.Endpoint)
+
+	content, err := encryptUpload(ctx, rs.envelope, rs, bucket, rs.objectName(DefaultDataKey), name, src)
+	if err != nil {
+		err = xerrors.Errorf("cannot encrypt %s: %w", obj, err)
+		return
+	}
+	defer content.Close()
+
+	// The size of the stream is unknown, hence we have to set the part size explicitly.
+	// Otherwise minio buffers parts large enough for the maximum object size.
+	_, err = rs.client.PutObject(ctx, bucket, obj, content, -1, minio.PutObjectOptions{
+		NumThreads:   rs.MinIOConfig.ParallelUpload,
+		PartSize:     streamPartSize,
+		UserMetadata: options.Annotations,
+		ContentType:  options.ContentType,
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// CopyObject copies an object to another name within the remote storage without downloading it
+func (rs *DirectMinIOStorage) CopyObject(ctx context.Context, src, dst string) (err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DirectCopyObject")
+	defer tracing.FinishSpan(span, &err)
+
+	if rs.client == nil {
+		return xerrors.Errorf("no minio client available - did you call Init()?")
+	}
+
+	bucket := rs.bucketName()
+	srcObj, dstObj := rs.objectName(src), rs.objectName(dst)
+	span.LogKV("bucket", bucket)
+	span.LogKV("src", srcObj)
+	span.LogKV("dst", dstObj)
+
+	// composing copies objects larger than 5GiB in several parts and keeps the metadata of the source
+	_, err = rs.client.ComposeObject(ctx,
+		minio.CopyDestOptions{Bucket: bucket, Object: dstObj},
+		minio.CopySrcOptions{Bucket: bucket, Object: srcObj},
+	)
+	if err != nil {
+		return xerrors.Errorf("cannot copy %s to %s: %w", srcObj, dstObj, err)
+	}
+	return nil
+}
+
 func minioBucketName(ownerID, bucketName string) string {
 	if bucketName != "" {
 		return bucketName
@@ -386,6 +530,55 @@ func (s *presignedMinIOStorage) DiskUsage(ctx context.Context, bucket string, pr
 	return total, nil
 }
 
+// ListBuckets returns the configured bucket or all user buckets
+func (s *presignedMinIOStorage) ListBuckets(ctx context.Context) (buckets []string, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "minio.ListBuckets")
+	defer tracing.FinishSpan(span, &err)
+
+	if s.MinIOConfig.BucketName != "" {
+		return []string{s.MinIOConfig.BucketName}, nil
+	}
+
+	bkts, err := s.client.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prefix := minioBucketName("", "")
+	for _, bkt := range bkts {
+		if strings.HasPrefix(bkt.Name, prefix) {
+			buckets = append(buckets, bkt.Name)
+		}
+	}
+	return buckets, nil
+}
+
+// ListObjects returns all objects in the bucket whose name starts with prefix
+func (s *presignedMinIOStorage) ListObjects(ctx context.Context, bucket, prefix string) (objects []ObjectInfo, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "minio.ListObjects")
+	defer tracing.FinishSpan(span, &err)
+
+	objectCh := s.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	})
+	for object := range objectCh {
+		if object.Err != nil {
+			if translateMinioError(object.Err) == ErrNotFound {
+				return nil, nil
+			}
+			return nil, object.Err
+		}
+		objects = append(objects, ObjectInfo{
+			Name:         object.Key,
+			Size:         object.Size,
+			LastModified: object.LastModified,
+		})
+	}
+	return objects, nil
+}
+
 func (s *presignedMinIOStorage) SignDownload(ctx context.Context, bucket, object string, options *SignedURLOptions) (info *DownloadInfo, err error) {
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "minio.SignDownload")
@@ -407,7 +600,7 @@ func (s *presignedMinIOStorage) SignDownload(ctx context.Context, bucket, object
 	if err != nil {
 		return nil, translateMinioError(err)
 	}
-	url, err := s.client.PresignedGetObject(ctx, bucket, object, 30*time.Minute, nil)
+	url, err := s.client.PresignedGetObject(ctx, bucket, object, options.expiry(30*time.Minute), nil)
 	if err != nil {
 		return nil, translateMinioError(err)
 	}
@@ -438,7 +631,7 @@ func (s *presignedMinIOStorage) SignUpload(ctx context.Context, bucket, obj stri
 		tracing.FinishSpan(span, &err)
 	}()
 
-	url, err := s.client.PresignedPutObject(ctx, bucket, obj, 30*time.Minute)
+	url, err := s.client.PresignedPutObject(ctx, bucket, obj, options.expiry(30*time.Minute))
 	if err != nil {
 		return nil, translateMinioError(err)
 	}
@@ -3,15 +3,22 @@
 // See License.AGPL.txt in the project root for license information.
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gitpod-io/gitpod/content-service/pkg/storage (interfaces: PresignedAccess,DirectAccess,PresignedS3Client,S3Client)
+// Source: github.com/gitpod-io/gitpod/content-service/pkg/storage (interfaces: PresignedAccess,DirectAccess,PresignedS3Client,S3Client,AzureClient,AzureBlobClient)
 
 // Package mock is a generated GoMock package.
 package mock
 
 import (
 	context "context"
+	io "io"
+	os "os"
 	reflect "reflect"
+	time "time"
 
+	runtime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	azblob "github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	blob "github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	sas "github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	s3 "github.com/aws/aws-sdk-go-v2/service/s3"
 	archive "github.com/gitpod-io/gitpod/content-service/pkg/archive"
@@ -267,6 +274,20 @@ func (mr *MockDirectAccessMockRecorder) Bucket(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bucket", reflect.TypeOf((*MockDirectAccess)(nil).Bucket), arg0)
 }
 
+// CopyObject mocks base method.
+func (m *MockDirectAccess) CopyObject(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyObject", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyObject indicates an expected call of CopyObject.
+func (mr *MockDirectAccessMockRecorder) CopyObject(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyObject", reflect.TypeOf((*MockDirectAccess)(nil).CopyObject), arg0, arg1, arg2)
+}
+
 // Download mocks base method.
 func (m *MockDirectAccess) Download(arg0 context.Context, arg1, arg2 string, arg3 []archive.IDMapping) (bool, error) {
 	m.ctrl.T.Helper()
@@ -297,6 +318,21 @@ func (mr *MockDirectAccessMockRecorder) DownloadSnapshot(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSnapshot", reflect.TypeOf((*MockDirectAccess)(nil).DownloadSnapshot), arg0, arg1, arg2, arg3)
 }
 
+// DownloadObject mocks base method.
+func (m *MockDirectAccess) DownloadObject(arg0 context.Context, arg1 string, arg2 io.Writer) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadObject", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadObject indicates an expected call of DownloadObject.
+func (mr *MockDirectAccessMockRecorder) DownloadObject(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadObject", reflect.TypeOf((*MockDirectAccess)(nil).DownloadObject), arg0, arg1, arg2)
+}
+
 // EnsureExists mocks base method.
 func (m *MockDirectAccess) EnsureExists(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -396,6 +432,27 @@ func (mr *MockDirectAccessMockRecorder) UploadInstance(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadInstance", reflect.TypeOf((*MockDirectAccess)(nil).UploadInstance), varargs...)
 }
 
+// UploadStream mocks base method.
+func (m *MockDirectAccess) UploadStream(arg0 context.Context, arg1 io.Reader, arg2 string, arg3 ...storage.UploadOption) (string, string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadStream", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UploadStream indicates an expected call of UploadStream.
+func (mr *MockDirectAccessMockRecorder) UploadStream(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadStream", reflect.TypeOf((*MockDirectAccess)(nil).UploadStream), varargs...)
+}
+
 // MockPresignedS3Client is a mock of PresignedS3Client interface.
 type MockPresignedS3Client struct {
 	ctrl     *gomock.Controller
@@ -482,6 +539,86 @@ func (m *MockS3Client) EXPECT() *MockS3ClientMockRecorder {
 	return m.recorder
 }
 
+// AbortMultipartUpload mocks base method.
+func (m *MockS3Client) AbortMultipartUpload(arg0 context.Context, arg1 *s3.AbortMultipartUploadInput, arg2 ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AbortMultipartUpload", varargs...)
+	ret0, _ := ret[0].(*s3.AbortMultipartUploadOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AbortMultipartUpload indicates an expected call of AbortMultipartUpload.
+func (mr *MockS3ClientMockRecorder) AbortMultipartUpload(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortMultipartUpload", reflect.TypeOf((*MockS3Client)(nil).AbortMultipartUpload), varargs...)
+}
+
+// CompleteMultipartUpload mocks base method.
+func (m *MockS3Client) CompleteMultipartUpload(arg0 context.Context, arg1 *s3.CompleteMultipartUploadInput, arg2 ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CompleteMultipartUpload", varargs...)
+	ret0, _ := ret[0].(*s3.CompleteMultipartUploadOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMultipartUpload indicates an expected call of CompleteMultipartUpload.
+func (mr *MockS3ClientMockRecorder) CompleteMultipartUpload(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMultipartUpload", reflect.TypeOf((*MockS3Client)(nil).CompleteMultipartUpload), varargs...)
+}
+
+// CopyObject mocks base method.
+func (m *MockS3Client) CopyObject(arg0 context.Context, arg1 *s3.CopyObjectInput, arg2 ...func(*s3.Options)) (*s3.CopyObjectOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CopyObject", varargs...)
+	ret0, _ := ret[0].(*s3.CopyObjectOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyObject indicates an expected call of CopyObject.
+func (mr *MockS3ClientMockRecorder) CopyObject(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyObject", reflect.TypeOf((*MockS3Client)(nil).CopyObject), varargs...)
+}
+
+// CreateMultipartUpload mocks base method.
+func (m *MockS3Client) CreateMultipartUpload(arg0 context.Context, arg1 *s3.CreateMultipartUploadInput, arg2 ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateMultipartUpload", varargs...)
+	ret0, _ := ret[0].(*s3.CreateMultipartUploadOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMultipartUpload indicates an expected call of CreateMultipartUpload.
+func (mr *MockS3ClientMockRecorder) CreateMultipartUpload(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMultipartUpload", reflect.TypeOf((*MockS3Client)(nil).CreateMultipartUpload), varargs...)
+}
+
 // DeleteObjects mocks base method.
 func (m *MockS3Client) DeleteObjects(arg0 context.Context, arg1 *s3.DeleteObjectsInput, arg2 ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
 	m.ctrl.T.Helper()
@@ -522,6 +659,46 @@ func (mr *MockS3ClientMockRecorder) GetObject(arg0, arg1 interface{}, arg2 ...in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockS3Client)(nil).GetObject), varargs...)
 }
 
+// HeadObject mocks base method.
+func (m *MockS3Client) HeadObject(arg0 context.Context, arg1 *s3.HeadObjectInput, arg2 ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HeadObject", varargs...)
+	ret0, _ := ret[0].(*s3.HeadObjectOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeadObject indicates an expected call of HeadObject.
+func (mr *MockS3ClientMockRecorder) HeadObject(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeadObject", reflect.TypeOf((*MockS3Client)(nil).HeadObject), varargs...)
+}
+
+// PutObject mocks base method.
+func (m *MockS3Client) PutObject(arg0 context.Context, arg1 *s3.PutObjectInput, arg2 ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutObject", varargs...)
+	ret0, _ := ret[0].(*s3.PutObjectOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutObject indicates an expected call of PutObject.
+func (mr *MockS3ClientMockRecorder) PutObject(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockS3Client)(nil).PutObject), varargs...)
+}
+
 // GetObjectAttributes mocks base method.
 func (m *MockS3Client) GetObjectAttributes(arg0 context.Context, arg1 *s3.GetObjectAttributesInput, arg2 ...func(*s3.Options)) (*s3.GetObjectAttributesOutput, error) {
 	m.ctrl.T.Helper()
@@ -561,3 +738,261 @@ func (mr *MockS3ClientMockRecorder) ListObjectsV2(arg0, arg1 interface{}, arg2 .
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjectsV2", reflect.TypeOf((*MockS3Client)(nil).ListObjectsV2), varargs...)
 }
+
+// UploadPartCopy mocks base method.
+func (m *MockS3Client) UploadPartCopy(arg0 context.Context, arg1 *s3.UploadPartCopyInput, arg2 ...func(*s3.Options)) (*s3.UploadPartCopyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadPartCopy", varargs...)
+	ret0, _ := ret[0].(*s3.UploadPartCopyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadPartCopy indicates an expected call of UploadPartCopy.
+func (mr *MockS3ClientMockRecorder) UploadPartCopy(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPartCopy", reflect.TypeOf((*MockS3Client)(nil).UploadPartCopy), varargs...)
+}
+
+// MockAzureClient is a mock of AzureClient interface.
+type MockAzureClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAzureClientMockRecorder
+}
+
+// MockAzureClientMockRecorder is the mock recorder for MockAzureClient.
+type MockAzureClientMockRecorder struct {
+	mock *MockAzureClient
+}
+
+// NewMockAzureClient creates a new mock instance.
+func NewMockAzureClient(ctrl *gomock.Controller) *MockAzureClient {
+	mock := &MockAzureClient{ctrl: ctrl}
+	mock.recorder = &MockAzureClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAzureClient) EXPECT() *MockAzureClientMockRecorder {
+	return m.recorder
+}
+
+// CreateContainer mocks base method.
+func (m *MockAzureClient) CreateContainer(arg0 context.Context, arg1 string, arg2 *azblob.CreateContainerOptions) (azblob.CreateContainerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContainer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(azblob.CreateContainerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContainer indicates an expected call of CreateContainer.
+func (mr *MockAzureClientMockRecorder) CreateContainer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContainer", reflect.TypeOf((*MockAzureClient)(nil).CreateContainer), arg0, arg1, arg2)
+}
+
+// DeleteBlob mocks base method.
+func (m *MockAzureClient) DeleteBlob(arg0 context.Context, arg1, arg2 string, arg3 *azblob.DeleteBlobOptions) (azblob.DeleteBlobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlob", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(azblob.DeleteBlobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBlob indicates an expected call of DeleteBlob.
+func (mr *MockAzureClientMockRecorder) DeleteBlob(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlob", reflect.TypeOf((*MockAzureClient)(nil).DeleteBlob), arg0, arg1, arg2, arg3)
+}
+
+// DeleteContainer mocks base method.
+func (m *MockAzureClient) DeleteContainer(arg0 context.Context, arg1 string, arg2 *azblob.DeleteContainerOptions) (azblob.DeleteContainerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContainer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(azblob.DeleteContainerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteContainer indicates an expected call of DeleteContainer.
+func (mr *MockAzureClientMockRecorder) DeleteContainer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContainer", reflect.TypeOf((*MockAzureClient)(nil).DeleteContainer), arg0, arg1, arg2)
+}
+
+// DownloadStream mocks base method.
+func (m *MockAzureClient) DownloadStream(arg0 context.Context, arg1, arg2 string, arg3 *azblob.DownloadStreamOptions) (azblob.DownloadStreamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadStream", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(azblob.DownloadStreamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadStream indicates an expected call of DownloadStream.
+func (mr *MockAzureClientMockRecorder) DownloadStream(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadStream", reflect.TypeOf((*MockAzureClient)(nil).DownloadStream), arg0, arg1, arg2, arg3)
+}
+
+// NewListBlobsFlatPager mocks base method.
+func (m *MockAzureClient) NewListBlobsFlatPager(arg0 string, arg1 *azblob.ListBlobsFlatOptions) *runtime.Pager[azblob.ListBlobsFlatResponse] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewListBlobsFlatPager", arg0, arg1)
+	ret0, _ := ret[0].(*runtime.Pager[azblob.ListBlobsFlatResponse])
+	return ret0
+}
+
+// NewListBlobsFlatPager indicates an expected call of NewListBlobsFlatPager.
+func (mr *MockAzureClientMockRecorder) NewListBlobsFlatPager(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewListBlobsFlatPager", reflect.TypeOf((*MockAzureClient)(nil).NewListBlobsFlatPager), arg0, arg1)
+}
+
+// NewListContainersPager mocks base method.
+func (m *MockAzureClient) NewListContainersPager(arg0 *azblob.ListContainersOptions) *runtime.Pager[azblob.ListContainersResponse] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewListContainersPager", arg0)
+	ret0, _ := ret[0].(*runtime.Pager[azblob.ListContainersResponse])
+	return ret0
+}
+
+// NewListContainersPager indicates an expected call of NewListContainersPager.
+func (mr *MockAzureClientMockRecorder) NewListContainersPager(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewListContainersPager", reflect.TypeOf((*MockAzureClient)(nil).NewListContainersPager), arg0)
+}
+
+// UploadBuffer mocks base method.
+func (m *MockAzureClient) UploadBuffer(arg0 context.Context, arg1, arg2 string, arg3 []byte, arg4 *azblob.UploadBufferOptions) (azblob.UploadBufferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadBuffer", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(azblob.UploadBufferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadBuffer indicates an expected call of UploadBuffer.
+func (mr *MockAzureClientMockRecorder) UploadBuffer(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadBuffer", reflect.TypeOf((*MockAzureClient)(nil).UploadBuffer), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UploadFile mocks base method.
+func (m *MockAzureClient) UploadFile(arg0 context.Context, arg1, arg2 string, arg3 *os.File, arg4 *azblob.UploadFileOptions) (azblob.UploadFileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFile", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(azblob.UploadFileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadFile indicates an expected call of UploadFile.
+func (mr *MockAzureClientMockRecorder) UploadFile(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockAzureClient)(nil).UploadFile), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UploadStream mocks base method.
+func (m *MockAzureClient) UploadStream(arg0 context.Context, arg1, arg2 string, arg3 io.Reader, arg4 *azblob.UploadStreamOptions) (azblob.UploadStreamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadStream", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(azblob.UploadStreamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadStream indicates an expected call of UploadStream.
+func (mr *MockAzureClientMockRecorder) UploadStream(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadStream", reflect.TypeOf((*MockAzureClient)(nil).UploadStream), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockAzureBlobClient is a mock of AzureBlobClient interface.
+type MockAzureBlobClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAzureBlobClientMockRecorder
+}
+
+// MockAzureBlobClientMockRecorder is the mock recorder for MockAzureBlobClient.
+type MockAzureBlobClientMockRecorder struct {
+	mock *MockAzureBlobClient
+}
+
+// NewMockAzureBlobClient creates a new mock instance.
+func NewMockAzureBlobClient(ctrl *gomock.Controller) *MockAzureBlobClient {
+	mock := &MockAzureBlobClient{ctrl: ctrl}
+	mock.recorder = &MockAzureBlobClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAzureBlobClient) EXPECT() *MockAzureBlobClientMockRecorder {
+	return m.recorder
+}
+
+// GetProperties mocks base method.
+func (m *MockAzureBlobClient) GetProperties(arg0 context.Context, arg1 *blob.GetPropertiesOptions) (blob.GetPropertiesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProperties", arg0, arg1)
+	ret0, _ := ret[0].(blob.GetPropertiesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProperties indicates an expected call of GetProperties.
+func (mr *MockAzureBlobClientMockRecorder) GetProperties(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProperties", reflect.TypeOf((*MockAzureBlobClient)(nil).GetProperties), arg0, arg1)
+}
+
+// GetSASURL mocks base method.
+func (m *MockAzureBlobClient) GetSASURL(arg0 sas.BlobPermissions, arg1 time.Time, arg2 *blob.GetSASURLOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSASURL", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSASURL indicates an expected call of GetSASURL.
+func (mr *MockAzureBlobClientMockRecorder) GetSASURL(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSASURL", reflect.TypeOf((*MockAzureBlobClient)(nil).GetSASURL), arg0, arg1, arg2)
+}
+
+// StartCopyFromURL mocks base method.
+func (m *MockAzureBlobClient) StartCopyFromURL(arg0 context.Context, arg1 string, arg2 *blob.StartCopyFromURLOptions) (blob.StartCopyFromURLResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCopyFromURL", arg0, arg1, arg2)
+	ret0, _ := ret[0].(blob.StartCopyFromURLResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartCopyFromURL indicates an expected call of StartCopyFromURL.
+func (mr *MockAzureBlobClientMockRecorder) StartCopyFromURL(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCopyFromURL", reflect.TypeOf((*MockAzureBlobClient)(nil).StartCopyFromURL), arg0, arg1, arg2)
+}
+
+// URL mocks base method.
+func (m *MockAzureBlobClient) URL() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URL")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// URL indicates an expected call of URL.
+func (mr *MockAzureBlobClientMockRecorder) URL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URL", reflect.TypeOf((*MockAzureBlobClient)(nil).URL))
+}
@@ -6,6 +6,7 @@ package storage
 
 import (
 	"context"
+	"io"
 	"net/http"
 
 	"golang.org/x/xerrors"
@@ -16,6 +17,9 @@ import (
 // NamedURLDownloader offers downloads from fixed URLs
 type NamedURLDownloader struct {
 	URLs map[string]string
+
+	// DataKeys decrypt the objects behind the URLs of the same name if they are encrypted
+	DataKeys map[string][]byte
 }
 
 // Download takes the latest state from the remote storage and downloads it to a local path
@@ -42,11 +46,50 @@ func (d *NamedURLDownloader) Download(ctx context.Context, destination string, n
 	}
 	defer resp.Body.Close()
 
-	err = extractTarbal(ctx, destination, resp.Body, mappings)
+	src, err := Decrypt(resp.Body, d.DataKeys[name])
 	if err != nil {
 		return true, err
 	}
+	err = extractTarbal(ctx, destination, src, mappings)
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// DownloadObject writes the content of a single object to dst without extracting it
+func (d *NamedURLDownloader) DownloadObject(ctx context.Context, name string, dst io.Writer) (found bool, err error) {
+	url, found := d.URLs[name]
+	if !found {
+		return false, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
 
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, xerrors.Errorf("non-OK status code: %v", resp.StatusCode)
+	}
+
+	src, err := Decrypt(resp.Body, d.DataKeys[name])
+	if err != nil {
+		return true, err
+	}
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		return true, err
+	}
 	return true, nil
 }
 
@@ -6,6 +6,7 @@ package storage
 
 import (
 	"context"
+	"io"
 
 	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
 )
@@ -35,6 +36,11 @@ func (rs *DirectNoopStorage) DownloadSnapshot(ctx context.Context, destination s
 	return false, nil
 }
 
+// DownloadObject always returns false and does nothing
+func (rs *DirectNoopStorage) DownloadObject(ctx context.Context, name string, dst io.Writer) (bool, error) {
+	return false, nil
+}
+
 // ListObjects returns all objects found with the given prefix. Returns an empty list if the bucket does not exuist (yet).
 func (rs *DirectNoopStorage) ListObjects(ctx context.Context, prefix string) (objects []string, err error) {
 	return nil, nil
@@ -55,6 +61,17 @@ func (rs *DirectNoopStorage) Upload(ctx context.Context, source string, name str
 	return "", "", nil
 }
 
+// UploadStream consumes src and does nothing
+func (rs *DirectNoopStorage) UploadStream(ctx context.Context, src io.Reader, name string, opts ...UploadOption) (string, string, error) {
+	_, err := io.Copy(io.Discard, src)
+	return "", "", err
+}
+
+// CopyObject does nothing
+func (rs *DirectNoopStorage) CopyObject(ctx context.Context, src, dst string) error {
+	return nil
+}
+
 // Bucket returns an empty string
 func (rs *DirectNoopStorage) Bucket(string) string {
 	return ""
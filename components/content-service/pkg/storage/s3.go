@@ -5,9 +5,12 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -21,16 +24,23 @@ import (
 	s3manager "github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 )
 
 const (
 	defaultCopyConcurrency = 10
 	defaultPartSize        = 50 // MiB
 	megabytes              = 1024 * 1024
+
+	// maxCopySize is the size of the largest object S3 copies in a single request
+	maxCopySize = 5 * 1024 * megabytes
+	// copyPartSize is the part size of copies of larger objects
+	copyPartSize = 1024 * megabytes
 )
 
 var _ DirectAccess = &s3Storage{}
 var _ PresignedAccess = &PresignedS3Storage{}
+var _ ObjectLister = &PresignedS3Storage{}
 
 type S3Config struct {
 	Bucket string
@@ -41,6 +51,13 @@ type S3Client interface {
 	DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
 	GetObjectAttributes(ctx context.Context, params *s3.GetObjectAttributesInput, optFns ...func(*s3.Options)) (*s3.GetObjectAttributesOutput, error)
 	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error)
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	UploadPartCopy(ctx context.Context, params *s3.UploadPartCopyInput, optFns ...func(*s3.Options)) (*s3.UploadPartCopyOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
 }
 
 type PresignedS3Client interface {
@@ -157,6 +174,36 @@ func (rs *PresignedS3Storage) DiskUsage(ctx context.Context, bucket string, pref
 	return
 }
 
+// ListBuckets implements ObjectLister
+func (rs *PresignedS3Storage) ListBuckets(ctx context.Context) ([]string, error) {
+	return []string{rs.Config.Bucket}, nil
+}
+
+// ListObjects implements ObjectLister
+func (rs *PresignedS3Storage) ListObjects(ctx context.Context, bucket string, prefix string) (objects []ObjectInfo, err error) {
+	paginator := s3.NewListObjectsV2Paginator(rs.client, &s3.ListObjectsV2Input{
+		Bucket: &rs.Config.Bucket,
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range page.Contents {
+			nfo := ObjectInfo{
+				Name: aws.ToString(obj.Key),
+				Size: obj.Size,
+			}
+			if obj.LastModified != nil {
+				nfo.LastModified = *obj.LastModified
+			}
+			objects = append(objects, nfo)
+		}
+	}
+	return objects, nil
+}
+
 // EnsureExists implements PresignedAccess
 func (rs *PresignedS3Storage) EnsureExists(ctx context.Context, bucket string) error {
 	return nil
@@ -267,6 +314,49 @@ type s3Storage struct {
 	OwnerID, WorkspaceID, InstanceID string
 
 	client S3Client
+
+	// envelope encrypts workspace content if encryption is enabled
+	envelope *envelope
+}
+
+func (s3st *s3Storage) readObject(ctx context.Context, bkt, obj string) (io.ReadCloser, error) {
+	resp, err := s3st.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bkt),
+		Key:    aws.String(obj),
+	})
+	var nsk *types.NoSuchKey
+	if errors.As(err, &nsk) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s3st *s3Storage) writeObject(ctx context.Context, bkt, obj string, content []byte) error {
+	_, err := s3st.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bkt),
+		Key:    aws.String(obj),
+		Body:   bytes.NewReader(content),
+	})
+	return err
+}
+
+func (s3st *s3Storage) createObject(ctx context.Context, bkt, obj string, content []byte) error {
+	_, err := s3st.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bkt),
+		Key:    aws.String(obj),
+		Body:   bytes.NewReader(content),
+	}, func(o *s3.Options) {
+		// this version of the SDK does not support conditional writes yet
+		o.APIOptions = append(o.APIOptions, smithyhttp.AddHeaderValue("If-None-Match", IfNotExistsHeaders["If-None-Match"]))
+	})
+	var re interface{ HTTPStatusCode() int }
+	if errors.As(err, &re) && (re.HTTPStatusCode() == http.StatusPreconditionFailed || re.HTTPStatusCode() == http.StatusConflict) {
+		return errObjectExists
+	}
+	return err
 }
 
 // Bucket implements DirectAccess
@@ -315,7 +405,11 @@ func (s3st *s3Storage) download(ctx context.Context, destination string, obj str
 		return false, err
 	}
 
-	err = archive.ExtractTarbal(ctx, s3File, destination, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
+	src, err := decryptDownload(ctx, s3st.envelope, s3st, s3st.Config.Bucket, s3File)
+	if err != nil {
+		return true, err
+	}
+	err = archive.ExtractTarbal(ctx, src, destination, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
 	if err != nil {
 		return true, xerrors.Errorf("tar %s: %s", destination, err.Error())
 	}
@@ -323,6 +417,32 @@ func (s3st *s3Storage) download(ctx context.Context, destination string, obj str
 	return true, nil
 }
 
+// DownloadObject implements DirectAccess
+func (s3st *s3Storage) DownloadObject(ctx context.Context, name string, dst io.Writer) (found bool, err error) {
+	resp, err := s3st.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s3st.Config.Bucket),
+		Key:    aws.String(s3st.objectName(name)),
+	})
+	var nsk *types.NoSuchKey
+	if errors.As(err, &nsk) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	src, err := decryptDownload(ctx, s3st.envelope, s3st, s3st.Config.Bucket, resp.Body)
+	if err != nil {
+		return true, err
+	}
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
 // EnsureExists implements DirectAccess
 func (*s3Storage) EnsureExists(ctx context.Context) error {
 	return nil
@@ -381,6 +501,20 @@ func s3WorkspaceBackupObjectName(ownerID, workspaceID, name string) string {
 
 // Upload implements DirectAccess
 func (s3st *s3Storage) Upload(ctx context.Context, source string, name string, opts ...UploadOption) (bucket string, obj string, err error) {
+	return s3st.upload(ctx, source, name, encryptable(name), opts...)
+}
+
+// upload uploads the file at source. Encrypted uploads are streamed, as the ciphertext is produced while reading the file.
+func (s3st *s3Storage) upload(ctx context.Context, source string, name string, encrypt bool, opts ...UploadOption) (bucket string, obj string, err error) {
+	if encrypt && s3st.envelope != nil {
+		f, err := os.Open(source)
+		if err != nil {
+			return "", "", xerrors.Errorf("cannot read backup file: %w", err)
+		}
+		defer f.Close()
+		return s3st.UploadStream(ctx, f, name, opts...)
+	}
+
 	options, err := GetUploadOptions(opts)
 	if err != nil {
 		err = xerrors.Errorf("cannot get options: %w", err)
@@ -434,10 +568,153 @@ func (s3st *s3Storage) Upload(ctx context.Context, source string, name string, o
 	return
 }
 
-// UploadInstance implements DirectAccess
+// UploadStream uploads everything read from src to the remote storage without buffering it on disk.
+// The content is encrypted if a data key envelope is configured.
+func (s3st *s3Storage) UploadStream(ctx context.Context, src io.Reader, name string, opts ...UploadOption) (bucket string, obj string, err error) {
+	options, err := GetUploadOptions(opts)
+	if err != nil {
+		err = xerrors.Errorf("cannot get options: %w", err)
+		return
+	}
+
+	if s3st.client == nil {
+		err = xerrors.Errorf("no s3 client available - did you call Init()?")
+		return
+	}
+
+	var contentType *string
+	if options.ContentType != "" {
+		contentType = aws.String(options.ContentType)
+	}
+
+	bucket = s3st.Config.Bucket
+	obj = s3st.objectName(name)
+
+	s3c, ok := s3st.client.(*s3.Client)
+	if !ok {
+		err = xerrors.Errorf("Can only upload with actual S3 client")
+		return
+	}
+
+	content, err := encryptUpload(ctx, s3st.envelope, s3st, bucket, s3st.objectName(DefaultDataKey), name, src)
+	if err != nil {
+		err = xerrors.Errorf("cannot encrypt %s: %w", obj, err)
+		return
+	}
+	defer content.Close()
+
+	// the content is no io.ReadSeeker, hence the uploader buffers each part in memory
+	uploader := s3manager.NewUploader(s3c, func(u *s3manager.Uploader) {
+		u.Concurrency = defaultCopyConcurrency
+		u.PartSize = streamPartSize
+	})
+	_, err = uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(obj),
+		Body:        content,
+		Metadata:    options.Annotations,
+		ContentType: contentType,
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// UploadInstance takes all files from a local location and uploads it to the per-instance remote storage
 func (s3st *s3Storage) UploadInstance(ctx context.Context, source string, name string, opts ...UploadOption) (bucket string, obj string, err error) {
 	if s3st.InstanceID == "" {
 		return "", "", xerrors.Errorf("instanceID is required to comput object name")
 	}
-	return s3st.Upload(ctx, source, InstanceObjectName(s3st.InstanceID, name), opts...)
+	// instance objects, e.g. prebuild logs, are not workspace content and hence never encrypted
+	return s3st.upload(ctx, source, InstanceObjectName(s3st.InstanceID, name), false, opts...)
+}
+
+// CopyObject copies an object to another name within the remote storage without downloading it.
+// Objects larger than 5GiB are copied in several parts.
+func (s3st *s3Storage) CopyObject(ctx context.Context, src, dst string) (err error) {
+	if s3st.client == nil {
+		return xerrors.Errorf("no s3 client available - did you call Init()?")
+	}
+
+	bucket := s3st.Config.Bucket
+	srcObj, dstObj := s3st.objectName(src), s3st.objectName(dst)
+	copySource := aws.String(bucket + "/" + srcObj)
+
+	head, err := s3st.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(srcObj),
+	})
+	if err != nil {
+		return xerrors.Errorf("cannot copy %s: %w", srcObj, err)
+	}
+	if head.ContentLength <= maxCopySize {
+		// the metadata of the source is copied as well
+		_, err = s3st.client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:     aws.String(bucket),
+			Key:        aws.String(dstObj),
+			CopySource: copySource,
+		})
+		if err != nil {
+			return xerrors.Errorf("cannot copy %s to %s: %w", srcObj, dstObj, err)
+		}
+		return nil
+	}
+
+	upload, err := s3st.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(dstObj),
+		ContentType: head.ContentType,
+		Metadata:    head.Metadata,
+	})
+	if err != nil {
+		return xerrors.Errorf("cannot copy %s to %s: %w", srcObj, dstObj, err)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		_, abortErr := s3st.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bucket),
+			Key:      aws.String(dstObj),
+			UploadId: upload.UploadId,
+		})
+		if abortErr != nil {
+			log.WithError(abortErr).WithField("obj", dstObj).Warn("cannot abort multipart copy")
+		}
+	}()
+
+	var parts []types.CompletedPart
+	for offset := int64(0); offset < head.ContentLength; offset += copyPartSize {
+		end := offset + copyPartSize - 1
+		if end >= head.ContentLength {
+			end = head.ContentLength - 1
+		}
+		partNumber := int32(len(parts) + 1)
+		part, err := s3st.client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
+			Bucket:            aws.String(bucket),
+			Key:               aws.String(dstObj),
+			UploadId:          upload.UploadId,
+			PartNumber:        partNumber,
+			CopySource:        copySource,
+			CopySourceRange:   aws.String(fmt.Sprintf("bytes=%d-%d", offset, end)),
+			CopySourceIfMatch: head.ETag,
+		})
+		if err != nil {
+			return xerrors.Errorf("cannot copy part %d of %s: %w", partNumber, srcObj, err)
+		}
+		parts = append(parts, types.CompletedPart{ETag: part.CopyPartResult.ETag, PartNumber: partNumber})
+	}
+
+	_, err = s3st.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(dstObj),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		return xerrors.Errorf("cannot copy %s to %s: %w", srcObj, dstObj, err)
+	}
+	return nil
 }
@@ -10,8 +10,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"regexp"
+	"strings"
+	"time"
 
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/sync/errgroup"
 	"golang.org/x/xerrors"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -31,13 +36,36 @@ const (
 
 	// FmtFullWorkspaceBackup is the format for names of full workspace backups
 	FmtFullWorkspaceBackup = "wsfull-%d.tar"
+
+	// DefaultChunkedBackupManifest is the name of the manifest of a regular backup which is stored as chunks.
+	// If DefaultBackup exists as well, the more recently modified one is restored, see LatestBackup.
+	DefaultChunkedBackupManifest = "full.chunks.json"
+
+	// BackupChunkPrefix is the prefix of all backup chunk names
+	BackupChunkPrefix = "chunks/"
+
+	// BackupHistoryManifest is the name of the index of previous backups of a workspace
+	BackupHistoryManifest = "backups.json"
+
+	// BackupVersionPrefix is the prefix of all objects which hold previous backups
+	BackupVersionPrefix = "backups/"
 )
 
 var (
 	// ErrNotFound is returned when an object is not found
 	ErrNotFound = fmt.Errorf("not found")
+
+	// errObjectExists is returned when an object which must not exist yet exists already
+	errObjectExists = fmt.Errorf("object exists already")
 )
 
+// IfNotExistsHeaders are the headers an upload using a URL signed with SignedURLOptions.IfNotExists must carry.
+// Each storage backend honours the header it understands.
+var IfNotExistsHeaders = map[string]string{
+	"If-None-Match":              "*",
+	"x-goog-if-generation-match": "0",
+}
+
 // BucketNamer provides names for storage buckets
 type BucketNamer interface {
 	// Bucket provides the bucket name for a particular user
@@ -98,6 +126,22 @@ type PresignedAccess interface {
 	InstanceObject(ownerID string, workspaceID string, instanceID string, name string) string
 }
 
+// ObjectLister is implemented by presigned access to storage which can enumerate its content
+type ObjectLister interface {
+	// ListBuckets returns all buckets which hold user content
+	ListBuckets(ctx context.Context) ([]string, error)
+
+	// ListObjects returns all objects in the bucket whose name starts with prefix. Returns an empty list if the bucket does not exist.
+	ListObjects(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error)
+}
+
+// ObjectInfo describes a remote storage object as listed by an ObjectLister
+type ObjectInfo struct {
+	Name         string
+	Size         int64
+	LastModified time.Time
+}
+
 // ObjectMeta describtes the metadata of a remote object
 type ObjectMeta struct {
 	ContentType        string
@@ -111,11 +155,21 @@ type DownloadInfo struct {
 	Meta ObjectMeta
 	URL  string
 	Size int64
+
+	// DataKey decrypts the object if it is encrypted. Anyone holding the download info can read the object.
+	DataKey []byte `json:",omitempty"`
+
+	// DecryptedURL serves the plaintext of an encrypted object through the content-service.
+	// Empty if the object is not encrypted or the content-service does not serve downloads.
+	DecryptedURL string `json:",omitempty"`
 }
 
 // UploadInfo describes an object for upload
 type UploadInfo struct {
 	URL string
+
+	// Headers are the headers an upload to URL must carry, e.g. the blob type Azure requires
+	Headers map[string]string
 }
 
 // DeleteObjectQuery specifies objects to delete, either by an exact name or prefix
@@ -130,6 +184,23 @@ type SignedURLOptions struct {
 	// to use the generated signed URL.
 	// Optional.
 	ContentType string
+
+	// IfNotExists makes the upload fail if the object exists already.
+	// Uploads must carry the IfNotExistsHeaders.
+	// Optional.
+	IfNotExists bool
+
+	// Expiry is how long the signed URL stays valid.
+	// Optional, backends fall back to their own default if zero.
+	Expiry time.Duration
+}
+
+// expiry returns how long a URL signed with these options stays valid, defaulting to def
+func (o *SignedURLOptions) expiry(def time.Duration) time.Duration {
+	if o != nil && o.Expiry > 0 {
+		return o.Expiry
+	}
+	return def
 }
 
 // DirectDownloader downloads a snapshot
@@ -139,6 +210,9 @@ type DirectDownloader interface {
 
 	// Downloads a snapshot. The snapshot name is expected to be one produced by Qualify
 	DownloadSnapshot(ctx context.Context, destination string, name string, mappings []archive.IDMapping) (found bool, err error)
+
+	// DownloadObject writes the content of a single object to dst without extracting it
+	DownloadObject(ctx context.Context, name string, dst io.Writer) (found bool, err error)
 }
 
 // DirectAccess represents a remote location where we can store data
@@ -162,8 +236,14 @@ type DirectAccess interface {
 	// Upload takes all files from a local location and uploads it to the remote storage
 	Upload(ctx context.Context, source string, name string, options ...UploadOption) (bucket, obj string, err error)
 
+	// UploadStream uploads everything read from src to the remote storage without buffering it on disk
+	UploadStream(ctx context.Context, src io.Reader, name string, options ...UploadOption) (bucket, obj string, err error)
+
 	// UploadInstance takes all files from a local location and uploads it to the remote storage
 	UploadInstance(ctx context.Context, source string, name string, options ...UploadOption) (bucket, obj string, err error)
+
+	// CopyObject copies an object to another name within the remote storage without downloading it
+	CopyObject(ctx context.Context, src, dst string) error
 }
 
 // UploadOptions configure remote storage upload
@@ -216,6 +296,9 @@ const (
 	ObjectAnnotationOCIContentType = "gitpod-oci-contentType"
 )
 
+// streamPartSize is the size of the parts UploadStream buffers in memory for multipart uploads
+const streamPartSize = 16 * 1024 * 1024
+
 // NewDirectAccess provides direct access to a storage system
 func NewDirectAccess(c *config.StorageConfig) (DirectAccess, error) {
 	stage := c.GetStage()
@@ -223,20 +306,61 @@ func NewDirectAccess(c *config.StorageConfig) (DirectAccess, error) {
 		return nil, xerrors.Errorf("missing storage stage")
 	}
 
+	var env *envelope
+	if c.Encryption != nil {
+		kms, err := NewKMS(c.Encryption)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create KMS: %w", err)
+		}
+		env = newEnvelope(kms)
+	}
+
 	switch c.Kind {
 	case config.GCloudStorage:
-		return newDirectGCPAccess(c.GCloudConfig, stage)
+		rs, err := newDirectGCPAccess(c.GCloudConfig, stage)
+		if err != nil {
+			return nil, err
+		}
+		rs.envelope = env
+		return rs, nil
 	case config.MinIOStorage:
-		return newDirectMinIOAccess(c.MinIOConfig)
+		rs, err := newDirectMinIOAccess(c.MinIOConfig)
+		if err != nil {
+			return nil, err
+		}
+		rs.envelope = env
+		return rs, nil
 	case config.S3Storage:
 		cfg, err := loadAwsConfig(c.S3Config)
 		if err != nil {
 			return nil, err
 		}
 
-		return newDirectS3Access(s3.NewFromConfig(*cfg), S3Config{
+		rs := newDirectS3Access(s3.NewFromConfig(*cfg), S3Config{
 			Bucket: c.S3Config.Bucket,
-		}), nil
+		})
+		rs.envelope = env
+		return rs, nil
+	case config.LocalStorage:
+		if c.LocalConfig == nil {
+			return nil, xerrors.Errorf("missing local storage config")
+		}
+		rs, err := newDirectLocalAccess(*c.LocalConfig)
+		if err != nil {
+			return nil, err
+		}
+		rs.envelope = env
+		return rs, nil
+	case config.AzureStorage:
+		if c.AzureConfig == nil {
+			return nil, xerrors.Errorf("missing Azure storage config")
+		}
+		rs, err := newDirectAzureAccess(*c.AzureConfig)
+		if err != nil {
+			return nil, err
+		}
+		rs.envelope = env
+		return rs, nil
 	default:
 		return &DirectNoopStorage{}, nil
 	}
@@ -244,6 +368,34 @@ func NewDirectAccess(c *config.StorageConfig) (DirectAccess, error) {
 
 // NewPresignedAccess provides presigned URLs to access a storage system
 func NewPresignedAccess(c *config.StorageConfig) (PresignedAccess, error) {
+	ps, err := newPresignedAccess(c)
+	if err != nil {
+		return nil, err
+	}
+	if c.Encryption == nil {
+		return ps, nil
+	}
+
+	kms, err := NewKMS(c.Encryption)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create KMS: %w", err)
+	}
+	var downloads *DownloadSigner
+	if c.Download != nil {
+		downloads, err = NewDownloadSigner(*c.Download)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create download signer: %w", err)
+		}
+	}
+	return &encryptedPresignedAccess{
+		PresignedAccess: ps,
+		envelope:        newEnvelope(kms),
+		downloads:       downloads,
+		client:          &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func newPresignedAccess(c *config.StorageConfig) (PresignedAccess, error) {
 	stage := c.GetStage()
 	if stage == "" {
 		return nil, xerrors.Errorf("missing storage stage")
@@ -263,6 +415,21 @@ func NewPresignedAccess(c *config.StorageConfig) (PresignedAccess, error) {
 		return NewPresignedS3Access(s3.NewFromConfig(*cfg), S3Config{
 			Bucket: c.S3Config.Bucket,
 		}), nil
+	case config.LocalStorage:
+		if c.LocalConfig == nil {
+			return nil, xerrors.Errorf("missing local storage config")
+		}
+		return newPresignedLocalAccess(*c.LocalConfig)
+	case config.AzureStorage:
+		if c.AzureConfig == nil {
+			return nil, xerrors.Errorf("missing Azure storage config")
+		}
+		cfg := *c.AzureConfig
+		cl, err := NewAzureClient(&cfg)
+		if err != nil {
+			return nil, err
+		}
+		return NewPresignedAzureAccess(cl, cfg), nil
 	default:
 		log.Warnf("falling back to noop presigned storage access. Is this intentional? (storage kind: %s)", c.Kind)
 		return &PresignedNoopStorage{}, nil
@@ -308,6 +475,80 @@ func blobObjectName(name string) (string, error) {
 	return fmt.Sprintf("blobs/%s", name), nil
 }
 
+// BackupChunkName returns the name of the backup chunk with the given digest
+func BackupChunkName(dgst digest.Digest) string {
+	return fmt.Sprintf("%s%s/%s", BackupChunkPrefix, dgst.Algorithm(), dgst.Encoded())
+}
+
+// signDownloadsConcurrency is the number of downloads SignDownloads signs in parallel
+const signDownloadsConcurrency = 16
+
+// SignDownloads signs the downloads of many objects of a bucket, e.g. of the chunks of a chunked backup.
+// Signing can involve a request per object, e.g. to read the data key of encrypted objects, hence we sign in parallel.
+// The download infos are returned in the order of objs.
+func SignDownloads(ctx context.Context, s PresignedAccess, bucket string, objs []string, options *SignedURLOptions) ([]*DownloadInfo, error) {
+	res := make([]*DownloadInfo, len(objs))
+	eg, egctx := errgroup.WithContext(ctx)
+	eg.SetLimit(signDownloadsConcurrency)
+	for i, obj := range objs {
+		i, obj := i, obj
+		eg.Go(func() error {
+			info, err := s.SignDownload(egctx, bucket, obj, options)
+			if err != nil {
+				return xerrors.Errorf("cannot sign download of %s: %w", obj, err)
+			}
+			res[i] = info
+			return nil
+		})
+	}
+	err := eg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// LatestBackup determines which regular backup of a workspace was uploaded last, DefaultBackup or
+// DefaultChunkedBackupManifest. Both exist once chunking was enabled or disabled for a workspace which
+// already had a backup. Storage which cannot list objects prefers the chunked backup.
+func LatestBackup(ctx context.Context, s PresignedAccess, bucket, backupObject, chunkedObject string) (string, error) {
+	lister, ok := s.(ObjectLister)
+	if !ok {
+		return DefaultChunkedBackupManifest, nil
+	}
+
+	prefix := backupObject
+	for !strings.HasPrefix(chunkedObject, prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+	objs, err := lister.ListObjects(ctx, bucket, prefix)
+	if err != nil {
+		return "", xerrors.Errorf("cannot list backups: %w", err)
+	}
+	var backup, chunked *ObjectInfo
+	for i, obj := range objs {
+		switch obj.Name {
+		case backupObject:
+			backup = &objs[i]
+		case chunkedObject:
+			chunked = &objs[i]
+		}
+	}
+	if backup != nil && (chunked == nil || backup.LastModified.After(chunked.LastModified)) {
+		return DefaultBackup, nil
+	}
+	return DefaultChunkedBackupManifest, nil
+}
+
+// BackupVersionName returns the name of the object holding the previous backup in the given slot.
+// Slots are reused once a backup drops out of the history, hence old versions never need to be deleted explicitly.
+func BackupVersionName(slot int, chunked bool) string {
+	if chunked {
+		return fmt.Sprintf("%s%d.chunks.json", BackupVersionPrefix, slot)
+	}
+	return fmt.Sprintf("%s%d.tar", BackupVersionPrefix, slot)
+}
+
 func InstanceObjectName(instanceID, name string) string {
 	return fmt.Sprintf("instances/%s/%s", instanceID, name)
 }
@@ -5,9 +5,15 @@
 package storage
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/google/go-cmp/cmp"
 	"golang.org/x/xerrors"
 )
 
@@ -72,3 +78,80 @@ func equivalentError(e1 error, e2 error) bool {
 	}
 	return false
 }
+
+// fakeS3Client records the copy requests against a single object
+type fakeS3Client struct {
+	S3Client
+
+	Size int64
+	Ops  []string
+}
+
+func (c *fakeS3Client) HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	return &s3.HeadObjectOutput{ContentLength: c.Size, ETag: aws.String("etag")}, nil
+}
+
+func (c *fakeS3Client) CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error) {
+	c.Ops = append(c.Ops, fmt.Sprintf("copy %s to %s", *params.CopySource, *params.Key))
+	return &s3.CopyObjectOutput{}, nil
+}
+
+func (c *fakeS3Client) CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	c.Ops = append(c.Ops, "create "+*params.Key)
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String("upload")}, nil
+}
+
+func (c *fakeS3Client) UploadPartCopy(ctx context.Context, params *s3.UploadPartCopyInput, optFns ...func(*s3.Options)) (*s3.UploadPartCopyOutput, error) {
+	c.Ops = append(c.Ops, fmt.Sprintf("part %d %s", params.PartNumber, *params.CopySourceRange))
+	return &s3.UploadPartCopyOutput{CopyPartResult: &types.CopyPartResult{ETag: aws.String(fmt.Sprintf("part-%d", params.PartNumber))}}, nil
+}
+
+func (c *fakeS3Client) CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	c.Ops = append(c.Ops, fmt.Sprintf("complete %s with %d parts", *params.Key, len(params.MultipartUpload.Parts)))
+	return &s3.CompleteMultipartUploadOutput{}, nil
+}
+
+func TestS3CopyObject(t *testing.T) {
+	const gib = 1024 * megabytes
+	multipart := []string{"create owner/workspaces/ws1/backups/0.tar"}
+	for i := int64(0); i < 5; i++ {
+		multipart = append(multipart, fmt.Sprintf("part %d bytes=%d-%d", i+1, i*gib, (i+1)*gib-1))
+	}
+	multipart = append(multipart,
+		fmt.Sprintf("part 6 bytes=%d-%d", 5*gib, 5*gib),
+		"complete owner/workspaces/ws1/backups/0.tar with 6 parts",
+	)
+
+	tests := []struct {
+		Name        string
+		Size        int64
+		Expectation []string
+	}{
+		{
+			Name:        "single request",
+			Size:        5 * gib,
+			Expectation: []string{"copy bucket/owner/workspaces/ws1/full.tar to owner/workspaces/ws1/backups/0.tar"},
+		},
+		{
+			Name:        "multipart",
+			Size:        5*gib + 1,
+			Expectation: multipart,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			client := &fakeS3Client{Size: test.Size}
+			st := newDirectS3Access(client, S3Config{Bucket: "bucket"})
+			st.OwnerID, st.WorkspaceID = "owner", "ws1"
+
+			err := st.CopyObject(context.Background(), DefaultBackup, "backups/0.tar")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, client.Ops); diff != "" {
+				t.Errorf("unexpected requests (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
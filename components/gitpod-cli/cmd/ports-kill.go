@@ -0,0 +1,79 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/utils"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var portsKillCmdOpts struct {
+	UDP   bool
+	Force bool
+}
+
+// portsKillCmd terminates the process serving a port
+var portsKillCmd = &cobra.Command{
+	Use:   "kill <port>",
+	Short: "Terminates the process which serves a port",
+	Long: `Terminates the process which serves a port.
+
+The process receives SIGTERM, or SIGKILL if --force is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		port, err := strconv.ParseUint(args[0], 10, 16)
+		if err != nil {
+			return GpError{Err: xerrors.Errorf("port should be integer"), OutCome: utils.Outcome_UserErr, ErrorCode: utils.UserErrorCode_InvalidArguments}
+		}
+		protocol := api.TransportProtocol_tcp
+		if portsKillCmdOpts.UDP {
+			protocol = api.TransportProtocol_udp
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		defer cancel()
+
+		client, err := supervisor.New(ctx)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		resp, err := client.Port.KillPortProcess(ctx, &api.KillPortProcessRequest{
+			Port:              uint32(port),
+			TransportProtocol: protocol,
+			Force:             portsKillCmdOpts.Force,
+		})
+		if code := status.Code(err); code == codes.NotFound || code == codes.FailedPrecondition {
+			return GpError{Err: xerrors.Errorf("cannot kill process of port %d: %s", port, status.Convert(err).Message()), OutCome: utils.Outcome_UserErr}
+		}
+		if err != nil {
+			return xerrors.Errorf("cannot kill process of port %d: %w", port, err)
+		}
+
+		if portsKillCmdOpts.Force {
+			fmt.Printf("Killed process %d serving port %d\n", resp.Pid, port)
+		} else {
+			fmt.Printf("Asked process %d serving port %d to terminate\n", resp.Pid, port)
+		}
+		return nil
+	},
+}
+
+func init() {
+	portsCmd.AddCommand(portsKillCmd)
+	portsKillCmd.Flags().BoolVar(&portsKillCmdOpts.UDP, "udp", false, "kill the process serving the UDP port rather than the TCP port")
+	portsKillCmd.Flags().BoolVarP(&portsKillCmdOpts.Force, "force", "f", false, "kill the process with SIGKILL")
+}
@@ -41,74 +41,158 @@ var listPortsCmd = &cobra.Command{
 			return nil
 		}
 
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Port", "Status", "URL", "Name & Description"})
-		table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
-		table.SetCenterSeparator("|")
-
+		var tcpPorts, udpPorts []*api.PortsStatus
 		for _, port := range ports {
-			status := ""
-			statusColor := tablewriter.FgHiBlackColor
-			accessible := port.Exposed != nil || port.Tunneled != nil
-
-			exposedUrl := ""
-			if port.Exposed != nil {
-				exposedUrl = port.Exposed.Url
+			if port.TransportProtocol == api.TransportProtocol_udp {
+				udpPorts = append(udpPorts, port)
+			} else {
+				tcpPorts = append(tcpPorts, port)
 			}
+		}
 
-			if !port.Served {
-				status = "not served"
-			} else if !accessible {
-				if port.AutoExposure == api.PortAutoExposure_failed {
-					status = "failed to expose"
-					statusColor = tablewriter.FgRedColor
-				} else {
-					status = "detecting..."
-					statusColor = tablewriter.FgYellowColor
-				}
-			} else if port.Exposed != nil {
-				if port.Exposed.Visibility == api.PortVisibility_public {
-					status = "open (public)"
-					statusColor = tablewriter.FgHiGreenColor
-				}
-				if port.Exposed.Visibility == api.PortVisibility_private {
-					status = "open (private)"
-					statusColor = tablewriter.FgHiCyanColor
-				}
-			} else if port.Tunneled != nil {
-				if port.Tunneled.Visibility == api.TunnelVisiblity(api.TunnelVisiblity_value["network"]) {
-					status = "open on all interfaces"
-					statusColor = tablewriter.FgHiGreenColor
-				}
-				if port.Tunneled.Visibility == api.TunnelVisiblity(api.TunnelVisiblity_value["host"]) {
-					status = "open on localhost"
-					statusColor = tablewriter.FgHiGreenColor
-				}
-			}
+		if len(udpPorts) == 0 {
+			renderPortsTable(tcpPorts)
+			return nil
+		}
+		if len(tcpPorts) > 0 {
+			fmt.Println("TCP ports:")
+			renderPortsTable(tcpPorts)
+			fmt.Println()
+		}
+		fmt.Println("UDP ports:")
+		renderUDPPortsTable(udpPorts)
+		return nil
+	},
+}
 
-			nameAndDescription := port.Name
-			if len(port.Description) > 0 {
-				if len(nameAndDescription) > 0 {
-					nameAndDescription = fmt.Sprint(nameAndDescription, ": ", port.Description)
-				} else {
-					nameAndDescription = port.Description
-				}
-			}
+func renderPortsTable(ports []*api.PortsStatus) {
+	table := newPortsTable([]string{"Port", "Status", "URL", "Name & Description", "Process"})
+	for _, port := range ports {
+		status := ""
+		statusColor := tablewriter.FgHiBlackColor
+		accessible := port.Exposed != nil || port.Tunneled != nil
+
+		exposedUrl := ""
+		if port.Exposed != nil {
+			exposedUrl = port.Exposed.Url
+		}
 
-			colors := []tablewriter.Colors{}
-			if !noColor && utils.ColorsEnabled() {
-				colors = []tablewriter.Colors{{}, {statusColor}, {}, {}}
+		if !port.Served {
+			status = "not served"
+		} else if !accessible {
+			if port.AutoExposure == api.PortAutoExposure_failed {
+				status = "failed to expose"
+				statusColor = tablewriter.FgRedColor
+			} else {
+				status = "detecting..."
+				statusColor = tablewriter.FgYellowColor
 			}
+		} else if port.Exposed != nil {
+			if port.Exposed.Visibility == api.PortVisibility_public {
+				status = "open (public)"
+				statusColor = tablewriter.FgHiGreenColor
+			}
+			if port.Exposed.Visibility == api.PortVisibility_private {
+				status = "open (private)"
+				statusColor = tablewriter.FgHiCyanColor
+			}
+			if port.Exposed.Visibility == api.PortVisibility_protected {
+				status = "open (protected)"
+				statusColor = tablewriter.FgHiCyanColor
+			}
+		} else if port.Tunneled != nil {
+			status, statusColor = tunneledStatus(port.Tunneled)
+		}
 
-			table.Rich(
-				[]string{fmt.Sprint(port.LocalPort), status, exposedUrl, nameAndDescription},
-				colors,
-			)
+		colors := []tablewriter.Colors{}
+		if !noColor && utils.ColorsEnabled() {
+			colors = []tablewriter.Colors{{}, {statusColor}, {}, {}, {}}
 		}
 
-		table.Render()
-		return nil
-	},
+		table.Rich(
+			[]string{fmt.Sprint(port.LocalPort), status, exposedUrl, portNameAndDescription(port), portProcess(port)},
+			colors,
+		)
+	}
+	table.Render()
+}
+
+// renderUDPPortsTable renders UDP ports, which cannot be exposed and thus have no URL.
+func renderUDPPortsTable(ports []*api.PortsStatus) {
+	table := newPortsTable([]string{"Port", "Status", "Name & Description", "Process"})
+	for _, port := range ports {
+		status := "served"
+		statusColor := tablewriter.FgYellowColor
+		if !port.Served {
+			status = "not served"
+			statusColor = tablewriter.FgHiBlackColor
+		} else if port.Tunneled != nil {
+			status, statusColor = tunneledStatus(port.Tunneled)
+		}
+
+		colors := []tablewriter.Colors{}
+		if !noColor && utils.ColorsEnabled() {
+			colors = []tablewriter.Colors{{}, {statusColor}, {}, {}}
+		}
+
+		table.Rich(
+			[]string{fmt.Sprint(port.LocalPort), status, portNameAndDescription(port), portProcess(port)},
+			colors,
+		)
+	}
+	table.Render()
+}
+
+func newPortsTable(header []string) *tablewriter.Table {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(header)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	return table
+}
+
+func tunneledStatus(tunneled *api.TunneledPortInfo) (status string, color int) {
+	if tunneled.Visibility == api.TunnelVisiblity(api.TunnelVisiblity_value["network"]) {
+		return "open on all interfaces", tablewriter.FgHiGreenColor
+	}
+	if tunneled.Visibility == api.TunnelVisiblity(api.TunnelVisiblity_value["host"]) {
+		return "open on localhost", tablewriter.FgHiGreenColor
+	}
+	return "", tablewriter.FgHiBlackColor
+}
+
+func portNameAndDescription(port *api.PortsStatus) string {
+	nameAndDescription := port.Name
+	if len(port.Description) > 0 {
+		if len(nameAndDescription) > 0 {
+			nameAndDescription = fmt.Sprint(nameAndDescription, ": ", port.Description)
+		} else {
+			nameAndDescription = port.Description
+		}
+	}
+	return nameAndDescription
+}
+
+// maxCmdlineWidth is the number of characters of a process command line shown in the ports list
+const maxCmdlineWidth = 40
+
+// portProcess describes the process serving a port, and the task or terminal it was started in.
+func portProcess(port *api.PortsStatus) string {
+	p := port.Process
+	if p == nil {
+		return ""
+	}
+	cmdline := p.Cmdline
+	if len(cmdline) > maxCmdlineWidth {
+		cmdline = cmdline[:maxCmdlineWidth-3] + "..."
+	}
+	res := fmt.Sprintf("%s (PID %d", cmdline, p.Pid)
+	if p.TaskId != "" {
+		res += ", task " + p.TaskId
+	} else if p.TerminalAlias != "" {
+		res += ", terminal " + p.TerminalAlias
+	}
+	return res + ")"
 }
 
 func init() {
@@ -0,0 +1,75 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/utils"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var portsShareCmdOpts struct {
+	TTL time.Duration
+}
+
+// portsShareCmd shares a port with people who are not signed in to Gitpod
+var portsShareCmd = &cobra.Command{
+	Use:   "share <port>",
+	Short: "Share a private port by means of a signed URL which expires",
+	Long: `Share a private port by means of a signed URL which expires.
+
+The port becomes protected: it remains private, but everyone holding the URL,
+or sending the token as bearer token in the Authorization header, can access it
+until the token expires.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		port, err := strconv.ParseUint(args[0], 10, 16)
+		if err != nil {
+			return GpError{Err: xerrors.Errorf("port should be integer"), OutCome: utils.Outcome_UserErr, ErrorCode: utils.UserErrorCode_InvalidArguments}
+		}
+		if portsShareCmdOpts.TTL < time.Second {
+			return GpError{Err: xerrors.Errorf("ttl should be at least one second"), OutCome: utils.Outcome_UserErr, ErrorCode: utils.UserErrorCode_InvalidArguments}
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		defer cancel()
+
+		client, err := supervisor.New(ctx)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		resp, err := client.Port.SharePort(ctx, &api.SharePortRequest{
+			Port:       uint32(port),
+			TtlSeconds: uint32(portsShareCmdOpts.TTL.Seconds()),
+		})
+		if status.Code(err) == codes.FailedPrecondition {
+			return GpError{Err: xerrors.Errorf("cannot share port %d: %s", port, status.Convert(err).Message()), OutCome: utils.Outcome_UserErr}
+		}
+		if err != nil {
+			return xerrors.Errorf("cannot share port %d: %w", port, err)
+		}
+
+		fmt.Printf("URL:     %s\n", resp.Url)
+		fmt.Printf("Token:   %s\n", resp.Token)
+		fmt.Printf("Expires: %s\n", time.Unix(resp.ExpiresAt, 0).Format(time.RFC3339))
+		return nil
+	},
+}
+
+func init() {
+	portsCmd.AddCommand(portsShareCmd)
+	portsShareCmd.Flags().DurationVar(&portsShareCmdOpts.TTL, "ttl", time.Hour, "how long the URL remains valid")
+}
@@ -21,6 +21,7 @@ import (
 var attachTaskCmdOpts struct {
 	Interactive bool
 	ForceResize bool
+	Offset      int64
 }
 
 // attachTaskCmd represents the attach task command
@@ -109,10 +110,15 @@ var attachTaskCmd = &cobra.Command{
 		interactive, _ := cmd.Flags().GetBool("interactive")
 		forceResize, _ := cmd.Flags().GetBool("force-resize")
 
-		exitCode, err := client.AttachToTerminal(cmd.Context(), terminalAlias, supervisor.AttachToTerminalOpts{
+		opts := supervisor.AttachToTerminalOpts{
 			ForceResize: forceResize,
 			Interactive: interactive,
-		})
+		}
+		if offset, _ := cmd.Flags().GetInt64("offset"); offset >= 0 {
+			opts.Offset = &offset
+		}
+
+		exitCode, err := client.AttachToTerminal(cmd.Context(), terminalAlias, opts)
 		if err != nil {
 			return err
 		}
@@ -125,4 +131,5 @@ func init() {
 
 	attachTaskCmd.Flags().BoolVarP(&attachTaskCmdOpts.Interactive, "interactive", "i", true, "assume control over the terminal")
 	attachTaskCmd.Flags().BoolVarP(&attachTaskCmdOpts.ForceResize, "force-resize", "r", true, "force this terminal's size irregardless of other clients")
+	attachTaskCmd.Flags().Int64Var(&attachTaskCmdOpts.Offset, "offset", -1, "replay the task's output from this byte offset instead of the most recent output")
 }
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
@@ -44,7 +45,7 @@ var listTasksCmd = &cobra.Command{
 		}
 
 		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Terminal ID", "Name", "State"})
+		table.SetHeader([]string{"Terminal ID", "Name", "State", "Restarts", "Last Exit Code"})
 		table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
 		table.SetCenterSeparator("|")
 
@@ -52,6 +53,7 @@ var listTasksCmd = &cobra.Command{
 			0: tablewriter.FgHiGreenColor,
 			1: tablewriter.FgHiGreenColor,
 			2: tablewriter.FgHiBlackColor,
+			3: tablewriter.FgHiYellowColor,
 		}
 
 		mapCurrentToColor := map[bool]int{
@@ -78,10 +80,15 @@ var listTasksCmd = &cobra.Command{
 			}
 
 			if !noColor && utils.ColorsEnabled() {
-				colors = []tablewriter.Colors{{mapCurrentToColor[isCurrent]}, {}, {mapStatusToColor[task.State]}}
+				colors = []tablewriter.Colors{{mapCurrentToColor[isCurrent]}, {}, {mapStatusToColor[task.State]}, {}, {}}
 			}
 
-			table.Rich([]string{task.Terminal, task.Presentation.Name, task.State.String()}, colors)
+			lastExitCode := ""
+			if task.LastExitCode != nil {
+				lastExitCode = strconv.Itoa(int(*task.LastExitCode))
+			}
+
+			table.Rich([]string{task.Terminal, task.Presentation.Name, task.State.String(), strconv.FormatUint(uint64(task.RestartCount), 10), lastExitCode}, colors)
 		}
 
 		table.Render()
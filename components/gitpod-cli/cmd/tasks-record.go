@@ -0,0 +1,222 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/utils"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/manifoldco/promptui"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+// recordTerminalAnnotation toggles the recording of a terminal session in supervisor
+const recordTerminalAnnotation = "gitpod.supervisor.record"
+
+var downloadRecordingCmdOpts struct {
+	Output string
+}
+
+// recordTaskCmd represents the task record command
+var recordTaskCmd = &cobra.Command{
+	Use:   "record",
+	Short: "Record workspace task sessions in the asciicast v2 format",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			_ = cmd.Help()
+		}
+		return nil
+	},
+}
+
+var startRecordingTaskCmd = &cobra.Command{
+	Use:   "start <id>",
+	Short: "Start recording a workspace task",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setTaskRecording(cmd.Context(), args, true)
+	},
+}
+
+var stopRecordingTaskCmd = &cobra.Command{
+	Use:   "stop <id>",
+	Short: "Stop recording a workspace task",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setTaskRecording(cmd.Context(), args, false)
+	},
+}
+
+func setTaskRecording(ctx context.Context, args []string, record bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	client, err := supervisor.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var terminalAlias string
+	if len(args) > 0 {
+		terminalAlias = args[0]
+	} else {
+		tasks, err := client.GetTasksListByState(ctx, api.TaskState_running)
+		if err != nil {
+			return xerrors.Errorf("cannot get task list: %w", err)
+		}
+		if len(tasks) == 0 {
+			fmt.Println("There are no running tasks")
+			return nil
+		}
+
+		var taskIndex int
+		if len(tasks) > 1 {
+			var taskNames []string
+			for _, task := range tasks {
+				taskNames = append(taskNames, task.Presentation.Name)
+			}
+
+			prompt := promptui.Select{
+				Label:        "What task do you want to record?",
+				Items:        taskNames,
+				HideSelected: true,
+			}
+			selectedIndex, selectedValue, err := prompt.Run()
+			if selectedValue == "" {
+				return nil
+			}
+			if err != nil {
+				return xerrors.Errorf("error occurred with the input prompt: %w", err)
+			}
+			taskIndex = selectedIndex
+		}
+		terminalAlias = tasks[taskIndex].Terminal
+	}
+
+	req := &api.UpdateTerminalAnnotationsRequest{Alias: terminalAlias}
+	if record {
+		req.Changed = map[string]string{recordTerminalAnnotation: "true"}
+	} else {
+		req.Deleted = []string{recordTerminalAnnotation}
+	}
+	_, err = client.Terminal.UpdateAnnotations(ctx, req)
+	if err != nil {
+		msg := fmt.Sprintf("Cannot change the recording of task %s.\nUse 'gp tasks list' to obtain the task id.\n", terminalAlias)
+		return GpError{Err: err, Message: msg, OutCome: utils.Outcome_UserErr}
+	}
+
+	if record {
+		fmt.Printf("Recording task %s. Run 'gp tasks record stop %s' to finish the recording.\n", terminalAlias, terminalAlias)
+	} else {
+		fmt.Println("Recording finished. Run 'gp tasks record list' to see all recordings.")
+	}
+	return nil
+}
+
+var listRecordingsTaskCmd = &cobra.Command{
+	Use:   "list [<id>]",
+	Short: "List finished task recordings, optionally of a single task",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+		defer cancel()
+
+		client, err := supervisor.New(ctx)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		req := &api.ListTerminalRecordingsRequest{}
+		if len(args) > 0 {
+			req.Alias = args[0]
+		}
+		resp, err := client.Terminal.ListRecordings(ctx, req)
+		if err != nil {
+			return xerrors.Errorf("cannot list recordings: %w", err)
+		}
+		if len(resp.Recordings) == 0 {
+			fmt.Println("No recordings found")
+			return nil
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Recording ID", "Terminal ID", "Started", "Size"})
+		table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+		table.SetCenterSeparator("|")
+		for _, rec := range resp.Recordings {
+			table.Append([]string{
+				rec.Id,
+				rec.Alias,
+				time.UnixMilli(rec.StartTime).Format(time.RFC3339),
+				strconv.FormatInt(rec.Size, 10),
+			})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var downloadRecordingTaskCmd = &cobra.Command{
+	Use:   "download <recording-id>",
+	Short: "Download a finished task recording",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := supervisor.New(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		stream, err := client.Terminal.DownloadRecording(cmd.Context(), &api.DownloadTerminalRecordingRequest{Id: args[0]})
+		if err != nil {
+			return xerrors.Errorf("cannot download recording: %w", err)
+		}
+
+		var out io.Writer = os.Stdout
+		if downloadRecordingCmdOpts.Output != "" {
+			f, err := os.Create(downloadRecordingCmdOpts.Output)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return xerrors.Errorf("cannot download recording: %w", err)
+			}
+			_, err = out.Write(resp.Data)
+			if err != nil {
+				return err
+			}
+		}
+	},
+}
+
+func init() {
+	tasksCmd.AddCommand(recordTaskCmd)
+	recordTaskCmd.AddCommand(startRecordingTaskCmd)
+	recordTaskCmd.AddCommand(stopRecordingTaskCmd)
+	recordTaskCmd.AddCommand(listRecordingsTaskCmd)
+	recordTaskCmd.AddCommand(downloadRecordingTaskCmd)
+
+	downloadRecordingTaskCmd.Flags().StringVarP(&downloadRecordingCmdOpts.Output, "output", "o", "", "write the recording to a file instead of stdout")
+}
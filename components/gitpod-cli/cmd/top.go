@@ -9,6 +9,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
@@ -23,7 +25,11 @@ import (
 )
 
 var topCmdOpts struct {
-	Json bool
+	Json      bool
+	Watch     bool
+	Processes bool
+	Events    bool
+	Interval  time.Duration
 }
 
 type topData struct {
@@ -34,6 +40,13 @@ type topData struct {
 var topCmd = &cobra.Command{
 	Use:   "top",
 	Short: "Display usage of workspace resources (CPU and memory)",
+	Long: `Display usage of workspace resources (CPU and memory).
+
+With --watch the usage is refreshed continuously and shown together with the
+history of the last hour. With --processes the workspace processes are listed
+with their CPU and memory usage. With --events the processes killed because the
+workspace ran out of memory and the times the workspace was CPU throttled during
+the last hour are listed.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
 		defer cancel()
@@ -44,28 +57,14 @@ var topCmd = &cobra.Command{
 		}
 		defer client.Close()
 
-		data := &topData{}
+		if topCmdOpts.Watch {
+			return watchTop(cmd.Context(), client)
+		}
 
-		g, ctx := errgroup.WithContext(ctx)
-		g.Go(func() error {
-			workspaceResources, err := client.Status.ResourcesStatus(ctx, &api.ResourcesStatuRequest{})
-			if err != nil {
-				return err
-			}
-			data.Resources = workspaceResources
-			return nil
+		data, err := fetchTopData(ctx, client, &api.ResourcesStatuRequest{
+			IncludeProcesses: topCmdOpts.Processes,
+			IncludeEvents:    topCmdOpts.Events,
 		})
-
-		g.Go(func() error {
-			wsInfo, err := client.Info.WorkspaceInfo(ctx, &api.WorkspaceInfoRequest{})
-			if err != nil {
-				return err
-			}
-			data.WorkspaceClass = wsInfo.WorkspaceClass
-			return nil
-		})
-
-		err = g.Wait()
 		if err != nil {
 			return err
 		}
@@ -76,10 +75,92 @@ var topCmd = &cobra.Command{
 			return nil
 		}
 		outputTable(data.Resources, data.WorkspaceClass)
+		if topCmdOpts.Processes {
+			fmt.Println()
+			outputProcesses(data.Resources.Processes)
+		}
+		if topCmdOpts.Events {
+			fmt.Println()
+			outputEvents(data.Resources.Events)
+		}
 		return nil
 	},
 }
 
+func fetchTopData(ctx context.Context, client *supervisor.SupervisorClient, req *api.ResourcesStatuRequest) (*topData, error) {
+	data := &topData{}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		workspaceResources, err := client.Status.ResourcesStatus(ctx, req)
+		if err != nil {
+			return err
+		}
+		data.Resources = workspaceResources
+		return nil
+	})
+
+	g.Go(func() error {
+		wsInfo, err := client.Info.WorkspaceInfo(ctx, &api.WorkspaceInfoRequest{})
+		if err != nil {
+			return err
+		}
+		data.WorkspaceClass = wsInfo.WorkspaceClass
+		return nil
+	})
+
+	err := g.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// watchTop refreshes the resource usage until ctx is canceled.
+func watchTop(ctx context.Context, client *supervisor.SupervisorClient) error {
+	req := &api.ResourcesStatuRequest{
+		IncludeHistory:   true,
+		IncludeProcesses: topCmdOpts.Processes,
+		IncludeEvents:    topCmdOpts.Events,
+	}
+	for {
+		fetchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		data, err := fetchTopData(fetchCtx, client, req)
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if topCmdOpts.Json {
+			content, _ := json.Marshal(data)
+			fmt.Println(string(content))
+		} else {
+			// clear the screen and move the cursor to the top left corner
+			fmt.Print("\033[H\033[2J")
+			outputTable(data.Resources, data.WorkspaceClass)
+			fmt.Println()
+			outputHistory(data.Resources)
+			if topCmdOpts.Processes {
+				fmt.Println()
+				outputProcesses(data.Resources.Processes)
+			}
+			if topCmdOpts.Events {
+				fmt.Println()
+				outputEvents(data.Resources.Events)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(topCmdOpts.Interval):
+		}
+	}
+}
+
 func formatWorkspaceClass(workspaceClass *api.WorkspaceInfoResponse_WorkspaceClass) string {
 	if workspaceClass == nil || workspaceClass.DisplayName == "" {
 		return ""
@@ -108,9 +189,174 @@ func outputTable(workspaceResources *api.ResourcesStatusResponse, workspaceClass
 	table.Rich([]string{"CPU (millicores)", cpu}, cpuColors)
 	table.Rich([]string{"Memory (bytes)", memory}, memoryColors)
 
+	if disk := workspaceResources.Disk; disk != nil && disk.Limit > 0 {
+		diskFraction := int64((float64(disk.Used) / float64(disk.Limit)) * 100)
+		var diskColors []tablewriter.Colors
+		if !noColor && utils.ColorsEnabled() {
+			diskColors = []tablewriter.Colors{nil, {getColor(disk.Severity)}}
+		}
+		table.Rich([]string{"Disk /workspace (bytes)", fmt.Sprintf("%dMi/%dMi (%d%%)", disk.Used/(1024*1024), disk.Limit/(1024*1024), diskFraction)}, diskColors)
+	}
+	if io := workspaceResources.Io; io != nil {
+		table.Append([]string{"IO (read/write)", fmt.Sprintf("%s/s / %s/s", formatBytes(io.ReadBytesPerSecond), formatBytes(io.WriteBytesPerSecond))})
+	}
+	if p := workspaceResources.Pressure; p != nil {
+		table.Append([]string{"Pressure (some/full)", fmt.Sprintf("CPU %.1f%%, memory %.1f%%/%.1f%%, IO %.1f%%/%.1f%%", p.CpuSome, p.MemorySome, p.MemoryFull, p.IoSome, p.IoFull)})
+	}
+
 	table.Render()
 }
 
+// historyWidth is the number of characters used to render the resource history
+const historyWidth = 60
+
+func outputHistory(workspaceResources *api.ResourcesStatusResponse) {
+	history := workspaceResources.History
+	if len(history) == 0 {
+		return
+	}
+	cpu := make([]int64, 0, len(history))
+	memory := make([]int64, 0, len(history))
+	for _, sample := range history {
+		cpu = append(cpu, sample.CpuUsed)
+		memory = append(memory, sample.MemoryUsed)
+	}
+	since := time.Since(time.Unix(history[0].Timestamp, 0)).Round(time.Minute)
+
+	fmt.Printf("History of the last %s (peak per column):\n", since)
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator(":")
+	table.Append([]string{"CPU", sparkline(cpu, workspaceResources.Cpu.Limit, historyWidth)})
+	table.Append([]string{"Memory", sparkline(memory, workspaceResources.Memory.Limit, historyWidth)})
+	table.Render()
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values relative to max in at most width characters.
+// If there are more values than characters, each character shows the peak of the values it covers.
+func sparkline(values []int64, max int64, width int) string {
+	if len(values) == 0 || max <= 0 {
+		return ""
+	}
+	buckets := len(values)
+	if buckets > width {
+		buckets = width
+	}
+	res := make([]rune, buckets)
+	for b := range res {
+		var peak int64
+		for _, v := range values[b*len(values)/buckets : (b+1)*len(values)/buckets] {
+			if v > peak {
+				peak = v
+			}
+		}
+		idx := int(float64(peak) / float64(max) * float64(len(sparks)-1))
+		if idx >= len(sparks) {
+			idx = len(sparks) - 1
+		}
+		if idx < 0 {
+			idx = 0
+		}
+		res[b] = sparks[idx]
+	}
+	return string(res)
+}
+
+// maxProcessCmdlineWidth is the number of characters of a command line shown in the process list
+const maxProcessCmdlineWidth = 80
+
+func outputProcesses(processes []*api.ProcessStatus) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"PID", "CPU %", "Memory", "Command"})
+	table.SetBorder(false)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	// processes are ordered such that parents precede their children
+	depth := make(map[int64]int, len(processes))
+	for _, p := range processes {
+		d, ok := depth[p.Ppid]
+		if ok {
+			d++
+		}
+		depth[p.Pid] = d
+
+		command := p.Cmdline
+		if command == "" {
+			command = "[" + p.Name + "]"
+		}
+		command = strings.Repeat("  ", d) + command
+		if len(command) > maxProcessCmdlineWidth {
+			command = command[:maxProcessCmdlineWidth-3] + "..."
+		}
+		table.Append([]string{
+			strconv.FormatInt(p.Pid, 10),
+			fmt.Sprintf("%.1f", p.CpuPercentage),
+			formatBytes(p.Rss),
+			command,
+		})
+	}
+	table.Render()
+}
+
+func outputEvents(events []*api.ResourceEvent) {
+	if len(events) == 0 {
+		fmt.Println("No OOM kills or CPU throttling in the last hour.")
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Time", "Event", "Details"})
+	table.SetBorder(false)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	for _, event := range events {
+		var (
+			kind    string
+			details string
+			color   int
+		)
+		switch event.Type {
+		case api.ResourceEventType_oom_kill:
+			kind = "OOM kill"
+			color = tablewriter.FgRedColor
+			details = "unknown process"
+			if event.Process != "" {
+				details = fmt.Sprintf("%s (PID %d)", event.Process, event.Pid)
+			}
+			details += fmt.Sprintf(", memory limit %dMi", event.MemoryLimit/(1024*1024))
+		case api.ResourceEventType_cpu_throttling:
+			kind = "CPU throttling"
+			color = tablewriter.FgYellowColor
+			details = fmt.Sprintf("throttled %.0f%% of the time, CPU limit %dm", event.ThrottledPercentage, event.CpuLimit)
+		default:
+			continue
+		}
+
+		var colors []tablewriter.Colors
+		if !noColor && utils.ColorsEnabled() {
+			colors = []tablewriter.Colors{nil, {color}, nil}
+		}
+		table.Rich([]string{time.Unix(event.Timestamp, 0).Format("15:04:05"), kind, details}, colors)
+	}
+	table.Render()
+}
+
+func formatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
 func getColor(severity api.ResourceStatusSeverity) int {
 	switch severity {
 	case api.ResourceStatusSeverity_danger:
@@ -125,5 +371,9 @@ func getColor(severity api.ResourceStatusSeverity) int {
 func init() {
 	topCmd.Flags().BoolVarP(&noColor, "no-color", "", false, "Disable output colorization")
 	topCmd.Flags().BoolVarP(&topCmdOpts.Json, "json", "j", false, "Output in JSON format")
+	topCmd.Flags().BoolVarP(&topCmdOpts.Watch, "watch", "w", false, "Refresh continuously and show the history of the last hour")
+	topCmd.Flags().BoolVarP(&topCmdOpts.Processes, "processes", "p", false, "List the workspace processes")
+	topCmd.Flags().BoolVarP(&topCmdOpts.Events, "events", "e", false, "List the OOM kills and CPU throttling of the last hour")
+	topCmd.Flags().DurationVar(&topCmdOpts.Interval, "interval", 2*time.Second, "Refresh interval in watch mode")
 	rootCmd.AddCommand(topCmd)
 }
@@ -0,0 +1,34 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Values      []int64
+		Max         int64
+		Width       int
+		Expectation string
+	}{
+		{"no values", nil, 10, 5, ""},
+		{"no limit", []int64{1, 2}, 0, 5, ""},
+		{"fewer values than width", []int64{0, 7, 14}, 14, 5, "▁▄█"},
+		{"peak per column", []int64{0, 14, 0, 0, 7, 0}, 14, 3, "█▁▄"},
+		{"values exceeding max", []int64{20}, 10, 5, "█"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act := sparkline(test.Values, test.Max, test.Width)
+			if act != test.Expectation {
+				t.Errorf("unexpected sparkline: want %q, got %q", test.Expectation, act)
+			}
+		})
+	}
+}
@@ -11,7 +11,7 @@ import (
 )
 
 func (client *SupervisorClient) GetPortsList(ctx context.Context) ([]*api.PortsStatus, error) {
-	portsStatusClient, portsStatusClientError := client.Status.PortsStatus(ctx, &api.PortsStatusRequest{Observe: false})
+	portsStatusClient, portsStatusClientError := client.Status.PortsStatus(ctx, &api.PortsStatusRequest{Observe: false, IncludeUdp: true})
 
 	if portsStatusClientError != nil {
 		return nil, portsStatusClientError
@@ -6,6 +6,8 @@ package supervisor
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"io"
 	"os"
 	"os/signal"
@@ -22,16 +24,38 @@ type AttachToTerminalOpts struct {
 	Interactive bool
 	ForceResize bool
 	Token       string
+	// Offset is the absolute output offset to replay from. If nil, the most recent output is replayed.
+	Offset *int64
 }
 
 func (client *SupervisorClient) AttachToTerminal(ctx context.Context, alias string, opts AttachToTerminalOpts) (int, error) {
+	// supervisor only accepts writes from clients which listen to the terminal with an ID and present their write token
+	clientID, err := newTerminalClientID()
+	if err != nil {
+		return 0, xerrors.Errorf("cannot attach to terminal: %w", err)
+	}
+	role := api.TerminalClientRole_read_only
+	if opts.Interactive {
+		role = api.TerminalClientRole_writer
+	}
+
 	// Copy to stdout/stderr
 	listen, err := client.Terminal.Listen(ctx, &api.ListenTerminalRequest{
-		Alias: alias,
+		Alias:      alias,
+		Offset:     opts.Offset,
+		ClientId:   clientID,
+		ClientName: "gp",
+		Role:       role,
 	})
 	if err != nil {
 		return 0, xerrors.Errorf("cannot attach to terminal: %w", err)
 	}
+	// clients with ID receive their write token first
+	first, err := listen.Recv()
+	if err != nil {
+		return 0, xerrors.Errorf("cannot attach to terminal: %w", err)
+	}
+	writeToken := first.GetWriteToken()
 	var exitCode int
 	errchan := make(chan error, 5)
 	go func() {
@@ -68,7 +92,9 @@ func (client *SupervisorClient) AttachToTerminal(ctx context.Context, alias stri
 				}
 
 				req := &api.SetTerminalSizeRequest{
-					Alias: alias,
+					Alias:      alias,
+					ClientId:   clientID,
+					WriteToken: writeToken,
 					Size: &api.TerminalSize{
 						Cols:     uint32(size.Cols),
 						Rows:     uint32(size.Rows),
@@ -101,7 +127,7 @@ func (client *SupervisorClient) AttachToTerminal(ctx context.Context, alias stri
 			for {
 				n, err := os.Stdin.Read(buf)
 				if n > 0 {
-					_, serr := client.Terminal.Write(ctx, &api.WriteTerminalRequest{Alias: alias, Stdin: buf[:n]})
+					_, serr := client.Terminal.Write(ctx, &api.WriteTerminalRequest{Alias: alias, Stdin: buf[:n], ClientId: clientID, WriteToken: writeToken})
 					if serr != nil {
 						errchan <- err
 						return
@@ -127,3 +153,12 @@ func (client *SupervisorClient) AttachToTerminal(ctx context.Context, alias stri
 		return 0, nil
 	}
 }
+
+func newTerminalClientID() (string, error) {
+	b := make([]byte, 8)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return "gp-" + hex.EncodeToString(b), nil
+}
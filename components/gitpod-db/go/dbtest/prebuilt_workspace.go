@@ -0,0 +1,58 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package dbtest
+
+import (
+	"testing"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// NewPrebuiltWorkspace creates a new stub prebuild with default values, unless these are set on the prebuild argument
+func NewPrebuiltWorkspace(t *testing.T, prebuild db.PrebuiltWorkspace) db.PrebuiltWorkspace {
+	t.Helper()
+
+	result := db.PrebuiltWorkspace{
+		ID:               uuid.New(),
+		CloneURL:         "https://github.com/gitpod-io/gitpod.git",
+		Commit:           "586f22ecaeeb3b4796fd92f9ae1ca3512ca1e330",
+		State:            db.PrebuiltWorkspaceState_Available,
+		BuildWorkspaceID: GenerateWorkspaceID(),
+	}
+	if prebuild.ID != uuid.Nil {
+		result.ID = prebuild.ID
+	}
+	if prebuild.State != "" {
+		result.State = prebuild.State
+	}
+	if prebuild.BuildWorkspaceID != "" {
+		result.BuildWorkspaceID = prebuild.BuildWorkspaceID
+	}
+	result.Snapshot = prebuild.Snapshot
+	return result
+}
+
+func CreatePrebuiltWorkspaces(t *testing.T, conn *gorm.DB, prebuilds ...db.PrebuiltWorkspace) []db.PrebuiltWorkspace {
+	t.Helper()
+
+	var records []db.PrebuiltWorkspace
+	var ids []string
+	for _, prebuild := range prebuilds {
+		record := NewPrebuiltWorkspace(t, prebuild)
+		records = append(records, record)
+		ids = append(ids, record.ID.String())
+	}
+
+	require.NoError(t, conn.CreateInBatches(&records, 1000).Error)
+
+	t.Cleanup(func() {
+		require.NoError(t, conn.Where(ids).Delete(&db.PrebuiltWorkspace{}).Error)
+	})
+
+	return records
+}
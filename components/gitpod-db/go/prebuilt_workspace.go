@@ -0,0 +1,66 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PrebuiltWorkspace represents the underlying DB object
+type PrebuiltWorkspace struct {
+	ID               uuid.UUID              `gorm:"primary_key;column:id;type:char;size:36;" json:"id"`
+	CloneURL         string                 `gorm:"column:cloneURL;type:varchar;size:255;" json:"cloneURL"`
+	Commit           string                 `gorm:"column:commit;type:varchar;size:255;" json:"commit"`
+	ProjectID        string                 `gorm:"column:projectId;type:char;size:36;" json:"projectId"`
+	Branch           string                 `gorm:"column:branch;type:varchar;size:255;" json:"branch"`
+	State            PrebuiltWorkspaceState `gorm:"column:state;type:varchar;size:255;" json:"state"`
+	CreationTime     time.Time              `gorm:"column:creationTime;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"creationTime"`
+	BuildWorkspaceID string                 `gorm:"column:buildWorkspaceId;type:char;size:36;" json:"buildWorkspaceId"`
+	Snapshot         string                 `gorm:"column:snapshot;type:varchar;size:255;" json:"snapshot"`
+	Error            string                 `gorm:"column:error;type:varchar;size:255;" json:"error"`
+	StatusVersion    int64                  `gorm:"column:statusVersion;type:bigint;default:0;" json:"statusVersion"`
+
+	LastModified time.Time `gorm:"column:_lastModified;type:timestamp;default:CURRENT_TIMESTAMP(6);" json:"_lastModified"`
+}
+
+// TableName sets the insert table name for this struct type
+func (p *PrebuiltWorkspace) TableName() string {
+	return "d_b_prebuilt_workspace"
+}
+
+type PrebuiltWorkspaceState string
+
+const (
+	PrebuiltWorkspaceState_Queued    PrebuiltWorkspaceState = "queued"
+	PrebuiltWorkspaceState_Building  PrebuiltWorkspaceState = "building"
+	PrebuiltWorkspaceState_Aborted   PrebuiltWorkspaceState = "aborted"
+	PrebuiltWorkspaceState_Timeout   PrebuiltWorkspaceState = "timeout"
+	PrebuiltWorkspaceState_Available PrebuiltWorkspaceState = "available"
+	PrebuiltWorkspaceState_Failed    PrebuiltWorkspaceState = "failed"
+)
+
+// FindAvailablePrebuiltWorkspaces finds all prebuilds which have a snapshot new workspaces can start from.
+func FindAvailablePrebuiltWorkspaces(ctx context.Context, conn *gorm.DB) ([]PrebuiltWorkspace, error) {
+	var prebuilds []PrebuiltWorkspace
+	var prebuildsInBatch []PrebuiltWorkspace
+
+	tx := conn.WithContext(ctx).
+		Where("state = ?", PrebuiltWorkspaceState_Available).
+		Where("snapshot != ?", "").
+		FindInBatches(&prebuildsInBatch, 1000, func(_ *gorm.DB, _ int) error {
+			prebuilds = append(prebuilds, prebuildsInBatch...)
+			return nil
+		})
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to find available prebuilt workspaces: %w", tx.Error)
+	}
+
+	return prebuilds, nil
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	"github.com/gitpod-io/gitpod/components/gitpod-db/go/dbtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindAvailablePrebuiltWorkspaces(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+
+	prebuilds := dbtest.CreatePrebuiltWorkspaces(t, conn,
+		db.PrebuiltWorkspace{State: db.PrebuiltWorkspaceState_Available, Snapshot: "workspaces/ws1/snapshot-1.tar@gitpod-user-foo"},
+		// available prebuilds without a snapshot are not usable
+		db.PrebuiltWorkspace{State: db.PrebuiltWorkspaceState_Available},
+		db.PrebuiltWorkspace{State: db.PrebuiltWorkspaceState_Building},
+		db.PrebuiltWorkspace{State: db.PrebuiltWorkspaceState_Failed, Snapshot: "workspaces/ws2/snapshot-1.tar@gitpod-user-foo"},
+	)
+
+	results, err := db.FindAvailablePrebuiltWorkspaces(context.Background(), conn)
+	require.NoError(t, err)
+
+	found := make(map[string]bool)
+	for _, r := range results {
+		found[r.ID.String()] = true
+	}
+	require.True(t, found[prebuilds[0].ID.String()])
+	require.False(t, found[prebuilds[1].ID.String()])
+	require.False(t, found[prebuilds[2].ID.String()])
+	require.False(t, found[prebuilds[3].ID.String()])
+}
@@ -271,3 +271,18 @@ func ListWorkspaceInstanceIDsWithPhaseStoppedButNoStoppingTime(ctx context.Conte
 	}
 	return ids, nil
 }
+
+// ListNotStoppedWorkspaceIDs lists the IDs of all workspaces which have an instance that has not stopped yet,
+// i.e. instances which are still starting, running or uploading their content.
+func ListNotStoppedWorkspaceIDs(ctx context.Context, conn *gorm.DB) ([]string, error) {
+	var ids []string
+	tx := conn.WithContext(ctx).
+		Model(&WorkspaceInstance{}).
+		Distinct("workspaceId").
+		Where("stoppedTime = ?", "").
+		Pluck("workspaceId", &ids)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to list workspaces with instances that have not stopped: %w", tx.Error)
+	}
+	return ids, nil
+}
@@ -226,3 +226,35 @@ func TestListWorkspaceInstanceIDsWithPhaseStoppedButNoStoppingTime(t *testing.T)
 	require.NoError(t, err)
 	require.Equal(t, []uuid.UUID{instances[1].ID}, detectedIDs)
 }
+
+func TestListNotStoppedWorkspaceIDs(t *testing.T) {
+	conn := dbtest.ConnectForTests(t)
+
+	workspaces := dbtest.CreateWorkspaces(t, conn,
+		dbtest.NewWorkspace(t, db.Workspace{}),
+		dbtest.NewWorkspace(t, db.Workspace{}),
+	)
+	dbtest.CreateWorkspaceInstances(t, conn,
+		// stopped
+		dbtest.NewWorkspaceInstance(t, db.WorkspaceInstance{
+			WorkspaceID: workspaces[0].ID,
+			StartedTime: db.NewVarCharTime(time.Now().Add(-time.Hour)),
+			StoppedTime: db.NewVarCharTime(time.Now()),
+		}),
+		// stopping, hence still uploading its content
+		dbtest.NewWorkspaceInstance(t, db.WorkspaceInstance{
+			WorkspaceID:  workspaces[1].ID,
+			StartedTime:  db.NewVarCharTime(time.Now().Add(-time.Hour)),
+			StoppingTime: db.NewVarCharTime(time.Now()),
+		}),
+		dbtest.NewWorkspaceInstance(t, db.WorkspaceInstance{
+			WorkspaceID: workspaces[1].ID,
+			StartedTime: db.NewVarCharTime(time.Now()),
+		}),
+	)
+
+	ids, err := db.ListNotStoppedWorkspaceIDs(context.Background(), conn)
+	require.NoError(t, err)
+	require.NotContains(t, ids, workspaces[0].ID)
+	require.Contains(t, ids, workspaces[1].ID)
+}
@@ -31,6 +31,29 @@ type CoreDump struct {
 type Env struct {
 }
 
+// GitCheckout Controls which parts of the repository are fetched and checked out. Useful for large repositories and monorepos.
+type GitCheckout struct {
+
+	// Selects the Git LFS objects which are fetched.
+	Lfs *GitCheckoutLfs `yaml:"lfs,omitempty" json:"lfs,omitempty"`
+
+	// Partial clone filter which defers fetching objects until they are needed, e.g. `blob:none`. See https://git-scm.com/docs/partial-clone.
+	PartialCloneFilter string `yaml:"partialCloneFilter,omitempty" json:"partialCloneFilter,omitempty"`
+
+	// Directories which are checked out in cone mode. Files at the root of the repository are always checked out. Everything is checked out if empty.
+	SparseCheckout []string `yaml:"sparseCheckout,omitempty" json:"sparseCheckout,omitempty"`
+}
+
+// GitCheckoutLfs Selects the Git LFS objects which are fetched.
+type GitCheckoutLfs struct {
+
+	// Patterns of files whose LFS objects are not fetched.
+	Exclude []string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
+
+	// Patterns of files whose LFS objects are fetched. All objects are fetched if empty.
+	Include []string `yaml:"include,omitempty" json:"include,omitempty"`
+}
+
 // Github Configures Gitpod's GitHub app
 type Github struct {
 
@@ -53,6 +76,9 @@ type GitpodConfig struct {
 	// Experimental network configuration in workspaces (deprecated). Enabled by default
 	ExperimentalNetwork bool `yaml:"experimentalNetwork,omitempty" json:"experimentalNetwork,omitempty"`
 
+	// Controls which parts of the repository are fetched and checked out. Useful for large repositories and monorepos.
+	GitCheckout *GitCheckout `yaml:"gitCheckout,omitempty" json:"gitCheckout,omitempty"`
+
 	// Git config values should be provided in pairs. E.g. `core.autocrlf: input`. See https://git-scm.com/docs/git-config#_values.
 	GitConfig map[string]string `yaml:"gitConfig,omitempty" json:"gitConfig,omitempty"`
 
@@ -81,6 +107,25 @@ type GitpodConfig struct {
 	WorkspaceLocation string `yaml:"workspaceLocation,omitempty" json:"workspaceLocation,omitempty"`
 }
 
+// HealthCheck A probe checking that the main `command` is healthy. If the probe fails `failureThreshold` times in a row, the task terminal is closed and the `restart` policy applies.
+type HealthCheck struct {
+
+	// Number of consecutive failed probes after which the task is considered unhealthy. Default is 3.
+	FailureThreshold float64 `yaml:"failureThreshold,omitempty" json:"failureThreshold,omitempty"`
+
+	// Seconds to wait after the task started before probing. Default is 10.
+	InitialDelay float64 `yaml:"initialDelay,omitempty" json:"initialDelay,omitempty"`
+
+	// Seconds between two probes. Default is 10.
+	Interval float64 `yaml:"interval,omitempty" json:"interval,omitempty"`
+
+	// If set, the probe sends an HTTP GET request to this path and expects a status code below 400. Otherwise the probe only opens a TCP connection.
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+
+	// The port to probe.
+	Port float64 `yaml:"port" json:"port"`
+}
+
 // Image_object The Docker image to run your workspace in.
 type Image_object struct {
 
@@ -153,7 +198,7 @@ type PortsItems struct {
 	// The protocol of workspace port.
 	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
 
-	// Whether the port visibility should be private or public. 'private' (default) will only allow users with workspace access to access the port. 'public' will allow everyone with the port URL to access the port.
+	// Whether the port visibility should be private, protected or public. 'private' (default) will only allow users with workspace access to access the port. 'protected' additionally allows access with a share token minted by `gp ports share`. 'public' will allow everyone with the port URL to access the port.
 	Visibility string `yaml:"visibility,omitempty" json:"visibility,omitempty"`
 }
 
@@ -189,6 +234,19 @@ type Prebuilds_object struct {
 	PullRequestsFromForks bool `yaml:"pullRequestsFromForks,omitempty" json:"pullRequestsFromForks,omitempty"`
 }
 
+// ReadyWhen Condition which marks this task as ready for its dependents. If multiple conditions are given, all of them have to be met. If unset, the task is ready as soon as it is started. In prebuilds, a task is always ready once it terminated successfully.
+type ReadyWhen struct {
+
+	// The task is ready once its terminal exited successfully.
+	ExitSuccess bool `yaml:"exitSuccess,omitempty" json:"exitSuccess,omitempty"`
+
+	// The task is ready once this file exists. Relative paths are resolved against the repository root.
+	File string `yaml:"file,omitempty" json:"file,omitempty"`
+
+	// The task is ready once this port is served.
+	Port float64 `yaml:"port,omitempty" json:"port,omitempty"`
+}
+
 // TasksItems
 type TasksItems struct {
 
@@ -198,12 +256,21 @@ type TasksItems struct {
 	// The main shell command to run after `before` and `init`. This command is executed last on every start and doesn't have to terminate.
 	Command string `yaml:"command,omitempty" json:"command,omitempty"`
 
+	// Names of tasks which have to be ready before this task is started. Tasks with an unknown dependency or a dependency cycle are not started.
+	DependsOn []string `yaml:"dependsOn,omitempty" json:"dependsOn,omitempty"`
+
 	// Environment variables to set.
 	Env *Env `yaml:"env,omitempty" json:"env,omitempty"`
 
+	// A probe checking that the main `command` is healthy. If the probe fails `failureThreshold` times in a row, the task terminal is closed and the `restart` policy applies.
+	HealthCheck *HealthCheck `yaml:"healthCheck,omitempty" json:"healthCheck,omitempty"`
+
 	// A shell command to run between `before` and the main `command`. This command is executed only on after initializing a workspace with a fresh clone, but not on restarts and snapshots. This command is expected to terminate. If it fails, the `command` property will not be executed.
 	Init string `yaml:"init,omitempty" json:"init,omitempty"`
 
+	// Maximum number of times the main `command` is restarted. Default is 5.
+	MaxRetries float64 `yaml:"maxRetries,omitempty" json:"maxRetries,omitempty"`
+
 	// Name of the task. Shown on the tab of the opened terminal.
 	Name string `yaml:"name,omitempty" json:"name,omitempty"`
 
@@ -215,6 +282,12 @@ type TasksItems struct {
 
 	// A shell command to run after `before`. This command is executed only on during workspace prebuilds. This command is expected to terminate. If it fails, the workspace build fails.
 	Prebuild string `yaml:"prebuild,omitempty" json:"prebuild,omitempty"`
+
+	// Condition which marks this task as ready for its dependents. If multiple conditions are given, all of them have to be met. If unset, the task is ready as soon as it is started. In prebuilds, a task is always ready once it terminated successfully.
+	ReadyWhen *ReadyWhen `yaml:"readyWhen,omitempty" json:"readyWhen,omitempty"`
+
+	// Whether the main `command` should be restarted once it terminated. 'never' (default) does not restart it, 'on-failure' restarts it if it failed or became unhealthy, 'always' restarts it whenever it terminated. Restarts run `before` and `command` in a new terminal and are delayed by an exponential backoff. Not applied in prebuilds.
+	Restart string `yaml:"restart,omitempty" json:"restart,omitempty"`
 }
 
 // Vscode Configure VS Code integration
@@ -1906,11 +1906,15 @@ type WorkspaceInstancePort struct {
 	URL        string  `json:"url,omitempty"`
 	Visibility string  `json:"visibility,omitempty"`
 	Protocol   string  `json:"protocol,omitempty"`
+	// TokenEpoch identifies the current protection of a protected port. Share tokens are only valid for this epoch.
+	TokenEpoch float64 `json:"tokenEpoch,omitempty"`
 }
 
 const (
 	PortVisibilityPublic  = "public"
 	PortVisibilityPrivate = "private"
+	// PortVisibilityProtected ports are private ports which can also be accessed with a share token
+	PortVisibilityProtected = "protected"
 )
 
 const (
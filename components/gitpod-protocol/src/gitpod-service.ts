@@ -422,6 +422,8 @@ export namespace GitpodServer {
         workspaceClass?: string;
         ideSettings?: IDESettings;
         region?: WorkspaceRegion;
+        // restores a previous backup of the workspace instead of the latest one
+        backupId?: string;
     }
     export interface TakeSnapshotOptions {
         workspaceId: string;
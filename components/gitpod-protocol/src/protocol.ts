@@ -898,6 +898,15 @@ export interface CoreDumpConfig {
     hardLimit?: number;
 }
 
+export interface GitCheckoutConfig {
+    partialCloneFilter?: string;
+    sparseCheckout?: string[];
+    lfs?: {
+        include?: string[];
+        exclude?: string[];
+    };
+}
+
 export interface WorkspaceConfig {
     mainConfiguration?: string;
     additionalRepositories?: RepositoryCloneInformation[];
@@ -907,6 +916,7 @@ export interface WorkspaceConfig {
     checkoutLocation?: string;
     workspaceLocation?: string;
     gitConfig?: { [config: string]: string };
+    gitCheckout?: GitCheckoutConfig;
     github?: GithubAppConfig;
     vscode?: VSCodeConfig;
     jetbrains?: JetBrainsConfig;
@@ -1075,6 +1085,25 @@ export interface TaskConfig {
     env?: { [env: string]: any };
     openIn?: "bottom" | "main" | "left" | "right";
     openMode?: "split-top" | "split-left" | "split-right" | "split-bottom" | "tab-before" | "tab-after";
+    dependsOn?: string[];
+    readyWhen?: TaskReadyWhen;
+    restart?: "never" | "on-failure" | "always";
+    maxRetries?: number;
+    healthCheck?: TaskHealthCheck;
+}
+
+export interface TaskReadyWhen {
+    port?: number;
+    file?: string;
+    exitSuccess?: boolean;
+}
+
+export interface TaskHealthCheck {
+    port: number;
+    path?: string;
+    initialDelay?: number;
+    interval?: number;
+    failureThreshold?: number;
 }
 
 export namespace TaskConfig {
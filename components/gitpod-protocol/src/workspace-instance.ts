@@ -183,8 +183,9 @@ export interface WorkspaceInstanceConditions {
 // AdmissionLevel describes who can access a workspace instance and its ports.
 export type AdmissionLevel = "owner_only" | "everyone";
 
-// PortVisibility describes how a port can be accessed
-export type PortVisibility = "public" | "private";
+// PortVisibility describes how a port can be accessed.
+// Protected ports are private ports which can also be accessed with a share token.
+export type PortVisibility = "public" | "private" | "protected";
 
 // PortProtocol
 export type PortProtocol = "http" | "https";
@@ -201,6 +202,9 @@ export interface WorkspaceInstancePort {
     url?: string;
 
     protocol?: PortProtocol;
+
+    // Token epoch share tokens of a protected port are bound to. Changes whenever the port becomes protected again.
+    tokenEpoch?: number;
 }
 
 // WorkspaceInstanceRepoStatus describes the status of th Git working copy of a workspace
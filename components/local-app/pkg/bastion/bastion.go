@@ -9,6 +9,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/binary"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -64,6 +65,7 @@ type TunnelListener struct {
 	RemotePort uint32
 	LocalAddr  string
 	LocalPort  uint32
+	Protocol   supervisor.TransportProtocol
 	Visibility supervisor.TunnelVisiblity
 	Ctx        context.Context
 	Cancel     func()
@@ -80,7 +82,7 @@ type Workspace struct {
 	supervisorClient   *grpc.ClientConn
 
 	tunnelMu        sync.RWMutex
-	tunnelListeners map[uint32]*TunnelListener
+	tunnelListeners map[tunnelKey]*TunnelListener
 	tunnelEnabled   bool
 	cancelTunnel    context.CancelFunc
 
@@ -95,15 +97,22 @@ type Workspace struct {
 	tunnelClientConnected bool
 }
 
+// tunnelKey identifies a tunneled workspace port.
+type tunnelKey struct {
+	port     uint32
+	protocol supervisor.TransportProtocol
+}
+
 func (ws *Workspace) Status() []*app.TunnelStatus {
 	ws.tunnelMu.RLock()
 	defer ws.tunnelMu.RUnlock()
 	res := make([]*app.TunnelStatus, 0, len(ws.tunnelListeners))
 	for _, listener := range ws.tunnelListeners {
 		res = append(res, &app.TunnelStatus{
-			RemotePort: listener.RemotePort,
-			LocalPort:  listener.LocalPort,
-			Visibility: listener.Visibility,
+			RemotePort:        listener.RemotePort,
+			LocalPort:         listener.LocalPort,
+			Visibility:        listener.Visibility,
+			TransportProtocol: listener.Protocol,
 		})
 	}
 	return res
@@ -327,7 +336,7 @@ func (b *Bastion) handleUpdate(ur *WorkspaceUpdateRequest) {
 			cancel: cancel,
 
 			tunnelClient:    make(chan chan *TunnelClient, 1),
-			tunnelListeners: make(map[uint32]*TunnelListener),
+			tunnelListeners: make(map[tunnelKey]*TunnelListener),
 			tunnelEnabled:   true,
 		}
 	}
@@ -349,7 +358,7 @@ func (b *Bastion) handleUpdate(ur *WorkspaceUpdateRequest) {
 		}
 		if ws.supervisorListener == nil && ws.tunnelClientConnected {
 			var err error
-			ws.supervisorListener, err = b.establishTunnel(ws.ctx, ws, "supervisor", 22999, 0, supervisor.TunnelVisiblity_host)
+			ws.supervisorListener, err = b.establishTunnel(ws.ctx, ws, "supervisor", 22999, 0, supervisor.TransportProtocol_tcp, supervisor.TunnelVisiblity_host)
 			if err != nil {
 				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).Error("cannot establish supervisor tunnel")
 			}
@@ -557,7 +566,7 @@ func newTunnelClient(ctx context.Context, ws *Workspace, reconnecting *gitpod.Re
 	return client, closed, err
 }
 
-func (b *Bastion) establishTunnel(ctx context.Context, ws *Workspace, logprefix string, remotePort int, targetPort int, visibility supervisor.TunnelVisiblity) (*TunnelListener, error) {
+func (b *Bastion) establishTunnel(ctx context.Context, ws *Workspace, logprefix string, remotePort int, targetPort int, protocol supervisor.TransportProtocol, visibility supervisor.TunnelVisiblity) (*TunnelListener, error) {
 	if !ws.tunnelClientConnected {
 		return nil, xerrors.Errorf("tunnel client is not connected")
 	}
@@ -569,6 +578,9 @@ func (b *Bastion) establishTunnel(ctx context.Context, ws *Workspace, logprefix
 	if visibility == supervisor.TunnelVisiblity_network {
 		targetHost = "0.0.0.0"
 	}
+	if protocol == supervisor.TransportProtocol_udp {
+		return b.establishUDPTunnel(ctx, ws, logprefix, targetHost, remotePort, targetPort, visibility)
+	}
 
 	netListener, err := net.Listen("tcp", targetHost+":"+strconv.Itoa(targetPort))
 	var localPort int
@@ -603,30 +615,14 @@ func (b *Bastion) establishTunnel(ctx context.Context, ws *Workspace, logprefix
 				defer logrus.WithField("workspace", ws.WorkspaceID).Debug(logprefix + ": connection closed")
 				defer conn.Close()
 
-				clientCh := make(chan *TunnelClient, 1)
-				select {
-				case <-listenerCtx.Done():
-					return
-				case ws.tunnelClient <- clientCh:
-				}
-				client := <-clientCh
-
-				payload, err := proto.Marshal(&supervisor.TunnelPortRequest{
-					ClientId:   client.ID,
+				sshChan, err := openTunnelChannel(listenerCtx, ws, logprefix, &supervisor.TunnelPortRequest{
 					Port:       uint32(remotePort),
 					TargetPort: uint32(localPort),
 				})
 				if err != nil {
-					logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("id", client.ID).Error(logprefix + ": failed to marshal tunnel payload")
-					return
-				}
-				sshChan, reqs, err := client.Conn.OpenChannel("tunnel", payload)
-				if err != nil {
-					logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("id", client.ID).Warn(logprefix + ": failed to establish tunnel")
 					return
 				}
 				defer sshChan.Close()
-				go ssh.DiscardRequests(reqs)
 
 				ctx, cancel := context.WithCancel(listenerCtx)
 				go func() {
@@ -645,6 +641,226 @@ func (b *Bastion) establishTunnel(ctx context.Context, ws *Workspace, logprefix
 		RemotePort: uint32(remotePort),
 		LocalAddr:  netListener.Addr().String(),
 		LocalPort:  uint32(localPort),
+		Protocol:   supervisor.TransportProtocol_tcp,
+		Visibility: visibility,
+		Ctx:        listenerCtx,
+		Cancel:     cancel,
+	}, nil
+}
+
+// openTunnelChannel opens an SSH tunnel channel to the workspace port described by req.
+// The request's client ID is filled in from the connected tunnel client.
+func openTunnelChannel(ctx context.Context, ws *Workspace, logprefix string, req *supervisor.TunnelPortRequest) (ssh.Channel, error) {
+	clientCh := make(chan *TunnelClient, 1)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case ws.tunnelClient <- clientCh:
+	}
+	client := <-clientCh
+
+	req.ClientId = client.ID
+	payload, err := proto.Marshal(req)
+	if err != nil {
+		logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("id", client.ID).Error(logprefix + ": failed to marshal tunnel payload")
+		return nil, err
+	}
+	sshChan, reqs, err := client.Conn.OpenChannel("tunnel", payload)
+	if err != nil {
+		logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("id", client.ID).Warn(logprefix + ": failed to establish tunnel")
+		return nil, err
+	}
+	go ssh.DiscardRequests(reqs)
+	return sshChan, nil
+}
+
+// udpSessionTimeout is the time after which a UDP tunnel session without traffic is closed.
+const udpSessionTimeout = 2 * time.Minute
+
+// udpSessionOpenTimeout is the time a new UDP tunnel session may take to open its SSH channel.
+const udpSessionOpenTimeout = 10 * time.Second
+
+// udpSessionQueueSize is the number of datagrams buffered per UDP tunnel session.
+// Datagrams arriving while the queue is full are dropped.
+const udpSessionQueueSize = 64
+
+// maxDatagramSize is the maximum size of a datagram tunneled over SSH.
+// Datagrams are framed with their length as a 16-bit big-endian integer.
+const maxDatagramSize = 0xFFFF
+
+// establishUDPTunnel tunnels a workspace UDP port. Each local peer gets its own SSH channel,
+// which is closed once the peer was idle for udpSessionTimeout.
+func (b *Bastion) establishUDPTunnel(ctx context.Context, ws *Workspace, logprefix string, targetHost string, remotePort int, targetPort int, visibility supervisor.TunnelVisiblity) (*TunnelListener, error) {
+	packetConn, err := net.ListenPacket("udp", targetHost+":"+strconv.Itoa(targetPort))
+	if err != nil {
+		packetConn, err = net.ListenPacket("udp", targetHost+":0")
+		if err != nil {
+			return nil, err
+		}
+	}
+	localPort := packetConn.LocalAddr().(*net.UDPAddr).Port
+	logrus.WithField("workspace", ws.WorkspaceID).Info(logprefix + ": listening on udp " + packetConn.LocalAddr().String() + "...")
+	listenerCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		<-listenerCtx.Done()
+		packetConn.Close()
+		logrus.WithField("workspace", ws.WorkspaceID).Info(logprefix + ": closed")
+	}()
+
+	type udpSession struct {
+		ctx      context.Context
+		cancel   context.CancelFunc
+		queue    chan []byte
+		lastSeen time.Time
+	}
+	var (
+		mu       sync.Mutex
+		sessions = make(map[string]*udpSession)
+	)
+	closeSession := func(addr string, sess *udpSession) {
+		mu.Lock()
+		if sessions[addr] == sess {
+			delete(sessions, addr)
+		}
+		mu.Unlock()
+		sess.cancel()
+	}
+	go func() {
+		ticker := time.NewTicker(udpSessionTimeout / 4)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-listenerCtx.Done():
+				return
+			case <-ticker.C:
+			}
+			mu.Lock()
+			for addr, sess := range sessions {
+				if time.Since(sess.lastSeen) > udpSessionTimeout {
+					logrus.WithField("workspace", ws.WorkspaceID).WithField("peer", addr).Debug(logprefix + ": session timed out")
+					delete(sessions, addr)
+					sess.cancel()
+				}
+			}
+			mu.Unlock()
+		}
+	}()
+	// serveSession opens the SSH channel of a session and forwards its datagrams in both directions.
+	// It runs independently of the read loop, so that a peer whose channel is slow to open or write to does not stall the others.
+	serveSession := func(addr net.Addr, sess *udpSession) {
+		defer closeSession(addr.String(), sess)
+
+		openCtx, cancelOpen := context.WithTimeout(sess.ctx, udpSessionOpenTimeout)
+		sshChan, err := openTunnelChannel(openCtx, ws, logprefix, &supervisor.TunnelPortRequest{
+			Port:              uint32(remotePort),
+			TargetPort:        uint32(localPort),
+			TransportProtocol: supervisor.TransportProtocol_udp,
+		})
+		cancelOpen()
+		if err != nil {
+			return
+		}
+		defer sshChan.Close()
+		logrus.WithField("workspace", ws.WorkspaceID).WithField("peer", addr.String()).Debug(logprefix + ": started new session")
+
+		go func() {
+			defer sess.cancel()
+			rbuf := make([]byte, maxDatagramSize)
+			for {
+				_, err := io.ReadFull(sshChan, rbuf[:2])
+				if err != nil {
+					return
+				}
+				size := binary.BigEndian.Uint16(rbuf[:2])
+				_, err = io.ReadFull(sshChan, rbuf[:size])
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				sess.lastSeen = time.Now()
+				mu.Unlock()
+				_, err = packetConn.WriteTo(rbuf[:size], addr)
+				if err != nil && listenerCtx.Err() != nil {
+					return
+				}
+			}
+		}()
+
+		for {
+			select {
+			case <-sess.ctx.Done():
+				return
+			case datagram := <-sess.queue:
+				_, err := sshChan.Write(datagram)
+				if err != nil {
+					logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("peer", addr.String()).Debug(logprefix + ": failed to forward datagram")
+					return
+				}
+			}
+		}
+	}
+	go func() {
+		buf := make([]byte, maxDatagramSize)
+		var backoff time.Duration
+		for {
+			n, addr, err := packetConn.ReadFrom(buf)
+			if listenerCtx.Err() != nil {
+				return
+			}
+			if errors.Is(err, net.ErrClosed) {
+				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).Warn(logprefix + ": udp listener closed")
+				cancel()
+				return
+			}
+			if err != nil {
+				// back off instead of spinning on an error which persists
+				if backoff == 0 {
+					backoff = 5 * time.Millisecond
+				} else if backoff *= 2; backoff > time.Second {
+					backoff = time.Second
+				}
+				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).Warn(logprefix + ": failed to read datagram")
+				select {
+				case <-listenerCtx.Done():
+					return
+				case <-time.After(backoff):
+				}
+				continue
+			}
+			backoff = 0
+
+			mu.Lock()
+			sess, exists := sessions[addr.String()]
+			if !exists {
+				sessCtx, sessCancel := context.WithCancel(listenerCtx)
+				sess = &udpSession{
+					ctx:    sessCtx,
+					cancel: sessCancel,
+					queue:  make(chan []byte, udpSessionQueueSize),
+				}
+				sessions[addr.String()] = sess
+			}
+			sess.lastSeen = time.Now()
+			mu.Unlock()
+			if !exists {
+				go serveSession(addr, sess)
+			}
+
+			datagram := make([]byte, 2+n)
+			binary.BigEndian.PutUint16(datagram[:2], uint16(n))
+			copy(datagram[2:], buf[:n])
+			select {
+			case sess.queue <- datagram:
+			default:
+				logrus.WithField("workspace", ws.WorkspaceID).WithField("peer", addr.String()).Debug(logprefix + ": session queue is full, dropping datagram")
+			}
+		}
+	}()
+	return &TunnelListener{
+		RemotePort: uint32(remotePort),
+		LocalAddr:  packetConn.LocalAddr().String(),
+		LocalPort:  uint32(localPort),
+		Protocol:   supervisor.TransportProtocol_udp,
 		Visibility: visibility,
 		Ctx:        listenerCtx,
 		Cancel:     cancel,
@@ -660,7 +876,7 @@ func (b *Bastion) establishSSHTunnel(ws *Workspace) (listener *TunnelListener, e
 	if err != nil {
 		return nil, xerrors.Errorf("cannot install authorized key: %w", err)
 	}
-	listener, err = b.establishTunnel(ws.ctx, ws, "ssh", 23001, 0, supervisor.TunnelVisiblity_host)
+	listener, err = b.establishTunnel(ws.ctx, ws, "ssh", 23001, 0, supervisor.TransportProtocol_tcp, supervisor.TunnelVisiblity_host)
 	return listener, err
 }
 
@@ -675,8 +891,15 @@ func installSSHAuthorizedKey(ws *Workspace, key string) error {
 	//nolint:errcheck
 	defer term.Shutdown(ctx, &supervisor.ShutdownTerminalRequest{Alias: tres.Terminal.Alias})
 
-	done := make(chan bool, 1)
-	recv, err := term.Listen(ctx, &supervisor.ListenTerminalRequest{Alias: tres.Terminal.Alias})
+	// supervisor only accepts writes to the terminal we own from clients which listen to it and present their write token
+	const clientID = "local-app"
+	done, writeToken := make(chan bool, 1), make(chan string, 1)
+	recv, err := term.Listen(ctx, &supervisor.ListenTerminalRequest{
+		Alias:    tres.Terminal.Alias,
+		ClientId: clientID,
+		Role:     supervisor.TerminalClientRole_owner,
+		Token:    tres.StarterToken,
+	})
 	if err != nil {
 		return err
 	}
@@ -691,6 +914,11 @@ func installSSHAuthorizedKey(ws *Workspace, key string) error {
 			if resp.Output == nil {
 				continue
 			}
+			if tkn, ok := resp.Output.(*supervisor.ListenTerminalResponse_WriteToken); ok {
+				// the write token tells us that we've joined the terminal
+				writeToken <- tkn.WriteToken
+				continue
+			}
 			out, ok := resp.Output.(*supervisor.ListenTerminalResponse_Data)
 			if !ok {
 				continue
@@ -702,9 +930,19 @@ func installSSHAuthorizedKey(ws *Workspace, key string) error {
 			}
 		}
 	}()
+	var tkn string
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return xerrors.Errorf("unable to upload SSH key")
+	case tkn = <-writeToken:
+	}
 	_, err = term.Write(ctx, &supervisor.WriteTerminalRequest{
-		Alias: tres.Terminal.Alias,
-		Stdin: []byte(fmt.Sprintf("mkdir -p ~/.ssh; echo %s >> ~/.ssh/authorized_keys; echo write done\r\n", strings.TrimSpace(key))),
+		Alias:      tres.Terminal.Alias,
+		ClientId:   clientID,
+		WriteToken: tkn,
+		Stdin:      []byte(fmt.Sprintf("mkdir -p ~/.ssh; echo %s >> ~/.ssh/authorized_keys; echo write done\r\n", strings.TrimSpace(key))),
 	})
 	if err != nil {
 		return err
@@ -762,7 +1000,8 @@ func (b *Bastion) tunnelPorts(ws *Workspace) {
 func (b *Bastion) doTunnelPorts(ctx context.Context, ws *Workspace) error {
 	statusService := supervisor.NewStatusServiceClient(ws.supervisorClient)
 	status, err := statusService.PortsStatus(ctx, &supervisor.PortsStatusRequest{
-		Observe: true,
+		Observe:    true,
+		IncludeUdp: true,
 	})
 	if err != nil {
 		return err
@@ -782,22 +1021,23 @@ func (b *Bastion) doTunnelPorts(ctx context.Context, ws *Workspace) error {
 			return err
 		}
 		ws.tunnelMu.Lock()
-		currentTunneled := make(map[uint32]struct{})
+		currentTunneled := make(map[tunnelKey]struct{})
 		for _, port := range resp.Ports {
 			visibility := supervisor.TunnelVisiblity_none
 			if port.Tunneled != nil {
 				visibility = port.Tunneled.Visibility
 			}
-			listener, alreadyTunneled := ws.tunnelListeners[port.LocalPort]
+			key := tunnelKey{port: port.LocalPort, protocol: port.TransportProtocol}
+			listener, alreadyTunneled := ws.tunnelListeners[key]
 			if alreadyTunneled && listener.Visibility != visibility {
 				listener.Cancel()
-				delete(ws.tunnelListeners, port.LocalPort)
+				delete(ws.tunnelListeners, key)
 			}
 			if visibility == supervisor.TunnelVisiblity_none {
 				continue
 			}
-			currentTunneled[port.LocalPort] = struct{}{}
-			_, alreadyTunneled = ws.tunnelListeners[port.LocalPort]
+			currentTunneled[key] = struct{}{}
+			_, alreadyTunneled = ws.tunnelListeners[key]
 			if alreadyTunneled {
 				continue
 			}
@@ -806,18 +1046,18 @@ func (b *Bastion) doTunnelPorts(ctx context.Context, ws *Workspace) error {
 				continue
 			}
 
-			logprefix := "tunnel[" + supervisor.TunnelVisiblity_name[int32(port.Tunneled.Visibility)] + ":" + strconv.Itoa(int(port.LocalPort)) + "]"
-			listener, err := b.establishTunnel(ws.ctx, ws, logprefix, int(port.LocalPort), int(port.Tunneled.TargetPort), port.Tunneled.Visibility)
+			logprefix := "tunnel[" + supervisor.TunnelVisiblity_name[int32(port.Tunneled.Visibility)] + ":" + strconv.Itoa(int(port.LocalPort)) + "/" + port.TransportProtocol.String() + "]"
+			listener, err := b.establishTunnel(ws.ctx, ws, logprefix, int(port.LocalPort), int(port.Tunneled.TargetPort), port.TransportProtocol, port.Tunneled.Visibility)
 			if err != nil {
-				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("port", port.LocalPort).Error("cannot establish port tunnel")
+				logrus.WithError(err).WithField("workspace", ws.WorkspaceID).WithField("port", port.LocalPort).WithField("protocol", port.TransportProtocol.String()).Error("cannot establish port tunnel")
 			} else {
-				ws.tunnelListeners[port.LocalPort] = listener
+				ws.tunnelListeners[key] = listener
 			}
 		}
-		for port, listener := range ws.tunnelListeners {
-			_, exists := currentTunneled[port]
+		for key, listener := range ws.tunnelListeners {
+			_, exists := currentTunneled[key]
 			if !exists {
-				delete(ws.tunnelListeners, port)
+				delete(ws.tunnelListeners, key)
 				listener.Cancel()
 			}
 		}
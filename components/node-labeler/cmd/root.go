@@ -23,8 +23,9 @@ var (
 	jsonLog bool
 	verbose bool
 
-	registryFacadePort int
-	wsdaemonPort       int
+	registryFacadePort  int
+	wsdaemonPort        int
+	wsdaemonPrePullPort int
 
 	namespace string
 )
@@ -50,6 +51,7 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().IntVar(&registryFacadePort, "registry-facade-port", 31750, "registry-facade node port")
 	rootCmd.PersistentFlags().IntVar(&wsdaemonPort, "ws-daemon-port", 8080, "ws-daemon service port")
+	rootCmd.PersistentFlags().IntVar(&wsdaemonPrePullPort, "ws-daemon-prepull-port", 0, "ws-daemon pre-pull status port, zero if ws-daemon does not pre-pull images")
 	rootCmd.PersistentFlags().StringVar(&namespace, "namespace", "default", "Namespace where Gitpod components are running")
 
 	rootCmd.PersistentFlags().BoolVarP(&jsonLog, "json-log", "j", true, "produce JSON log output on verbose level")
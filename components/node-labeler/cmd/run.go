@@ -7,6 +7,7 @@ package cmd
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -41,6 +42,7 @@ import (
 const (
 	registryFacadeLabel = "gitpod.io/registry-facade_ready_ns_%v"
 	wsdaemonLabel       = "gitpod.io/ws-daemon_ready_ns_%v"
+	wsdaemonWarmLabel   = "gitpod.io/ws-daemon_warm_ns_%v"
 
 	registryFacade = "registry-facade"
 	wsDaemon       = "ws-daemon"
@@ -48,6 +50,10 @@ const (
 
 var defaultRequeueTime = time.Second * 10
 
+// warmRequeueTime is the interval in which we check how warm a node is. Nodes turn warm and cold again
+// while ws-daemon pre-pulls images, without any change to the ws-daemon pod.
+var warmRequeueTime = time.Minute
+
 // serveCmd represents the serve command
 var runCmd = &cobra.Command{
 	Use:   "run",
@@ -179,16 +185,22 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req reconcile.Request) (r
 		// the pod is being removed.
 		// remove the component label from the node
 		time.Sleep(1 * time.Second)
-		err := updateLabel(labelToUpdate, false, nodeName, r)
-		if err != nil {
-			// this is a edge case when cluster-autoscaler removes a node
-			// (all the running pods will be removed after that)
-			if errors.IsNotFound(err) {
-				return reconcile.Result{}, nil
+		labelsToRemove := []string{labelToUpdate}
+		if component == wsDaemon {
+			labelsToRemove = append(labelsToRemove, fmt.Sprintf(wsdaemonWarmLabel, namespace))
+		}
+		for _, label := range labelsToRemove {
+			err := updateLabel(label, false, nodeName, r)
+			if err != nil {
+				// this is a edge case when cluster-autoscaler removes a node
+				// (all the running pods will be removed after that)
+				if errors.IsNotFound(err) {
+					return reconcile.Result{}, nil
+				}
+
+				log.WithError(err).Error("removing node label")
+				return reconcile.Result{RequeueAfter: defaultRequeueTime}, err
 			}
-
-			log.WithError(err).Error("removing node label")
-			return reconcile.Result{RequeueAfter: defaultRequeueTime}, err
 		}
 
 		return reconcile.Result{}, err
@@ -205,9 +217,19 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req reconcile.Request) (r
 		return reconcile.Result{}, fmt.Errorf("obtaining node %s: %w", nodeName, err)
 	}
 
+	var result reconcile.Result
+	if component == wsDaemon && wsdaemonPrePullPort != 0 {
+		// how warm the node is changes independently of readiness
+		err = reconcileWarmLabel(&node, ipAddress, strconv.Itoa(wsdaemonPrePullPort), r)
+		if err != nil {
+			log.WithError(err).WithField("node", nodeName).Warn("cannot update warm label")
+		}
+		result.RequeueAfter = warmRequeueTime
+	}
+
 	if labelValue, exists := node.Labels[labelToUpdate]; exists && labelValue == "true" {
 		// nothing to do, the label already exists.
-		return reconcile.Result{}, nil
+		return result, nil
 	}
 
 	err = checkTCPPortIsReachable(ipAddress, port)
@@ -234,7 +256,7 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req reconcile.Request) (r
 	NodeLabelerTimeHistVec.WithLabelValues(component).Observe(readyIn.Seconds())
 	NodeLabelerCounterVec.WithLabelValues(component).Inc()
 
-	return reconcile.Result{}, nil
+	return result, nil
 }
 
 func updateLabel(label string, add bool, nodeName string, client client.Client) error {
@@ -308,6 +330,57 @@ func checkRegistryFacade(host, port string) error {
 	return fmt.Errorf("registry-facade is not ready yet")
 }
 
+// reconcileWarmLabel labels the node if ws-daemon has warmed its content store with the popular images
+func reconcileWarmLabel(node *corev1.Node, host, port string, client client.Client) error {
+	label := fmt.Sprintf(wsdaemonWarmLabel, namespace)
+	_, labeled := node.Labels[label]
+
+	warm, err := checkWsDaemonWarm(host, port)
+	if err != nil {
+		return err
+	}
+	if warm == labeled {
+		return nil
+	}
+
+	return updateLabel(label, warm, node.Name, client)
+}
+
+// checkWsDaemonWarm returns true if ws-daemon reports a warm node
+func checkWsDaemonWarm(host, port string) (bool, error) {
+	client := &http.Client{
+		Transport: newDefaultTransport(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	statusURL := fmt.Sprintf("http://%v/prepull/status", net.JoinHostPort(host, port))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, statusURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("building HTTP request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("unexpected error during HTTP request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var status struct {
+		Warm bool `json:"warm"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	if err != nil {
+		return false, fmt.Errorf("cannot decode pre-pull status: %v", err)
+	}
+	return status.Warm, nil
+}
+
 func newDefaultTransport() *http.Transport {
 	return &http.Transport{
 		DialContext: (&net.Dialer{
@@ -73,6 +73,14 @@ type Config struct {
 	IPFSCache *IPFSCacheConfig `json:"ipfs,omitempty"`
 
 	RedisCache *RedisCacheConfig `json:"redis,omitempty"`
+
+	LazyPull *LazyPullConfig `json:"lazyPull,omitempty"`
+
+	BlobCache *BlobCacheConfig `json:"blobCache,omitempty"`
+
+	Popularity *PopularityConfig `json:"popularity,omitempty"`
+
+	SignatureVerification *SignatureVerificationConfig `json:"signatureVerification,omitempty"`
 }
 
 type RedisCacheConfig struct {
@@ -87,6 +95,71 @@ type RedisCacheConfig struct {
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
+// LazyPullConfig configures the conversion of image layers to eStargz. Nodes whose snapshotter supports
+// lazy pulling can start a workspace container before all of its layer content has arrived.
+type LazyPullConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// Location is the directory converted layers are stored in
+	Location string `json:"location"`
+
+	// MinLayerSize is the size in bytes below which layers are not converted, but served as they are
+	MinLayerSize int64 `json:"minLayerSize,omitempty"`
+
+	// MaxBytes is the disk budget of the converted layers. Least recently used layers are evicted once they
+	// grow beyond it, and the images they belong to are served with their original layers. Zero means no budget.
+	MaxBytes int64 `json:"maxBytes,omitempty"`
+}
+
+// BlobCacheConfig configures the node-local cache of image layers on disk. Unlike the IPFS cache it
+// does not need any infrastructure besides a disk.
+type BlobCacheConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// Location is the directory the cached blobs are stored in
+	Location string `json:"location"`
+
+	// MaxBytes is the disk budget of the cache. Least recently used blobs are evicted once the cache
+	// grows beyond it. Zero means no budget.
+	MaxBytes int64 `json:"maxBytes,omitempty"`
+}
+
+// PopularityConfig configures the tracking of the images workspaces request most frequently.
+// ws-daemon warms the node's containerd content store with those images.
+type PopularityConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// Window is the sliding window over which image requests are counted, e.g. "24h". Defaults to 24h.
+	Window string `json:"window,omitempty"`
+}
+
+// SignatureVerificationConfig configures the admission of workspace base images based on their cosign
+// signatures and attestations
+type SignatureVerificationConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// AuditOnly logs images which fail the verification instead of refusing them
+	AuditOnly bool `json:"auditOnly,omitempty"`
+
+	// Policies determine which keys the images of a repository must be signed with.
+	// Images which match no policy are refused.
+	Policies []SignaturePolicy `json:"policies"`
+}
+
+// SignaturePolicy requires the images of some repositories to be signed by one of the public keys
+type SignaturePolicy struct {
+	// Repositories are the image names the policy applies to, including all names below them,
+	// e.g. "eu.gcr.io/gitpod" covers "eu.gcr.io/gitpod/base" but not "eu.gcr.io/gitpod-dev/base".
+	// Image names are normalised first, i.e. "ubuntu" becomes "docker.io/library/ubuntu".
+	Repositories []string `json:"repositories"`
+
+	// PublicKeys are paths to PEM encoded public keys
+	PublicKeys []string `json:"publicKeys"`
+
+	// AuditOnly logs images of this policy which fail the verification instead of refusing them
+	AuditOnly bool `json:"auditOnly,omitempty"`
+}
+
 type IPFSCacheConfig struct {
 	Enabled  bool   `json:"enabled"`
 	IPFSAddr string `json:"ipfsAddr"`
@@ -96,4 +169,8 @@ type IPFSCacheConfig struct {
 type StaticLayerCfg struct {
 	Ref  string `json:"ref"`
 	Type string `json:"type"`
+
+	// Platform restricts the layer to images of a platform, e.g. linux/arm64. Without it, file layers
+	// are added to all images and image layers come from the matching platform of multi-platform images.
+	Platform string `json:"platform,omitempty"`
 }
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"os"
 	"sync"
 	"syscall"
 	"time"
@@ -75,6 +76,8 @@ func (reg *Registry) handleBlob(ctx context.Context, r *http.Request) http.Handl
 			reg.LayerSource,
 		},
 		ConfigModifier: reg.ConfigModifier,
+		LazyLayers:     reg.LazyLayers,
+		BlobCache:      reg.BlobCache,
 
 		Metrics: reg.metrics,
 	}
@@ -102,6 +105,8 @@ type blobHandler struct {
 	IPFS              *IPFSBlobCache
 	AdditionalSources []BlobSource
 	ConfigModifier    ConfigModifier
+	LazyLayers        *LazyLayerSource
+	BlobCache         *DiskBlobCache
 
 	Metrics *metrics
 }
@@ -124,14 +129,6 @@ func (bh *blobHandler) getBlob(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	err := func() error {
-		// TODO: rather than download the same manifest over and over again,
-		//       we should add it to the store and try and fetch it from there.
-		//		 Only if the store fetch fails should we attetmpt to download it.
-		manifest, fetcher, err := bh.downloadManifest(ctx, bh.Spec.BaseRef)
-		if err != nil {
-			return xerrors.Errorf("cannnot fetch the manifest: %w", err)
-		}
-
 		var srcs []BlobSource
 
 		// 1. local store (faster)
@@ -143,30 +140,42 @@ func (bh *blobHandler) getBlob(w http.ResponseWriter, r *http.Request) {
 			srcs = append(srcs, ipfsSrc)
 		}
 
-		// 3. upstream registry
-		srcs = append(srcs, proxyingBlobSource{Fetcher: fetcher, Blobs: manifest.Layers})
-
-		srcs = append(srcs, &configBlobSource{Fetcher: fetcher, Spec: bh.Spec, Manifest: manifest, ConfigModifier: bh.ConfigModifier})
-		srcs = append(srcs, bh.AdditionalSources...)
+		// 3. eStargz layers (if configured)
+		if bh.LazyLayers != nil {
+			srcs = append(srcs, bh.LazyLayers)
+		}
 
 		w.Header().Set("Etag", bh.Digest.String())
 
-		var retrieved bool
-		var src BlobSource
-		var dontCache bool
-		for _, s := range srcs {
-			if !s.HasBlob(ctx, bh.Spec, bh.Digest) {
-				continue
+		retrieved, src, dontCache, err := bh.retrieve(ctx, srcs, w, r)
+		srcCtx := ctx
+		if !retrieved {
+			// All other sources depend on the platform: multi-platform images have layers and a config for each
+			// platform. We try the platform of the node first because that's what containerd usually pulls.
+			//
+			// TODO: rather than download the same manifest over and over again,
+			//       we should add it to the store and try and fetch it from there.
+			//		 Only if the store fetch fails should we attetmpt to download it.
+			desc, fetcher, plfs, ferr := bh.resolveBase(ctx, bh.Spec.BaseRef)
+			if ferr != nil {
+				return xerrors.Errorf("cannnot fetch the manifest: %w", ferr)
 			}
-
-			retrieved, dontCache, err = bh.retrieveFromSource(ctx, s, w, r)
-			if err != nil {
-				log.WithField("src", s.Name()).WithError(err).Error("unable to retrieve blob")
-			}
-
-			if retrieved {
-				src = s
-				break
+			for _, p := range plfs {
+				pctx := withPlatform(ctx, p)
+				manifest, _, ferr := DownloadManifest(pctx, AsFetcherFunc(fetcher), desc, WithStore(bh.Store))
+				if ferr != nil {
+					return xerrors.Errorf("cannnot fetch the manifest: %w", ferr)
+				}
+
+				var perr error
+				retrieved, src, dontCache, perr = bh.retrieve(pctx, bh.platformSources(fetcher, manifest), w, r)
+				if perr != nil {
+					err = perr
+				}
+				if retrieved {
+					srcCtx = pctx
+					break
+				}
 			}
 		}
 
@@ -182,7 +191,11 @@ func (bh *blobHandler) getBlob(w http.ResponseWriter, r *http.Request) {
 		go func() {
 			// we can do this only after the io.Copy above. Otherwise we might expect the blob
 			// to be in the blobstore when in reality it isn't.
-			_, mediaType, _, rc, err := src.GetBlob(context.Background(), bh.Spec, bh.Digest)
+			bctx := context.Background()
+			if p, ok := srcCtx.Value(platformContextKey{}).(ociv1.Platform); ok {
+				bctx = withPlatform(bctx, p)
+			}
+			_, mediaType, _, rc, err := src.GetBlob(bctx, bh.Spec, bh.Digest)
 			if err != nil {
 				log.WithError(err).WithField("digest", bh.Digest).Warn("cannot push to IPFS - unable to get blob")
 				return
@@ -227,6 +240,19 @@ func (bh *blobHandler) retrieveFromSource(ctx context.Context, src BlobSource, w
 
 	w.Header().Set("Content-Type", mediaType)
 
+	if f, ok := rc.(*os.File); ok {
+		// Files support range requests, which lazy pulls rely on
+		cw := &countingResponseWriter{ResponseWriter: w}
+		t0 := time.Now()
+		http.ServeContent(cw, r, "", time.Time{}, f)
+		if bh.Metrics != nil {
+			bh.Metrics.BlobDownloadCounter.WithLabelValues(src.Name(), "true").Inc()
+			bh.Metrics.BlobDownloadSpeedHist.WithLabelValues(src.Name()).Observe(float64(cw.N) / time.Since(t0).Seconds())
+			bh.Metrics.BlobDownloadSizeCounter.WithLabelValues(src.Name()).Add(float64(cw.N))
+		}
+		return true, dontCache, nil
+	}
+
 	bp := bufPool.Get().(*[]byte)
 	defer bufPool.Put(bp)
 
@@ -260,19 +286,78 @@ func (bh *blobHandler) retrieveFromSource(ctx context.Context, src BlobSource, w
 	return true, dontCache, nil
 }
 
-func (bh *blobHandler) downloadManifest(ctx context.Context, ref string) (res *ociv1.Manifest, fetcher remotes.Fetcher, err error) {
-	_, desc, err := bh.Resolver.Resolve(ctx, ref)
+// retrieve serves the blob from the first source which has it
+func (bh *blobHandler) retrieve(ctx context.Context, srcs []BlobSource, w http.ResponseWriter, r *http.Request) (retrieved bool, src BlobSource, dontCache bool, err error) {
+	for _, s := range srcs {
+		if !s.HasBlob(ctx, bh.Spec, bh.Digest) {
+			continue
+		}
+
+		retrieved, dontCache, err = bh.retrieveFromSource(ctx, s, w, r)
+		if err != nil {
+			log.WithField("src", s.Name()).WithError(err).Error("unable to retrieve blob")
+		}
+
+		if retrieved {
+			return true, s, dontCache, nil
+		}
+	}
+	return false, nil, false, err
+}
+
+// platformSources returns the blob sources for the platform-specific manifest of the base image
+func (bh *blobHandler) platformSources(fetcher remotes.Fetcher, manifest *ociv1.Manifest) []BlobSource {
+	var srcs []BlobSource
+
+	// 4. disk blob cache (if configured), which downloads blobs from the upstream registry once
+	if bh.BlobCache != nil {
+		srcs = append(srcs, diskCacheBlobSource{Cache: bh.BlobCache, Fetcher: fetcher, Blobs: manifest.Layers})
+	}
+
+	// 5. upstream registry
+	srcs = append(srcs, proxyingBlobSource{Fetcher: fetcher, Blobs: manifest.Layers})
+
+	srcs = append(srcs, &configBlobSource{Fetcher: fetcher, Spec: bh.Spec, Manifest: manifest, ConfigModifier: bh.ConfigModifier, LazyLayers: bh.LazyLayers})
+	srcs = append(srcs, bh.AdditionalSources...)
+	return srcs
+}
+
+// resolveBase resolves the base image and lists the platforms it's available for, the node's platform first.
+func (bh *blobHandler) resolveBase(ctx context.Context, ref string) (desc ociv1.Descriptor, fetcher remotes.Fetcher, plfs []ociv1.Platform, err error) {
+	_, desc, err = bh.Resolver.Resolve(ctx, ref)
 	if err != nil {
 		// ErrInvalidAuthorization
-		return nil, nil, err
+		return
 	}
 
 	fetcher, err = bh.Resolver.Fetcher(ctx, ref)
 	if err != nil {
 		log.WithError(err).WithField("ref", ref).WithField("instanceId", bh.Name).Error("cannot get fetcher")
-		return nil, nil, err
+		return
 	}
-	res, _, err = DownloadManifest(ctx, AsFetcherFunc(fetcher), desc, WithStore(bh.Store))
+
+	index, err := DownloadIndex(ctx, AsFetcherFunc(fetcher), desc, WithStore(bh.Store))
+	if err != nil {
+		return
+	}
+	if index != nil {
+		plfs = indexPlatforms(ctx, index)
+	}
+	if len(plfs) == 0 {
+		plfs = []ociv1.Platform{platformFromContext(ctx)}
+	}
+	return
+}
+
+// countingResponseWriter counts the bytes written to the response body
+type countingResponseWriter struct {
+	http.ResponseWriter
+	N int64
+}
+
+func (w *countingResponseWriter) Write(b []byte) (n int, err error) {
+	n, err = w.ResponseWriter.Write(b)
+	w.N += int64(n)
 	return
 }
 
@@ -370,6 +455,7 @@ type configBlobSource struct {
 	Spec           *api.ImageSpec
 	Manifest       *ociv1.Manifest
 	ConfigModifier ConfigModifier
+	LazyLayers     *LazyLayerSource
 }
 
 func (sbs configBlobSource) Name() string {
@@ -377,45 +463,70 @@ func (sbs configBlobSource) Name() string {
 }
 
 func (pbs *configBlobSource) HasBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) bool {
-	cfg, err := pbs.getConfig(ctx)
+	cfg, err := pbs.getConfig(ctx, dgst)
 	if err != nil {
 		log.WithError(err).Error("cannot (re-)produce image config")
 		return false
 	}
-
-	cfgDgst := digest.FromBytes(cfg)
-	return cfgDgst == dgst
+	return cfg != nil
 }
 
 func (pbs *configBlobSource) GetBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) (dontCache bool, mediaType string, url string, data io.ReadCloser, err error) {
-	if !pbs.HasBlob(ctx, spec, dgst) {
-		err = distv2.ErrorCodeBlobUnknown
+	cfg, err := pbs.getConfig(ctx, dgst)
+	if err != nil {
 		return
 	}
-
-	cfg, err := pbs.getConfig(ctx)
-	if err != nil {
+	if cfg == nil {
+		err = distv2.ErrorCodeBlobUnknown
 		return
 	}
+
 	mediaType = pbs.Manifest.Config.MediaType
 	data = io.NopCloser(bytes.NewReader(cfg))
 	return
 }
 
-func (pbs *configBlobSource) getConfig(ctx context.Context) (rawCfg []byte, err error) {
+// getConfig (re-)produces the image config with the given digest. If there's no such config, getConfig returns nil.
+func (pbs *configBlobSource) getConfig(ctx context.Context, dgst digest.Digest) (rawCfg []byte, err error) {
 	manifest := *pbs.Manifest
 	cfg, err := DownloadConfig(ctx, AsFetcherFunc(pbs.Fetcher), "", manifest.Config)
 	if err != nil {
 		return
 	}
 
-	_, err = pbs.ConfigModifier(ctx, pbs.Spec, cfg)
-	if err != nil {
-		return
+	// The manifest might have pointed to eStargz layers, which changes the diff IDs of the config. LazyLayerSource
+	// replaces either all convertible layers or none, hence there are exactly two versions of the config.
+	candidates := []*ociv1.Image{cfg}
+	if pbs.LazyLayers != nil {
+		var lazyCfg ociv1.Image
+		rawCfg, err = json.Marshal(cfg)
+		if err != nil {
+			return
+		}
+		err = json.Unmarshal(rawCfg, &lazyCfg)
+		if err != nil {
+			return
+		}
+		if pbs.LazyLayers.apply(manifest.MediaType, &manifest, &lazyCfg) {
+			candidates = append([]*ociv1.Image{&lazyCfg}, candidates...)
+		}
 	}
 
-	rawCfg, err = json.Marshal(cfg)
-	return
+	for _, c := range candidates {
+		_, err = pbs.ConfigModifier(ctx, pbs.Spec, c)
+		if err != nil {
+			return nil, err
+		}
+
+		rawCfg, err = json.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+		if digest.FromBytes(rawCfg) == dgst {
+			return rawCfg, nil
+		}
+	}
+	return nil, nil
 }
 
 type ipfsBlobSource struct {
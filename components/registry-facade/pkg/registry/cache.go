@@ -233,7 +233,7 @@ func (w *redisBlobWriter) Commit(ctx context.Context, size int64, expected diges
 		return err
 	}
 
-	if existingKeys != 0 {
+	if existingKeys != 0 && !w.isStale(ctx, kInfo) {
 		return nil
 	}
 
@@ -248,6 +248,21 @@ func (w *redisBlobWriter) Commit(ctx context.Context, size int64, expected diges
 	return nil
 }
 
+// isStale returns true if the content stored under our digest is not what the digest refers to.
+// Older versions stored the first manifest of an image index under the digest of the index.
+func (w *redisBlobWriter) isStale(ctx context.Context, kInfo string) bool {
+	res, err := w.client.Get(ctx, kInfo).Result()
+	if err != nil {
+		return false
+	}
+	var nfo redisBlobInfo
+	err = json.Unmarshal([]byte(res), &nfo)
+	if err != nil {
+		return false
+	}
+	return nfo.Digest != "" && nfo.Digest != w.digest.String()
+}
+
 // Status returns the current state of write
 func (w *redisBlobWriter) Status() (content.Status, error) {
 	return content.Status{}, fmt.Errorf("not implemented")
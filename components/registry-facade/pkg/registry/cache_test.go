@@ -112,3 +112,36 @@ func TestRedisBlobStore_Writer(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestRedisBlobStore_WriterReplacesStaleContent(t *testing.T) {
+	cnt := []byte("index")
+	dgst := digest.FromBytes(cnt)
+
+	// older versions stored the manifest of an index under the digest of the index
+	client, mock := redismock.NewClientMock()
+	mock.ExpectExists("cnt."+string(dgst), "nfo."+string(dgst)).SetVal(2)
+	mock.ExpectGet("nfo." + string(dgst)).SetVal(`{"Digest":"` + string(digest.FromString("manifest")) + `","Size":8}`)
+	mock.ExpectMSet(
+		"cnt."+string(dgst), string(cnt),
+		"nfo."+string(dgst), `{"Digest":"`+string(dgst)+`","Size":5,"CreatedAt":1,"UpdatedAt":1,"Labels":{"Content-Type":"application/vnd.oci.image.index.v1+json"}}`,
+	).SetVal("OK")
+
+	store := &RedisBlobStore{Client: client}
+	w, err := store.Writer(context.Background(), content.WithDescriptor(ociv1.Descriptor{
+		Digest:    dgst,
+		MediaType: ociv1.MediaTypeImageIndex,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.(*redisBlobWriter).forTestingOnlyTime = time.Unix(1, 1)
+	_, _ = w.Write(cnt)
+	w.Close()
+	err = w.Commit(context.Background(), int64(len(cnt)), dgst, content.WithLabels(contentTypeLabel(ociv1.MediaTypeImageIndex)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,433 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+	"github.com/gitpod-io/gitpod/registry-facade/api/config"
+)
+
+// blobDownloadIdleTimeout is the time an upstream download may not make any progress before we give up on it
+const blobDownloadIdleTimeout = 2 * time.Minute
+
+// DiskBlobCache is a node-local, content-addressed cache of blobs on disk. Concurrent requests for the same blob
+// share a single upstream download, and are served while that download is still in progress.
+type DiskBlobCache struct {
+	Location string
+	MaxBytes int64
+
+	mu        sync.Mutex
+	entries   map[digest.Digest]*diskCacheEntry
+	size      int64
+	reserved  int64
+	downloads map[digest.Digest]*blobDownload
+
+	idleTimeout time.Duration
+
+	requests  *prometheus.CounterVec
+	evictions prometheus.Counter
+	sizeGauge prometheus.Gauge
+}
+
+type diskCacheEntry struct {
+	Size     int64
+	LastUsed time.Time
+}
+
+// NewDiskBlobCache creates a new disk blob cache and loads the blobs cached previously
+func NewDiskBlobCache(cfg config.BlobCacheConfig, reg prometheus.Registerer) (*DiskBlobCache, error) {
+	if cfg.Location == "" {
+		return nil, xerrors.Errorf("blob cache location must not be empty")
+	}
+
+	res := &DiskBlobCache{
+		Location:    cfg.Location,
+		MaxBytes:    cfg.MaxBytes,
+		entries:     make(map[digest.Digest]*diskCacheEntry),
+		downloads:   make(map[digest.Digest]*blobDownload),
+		idleTimeout: blobDownloadIdleTimeout,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "blob_cache_requests_total",
+			Help: "number of blob requests served by the disk blob cache",
+		}, []string{"result"}),
+		evictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "blob_cache_evictions_total",
+			Help: "number of blobs evicted from the disk blob cache",
+		}),
+		sizeGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "blob_cache_size_bytes",
+			Help: "size of all blobs in the disk blob cache",
+		}),
+	}
+	for _, c := range []prometheus.Collector{res.requests, res.evictions, res.sizeGauge} {
+		err := reg.Register(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// downloads which were in progress when we stopped are lost
+	err := os.RemoveAll(res.tmpDir())
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range []string{res.tmpDir(), filepath.Join(res.Location, "blobs", string(digest.Canonical))} {
+		err = os.MkdirAll(dir, 0755)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create blob cache location: %w", err)
+		}
+	}
+
+	fns, err := filepath.Glob(filepath.Join(res.Location, "blobs", "*", "*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, fn := range fns {
+		dgst := digest.NewDigestFromEncoded(digest.Algorithm(filepath.Base(filepath.Dir(fn))), filepath.Base(fn))
+		if dgst.Validate() != nil {
+			continue
+		}
+		stat, err := os.Stat(fn)
+		if err != nil {
+			continue
+		}
+		res.entries[dgst] = &diskCacheEntry{Size: stat.Size(), LastUsed: stat.ModTime()}
+		res.size += stat.Size()
+	}
+	res.sizeGauge.Set(float64(res.size))
+	log.WithField("blobs", len(res.entries)).WithField("size", res.size).WithField("location", cfg.Location).Info("disk blob cache enabled")
+
+	return res, nil
+}
+
+func (c *DiskBlobCache) tmpDir() string {
+	return filepath.Join(c.Location, "tmp")
+}
+
+func (c *DiskBlobCache) blobPath(dgst digest.Digest) string {
+	return filepath.Join(c.Location, "blobs", dgst.Algorithm().String(), dgst.Encoded())
+}
+
+// Get returns the content of a blob. Blobs which are not cached yet are downloaded using the fetcher. If the blob
+// is cached already, Get returns an *os.File. Reading a blob which is still being downloaded fails once ctx is done.
+func (c *DiskBlobCache) Get(ctx context.Context, fetcher remotes.Fetcher, desc ociv1.Descriptor) (io.ReadCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[desc.Digest]; ok {
+		f, err := os.Open(c.blobPath(desc.Digest))
+		if err == nil {
+			c.requests.WithLabelValues("hit").Inc()
+			entry.LastUsed = time.Now()
+			// the modification time keeps track of the last use across restarts
+			_ = os.Chtimes(f.Name(), entry.LastUsed, entry.LastUsed)
+			return f, nil
+		}
+		log.WithError(err).WithField("digest", desc.Digest).Warn("cached blob is gone - downloading it again")
+		c.remove(desc.Digest)
+	}
+
+	if dl, ok := c.downloads[desc.Digest]; ok {
+		c.requests.WithLabelValues("coalesced").Inc()
+		return dl.reader(ctx)
+	}
+
+	tmp, err := os.CreateTemp(c.tmpDir(), "blob-*")
+	if err != nil {
+		return nil, err
+	}
+	dl := &blobDownload{path: tmp.Name()}
+	dl.cond = sync.NewCond(&dl.mu)
+	rd, err := dl.reader(ctx)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	c.downloads[desc.Digest] = dl
+	c.requests.WithLabelValues("miss").Inc()
+
+	// downloads in progress take up their share of the budget already, unless they are too large to be cached anyway
+	if c.MaxBytes > 0 && desc.Size <= c.MaxBytes {
+		dl.reserved = desc.Size
+		c.reserved += dl.reserved
+		c.enforceBudget()
+	}
+
+	// the download must not depend on the request which happened to start it
+	go c.download(fetcher, desc, dl, tmp)
+
+	return rd, nil
+}
+
+func (c *DiskBlobCache) download(fetcher remotes.Fetcher, desc ociv1.Descriptor, dl *blobDownload, tmp *os.File) {
+	err := func() error {
+		defer tmp.Close()
+
+		// the upstream must keep making progress, otherwise the download and everyone following it would hang
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		idle := time.AfterFunc(c.idleTimeout, cancel)
+		defer idle.Stop()
+
+		rc, err := fetcher.Fetch(ctx, desc)
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+
+		verifier := desc.Digest.Verifier()
+		_, err = io.Copy(dl.writer(tmp), io.TeeReader(&idleReader{r: rc, timer: idle, timeout: c.idleTimeout}, verifier))
+		if err != nil && ctx.Err() != nil {
+			return xerrors.Errorf("download of blob %s stalled: %w", desc.Digest, err)
+		}
+		if err != nil {
+			return err
+		}
+		if !verifier.Verified() {
+			return xerrors.Errorf("digest mismatch for blob %s", desc.Digest)
+		}
+		return nil
+	}()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.downloads, desc.Digest)
+	c.reserved -= dl.reserved
+
+	if err == nil && c.MaxBytes > 0 && dl.n > c.MaxBytes {
+		log.WithField("digest", desc.Digest).WithField("size", dl.n).Debug("not caching blob larger than the cache")
+		os.Remove(dl.path)
+	} else if err == nil {
+		err = os.Rename(dl.path, c.blobPath(desc.Digest))
+		if err == nil {
+			c.entries[desc.Digest] = &diskCacheEntry{Size: dl.n, LastUsed: time.Now()}
+			c.size += dl.n
+			c.enforceBudget()
+			c.sizeGauge.Set(float64(c.size))
+		}
+	}
+	if err != nil {
+		log.WithError(err).WithField("digest", desc.Digest).Warn("cannot cache blob")
+		os.Remove(dl.path)
+	}
+
+	dl.finish(err)
+}
+
+// enforceBudget evicts the least recently used blobs until the cache and the downloads in progress fit its budget.
+// Callers must hold c.mu.
+func (c *DiskBlobCache) enforceBudget() {
+	if c.MaxBytes <= 0 || c.size+c.reserved <= c.MaxBytes {
+		return
+	}
+
+	dgsts := make([]digest.Digest, 0, len(c.entries))
+	for dgst := range c.entries {
+		dgsts = append(dgsts, dgst)
+	}
+	sort.Slice(dgsts, func(i, j int) bool { return c.entries[dgsts[i]].LastUsed.Before(c.entries[dgsts[j]].LastUsed) })
+
+	for _, dgst := range dgsts {
+		if c.size+c.reserved <= c.MaxBytes {
+			break
+		}
+		// readers which have the blob open already can continue reading it
+		err := os.Remove(c.blobPath(dgst))
+		if err != nil && !os.IsNotExist(err) {
+			log.WithError(err).WithField("digest", dgst).Warn("cannot evict blob")
+			continue
+		}
+		c.remove(dgst)
+		c.evictions.Inc()
+	}
+}
+
+// remove forgets about a blob. Callers must hold c.mu.
+func (c *DiskBlobCache) remove(dgst digest.Digest) {
+	entry, ok := c.entries[dgst]
+	if !ok {
+		return
+	}
+	delete(c.entries, dgst)
+	c.size -= entry.Size
+	c.sizeGauge.Set(float64(c.size))
+}
+
+// blobDownload is a blob download in progress. Any number of readers can follow it.
+type blobDownload struct {
+	path     string
+	reserved int64
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	n    int64
+	done bool
+	err  error
+}
+
+func (dl *blobDownload) reader(ctx context.Context) (io.ReadCloser, error) {
+	f, err := os.Open(dl.path)
+	if err != nil {
+		return nil, err
+	}
+	r := &blobDownloadReader{ctx: ctx, dl: dl, f: f, closed: make(chan struct{})}
+
+	// wake the reader up when its request is gone, s.t. it doesn't wait for the download
+	go func() {
+		select {
+		case <-ctx.Done():
+			dl.mu.Lock()
+			dl.cond.Broadcast()
+			dl.mu.Unlock()
+		case <-r.closed:
+		}
+	}()
+
+	return r, nil
+}
+
+func (dl *blobDownload) writer(f *os.File) io.Writer {
+	return writerFunc(func(p []byte) (int, error) {
+		n, err := f.Write(p)
+
+		dl.mu.Lock()
+		dl.n += int64(n)
+		dl.mu.Unlock()
+		dl.cond.Broadcast()
+
+		return n, err
+	})
+}
+
+func (dl *blobDownload) finish(err error) {
+	dl.mu.Lock()
+	dl.done = true
+	dl.err = err
+	dl.mu.Unlock()
+	dl.cond.Broadcast()
+}
+
+// idleReader pushes the timer back whenever it reads something
+type idleReader struct {
+	r       io.Reader
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+func (r *idleReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.timer.Reset(r.timeout)
+	}
+	return n, err
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
+
+// blobDownloadReader reads a blob while it is being downloaded
+type blobDownloadReader struct {
+	ctx context.Context
+	dl  *blobDownload
+	f   *os.File
+	off int64
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (r *blobDownloadReader) Read(p []byte) (n int, err error) {
+	dl := r.dl
+	dl.mu.Lock()
+	for r.off >= dl.n && !dl.done && r.ctx.Err() == nil {
+		dl.cond.Wait()
+	}
+	available, dlErr := dl.n-r.off, dl.err
+	dl.mu.Unlock()
+
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if available <= 0 {
+		if dlErr != nil {
+			return 0, dlErr
+		}
+		return 0, io.EOF
+	}
+	if int64(len(p)) > available {
+		p = p[:available]
+	}
+	n, err = r.f.ReadAt(p, r.off)
+	r.off += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (r *blobDownloadReader) Close() error {
+	r.closeOnce.Do(func() { close(r.closed) })
+	return r.f.Close()
+}
+
+// diskCacheBlobSource serves the blobs of an image through the disk blob cache
+type diskCacheBlobSource struct {
+	Cache   *DiskBlobCache
+	Fetcher remotes.Fetcher
+	Blobs   []ociv1.Descriptor
+}
+
+func (dbs diskCacheBlobSource) Name() string {
+	return "diskcache"
+}
+
+func (dbs diskCacheBlobSource) HasBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) bool {
+	for _, b := range dbs.Blobs {
+		if b.Digest == dgst {
+			return true
+		}
+	}
+	return false
+}
+
+func (dbs diskCacheBlobSource) GetBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) (dontCache bool, mediaType string, url string, data io.ReadCloser, err error) {
+	var src ociv1.Descriptor
+	for _, b := range dbs.Blobs {
+		if b.Digest == dgst {
+			src = b
+			break
+		}
+	}
+	if src.Digest == "" {
+		err = errdefs.ErrNotFound
+		return
+	}
+
+	data, err = dbs.Cache.Get(ctx, dbs.Fetcher, src)
+	if err != nil {
+		return
+	}
+	// the blob is cached on disk already - there's no point in pushing it to IPFS as well
+	return true, src.MediaType, "", data, nil
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/gitpod-io/gitpod/registry-facade/api/config"
+)
+
+func TestDiskBlobCacheCoalescing(t *testing.T) {
+	const clients = 5
+	content := strings.Repeat("blob", 4096)
+	desc := ociv1.Descriptor{MediaType: ociv1.MediaTypeImageLayerGzip, Digest: digest.FromString(content), Size: int64(len(content))}
+
+	// the upstream sends half of the blob and waits until all clients are waiting for the rest
+	gate := make(chan struct{})
+	fetcher := &countingFetcher{Content: func() io.Reader {
+		return io.MultiReader(strings.NewReader(content[:len(content)/2]), gatedReader{gate, strings.NewReader(content[len(content)/2:])})
+	}}
+	cache := newTestDiskBlobCache(t, config.BlobCacheConfig{})
+
+	var (
+		wg  sync.WaitGroup
+		act = make([]string, clients)
+	)
+	for i := 0; i < clients; i++ {
+		rc, err := cache.Get(context.Background(), fetcher, desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer rc.Close()
+			b, err := io.ReadAll(rc)
+			if err != nil {
+				t.Errorf("client %d cannot read blob: %v", i, err)
+			}
+			act[i] = string(b)
+		}(i)
+	}
+	close(gate)
+	wg.Wait()
+
+	for i := range act {
+		if act[i] != content {
+			t.Errorf("client %d received unexpected content", i)
+		}
+	}
+	waitForDownloads(t, cache)
+
+	rc, err := cache.Get(context.Background(), fetcher, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(b) != content {
+		t.Error("cached blob has unexpected content")
+	}
+
+	if diff := cmp.Diff(int64(1), fetcher.Count.Load()); diff != "" {
+		t.Errorf("unexpected number of upstream fetches (-want +got):\n%s", diff)
+	}
+	for result, expectation := range map[string]float64{"miss": 1, "coalesced": clients - 1, "hit": 1} {
+		if diff := cmp.Diff(expectation, testutil.ToFloat64(cache.requests.WithLabelValues(result))); diff != "" {
+			t.Errorf("unexpected number of %s requests (-want +got):\n%s", result, diff)
+		}
+	}
+}
+
+func TestDiskBlobCacheDigestMismatch(t *testing.T) {
+	desc := ociv1.Descriptor{MediaType: ociv1.MediaTypeImageLayerGzip, Digest: digest.FromString("expected"), Size: 8}
+	fetcher := &countingFetcher{Content: func() io.Reader { return strings.NewReader("tampered") }}
+	cache := newTestDiskBlobCache(t, config.BlobCacheConfig{})
+
+	rc, err := cache.Get(context.Background(), fetcher, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = io.ReadAll(rc)
+	rc.Close()
+	if err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Errorf("expected digest mismatch, got %v", err)
+	}
+	waitForDownloads(t, cache)
+
+	if len(cache.entries) != 0 {
+		t.Error("blob with invalid digest must not be cached")
+	}
+}
+
+func TestDiskBlobCacheEviction(t *testing.T) {
+	blobs := map[string]ociv1.Descriptor{}
+	for _, name := range []string{"a", "b", "c"} {
+		content := strings.Repeat(name, 10)
+		blobs[name] = ociv1.Descriptor{Digest: digest.FromString(content), Size: 10}
+	}
+	fetch := func(t *testing.T, cache *DiskBlobCache, name string) {
+		fetcher := &countingFetcher{Content: func() io.Reader { return strings.NewReader(strings.Repeat(name, 10)) }}
+		rc, err := cache.Get(context.Background(), fetcher, blobs[name])
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = io.Copy(io.Discard, rc)
+		rc.Close()
+		waitForDownloads(t, cache)
+	}
+
+	cfg := config.BlobCacheConfig{Location: t.TempDir(), MaxBytes: 25}
+	cache := newTestDiskBlobCache(t, cfg)
+	fetch(t, cache, "a")
+	fetch(t, cache, "b")
+	// using a makes b the least recently used blob
+	cache.entries[blobs["a"].Digest].LastUsed = time.Now().Add(-2 * time.Minute)
+	cache.entries[blobs["b"].Digest].LastUsed = time.Now().Add(-3 * time.Minute)
+	fetch(t, cache, "a")
+	fetch(t, cache, "c")
+
+	var act []string
+	for _, name := range []string{"a", "b", "c"} {
+		if _, ok := cache.entries[blobs[name].Digest]; ok {
+			act = append(act, name)
+		}
+	}
+	if diff := cmp.Diff([]string{"a", "c"}, act); diff != "" {
+		t.Errorf("unexpected cached blobs (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(float64(1), testutil.ToFloat64(cache.evictions)); diff != "" {
+		t.Errorf("unexpected number of evictions (-want +got):\n%s", diff)
+	}
+
+	// cached blobs survive a restart
+	restarted := newTestDiskBlobCache(t, cfg)
+	if diff := cmp.Diff(int64(20), restarted.size); diff != "" {
+		t.Errorf("unexpected cache size after restart (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiskBlobCacheDownloadInProgress(t *testing.T) {
+	content := strings.Repeat("blob", 10)
+	desc := ociv1.Descriptor{MediaType: ociv1.MediaTypeImageLayerGzip, Digest: digest.FromString(content), Size: int64(len(content))}
+	gate := make(chan struct{})
+	fetcher := &countingFetcher{Content: func() io.Reader { return gatedReader{gate, strings.NewReader(content)} }}
+	cache := newTestDiskBlobCache(t, config.BlobCacheConfig{MaxBytes: 50})
+	defer func() {
+		close(gate)
+		waitForDownloads(t, cache)
+	}()
+
+	cached := ociv1.Descriptor{Digest: digest.FromString(strings.Repeat("c", 20)), Size: 20}
+	rc, err := cache.Get(context.Background(), &countingFetcher{Content: func() io.Reader { return strings.NewReader(strings.Repeat("c", 20)) }}, cached)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = io.Copy(io.Discard, rc)
+	rc.Close()
+	waitForDownloads(t, cache)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rc, err = cache.Get(ctx, fetcher, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+
+	// the download counts against the budget before it has finished
+	cache.mu.Lock()
+	_, stillCached := cache.entries[cached.Digest]
+	cache.mu.Unlock()
+	if stillCached {
+		t.Error("expected the download in progress to evict the cached blob")
+	}
+
+	// readers do not wait for the download once their request is gone
+	errc := make(chan error, 1)
+	go func() {
+		_, err := io.ReadAll(rc)
+		errc <- err
+	}()
+	cancel()
+	select {
+	case err := <-errc:
+		if err != context.Canceled {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("reader did not return after its context was cancelled")
+	}
+}
+
+func TestDiskBlobCacheStalledDownload(t *testing.T) {
+	desc := ociv1.Descriptor{MediaType: ociv1.MediaTypeImageLayerGzip, Digest: digest.FromString("stalled"), Size: 7}
+	cache := newTestDiskBlobCache(t, config.BlobCacheConfig{})
+	cache.idleTimeout = 50 * time.Millisecond
+
+	rc, err := cache.Get(context.Background(), stallingFetcher{}, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = io.ReadAll(rc)
+	rc.Close()
+	if err == nil || !strings.Contains(err.Error(), "stalled") {
+		t.Errorf("expected stalled download, got %v", err)
+	}
+	waitForDownloads(t, cache)
+}
+
+func newTestDiskBlobCache(t *testing.T, cfg config.BlobCacheConfig) *DiskBlobCache {
+	if cfg.Location == "" {
+		cfg.Location = t.TempDir()
+	}
+	cache, err := NewDiskBlobCache(cfg, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cache
+}
+
+// waitForDownloads waits until no blob is being downloaded anymore
+func waitForDownloads(t *testing.T, cache *DiskBlobCache) {
+	for i := 0; i < 100; i++ {
+		cache.mu.Lock()
+		pending := len(cache.downloads)
+		cache.mu.Unlock()
+		if pending == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for downloads")
+}
+
+// countingFetcher serves the same content for every descriptor and counts the fetches
+type countingFetcher struct {
+	Content func() io.Reader
+	Count   atomic.Int64
+}
+
+var _ remotes.Fetcher = &countingFetcher{}
+
+func (f *countingFetcher) Fetch(ctx context.Context, desc ociv1.Descriptor) (io.ReadCloser, error) {
+	f.Count.Add(1)
+	return io.NopCloser(f.Content()), nil
+}
+
+type gatedReader struct {
+	gate <-chan struct{}
+	r    io.Reader
+}
+
+func (g gatedReader) Read(p []byte) (int, error) {
+	<-g.gate
+	return g.r.Read(p)
+}
+
+// stallingFetcher never sends any content
+type stallingFetcher struct{}
+
+func (stallingFetcher) Fetch(ctx context.Context, desc ociv1.Descriptor) (io.ReadCloser, error) {
+	return io.NopCloser(ctxReader{ctx}), nil
+}
+
+type ctxReader struct {
+	ctx context.Context
+}
+
+func (r ctxReader) Read(p []byte) (int, error) {
+	<-r.ctx.Done()
+	return 0, r.ctx.Err()
+}
@@ -16,6 +16,7 @@ import (
 	"sync"
 
 	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/platforms"
 	"github.com/containerd/containerd/remotes"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/opencontainers/go-digest"
@@ -232,7 +233,8 @@ const (
 	envPrefixPrepend = "GITPOD_ENV_PREPEND_"
 )
 
-// NewStaticSourceFromImage downloads image layers into the store and uses them as static layer
+// NewStaticSourceFromImage downloads image layers into the store and uses them as static layer.
+// If ref is a multi-platform image we use the layers of the platform of ctx.
 func NewStaticSourceFromImage(ctx context.Context, resolver remotes.Resolver, ref string) (*ImageLayerSource, error) {
 	_, desc, err := resolver.Resolve(ctx, ref)
 	if err != nil {
@@ -307,6 +309,63 @@ func NewStaticSourceFromImage(ctx context.Context, resolver remotes.Resolver, re
 	}, nil
 }
 
+// NewPlatformStaticSourceFromImage uses the layers of each platform of a multi-platform image as static layers
+// of images of that platform. If platform is not empty only images of that platform get the layers.
+func NewPlatformStaticSourceFromImage(ctx context.Context, resolver remotes.Resolver, ref string, platform string) (LayerSource, error) {
+	var only *ociv1.Platform
+	if platform != "" {
+		p, err := platforms.Parse(platform)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid platform %s: %w", platform, err)
+		}
+		only = &p
+	}
+
+	_, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	fetcher, err := resolver.Fetcher(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	index, err := DownloadIndex(ctx, AsFetcherFunc(fetcher), desc)
+	if err != nil {
+		return nil, err
+	}
+	var plfs []ociv1.Platform
+	if index != nil {
+		plfs = indexPlatforms(ctx, index)
+	}
+	if len(plfs) == 0 {
+		// not a multi-platform image
+		src, err := NewStaticSourceFromImage(ctx, resolver, ref)
+		if err != nil {
+			return nil, err
+		}
+		if only == nil {
+			return src, nil
+		}
+		return &PlatformLayerSource{LayerSource: src, Platform: *only}, nil
+	}
+
+	var res CompositeLayerSource
+	for _, p := range plfs {
+		if only != nil && !platforms.NewMatcher(*only).Match(p) {
+			continue
+		}
+		src, err := NewStaticSourceFromImage(withPlatform(ctx, p), resolver, ref)
+		if err != nil {
+			return nil, xerrors.Errorf("platform %s: %w", platforms.Format(p), err)
+		}
+		res = append(res, &PlatformLayerSource{LayerSource: src, Platform: p})
+	}
+	if len(res) == 0 {
+		return nil, xerrors.Errorf("%s has no image for platform %s", ref, platform)
+	}
+	return res, nil
+}
+
 // getSkipNLabelValue returns the parsed label value of the LabelSkipNLayer label.
 func getSkipNLabelValue(cfg *ociv1.ImageConfig) (skipN int, err error) {
 	v, ok := cfg.Labels[labelSkipNLayer]
@@ -494,7 +553,9 @@ func (src *SpecMappedImagedSource) getDelegate(ctx context.Context, spec *api.Im
 		if ref == "" {
 			continue
 		}
-		if s, ok := src.cache.Get(ref); ok {
+		// multi-platform images provide different layers for each platform
+		key := ref + "@" + platforms.Format(platformFromContext(ctx))
+		if s, ok := src.cache.Get(key); ok {
 			layers[i] = s.(LayerSource)
 			continue
 		}
@@ -502,7 +563,7 @@ func (src *SpecMappedImagedSource) getDelegate(ctx context.Context, spec *api.Im
 		if err != nil {
 			return nil, err
 		}
-		src.cache.Add(ref, lsrc)
+		src.cache.Add(key, lsrc)
 		layers[i] = lsrc
 	}
 	return layers, nil
@@ -566,7 +627,7 @@ func (src *ContentLayerSource) GetLayer(ctx context.Context, spec *api.ImageSpec
 
 			res[i] = AddonLayer{
 				Descriptor: ociv1.Descriptor{
-					MediaType: rl.MediaType,
+					MediaType: remoteLayerMediaType(rl),
 					Digest:    dgst,
 					URLs:      urls,
 					Size:      rl.Size,
@@ -618,12 +679,7 @@ func (src *ContentLayerSource) GetBlob(ctx context.Context, spec *api.ImageSpec,
 
 		if rl := layer.GetRemote(); rl != nil {
 			if rl.Digest == dgst.String() {
-				mt := ociv1.MediaTypeImageLayerGzip
-				if rl.DiffId == rl.Digest || rl.DiffId == "" {
-					mt = ociv1.MediaTypeImageLayer
-				}
-
-				return false, mt, rl.Url, nil, nil
+				return false, remoteLayerMediaType(rl), rl.Url, nil, nil
 			}
 		}
 	}
@@ -632,6 +688,18 @@ func (src *ContentLayerSource) GetBlob(ctx context.Context, spec *api.ImageSpec,
 	return
 }
 
+// remoteLayerMediaType returns the media type of a remote content layer. Layers which don't declare
+// their media type are gzip compressed if their digest differs from their diffID.
+func remoteLayerMediaType(rl *api.RemoteContentLayer) string {
+	if rl.MediaType != "" {
+		return rl.MediaType
+	}
+	if rl.DiffId == rl.Digest || rl.DiffId == "" {
+		return ociv1.MediaTypeImageLayer
+	}
+	return ociv1.MediaTypeImageLayerGzip
+}
+
 // ParsedEnvs is parsed image envs configuration
 type ParsedEnvs struct {
 	keys   []string
@@ -15,6 +15,8 @@ import (
 	"time"
 
 	ctesting "github.com/gitpod-io/gitpod/common-go/testing"
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+	"github.com/opencontainers/go-digest"
 	"golang.org/x/xerrors"
 
 	"github.com/containerd/containerd/remotes"
@@ -161,3 +163,59 @@ func (f *fakeFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.Re
 	}
 	return io.NopCloser(bytes.NewReader(c)), nil
 }
+
+func TestContentLayerSourceRemoteMediaType(t *testing.T) {
+	const (
+		dgst   = "sha256:b1e7a1dbfe61b8bbd9d2af4ba3c1f63d9ca7e4e4e2eb3ee9bb25b4b38f2d2a2c"
+		diffID = "sha256:3f39d1fd1ca8b0ad4ea1c2b6bf1c9e0b15b86e6e4b7a5e2bf4a4e0e11d1bb03a"
+	)
+	tests := []struct {
+		Name        string
+		Layer       *api.RemoteContentLayer
+		Expectation string
+	}{
+		{
+			Name:        "uncompressed without media type",
+			Layer:       &api.RemoteContentLayer{Digest: dgst, DiffId: dgst},
+			Expectation: ocispec.MediaTypeImageLayer,
+		},
+		{
+			Name:        "compressed without media type",
+			Layer:       &api.RemoteContentLayer{Digest: dgst, DiffId: diffID},
+			Expectation: ocispec.MediaTypeImageLayerGzip,
+		},
+		{
+			Name:        "zstd",
+			Layer:       &api.RemoteContentLayer{Digest: dgst, DiffId: diffID, MediaType: ocispec.MediaTypeImageLayerZstd},
+			Expectation: ocispec.MediaTypeImageLayerZstd,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			src, err := NewContentLayerSource()
+			if err != nil {
+				t.Fatal(err)
+			}
+			spec := &api.ImageSpec{
+				ContentLayer: []*api.ContentLayer{{Spec: &api.ContentLayer_Remote{Remote: test.Layer}}},
+			}
+
+			layers, err := src.GetLayer(context.Background(), spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if mt := layers[0].Descriptor.MediaType; mt != test.Expectation {
+				t.Errorf("unexpected layer media type: %s", mt)
+			}
+
+			_, mt, _, _, err := src.GetBlob(context.Background(), spec, digest.Digest(dgst))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if mt != test.Expectation {
+				t.Errorf("unexpected blob media type: %s", mt)
+			}
+		})
+	}
+}
@@ -0,0 +1,458 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/stargz-snapshotter/estargz"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+	"github.com/gitpod-io/gitpod/registry-facade/api/config"
+)
+
+const (
+	// lazyConversionConcurrency is the number of layers converted at the same time
+	lazyConversionConcurrency = 2
+
+	// lazyConversionBackoff is the time we wait before converting a layer again whose conversion failed
+	lazyConversionBackoff = 1 * time.Hour
+
+	// lazyConversionTimeout is the time a single layer conversion, including its download, may take
+	lazyConversionTimeout = 30 * time.Minute
+)
+
+// LazyLayerSource converts image layers to eStargz, s.t. nodes with a lazy-pulling snapshotter can start
+// containers before all layer content has arrived. Layers are converted in the background the first time
+// they are requested. Until all convertible layers of a manifest are converted, and whenever anything goes wrong,
+// the original layers are served.
+//
+// Converted layers are kept within a disk budget. Once it's exceeded, the least recently used layers are evicted
+// and manifests which referred to them fall back to their original layers.
+//
+// LazyLayerSource is a BlobSource for the converted layers.
+type LazyLayerSource struct {
+	Location     string
+	MinLayerSize int64
+	MaxBytes     int64
+
+	mu        sync.RWMutex
+	layers    map[digest.Digest]*lazyLayer
+	converted map[digest.Digest]*lazyLayer
+	pending   map[digest.Digest]struct{}
+	failed    map[digest.Digest]time.Time
+	size      int64
+	sem       chan struct{}
+
+	conversions *prometheus.CounterVec
+	evictions   prometheus.Counter
+}
+
+// lazyLayer describes the eStargz version of a layer
+type lazyLayer struct {
+	Original  digest.Digest `json:"original"`
+	Digest    digest.Digest `json:"digest"`
+	Size      int64         `json:"size"`
+	DiffID    digest.Digest `json:"diffID"`
+	TOCDigest digest.Digest `json:"tocDigest"`
+
+	lastUsed time.Time
+}
+
+// NewLazyLayerSource creates a new lazy layer source and loads the layers converted previously
+func NewLazyLayerSource(cfg config.LazyPullConfig, reg prometheus.Registerer) (*LazyLayerSource, error) {
+	if cfg.Location == "" {
+		return nil, xerrors.Errorf("lazy pull location must not be empty")
+	}
+	err := os.MkdirAll(cfg.Location, 0755)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create lazy pull location: %w", err)
+	}
+
+	res := &LazyLayerSource{
+		Location:     cfg.Location,
+		MinLayerSize: cfg.MinLayerSize,
+		MaxBytes:     cfg.MaxBytes,
+		layers:       make(map[digest.Digest]*lazyLayer),
+		converted:    make(map[digest.Digest]*lazyLayer),
+		pending:      make(map[digest.Digest]struct{}),
+		failed:       make(map[digest.Digest]time.Time),
+		sem:          make(chan struct{}, lazyConversionConcurrency),
+		conversions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "lazy_layer_conversions_total",
+			Help: "number of layers converted to eStargz",
+		}, []string{"ok"}),
+		evictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "lazy_layer_evictions_total",
+			Help: "number of eStargz layers evicted to stay within the disk budget",
+		}),
+	}
+	for _, c := range []prometheus.Collector{res.conversions, res.evictions} {
+		err = reg.Register(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// conversions which were in progress when we stopped are lost
+	for _, pattern := range []string{"download-*", "convert-*", "*.json.tmp"} {
+		fns, err := filepath.Glob(filepath.Join(cfg.Location, pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, fn := range fns {
+			_ = os.Remove(fn)
+		}
+	}
+
+	fns, err := filepath.Glob(filepath.Join(cfg.Location, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, fn := range fns {
+		fc, err := os.ReadFile(fn)
+		if err != nil {
+			return nil, err
+		}
+		var l lazyLayer
+		err = json.Unmarshal(fc, &l)
+		if err != nil {
+			log.WithError(err).WithField("fn", fn).Warn("ignoring invalid converted layer")
+			continue
+		}
+		stat, err := os.Stat(res.blobPath(l.Digest))
+		if err != nil {
+			log.WithError(err).WithField("fn", fn).Warn("ignoring converted layer without blob")
+			continue
+		}
+		// the modification time keeps track of the last use across restarts
+		l.lastUsed = stat.ModTime()
+		res.layers[l.Original] = &l
+		res.converted[l.Digest] = &l
+		res.size += l.Size
+	}
+	res.enforceBudget()
+	log.WithField("layers", len(res.layers)).WithField("size", res.size).WithField("location", cfg.Location).Info("lazy pulling enabled")
+
+	return res, nil
+}
+
+// Prepare replaces the layers of a manifest with their eStargz version once all of them have been converted, and
+// updates the diff IDs of the image config accordingly. Layers which have not been converted yet are scheduled for
+// conversion, and the manifest is served as it is until then.
+func (s *LazyLayerSource) Prepare(fetcher remotes.Fetcher, mediaType string, manifest *ociv1.Manifest, cfg *ociv1.Image) {
+	for _, l := range manifest.Layers {
+		if !s.convertible(l) {
+			continue
+		}
+		s.mu.RLock()
+		_, converted := s.layers[l.Digest]
+		s.mu.RUnlock()
+		if !converted {
+			s.schedule(fetcher, l)
+		}
+	}
+
+	s.apply(mediaType, manifest, cfg)
+}
+
+// apply replaces the layers of a manifest with their eStargz version and returns true if it did. Layers are replaced
+// only if every convertible layer has been converted, s.t. a manifest has exactly two versions: the original one and the
+// eStargz one. configBlobSource relies on this to reproduce the config of either version.
+// apply never modifies the layers or diff IDs in place, s.t. callers can apply it to shallow copies.
+func (s *LazyLayerSource) apply(mediaType string, manifest *ociv1.Manifest, cfg *ociv1.Image) bool {
+	if len(manifest.Layers) != len(cfg.RootFS.DiffIDs) {
+		// we can't tell which diff ID belongs to which layer
+		return false
+	}
+
+	layerMediaType := ociv1.MediaTypeImageLayerGzip
+	if mediaType == images.MediaTypeDockerSchema2Manifest {
+		layerMediaType = images.MediaTypeDockerSchema2LayerGzip
+	}
+
+	var (
+		layers  = make([]ociv1.Descriptor, len(manifest.Layers))
+		diffIDs = make([]digest.Digest, len(cfg.RootFS.DiffIDs))
+		changed bool
+	)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, l := range manifest.Layers {
+		layers[i], diffIDs[i] = l, cfg.RootFS.DiffIDs[i]
+
+		ll, ok := s.layers[l.Digest]
+		if !ok && s.convertible(l) {
+			return false
+		}
+		if !ok {
+			continue
+		}
+		// clients pull the layers of manifests we serve next - they must not be evicted before that
+		ll.lastUsed = time.Now()
+
+		annotations := make(map[string]string, len(l.Annotations)+1)
+		for k, v := range l.Annotations {
+			annotations[k] = v
+		}
+		annotations[estargz.TOCJSONDigestAnnotation] = ll.TOCDigest.String()
+		layers[i] = ociv1.Descriptor{
+			MediaType:   layerMediaType,
+			Digest:      ll.Digest,
+			Size:        ll.Size,
+			Annotations: annotations,
+		}
+		diffIDs[i] = ll.DiffID
+		changed = true
+	}
+
+	if changed {
+		manifest.Layers = layers
+		cfg.RootFS.DiffIDs = diffIDs
+	}
+	return changed
+}
+
+// convertible returns true if a layer is worth converting
+func (s *LazyLayerSource) convertible(l ociv1.Descriptor) bool {
+	if _, ok := l.Annotations[estargz.TOCJSONDigestAnnotation]; ok {
+		// already eStargz
+		return false
+	}
+	if len(l.URLs) > 0 || l.Size < s.MinLayerSize {
+		return false
+	}
+	if s.MaxBytes > 0 && l.Size > s.MaxBytes {
+		// the eStargz version of a layer is a little larger than the original
+		return false
+	}
+
+	switch l.MediaType {
+	case ociv1.MediaTypeImageLayer, ociv1.MediaTypeImageLayerGzip, ociv1.MediaTypeImageLayerZstd,
+		images.MediaTypeDockerSchema2Layer, images.MediaTypeDockerSchema2LayerGzip:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *LazyLayerSource) schedule(fetcher remotes.Fetcher, l ociv1.Descriptor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.pending[l.Digest]; ok {
+		return
+	}
+	if t, ok := s.failed[l.Digest]; ok && time.Since(t) < lazyConversionBackoff {
+		return
+	}
+	s.pending[l.Digest] = struct{}{}
+
+	go func() {
+		s.sem <- struct{}{}
+		defer func() { <-s.sem }()
+
+		log := log.WithField("digest", l.Digest)
+		t0 := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), lazyConversionTimeout)
+		defer cancel()
+		ll, err := s.convert(ctx, fetcher, l)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.pending, l.Digest)
+		if err != nil {
+			s.failed[l.Digest] = time.Now()
+			s.conversions.WithLabelValues("false").Inc()
+			log.WithError(err).Warn("cannot convert layer to eStargz - serving it as it is")
+			return
+		}
+		delete(s.failed, l.Digest)
+		ll.lastUsed = time.Now()
+		s.layers[ll.Original] = ll
+		s.converted[ll.Digest] = ll
+		s.size += ll.Size
+		s.enforceBudget()
+		s.conversions.WithLabelValues("true").Inc()
+		log.WithField("estargz", ll.Digest).WithField("duration", time.Since(t0).String()).Info("converted layer to eStargz")
+	}()
+}
+
+// convert downloads a layer and converts it to eStargz
+func (s *LazyLayerSource) convert(ctx context.Context, fetcher remotes.Fetcher, l ociv1.Descriptor) (res *lazyLayer, err error) {
+	defer func() {
+		// a layer we cannot convert must not take the registry down with it
+		if r := recover(); r != nil {
+			err = xerrors.Errorf("panic during conversion: %v", r)
+		}
+	}()
+
+	rc, err := fetcher.Fetch(ctx, l)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot fetch layer: %w", err)
+	}
+	defer rc.Close()
+
+	// estargz.Build needs random access to the original layer
+	orig, err := os.CreateTemp(s.Location, "download-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(orig.Name())
+	defer orig.Close()
+
+	verifier := l.Digest.Verifier()
+	n, err := io.Copy(orig, io.TeeReader(rc, verifier))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot download layer: %w", err)
+	}
+	if !verifier.Verified() {
+		return nil, xerrors.Errorf("digest mismatch for layer %s", l.Digest)
+	}
+
+	blob, err := estargz.Build(io.NewSectionReader(orig, 0, n), estargz.WithContext(ctx))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot build eStargz: %w", err)
+	}
+	defer blob.Close()
+
+	out, err := os.CreateTemp(s.Location, "convert-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(out.Name())
+	defer out.Close()
+
+	digester := digest.Canonical.Digester()
+	size, err := io.Copy(out, io.TeeReader(blob, digester.Hash()))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot write eStargz: %w", err)
+	}
+	err = blob.Close()
+	if err != nil {
+		return nil, err
+	}
+	err = out.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	res = &lazyLayer{
+		Original:  l.Digest,
+		Digest:    digester.Digest(),
+		Size:      size,
+		DiffID:    blob.DiffID(),
+		TOCDigest: blob.TOCDigest(),
+	}
+	err = os.Rename(out.Name(), s.blobPath(res.Digest))
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	fn := s.metaPath(l.Digest)
+	err = os.WriteFile(fn+".tmp", meta, 0644)
+	if err != nil {
+		return nil, err
+	}
+	err = os.Rename(fn+".tmp", fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// enforceBudget evicts the least recently used layers until the converted layers fit the disk budget.
+// Callers must hold s.mu.
+func (s *LazyLayerSource) enforceBudget() {
+	if s.MaxBytes <= 0 || s.size <= s.MaxBytes {
+		return
+	}
+
+	lls := make([]*lazyLayer, 0, len(s.layers))
+	for _, ll := range s.layers {
+		lls = append(lls, ll)
+	}
+	sort.Slice(lls, func(i, j int) bool { return lls[i].lastUsed.Before(lls[j].lastUsed) })
+
+	for _, ll := range lls {
+		if s.size <= s.MaxBytes {
+			break
+		}
+		// readers which have the blob open already can continue reading it
+		err := os.Remove(s.blobPath(ll.Digest))
+		if err != nil && !os.IsNotExist(err) {
+			log.WithError(err).WithField("digest", ll.Digest).Warn("cannot evict eStargz layer")
+			continue
+		}
+		_ = os.Remove(s.metaPath(ll.Original))
+		delete(s.layers, ll.Original)
+		delete(s.converted, ll.Digest)
+		s.size -= ll.Size
+		s.evictions.Inc()
+	}
+}
+
+func (s *LazyLayerSource) metaPath(original digest.Digest) string {
+	return filepath.Join(s.Location, original.Encoded()+".json")
+}
+
+func (s *LazyLayerSource) blobPath(dgst digest.Digest) string {
+	return filepath.Join(s.Location, dgst.Encoded()+".estargz")
+}
+
+// Name identifies the blob source in metrics
+func (s *LazyLayerSource) Name() string {
+	return "lazy"
+}
+
+// HasBlob checks if a digest can be served by this blob source
+func (s *LazyLayerSource) HasBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.converted[dgst]
+	return ok
+}
+
+// GetBlob provides access to a converted layer. The returned data supports seeking s.t. lazy pulls can
+// request byte ranges.
+func (s *LazyLayerSource) GetBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) (dontCache bool, mediaType string, url string, data io.ReadCloser, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ll, ok := s.converted[dgst]
+	if !ok {
+		err = errdefs.ErrNotFound
+		return
+	}
+
+	f, err := os.Open(s.blobPath(dgst))
+	if err != nil {
+		return
+	}
+	ll.lastUsed = time.Now()
+	_ = os.Chtimes(f.Name(), ll.lastUsed, ll.lastUsed)
+	return false, ociv1.MediaTypeImageLayerGzip, "", f, nil
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containerd/stargz-snapshotter/estargz"
+	"github.com/google/go-cmp/cmp"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+	"github.com/gitpod-io/gitpod/registry-facade/api/config"
+)
+
+func TestLazyLayerSource(t *testing.T) {
+	var tarball bytes.Buffer
+	tw := tar.NewWriter(&tarball)
+	for name, content := range map[string]string{"foo.txt": "foo", "bar/bar.txt": "bar"} {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = tw.Write([]byte(content))
+	}
+	tw.Close()
+	var layer bytes.Buffer
+	gz := gzip.NewWriter(&layer)
+	_, _ = gz.Write(tarball.Bytes())
+	gz.Close()
+
+	var (
+		layerDigest = digest.FromBytes(layer.Bytes())
+		diffID      = digest.FromBytes(tarball.Bytes())
+		foreign     = ociv1.Descriptor{
+			MediaType: ociv1.MediaTypeImageLayerGzip,
+			Digest:    digest.FromString("foreign"),
+			Size:      7,
+			URLs:      []string{"https://example.com/foreign"},
+		}
+		foreignDiffID = digest.FromString("foreign-diff")
+	)
+	fetcher := &fakeFetcher{Content: map[string][]byte{layerDigest.Encoded(): layer.Bytes()}}
+	newManifest := func() (*ociv1.Manifest, *ociv1.Image) {
+		return &ociv1.Manifest{
+			Layers: []ociv1.Descriptor{
+				{MediaType: ociv1.MediaTypeImageLayerGzip, Digest: layerDigest, Size: int64(layer.Len())},
+				foreign,
+			},
+		}, &ociv1.Image{
+			RootFS: ociv1.RootFS{Type: "layers", DiffIDs: []digest.Digest{diffID, foreignDiffID}},
+		}
+	}
+
+	loc := t.TempDir()
+	src, err := NewLazyLayerSource(config.LazyPullConfig{Location: loc}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first request serves the original layers and starts the conversion
+	mf, cfg := newManifest()
+	src.Prepare(fetcher, ociv1.MediaTypeImageManifest, mf, cfg)
+	expMF, expCfg := newManifest()
+	if diff := cmp.Diff(expMF, mf); diff != "" {
+		t.Errorf("unexpected manifest before conversion (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expCfg, cfg); diff != "" {
+		t.Errorf("unexpected config before conversion (-want +got):\n%s", diff)
+	}
+	waitForConversions(t, src)
+
+	mf, cfg = newManifest()
+	src.Prepare(fetcher, ociv1.MediaTypeImageManifest, mf, cfg)
+	lazy := mf.Layers[0]
+	if lazy.Digest == layerDigest {
+		t.Fatal("expected layer to be replaced after conversion")
+	}
+	if lazy.Annotations[estargz.TOCJSONDigestAnnotation] == "" {
+		t.Error("converted layer has no TOC digest annotation")
+	}
+	if diff := cmp.Diff(foreign, mf.Layers[1]); diff != "" {
+		t.Errorf("foreign layer must not be converted (-want +got):\n%s", diff)
+	}
+	if cfg.RootFS.DiffIDs[0] == diffID || cfg.RootFS.DiffIDs[1] != foreignDiffID {
+		t.Errorf("unexpected diff IDs after conversion: %v", cfg.RootFS.DiffIDs)
+	}
+
+	// the converted layer is a valid eStargz blob matching the manifest and config
+	if !src.HasBlob(context.Background(), nil, lazy.Digest) {
+		t.Fatal("expected converted layer to be available")
+	}
+	_, _, _, rc, err := src.GetBlob(context.Background(), nil, lazy.Digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act := digest.FromBytes(blob); act != lazy.Digest || int64(len(blob)) != lazy.Size {
+		t.Errorf("converted layer does not match its descriptor: got %s (%d bytes)", act, len(blob))
+	}
+	r, err := estargz.Open(io.NewSectionReader(bytes.NewReader(blob), 0, int64(len(blob))))
+	if err != nil {
+		t.Fatalf("converted layer is no eStargz: %v", err)
+	}
+	if _, ok := r.Lookup("bar/bar.txt"); !ok {
+		t.Error("converted layer misses content")
+	}
+	if diff := cmp.Diff(lazy.Annotations[estargz.TOCJSONDigestAnnotation], r.TOCDigest().String()); diff != "" {
+		t.Errorf("unexpected TOC digest (-want +got):\n%s", diff)
+	}
+	uncompressed, err := gzip.NewReader(bytes.NewReader(blob))
+	if err != nil {
+		t.Fatal(err)
+	}
+	actDiffID, err := digest.FromReader(uncompressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(cfg.RootFS.DiffIDs[0], actDiffID); diff != "" {
+		t.Errorf("unexpected diff ID (-want +got):\n%s", diff)
+	}
+
+	// converted layers survive a restart
+	restarted, err := NewLazyLayerSource(config.LazyPullConfig{Location: loc}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !restarted.HasBlob(context.Background(), nil, lazy.Digest) {
+		t.Error("expected converted layer to be loaded after restart")
+	}
+
+	// the config blob can be reproduced for the original as well as the converted layers
+	addon := digest.FromString("addon")
+	rawCfg, err := json.Marshal(&ociv1.Image{RootFS: ociv1.RootFS{Type: "layers", DiffIDs: []digest.Digest{diffID, foreignDiffID}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	origMF, _ := newManifest()
+	origMF.Config = ociv1.Descriptor{MediaType: ociv1.MediaTypeImageConfig, Digest: digest.FromBytes(rawCfg), Size: int64(len(rawCfg))}
+	fetcher.Content[origMF.Config.Digest.Encoded()] = rawCfg
+	cfgSrc := &configBlobSource{
+		Fetcher:  fetcher,
+		Manifest: origMF,
+		ConfigModifier: func(ctx context.Context, spec *api.ImageSpec, cfg *ociv1.Image) ([]ociv1.Descriptor, error) {
+			cfg.RootFS.DiffIDs = append(cfg.RootFS.DiffIDs, addon)
+			return nil, nil
+		},
+		LazyLayers: src,
+	}
+	for name, diffIDs := range map[string][]digest.Digest{
+		"original": {diffID, foreignDiffID, addon},
+		"eStargz":  {cfg.RootFS.DiffIDs[0], foreignDiffID, addon},
+	} {
+		raw, err := json.Marshal(&ociv1.Image{RootFS: ociv1.RootFS{Type: "layers", DiffIDs: diffIDs}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cfgSrc.HasBlob(context.Background(), nil, digest.FromBytes(raw)) {
+			t.Errorf("cannot reproduce the config for the %s layers", name)
+		}
+	}
+
+	// converted layers beyond the disk budget are evicted, and manifests fall back to the original layers
+	budgeted, err := NewLazyLayerSource(config.LazyPullConfig{Location: loc, MaxBytes: int64(layer.Len())}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if budgeted.HasBlob(context.Background(), nil, lazy.Digest) {
+		t.Error("expected converted layer to be evicted")
+	}
+	if fns, _ := filepath.Glob(filepath.Join(loc, "*")); len(fns) != 0 {
+		t.Errorf("evicted layer left files behind: %v", fns)
+	}
+	budgeted.mu.Lock()
+	budgeted.pending[layerDigest] = struct{}{}
+	budgeted.mu.Unlock()
+	mf, cfg = newManifest()
+	budgeted.Prepare(fetcher, ociv1.MediaTypeImageManifest, mf, cfg)
+	if diff := cmp.Diff(expMF, mf); diff != "" {
+		t.Errorf("unexpected manifest after eviction (-want +got):\n%s", diff)
+	}
+}
+
+// waitForConversions waits until no layer is being converted anymore
+func waitForConversions(t *testing.T, src *LazyLayerSource) {
+	for i := 0; i < 100; i++ {
+		src.mu.RLock()
+		pending := len(src.pending)
+		src.mu.RUnlock()
+		if pending == 0 {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for layer conversion")
+}
@@ -18,10 +18,14 @@ import (
 	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
 	"github.com/containerd/containerd/remotes"
+	"github.com/docker/distribution/registry/api/errcode"
 	distv2 "github.com/docker/distribution/registry/api/v2"
 	"github.com/gorilla/handlers"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
 	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
@@ -70,6 +74,9 @@ func (reg *Registry) handleManifest(ctx context.Context, r *http.Request) http.H
 		Resolver:       reg.Resolver(),
 		Store:          reg.Store,
 		ConfigModifier: reg.ConfigModifier,
+		LazyLayers:     reg.LazyLayers,
+		Signatures:     reg.Signatures,
+		Composed:       reg.composed,
 	}
 	reference := getReference(ctx)
 	dgst, err := digest.Parse(reference)
@@ -79,6 +86,19 @@ func (reg *Registry) handleManifest(ctx context.Context, r *http.Request) http.H
 		manifestHandler.Digest = dgst
 	}
 
+	// containerd resolves the tag before it fetches the manifest by digest - we count each pull only once
+	if reg.Popularity != nil && manifestHandler.Tag != "" {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			err := reg.Popularity.Record(ctx, popularRefs(spec)...)
+			if err != nil {
+				log.WithError(err).WithField("name", name).Warn("cannot record image popularity")
+			}
+		}()
+	}
+
 	mhandler := handlers.MethodHandler{
 		"GET":    http.HandlerFunc(manifestHandler.getManifest),
 		"HEAD":   http.HandlerFunc(manifestHandler.getManifest),
@@ -102,6 +122,9 @@ type manifestHandler struct {
 	Resolver       remotes.Resolver
 	Store          BlobStore
 	ConfigModifier ConfigModifier
+	LazyLayers     *LazyLayerSource
+	Signatures     *SignatureVerifier
+	Composed       *lru.Cache
 
 	Name   string
 	Tag    string
@@ -119,8 +142,9 @@ func (mh *manifestHandler) getManifest(w http.ResponseWriter, r *http.Request) {
 		tracing.LogMessageSafe(span, "spec", mh.Spec)
 
 		var (
-			acceptType string
-			err        error
+			acceptType  string
+			acceptIndex bool
+			err         error
 		)
 		for _, acceptHeader := range r.Header["Accept"] {
 			for _, mediaType := range strings.Split(acceptHeader, ",") {
@@ -128,23 +152,28 @@ func (mh *manifestHandler) getManifest(w http.ResponseWriter, r *http.Request) {
 					continue
 				}
 
-				if mediaType == ociv1.MediaTypeImageManifest ||
-					mediaType == images.MediaTypeDockerSchema2Manifest ||
-					mediaType == "*" {
-
+				switch mediaType {
+				case ociv1.MediaTypeImageManifest, images.MediaTypeDockerSchema2Manifest:
 					acceptType = ociv1.MediaTypeImageManifest
-					break
+				case ociv1.MediaTypeImageIndex, images.MediaTypeDockerSchema2ManifestList:
+					acceptIndex = true
+				case "*":
+					acceptType = ociv1.MediaTypeImageManifest
+					acceptIndex = true
 				}
 			}
-			if acceptType != "" {
-				break
-			}
 		}
 		if acceptType == "" {
 			return distv2.ErrorCodeManifestUnknown.WithMessage("Accept header does not include OCIv1 or v2 manifests")
 		}
 
-		// Note: we ignore the mh.Digest for now because we always return a manifest, never a manifest index.
+		// Clients which received an index fetch its manifests by digest next
+		if res, ok := mh.getComposed(mh.Digest); ok {
+			mh.serve(w, res)
+			log.WithFields(logFields).Debug("get manifest (end)")
+			return nil
+		}
+
 		ref := mh.Spec.BaseRef
 
 		_, desc, err := mh.Resolver.Resolve(ctx, ref)
@@ -154,6 +183,14 @@ func (mh *manifestHandler) getManifest(w http.ResponseWriter, r *http.Request) {
 			return err
 		}
 
+		// signatures refer to what the reference resolves to, i.e. the index of multi-platform images
+		if mh.Signatures != nil {
+			err = mh.Signatures.Verify(ctx, mh.Resolver, ref, desc)
+			if err != nil {
+				return errcode.ErrorCodeDenied.WithMessage(err.Error())
+			}
+		}
+
 		var fcache remotes.Fetcher
 		fetch := func() (remotes.Fetcher, error) {
 			if fcache != nil {
@@ -168,86 +205,33 @@ func (mh *manifestHandler) getManifest(w http.ResponseWriter, r *http.Request) {
 			return fcache, nil
 		}
 
-		manifest, ndesc, err := DownloadManifest(ctx, fetch, desc, WithStore(mh.Store))
-		if err != nil {
-			log.WithError(err).WithField("desc", desc).WithFields(logFields).WithField("ref", ref).Error("cannot download manifest")
-			return distv2.ErrorCodeManifestUnknown.WithDetail(err)
-		}
-		desc = *ndesc
-
-		var p []byte
-		switch desc.MediaType {
-		case images.MediaTypeDockerSchema2Manifest, ociv1.MediaTypeImageManifest:
-			// download config
-			cfg, err := DownloadConfig(ctx, fetch, ref, manifest.Config, WithStore(mh.Store))
+		var index *ociv1.Index
+		if acceptIndex {
+			index, err = DownloadIndex(ctx, fetch, desc, WithStore(mh.Store))
 			if err != nil {
-				log.WithError(err).WithFields(logFields).Error("cannot download config")
-				return err
-			}
-
-			// modify config
-			addonLayer, err := mh.ConfigModifier(ctx, mh.Spec, cfg)
-			if err != nil {
-				log.WithError(err).WithFields(logFields).Error("cannot modify config")
-				return err
-			}
-			manifest.Layers = append(manifest.Layers, addonLayer...)
-
-			// place config in store
-			rawCfg, err := json.Marshal(cfg)
-			if err != nil {
-				log.WithError(err).WithFields(logFields).Error("cannot marshal config")
-				return err
-			}
-			cfgDgst := digest.FromBytes(rawCfg)
-
-			// update config digest in manifest
-			manifest.Config.Digest = cfgDgst
-			manifest.Config.URLs = nil
-			manifest.Config.Size = int64(len(rawCfg))
-
-			// optimization: we store the config in the store just in case the client attempts to download the config blob
-			// 				 from us. If they download it from a registry facade from which the manifest hasn't been downloaded
-			//               we'll re-create the config on the fly.
-			if w, err := mh.Store.Writer(ctx, content.WithRef(ref), content.WithDescriptor(manifest.Config)); err == nil {
-				defer w.Close()
-
-				_, err = w.Write(rawCfg)
-				if err != nil {
-					log.WithError(err).WithFields(logFields).Warn("cannot write config to store - we'll regenerate it on demand")
-				}
-				err = w.Commit(ctx, 0, cfgDgst, content.WithLabels(contentTypeLabel(manifest.Config.MediaType)))
-				if err != nil {
-					log.WithError(err).WithFields(logFields).Warn("cannot commit config to store - we'll regenerate it on demand")
-				}
-			}
-
-			// When serving images.MediaTypeDockerSchema2Manifest we have to set the mediaType in the manifest itself.
-			// Although somewhat compatible with the OCI manifest spec (see https://github.com/opencontainers/image-spec/blob/master/manifest.md),
-			// this field is not part of the OCI Go structs. In this particular case, we'll go ahead and add it ourselves.
-			//
-			// fixes https://github.com/gitpod-io/gitpod/pull/3397
-			if desc.MediaType == images.MediaTypeDockerSchema2Manifest {
-				type ManifestWithMediaType struct {
-					ociv1.Manifest
-					MediaType string `json:"mediaType"`
-				}
-				p, _ = json.Marshal(ManifestWithMediaType{
-					Manifest:  *manifest,
-					MediaType: images.MediaTypeDockerSchema2Manifest,
-				})
-			} else {
-				p, _ = json.Marshal(manifest)
+				log.WithError(err).WithField("desc", desc).WithFields(logFields).WithField("ref", ref).Error("cannot download index")
+				return distv2.ErrorCodeManifestUnknown.WithDetail(err)
 			}
 		}
 
-		dgst := digest.FromBytes(p).String()
+		var res *composedManifest
+		if index != nil {
+			res, err = mh.composeIndex(withPlatform(ctx, requestedPlatform(r)), fetch, ref, index)
+		} else {
+			res, err = mh.composeManifest(withPlatform(ctx, requestedPlatform(r)), fetch, ref, desc, true)
+		}
+		if err != nil {
+			return err
+		}
 
-		w.Header().Set("Content-Type", desc.MediaType)
-		w.Header().Set("Content-Length", fmt.Sprint(len(p)))
-		w.Header().Set("Etag", fmt.Sprintf(`"%s"`, dgst))
-		w.Header().Set("Docker-Content-Digest", dgst)
-		_, _ = w.Write(p)
+		// Note: clients asking for a digest we don't know get what the tag points to, as we used to.
+		//       Composed manifests are kept in memory only, i.e. other replicas and this one after a restart
+		//       don't know them. They recompose what the tag points to instead, which yields the same digests
+		//       unless the base image, the addon layers or the eStargz layers we serve changed in the meantime.
+		if c, ok := mh.getComposed(mh.Digest); ok {
+			res = c
+		}
+		mh.serve(w, res)
 
 		log.WithFields(logFields).Debug("get manifest (end)")
 		return nil
@@ -260,6 +244,188 @@ func (mh *manifestHandler) getManifest(w http.ResponseWriter, r *http.Request) {
 	tracing.FinishSpan(span, &err)
 }
 
+// composedManifest is a manifest or index we produced from the base image and the addon layers
+type composedManifest struct {
+	MediaType string
+	Content   []byte
+}
+
+func (c *composedManifest) digest() digest.Digest {
+	return digest.FromBytes(c.Content)
+}
+
+func (mh *manifestHandler) composedKey(dgst digest.Digest) string {
+	return mh.Name + "@" + dgst.String()
+}
+
+func (mh *manifestHandler) addComposed(c *composedManifest) {
+	if mh.Composed == nil {
+		return
+	}
+	mh.Composed.Add(mh.composedKey(c.digest()), c)
+}
+
+func (mh *manifestHandler) getComposed(dgst digest.Digest) (*composedManifest, bool) {
+	if mh.Composed == nil || dgst == "" {
+		return nil, false
+	}
+	c, ok := mh.Composed.Get(mh.composedKey(dgst))
+	if !ok {
+		return nil, false
+	}
+	return c.(*composedManifest), true
+}
+
+func (mh *manifestHandler) serve(w http.ResponseWriter, c *composedManifest) {
+	dgst := c.digest().String()
+
+	w.Header().Set("Content-Type", c.MediaType)
+	w.Header().Set("Content-Length", fmt.Sprint(len(c.Content)))
+	w.Header().Set("Etag", fmt.Sprintf(`"%s"`, dgst))
+	w.Header().Set("Docker-Content-Digest", dgst)
+	_, _ = w.Write(c.Content)
+}
+
+// composeIndex composes a manifest for each platform of a multi-platform base image.
+// Platforms we cannot compose a manifest for, e.g. because an IDE image isn't available for them, are left out.
+//
+// Only the manifest for the platform of ctx is what the client is going to pull. The index has to name the digests
+// of all other manifests too, but we compose them from their original layers, i.e. we don't convert their layers to eStargz.
+func (mh *manifestHandler) composeIndex(ctx context.Context, fetch FetcherFunc, ref string, index *ociv1.Index) (*composedManifest, error) {
+	res := ociv1.Index{
+		Versioned:   specs.Versioned{SchemaVersion: 2},
+		MediaType:   index.MediaType,
+		Annotations: index.Annotations,
+	}
+	// the index might not have a manifest for the platform of ctx, in which case none is pulled lazily
+	requested, _ := selectManifest(index, platformFromContext(ctx))
+	for _, md := range index.Manifests {
+		if md.Platform == nil || md.Platform.OS == "unknown" || md.Platform.Architecture == "unknown" {
+			// attestations and other artifacts don't refer to the base image we compose
+			continue
+		}
+
+		mf, err := mh.composeManifest(withPlatform(ctx, *md.Platform), fetch, ref, md, md.Digest == requested.Digest)
+		if err != nil {
+			log.WithError(err).WithField("ref", ref).WithField("platform", platforms.Format(*md.Platform)).Warn("cannot compose manifest for platform - leaving it out of the index")
+			continue
+		}
+		res.Manifests = append(res.Manifests, ociv1.Descriptor{
+			MediaType: mf.MediaType,
+			Digest:    mf.digest(),
+			Size:      int64(len(mf.Content)),
+			Platform:  md.Platform,
+		})
+	}
+	if len(res.Manifests) == 0 {
+		return nil, distv2.ErrorCodeManifestUnknown.WithMessage("cannot compose a manifest for any platform of " + ref)
+	}
+
+	p, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	c := &composedManifest{MediaType: index.MediaType, Content: p}
+	mh.addComposed(c)
+	return c, nil
+}
+
+// composeManifest adds the addon layers to the base image manifest desc points to. If desc points to an index
+// we use the manifest for the platform of ctx. With lazy, the manifest refers to the eStargz version of its layers
+// once they are converted.
+func (mh *manifestHandler) composeManifest(ctx context.Context, fetch FetcherFunc, ref string, desc ociv1.Descriptor, lazy bool) (*composedManifest, error) {
+	logFields := log.OWI("", "", mh.Name)
+	logFields["ref"] = ref
+	logFields["platform"] = platforms.Format(platformFromContext(ctx))
+
+	manifest, ndesc, err := DownloadManifest(ctx, fetch, desc, WithStore(mh.Store))
+	if err != nil {
+		log.WithError(err).WithField("desc", desc).WithFields(logFields).Error("cannot download manifest")
+		return nil, distv2.ErrorCodeManifestUnknown.WithDetail(err)
+	}
+	desc = *ndesc
+
+	var p []byte
+	switch desc.MediaType {
+	case images.MediaTypeDockerSchema2Manifest, ociv1.MediaTypeImageManifest:
+		// download config
+		cfg, err := DownloadConfig(ctx, fetch, ref, manifest.Config, WithStore(mh.Store))
+		if err != nil {
+			log.WithError(err).WithFields(logFields).Error("cannot download config")
+			return nil, err
+		}
+
+		// serve the eStargz version of the layers where we have them
+		if mh.LazyLayers != nil && lazy {
+			fetcher, err := fetch()
+			if err != nil {
+				log.WithError(err).WithFields(logFields).Warn("cannot get fetcher - not converting layers to eStargz")
+			} else {
+				mh.LazyLayers.Prepare(fetcher, desc.MediaType, manifest, cfg)
+			}
+		}
+
+		// modify config
+		addonLayer, err := mh.ConfigModifier(ctx, mh.Spec, cfg)
+		if err != nil {
+			log.WithError(err).WithFields(logFields).Error("cannot modify config")
+			return nil, err
+		}
+		manifest.Layers = append(manifest.Layers, addonLayer...)
+
+		// place config in store
+		rawCfg, err := json.Marshal(cfg)
+		if err != nil {
+			log.WithError(err).WithFields(logFields).Error("cannot marshal config")
+			return nil, err
+		}
+		cfgDgst := digest.FromBytes(rawCfg)
+
+		// update config digest in manifest
+		manifest.Config.Digest = cfgDgst
+		manifest.Config.URLs = nil
+		manifest.Config.Size = int64(len(rawCfg))
+
+		// optimization: we store the config in the store just in case the client attempts to download the config blob
+		// 				 from us. If they download it from a registry facade from which the manifest hasn't been downloaded
+		//               we'll re-create the config on the fly.
+		if w, err := mh.Store.Writer(ctx, content.WithRef(ref), content.WithDescriptor(manifest.Config)); err == nil {
+			defer w.Close()
+
+			_, err = w.Write(rawCfg)
+			if err != nil {
+				log.WithError(err).WithFields(logFields).Warn("cannot write config to store - we'll regenerate it on demand")
+			}
+			err = w.Commit(ctx, 0, cfgDgst, content.WithLabels(contentTypeLabel(manifest.Config.MediaType)))
+			if err != nil {
+				log.WithError(err).WithFields(logFields).Warn("cannot commit config to store - we'll regenerate it on demand")
+			}
+		}
+
+		// When serving images.MediaTypeDockerSchema2Manifest we have to set the mediaType in the manifest itself.
+		// Although somewhat compatible with the OCI manifest spec (see https://github.com/opencontainers/image-spec/blob/master/manifest.md),
+		// this field is not part of the OCI Go structs. In this particular case, we'll go ahead and add it ourselves.
+		//
+		// fixes https://github.com/gitpod-io/gitpod/pull/3397
+		if desc.MediaType == images.MediaTypeDockerSchema2Manifest {
+			type ManifestWithMediaType struct {
+				ociv1.Manifest
+				MediaType string `json:"mediaType"`
+			}
+			p, _ = json.Marshal(ManifestWithMediaType{
+				Manifest:  *manifest,
+				MediaType: images.MediaTypeDockerSchema2Manifest,
+			})
+		} else {
+			p, _ = json.Marshal(manifest)
+		}
+	}
+
+	res := &composedManifest{MediaType: desc.MediaType, Content: p}
+	mh.addComposed(res)
+	return res, nil
+}
+
 // DownloadConfig downloads and unmarshales OCIv2 image config, referred to by an OCI descriptor.
 func DownloadConfig(ctx context.Context, fetch FetcherFunc, ref string, desc ociv1.Descriptor, options ...ManifestDownloadOption) (cfg *ociv1.Image, err error) {
 	if desc.MediaType != images.MediaTypeDockerSchema2Config &&
@@ -374,70 +540,15 @@ func AsFetcherFunc(f remotes.Fetcher) FetcherFunc {
 }
 
 // DownloadManifest downloads and unmarshals the manifest of the given desc. If the desc points to manifest list
-// we choose the first manifest in that list.
+// we choose the manifest for the platform of ctx (see withPlatform).
 func DownloadManifest(ctx context.Context, fetch FetcherFunc, desc ociv1.Descriptor, options ...ManifestDownloadOption) (cfg *ociv1.Manifest, rdesc *ociv1.Descriptor, err error) {
 	var opts manifestDownloadOptions
 	for _, o := range options {
 		o(&opts)
 	}
 
-	var (
-		placeInStore bool
-		rc           io.ReadCloser
-		mediaType    = desc.MediaType
-	)
-	if opts.Store != nil {
-		func() {
-			nfo, err := opts.Store.Info(ctx, desc.Digest)
-			if errors.Is(err, errdefs.ErrNotFound) {
-				// not in store yet
-				return
-			}
-			if err != nil {
-				log.WithError(err).WithField("desc", desc).Warn("cannot get manifest from store")
-				return
-			}
-			if nfo.Labels["Content-Type"] == "" {
-				// we have broken data in the store - ignore it and overwrite
-				return
-			}
-
-			r, err := opts.Store.ReaderAt(ctx, desc)
-			if errors.Is(err, errdefs.ErrNotFound) {
-				// not in store yet
-				return
-			}
-			if err != nil {
-				log.WithError(err).WithField("desc", desc).Warn("cannot get manifest from store")
-				return
-			}
-
-			mediaType, rc = nfo.Labels["Content-Type"], &reader{ReaderAt: r}
-		}()
-	}
-	if rc == nil {
-		// did not find in store, or there was no store. Either way, let's fetch this
-		// thing from the remote.
-		placeInStore = true
-
-		var fetcher remotes.Fetcher
-		fetcher, err = fetch()
-		if err != nil {
-			return
-		}
-
-		rc, err = fetcher.Fetch(ctx, desc)
-		if err != nil {
-			err = xerrors.Errorf("cannot fetch manifest: %w", err)
-			return
-		}
-		mediaType = desc.MediaType
-	}
-
-	inpt, err := io.ReadAll(rc)
-	rc.Close()
+	mediaType, inpt, err := downloadManifestBlob(ctx, fetch, desc, opts)
 	if err != nil {
-		err = xerrors.Errorf("cannot download manifest: %w", err)
 		return
 	}
 
@@ -448,8 +559,8 @@ func DownloadManifest(ctx context.Context, fetch FetcherFunc, desc ociv1.Descrip
 	case images.MediaTypeDockerSchema2ManifestList, ociv1.MediaTypeImageIndex:
 		log.WithField("desc", rdesc).Debug("resolving image index")
 
-		// we received a manifest list which means we'll pick the default platform
-		// and fetch that manifest
+		// we received a manifest list which means we'll pick the manifest of the platform
+		// we're asked for and fetch that manifest
 		var list ociv1.Index
 		err = json.Unmarshal(inpt, &list)
 		if err != nil {
@@ -461,26 +572,17 @@ func DownloadManifest(ctx context.Context, fetch FetcherFunc, desc ociv1.Descrip
 			return
 		}
 
-		var fetcher remotes.Fetcher
-		fetcher, err = fetch()
+		var md ociv1.Descriptor
+		md, err = selectManifest(&list, platformFromContext(ctx))
 		if err != nil {
 			return
 		}
-
-		// TODO(cw): choose by platform, not just the first manifest
-		md := list.Manifests[0]
-		rc, err = fetcher.Fetch(ctx, md)
+		mediaType, inpt, err = downloadManifestBlob(ctx, fetch, md, opts)
 		if err != nil {
-			err = xerrors.Errorf("cannot download config: %w", err)
 			return
 		}
 		rdesc = &md
-		inpt, err = io.ReadAll(rc)
-		rc.Close()
-		if err != nil {
-			err = xerrors.Errorf("cannot download manifest: %w", err)
-			return
-		}
+		rdesc.MediaType = mediaType
 	}
 
 	switch rdesc.MediaType {
@@ -497,31 +599,132 @@ func DownloadManifest(ctx context.Context, fetch FetcherFunc, desc ociv1.Descrip
 		return
 	}
 
-	if opts.Store != nil && placeInStore {
-		// We're cheating here and store the actual image manifest under the desc of what's
-		// possibly an image index. This way we don't have to resolve the image index the next
-		// time one wishes to resolve desc.
+	cfg = &res
+	return
+}
+
+// DownloadIndex downloads and unmarshals the image index of the given desc. If the desc points to
+// a manifest rather than an index, DownloadIndex returns nil.
+func DownloadIndex(ctx context.Context, fetch FetcherFunc, desc ociv1.Descriptor, options ...ManifestDownloadOption) (*ociv1.Index, error) {
+	switch desc.MediaType {
+	case images.MediaTypeDockerSchema2Manifest, ociv1.MediaTypeImageManifest:
+		return nil, nil
+	}
+
+	var opts manifestDownloadOptions
+	for _, o := range options {
+		o(&opts)
+	}
+
+	mediaType, inpt, err := downloadManifestBlob(ctx, fetch, desc, opts)
+	if err != nil {
+		return nil, err
+	}
+	switch mediaType {
+	case images.MediaTypeDockerSchema2ManifestList, ociv1.MediaTypeImageIndex:
+	default:
+		return nil, nil
+	}
+
+	var res ociv1.Index
+	err = json.Unmarshal(inpt, &res)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal index: %w", err)
+	}
+	res.MediaType = mediaType
+	return &res, nil
+}
+
+// downloadManifestBlob downloads the manifest or index desc points to, using the store if there is one.
+func downloadManifestBlob(ctx context.Context, fetch FetcherFunc, desc ociv1.Descriptor, opts manifestDownloadOptions) (mediaType string, inpt []byte, err error) {
+	if opts.Store != nil {
+		mediaType, inpt = readManifestFromStore(ctx, opts.Store, desc)
+		if inpt != nil {
+			return mediaType, inpt, nil
+		}
+	}
+
+	// did not find in store, or there was no store. Either way, let's fetch this
+	// thing from the remote.
+	fetcher, err := fetch()
+	if err != nil {
+		return
+	}
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		err = xerrors.Errorf("cannot fetch manifest: %w", err)
+		return
+	}
+	inpt, err = io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		err = xerrors.Errorf("cannot download manifest: %w", err)
+		return
+	}
+	mediaType = desc.MediaType
+
+	if opts.Store != nil && digest.FromBytes(inpt) == desc.Digest {
 		w, err := opts.Store.Writer(ctx, content.WithDescriptor(desc), content.WithRef(desc.Digest.String()))
 		if err != nil {
-			if err != nil && !strings.Contains(err.Error(), "already exists") {
-				log.WithError(err).WithField("desc", *rdesc).Warn("cannot create store writer")
+			if !strings.Contains(err.Error(), "already exists") {
+				log.WithError(err).WithField("desc", desc).Warn("cannot create store writer")
 			}
 		} else {
 			_, err = io.Copy(w, bytes.NewReader(inpt))
 			if err != nil {
-				log.WithError(err).WithField("desc", *rdesc).Warn("cannot copy manifest")
+				log.WithError(err).WithField("desc", desc).Warn("cannot copy manifest")
 			}
 
-			err = w.Commit(ctx, 0, digest.FromBytes(inpt), content.WithLabels(map[string]string{"Content-Type": rdesc.MediaType}))
+			err = w.Commit(ctx, 0, desc.Digest, content.WithLabels(contentTypeLabel(mediaType)))
 			if err != nil {
-				log.WithError(err).WithField("desc", *rdesc).Warn("cannot store manifest")
+				log.WithError(err).WithField("desc", desc).Warn("cannot store manifest")
 			}
 			w.Close()
 		}
 	}
 
-	cfg = &res
-	return
+	return mediaType, inpt, nil
+}
+
+// readManifestFromStore returns the manifest or index desc points to if it's in the store, nil otherwise.
+func readManifestFromStore(ctx context.Context, store BlobStore, desc ociv1.Descriptor) (mediaType string, inpt []byte) {
+	nfo, err := store.Info(ctx, desc.Digest)
+	if errors.Is(err, errdefs.ErrNotFound) {
+		// not in store yet
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("desc", desc).Warn("cannot get manifest from store")
+		return
+	}
+	if nfo.Labels["Content-Type"] == "" {
+		// we have broken data in the store - ignore it and overwrite
+		return
+	}
+
+	r, err := store.ReaderAt(ctx, desc)
+	if errors.Is(err, errdefs.ErrNotFound) {
+		// not in store yet
+		return
+	}
+	if err != nil {
+		log.WithError(err).WithField("desc", desc).Warn("cannot get manifest from store")
+		return
+	}
+	defer r.Close()
+
+	res, err := io.ReadAll(&reader{ReaderAt: r})
+	if err != nil {
+		log.WithError(err).WithField("desc", desc).Warn("cannot read manifest from store")
+		return
+	}
+	if digest.FromBytes(res) != desc.Digest {
+		// Older versions stored the first manifest of an image index under the digest of the index.
+		// That's the wrong manifest for all other platforms - ignore it and overwrite.
+		return
+	}
+
+	return nfo.Labels["Content-Type"], res
 }
 
 func (mh *manifestHandler) putManifest(w http.ResponseWriter, r *http.Request) {
@@ -8,13 +8,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/google/go-cmp/cmp"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/opencontainers/go-digest"
 	"github.com/opencontainers/image-spec/specs-go"
 	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+	"github.com/gitpod-io/gitpod/registry-facade/api/config"
 )
 
 func TestDownloadManifest(t *testing.T) {
@@ -105,6 +115,270 @@ func TestDownloadManifest(t *testing.T) {
 	}
 }
 
+func TestDownloadManifestForPlatform(t *testing.T) {
+	f := &fakeFetcher{Content: make(map[string][]byte)}
+	img := addMultiPlatformImage(t, f, "base:latest", ociv1.MediaTypeImageIndex, "linux/amd64", "linux/arm64")
+
+	tests := []struct {
+		Name          string
+		Platform      string
+		Expectation   digest.Digest
+		ExpectedError string
+	}{
+		{Name: "amd64", Platform: "linux/amd64", Expectation: img.Manifests["linux/amd64"]},
+		{Name: "arm64", Platform: "linux/arm64", Expectation: img.Manifests["linux/arm64"]},
+		{Name: "arm64 with variant", Platform: "linux/arm64/v8", Expectation: img.Manifests["linux/arm64"]},
+		{Name: "unavailable platform", Platform: "windows/amd64", ExpectedError: "no manifest for platform windows/amd64"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx := withPlatform(context.Background(), platforms.MustParse(test.Platform))
+			_, desc, err := DownloadManifest(ctx, AsFetcherFunc(f), img.Desc)
+			if test.ExpectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), test.ExpectedError) {
+					t.Fatalf("expected error containing %q, got %v", test.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, desc.Digest); diff != "" {
+				t.Errorf("unexpected manifest (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetManifest(t *testing.T) {
+	const (
+		dockerUA = "docker/20.10.21 go/go1.18.7 git-commit/3056208 kernel/5.15.0 os/linux arch/arm64"
+		accept   = ociv1.MediaTypeImageManifest + ", " + images.MediaTypeDockerSchema2Manifest
+	)
+	acceptIndex := accept + ", " + ociv1.MediaTypeImageIndex + ", " + images.MediaTypeDockerSchema2ManifestList
+
+	type Expectation struct {
+		MediaType string
+		Platforms []string
+		Layer     string
+	}
+	tests := []struct {
+		Name           string
+		IndexMediaType string
+		Accept         string
+		UserAgent      string
+		Expectation    Expectation
+	}{
+		{
+			Name:           "OCI index",
+			IndexMediaType: ociv1.MediaTypeImageIndex,
+			Accept:         acceptIndex,
+			Expectation:    Expectation{MediaType: ociv1.MediaTypeImageIndex, Platforms: []string{"linux/amd64", "linux/arm64"}},
+		},
+		{
+			Name:           "Docker manifest list",
+			IndexMediaType: images.MediaTypeDockerSchema2ManifestList,
+			Accept:         acceptIndex,
+			Expectation:    Expectation{MediaType: images.MediaTypeDockerSchema2ManifestList, Platforms: []string{"linux/amd64", "linux/arm64"}},
+		},
+		{
+			Name:           "client does not accept indexes",
+			IndexMediaType: ociv1.MediaTypeImageIndex,
+			Accept:         accept,
+			UserAgent:      dockerUA,
+			Expectation:    Expectation{MediaType: ociv1.MediaTypeImageManifest, Layer: "ide-linux/arm64"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			f := &fakeFetcher{Content: make(map[string][]byte)}
+			addMultiPlatformImage(t, f, "base:latest", test.IndexMediaType, "linux/amd64", "linux/arm64", "unknown/unknown")
+
+			// the IDE layer is different for each platform
+			var ide CompositeLayerSource
+			for _, p := range []string{"linux/amd64", "linux/arm64"} {
+				plf := platforms.Normalize(platforms.MustParse(p))
+				ide = append(ide, &PlatformLayerSource{Platform: plf, LayerSource: ImageLayerSource{
+					layers: []imagebackedLayer{{AddonLayer: AddonLayer{
+						Descriptor: ociv1.Descriptor{MediaType: ociv1.MediaTypeImageLayerGzip, Digest: digest.FromString("ide-" + platforms.Format(plf)), Size: 10},
+						DiffID:     digest.FromString("ide-" + platforms.Format(plf) + "-diff"),
+					}}},
+				}})
+			}
+			composed, err := lru.New(10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mh := &manifestHandler{
+				Name:           "workspace",
+				Spec:           &api.ImageSpec{BaseRef: "base:latest"},
+				Resolver:       f,
+				Store:          &alwaysNotFoundStore{},
+				ConfigModifier: NewConfigModifierFromLayerSource(ide),
+				Composed:       composed,
+				Tag:            "latest",
+			}
+
+			get := func(accept, userAgent string) (mediaType string, content []byte) {
+				req := httptest.NewRequest("GET", "/v2/workspace/manifests/latest", nil)
+				req.Header.Set("Accept", accept)
+				req.Header.Set("User-Agent", userAgent)
+				rec := httptest.NewRecorder()
+				mh.getManifest(rec, req)
+				if rec.Code != 200 {
+					t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body.String())
+				}
+				if act := rec.Header().Get("Docker-Content-Digest"); act != digest.FromBytes(rec.Body.Bytes()).String() {
+					t.Errorf("unexpected Docker-Content-Digest %s", act)
+				}
+				return rec.Header().Get("Content-Type"), rec.Body.Bytes()
+			}
+			lastLayer := func(content []byte) string {
+				var mf ociv1.Manifest
+				err := json.Unmarshal(content, &mf)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(mf.Layers) == 0 {
+					t.Fatal("manifest has no layers")
+				}
+				return mf.Layers[len(mf.Layers)-1].Digest.String()
+			}
+
+			var act Expectation
+			mediaType, content := get(test.Accept, test.UserAgent)
+			act.MediaType = mediaType
+			switch mediaType {
+			case ociv1.MediaTypeImageIndex, images.MediaTypeDockerSchema2ManifestList:
+				var index ociv1.Index
+				err = json.Unmarshal(content, &index)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if index.MediaType != mediaType {
+					t.Errorf("index has media type %s, expected %s", index.MediaType, mediaType)
+				}
+				for _, m := range index.Manifests {
+					plf := platforms.Format(platforms.Normalize(*m.Platform))
+					act.Platforms = append(act.Platforms, plf)
+
+					// containerd fetches the manifests of the index by digest
+					mh.Tag, mh.Digest = "", m.Digest
+					mt, mf := get(accept, "")
+					if mt != m.MediaType || digest.FromBytes(mf) != m.Digest {
+						t.Errorf("manifest for %s: got %s %s, expected %s %s", plf, mt, digest.FromBytes(mf), m.MediaType, m.Digest)
+					}
+					if exp := digest.FromString("ide-" + plf).String(); lastLayer(mf) != exp {
+						t.Errorf("manifest for %s has IDE layer %s, expected %s", plf, lastLayer(mf), exp)
+					}
+				}
+			default:
+				for p := range map[string]struct{}{"linux/amd64": {}, "linux/arm64": {}} {
+					if lastLayer(content) == digest.FromString("ide-"+p).String() {
+						act.Layer = "ide-" + p
+					}
+				}
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected manifest (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetManifestLazyLayers(t *testing.T) {
+	f := &fakeFetcher{Content: make(map[string][]byte)}
+	addMultiPlatformImage(t, f, "base:latest", ociv1.MediaTypeImageIndex, "linux/amd64", "linux/arm64")
+	lazy, err := NewLazyLayerSource(config.LazyPullConfig{Location: t.TempDir()}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mh := &manifestHandler{
+		Name:           "workspace",
+		Spec:           &api.ImageSpec{BaseRef: "base:latest"},
+		Resolver:       f,
+		Store:          &alwaysNotFoundStore{},
+		ConfigModifier: NewConfigModifierFromLayerSource(CompositeLayerSource{}),
+		LazyLayers:     lazy,
+		Tag:            "latest",
+	}
+
+	req := httptest.NewRequest("GET", "/v2/workspace/manifests/latest", nil)
+	req.Header.Set("Accept", ociv1.MediaTypeImageManifest+", "+ociv1.MediaTypeImageIndex)
+	req.Header.Set("User-Agent", "docker/20.10.21 go/go1.18.7 git-commit/3056208 kernel/5.15.0 os/linux arch/arm64")
+	rec := httptest.NewRecorder()
+	mh.getManifest(rec, req)
+	if rec.Code != 200 {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body.String())
+	}
+	waitForConversions(t, lazy)
+
+	// only the layers of the requested platform are converted to eStargz
+	var act []digest.Digest
+	lazy.mu.RLock()
+	for dgst := range lazy.failed {
+		act = append(act, dgst)
+	}
+	for dgst := range lazy.layers {
+		act = append(act, dgst)
+	}
+	lazy.mu.RUnlock()
+	if diff := cmp.Diff([]digest.Digest{digest.FromString("linux/arm64")}, act); diff != "" {
+		t.Errorf("unexpected layers scheduled for conversion (-want +got):\n%s", diff)
+	}
+}
+
+type multiPlatformImage struct {
+	Desc      ociv1.Descriptor
+	Manifests map[string]digest.Digest
+}
+
+// addMultiPlatformImage adds an image index with a single-layer image for each platform to the fetcher
+func addMultiPlatformImage(t *testing.T, f *fakeFetcher, ref string, mediaType string, plfs ...string) multiPlatformImage {
+	manifestMediaType, configMediaType := ociv1.MediaTypeImageManifest, ociv1.MediaTypeImageConfig
+	if mediaType == images.MediaTypeDockerSchema2ManifestList {
+		manifestMediaType, configMediaType = images.MediaTypeDockerSchema2Manifest, images.MediaTypeDockerSchema2Config
+	}
+	add := func(v interface{}) (digest.Digest, int64) {
+		c, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dgst := digest.FromBytes(c)
+		f.Content[dgst.Encoded()] = c
+		return dgst, int64(len(c))
+	}
+
+	res := multiPlatformImage{Manifests: make(map[string]digest.Digest)}
+	index := ociv1.Index{Versioned: specs.Versioned{SchemaVersion: 2}, MediaType: mediaType}
+	for _, p := range plfs {
+		plf := platforms.MustParse(p)
+		cfgDgst, cfgSize := add(ociv1.Image{
+			Architecture: plf.Architecture,
+			OS:           plf.OS,
+			RootFS:       ociv1.RootFS{Type: "layers", DiffIDs: []digest.Digest{digest.FromString(p + "-diff")}},
+		})
+		mfDgst, mfSize := add(ociv1.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: manifestMediaType,
+			Config:    ociv1.Descriptor{MediaType: configMediaType, Digest: cfgDgst, Size: cfgSize},
+			Layers:    []ociv1.Descriptor{{MediaType: ociv1.MediaTypeImageLayerGzip, Digest: digest.FromString(p), Size: 10}},
+		})
+		index.Manifests = append(index.Manifests, ociv1.Descriptor{MediaType: manifestMediaType, Digest: mfDgst, Size: mfSize, Platform: &plf})
+		res.Manifests[p] = mfDgst
+	}
+	dgst, size := add(index)
+	res.Desc = ociv1.Descriptor{MediaType: mediaType, Digest: dgst, Size: size}
+
+	rawDesc, err := json.Marshal(res.Desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Content[ref] = rawDesc
+	return res
+}
+
 type alwaysNotFoundStore struct{}
 
 func (fbs *alwaysNotFoundStore) ReaderAt(ctx context.Context, desc ociv1.Descriptor) (content.ReaderAt, error) {
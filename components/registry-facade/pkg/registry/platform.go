@@ -0,0 +1,153 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/platforms"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+)
+
+type platformContextKey struct{}
+
+// withPlatform makes everything that's downloaded or composed using ctx use the platform-specific
+// variant of multi-platform images.
+func withPlatform(ctx context.Context, platform ociv1.Platform) context.Context {
+	return context.WithValue(ctx, platformContextKey{}, platforms.Normalize(platform))
+}
+
+// platformFromContext returns the platform set using withPlatform. Without one we use the platform
+// registry-facade runs on: it serves the node it runs on, hence that's what containerd pulls.
+func platformFromContext(ctx context.Context) ociv1.Platform {
+	if p, ok := ctx.Value(platformContextKey{}).(ociv1.Platform); ok {
+		return p
+	}
+	return platforms.DefaultSpec()
+}
+
+// requestedPlatform returns the platform a client asks for. Docker announces its platform in the user agent,
+// e.g. "docker/20.10.21 go/go1.18.7 kernel/5.15.0 os/linux arch/arm64". Everyone else gets the node's platform.
+func requestedPlatform(r *http.Request) ociv1.Platform {
+	res := platforms.DefaultSpec()
+	for _, field := range strings.Fields(r.UserAgent()) {
+		key, value, ok := strings.Cut(field, "/")
+		if !ok || value == "" {
+			continue
+		}
+		switch key {
+		case "os":
+			res.OS = value
+		case "arch":
+			if value != res.Architecture {
+				res.Architecture, res.Variant = value, ""
+			}
+		}
+	}
+	return platforms.Normalize(res)
+}
+
+// selectManifest chooses the manifest for platform from an image index. Manifests without a platform
+// are used only if there's nothing more specific.
+func selectManifest(index *ociv1.Index, platform ociv1.Platform) (ociv1.Descriptor, error) {
+	matcher := platforms.Only(platform)
+
+	var candidates []ociv1.Descriptor
+	for _, m := range index.Manifests {
+		if m.Platform == nil || matcher.Match(*m.Platform) {
+			candidates = append(candidates, m)
+		}
+	}
+	if len(candidates) == 0 {
+		return ociv1.Descriptor{}, xerrors.Errorf("no manifest for platform %s: %w", platforms.Format(platform), errdefs.ErrNotFound)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		pi, pj := candidates[i].Platform, candidates[j].Platform
+		if pi == nil || pj == nil {
+			return pj == nil && pi != nil
+		}
+		return matcher.Less(*pi, *pj)
+	})
+	return candidates[0], nil
+}
+
+// indexPlatforms lists the platforms an image index has runnable images for, starting with those
+// closest to the platform of ctx.
+func indexPlatforms(ctx context.Context, index *ociv1.Index) []ociv1.Platform {
+	var (
+		res  []ociv1.Platform
+		seen = make(map[string]struct{})
+	)
+	for _, m := range index.Manifests {
+		// attestations and other artifacts are stored with an "unknown" platform
+		if m.Platform == nil || m.Platform.OS == "unknown" || m.Platform.Architecture == "unknown" {
+			continue
+		}
+		p := platforms.Normalize(*m.Platform)
+		if _, exists := seen[platforms.Format(p)]; exists {
+			continue
+		}
+		seen[platforms.Format(p)] = struct{}{}
+		res = append(res, p)
+	}
+
+	matcher := platforms.Only(platformFromContext(ctx))
+	sort.SliceStable(res, func(i, j int) bool { return matcher.Less(res[i], res[j]) })
+	return res
+}
+
+// PlatformLayerSource provides the layers of its delegate to images of one platform only
+type PlatformLayerSource struct {
+	LayerSource
+	Platform ociv1.Platform
+}
+
+func (src *PlatformLayerSource) applies(ctx context.Context) bool {
+	return platforms.NewMatcher(src.Platform).Match(platformFromContext(ctx))
+}
+
+// Envs returns the list of env modifiers
+func (src *PlatformLayerSource) Envs(ctx context.Context, spec *api.ImageSpec) ([]EnvModifier, error) {
+	if !src.applies(ctx) {
+		return nil, nil
+	}
+	return src.LayerSource.Envs(ctx, spec)
+}
+
+// GetLayer returns the layers of the delegate if ctx asks for our platform
+func (src *PlatformLayerSource) GetLayer(ctx context.Context, spec *api.ImageSpec) ([]AddonLayer, error) {
+	if !src.applies(ctx) {
+		return nil, nil
+	}
+	return src.LayerSource.GetLayer(ctx, spec)
+}
+
+// HasBlob checks if a digest can be served by this blob source
+func (src *PlatformLayerSource) HasBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) bool {
+	if !src.applies(ctx) {
+		return false
+	}
+	return src.LayerSource.HasBlob(ctx, spec, dgst)
+}
+
+// GetBlob provides access to a blob. If a ReadCloser is returned the receiver is expected to
+// call close on it eventually.
+func (src *PlatformLayerSource) GetBlob(ctx context.Context, spec *api.ImageSpec, dgst digest.Digest) (dontCache bool, mediaType string, url string, data io.ReadCloser, err error) {
+	if !src.applies(ctx) {
+		err = errdefs.ErrNotFound
+		return
+	}
+	return src.LayerSource.GetBlob(ctx, spec, dgst)
+}
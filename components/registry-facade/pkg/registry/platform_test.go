@@ -0,0 +1,72 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/containerd/containerd/platforms"
+	"github.com/google/go-cmp/cmp"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestRequestedPlatform(t *testing.T) {
+	tests := []struct {
+		Name        string
+		UserAgent   string
+		Expectation string
+	}{
+		{Name: "containerd", UserAgent: "containerd/v1.6.20", Expectation: platforms.Format(platforms.DefaultSpec())},
+		{Name: "docker on arm64", UserAgent: "docker/20.10.21 go/go1.18.7 git-commit/3056208 kernel/5.15.0 os/linux arch/arm64 UpstreamClient(Docker-Client/20.10.21 \\(linux\\))", Expectation: "linux/arm64"},
+		{Name: "docker on amd64", UserAgent: "docker/20.10.21 go/go1.18.7 os/linux arch/amd64", Expectation: "linux/amd64"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/v2/workspace/manifests/latest", nil)
+			req.Header.Set("User-Agent", test.UserAgent)
+
+			act := platforms.Format(requestedPlatform(req))
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected platform (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIndexPlatforms(t *testing.T) {
+	desc := func(p string) ociv1.Descriptor {
+		plf := platforms.MustParse(p)
+		return ociv1.Descriptor{Platform: &plf}
+	}
+	index := &ociv1.Index{Manifests: []ociv1.Descriptor{
+		desc("linux/amd64"),
+		desc("unknown/unknown"),
+		desc("linux/arm64"),
+		desc("linux/arm64/v8"),
+		{},
+	}}
+
+	tests := []struct {
+		Name        string
+		Platform    string
+		Expectation []string
+	}{
+		{Name: "amd64 node", Platform: "linux/amd64", Expectation: []string{"linux/amd64", "linux/arm64"}},
+		{Name: "arm64 node", Platform: "linux/arm64", Expectation: []string{"linux/arm64", "linux/amd64"}},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var act []string
+			for _, p := range indexPlatforms(withPlatform(context.Background(), platforms.MustParse(test.Platform)), index) {
+				act = append(act, platforms.Format(p))
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected platforms (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
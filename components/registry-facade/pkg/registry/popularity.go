@@ -0,0 +1,261 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+)
+
+const (
+	// popularityBuckets is the number of buckets a popularity window is split into.
+	// The window moves in steps of a single bucket.
+	popularityBuckets = 12
+
+	// PopularityPath is the path under which registry-facade serves the most frequently requested images
+	PopularityPath = "/popularity"
+
+	// defaultPopularityLimit is the number of references the popularity endpoint serves unless asked otherwise
+	defaultPopularityLimit = 10
+	// maxPopularityLimit is the largest number of references the popularity endpoint serves
+	maxPopularityLimit = 100
+)
+
+// PopularityTracker counts how often image references are requested over a sliding window
+type PopularityTracker interface {
+	// Record counts a request for each of the references
+	Record(ctx context.Context, refs ...string) error
+
+	// Top returns the n most frequently requested references, most popular first
+	Top(ctx context.Context, n int) ([]RefPopularity, error)
+}
+
+// RefPopularity is the number of requests for an image reference within the window
+type RefPopularity struct {
+	Ref      string `json:"ref"`
+	Requests int64  `json:"requests"`
+}
+
+// PopularityHandler serves the most frequently requested references as JSON, most popular first.
+// The limit query parameter sets the number of references.
+func PopularityHandler(t PopularityTracker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		limit := defaultPopularityLimit
+		if l := r.URL.Query().Get("limit"); l != "" {
+			var err error
+			limit, err = strconv.Atoi(l)
+			if err != nil || limit <= 0 {
+				http.Error(w, "invalid limit", http.StatusBadRequest)
+				return
+			}
+		}
+		if limit > maxPopularityLimit {
+			limit = maxPopularityLimit
+		}
+
+		top, err := t.Top(r.Context(), limit)
+		if err != nil {
+			log.WithError(err).Warn("cannot get image popularity")
+			http.Error(w, "cannot get image popularity", http.StatusInternalServerError)
+			return
+		}
+		if top == nil {
+			top = []RefPopularity{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(top)
+		if err != nil {
+			log.WithError(err).Warn("cannot serve image popularity")
+		}
+	})
+}
+
+// popularRefs returns the references of an image spec worth keeping warm
+func popularRefs(spec *api.ImageSpec) []string {
+	var res []string
+	for _, ref := range append([]string{spec.BaseRef, spec.IdeRef, spec.SupervisorRef}, spec.IdeLayerRef...) {
+		if ref == "" {
+			continue
+		}
+		res = append(res, ref)
+	}
+	return res
+}
+
+// sortPopularity sorts by number of requests, most popular first
+func sortPopularity(p []RefPopularity) {
+	sort.Slice(p, func(i, j int) bool {
+		if p[i].Requests == p[j].Requests {
+			return p[i].Ref < p[j].Ref
+		}
+		return p[i].Requests > p[j].Requests
+	})
+}
+
+// NewWindowedPopularityTracker creates a popularity tracker which keeps its counts in memory
+func NewWindowedPopularityTracker(window time.Duration) *WindowedPopularityTracker {
+	return &WindowedPopularityTracker{
+		Window: window,
+		now:    time.Now,
+	}
+}
+
+// WindowedPopularityTracker counts the requests this registry-facade instance serves
+type WindowedPopularityTracker struct {
+	Window time.Duration
+
+	mu      sync.Mutex
+	buckets []popularityBucket
+	now     func() time.Time
+}
+
+type popularityBucket struct {
+	Start  time.Time
+	Counts map[string]int64
+}
+
+var _ PopularityTracker = &WindowedPopularityTracker{}
+
+// Record counts a request for each of the references
+func (t *WindowedPopularityTracker) Record(ctx context.Context, refs ...string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	start := t.now().Truncate(t.Window / popularityBuckets)
+	if len(t.buckets) == 0 || t.buckets[len(t.buckets)-1].Start != start {
+		t.buckets = append(t.buckets, popularityBucket{Start: start, Counts: make(map[string]int64)})
+	}
+	t.expire()
+
+	bucket := t.buckets[len(t.buckets)-1]
+	for _, ref := range refs {
+		bucket.Counts[ref]++
+	}
+	return nil
+}
+
+// Top returns the n most frequently requested references, most popular first
+func (t *WindowedPopularityTracker) Top(ctx context.Context, n int) ([]RefPopularity, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.expire()
+	counts := make(map[string]int64)
+	for _, b := range t.buckets {
+		for ref, c := range b.Counts {
+			counts[ref] += c
+		}
+	}
+
+	res := make([]RefPopularity, 0, len(counts))
+	for ref, c := range counts {
+		res = append(res, RefPopularity{Ref: ref, Requests: c})
+	}
+	sortPopularity(res)
+	if len(res) > n {
+		res = res[:n]
+	}
+	return res, nil
+}
+
+// expire drops all buckets which have left the window. Callers must hold t.mu.
+func (t *WindowedPopularityTracker) expire() {
+	width := t.Window / popularityBuckets
+	oldest := t.now().Truncate(width).Add(-(popularityBuckets - 1) * width)
+
+	var i int
+	for i < len(t.buckets) && t.buckets[i].Start.Before(oldest) {
+		i++
+	}
+	t.buckets = t.buckets[i:]
+}
+
+// RedisPopularityTracker counts the requests of all registry-facade instances which share a Redis.
+// New nodes know which images are popular before they have served a single request.
+type RedisPopularityTracker struct {
+	Client *redis.Client
+	Window time.Duration
+
+	now func() time.Time
+}
+
+var _ PopularityTracker = &RedisPopularityTracker{}
+
+// NewRedisPopularityTracker creates a popularity tracker which keeps its counts in Redis
+func NewRedisPopularityTracker(client *redis.Client, window time.Duration) *RedisPopularityTracker {
+	return &RedisPopularityTracker{
+		Client: client,
+		Window: window,
+		now:    time.Now,
+	}
+}
+
+func (t *RedisPopularityTracker) key(start time.Time) string {
+	return fmt.Sprintf("popularity.%d", start.Unix())
+}
+
+// Record counts a request for each of the references
+func (t *RedisPopularityTracker) Record(ctx context.Context, refs ...string) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	width := t.Window / popularityBuckets
+	key := t.key(t.now().Truncate(width))
+	_, err := t.Client.Pipelined(ctx, func(p redis.Pipeliner) error {
+		for _, ref := range refs {
+			p.ZIncrBy(ctx, key, 1, ref)
+		}
+		p.Expire(ctx, key, t.Window+width)
+		return nil
+	})
+	return err
+}
+
+// Top returns the n most frequently requested references, most popular first
+func (t *RedisPopularityTracker) Top(ctx context.Context, n int) ([]RefPopularity, error) {
+	width := t.Window / popularityBuckets
+	start := t.now().Truncate(width)
+
+	keys := make([]string, popularityBuckets)
+	for i := range keys {
+		keys[i] = t.key(start.Add(-time.Duration(i) * width))
+	}
+	zs, err := t.Client.ZUnionWithScores(ctx, redis.ZStore{Keys: keys}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]RefPopularity, 0, len(zs))
+	for _, z := range zs {
+		ref, ok := z.Member.(string)
+		if !ok {
+			continue
+		}
+		res = append(res, RefPopularity{Ref: ref, Requests: int64(z.Score)})
+	}
+	sortPopularity(res)
+	if len(res) > n {
+		res = res[:n]
+	}
+	return res, nil
+}
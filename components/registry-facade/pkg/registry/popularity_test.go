@@ -0,0 +1,181 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/go-cmp/cmp"
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/gitpod-io/gitpod/registry-facade/api"
+)
+
+func TestPopularityTracker(t *testing.T) {
+	const window = 12 * time.Hour
+
+	type record struct {
+		After time.Duration
+		Refs  []string
+	}
+	tests := []struct {
+		Name        string
+		Records     []record
+		Top         int
+		TopAfter    time.Duration
+		Expectation []RefPopularity
+	}{
+		{
+			Name: "most popular first",
+			Records: []record{
+				{Refs: []string{"base:a", "ide:1"}},
+				{Refs: []string{"base:b", "ide:1"}},
+				{After: time.Hour, Refs: []string{"base:b", "ide:1"}},
+			},
+			Top:      10,
+			TopAfter: time.Hour,
+			Expectation: []RefPopularity{
+				{Ref: "ide:1", Requests: 3},
+				{Ref: "base:b", Requests: 2},
+				{Ref: "base:a", Requests: 1},
+			},
+		},
+		{
+			Name: "limited",
+			Records: []record{
+				{Refs: []string{"base:a", "base:b", "base:b", "base:c", "base:c", "base:c"}},
+			},
+			Top: 2,
+			Expectation: []RefPopularity{
+				{Ref: "base:c", Requests: 3},
+				{Ref: "base:b", Requests: 2},
+			},
+		},
+		{
+			Name: "requests leave the window",
+			Records: []record{
+				{Refs: []string{"base:a", "base:a"}},
+				{After: 6 * time.Hour, Refs: []string{"base:b"}},
+			},
+			Top:      10,
+			TopAfter: 13 * time.Hour,
+			Expectation: []RefPopularity{
+				{Ref: "base:b", Requests: 1},
+			},
+		},
+	}
+
+	newTrackers := func(t *testing.T, now func() time.Time) map[string]PopularityTracker {
+		srv, err := miniredis.Run()
+		if err != nil {
+			t.Fatalf("cannot run mini redis server: %v", err)
+		}
+		t.Cleanup(srv.Close)
+
+		windowed := NewWindowedPopularityTracker(window)
+		windowed.now = now
+		rds := NewRedisPopularityTracker(redis.NewClient(&redis.Options{Addr: srv.Addr()}), window)
+		rds.now = now
+		return map[string]PopularityTracker{"windowed": windowed, "redis": rds}
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				ctx = context.Background()
+				t0  = time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+				now = t0
+			)
+			for name, tracker := range newTrackers(t, func() time.Time { return now }) {
+				t.Run(name, func(t *testing.T) {
+					now = t0
+					for _, r := range test.Records {
+						now = t0.Add(r.After)
+						err := tracker.Record(ctx, r.Refs...)
+						if err != nil {
+							t.Fatal(err)
+						}
+					}
+
+					now = t0.Add(test.TopAfter)
+					act, err := tracker.Top(ctx, test.Top)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if diff := cmp.Diff(test.Expectation, act); diff != "" {
+						t.Errorf("unexpected popularity (-want +got):\n%s", diff)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestPopularRefs(t *testing.T) {
+	act := popularRefs(&api.ImageSpec{
+		BaseRef:     "base:latest",
+		IdeRef:      "ide:latest",
+		IdeLayerRef: []string{"ide-layer:a", "ide-layer:b"},
+	})
+	if diff := cmp.Diff([]string{"base:latest", "ide:latest", "ide-layer:a", "ide-layer:b"}, act); diff != "" {
+		t.Errorf("unexpected refs (-want +got):\n%s", diff)
+	}
+}
+
+func TestPopularityHandler(t *testing.T) {
+	tracker := NewWindowedPopularityTracker(time.Hour)
+	err := tracker.Record(context.Background(), "base:a", "base:a", "base:b", "ide:1", "ide:1", "ide:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name        string
+		Query       string
+		Code        int
+		Expectation []RefPopularity
+	}{
+		{
+			Name:        "default limit",
+			Code:        http.StatusOK,
+			Expectation: []RefPopularity{{Ref: "ide:1", Requests: 3}, {Ref: "base:a", Requests: 2}, {Ref: "base:b", Requests: 1}},
+		},
+		{
+			Name:        "limit",
+			Query:       "?limit=1",
+			Code:        http.StatusOK,
+			Expectation: []RefPopularity{{Ref: "ide:1", Requests: 3}},
+		},
+		{Name: "invalid limit", Query: "?limit=foo", Code: http.StatusBadRequest},
+		{Name: "negative limit", Query: "?limit=-1", Code: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			PopularityHandler(tracker).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, PopularityPath+test.Query, nil))
+			if rec.Code != test.Code {
+				t.Fatalf("unexpected status code: want %d, got %d", test.Code, rec.Code)
+			}
+			if test.Code != http.StatusOK {
+				return
+			}
+
+			var act []RefPopularity
+			err := json.Unmarshal(rec.Body.Bytes(), &act)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected popularity (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
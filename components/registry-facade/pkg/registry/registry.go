@@ -23,6 +23,7 @@ import (
 	"github.com/gitpod-io/gitpod/registry-facade/api/config"
 
 	"github.com/containerd/containerd/content/local"
+	"github.com/containerd/containerd/platforms"
 	"github.com/containerd/containerd/remotes"
 	"github.com/docker/distribution"
 	"github.com/docker/distribution/reference"
@@ -30,6 +31,7 @@ import (
 	distv2 "github.com/docker/distribution/registry/api/v2"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/gorilla/mux"
+	lru "github.com/hashicorp/golang-lru"
 	httpapi "github.com/ipfs/go-ipfs-http-client"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prometheus/client_golang/prometheus"
@@ -50,9 +52,17 @@ func buildStaticLayer(ctx context.Context, cfg []config.StaticLayerCfg, newResol
 			if err != nil {
 				return nil, xerrors.Errorf("cannot source layer from %s: %w", sl.Ref, err)
 			}
+			if sl.Platform != "" {
+				p, err := platforms.Parse(sl.Platform)
+				if err != nil {
+					return nil, xerrors.Errorf("invalid platform for static layer %s: %w", sl.Ref, err)
+				}
+				l = append(l, &PlatformLayerSource{LayerSource: src, Platform: p})
+				continue
+			}
 			l = append(l, src)
 		case "image":
-			src, err := NewStaticSourceFromImage(ctx, newResolver(), sl.Ref)
+			src, err := NewPlatformStaticSourceFromImage(ctx, newResolver(), sl.Ref, sl.Platform)
 			if err != nil {
 				return nil, xerrors.Errorf("cannot source layer from %s: %w", sl.Ref, err)
 			}
@@ -64,6 +74,9 @@ func buildStaticLayer(ctx context.Context, cfg []config.StaticLayerCfg, newResol
 	return l, nil
 }
 
+// defaultPopularityWindow is the window over which image requests are counted unless configured otherwise
+const defaultPopularityWindow = 24 * time.Hour
+
 // ResolverProvider provides new resolver
 type ResolverProvider func() remotes.Resolver
 
@@ -73,11 +86,16 @@ type Registry struct {
 	Resolver       ResolverProvider
 	Store          BlobStore
 	IPFS           *IPFSBlobCache
+	LazyLayers     *LazyLayerSource
+	BlobCache      *DiskBlobCache
+	Popularity     PopularityTracker
+	Signatures     *SignatureVerifier
 	LayerSource    LayerSource
 	ConfigModifier ConfigModifier
 	SpecProvider   map[string]ImageSpecProvider
 
 	staticLayerSource *RevisioningLayerSource
+	composed          *lru.Cache
 	metrics           *metrics
 	srv               *http.Server
 }
@@ -229,15 +247,73 @@ func NewRegistry(cfg config.Config, newResolver ResolverProvider, reg prometheus
 		log.WithField("config", cfg.IPFSCache).Info("enabling IPFS caching")
 	}
 
+	var lazyLayers *LazyLayerSource
+	if cfg.LazyPull != nil && cfg.LazyPull.Enabled {
+		lazyLayers, err = NewLazyLayerSource(*cfg.LazyPull, reg)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create lazy layer source: %w", err)
+		}
+	}
+
+	var blobCache *DiskBlobCache
+	if cfg.BlobCache != nil && cfg.BlobCache.Enabled {
+		blobCache, err = NewDiskBlobCache(*cfg.BlobCache, reg)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create blob cache: %w", err)
+		}
+	}
+
+	var popularity PopularityTracker
+	if cfg.Popularity != nil && cfg.Popularity.Enabled {
+		window := defaultPopularityWindow
+		if cfg.Popularity.Window != "" {
+			window, err = time.ParseDuration(cfg.Popularity.Window)
+			if err != nil {
+				return nil, xerrors.Errorf("invalid popularity window: %w", err)
+			}
+		}
+
+		if cfg.RedisCache != nil && cfg.RedisCache.Enabled {
+			rdc, err := getRedisClient(cfg.RedisCache)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot connect to Redis: %w", err)
+			}
+			popularity = NewRedisPopularityTracker(rdc, window)
+		} else {
+			popularity = NewWindowedPopularityTracker(window)
+		}
+		log.WithField("window", window).Info("tracking image popularity")
+	}
+
+	var signatures *SignatureVerifier
+	if cfg.SignatureVerification != nil && cfg.SignatureVerification.Enabled {
+		signatures, err = NewSignatureVerifier(*cfg.SignatureVerification, reg)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create signature verifier: %w", err)
+		}
+		log.WithField("policies", len(signatures.Policies)).WithField("auditOnly", signatures.AuditOnly).Info("verifying image signatures")
+	}
+
+	// containerd fetches the manifests of the index we composed by digest right after it resolved the tag
+	composed, err := lru.New(1024)
+	if err != nil {
+		return nil, err
+	}
+
 	layerSource := CompositeLayerSource(layerSources)
 	return &Registry{
 		Config:            cfg,
 		Resolver:          newResolver,
 		Store:             mfStore,
 		IPFS:              ipfs,
+		LazyLayers:        lazyLayers,
+		BlobCache:         blobCache,
+		Popularity:        popularity,
+		Signatures:        signatures,
 		SpecProvider:      specProvider,
 		LayerSource:       layerSource,
 		staticLayerSource: staticLayer,
+		composed:          composed,
 		ConfigModifier:    NewConfigModifierFromLayerSource(layerSource),
 		metrics:           metrics,
 	}, nil
@@ -326,6 +402,9 @@ func (reg *Registry) Serve() error {
 	}
 	mux := http.NewServeMux()
 	mux.Handle("/", handler)
+	if reg.Popularity != nil {
+		mux.Handle(PopularityPath, PopularityHandler(reg.Popularity))
+	}
 
 	if addr := os.Getenv("REGFAC_NO_TLS_DEBUG"); addr != "" {
 		// Gitpod port-forwarding also does SSL termination. If we only served the HTTPS service
@@ -0,0 +1,384 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/docker/distribution/reference"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/registry-facade/api/config"
+)
+
+const (
+	// cosign stores signatures and attestations of an image in the image's repository, tagged by the image digest
+	cosignSignatureTagSuffix   = ".sig"
+	cosignAttestationTagSuffix = ".att"
+
+	cosignSimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignSignatureAnnotation    = "dev.cosignproject.cosign/signature"
+	cosignSignatureType          = "cosign container image signature"
+
+	dsseEnvelopeMediaType = "application/vnd.dsse.envelope.v1+json"
+	inTotoPayloadType     = "application/vnd.in-toto+json"
+
+	// maxSignatureBlobSize limits the size of the signature payloads and attestations we download
+	maxSignatureBlobSize = 4 << 20
+)
+
+// SignatureVerifier admits images which carry a cosign signature or attestation made with a trusted key
+type SignatureVerifier struct {
+	AuditOnly bool
+	Policies  []SignaturePolicy
+
+	// verified caches the images whose signature we have verified already
+	verified      *lru.Cache
+	verifications *prometheus.CounterVec
+}
+
+// SignaturePolicy requires the images of some repositories to be signed by one of the keys
+type SignaturePolicy struct {
+	Repositories []string
+	Keys         []crypto.PublicKey
+	AuditOnly    bool
+}
+
+// NewSignatureVerifier loads the public keys of all policies
+func NewSignatureVerifier(cfg config.SignatureVerificationConfig, reg prometheus.Registerer) (*SignatureVerifier, error) {
+	res := &SignatureVerifier{
+		AuditOnly: cfg.AuditOnly,
+		verifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "signature_verifications_total",
+			Help: "number of image signature verifications",
+		}, []string{"result"}),
+	}
+	for i, p := range cfg.Policies {
+		if len(p.PublicKeys) == 0 {
+			return nil, xerrors.Errorf("signature policy %d has no public keys", i)
+		}
+		policy := SignaturePolicy{Repositories: p.Repositories, AuditOnly: p.AuditOnly}
+		for _, fn := range p.PublicKeys {
+			key, err := loadPublicKey(fn)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot load public key %s: %w", fn, err)
+			}
+			policy.Keys = append(policy.Keys, key)
+		}
+		res.Policies = append(res.Policies, policy)
+	}
+
+	var err error
+	res.verified, err = lru.New(1024)
+	if err != nil {
+		return nil, err
+	}
+	err = reg.Register(res.verifications)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func loadPublicKey(fn string) (crypto.PublicKey, error) {
+	fc, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(fc)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, xerrors.Errorf("no PEM encoded public key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, xerrors.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// ErrImageNotSigned is returned when an image carries no signature or attestation made with a trusted key
+type ErrImageNotSigned struct {
+	Ref    string
+	Reason string
+}
+
+func (e *ErrImageNotSigned) Error() string {
+	return fmt.Sprintf("image %s is not signed by a trusted key: %s", e.Ref, e.Reason)
+}
+
+// Verify returns an error if the image desc, which ref resolved to, is not signed by a trusted key.
+// Images of policies in audit-only mode are logged rather than refused.
+func (v *SignatureVerifier) Verify(ctx context.Context, resolver remotes.Resolver, ref string, desc ociv1.Descriptor) error {
+	key := ref + "@" + desc.Digest.String()
+	if _, ok := v.verified.Get(key); ok {
+		v.verifications.WithLabelValues("verified").Inc()
+		return nil
+	}
+
+	policy, err := v.verify(ctx, resolver, ref, desc)
+	if err == nil {
+		v.verified.Add(key, struct{}{})
+		v.verifications.WithLabelValues("verified").Inc()
+		return nil
+	}
+
+	if v.AuditOnly || (policy != nil && policy.AuditOnly) {
+		v.verifications.WithLabelValues("audited").Inc()
+		log.WithError(err).WithField("ref", ref).WithField("digest", desc.Digest).Warn("admitting image which failed signature verification (audit only)")
+		return nil
+	}
+	v.verifications.WithLabelValues("refused").Inc()
+	log.WithError(err).WithField("ref", ref).WithField("digest", desc.Digest).Warn("refusing image which failed signature verification")
+	return err
+}
+
+// coversRepository returns true if repo is the repository a policy names, or one below it.
+// "eu.gcr.io/gitpod" covers "eu.gcr.io/gitpod/base", but not "eu.gcr.io/gitpod-dev/base".
+func coversRepository(name, repo string) bool {
+	name = strings.TrimSuffix(name, "/")
+	return repo == name || strings.HasPrefix(repo, name+"/")
+}
+
+func (v *SignatureVerifier) verify(ctx context.Context, resolver remotes.Resolver, ref string, desc ociv1.Descriptor) (*SignaturePolicy, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return nil, &ErrImageNotSigned{Ref: ref, Reason: err.Error()}
+	}
+	repo := named.Name()
+
+	var policy *SignaturePolicy
+	for i, p := range v.Policies {
+		for _, prefix := range p.Repositories {
+			if coversRepository(prefix, repo) {
+				policy = &v.Policies[i]
+				break
+			}
+		}
+		if policy != nil {
+			break
+		}
+	}
+	if policy == nil {
+		return nil, &ErrImageNotSigned{Ref: ref, Reason: "repository is not covered by any signature policy"}
+	}
+
+	tag := strings.ReplaceAll(desc.Digest.String(), ":", "-")
+	for _, check := range []struct {
+		Suffix string
+		Verify func(payload []byte, layer ociv1.Descriptor) error
+	}{
+		{Suffix: cosignSignatureTagSuffix, Verify: func(payload []byte, layer ociv1.Descriptor) error {
+			return verifySimpleSigning(policy.Keys, payload, layer, desc.Digest)
+		}},
+		{Suffix: cosignAttestationTagSuffix, Verify: func(payload []byte, layer ociv1.Descriptor) error {
+			return verifyAttestation(policy.Keys, payload, layer, desc.Digest)
+		}},
+	} {
+		err = verifySignatureManifest(ctx, resolver, repo+":"+tag+check.Suffix, check.Verify)
+		if err == nil {
+			return policy, nil
+		}
+		log.WithError(err).WithField("ref", ref).WithField("digest", desc.Digest).Debug("no valid signature")
+	}
+	return policy, &ErrImageNotSigned{Ref: ref, Reason: "no valid signature or attestation found"}
+}
+
+// verifySignatureManifest succeeds if any of the layers of the signature or attestation manifest is valid
+func verifySignatureManifest(ctx context.Context, resolver remotes.Resolver, sigRef string, verify func(payload []byte, layer ociv1.Descriptor) error) error {
+	name, desc, err := resolver.Resolve(ctx, sigRef)
+	if err != nil {
+		return err
+	}
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	var manifest ociv1.Manifest
+	rawManifest, err := fetchSignatureBlob(ctx, fetcher, desc)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(rawManifest, &manifest)
+	if err != nil {
+		return xerrors.Errorf("cannot unmarshal signature manifest: %w", err)
+	}
+
+	err = xerrors.Errorf("signature manifest has no layers")
+	for _, layer := range manifest.Layers {
+		var payload []byte
+		payload, err = fetchSignatureBlob(ctx, fetcher, layer)
+		if err != nil {
+			continue
+		}
+		err = verify(payload, layer)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func fetchSignatureBlob(ctx context.Context, fetcher remotes.Fetcher, desc ociv1.Descriptor) ([]byte, error) {
+	if desc.Size > maxSignatureBlobSize {
+		return nil, xerrors.Errorf("signature blob %s is too large", desc.Digest)
+	}
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	res, err := io.ReadAll(io.LimitReader(rc, maxSignatureBlobSize))
+	if err != nil {
+		return nil, err
+	}
+	if digest.FromBytes(res) != desc.Digest {
+		return nil, xerrors.Errorf("digest mismatch for signature blob %s", desc.Digest)
+	}
+	return res, nil
+}
+
+// verifySimpleSigning verifies a cosign signature, i.e. a simple signing payload with the signature in an annotation
+func verifySimpleSigning(keys []crypto.PublicKey, payload []byte, layer ociv1.Descriptor, image digest.Digest) error {
+	if layer.MediaType != cosignSimpleSigningMediaType {
+		return xerrors.Errorf("unsupported signature media type %s", layer.MediaType)
+	}
+	sig, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+	if err != nil {
+		return xerrors.Errorf("cannot decode signature: %w", err)
+	}
+	err = verifyWithAnyKey(keys, payload, sig)
+	if err != nil {
+		return err
+	}
+
+	// the signature is valid - now let's make sure it's for this image
+	var simpleSigning struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+			Type string `json:"type"`
+		} `json:"critical"`
+	}
+	err = json.Unmarshal(payload, &simpleSigning)
+	if err != nil {
+		return xerrors.Errorf("cannot unmarshal signature payload: %w", err)
+	}
+	if simpleSigning.Critical.Type != cosignSignatureType {
+		return xerrors.Errorf("unsupported signature type %s", simpleSigning.Critical.Type)
+	}
+	if simpleSigning.Critical.Image.DockerManifestDigest != image.String() {
+		return xerrors.Errorf("signature is for image %s", simpleSigning.Critical.Image.DockerManifestDigest)
+	}
+	return nil
+}
+
+// verifyAttestation verifies a DSSE envelope which contains an in-toto statement about the image
+func verifyAttestation(keys []crypto.PublicKey, envelope []byte, layer ociv1.Descriptor, image digest.Digest) error {
+	if layer.MediaType != dsseEnvelopeMediaType {
+		return xerrors.Errorf("unsupported attestation media type %s", layer.MediaType)
+	}
+	var env struct {
+		PayloadType string `json:"payloadType"`
+		Payload     string `json:"payload"`
+		Signatures  []struct {
+			Sig string `json:"sig"`
+		} `json:"signatures"`
+	}
+	err := json.Unmarshal(envelope, &env)
+	if err != nil {
+		return xerrors.Errorf("cannot unmarshal attestation: %w", err)
+	}
+	if env.PayloadType != inTotoPayloadType {
+		return xerrors.Errorf("unsupported attestation payload type %s", env.PayloadType)
+	}
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return xerrors.Errorf("cannot decode attestation payload: %w", err)
+	}
+
+	pae := dssePAE(env.PayloadType, payload)
+	err = xerrors.Errorf("attestation has no signatures")
+	for _, s := range env.Signatures {
+		var sig []byte
+		sig, err = base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			continue
+		}
+		err = verifyWithAnyKey(keys, pae, sig)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	var statement struct {
+		Subject []struct {
+			Digest map[string]string `json:"digest"`
+		} `json:"subject"`
+	}
+	err = json.Unmarshal(payload, &statement)
+	if err != nil {
+		return xerrors.Errorf("cannot unmarshal attestation statement: %w", err)
+	}
+	for _, s := range statement.Subject {
+		if s.Digest[image.Algorithm().String()] == image.Encoded() {
+			return nil
+		}
+	}
+	return xerrors.Errorf("attestation is not about image %s", image)
+}
+
+// dssePAE returns the pre-authentication encoding of a DSSE payload, which is what DSSE signatures sign
+func dssePAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+func verifyWithAnyKey(keys []crypto.PublicKey, msg, sig []byte) error {
+	hash := sha256.Sum256(msg)
+	for _, key := range keys {
+		var valid bool
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			valid = ecdsa.VerifyASN1(k, hash[:], sig)
+		case *rsa.PublicKey:
+			valid = rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], sig) == nil
+		case ed25519.PublicKey:
+			valid = ed25519.Verify(k, msg, sig)
+		}
+		if valid {
+			return nil
+		}
+	}
+	return xerrors.Errorf("signature does not match any trusted key")
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registry
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gitpod-io/gitpod/registry-facade/api/config"
+)
+
+func TestSignatureVerifier(t *testing.T) {
+	const (
+		repo = "eu.gcr.io/gitpod/base"
+		ref  = repo + ":latest"
+	)
+	var (
+		image = ociv1.Descriptor{MediaType: ociv1.MediaTypeImageIndex, Digest: digest.FromString("image"), Size: 5}
+		other = digest.FromString("other-image")
+
+		trusted   = newECDSAKey(t)
+		untrusted = newECDSAKey(t)
+		_, edKey  = newEd25519Key(t)
+	)
+	keyDir := t.TempDir()
+	trustedFN := writePublicKey(t, keyDir, "trusted.pub", trusted.Public())
+	edFN := writePublicKey(t, keyDir, "ed25519.pub", edKey.Public())
+
+	tests := []struct {
+		Name          string
+		Ref           string
+		Prep          func(f *fakeFetcher)
+		Config        config.SignatureVerificationConfig
+		ExpectedError string
+	}{
+		{
+			Name: "signed",
+			Prep: func(f *fakeFetcher) { addCosignSignature(t, f, trusted, repo, image.Digest, image.Digest) },
+		},
+		{
+			Name: "signed with ed25519 key",
+			Prep: func(f *fakeFetcher) { addCosignSignature(t, f, edKey, repo, image.Digest, image.Digest) },
+			Config: config.SignatureVerificationConfig{Policies: []config.SignaturePolicy{
+				{Repositories: []string{"eu.gcr.io/gitpod/"}, PublicKeys: []string{edFN}},
+			}},
+		},
+		{
+			Name: "attested",
+			Prep: func(f *fakeFetcher) { addAttestation(t, f, trusted, repo, image.Digest, image.Digest) },
+		},
+		{
+			Name:          "unsigned",
+			Prep:          func(f *fakeFetcher) {},
+			ExpectedError: "no valid signature or attestation found",
+		},
+		{
+			Name:          "signed by untrusted key",
+			Prep:          func(f *fakeFetcher) { addCosignSignature(t, f, untrusted, repo, image.Digest, image.Digest) },
+			ExpectedError: "no valid signature or attestation found",
+		},
+		{
+			Name:          "signature for another image",
+			Prep:          func(f *fakeFetcher) { addCosignSignature(t, f, trusted, repo, image.Digest, other) },
+			ExpectedError: "no valid signature or attestation found",
+		},
+		{
+			Name:          "attestation about another image",
+			Prep:          func(f *fakeFetcher) { addAttestation(t, f, trusted, repo, image.Digest, other) },
+			ExpectedError: "no valid signature or attestation found",
+		},
+		{
+			Name: "repository not covered by any policy",
+			Ref:  "docker.io/library/ubuntu:latest",
+			Prep: func(f *fakeFetcher) {
+				addCosignSignature(t, f, trusted, "docker.io/library/ubuntu", image.Digest, image.Digest)
+			},
+			ExpectedError: "not covered by any signature policy",
+		},
+		{
+			Name: "repository sharing a name prefix with a policy",
+			Ref:  "eu.gcr.io/gitpod-dev/base:latest",
+			Prep: func(f *fakeFetcher) {
+				addCosignSignature(t, f, trusted, "eu.gcr.io/gitpod-dev/base", image.Digest, image.Digest)
+			},
+			Config: config.SignatureVerificationConfig{Policies: []config.SignaturePolicy{
+				{Repositories: []string{"eu.gcr.io/gitpod"}, PublicKeys: []string{trustedFN}},
+			}},
+			ExpectedError: "not covered by any signature policy",
+		},
+		{
+			Name: "policy naming the repository",
+			Prep: func(f *fakeFetcher) { addCosignSignature(t, f, trusted, repo, image.Digest, image.Digest) },
+			Config: config.SignatureVerificationConfig{Policies: []config.SignaturePolicy{
+				{Repositories: []string{repo}, PublicKeys: []string{trustedFN}},
+			}},
+		},
+		{
+			Name: "audit only",
+			Prep: func(f *fakeFetcher) {},
+			Config: config.SignatureVerificationConfig{AuditOnly: true, Policies: []config.SignaturePolicy{
+				{Repositories: []string{"eu.gcr.io/gitpod/"}, PublicKeys: []string{trustedFN}},
+			}},
+		},
+		{
+			Name: "policy in audit only mode",
+			Prep: func(f *fakeFetcher) {},
+			Config: config.SignatureVerificationConfig{Policies: []config.SignaturePolicy{
+				{Repositories: []string{"eu.gcr.io/gitpod/"}, PublicKeys: []string{trustedFN}, AuditOnly: true},
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			cfg := test.Config
+			if len(cfg.Policies) == 0 {
+				cfg.Policies = []config.SignaturePolicy{
+					{Repositories: []string{"eu.gcr.io/gitpod/"}, PublicKeys: []string{trustedFN}},
+				}
+			}
+			verifier, err := NewSignatureVerifier(cfg, prometheus.NewRegistry())
+			if err != nil {
+				t.Fatal(err)
+			}
+			f := &fakeFetcher{Content: make(map[string][]byte)}
+			test.Prep(f)
+			r := test.Ref
+			if r == "" {
+				r = ref
+			}
+
+			err = verifier.Verify(context.Background(), f, r, image)
+			if test.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.ExpectedError) {
+				t.Fatalf("expected error containing %q, got %v", test.ExpectedError, err)
+			}
+			var nse *ErrImageNotSigned
+			if !errors.As(err, &nse) {
+				t.Errorf("expected ErrImageNotSigned, got %T", err)
+			}
+		})
+	}
+}
+
+func TestNewSignatureVerifier(t *testing.T) {
+	dir := t.TempDir()
+	invalidFN := filepath.Join(dir, "invalid.pub")
+	err := os.WriteFile(invalidFN, []byte("not a key"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name   string
+		Policy config.SignaturePolicy
+	}{
+		{Name: "no keys", Policy: config.SignaturePolicy{Repositories: []string{"eu.gcr.io/gitpod/"}}},
+		{Name: "missing key", Policy: config.SignaturePolicy{PublicKeys: []string{filepath.Join(dir, "missing.pub")}}},
+		{Name: "invalid key", Policy: config.SignaturePolicy{PublicKeys: []string{invalidFN}}},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := NewSignatureVerifier(config.SignatureVerificationConfig{Policies: []config.SignaturePolicy{test.Policy}}, prometheus.NewRegistry())
+			if err == nil {
+				t.Fatal("expected an error, got nothing")
+			}
+		})
+	}
+}
+
+func newECDSAKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func newEd25519Key(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pub, priv
+}
+
+func writePublicKey(t *testing.T, dir, name string, key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := filepath.Join(dir, name)
+	err = os.WriteFile(fn, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fn
+}
+
+// sign signs msg the way cosign does: ECDSA signs the SHA256 hash, ed25519 the message itself
+func sign(t *testing.T, key crypto.Signer, msg []byte) []byte {
+	var (
+		sig []byte
+		err error
+	)
+	if _, ok := key.(ed25519.PrivateKey); ok {
+		sig, err = key.Sign(rand.Reader, msg, crypto.Hash(0))
+	} else {
+		hash := sha256.Sum256(msg)
+		sig, err = key.Sign(rand.Reader, hash[:], crypto.SHA256)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+// addSignatureManifest adds a cosign signature or attestation manifest for image to the fetcher
+func addSignatureManifest(t *testing.T, f *fakeFetcher, repo string, image digest.Digest, suffix string, layer ociv1.Descriptor, content []byte) {
+	f.Content[layer.Digest.Encoded()] = content
+
+	mf, err := json.Marshal(ociv1.Manifest{MediaType: ociv1.MediaTypeImageManifest, Layers: []ociv1.Descriptor{layer}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mfDesc := ociv1.Descriptor{MediaType: ociv1.MediaTypeImageManifest, Digest: digest.FromBytes(mf), Size: int64(len(mf))}
+	f.Content[mfDesc.Digest.Encoded()] = mf
+
+	rawDesc, err := json.Marshal(mfDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Content[repo+":"+strings.ReplaceAll(image.String(), ":", "-")+suffix] = rawDesc
+}
+
+func addCosignSignature(t *testing.T, f *fakeFetcher, key crypto.Signer, repo string, image, signed digest.Digest) {
+	payload := []byte(`{"critical":{"identity":{"docker-reference":"` + repo + `"},"image":{"docker-manifest-digest":"` + signed.String() + `"},"type":"cosign container image signature"},"optional":null}`)
+	layer := ociv1.Descriptor{
+		MediaType:   cosignSimpleSigningMediaType,
+		Digest:      digest.FromBytes(payload),
+		Size:        int64(len(payload)),
+		Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sign(t, key, payload))},
+	}
+	addSignatureManifest(t, f, repo, image, cosignSignatureTagSuffix, layer, payload)
+}
+
+func addAttestation(t *testing.T, f *fakeFetcher, key crypto.Signer, repo string, image, subject digest.Digest) {
+	statement := []byte(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://slsa.dev/provenance/v0.2","subject":[{"name":"` + repo + `","digest":{"sha256":"` + subject.Encoded() + `"}}],"predicate":{}}`)
+	envelope, err := json.Marshal(map[string]interface{}{
+		"payloadType": inTotoPayloadType,
+		"payload":     base64.StdEncoding.EncodeToString(statement),
+		"signatures": []map[string]string{
+			{"keyid": "", "sig": base64.StdEncoding.EncodeToString(sign(t, key, dssePAE(inTotoPayloadType, statement)))},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer := ociv1.Descriptor{MediaType: dsseEnvelopeMediaType, Digest: digest.FromBytes(envelope), Size: int64(len(envelope))}
+	addSignatureManifest(t, f, repo, image, cosignAttestationTagSuffix, layer, envelope)
+}
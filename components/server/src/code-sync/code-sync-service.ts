@@ -475,16 +475,19 @@ export class CodeSyncService {
                 )(request);
                 const url = urlResponse.getUrl();
                 const content = req.body as string;
+                const headers: { [key: string]: string } = {
+                    "content-length": req.headers["content-length"] || String(content.length),
+                    "content-type": contentType,
+                };
+                // some storage backends only accept uploads which carry additional headers, e.g. Azure's blob type
+                urlResponse.getHeadersMap().forEach((value, key) => (headers[key.toLowerCase()] = value));
                 const response = await fetch(url, {
                     timeout: 10000,
                     method: "PUT",
                     body: content,
-                    headers: {
-                        "content-length": req.headers["content-length"] || String(content.length),
-                        "content-type": contentType,
-                    },
+                    headers,
                 });
-                if (response.status !== 200) {
+                if (!response.ok) {
                     throw new Error(
                         `code sync: blob service: upload failed with ${response.status} ${response.statusText}`,
                     );
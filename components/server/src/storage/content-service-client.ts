@@ -16,6 +16,9 @@ import {
 import {
     DeleteWorkspaceRequest,
     DeleteWorkspaceResponse,
+    ListWorkspaceBackupsRequest,
+    ListWorkspaceBackupsResponse,
+    WorkspaceBackup,
     WorkspaceDownloadURLRequest,
     WorkspaceDownloadURLResponse,
     WorkspaceSnapshotExistsRequest,
@@ -77,10 +80,17 @@ export class ContentServiceStorageClient implements StorageClient {
         });
     }
 
-    public async createWorkspaceContentDownloadUrl(ownerId: string, workspaceId: string): Promise<string> {
+    public async createWorkspaceContentDownloadUrl(
+        ownerId: string,
+        workspaceId: string,
+        backupId?: string,
+    ): Promise<string> {
         const request = new WorkspaceDownloadURLRequest();
         request.setOwnerId(ownerId);
         request.setWorkspaceId(workspaceId);
+        if (backupId) {
+            request.setBackupId(backupId);
+        }
 
         const response = await new Promise<WorkspaceDownloadURLResponse>((resolve, reject) => {
             const client = this.workspaceServiceClientProvider.getDefault();
@@ -95,6 +105,24 @@ export class ContentServiceStorageClient implements StorageClient {
         return response.toObject().url;
     }
 
+    public async listWorkspaceBackups(ownerId: string, workspaceId: string): Promise<WorkspaceBackup.AsObject[]> {
+        const request = new ListWorkspaceBackupsRequest();
+        request.setOwnerId(ownerId);
+        request.setWorkspaceId(workspaceId);
+
+        const response = await new Promise<ListWorkspaceBackupsResponse>((resolve, reject) => {
+            const client = this.workspaceServiceClientProvider.getDefault();
+            client.listWorkspaceBackups(request, (err: any, resp: ListWorkspaceBackupsResponse) => {
+                if (err) {
+                    reject(err);
+                } else {
+                    resolve(resp);
+                }
+            });
+        });
+        return response.toObject().backupsList;
+    }
+
     public async createPluginUploadUrl(bucket: string, objectPath: string): Promise<string> {
         const request = new PluginUploadURLRequest();
         request.setBucket(bucket);
@@ -4,6 +4,8 @@
  * See License.AGPL.txt in the project root for license information.
  */
 
+import { WorkspaceBackup } from "@gitpod/content-service/lib/workspace_pb";
+
 export const StorageClient = Symbol("StorageClient");
 
 export interface StorageClient {
@@ -13,8 +15,12 @@ export interface StorageClient {
     // deleteWorkspaceBackups deletes storage objects for a given workspace
     deleteWorkspaceBackups(ownerId: string, workspaceId: string, includeSnapshots: boolean): Promise<void>;
 
-    // createWorkspaceContentDownloadUrl creates a signed URL from which one can download workspace content
-    createWorkspaceContentDownloadUrl(ownerId: string, workspaceId: string): Promise<string>;
+    // createWorkspaceContentDownloadUrl creates a signed URL from which one can download workspace content.
+    // If backupId is given, the URL points to that previous backup instead of the latest one.
+    createWorkspaceContentDownloadUrl(ownerId: string, workspaceId: string, backupId?: string): Promise<string>;
+
+    // listWorkspaceBackups lists the previous backups of a workspace which can be restored, newest first
+    listWorkspaceBackups(ownerId: string, workspaceId: string): Promise<WorkspaceBackup.AsObject[]>;
 
     createPluginUploadUrl(bucket: string, objectPath: string): Promise<string>;
     createPluginDownloadUrl(bucket: string, objectPath: string): Promise<string>;
@@ -2092,6 +2092,7 @@ export class GitpodServerImpl implements GitpodServerWithTracing, Disposable {
                         url: p.getUrl(),
                         visibility: this.portVisibilityFromProto(p.getVisibility()),
                         protocol: this.portProtocolFromProto(p.getProtocol()),
+                        tokenEpoch: p.getTokenEpoch() || undefined,
                     },
             );
 
@@ -2125,6 +2126,9 @@ export class GitpodServerImpl implements GitpodServerWithTracing, Disposable {
         spec.setPort(port.port);
         spec.setVisibility(this.portVisibilityToProto(port.visibility));
         spec.setProtocol(this.portProtocolToProto(port.protocol));
+        if (port.visibility === "protected") {
+            spec.setTokenEpoch(port.tokenEpoch || 0);
+        }
         req.setSpec(spec);
         req.setExpose(true);
 
@@ -2143,6 +2147,8 @@ export class GitpodServerImpl implements GitpodServerWithTracing, Disposable {
                 return "private";
             case ProtoPortVisibility.PORT_VISIBILITY_PUBLIC:
                 return "public";
+            case ProtoPortVisibility.PORT_VISIBILITY_PROTECTED:
+                return "protected";
         }
     }
 
@@ -2153,6 +2159,8 @@ export class GitpodServerImpl implements GitpodServerWithTracing, Disposable {
                 return ProtoPortVisibility.PORT_VISIBILITY_PRIVATE;
             case "public":
                 return ProtoPortVisibility.PORT_VISIBILITY_PUBLIC;
+            case "protected":
+                return ProtoPortVisibility.PORT_VISIBILITY_PROTECTED;
         }
     }
 
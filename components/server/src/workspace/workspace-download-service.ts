@@ -21,6 +21,7 @@ export class WorkspaceDownloadService {
     get apiRouter(): express.Router {
         const router = express.Router();
         this.addDownloadHandler(router);
+        this.addListBackupsHandler(router);
         return router;
     }
 
@@ -33,25 +34,20 @@ export class WorkspaceDownloadService {
             const userId = req.user.id;
 
             const workspaceId = req.params.id;
+            // backupId selects a previous backup as listed by /backups/:id instead of the latest one
+            const backupId = typeof req.query.backupId === "string" ? req.query.backupId : undefined;
             try {
-                const wsi = await this.workspaceDB.trace({}).findWorkspaceAndInstance(workspaceId);
-                if (!wsi || !!wsi.deleted || !!wsi.softDeleted) {
-                    res.sendStatus(404);
+                if (!(await this.mayAccessContent(req.user, workspaceId, res))) {
                     return;
                 }
 
-                if (
-                    wsi.ownerId !== userId &&
-                    !this.authorizationService.hasPermission(req.user, Permission.ADMIN_WORKSPACE_CONTENT)
-                ) {
-                    log.warn({ workspaceId, userId }, "user attempted to download someone else's workspace");
-                    res.sendStatus(500);
-                    return;
-                }
-
-                const signedUrl = await this.storageClient.createWorkspaceContentDownloadUrl(userId, workspaceId);
+                const signedUrl = await this.storageClient.createWorkspaceContentDownloadUrl(
+                    userId,
+                    workspaceId,
+                    backupId,
+                );
 
-                log.info({ workspaceId, userId }, "user is downloading workspace content");
+                log.info({ workspaceId, userId }, "user is downloading workspace content", { backupId });
                 res.send(signedUrl);
             } catch (err) {
                 log.error({ workspaceId }, "cannot prepare workspace download", err);
@@ -59,4 +55,46 @@ export class WorkspaceDownloadService {
             }
         });
     }
+
+    protected addListBackupsHandler(router: express.Router) {
+        router.get("/backups/:id", async (req, res, next) => {
+            if (!req.isAuthenticated() || !User.is(req.user)) {
+                res.sendStatus(500);
+                return;
+            }
+            const userId = req.user.id;
+
+            const workspaceId = req.params.id;
+            try {
+                if (!(await this.mayAccessContent(req.user, workspaceId, res))) {
+                    return;
+                }
+
+                const backups = await this.storageClient.listWorkspaceBackups(userId, workspaceId);
+                res.json(backups);
+            } catch (err) {
+                log.error({ workspaceId }, "cannot list workspace backups", err);
+                res.sendStatus(500);
+            }
+        });
+    }
+
+    // mayAccessContent checks whether the user may access the workspace content and answers the request if not
+    protected async mayAccessContent(user: User, workspaceId: string, res: express.Response): Promise<boolean> {
+        const wsi = await this.workspaceDB.trace({}).findWorkspaceAndInstance(workspaceId);
+        if (!wsi || !!wsi.deleted || !!wsi.softDeleted) {
+            res.sendStatus(404);
+            return false;
+        }
+
+        if (
+            wsi.ownerId !== user.id &&
+            !this.authorizationService.hasPermission(user, Permission.ADMIN_WORKSPACE_CONTENT)
+        ) {
+            log.warn({ workspaceId, userId: user.id }, "user attempted to access someone else's workspace content");
+            res.sendStatus(500);
+            return false;
+        }
+        return true;
+    }
 }
@@ -14,7 +14,7 @@ import {
     SnapshotInitializer,
     WorkspaceInitializer,
 } from "@gitpod/content-service/lib";
-import { CompositeInitializer, FromBackupInitializer } from "@gitpod/content-service/lib/initializer_pb";
+import { CompositeInitializer, FromBackupInitializer, GitLFSConfig } from "@gitpod/content-service/lib/initializer_pb";
 import {
     DBUser,
     DBWithTracing,
@@ -62,6 +62,7 @@ import {
 import { IAnalyticsWriter } from "@gitpod/gitpod-protocol/lib/analytics";
 import { AttributionId } from "@gitpod/gitpod-protocol/lib/attribution";
 import { getExperimentsClientForBackend } from "@gitpod/gitpod-protocol/lib/experiments/configcat-server";
+import { ErrorCodes } from "@gitpod/gitpod-protocol/lib/messaging/error";
 import { Deferred } from "@gitpod/gitpod-protocol/lib/util/deferred";
 import { LogContext, log } from "@gitpod/gitpod-protocol/lib/util/logging";
 import { repeat } from "@gitpod/gitpod-protocol/lib/util/repeat";
@@ -102,6 +103,7 @@ import * as crypto from "crypto";
 import { inject, injectable } from "inversify";
 import * as path from "path";
 import { v4 as uuidv4 } from "uuid";
+import { ResponseError } from "vscode-jsonrpc";
 import { HostContextProvider } from "../auth/host-context-provider";
 import { ScopedResourceGuard } from "../auth/resource-access";
 import { BillingModes } from "../billing/billing-mode";
@@ -274,6 +276,9 @@ export class WorkspaceStarter {
                     break;
                 }
             }
+            if (options.backupId && !lastValidWorkspaceInstance) {
+                throw new ResponseError(ErrorCodes.BAD_REQUEST, "Workspace has no backup to restore.");
+            }
 
             let ideSettings = options.ideSettings;
 
@@ -349,6 +354,7 @@ export class WorkspaceStarter {
                     options.rethrow,
                     forceRebuild,
                     options?.region,
+                    options.backupId,
                 ).catch((err) => log.error("actuallyStartWorkspace", err));
                 return { instanceID: instance.id };
             }
@@ -364,6 +370,7 @@ export class WorkspaceStarter {
                 options.rethrow,
                 forceRebuild,
                 options?.region,
+                options.backupId,
             );
         } catch (e) {
             this.logAndTraceStartWorkspaceError({ span }, { userId: user.id, instanceId }, e);
@@ -475,6 +482,7 @@ export class WorkspaceStarter {
         rethrow?: boolean,
         forceRebuild?: boolean,
         region?: WorkspaceRegion,
+        backupId?: string,
     ): Promise<StartWorkspaceResult> {
         const span = TraceContext.startSpan("actuallyStartWorkspace", ctx);
         span.setTag("region_preference", region);
@@ -508,6 +516,7 @@ export class WorkspaceStarter {
                 lastValidWorkspaceInstanceId,
                 ideConfig,
                 envVars,
+                backupId,
             );
 
             // create start workspace request
@@ -1322,6 +1331,7 @@ export class WorkspaceStarter {
         lastValidWorkspaceInstanceId: string,
         ideConfig: IdeServiceApi.ResolveWorkspaceConfigResponse,
         envVars: ResolvedEnvVars,
+        backupId?: string,
     ): Promise<StartWorkspaceSpec> {
         const context = workspace.context;
 
@@ -1474,6 +1484,7 @@ export class WorkspaceStarter {
             workspace.context,
             user,
             lastValidWorkspaceInstanceId,
+            backupId,
         );
         const userTimeoutPromise = this.entitlementService.getDefaultWorkspaceTimeout(user, new Date());
         const allowSetTimeoutPromise = this.entitlementService.maySetTimeout(user, new Date());
@@ -1645,6 +1656,7 @@ export class WorkspaceStarter {
         context: WorkspaceContext,
         user: User,
         lastValidWorkspaceInstanceId: string,
+        backupId?: string,
     ): Promise<{ initializer: WorkspaceInitializer; disposable: Disposable }> {
         let result = new WorkspaceInitializer();
         const disp = new DisposableCollection();
@@ -1654,6 +1666,9 @@ export class WorkspaceStarter {
             if (CommitContext.is(context)) {
                 backup.setCheckoutLocation(context.checkoutLocation || "");
             }
+            if (backupId) {
+                backup.setBackupId(backupId);
+            }
             result.setBackup(backup);
         } else if (SnapshotContext.is(context)) {
             const snapshot = new SnapshotInitializer();
@@ -1832,6 +1847,22 @@ export class WorkspaceStarter {
             result.setUpstreamRemoteUri(context.upstreamRemoteURI);
         }
 
+        const gitCheckout = workspace.config.gitCheckout;
+        if (!!gitCheckout) {
+            if (!!gitCheckout.partialCloneFilter) {
+                result.setPartialCloneFilter(gitCheckout.partialCloneFilter);
+            }
+            if (!!gitCheckout.sparseCheckout) {
+                result.setSparseCheckoutList(gitCheckout.sparseCheckout);
+            }
+            if (!!gitCheckout.lfs) {
+                const lfs = new GitLFSConfig();
+                lfs.setIncludeList(gitCheckout.lfs.include || []);
+                lfs.setExcludeList(gitCheckout.lfs.exclude || []);
+                result.setLfs(lfs);
+            }
+        }
+
         return {
             initializer: result,
         };
@@ -37,6 +37,11 @@ type PortServiceClient interface {
 	AutoTunnel(ctx context.Context, in *AutoTunnelRequest, opts ...grpc.CallOption) (*AutoTunnelResponse, error)
 	// RetryAutoExpose retries auto exposing the give port
 	RetryAutoExpose(ctx context.Context, in *RetryAutoExposeRequest, opts ...grpc.CallOption) (*RetryAutoExposeResponse, error)
+	// SharePort mints a token which grants access to a non-public port until it expires.
+	// A private port becomes protected by sharing it.
+	SharePort(ctx context.Context, in *SharePortRequest, opts ...grpc.CallOption) (*SharePortResponse, error)
+	// KillPortProcess terminates the process which serves the given port.
+	KillPortProcess(ctx context.Context, in *KillPortProcessRequest, opts ...grpc.CallOption) (*KillPortProcessResponse, error)
 }
 
 type portServiceClient struct {
@@ -114,6 +119,24 @@ func (c *portServiceClient) RetryAutoExpose(ctx context.Context, in *RetryAutoEx
 	return out, nil
 }
 
+func (c *portServiceClient) SharePort(ctx context.Context, in *SharePortRequest, opts ...grpc.CallOption) (*SharePortResponse, error) {
+	out := new(SharePortResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.PortService/SharePort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portServiceClient) KillPortProcess(ctx context.Context, in *KillPortProcessRequest, opts ...grpc.CallOption) (*KillPortProcessResponse, error) {
+	out := new(KillPortProcessResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.PortService/KillPortProcess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PortServiceServer is the server API for PortService service.
 // All implementations must embed UnimplementedPortServiceServer
 // for forward compatibility
@@ -129,6 +152,11 @@ type PortServiceServer interface {
 	AutoTunnel(context.Context, *AutoTunnelRequest) (*AutoTunnelResponse, error)
 	// RetryAutoExpose retries auto exposing the give port
 	RetryAutoExpose(context.Context, *RetryAutoExposeRequest) (*RetryAutoExposeResponse, error)
+	// SharePort mints a token which grants access to a non-public port until it expires.
+	// A private port becomes protected by sharing it.
+	SharePort(context.Context, *SharePortRequest) (*SharePortResponse, error)
+	// KillPortProcess terminates the process which serves the given port.
+	KillPortProcess(context.Context, *KillPortProcessRequest) (*KillPortProcessResponse, error)
 	mustEmbedUnimplementedPortServiceServer()
 }
 
@@ -151,6 +179,12 @@ func (UnimplementedPortServiceServer) AutoTunnel(context.Context, *AutoTunnelReq
 func (UnimplementedPortServiceServer) RetryAutoExpose(context.Context, *RetryAutoExposeRequest) (*RetryAutoExposeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetryAutoExpose not implemented")
 }
+func (UnimplementedPortServiceServer) SharePort(context.Context, *SharePortRequest) (*SharePortResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SharePort not implemented")
+}
+func (UnimplementedPortServiceServer) KillPortProcess(context.Context, *KillPortProcessRequest) (*KillPortProcessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KillPortProcess not implemented")
+}
 func (UnimplementedPortServiceServer) mustEmbedUnimplementedPortServiceServer() {}
 
 // UnsafePortServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -262,6 +296,42 @@ func _PortService_RetryAutoExpose_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PortService_SharePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharePortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortServiceServer).SharePort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.PortService/SharePort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortServiceServer).SharePort(ctx, req.(*SharePortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PortService_KillPortProcess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KillPortProcessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortServiceServer).KillPortProcess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.PortService/KillPortProcess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortServiceServer).KillPortProcess(ctx, req.(*KillPortProcessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PortService_ServiceDesc is the grpc.ServiceDesc for PortService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -285,6 +355,14 @@ var PortService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RetryAutoExpose",
 			Handler:    _PortService_RetryAutoExpose_Handler,
 		},
+		{
+			MethodName: "SharePort",
+			Handler:    _PortService_SharePort_Handler,
+		},
+		{
+			MethodName: "KillPortProcess",
+			Handler:    _PortService_KillPortProcess_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -45,6 +45,16 @@ type TerminalServiceClient interface {
 	SetTitle(ctx context.Context, in *SetTerminalTitleRequest, opts ...grpc.CallOption) (*SetTerminalTitleResponse, error)
 	// UpdateAnnotations updates the terminal's annotations
 	UpdateAnnotations(ctx context.Context, in *UpdateTerminalAnnotationsRequest, opts ...grpc.CallOption) (*UpdateTerminalAnnotationsResponse, error)
+	// SetClientRole changes the role of a client listening to the terminal. Only the owner can change roles.
+	SetClientRole(ctx context.Context, in *SetTerminalClientRoleRequest, opts ...grpc.CallOption) (*SetTerminalClientRoleResponse, error)
+	// ReadScrollback reads a range of the terminal's output from its on-disk scrollback.
+	ReadScrollback(ctx context.Context, in *ReadTerminalScrollbackRequest, opts ...grpc.CallOption) (*ReadTerminalScrollbackResponse, error)
+	// SearchScrollback searches the terminal's on-disk scrollback for lines matching a regular expression.
+	SearchScrollback(ctx context.Context, in *SearchTerminalScrollbackRequest, opts ...grpc.CallOption) (*SearchTerminalScrollbackResponse, error)
+	// ListRecordings lists finished terminal recordings.
+	ListRecordings(ctx context.Context, in *ListTerminalRecordingsRequest, opts ...grpc.CallOption) (*ListTerminalRecordingsResponse, error)
+	// DownloadRecording downloads a finished terminal recording in the asciicast v2 format.
+	DownloadRecording(ctx context.Context, in *DownloadTerminalRecordingRequest, opts ...grpc.CallOption) (TerminalService_DownloadRecordingClient, error)
 }
 
 type terminalServiceClient struct {
@@ -159,6 +169,74 @@ func (c *terminalServiceClient) UpdateAnnotations(ctx context.Context, in *Updat
 	return out, nil
 }
 
+func (c *terminalServiceClient) SetClientRole(ctx context.Context, in *SetTerminalClientRoleRequest, opts ...grpc.CallOption) (*SetTerminalClientRoleResponse, error) {
+	out := new(SetTerminalClientRoleResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TerminalService/SetClientRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terminalServiceClient) ReadScrollback(ctx context.Context, in *ReadTerminalScrollbackRequest, opts ...grpc.CallOption) (*ReadTerminalScrollbackResponse, error) {
+	out := new(ReadTerminalScrollbackResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TerminalService/ReadScrollback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terminalServiceClient) SearchScrollback(ctx context.Context, in *SearchTerminalScrollbackRequest, opts ...grpc.CallOption) (*SearchTerminalScrollbackResponse, error) {
+	out := new(SearchTerminalScrollbackResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TerminalService/SearchScrollback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terminalServiceClient) ListRecordings(ctx context.Context, in *ListTerminalRecordingsRequest, opts ...grpc.CallOption) (*ListTerminalRecordingsResponse, error) {
+	out := new(ListTerminalRecordingsResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TerminalService/ListRecordings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terminalServiceClient) DownloadRecording(ctx context.Context, in *DownloadTerminalRecordingRequest, opts ...grpc.CallOption) (TerminalService_DownloadRecordingClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TerminalService_ServiceDesc.Streams[1], "/supervisor.TerminalService/DownloadRecording", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &terminalServiceDownloadRecordingClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TerminalService_DownloadRecordingClient interface {
+	Recv() (*DownloadTerminalRecordingResponse, error)
+	grpc.ClientStream
+}
+
+type terminalServiceDownloadRecordingClient struct {
+	grpc.ClientStream
+}
+
+func (x *terminalServiceDownloadRecordingClient) Recv() (*DownloadTerminalRecordingResponse, error) {
+	m := new(DownloadTerminalRecordingResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TerminalServiceServer is the server API for TerminalService service.
 // All implementations must embed UnimplementedTerminalServiceServer
 // for forward compatibility
@@ -182,6 +260,16 @@ type TerminalServiceServer interface {
 	SetTitle(context.Context, *SetTerminalTitleRequest) (*SetTerminalTitleResponse, error)
 	// UpdateAnnotations updates the terminal's annotations
 	UpdateAnnotations(context.Context, *UpdateTerminalAnnotationsRequest) (*UpdateTerminalAnnotationsResponse, error)
+	// SetClientRole changes the role of a client listening to the terminal. Only the owner can change roles.
+	SetClientRole(context.Context, *SetTerminalClientRoleRequest) (*SetTerminalClientRoleResponse, error)
+	// ReadScrollback reads a range of the terminal's output from its on-disk scrollback.
+	ReadScrollback(context.Context, *ReadTerminalScrollbackRequest) (*ReadTerminalScrollbackResponse, error)
+	// SearchScrollback searches the terminal's on-disk scrollback for lines matching a regular expression.
+	SearchScrollback(context.Context, *SearchTerminalScrollbackRequest) (*SearchTerminalScrollbackResponse, error)
+	// ListRecordings lists finished terminal recordings.
+	ListRecordings(context.Context, *ListTerminalRecordingsRequest) (*ListTerminalRecordingsResponse, error)
+	// DownloadRecording downloads a finished terminal recording in the asciicast v2 format.
+	DownloadRecording(*DownloadTerminalRecordingRequest, TerminalService_DownloadRecordingServer) error
 	mustEmbedUnimplementedTerminalServiceServer()
 }
 
@@ -216,6 +304,21 @@ func (UnimplementedTerminalServiceServer) SetTitle(context.Context, *SetTerminal
 func (UnimplementedTerminalServiceServer) UpdateAnnotations(context.Context, *UpdateTerminalAnnotationsRequest) (*UpdateTerminalAnnotationsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateAnnotations not implemented")
 }
+func (UnimplementedTerminalServiceServer) SetClientRole(context.Context, *SetTerminalClientRoleRequest) (*SetTerminalClientRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetClientRole not implemented")
+}
+func (UnimplementedTerminalServiceServer) ReadScrollback(context.Context, *ReadTerminalScrollbackRequest) (*ReadTerminalScrollbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadScrollback not implemented")
+}
+func (UnimplementedTerminalServiceServer) SearchScrollback(context.Context, *SearchTerminalScrollbackRequest) (*SearchTerminalScrollbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchScrollback not implemented")
+}
+func (UnimplementedTerminalServiceServer) ListRecordings(context.Context, *ListTerminalRecordingsRequest) (*ListTerminalRecordingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecordings not implemented")
+}
+func (UnimplementedTerminalServiceServer) DownloadRecording(*DownloadTerminalRecordingRequest, TerminalService_DownloadRecordingServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadRecording not implemented")
+}
 func (UnimplementedTerminalServiceServer) mustEmbedUnimplementedTerminalServiceServer() {}
 
 // UnsafeTerminalServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -394,6 +497,99 @@ func _TerminalService_UpdateAnnotations_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TerminalService_SetClientRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTerminalClientRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerminalServiceServer).SetClientRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TerminalService/SetClientRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerminalServiceServer).SetClientRole(ctx, req.(*SetTerminalClientRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerminalService_ReadScrollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadTerminalScrollbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerminalServiceServer).ReadScrollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TerminalService/ReadScrollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerminalServiceServer).ReadScrollback(ctx, req.(*ReadTerminalScrollbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerminalService_SearchScrollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTerminalScrollbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerminalServiceServer).SearchScrollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TerminalService/SearchScrollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerminalServiceServer).SearchScrollback(ctx, req.(*SearchTerminalScrollbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerminalService_ListRecordings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTerminalRecordingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerminalServiceServer).ListRecordings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TerminalService/ListRecordings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerminalServiceServer).ListRecordings(ctx, req.(*ListTerminalRecordingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerminalService_DownloadRecording_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadTerminalRecordingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerminalServiceServer).DownloadRecording(m, &terminalServiceDownloadRecordingServer{stream})
+}
+
+type TerminalService_DownloadRecordingServer interface {
+	Send(*DownloadTerminalRecordingResponse) error
+	grpc.ServerStream
+}
+
+type terminalServiceDownloadRecordingServer struct {
+	grpc.ServerStream
+}
+
+func (x *terminalServiceDownloadRecordingServer) Send(m *DownloadTerminalRecordingResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // TerminalService_ServiceDesc is the grpc.ServiceDesc for TerminalService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -433,6 +629,22 @@ var TerminalService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateAnnotations",
 			Handler:    _TerminalService_UpdateAnnotations_Handler,
 		},
+		{
+			MethodName: "SetClientRole",
+			Handler:    _TerminalService_SetClientRole_Handler,
+		},
+		{
+			MethodName: "ReadScrollback",
+			Handler:    _TerminalService_ReadScrollback_Handler,
+		},
+		{
+			MethodName: "SearchScrollback",
+			Handler:    _TerminalService_SearchScrollback_Handler,
+		},
+		{
+			MethodName: "ListRecordings",
+			Handler:    _TerminalService_ListRecordings_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -440,6 +652,11 @@ var TerminalService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TerminalService_Listen_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DownloadRecording",
+			Handler:       _TerminalService_DownloadRecording_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "terminal.proto",
 }
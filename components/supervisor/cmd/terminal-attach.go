@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/creack/pty"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 
@@ -77,13 +78,28 @@ type attachToTerminalOpts struct {
 }
 
 func attachToTerminal(ctx context.Context, client api.TerminalServiceClient, alias string, opts attachToTerminalOpts) {
+	// supervisor only accepts writes from clients which listen to the terminal with an ID and present their write token
+	clientID := "supervisor-" + uuid.New().String()
+	role := api.TerminalClientRole_read_only
+	if opts.Interactive {
+		role = api.TerminalClientRole_writer
+	}
+
 	// Copy to stdout/stderr
 	listen, err := client.Listen(ctx, &api.ListenTerminalRequest{
-		Alias: alias,
+		Alias:    alias,
+		ClientId: clientID,
+		Role:     role,
 	})
 	if err != nil {
 		log.WithError(err).Fatal("cannot attach to terminal")
 	}
+	// clients with ID receive their write token first
+	first, err := listen.Recv()
+	if err != nil {
+		log.WithError(err).Fatal("cannot attach to terminal")
+	}
+	writeToken := first.GetWriteToken()
 	var exitCode int
 	errchan := make(chan error, 5)
 	go func() {
@@ -120,7 +136,9 @@ func attachToTerminal(ctx context.Context, client api.TerminalServiceClient, ali
 				}
 
 				req := &api.SetTerminalSizeRequest{
-					Alias: alias,
+					Alias:      alias,
+					ClientId:   clientID,
+					WriteToken: writeToken,
 					Size: &api.TerminalSize{
 						Cols:     uint32(size.Cols),
 						Rows:     uint32(size.Rows),
@@ -153,7 +171,7 @@ func attachToTerminal(ctx context.Context, client api.TerminalServiceClient, ali
 			for {
 				n, err := os.Stdin.Read(buf)
 				if n > 0 {
-					_, serr := client.Write(ctx, &api.WriteTerminalRequest{Alias: alias, Stdin: buf[:n]})
+					_, serr := client.Write(ctx, &api.WriteTerminalRequest{Alias: alias, Stdin: buf[:n], ClientId: clientID, WriteToken: writeToken})
 					if serr != nil {
 						errchan <- err
 						return
@@ -6,14 +6,18 @@ package ports
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"sync"
 	"time"
 
 	backoff "github.com/cenkalti/backoff/v4"
 	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/porttoken"
 	gitpod "github.com/gitpod-io/gitpod/gitpod-protocol"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/serverapi"
+	"golang.org/x/xerrors"
 )
 
 // ExposedPort represents an exposed pprt
@@ -21,9 +25,18 @@ type ExposedPort struct {
 	LocalPort uint32
 	URL       string
 	Public    bool
+	// Protected ports are private ports which can also be accessed with a share token
+	Protected bool
 	Protocol  string
 }
 
+// SharedPort describes a token which grants access to a protected port
+type SharedPort struct {
+	Token     string
+	URL       string
+	ExpiresAt time.Time
+}
+
 // ExposedPortsInterface provides access to port exposure
 type ExposedPortsInterface interface {
 	// Observe starts observing the exposed ports until the context is canceled.
@@ -37,9 +50,20 @@ type ExposedPortsInterface interface {
 	Run(ctx context.Context)
 
 	// Expose exposes a port to the internet. Upon successful execution any Observer will be updated.
-	Expose(ctx context.Context, port uint32, public bool, protocol string) <-chan error
+	// Visibility is one of gitpod.PortVisibilityPrivate, gitpod.PortVisibilityProtected or gitpod.PortVisibilityPublic.
+	Expose(ctx context.Context, port uint32, visibility string, protocol string) <-chan error
+
+	// Share mints a token which grants access to a protected port until it expires.
+	Share(ctx context.Context, port uint32, ttl time.Duration) (*SharedPort, error)
 }
 
+var (
+	// ErrSharingUnavailable is returned if ports cannot be shared
+	ErrSharingUnavailable = errors.New("port sharing is not available")
+	// ErrPortNotProtected is returned when sharing a port which has not been exposed as protected
+	ErrPortNotProtected = errors.New("port is not protected")
+)
+
 // NoopExposedPorts implements ExposedPortsInterface but does nothing
 type NoopExposedPorts struct{}
 
@@ -52,12 +76,17 @@ func (*NoopExposedPorts) Observe(ctx context.Context) (<-chan []ExposedPort, <-c
 func (*NoopExposedPorts) Run(ctx context.Context) {}
 
 // Expose exposes a port to the internet. Upon successful execution any Observer will be updated.
-func (*NoopExposedPorts) Expose(ctx context.Context, local uint32, public bool, protocol string) <-chan error {
+func (*NoopExposedPorts) Expose(ctx context.Context, local uint32, visibility string, protocol string) <-chan error {
 	done := make(chan error)
 	close(done)
 	return done
 }
 
+// Share mints a token which grants access to a protected port until it expires.
+func (*NoopExposedPorts) Share(ctx context.Context, port uint32, ttl time.Duration) (*SharedPort, error) {
+	return nil, ErrSharingUnavailable
+}
+
 // GitpodExposedPorts uses a connection to the Gitpod server to implement
 // the ExposedPortsInterface.
 type GitpodExposedPorts struct {
@@ -70,6 +99,12 @@ type GitpodExposedPorts struct {
 	localExposedNotice chan struct{}
 	lastServerExposed  []*gitpod.WorkspaceInstancePort
 
+	// protectedPorts maps the ports which were exposed as protected to their token epoch.
+	// Share tokens are bound to the epoch, and every time a port becomes protected it gets a new one.
+	protectedPorts map[uint32]uint64
+	lastTokenEpoch uint64
+	protectedMu    sync.RWMutex
+
 	requests chan *exposePortRequest
 }
 
@@ -90,6 +125,7 @@ func NewGitpodExposedPorts(workspaceID string, instanceID string, workspaceUrl s
 		// allow clients to submit 30 expose requests without blocking
 		requests:           make(chan *exposePortRequest, 30),
 		localExposedNotice: make(chan struct{}, 30),
+		protectedPorts:     make(map[uint32]uint64),
 	}
 }
 
@@ -111,6 +147,37 @@ func (g *GitpodExposedPorts) getPortProtocol(protocol string) string {
 	}
 }
 
+// tokenEpoch returns the token epoch of a protected port, or 0 if the port is not protected.
+func (g *GitpodExposedPorts) tokenEpoch(port uint32) uint64 {
+	g.protectedMu.RLock()
+	defer g.protectedMu.RUnlock()
+	return g.protectedPorts[port]
+}
+
+// setProtected records whether a port is protected and returns its token epoch and whether that changed.
+// Ports which become protected get a new epoch, s.t. tokens minted for an earlier protection are invalid.
+func (g *GitpodExposedPorts) setProtected(port uint32, protected bool) (epoch uint64, changed bool) {
+	g.protectedMu.Lock()
+	defer g.protectedMu.Unlock()
+	epoch, exists := g.protectedPorts[port]
+	if exists == protected {
+		return epoch, false
+	}
+	if !protected {
+		delete(g.protectedPorts, port)
+		return 0, true
+	}
+
+	// epochs are based on time s.t. they keep increasing across supervisor restarts
+	epoch = uint64(time.Now().UnixMilli())
+	if epoch <= g.lastTokenEpoch {
+		epoch = g.lastTokenEpoch + 1
+	}
+	g.lastTokenEpoch = epoch
+	g.protectedPorts[port] = epoch
+	return epoch, true
+}
+
 func (g *GitpodExposedPorts) existInLocalExposed(port uint32) bool {
 	for _, p := range g.localExposedPort {
 		if p == port {
@@ -142,15 +209,18 @@ func (g *GitpodExposedPorts) Observe(ctx context.Context) (<-chan []ExposedPort,
 				res[port] = ExposedPort{
 					LocalPort: port,
 					Public:    false,
+					Protected: g.tokenEpoch(port) != 0,
 					URL:       g.getPortUrl(port),
 					Protocol:  gitpod.PortProtocolHTTP,
 				}
 			}
 
 			for _, p := range serverExposePort {
+				public := p.Visibility == gitpod.PortVisibilityPublic
 				res[uint32(p.Port)] = ExposedPort{
 					LocalPort: uint32(p.Port),
-					Public:    p.Visibility == "public",
+					Public:    public,
+					Protected: p.Visibility == gitpod.PortVisibilityProtected,
 					URL:       g.getPortUrl(uint32(p.Port)),
 					Protocol:  g.getPortProtocol(p.Protocol),
 				}
@@ -238,11 +308,20 @@ func (g *GitpodExposedPorts) doExpose(req *exposePortRequest) {
 }
 
 // Expose exposes a port to the internet. Upon successful execution any Observer will be updated.
-func (g *GitpodExposedPorts) Expose(ctx context.Context, local uint32, public bool, protocol string) <-chan error {
+func (g *GitpodExposedPorts) Expose(ctx context.Context, local uint32, visibility string, protocol string) <-chan error {
 	if protocol != gitpod.PortProtocolHTTPS && protocol != gitpod.PortProtocolHTTP {
 		protocol = gitpod.PortProtocolHTTP
 	}
-	if !public && protocol != gitpod.PortProtocolHTTPS {
+	if visibility != gitpod.PortVisibilityPublic && visibility != gitpod.PortVisibilityProtected {
+		visibility = gitpod.PortVisibilityPrivate
+	}
+	epoch, protectionChanged := g.setProtected(local, visibility == gitpod.PortVisibilityProtected)
+	if protectionChanged {
+		g.localExposedNotice <- struct{}{}
+	}
+	// ws-proxy checks share tokens against the epoch known to the server, hence protected ports
+	// and ports which stopped being protected must reach the server.
+	if visibility == gitpod.PortVisibilityPrivate && !protectionChanged && protocol != gitpod.PortProtocolHTTPS {
 		if !g.existInLocalExposed(local) {
 			g.localExposedPort = append(g.localExposedPort, local)
 			g.localExposedNotice <- struct{}{}
@@ -251,15 +330,12 @@ func (g *GitpodExposedPorts) Expose(ctx context.Context, local uint32, public bo
 		close(c)
 		return c
 	}
-	visibility := gitpod.PortVisibilityPrivate
-	if public {
-		visibility = gitpod.PortVisibilityPublic
-	}
 	req := &exposePortRequest{
 		port: &gitpod.WorkspaceInstancePort{
 			Port:       float64(local),
 			Visibility: visibility,
 			Protocol:   protocol,
+			TokenEpoch: float64(epoch),
 		},
 		ctx:  ctx,
 		done: make(chan error),
@@ -267,3 +343,31 @@ func (g *GitpodExposedPorts) Expose(ctx context.Context, local uint32, public bo
 	g.requests <- req
 	return req.done
 }
+
+// Share mints a token which grants access to a protected port until it expires.
+func (g *GitpodExposedPorts) Share(ctx context.Context, port uint32, ttl time.Duration) (*SharedPort, error) {
+	ownerToken, err := g.gitpodService.GetOwnerToken(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot get owner token: %w", err)
+	}
+	u, err := url.Parse(g.getPortUrl(port))
+	if err != nil {
+		return nil, err
+	}
+
+	epoch := g.tokenEpoch(port)
+	if epoch == 0 {
+		return nil, ErrPortNotProtected
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	token := porttoken.Mint(ownerToken, g.InstanceID, port, epoch, expiresAt)
+	q := u.Query()
+	q.Set(porttoken.QueryParam, token)
+	u.RawQuery = q.Encode()
+	return &SharedPort{
+		Token:     token,
+		URL:       u.String(),
+		ExpiresAt: expiresAt,
+	}, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/gitpod-io/gitpod/common-go/porttoken"
+	gitpod "github.com/gitpod-io/gitpod/gitpod-protocol"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/serverapi"
+)
+
+type openPortRecorder struct {
+	serverapi.APIInterface
+	opened []gitpod.WorkspaceInstancePort
+}
+
+func (r *openPortRecorder) OpenPort(ctx context.Context, port *gitpod.WorkspaceInstancePort) (*gitpod.WorkspaceInstancePort, error) {
+	r.opened = append(r.opened, *port)
+	return port, nil
+}
+
+func (r *openPortRecorder) GetOwnerToken(ctx context.Context) (string, error) {
+	return "owner-token", nil
+}
+
+func TestGitpodExposedPortsProtection(t *testing.T) {
+	server := &openPortRecorder{}
+	g := NewGitpodExposedPorts("workspace", "instance", "https://workspace.gitpod.io", server)
+	expose := func(visibility string) {
+		done := g.Expose(context.Background(), 8080, visibility, gitpod.PortProtocolHTTP)
+		select {
+		case req := <-g.requests:
+			g.doExpose(req)
+		default:
+		}
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// private HTTP ports are exposed locally only
+	expose(gitpod.PortVisibilityPrivate)
+	if len(server.opened) != 0 {
+		t.Fatalf("private port was sent to the server: %v", server.opened)
+	}
+
+	expose(gitpod.PortVisibilityProtected)
+	if len(server.opened) != 1 || server.opened[0].Visibility != gitpod.PortVisibilityProtected || server.opened[0].TokenEpoch == 0 {
+		t.Fatalf("protected port was not sent to the server with a token epoch: %v", server.opened)
+	}
+	epoch := uint64(server.opened[0].TokenEpoch)
+
+	shared, err := g.Share(context.Background(), 8080, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := porttoken.Verify("owner-token", "instance", 8080, epoch, shared.Token, time.Now()); err != nil {
+		t.Errorf("share token is not valid for the port's token epoch: %v", err)
+	}
+
+	// making the port private again must reach the server to invalidate the share tokens
+	expose(gitpod.PortVisibilityPrivate)
+	if diff := cmp.Diff(gitpod.WorkspaceInstancePort{Port: 8080, Visibility: gitpod.PortVisibilityPrivate, Protocol: gitpod.PortProtocolHTTP}, server.opened[len(server.opened)-1]); diff != "" {
+		t.Errorf("unexpected port sent to the server (-want +got):\n%s", diff)
+	}
+	if _, err := g.Share(context.Background(), 8080, time.Hour); err != ErrPortNotProtected {
+		t.Errorf("expected ErrPortNotProtected sharing a private port, got %v", err)
+	}
+
+	expose(gitpod.PortVisibilityProtected)
+	if next := uint64(server.opened[len(server.opened)-1].TokenEpoch); next <= epoch {
+		t.Errorf("protecting a port again must use a new token epoch: got %d after %d", next, epoch)
+	}
+	if err := porttoken.Verify("owner-token", "instance", 8080, uint64(server.opened[len(server.opened)-1].TokenEpoch), shared.Token, time.Now()); err == nil {
+		t.Error("share token minted for an earlier protection is still valid")
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"os"
 	"reflect"
 	"sort"
 	"sync"
@@ -52,9 +53,10 @@ func NewManager(exposed ExposedPortsInterface, served ServedPortsObserver, confi
 		internal:     internal,
 		proxies:      make(map[uint32]*localhostProxy),
 		autoExposed:  make(map[uint32]*autoExposure),
-		autoTunneled: make(map[uint32]struct{}),
+		autoTunneled: make(map[portKey]struct{}),
 
 		state:         state,
+		udpState:      make(map[uint32]*managedPort),
 		subscriptions: make(map[*Subscription]struct{}),
 		proxyStarter:  startLocalhostProxy,
 
@@ -67,11 +69,17 @@ type localhostProxy struct {
 	proxyPort uint32
 }
 
+// portKey identifies a port served on a transport protocol.
+type portKey struct {
+	port     uint32
+	protocol api.TransportProtocol
+}
+
 type autoExposure struct {
-	state    api.PortAutoExposure
-	ctx      context.Context
-	public   bool
-	protocol string
+	state      api.PortAutoExposure
+	ctx        context.Context
+	visibility string
+	protocol   string
 }
 
 // Manager brings together served and exposed ports. It keeps track of which port is exposed, which one is served,
@@ -81,6 +89,8 @@ type Manager struct {
 	S ServedPortsObserver
 	C ConfigInterace
 	T TunneledPortsInterface
+	// P finds the processes serving ports. If it is nil, the processes are not reported.
+	P ProcessResolver
 
 	forceUpdates chan struct{}
 
@@ -89,34 +99,40 @@ type Manager struct {
 	proxyStarter func(port uint32) (proxy io.Closer, err error)
 	autoExposed  map[uint32]*autoExposure
 
-	autoTunneled      map[uint32]struct{}
+	autoTunneled      map[portKey]struct{}
 	autoTunnelEnabled bool
 
 	configs  *Configs
 	exposed  []ExposedPort
 	served   []ServedPort
 	tunneled []PortTunnelState
+	// processes maps the socket inodes of served ports to the processes serving them
+	processes map[uint64]*ServingProcess
 
 	state map[uint32]*managedPort
-	mu    sync.RWMutex
+	// udpState holds the UDP ports. They can be tunneled, but not exposed.
+	udpState map[uint32]*managedPort
+	mu       sync.RWMutex
 
 	subscriptions map[*Subscription]struct{}
 	closed        bool
 }
 
 type managedPort struct {
-	Served       bool
-	Exposed      bool
-	Visibility   api.PortVisibility
-	Protocol     api.PortProtocol
-	Description  string
-	Name         string
-	URL          string
-	OnExposed    api.OnPortExposedAction // deprecated
-	OnOpen       api.PortsStatus_OnOpenAction
-	AutoExposure api.PortAutoExposure
+	Served            bool
+	Exposed           bool
+	Visibility        api.PortVisibility
+	Protocol          api.PortProtocol
+	TransportProtocol api.TransportProtocol
+	Description       string
+	Name              string
+	URL               string
+	OnExposed         api.OnPortExposedAction // deprecated
+	OnOpen            api.PortsStatus_OnOpenAction
+	AutoExposure      api.PortAutoExposure
 
 	LocalhostPort uint32
+	Process       *ServingProcess
 
 	Tunneled           bool
 	TunneledTargetPort uint32
@@ -266,26 +282,30 @@ func (pm *Manager) updateState(ctx context.Context, exposed []ExposedPort, serve
 	}
 
 	if served != nil {
-		servedMap := make(map[uint32]ServedPort)
+		servedMap := make(map[portKey]ServedPort)
 		for _, port := range served {
-			if _, existProxy := pm.proxies[port.Port]; existProxy && port.Address.String() == workspaceIPAdress {
+			if _, existProxy := pm.proxies[port.Port]; existProxy && port.Protocol == api.TransportProtocol_tcp && port.Address.String() == workspaceIPAdress {
 				// Ignore entries that are bound to the workspace ip address
 				// as they are created by the internal reverse proxy
 				continue
 			}
 
-			current, exists := servedMap[port.Port]
+			key := portKey{port.Port, port.Protocol}
+			current, exists := servedMap[key]
 			if !exists || (!port.BoundToLocalhost && current.BoundToLocalhost) {
-				servedMap[port.Port] = port
+				servedMap[key] = port
 			}
 		}
 
-		var servedKeys []uint32
+		var servedKeys []portKey
 		for k := range servedMap {
 			servedKeys = append(servedKeys, k)
 		}
 		sort.Slice(servedKeys, func(i, j int) bool {
-			return servedKeys[i] < servedKeys[j]
+			if servedKeys[i].port == servedKeys[j].port {
+				return servedKeys[i].protocol < servedKeys[j].protocol
+			}
+			return servedKeys[i].port < servedKeys[j].port
 		})
 
 		var newServed []ServedPort
@@ -296,6 +316,7 @@ func (pm *Manager) updateState(ctx context.Context, exposed []ExposedPort, serve
 		if !reflect.DeepEqual(pm.served, newServed) {
 			log.WithField("served", newServed).Debug("updating served ports")
 			pm.served = newServed
+			pm.processes = pm.resolveProcesses()
 			pm.updateProxies()
 			pm.autoTunnel(ctx)
 		}
@@ -306,8 +327,10 @@ func (pm *Manager) updateState(ctx context.Context, exposed []ExposedPort, serve
 	}
 
 	newState := pm.nextState(ctx)
-	stateChanged := !reflect.DeepEqual(newState, pm.state)
+	newUDPState := pm.nextUDPState()
+	stateChanged := !reflect.DeepEqual(newState, pm.state) || !reflect.DeepEqual(newUDPState, pm.udpState)
 	pm.state = newState
+	pm.udpState = newUDPState
 
 	if !stateChanged && configured == nil {
 		return
@@ -359,6 +382,8 @@ func (pm *Manager) nextState(ctx context.Context) map[uint32]*managedPort {
 		Visibility := api.PortVisibility_private
 		if exposed.Public {
 			Visibility = api.PortVisibility_public
+		} else if exposed.Protected {
+			Visibility = api.PortVisibility_protected
 		}
 		portProtocol := api.PortProtocol_http
 		if exposed.Protocol == gitpod.PortProtocolHTTPS {
@@ -373,7 +398,7 @@ func (pm *Manager) nextState(ctx context.Context) map[uint32]*managedPort {
 
 	for _, tunneled := range pm.tunneled {
 		port := tunneled.Desc.LocalPort
-		if pm.boundInternally(port) {
+		if pm.boundInternally(port) || tunneled.Desc.Protocol != api.TransportProtocol_tcp {
 			continue
 		}
 		mp := genManagedPort(port)
@@ -398,12 +423,8 @@ func (pm *Manager) nextState(ctx context.Context) map[uint32]*managedPort {
 				return
 			}
 
-			mp.Visibility = api.PortVisibility_private
-			if config.Visibility == "public" {
-				mp.Visibility = api.PortVisibility_public
-			}
-			public := mp.Visibility == api.PortVisibility_public
-			mp.AutoExposure = pm.autoExpose(ctx, mp.LocalhostPort, public, config.Protocol).state
+			mp.Visibility = toAPIVisibility(config.Visibility)
+			mp.AutoExposure = pm.autoExpose(ctx, mp.LocalhostPort, toGitpodVisibility(mp.Visibility), config.Protocol).state
 		})
 	}
 
@@ -412,11 +433,12 @@ func (pm *Manager) nextState(ctx context.Context) map[uint32]*managedPort {
 	// and need configured to decide about default visiblity properly
 	for _, served := range pm.served {
 		port := served.Port
-		if pm.boundInternally(port) {
+		if pm.boundInternally(port) || served.Protocol != api.TransportProtocol_tcp {
 			continue
 		}
 		mp := genManagedPort(port)
 		mp.Served = true
+		mp.Process = pm.processes[served.Inode]
 
 		autoExposure, autoExposed := pm.autoExposed[port]
 		if autoExposed {
@@ -424,7 +446,7 @@ func (pm *Manager) nextState(ctx context.Context) map[uint32]*managedPort {
 			continue
 		}
 
-		var public bool
+		visibility := api.PortVisibility_private
 		protocol := "http"
 		config, kind, exists := pm.configs.Get(mp.LocalhostPort)
 
@@ -439,18 +461,18 @@ func (pm *Manager) nextState(ctx context.Context) map[uint32]*managedPort {
 
 		configured := exists && kind == PortConfigKind
 		if mp.Exposed || configured {
-			public = mp.Visibility == api.PortVisibility_public
+			visibility = mp.Visibility
 			protocol = getProtocol(mp.Protocol)
 		} else if exists {
-			public = config.Visibility == "public"
+			visibility = toAPIVisibility(config.Visibility)
 			protocol = config.Protocol
 		}
 
-		if mp.Exposed && ((mp.Visibility == api.PortVisibility_public && public) || (mp.Visibility == api.PortVisibility_private && !public)) && protocol != "https" {
+		if mp.Exposed && mp.Visibility == visibility && protocol != "https" {
 			continue
 		}
 
-		mp.AutoExposure = pm.autoExpose(ctx, mp.LocalhostPort, public, protocol).state
+		mp.AutoExposure = pm.autoExpose(ctx, mp.LocalhostPort, toGitpodVisibility(visibility), protocol).state
 	}
 
 	var ports []uint32
@@ -470,14 +492,71 @@ func (pm *Manager) nextState(ctx context.Context) map[uint32]*managedPort {
 	return newState
 }
 
+// nextUDPState computes the state of UDP ports. They cannot be opened in a browser,
+// hence they are neither exposed nor do they trigger any on open action.
+func (pm *Manager) nextUDPState() map[uint32]*managedPort {
+	state := make(map[uint32]*managedPort)
+	genManagedPort := func(port uint32) *managedPort {
+		if mp, exists := state[port]; exists {
+			return mp
+		}
+		mp := &managedPort{
+			LocalhostPort:     port,
+			TransportProtocol: api.TransportProtocol_udp,
+			OnExposed:         api.OnPortExposedAction_ignore,
+			OnOpen:            api.PortsStatus_ignore,
+		}
+		if config, _, exists := pm.configs.Get(port); exists {
+			mp.Name = config.Name
+			mp.Description = config.Description
+		}
+		state[port] = mp
+		return mp
+	}
+
+	for _, tunneled := range pm.tunneled {
+		port := tunneled.Desc.LocalPort
+		if pm.boundInternally(port) || tunneled.Desc.Protocol != api.TransportProtocol_udp {
+			continue
+		}
+		mp := genManagedPort(port)
+		mp.Tunneled = true
+		mp.TunneledTargetPort = tunneled.Desc.TargetPort
+		mp.TunneledVisibility = tunneled.Desc.Visibility
+		mp.TunneledClients = tunneled.Clients
+	}
+	for _, served := range pm.served {
+		if pm.boundInternally(served.Port) || served.Protocol != api.TransportProtocol_udp {
+			continue
+		}
+		mp := genManagedPort(served.Port)
+		mp.Served = true
+		mp.Process = pm.processes[served.Inode]
+	}
+	return state
+}
+
+// resolveProcesses finds the processes serving the served ports.
+// Callers are expected to hold mu.
+func (pm *Manager) resolveProcesses() map[uint64]*ServingProcess {
+	if pm.P == nil {
+		return nil
+	}
+	inodes := make([]uint64, 0, len(pm.served))
+	for _, served := range pm.served {
+		inodes = append(inodes, served.Inode)
+	}
+	return pm.P.ResolveSockets(inodes)
+}
+
 // clients should guard a call with check whether such port is already exposed or auto exposed
-func (pm *Manager) autoExpose(ctx context.Context, localPort uint32, public bool, protocol string) *autoExposure {
-	exposing := pm.E.Expose(ctx, localPort, public, protocol)
+func (pm *Manager) autoExpose(ctx context.Context, localPort uint32, visibility string, protocol string) *autoExposure {
+	exposing := pm.E.Expose(ctx, localPort, visibility, protocol)
 	autoExpose := &autoExposure{
-		state:    api.PortAutoExposure_trying,
-		ctx:      ctx,
-		public:   public,
-		protocol: protocol,
+		state:      api.PortAutoExposure_trying,
+		ctx:        ctx,
+		visibility: visibility,
+		protocol:   protocol,
 	}
 	go func() {
 		err := <-exposing
@@ -504,7 +583,7 @@ func (pm *Manager) RetryAutoExpose(ctx context.Context, localPort uint32) {
 	if !autoExposed || autoExpose.state != api.PortAutoExposure_failed || autoExpose.ctx.Err() != nil {
 		return
 	}
-	pm.autoExpose(autoExpose.ctx, localPort, autoExpose.public, autoExpose.protocol)
+	pm.autoExpose(autoExpose.ctx, localPort, autoExpose.visibility, autoExpose.protocol)
 	pm.forceUpdate()
 }
 
@@ -516,47 +595,55 @@ func (pm *Manager) forceUpdate() {
 
 func (pm *Manager) autoTunnel(ctx context.Context) {
 	if !pm.autoTunnelEnabled {
-		var localPorts []uint32
-		for localPort := range pm.autoTunneled {
-			localPorts = append(localPorts, localPort)
+		localPorts := make(map[api.TransportProtocol][]uint32)
+		for key := range pm.autoTunneled {
+			localPorts[key.protocol] = append(localPorts[key.protocol], key.port)
 		}
 		// CloseTunnel ensures that everything is closed
-		pm.autoTunneled = make(map[uint32]struct{})
-		_, err := pm.T.CloseTunnel(ctx, localPorts...)
-		if err != nil {
-			log.WithError(err).Error("cannot close auto tunneled ports")
+		pm.autoTunneled = make(map[portKey]struct{})
+		for protocol, ports := range localPorts {
+			_, err := pm.T.CloseTunnel(ctx, protocol, ports...)
+			if err != nil {
+				log.WithError(err).WithField("protocol", protocol).Error("cannot close auto tunneled ports")
+			}
 		}
 		return
 	}
-	var descs []*PortTunnelDescription
+	descs := make(map[api.TransportProtocol][]*PortTunnelDescription)
 	for _, served := range pm.served {
 		if pm.boundInternally(served.Port) {
 			continue
 		}
 
-		_, autoTunneled := pm.autoTunneled[served.Port]
+		_, autoTunneled := pm.autoTunneled[portKey{served.Port, served.Protocol}]
 		if !autoTunneled {
-			descs = append(descs, &PortTunnelDescription{
+			descs[served.Protocol] = append(descs[served.Protocol], &PortTunnelDescription{
 				LocalPort:  served.Port,
 				TargetPort: served.Port,
 				Visibility: api.TunnelVisiblity_host,
+				Protocol:   served.Protocol,
 			})
 		}
 	}
-	autoTunneled, err := pm.T.Tunnel(ctx, &TunnelOptions{
-		SkipIfExists: true,
-	}, descs...)
-	if err != nil {
-		log.WithError(err).Error("cannot auto tunnel ports")
-	}
-	for _, localPort := range autoTunneled {
-		pm.autoTunneled[localPort] = struct{}{}
+	for protocol, descs := range descs {
+		autoTunneled, err := pm.T.Tunnel(ctx, &TunnelOptions{
+			SkipIfExists: true,
+		}, descs...)
+		if err != nil {
+			log.WithError(err).WithField("protocol", protocol).Error("cannot auto tunnel ports")
+		}
+		for _, localPort := range autoTunneled {
+			pm.autoTunneled[portKey{localPort, protocol}] = struct{}{}
+		}
 	}
 }
 
 func (pm *Manager) updateProxies() {
 	servedPortMap := map[uint32]bool{}
 	for _, s := range pm.served {
+		if s.Protocol != api.TransportProtocol_tcp {
+			continue
+		}
 		servedPortMap[s.Port] = s.BoundToLocalhost
 	}
 
@@ -575,7 +662,7 @@ func (pm *Manager) updateProxies() {
 	for _, served := range pm.served {
 		localPort := served.Port
 		_, exists := pm.proxies[localPort]
-		if exists || !served.BoundToLocalhost {
+		if exists || !served.BoundToLocalhost || served.Protocol != api.TransportProtocol_tcp {
 			continue
 		}
 
@@ -674,14 +761,140 @@ func (pm *Manager) Expose(ctx context.Context, port uint32) error {
 	pm.mu.RUnlock()
 	unlock = false
 
-	public := exists && config.Visibility != "private"
-	err := <-pm.E.Expose(ctx, port, public, config.Protocol)
+	visibility := gitpod.PortVisibilityPrivate
+	if exists && config.Visibility != gitpod.PortVisibilityPrivate {
+		visibility = gitpod.PortVisibilityPublic
+		if config.Visibility == gitpod.PortVisibilityProtected {
+			visibility = gitpod.PortVisibilityProtected
+		}
+	}
+	err := <-pm.E.Expose(ctx, port, visibility, config.Protocol)
 	if err != nil && err != context.Canceled {
 		log.WithError(err).WithField("port", port).Error("cannot expose port")
 	}
 	return err
 }
 
+var (
+	// ErrPortNotExposed is returned when sharing a port which is not exposed
+	ErrPortNotExposed = xerrors.New("port is not exposed")
+	// ErrPortPublic is returned when sharing a public port
+	ErrPortPublic = xerrors.New("port is public and does not need to be shared")
+)
+
+// SharePort mints a token which grants access to an exposed port until it expires.
+// Private ports become protected, public ports cannot be shared.
+func (pm *Manager) SharePort(ctx context.Context, port uint32, ttl time.Duration) (*SharedPort, error) {
+	pm.mu.RLock()
+	var (
+		visibility api.PortVisibility
+		protocol   = gitpod.PortProtocolHTTP
+	)
+	mp, ok := pm.state[port]
+	if ok {
+		visibility = mp.Visibility
+		if mp.Protocol == api.PortProtocol_https {
+			protocol = gitpod.PortProtocolHTTPS
+		}
+		ok = mp.Exposed
+	}
+	pm.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrPortNotExposed
+	}
+	if visibility == api.PortVisibility_public {
+		return nil, ErrPortPublic
+	}
+	// exposing protected ports again is a no-op unless they lost their token epoch, e.g. after a supervisor restart
+	err := <-pm.E.Expose(ctx, port, gitpod.PortVisibilityProtected, protocol)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot protect port: %w", err)
+	}
+	return pm.E.Share(ctx, port, ttl)
+}
+
+func toAPIVisibility(visibility string) api.PortVisibility {
+	switch visibility {
+	case gitpod.PortVisibilityPublic:
+		return api.PortVisibility_public
+	case gitpod.PortVisibilityProtected:
+		return api.PortVisibility_protected
+	default:
+		return api.PortVisibility_private
+	}
+}
+
+func toGitpodVisibility(visibility api.PortVisibility) string {
+	switch visibility {
+	case api.PortVisibility_public:
+		return gitpod.PortVisibilityPublic
+	case api.PortVisibility_protected:
+		return gitpod.PortVisibilityProtected
+	default:
+		return gitpod.PortVisibilityPrivate
+	}
+}
+
+var (
+	// ErrPortNotServed is returned when killing the process of a port which is not served
+	ErrPortNotServed = xerrors.New("port is not served")
+	// ErrPortInternal is returned when killing the process of a port served by Gitpod itself
+	ErrPortInternal = xerrors.New("port is served by Gitpod")
+	// ErrProcessUnknown is returned when the process serving a port cannot be found
+	ErrProcessUnknown = xerrors.New("cannot find the process serving the port")
+)
+
+// KillPortProcess asks the process serving a port to terminate. If force is true, the process is killed instead.
+// It returns the PID of the signaled process.
+func (pm *Manager) KillPortProcess(protocol api.TransportProtocol, port uint32, force bool) (pid int, err error) {
+	pm.mu.RLock()
+	var (
+		served bool
+		inode  uint64
+	)
+	for _, sp := range pm.served {
+		if sp.Port == port && sp.Protocol == protocol {
+			served = true
+			inode = sp.Inode
+			break
+		}
+	}
+	internal := pm.boundInternally(port)
+	resolver := pm.P
+	pm.mu.RUnlock()
+
+	if internal {
+		return 0, ErrPortInternal
+	}
+	if !served {
+		return 0, ErrPortNotServed
+	}
+	if resolver == nil || inode == 0 {
+		return 0, ErrProcessUnknown
+	}
+
+	// resolve the process again rather than relying on the state, the process might have changed since
+	process, ok := resolver.ResolveSockets([]uint64{inode})[inode]
+	if !ok {
+		return 0, ErrProcessUnknown
+	}
+	if process.PID == os.Getpid() {
+		return 0, ErrPortInternal
+	}
+
+	sig := syscall.SIGTERM
+	if force {
+		sig = syscall.SIGKILL
+	}
+	err = syscall.Kill(process.PID, sig)
+	if err != nil {
+		return 0, xerrors.Errorf("cannot signal process %d: %w", process.PID, err)
+	}
+	log.WithField("port", port).WithField("pid", process.PID).WithField("signal", sig.String()).Info("signaled process serving port")
+	return process.PID, nil
+}
+
 // Tunnel opens a new tunnel.
 func (pm *Manager) Tunnel(ctx context.Context, desc *PortTunnelDescription) error {
 	pm.mu.Lock()
@@ -694,13 +907,13 @@ func (pm *Manager) Tunnel(ctx context.Context, desc *PortTunnelDescription) erro
 		SkipIfExists: false,
 	}, desc)
 	for _, localPort := range tunneled {
-		delete(pm.autoTunneled, localPort)
+		delete(pm.autoTunneled, portKey{localPort, desc.Protocol})
 	}
 	return err
 }
 
 // CloseTunnel closes the tunnel.
-func (pm *Manager) CloseTunnel(ctx context.Context, port uint32) error {
+func (pm *Manager) CloseTunnel(ctx context.Context, protocol api.TransportProtocol, port uint32) error {
 	unlock := true
 	pm.mu.RLock()
 	defer func() {
@@ -718,13 +931,13 @@ func (pm *Manager) CloseTunnel(ctx context.Context, port uint32) error {
 	pm.mu.RUnlock()
 	unlock = false
 
-	_, err := pm.T.CloseTunnel(ctx, port)
+	_, err := pm.T.CloseTunnel(ctx, protocol, port)
 	return err
 }
 
 // EstablishTunnel actually establishes the tunnel
-func (pm *Manager) EstablishTunnel(ctx context.Context, clientID string, localPort uint32, targetPort uint32) (net.Conn, error) {
-	return pm.T.EstablishTunnel(ctx, clientID, localPort, targetPort)
+func (pm *Manager) EstablishTunnel(ctx context.Context, clientID string, localPort uint32, targetPort uint32, protocol api.TransportProtocol) (net.Conn, error) {
+	return pm.T.EstablishTunnel(ctx, clientID, localPort, targetPort, protocol)
 }
 
 // AutoTunnel controls enablement of auto tunneling
@@ -778,9 +991,9 @@ func (pm *Manager) Subscribe() (*Subscription, error) {
 // getStatus produces an API compatible port status list.
 // Callers are expected to hold mu.
 func (pm *Manager) getStatus() []*api.PortsStatus {
-	res := make([]*api.PortsStatus, 0, len(pm.state))
+	res := make([]*api.PortsStatus, 0, len(pm.state)+len(pm.udpState))
 	for port := range pm.state {
-		res = append(res, pm.getPortStatus(port))
+		res = append(res, getPortStatus(pm.state[port]))
 	}
 	sort.SliceStable(res, func(i, j int) bool {
 		// Max number of port 65536
@@ -798,17 +1011,24 @@ func (pm *Manager) getStatus() []*api.PortsStatus {
 		// Ranged ports
 		return res[i].LocalPort < res[j].LocalPort
 	})
-	return res
+
+	// UDP ports follow the TCP ones
+	udp := make([]*api.PortsStatus, 0, len(pm.udpState))
+	for _, mp := range pm.udpState {
+		udp = append(udp, getPortStatus(mp))
+	}
+	sort.Slice(udp, func(i, j int) bool { return udp[i].LocalPort < udp[j].LocalPort })
+	return append(res, udp...)
 }
 
-func (pm *Manager) getPortStatus(port uint32) *api.PortsStatus {
-	mp := pm.state[port]
+func getPortStatus(mp *managedPort) *api.PortsStatus {
 	ps := &api.PortsStatus{
-		LocalPort:   mp.LocalhostPort,
-		Served:      mp.Served,
-		Description: mp.Description,
-		Name:        mp.Name,
-		OnOpen:      mp.OnOpen,
+		LocalPort:         mp.LocalhostPort,
+		Served:            mp.Served,
+		Description:       mp.Description,
+		Name:              mp.Name,
+		OnOpen:            mp.OnOpen,
+		TransportProtocol: mp.TransportProtocol,
 	}
 	if mp.Exposed && mp.URL != "" {
 		ps.Exposed = &api.ExposedPortInfo{
@@ -825,6 +1045,14 @@ func (pm *Manager) getPortStatus(port uint32) *api.PortsStatus {
 			Clients:    mp.TunneledClients,
 		}
 	}
+	if mp.Process != nil {
+		ps.Process = &api.PortProcess{
+			Pid:           int64(mp.Process.PID),
+			Cmdline:       mp.Process.Cmdline,
+			TerminalAlias: mp.Process.TerminalAlias,
+			TaskId:        mp.Process.TaskID,
+		}
+	}
 	return ps
 }
 
@@ -40,6 +40,7 @@ func TestPortsUpdateState(t *testing.T) {
 	tests := []struct {
 		Desc             string
 		InternalPorts    []uint32
+		Processes        testProcessResolver
 		Changes          []Change
 		ExpectedExposure ExposureExpectation
 		ExpectedUpdates  UpdateExpectation
@@ -47,10 +48,10 @@ func TestPortsUpdateState(t *testing.T) {
 		{
 			Desc: "basic locally served",
 			Changes: []Change{
-				{Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 8080, true}}},
+				{Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 8080, true, api.TransportProtocol_tcp, 0}}},
 				{Exposed: []ExposedPort{{LocalPort: 8080, URL: "foobar"}}},
-				{Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 8080, true}, {net.IPv4zero, 60000, false}}},
-				{Served: []ServedPort{{net.IPv4zero, 60000, false}}},
+				{Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 8080, true, api.TransportProtocol_tcp, 0}, {net.IPv4zero, 60000, false, api.TransportProtocol_tcp, 0}}},
+				{Served: []ServedPort{{net.IPv4zero, 60000, false, api.TransportProtocol_tcp, 0}}},
 				{Served: []ServedPort{}},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -69,7 +70,7 @@ func TestPortsUpdateState(t *testing.T) {
 		{
 			Desc: "basic globally served",
 			Changes: []Change{
-				{Served: []ServedPort{{net.IPv4zero, 8080, false}}},
+				{Served: []ServedPort{{net.IPv4zero, 8080, false, api.TransportProtocol_tcp, 0}}},
 				{Served: []ServedPort{}},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -103,7 +104,7 @@ func TestPortsUpdateState(t *testing.T) {
 			InternalPorts: []uint32{8080},
 			Changes: []Change{
 				{Served: []ServedPort{}},
-				{Served: []ServedPort{{net.IPv4zero, 8080, false}}},
+				{Served: []ServedPort{{net.IPv4zero, 8080, false, api.TransportProtocol_tcp, 0}}},
 			},
 			ExpectedExposure: ExposureExpectation(nil),
 			ExpectedUpdates:  UpdateExpectation{{}},
@@ -117,9 +118,9 @@ func TestPortsUpdateState(t *testing.T) {
 						Port:   "4000-5000",
 					}},
 				}},
-				{Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 4040, true}}},
+				{Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 4040, true, api.TransportProtocol_tcp, 0}}},
 				{Exposed: []ExposedPort{{LocalPort: 4040, Public: true, URL: "4040-foobar"}}},
-				{Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 4040, true}, {net.IPv4zero, 60000, false}}},
+				{Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 4040, true, api.TransportProtocol_tcp, 0}, {net.IPv4zero, 60000, false, api.TransportProtocol_tcp, 0}}},
 			},
 			ExpectedExposure: []ExposedPort{
 				{LocalPort: 4040},
@@ -151,19 +152,19 @@ func TestPortsUpdateState(t *testing.T) {
 					Exposed: []ExposedPort{{LocalPort: 8080, Public: true, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 8080, true}},
+					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 8080, true, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 8080, Public: true, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 8080, true}},
+					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 8080, true, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Served: []ServedPort{},
 				},
 				{
-					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 8080, false}},
+					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 8080, false, api.TransportProtocol_tcp, 0}},
 				},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -179,11 +180,76 @@ func TestPortsUpdateState(t *testing.T) {
 				[]*api.PortsStatus{{LocalPort: 8080, Served: true, OnOpen: api.PortsStatus_notify, Exposed: &api.ExposedPortInfo{Visibility: api.PortVisibility_public, OnExposed: api.OnPortExposedAction_notify, Url: "foobar"}}},
 			},
 		},
+		{
+			Desc: "auto expose protected ports",
+			Changes: []Change{
+				{
+					Config: &ConfigChange{instance: []*gitpod.PortsItems{
+						{Port: 8080, Visibility: "protected"},
+					}},
+				},
+				{
+					Exposed: []ExposedPort{{LocalPort: 8080, Protected: true, URL: "foobar"}},
+				},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 8080, Protected: true},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{},
+				[]*api.PortsStatus{{LocalPort: 8080, OnOpen: api.PortsStatus_notify}},
+				[]*api.PortsStatus{{LocalPort: 8080, OnOpen: api.PortsStatus_notify, Exposed: &api.ExposedPortInfo{Visibility: api.PortVisibility_protected, OnExposed: api.OnPortExposedAction_notify, Url: "foobar"}}},
+			},
+		},
+		{
+			Desc: "udp ports are neither exposed nor proxied",
+			Changes: []Change{
+				{Served: []ServedPort{{net.IPv4zero, 53, false, api.TransportProtocol_udp, 0}, {net.IPv4(127, 0, 0, 1), 53, true, api.TransportProtocol_tcp, 0}}},
+				{Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 53, true, api.TransportProtocol_udp, 0}}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 53},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{},
+				{
+					{LocalPort: 53, Served: true, OnOpen: api.PortsStatus_notify_private},
+					{LocalPort: 53, Served: true, OnOpen: api.PortsStatus_ignore, TransportProtocol: api.TransportProtocol_udp},
+				},
+				{
+					{LocalPort: 53, Served: true, OnOpen: api.PortsStatus_ignore, TransportProtocol: api.TransportProtocol_udp},
+				},
+			},
+		},
+		{
+			Desc: "served ports report their process",
+			Processes: testProcessResolver{
+				1234: {PID: 42, Cmdline: "python -m http.server 8080", TerminalAlias: "term-1", TaskID: "0"},
+				5678: {PID: 43, Cmdline: "dnsmasq"},
+			},
+			Changes: []Change{
+				{Served: []ServedPort{{net.IPv4zero, 8080, false, api.TransportProtocol_tcp, 1234}, {net.IPv4zero, 53, false, api.TransportProtocol_udp, 5678}}},
+				{Served: []ServedPort{{net.IPv4zero, 8080, false, api.TransportProtocol_tcp, 4321}}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 8080},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{},
+				{
+					{LocalPort: 8080, Served: true, OnOpen: api.PortsStatus_notify_private, Process: &api.PortProcess{Pid: 42, Cmdline: "python -m http.server 8080", TerminalAlias: "term-1", TaskId: "0"}},
+					{LocalPort: 53, Served: true, OnOpen: api.PortsStatus_ignore, TransportProtocol: api.TransportProtocol_udp, Process: &api.PortProcess{Pid: 43, Cmdline: "dnsmasq"}},
+				},
+				{
+					{LocalPort: 8080, Served: true, OnOpen: api.PortsStatus_notify_private},
+				},
+			},
+		},
 		{
 			Desc: "starting multiple proxies for the same served event",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 8080, true}, {net.IPv4zero, 3000, true}},
+					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 8080, true, api.TransportProtocol_tcp, 0}, {net.IPv4zero, 3000, true, api.TransportProtocol_tcp, 0}},
 				},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -207,7 +273,7 @@ func TestPortsUpdateState(t *testing.T) {
 					}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4zero, 8080, false}},
+					Served: []ServedPort{{net.IPv4zero, 8080, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 8080, Public: false, URL: "foobar"}},
@@ -227,13 +293,13 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served locally and then globally too, prefer globally (exposed in between)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true}},
+					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true}, {net.IPv4zero, 5900, false}},
+					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true, api.TransportProtocol_tcp, 0}, {net.IPv4zero, 5900, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, URL: "foobar"}},
@@ -252,10 +318,10 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served locally and then globally too, prefer globally (exposed after)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true}},
+					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true, api.TransportProtocol_tcp, 0}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true}, {net.IPv4zero, 5900, false}},
+					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true, api.TransportProtocol_tcp, 0}, {net.IPv4zero, 5900, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, URL: "foobar"}},
@@ -277,13 +343,13 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served globally and then locally too, prefer globally (exposed in between)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{net.IPv4zero, 5900, false}},
+					Served: []ServedPort{{net.IPv4zero, 5900, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4zero, 5900, false}, {net.IPv4(127, 0, 0, 1), 5900, true}},
+					Served: []ServedPort{{net.IPv4zero, 5900, false, api.TransportProtocol_tcp, 0}, {net.IPv4(127, 0, 0, 1), 5900, true, api.TransportProtocol_tcp, 0}},
 				},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -299,10 +365,10 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served globally and then locally too, prefer globally (exposed after)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{net.IPv4zero, 5900, false}},
+					Served: []ServedPort{{net.IPv4zero, 5900, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4zero, 5900, false}, {net.IPv4(127, 0, 0, 1), 5900, true}},
+					Served: []ServedPort{{net.IPv4zero, 5900, false, api.TransportProtocol_tcp, 0}, {net.IPv4(127, 0, 0, 1), 5900, true, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, URL: "foobar"}},
@@ -321,13 +387,13 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served locally on ip4 and then locally on ip6 too, prefer first (exposed in between)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true}},
+					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true}, {net.IPv6zero, 5900, true}},
+					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true, api.TransportProtocol_tcp, 0}, {net.IPv6zero, 5900, true, api.TransportProtocol_tcp, 0}},
 				},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -343,10 +409,10 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served locally on ip4 and then locally on ip6 too, prefer first (exposed after)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true}},
+					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true, api.TransportProtocol_tcp, 0}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true}, {net.IPv6zero, 5900, true}},
+					Served: []ServedPort{{net.IPv4(127, 0, 0, 1), 5900, true, api.TransportProtocol_tcp, 0}, {net.IPv6zero, 5900, true, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, URL: "foobar"}},
@@ -365,13 +431,13 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served locally on ip4 and then globally on ip6 too, prefer first (exposed in between)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{net.IPv4zero, 5900, false}},
+					Served: []ServedPort{{net.IPv4zero, 5900, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4zero, 5900, false}, {net.IPv6zero, 5900, false}},
+					Served: []ServedPort{{net.IPv4zero, 5900, false, api.TransportProtocol_tcp, 0}, {net.IPv6zero, 5900, false, api.TransportProtocol_tcp, 0}},
 				},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -387,10 +453,10 @@ func TestPortsUpdateState(t *testing.T) {
 			Desc: "the same port served locally on ip4 and then globally on ip6 too, prefer first (exposed after)",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{net.IPv4zero, 5900, false}},
+					Served: []ServedPort{{net.IPv4zero, 5900, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4zero, 5900, false}, {net.IPv6zero, 5900, false}},
+					Served: []ServedPort{{net.IPv4zero, 5900, false, api.TransportProtocol_tcp, 0}, {net.IPv6zero, 5900, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 5900, URL: "foobar"}},
@@ -414,7 +480,7 @@ func TestPortsUpdateState(t *testing.T) {
 					}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4zero, 8080, false}},
+					Served: []ServedPort{{net.IPv4zero, 8080, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 8080, Public: false, URL: "foobar"}},
@@ -439,7 +505,7 @@ func TestPortsUpdateState(t *testing.T) {
 					}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4zero, 3000, false}},
+					Served: []ServedPort{{net.IPv4zero, 3000, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 3000, Public: false, URL: "foobar"}},
@@ -472,10 +538,10 @@ func TestPortsUpdateState(t *testing.T) {
 					}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4zero, 5002, false}},
+					Served: []ServedPort{{net.IPv4zero, 5002, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4zero, 5002, false}, {net.IPv4zero, 5001, false}},
+					Served: []ServedPort{{net.IPv4zero, 5002, false, api.TransportProtocol_tcp, 0}, {net.IPv4zero, 5001, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Config: &ConfigChange{instance: []*gitpod.PortsItems{
@@ -484,7 +550,7 @@ func TestPortsUpdateState(t *testing.T) {
 					}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4zero, 5001, false}, {net.IPv4zero, 3000, false}},
+					Served: []ServedPort{{net.IPv4zero, 5001, false, api.TransportProtocol_tcp, 0}, {net.IPv4zero, 3000, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 3000, Public: false, URL: "foobar"}},
@@ -557,10 +623,10 @@ func TestPortsUpdateState(t *testing.T) {
 					},
 				},
 				{
-					Served: []ServedPort{{net.IPv4zero, 3000, false}},
+					Served: []ServedPort{{net.IPv4zero, 3000, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
-					Served: []ServedPort{{net.IPv4zero, 3000, false}, {net.IPv4zero, 3001, false}, {net.IPv4zero, 3002, false}},
+					Served: []ServedPort{{net.IPv4zero, 3000, false, api.TransportProtocol_tcp, 0}, {net.IPv4zero, 3001, false, api.TransportProtocol_tcp, 0}, {net.IPv4zero, 3002, false, api.TransportProtocol_tcp, 0}},
 				},
 				{
 					Config: &ConfigChange{
@@ -672,6 +738,9 @@ func TestPortsUpdateState(t *testing.T) {
 			pm.proxyStarter = func(port uint32) (io.Closer, error) {
 				return io.NopCloser(nil), nil
 			}
+			if test.Processes != nil {
+				pm.P = test.Processes
+			}
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -733,6 +802,7 @@ func TestPortsUpdateState(t *testing.T) {
 				ignoreUnexported = cmpopts.IgnoreUnexported(
 					api.PortsStatus{},
 					api.ExposedPortInfo{},
+					api.PortProcess{},
 				)
 			)
 			if diff := cmp.Diff(test.ExpectedExposure, ExposureExpectation(exposed.Exposures), sortExposed, ignoreUnexported); diff != "" {
@@ -757,10 +827,10 @@ func (tep *testTunneledPorts) Observe(ctx context.Context) (<-chan []PortTunnelS
 func (tep *testTunneledPorts) Tunnel(ctx context.Context, options *TunnelOptions, descs ...*PortTunnelDescription) ([]uint32, error) {
 	return nil, nil
 }
-func (tep *testTunneledPorts) CloseTunnel(ctx context.Context, localPorts ...uint32) ([]uint32, error) {
+func (tep *testTunneledPorts) CloseTunnel(ctx context.Context, protocol api.TransportProtocol, localPorts ...uint32) ([]uint32, error) {
 	return nil, nil
 }
-func (tep *testTunneledPorts) EstablishTunnel(ctx context.Context, clientID string, localPort uint32, targetPort uint32) (net.Conn, error) {
+func (tep *testTunneledPorts) EstablishTunnel(ctx context.Context, clientID string, localPort uint32, targetPort uint32, protocol api.TransportProtocol) (net.Conn, error) {
 	return nil, nil
 }
 
@@ -788,17 +858,22 @@ func (tep *testExposedPorts) Observe(ctx context.Context) (<-chan []ExposedPort,
 func (tep *testExposedPorts) Run(ctx context.Context) {
 }
 
-func (tep *testExposedPorts) Expose(ctx context.Context, local uint32, public bool, protocol string) <-chan error {
+func (tep *testExposedPorts) Expose(ctx context.Context, local uint32, visibility string, protocol string) <-chan error {
 	tep.mu.Lock()
 	defer tep.mu.Unlock()
 
 	tep.Exposures = append(tep.Exposures, ExposedPort{
 		LocalPort: local,
-		Public:    public,
+		Public:    visibility == gitpod.PortVisibilityPublic,
+		Protected: visibility == gitpod.PortVisibilityProtected,
 	})
 	return nil
 }
 
+func (tep *testExposedPorts) Share(ctx context.Context, port uint32, ttl time.Duration) (*SharedPort, error) {
+	return &SharedPort{Token: "token", URL: "foobar?gitpod_port_token=token"}, nil
+}
+
 type testServedPorts struct {
 	Changes chan []ServedPort
 	Error   chan error
@@ -990,3 +1065,15 @@ func TestManager_getStatus(t *testing.T) {
 		})
 	}
 }
+
+type testProcessResolver map[uint64]*ServingProcess
+
+func (tp testProcessResolver) ResolveSockets(inodes []uint64) map[uint64]*ServingProcess {
+	res := make(map[uint64]*ServingProcess)
+	for _, inode := range inodes {
+		if p, ok := tp[inode]; ok {
+			res[inode] = p
+		}
+	}
+	return res
+}
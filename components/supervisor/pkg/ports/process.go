@@ -0,0 +1,157 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// ServingProcess describes the process which serves a port.
+type ServingProcess struct {
+	PID           int
+	Cmdline       string
+	TerminalAlias string
+	TaskID        string
+}
+
+// ProcessResolver finds the processes serving ports.
+type ProcessResolver interface {
+	// ResolveSockets returns the processes owning the sockets with the given inodes.
+	// Sockets whose owner cannot be found are missing from the result.
+	ResolveSockets(inodes []uint64) map[uint64]*ServingProcess
+}
+
+// maxProcessTreeDepth limits how many ancestors of a process are searched for its terminal.
+const maxProcessTreeDepth = 64
+
+// ProcfsProcessResolver finds socket owners by scanning the file descriptors of all processes in procfs.
+type ProcfsProcessResolver struct {
+	// ProcRoot is where procfs is mounted. Defaults to /proc.
+	ProcRoot string
+
+	// Owner returns the terminal alias and the task ID of a process started in a supervisor terminal.
+	// For processes which are unknown to Owner, their ancestors are looked up. Owner is optional.
+	Owner func(pid int) (terminalAlias string, taskID string, ok bool)
+}
+
+// ResolveSockets returns the processes owning the sockets with the given inodes.
+// If a socket is shared by several processes, e.g. after a fork, the one with the lowest PID is returned.
+func (r *ProcfsProcessResolver) ResolveSockets(inodes []uint64) map[uint64]*ServingProcess {
+	wanted := make(map[string]uint64, len(inodes))
+	for _, inode := range inodes {
+		if inode == 0 {
+			continue
+		}
+		wanted[fmt.Sprintf("socket:[%d]", inode)] = inode
+	}
+	if len(wanted) == 0 {
+		return nil
+	}
+
+	root := r.procRoot()
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		log.WithError(err).Warn("cannot list processes")
+		return nil
+	}
+	var pids []int
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		pids = append(pids, pid)
+	}
+	sort.Ints(pids)
+
+	res := make(map[uint64]*ServingProcess)
+	for _, pid := range pids {
+		fdDir := filepath.Join(root, strconv.Itoa(pid), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			// the process has exited in the meantime
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil {
+				continue
+			}
+			inode, ok := wanted[link]
+			if !ok {
+				continue
+			}
+			if _, exists := res[inode]; exists {
+				continue
+			}
+			res[inode] = r.describe(pid)
+		}
+		if len(res) == len(wanted) {
+			break
+		}
+	}
+	return res
+}
+
+func (r *ProcfsProcessResolver) procRoot() string {
+	if r.ProcRoot == "" {
+		return "/proc"
+	}
+	return r.ProcRoot
+}
+
+func (r *ProcfsProcessResolver) describe(pid int) *ServingProcess {
+	res := &ServingProcess{PID: pid}
+	cmdline, err := os.ReadFile(filepath.Join(r.procRoot(), strconv.Itoa(pid), "cmdline"))
+	if err == nil {
+		res.Cmdline = strings.TrimSpace(string(bytes.ReplaceAll(cmdline, []byte{0}, []byte{' '})))
+	}
+	if r.Owner == nil {
+		return res
+	}
+
+	current := pid
+	for i := 0; i < maxProcessTreeDepth && current > 0; i++ {
+		alias, taskID, ok := r.Owner(current)
+		if ok {
+			res.TerminalAlias = alias
+			res.TaskID = taskID
+			break
+		}
+		current = r.parentPID(current)
+	}
+	return res
+}
+
+// parentPID returns the PID of the parent of a process, or 0 if it cannot be determined.
+func (r *ProcfsProcessResolver) parentPID(pid int) int {
+	stat, err := os.ReadFile(filepath.Join(r.procRoot(), strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0
+	}
+	// the command name may contain spaces and parentheses, hence we parse the fields after its closing parenthesis:
+	// the state is followed by the parent's PID.
+	end := bytes.LastIndexByte(stat, ')')
+	if end == -1 {
+		return 0
+	}
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 2 {
+		return 0
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0
+	}
+	return ppid
+}
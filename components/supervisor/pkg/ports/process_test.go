@@ -0,0 +1,124 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProcfsProcessResolver(t *testing.T) {
+	type proc struct {
+		PID     int
+		PPID    int
+		Cmdline string
+		Sockets []uint64
+	}
+	type owner struct {
+		TerminalAlias string
+		TaskID        string
+	}
+	tests := []struct {
+		Name        string
+		Procs       []proc
+		Owners      map[int]owner
+		Inodes      []uint64
+		Expectation map[uint64]*ServingProcess
+	}{
+		{
+			Name: "no inodes",
+			Procs: []proc{
+				{PID: 10, PPID: 1, Cmdline: "python\x00-m\x00http.server\x00", Sockets: []uint64{1234}},
+			},
+		},
+		{
+			Name: "process without terminal",
+			Procs: []proc{
+				{PID: 10, PPID: 1, Cmdline: "python\x00-m\x00http.server\x00", Sockets: []uint64{1234}},
+				{PID: 11, PPID: 1, Cmdline: "sleep\x00100\x00"},
+			},
+			Inodes: []uint64{1234, 5678},
+			Expectation: map[uint64]*ServingProcess{
+				1234: {PID: 10, Cmdline: "python -m http.server"},
+			},
+		},
+		{
+			Name: "process started in a task terminal",
+			Procs: []proc{
+				{PID: 10, PPID: 1, Cmdline: "/bin/bash\x00"},
+				{PID: 20, PPID: 10, Cmdline: "npm\x00start\x00"},
+				{PID: 30, PPID: 20, Cmdline: "node\x00server.js\x00", Sockets: []uint64{1234}},
+			},
+			Owners: map[int]owner{
+				10: {TerminalAlias: "term-1", TaskID: "0"},
+			},
+			Inodes: []uint64{1234},
+			Expectation: map[uint64]*ServingProcess{
+				1234: {PID: 30, Cmdline: "node server.js", TerminalAlias: "term-1", TaskID: "0"},
+			},
+		},
+		{
+			Name: "socket shared with a child",
+			Procs: []proc{
+				{PID: 100, PPID: 1, Cmdline: "gunicorn\x00", Sockets: []uint64{1234}},
+				{PID: 20, PPID: 1, Cmdline: "nginx\x00", Sockets: []uint64{4321}},
+				{PID: 101, PPID: 100, Cmdline: "gunicorn: worker\x00", Sockets: []uint64{1234}},
+			},
+			Inodes: []uint64{1234, 4321},
+			Expectation: map[uint64]*ServingProcess{
+				1234: {PID: 100, Cmdline: "gunicorn"},
+				4321: {PID: 20, Cmdline: "nginx"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			root := t.TempDir()
+			for _, p := range test.Procs {
+				dir := filepath.Join(root, strconv.Itoa(p.PID))
+				err := os.MkdirAll(filepath.Join(dir, "fd"), 0755)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = os.WriteFile(filepath.Join(dir, "cmdline"), []byte(p.Cmdline), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+				stat := strconv.Itoa(p.PID) + " (some (cmd)) S " + strconv.Itoa(p.PPID) + " 1 1 0 -1"
+				err = os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i, inode := range p.Sockets {
+					err = os.Symlink("socket:["+strconv.FormatUint(inode, 10)+"]", filepath.Join(dir, "fd", strconv.Itoa(i+3)))
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			resolver := &ProcfsProcessResolver{
+				ProcRoot: root,
+				Owner: func(pid int) (string, string, bool) {
+					o, ok := test.Owners[pid]
+					return o.TerminalAlias, o.TaskID, ok
+				},
+			}
+			act := resolver.ResolveSockets(test.Inodes)
+			if len(act) == 0 {
+				act = nil
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
 )
 
 // ServedPort describes a port served by a local service.
@@ -26,6 +27,9 @@ type ServedPort struct {
 	Address          net.IP
 	Port             uint32
 	BoundToLocalhost bool
+	Protocol         api.TransportProtocol
+	// Inode of the socket, used to find the process serving the port. Zero if unknown.
+	Inode uint64
 }
 
 // ServedPortsObserver observes the locally served ports and provides
@@ -44,8 +48,25 @@ const (
 
 	fnNetTCP  = "/proc/net/tcp"
 	fnNetTCP6 = "/proc/net/tcp6"
+	fnNetUDP  = "/proc/net/udp"
+	fnNetUDP6 = "/proc/net/udp6"
+
+	// socket states as found in /proc/net/*, see include/net/tcp_states.h
+	stateTCPListen = "0A"
+	// unconnected UDP sockets are in the closed state
+	stateUDPUnconnected = "07"
 )
 
+var netFiles = []struct {
+	Path     string
+	Protocol api.TransportProtocol
+}{
+	{fnNetTCP, api.TransportProtocol_tcp},
+	{fnNetTCP6, api.TransportProtocol_tcp},
+	{fnNetUDP, api.TransportProtocol_udp},
+	{fnNetUDP6, api.TransportProtocol_udp},
+}
+
 // PollingServedPortsObserver regularly polls "/proc" to observe port changes.
 type PollingServedPortsObserver struct {
 	RefreshInterval time.Duration
@@ -84,20 +105,22 @@ func (p *PollingServedPortsObserver) Observe(ctx context.Context) (<-chan []Serv
 				ports   []ServedPort
 			)
 
-			var protos []string
-			for _, path := range []string{fnNetTCP, fnNetTCP6} {
-				if _, err := os.Stat(path); err == nil {
-					protos = append(protos, path)
+			for _, nf := range netFiles {
+				if _, err := os.Stat(nf.Path); err != nil {
+					continue
 				}
-			}
 
-			for _, fn := range protos {
-				fc, err := p.fileOpener(fn)
+				fc, err := p.fileOpener(nf.Path)
 				if err != nil {
 					errchan <- err
 					continue
 				}
-				ps, err := readNetTCPFile(fc, true)
+				var ps []ServedPort
+				if nf.Protocol == api.TransportProtocol_udp {
+					ps, err = readNetUDPFile(fc)
+				} else {
+					ps, err = readNetTCPFile(fc, true)
+				}
 				fc.Close()
 
 				if err != nil {
@@ -105,7 +128,7 @@ func (p *PollingServedPortsObserver) Observe(ctx context.Context) (<-chan []Serv
 					continue
 				}
 				for _, port := range ps {
-					key := fmt.Sprintf("%s:%d", hex.EncodeToString(port.Address), port.Port)
+					key := fmt.Sprintf("%s:%s:%d", port.Protocol, hex.EncodeToString(port.Address), port.Port)
 					_, exists := visited[key]
 					if exists {
 						continue
@@ -125,13 +148,33 @@ func (p *PollingServedPortsObserver) Observe(ctx context.Context) (<-chan []Serv
 }
 
 func readNetTCPFile(fc io.Reader, listeningOnly bool) (ports []ServedPort, err error) {
+	var state string
+	if listeningOnly {
+		state = stateTCPListen
+	}
+	return readNetFile(fc, api.TransportProtocol_tcp, state)
+}
+
+// readNetUDPFile reads the UDP ports which are bound, but not connected to a remote address.
+func readNetUDPFile(fc io.Reader) (ports []ServedPort, err error) {
+	return readNetFile(fc, api.TransportProtocol_udp, stateUDPUnconnected)
+}
+
+// readNetFile reads the ports from a /proc/net/{tcp,udp}* file. If state is not empty,
+// only sockets in that state are returned.
+func readNetFile(fc io.Reader, protocol api.TransportProtocol, state string) (ports []ServedPort, err error) {
 	scanner := bufio.NewScanner(fc)
 	for scanner.Scan() {
 		fields := strings.Fields(scanner.Text())
 		if len(fields) < 4 {
 			continue
 		}
-		if listeningOnly && fields[3] != "0A" {
+		if state != "" && fields[3] != state {
+			continue
+		}
+		if protocol == api.TransportProtocol_udp && !isUnspecifiedRemote(fields[2]) {
+			// UDP client sockets which sent a datagram are in the same state as bound ones,
+			// but carry the remote address they talk to.
 			continue
 		}
 
@@ -143,15 +186,22 @@ func readNetTCPFile(fc io.Reader, listeningOnly bool) (ports []ServedPort, err e
 
 		port, err := strconv.ParseUint(portHex, 16, 32)
 		if err != nil {
-			log.WithError(err).WithField("port", portHex).Warn("cannot parse port entry from /proc/net/tcp* file")
+			log.WithError(err).WithField("port", portHex).Warn("cannot parse port entry from /proc/net/* file")
 			continue
 		}
 		ipAddress := hexDecodeIP([]byte(addrHex))
 
+		var inode uint64
+		if len(fields) > 9 {
+			inode, _ = strconv.ParseUint(fields[9], 10, 64)
+		}
+
 		ports = append(ports, ServedPort{
 			BoundToLocalhost: ipAddress.IsLoopback(),
 			Address:          ipAddress,
 			Port:             uint32(port),
+			Protocol:         protocol,
+			Inode:            inode,
 		})
 
 		sort.Slice(ports, func(i, j int) bool {
@@ -172,6 +222,12 @@ func readNetTCPFile(fc io.Reader, listeningOnly bool) (ports []ServedPort, err e
 	return
 }
 
+// isUnspecifiedRemote returns true if the hex encoded remote address of a /proc/net/* entry
+// is the unspecified address with port 0, i.e. the socket is not connected.
+func isUnspecifiedRemote(remote string) bool {
+	return strings.Trim(remote, "0:") == ""
+}
+
 // Parses IPv4/IPv6 addresses. The address is a big endian 32 bit ints, hex encoded.
 // We just decode the hex and flip the bytes in every group of 4.
 func hexDecodeIP(src []byte) net.IP {
@@ -15,6 +15,9 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
 )
 
 const validTCPInput = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
@@ -36,12 +39,24 @@ const validTCP6Input = `  sl  local_address                         remote_addre
    7: 0000000000000000FFFF0000940C380A:59D7 0000000000000000FFFF00006100840A:E08A 06 00000000:00000000 03:000003E6 00000000     0        0 0 3 0000000000000000
   20: 0000000000000000FFFF00000100007F:59D7 0000000000000000FFFF00000100007F:EB64 01 00000000:00000000 02:000003D2 00000000 33333        0 57014424 2 0000000000000000 20 4 0 10 -1`
 
+const validUDPInput = `   sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+  108: 00000000:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000 33333        0 20817 2 0000000000000000 0
+  230: 0100007F:14E9 00000000:0000 07 00000000:00000000 00:00000000 00000000 33333        0 20821 2 0000000000000000 0
+  300: 940C380A:D3F5 08080808:0035 01 00000000:00000000 00:00000000 00000000 33333        0 57015718 2 0000000000000000 0
+  301: 940C380A:C2A1 08080808:0035 07 00000000:00000000 00:00000000 00000000 33333        0 57015719 2 0000000000000000 0
+`
+
+const validUDP6Input = `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+    1: 00000000000000000000000000000000:1151 00000000000000000000000000000000:0000 07 00000000:00000000 00:00000000 00000000 33333        0 57007063 2 0000000000000000 0
+`
+
 func TestObserve(t *testing.T) {
 	type Expectation [][]ServedPort
 	tests := []struct {
-		Name         string
-		FileContents []string
-		Expectation  Expectation
+		Name            string
+		FileContents    []string
+		UDPFileContents []string
+		Expectation     Expectation
 	}{
 		{
 			Name: "basic positive",
@@ -61,6 +76,27 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "tcp and udp ports",
+			FileContents: []string{
+				"", "",
+				validTCPInput, "",
+			},
+			UDPFileContents: []string{
+				"", "",
+				validUDPInput, validUDP6Input,
+			},
+			Expectation: Expectation{
+				{
+					{Address: net.IPv4(127, 0, 0, 1), Port: 5900, BoundToLocalhost: true},
+					{Address: net.IPv4zero, Port: 6080},
+					{Address: net.IPv4zero, Port: 23000},
+					{Address: net.IPv4zero, Port: 53, Protocol: api.TransportProtocol_udp},
+					{Address: net.IPv4(127, 0, 0, 1), Port: 5353, BoundToLocalhost: true, Protocol: api.TransportProtocol_udp},
+					{Address: net.IPv6zero, Port: 4433, Protocol: api.TransportProtocol_udp},
+				},
+			},
+		},
 		{
 			Name: "the same port bound locally on ip4 and ip6",
 			FileContents: []string{
@@ -208,10 +244,18 @@ func TestObserve(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
-			var f int
+			var f, u int
 			obs := PollingServedPortsObserver{
 				RefreshInterval: 100 * time.Millisecond,
 				fileOpener: func(fn string) (io.ReadCloser, error) {
+					if fn == fnNetUDP || fn == fnNetUDP6 {
+						if u >= len(test.UDPFileContents) {
+							return io.NopCloser(strings.NewReader("")), nil
+						}
+						res := io.NopCloser(strings.NewReader(test.UDPFileContents[u]))
+						u++
+						return res, nil
+					}
 					if f >= len(test.FileContents) {
 						return nil, os.ErrNotExist
 					}
@@ -238,7 +282,8 @@ func TestObserve(t *testing.T) {
 				act = append(act, up)
 			}
 
-			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+			// inodes are covered by TestReadNetTCPFile and TestReadNetUDPFile
+			if diff := cmp.Diff(test.Expectation, act, cmpopts.IgnoreFields(ServedPort{}, "Inode")); diff != "" {
 				t.Errorf("unexpected result (-want +got):\n%s", diff)
 			}
 		})
@@ -262,9 +307,9 @@ func TestReadNetTCPFile(t *testing.T) {
 			ListeningOnly: true,
 			Expectation: Expectation{
 				Ports: []ServedPort{
-					{Address: net.IPv4(127, 0, 0, 1), Port: 5900, BoundToLocalhost: true},
-					{Address: net.IPv4zero, Port: 6080},
-					{Address: net.IPv4zero, Port: 23000},
+					{Address: net.IPv4(127, 0, 0, 1), Port: 5900, BoundToLocalhost: true, Inode: 57019442},
+					{Address: net.IPv4zero, Port: 6080, Inode: 57020850},
+					{Address: net.IPv4zero, Port: 23000, Inode: 57008615},
 				},
 			},
 		},
@@ -274,10 +319,10 @@ func TestReadNetTCPFile(t *testing.T) {
 			ListeningOnly: true,
 			Expectation: Expectation{
 				Ports: []ServedPort{
-					{Address: net.IPv6loopback, Port: 5900, BoundToLocalhost: true},
-					{Address: net.IPv6zero, Port: 22999},
-					{Address: net.IPv6zero, Port: 35900},
-					{Address: net.IPv6zero, Port: 36080},
+					{Address: net.IPv6loopback, Port: 5900, BoundToLocalhost: true, Inode: 57019446},
+					{Address: net.IPv6zero, Port: 22999, Inode: 57007063},
+					{Address: net.IPv6zero, Port: 35900, Inode: 57022992},
+					{Address: net.IPv6zero, Port: 36080, Inode: 57018070},
 				},
 			},
 		},
@@ -300,3 +345,46 @@ func TestReadNetTCPFile(t *testing.T) {
 		})
 	}
 }
+
+func TestReadNetUDPFile(t *testing.T) {
+	type Expectation struct {
+		Ports []ServedPort
+		Error error
+	}
+	tests := []struct {
+		Name        string
+		Input       string
+		Expectation Expectation
+	}{
+		{
+			Name:  "valid udp4 input",
+			Input: validUDPInput,
+			Expectation: Expectation{
+				Ports: []ServedPort{
+					{Address: net.IPv4zero, Port: 53, Protocol: api.TransportProtocol_udp, Inode: 20817},
+					{Address: net.IPv4(127, 0, 0, 1), Port: 5353, BoundToLocalhost: true, Protocol: api.TransportProtocol_udp, Inode: 20821},
+				},
+			},
+		},
+		{
+			Name:  "valid udp6 input",
+			Input: validUDP6Input,
+			Expectation: Expectation{
+				Ports: []ServedPort{
+					{Address: net.IPv6zero, Port: 4433, Protocol: api.TransportProtocol_udp, Inode: 57007063},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var act Expectation
+			act.Ports, act.Error = readNetUDPFile(strings.NewReader(test.Input))
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
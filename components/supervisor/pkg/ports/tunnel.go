@@ -6,12 +6,15 @@ package ports
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"sort"
 	"strconv"
 	"sync"
+	"syscall"
 
 	"golang.org/x/xerrors"
 
@@ -22,6 +25,7 @@ type PortTunnelDescription struct {
 	LocalPort  uint32
 	TargetPort uint32
 	Visibility api.TunnelVisiblity
+	Protocol   api.TransportProtocol
 }
 
 type PortTunnelState struct {
@@ -49,20 +53,22 @@ type TunneledPortsInterface interface {
 
 	// Tunnel notifies clients to install listeners on remote machines.
 	// After that such clients should call EstablishTunnel to forward incoming connections.
+	// It returns the local ports of the tunnels which were opened.
 	Tunnel(ctx context.Context, options *TunnelOptions, descs ...*PortTunnelDescription) ([]uint32, error)
 
-	// CloseTunnel closes tunnels.
-	CloseTunnel(ctx context.Context, localPorts ...uint32) ([]uint32, error)
+	// CloseTunnel closes the tunnels of the local ports served on the given protocol.
+	CloseTunnel(ctx context.Context, protocol api.TransportProtocol, localPorts ...uint32) ([]uint32, error)
 
 	// EstablishTunnel actually establishes the tunnel for an incoming connection on a remote machine.
-	EstablishTunnel(ctx context.Context, clientID string, localPort uint32, targetPort uint32) (net.Conn, error)
+	// Tunnels of UDP ports carry length prefixed datagrams, see newDatagramStreamConn.
+	EstablishTunnel(ctx context.Context, clientID string, localPort uint32, targetPort uint32, protocol api.TransportProtocol) (net.Conn, error)
 }
 
 // TunneledPortsService observes the tunneled ports.
 type TunneledPortsService struct {
 	mu      *sync.RWMutex
 	cond    *sync.Cond
-	tunnels map[uint32]*PortTunnel
+	tunnels map[portKey]*PortTunnel
 }
 
 // NewTunneledPortsService creates a new instance.
@@ -71,7 +77,7 @@ func NewTunneledPortsService(debugEnable bool) *TunneledPortsService {
 	return &TunneledPortsService{
 		mu:      &mu,
 		cond:    sync.NewCond(&mu),
-		tunnels: make(map[uint32]*PortTunnel),
+		tunnels: make(map[portKey]*PortTunnel),
 	}
 }
 
@@ -147,7 +153,8 @@ func (p *TunneledPortsService) Tunnel(ctx context.Context, options *TunnelOption
 			}
 			continue
 		}
-		tunnel, tunnelExists := p.tunnels[desc.LocalPort]
+		key := portKey{desc.LocalPort, desc.Protocol}
+		tunnel, tunnelExists := p.tunnels[key]
 		if !tunnelExists {
 			tunnel = &PortTunnel{
 				State: PortTunnelState{
@@ -155,7 +162,7 @@ func (p *TunneledPortsService) Tunnel(ctx context.Context, options *TunnelOption
 				},
 				Conns: make(map[string]map[net.Conn]struct{}),
 			}
-			p.tunnels[desc.LocalPort] = tunnel
+			p.tunnels[key] = tunnel
 		} else if options.SkipIfExists {
 			continue
 		}
@@ -170,15 +177,16 @@ func (p *TunneledPortsService) Tunnel(ctx context.Context, options *TunnelOption
 }
 
 // CloseTunnel closes tunnels.
-func (p *TunneledPortsService) CloseTunnel(ctx context.Context, localPorts ...uint32) (closedPorts []uint32, err error) {
+func (p *TunneledPortsService) CloseTunnel(ctx context.Context, protocol api.TransportProtocol, localPorts ...uint32) (closedPorts []uint32, err error) {
 	var closed []*PortTunnel
 	p.cond.L.Lock()
 	for _, localPort := range localPorts {
-		tunnel, existsTunnel := p.tunnels[localPort]
+		key := portKey{localPort, protocol}
+		tunnel, existsTunnel := p.tunnels[key]
 		if !existsTunnel {
 			continue
 		}
-		delete(p.tunnels, localPort)
+		delete(p.tunnels, key)
 		closed = append(closed, tunnel)
 		closedPorts = append(closedPorts, localPort)
 	}
@@ -205,11 +213,12 @@ func (p *TunneledPortsService) CloseTunnel(ctx context.Context, localPorts ...ui
 }
 
 // EstablishTunnel actually establishes the tunnel.
-func (p *TunneledPortsService) EstablishTunnel(ctx context.Context, clientID string, localPort uint32, targetPort uint32) (net.Conn, error) {
+func (p *TunneledPortsService) EstablishTunnel(ctx context.Context, clientID string, localPort uint32, targetPort uint32, protocol api.TransportProtocol) (net.Conn, error) {
 	p.cond.L.Lock()
 	defer p.cond.L.Unlock()
 
-	tunnel, tunnelExists := p.tunnels[localPort]
+	key := portKey{localPort, protocol}
+	tunnel, tunnelExists := p.tunnels[key]
 	if tunnelExists {
 		expectedTargetPort, clientExists := tunnel.State.Clients[clientID]
 		if clientExists && expectedTargetPort != targetPort {
@@ -220,7 +229,18 @@ func (p *TunneledPortsService) EstablishTunnel(ctx context.Context, clientID str
 	}
 
 	addr := net.JoinHostPort("localhost", strconv.FormatInt(int64(localPort), 10))
-	conn, err := net.Dial("tcp", addr)
+	var (
+		conn net.Conn
+		err  error
+	)
+	if protocol == api.TransportProtocol_udp {
+		conn, err = net.Dial("udp", addr)
+		if err == nil {
+			conn = newDatagramStreamConn(conn)
+		}
+	} else {
+		conn, err = net.Dial("tcp", addr)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -230,7 +250,7 @@ func (p *TunneledPortsService) EstablishTunnel(ctx context.Context, clientID str
 		onDidClose: func() {
 			p.cond.L.Lock()
 			defer p.cond.L.Unlock()
-			_, existsTunnel := p.tunnels[localPort]
+			_, existsTunnel := p.tunnels[key]
 			if !existsTunnel {
 				return
 			}
@@ -255,15 +275,21 @@ func (p *TunneledPortsService) Snapshot(w io.Writer) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	localPorts := make([]uint32, 0, len(p.tunnels))
+	keys := make([]portKey, 0, len(p.tunnels))
 	for k := range p.tunnels {
-		localPorts = append(localPorts, k)
+		keys = append(keys, k)
 	}
-	sort.Slice(localPorts, func(i, j int) bool { return localPorts[i] < localPorts[j] })
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].port == keys[j].port {
+			return keys[i].protocol < keys[j].protocol
+		}
+		return keys[i].port < keys[j].port
+	})
 
-	for _, localPort := range localPorts {
-		tunnel := p.tunnels[localPort]
+	for _, key := range keys {
+		tunnel := p.tunnels[key]
 		fmt.Fprintf(w, "Local Port: %d\n", tunnel.State.Desc.LocalPort)
+		fmt.Fprintf(w, "Protocol: %s\n", tunnel.State.Desc.Protocol)
 		fmt.Fprintf(w, "Target Port: %d\n", tunnel.State.Desc.TargetPort)
 		visibilty := api.TunnelVisiblity_name[int32(tunnel.State.Desc.Visibility)]
 		fmt.Fprintf(w, "Visibility: %s\n", visibilty)
@@ -275,3 +301,59 @@ func (p *TunneledPortsService) Snapshot(w io.Writer) {
 		fmt.Fprintf(w, "\n")
 	}
 }
+
+// maxDatagramSize is the largest payload of a UDP datagram.
+const maxDatagramSize = 0xFFFF
+
+// datagramStreamConn adapts a connected UDP socket to a byte stream, s.t. it can be tunneled
+// like a TCP connection. On the stream each datagram is prefixed with its length as 16 bit
+// big endian integer.
+type datagramStreamConn struct {
+	net.Conn
+
+	// rbuf holds the remainder of the last frame which was only partially read
+	rbuf []byte
+	// wbuf holds the beginning of a frame which was only partially written
+	wbuf []byte
+}
+
+func newDatagramStreamConn(conn net.Conn) *datagramStreamConn {
+	return &datagramStreamConn{Conn: conn}
+}
+
+// Read reads the next datagram and returns it as frame.
+func (c *datagramStreamConn) Read(p []byte) (int, error) {
+	for len(c.rbuf) == 0 {
+		buf := make([]byte, 2+maxDatagramSize)
+		n, err := c.Conn.Read(buf[2:])
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			// nothing is listening at the moment, the datagram was lost
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		binary.BigEndian.PutUint16(buf, uint16(n))
+		c.rbuf = buf[:2+n]
+	}
+	n := copy(p, c.rbuf)
+	c.rbuf = c.rbuf[n:]
+	return n, nil
+}
+
+// Write sends all complete frames in p as datagrams and buffers the rest.
+func (c *datagramStreamConn) Write(p []byte) (int, error) {
+	c.wbuf = append(c.wbuf, p...)
+	for len(c.wbuf) >= 2 {
+		size := int(binary.BigEndian.Uint16(c.wbuf))
+		if len(c.wbuf) < 2+size {
+			break
+		}
+		_, err := c.Conn.Write(c.wbuf[2 : 2+size])
+		if err != nil && !errors.Is(err, syscall.ECONNREFUSED) {
+			return 0, err
+		}
+		c.wbuf = c.wbuf[2+size:]
+	}
+	return len(p), nil
+}
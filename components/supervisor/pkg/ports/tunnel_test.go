@@ -119,7 +119,7 @@ func TestLocalPortTunneling(t *testing.T) {
 		}
 		defer src.Close()
 
-		dst, err := service.EstablishTunnel(ctx, "test", localPort, targetPort)
+		dst, err := service.EstablishTunnel(ctx, "test", localPort, targetPort, api.TransportProtocol_tcp)
 		if err != nil {
 			return err
 		}
@@ -153,7 +153,7 @@ func TestLocalPortTunneling(t *testing.T) {
 	}
 	assertUpdate([]PortTunnelState{{Desc: desc, Clients: map[string]uint32{"test": targetPort}}})
 
-	_, err = service.CloseTunnel(ctx, localPort)
+	_, err = service.CloseTunnel(ctx, api.TransportProtocol_tcp, localPort)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -167,6 +167,70 @@ func TestLocalPortTunneling(t *testing.T) {
 	}
 }
 
+func TestUDPPortTunneling(t *testing.T) {
+	echo, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer echo.Close()
+	go func() {
+		buf := make([]byte, maxDatagramSize)
+		for {
+			n, addr, err := echo.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = echo.WriteTo(append(buf[:n:n], '!'), addr)
+		}
+	}()
+	localPort := uint32(echo.LocalAddr().(*net.UDPAddr).Port)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service := NewTunneledPortsService(false)
+	_, err = service.Tunnel(ctx, &TunnelOptions{}, &PortTunnelDescription{
+		LocalPort:  localPort,
+		TargetPort: localPort,
+		Visibility: api.TunnelVisiblity_host,
+		Protocol:   api.TransportProtocol_udp,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = service.EstablishTunnel(ctx, "test", localPort, localPort, api.TransportProtocol_tcp)
+	if err == nil {
+		t.Fatal("expected no tcp tunnel for a udp port")
+	}
+	tunnel, err := service.EstablishTunnel(ctx, "test", localPort, localPort, api.TransportProtocol_udp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tunnel.Close()
+
+	// frames may be split arbitrarily on the stream
+	_, _ = tunnel.Write([]byte{0})
+	_, _ = tunnel.Write([]byte{5, 'H', 'e'})
+	_, _ = tunnel.Write([]byte{'l', 'l', 'o', 0, 2, 'h', 'i'})
+
+	var got []string
+	for i := 0; i < 2; i++ {
+		var size [2]byte
+		_, err = io.ReadFull(tunnel, size[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := make([]byte, int(size[0])<<8|int(size[1]))
+		_, err = io.ReadFull(tunnel, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(data))
+	}
+	if diff := cmp.Diff([]string{"Hello!", "hi!"}, got); diff != "" {
+		t.Errorf("unexpected datagrams (-want +got):\n%s", diff)
+	}
+}
+
 func availablePort() (uint32, error) {
 	l, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
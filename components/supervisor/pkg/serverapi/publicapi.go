@@ -32,6 +32,7 @@ import (
 type APIInterface interface {
 	GetToken(ctx context.Context, query *gitpod.GetTokenSearchOptions) (res *gitpod.Token, err error)
 	OpenPort(ctx context.Context, port *gitpod.WorkspaceInstancePort) (res *gitpod.WorkspaceInstancePort, err error)
+	GetOwnerToken(ctx context.Context) (res string, err error)
 	InstanceUpdates(ctx context.Context) (<-chan *gitpod.WorkspaceInstance, error)
 
 	// Metrics
@@ -87,6 +88,7 @@ func NewServerApiService(ctx context.Context, cfg *ServiceConfig, tknsrv api.Tok
 		Scope: []string{
 			"function:getToken",
 			"function:openPort",
+			"function:getOwnerToken",
 			"function:trackEvent",
 			"function:getWorkspace",
 		},
@@ -272,6 +274,32 @@ func (s *Service) OpenPort(ctx context.Context, port *gitpod.WorkspaceInstancePo
 	return port, nil
 }
 
+// GetOwnerToken returns the owner token of the workspace.
+func (s *Service) GetOwnerToken(ctx context.Context) (res string, err error) {
+	startTime := time.Now()
+	usePublicApi := s.usePublicAPI(ctx)
+	defer func() {
+		s.apiMetrics.ProcessMetrics(usePublicApi, "GetOwnerToken", err, startTime)
+	}()
+	if s == nil {
+		return "", errNotConnected
+	}
+	workspaceID := s.cfg.WorkspaceID
+	if !usePublicApi {
+		return s.gitpodService.GetOwnerToken(ctx, workspaceID)
+	}
+
+	service := v1.NewWorkspacesServiceClient(s.publicAPIConn)
+	resp, err := service.GetOwnerToken(ctx, &v1.GetOwnerTokenRequest{
+		WorkspaceId: workspaceID,
+	})
+	if err != nil {
+		log.WithField("method", "GetOwnerToken").WithError(err).Error("failed to call PublicAPI")
+		return "", err
+	}
+	return resp.Token, nil
+}
+
 // onInstanceUpdates listen to server and public API instanceUpdates and publish to subscribers once Service created.
 func (s *Service) onInstanceUpdates(ctx context.Context) {
 	errChan := make(chan error)
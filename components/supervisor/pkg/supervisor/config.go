@@ -319,6 +319,32 @@ type TaskConfig struct {
 	Env      *map[string]interface{} `json:"env,omitempty"`
 	OpenIn   *string                 `json:"openIn,omitempty"`
 	OpenMode *string                 `json:"openMode,omitempty"`
+
+	DependsOn []string       `json:"dependsOn,omitempty"`
+	ReadyWhen *TaskReadyWhen `json:"readyWhen,omitempty"`
+
+	Restart     *string          `json:"restart,omitempty"`
+	MaxRetries  *int             `json:"maxRetries,omitempty"`
+	HealthCheck *TaskHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// TaskReadyWhen defines when a task is ready for the tasks depending on it.
+// All conditions which are set have to be met.
+type TaskReadyWhen struct {
+	Port        *int    `json:"port,omitempty"`
+	File        *string `json:"file,omitempty"`
+	ExitSuccess bool    `json:"exitSuccess,omitempty"`
+}
+
+// TaskHealthCheck defines a probe of a task's command. If the probe fails
+// FailureThreshold times in a row, the task terminal is closed.
+type TaskHealthCheck struct {
+	Port int `json:"port"`
+	// Path makes the probe an HTTP GET request. If it is not set, the probe only connects to the port.
+	Path             *string `json:"path,omitempty"`
+	InitialDelay     *int    `json:"initialDelay,omitempty"`
+	Interval         *int    `json:"interval,omitempty"`
+	FailureThreshold *int    `json:"failureThreshold,omitempty"`
 }
 
 // Validate validates this configuration.
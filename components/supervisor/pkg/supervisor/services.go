@@ -222,7 +222,7 @@ func (s *statusService) BackupStatus(ctx context.Context, req *api.BackupStatusR
 func (s *statusService) PortsStatus(req *api.PortsStatusRequest, srv api.StatusService_PortsStatusServer) error {
 	if !req.Observe {
 		return srv.Send(&api.PortsStatusResponse{
-			Ports: s.Ports.Status(),
+			Ports: filterPortsStatus(s.Ports.Status(), req.IncludeUdp),
 		})
 	}
 
@@ -245,7 +245,7 @@ func (s *statusService) PortsStatus(req *api.PortsStatusRequest, srv api.StatusS
 				return nil
 			}
 			err := srv.Send(&api.PortsStatusResponse{
-				Ports: update,
+				Ports: filterPortsStatus(update, req.IncludeUdp),
 			})
 			if err != nil {
 				return err
@@ -254,6 +254,22 @@ func (s *statusService) PortsStatus(req *api.PortsStatusRequest, srv api.StatusS
 	}
 }
 
+// filterPortsStatus drops the UDP ports unless the client asked for them. Clients which don't
+// know about transport protocols expect a single status per port.
+func filterPortsStatus(ports []*api.PortsStatus, includeUDP bool) []*api.PortsStatus {
+	if includeUDP {
+		return ports
+	}
+	res := make([]*api.PortsStatus, 0, len(ports))
+	for _, p := range ports {
+		if p.TransportProtocol == api.TransportProtocol_udp {
+			continue
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
 func (s *statusService) TasksStatus(req *api.TasksStatusRequest, srv api.StatusService_TasksStatusServer) error {
 	select {
 	case <-srv.Context().Done():
@@ -892,6 +908,7 @@ func (s *portService) Tunnel(ctx context.Context, req *api.TunnelPortRequest) (*
 		LocalPort:  req.Port,
 		TargetPort: req.TargetPort,
 		Visibility: req.Visibility,
+		Protocol:   req.TransportProtocol,
 	})
 	if err != nil {
 		return nil, err
@@ -901,7 +918,7 @@ func (s *portService) Tunnel(ctx context.Context, req *api.TunnelPortRequest) (*
 
 // CloseTunnel closes the tunnel.
 func (s *portService) CloseTunnel(ctx context.Context, req *api.CloseTunnelRequest) (*api.CloseTunnelResponse, error) {
-	err := s.portsManager.CloseTunnel(ctx, req.Port)
+	err := s.portsManager.CloseTunnel(ctx, req.TransportProtocol, req.Port)
 	if err != nil {
 		return nil, err
 	}
@@ -915,11 +932,11 @@ func (s *portService) EstablishTunnel(stream api.PortService_EstablishTunnelServ
 		return status.Error(codes.Internal, err.Error())
 	}
 	desc := req.GetDesc()
-	if desc != nil {
+	if desc == nil {
 		return status.Error(codes.FailedPrecondition, "first request should be a desc")
 	}
 
-	tunnel, err := s.portsManager.EstablishTunnel(stream.Context(), desc.ClientId, desc.Port, desc.TargetPort)
+	tunnel, err := s.portsManager.EstablishTunnel(stream.Context(), desc.ClientId, desc.Port, desc.TargetPort, desc.TransportProtocol)
 	if err != nil {
 		return status.Errorf(codes.Internal, "failed establish the tunnel: %v", err)
 	}
@@ -984,7 +1001,48 @@ func (s *portService) RetryAutoExpose(ctx context.Context, req *api.RetryAutoExp
 	return &api.RetryAutoExposeResponse{}, nil
 }
 
+// defaultPortShareTTL is the lifetime of port share tokens if the client does not ask for one.
+const defaultPortShareTTL = 1 * time.Hour
+
+// SharePort mints a token which grants access to a protected port.
+func (s *portService) SharePort(ctx context.Context, req *api.SharePortRequest) (*api.SharePortResponse, error) {
+	ttl := defaultPortShareTTL
+	if req.TtlSeconds > 0 {
+		ttl = time.Duration(req.TtlSeconds) * time.Second
+	}
+	shared, err := s.portsManager.SharePort(ctx, req.Port, ttl)
+	if errors.Is(err, ports.ErrPortNotExposed) || errors.Is(err, ports.ErrPortPublic) || errors.Is(err, ports.ErrPortNotProtected) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, ports.ErrSharingUnavailable) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.SharePortResponse{
+		Token:     shared.Token,
+		Url:       shared.URL,
+		ExpiresAt: shared.ExpiresAt.Unix(),
+	}, nil
+}
+
+// KillPortProcess terminates the process which serves a port.
+func (s *portService) KillPortProcess(ctx context.Context, req *api.KillPortProcessRequest) (*api.KillPortProcessResponse, error) {
+	pid, err := s.portsManager.KillPortProcess(req.TransportProtocol, req.Port, req.Force)
+	if errors.Is(err, ports.ErrPortNotServed) || errors.Is(err, ports.ErrProcessUnknown) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, ports.ErrPortInternal) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.KillPortProcessResponse{Pid: int64(pid)}, nil
+}
+
 // ResourcesStatus provides workspace resources status information.
 func (s *statusService) ResourcesStatus(ctx context.Context, in *api.ResourcesStatuRequest) (*api.ResourcesStatusResponse, error) {
-	return s.topService.data, nil
+	return s.topService.Status(in), nil
 }
@@ -384,3 +384,30 @@ type tokenProviderFunc func(ctx context.Context, req *api.GetTokenRequest) (tkn
 func (f tokenProviderFunc) GetToken(ctx context.Context, req *api.GetTokenRequest) (tkn *Token, err error) {
 	return f(ctx, req)
 }
+
+func TestFilterPortsStatus(t *testing.T) {
+	ports := []*api.PortsStatus{
+		{LocalPort: 53, TransportProtocol: api.TransportProtocol_tcp},
+		{LocalPort: 8080, TransportProtocol: api.TransportProtocol_tcp},
+		{LocalPort: 53, TransportProtocol: api.TransportProtocol_udp},
+	}
+	tests := []struct {
+		Desc        string
+		IncludeUDP  bool
+		Expectation []uint32
+	}{
+		{Desc: "tcp only", Expectation: []uint32{53, 8080}},
+		{Desc: "include udp", IncludeUDP: true, Expectation: []uint32{53, 8080, 53}},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			var act []uint32
+			for _, p := range filterPortsStatus(ports, test.IncludeUDP) {
+				act = append(act, p.LocalPort)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected ports (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -50,6 +50,7 @@ import (
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/content-service/pkg/executor"
 	"github.com/gitpod-io/gitpod/content-service/pkg/git"
+	"github.com/gitpod-io/gitpod/content-service/pkg/logs"
 	gitpod "github.com/gitpod-io/gitpod/gitpod-protocol"
 	"github.com/gitpod-io/gitpod/supervisor/api"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/activation"
@@ -296,6 +297,7 @@ func Run(options ...RunOption) {
 	)
 
 	topService := NewTopService()
+	topService.notifier = notificationService
 	if !opts.RunGP {
 		topService.Observe(ctx)
 	}
@@ -342,9 +344,25 @@ func Run(options ...RunOption) {
 		Uid: gitpodUID,
 		Gid: gitpodGID,
 	}
+	termMuxSrv.Scrollback = &terminal.ScrollbackConfig{
+		// the scrollback must not end up in workspace backups
+		Location: filepath.Join(os.TempDir(), "gitpod-terminal-scrollback"),
+	}
+	termMuxSrv.RecordingLocation = filepath.Join(logs.TerminalStoreLocation, "recordings")
 
 	taskManager := newTasksManager(cfg, termMuxSrv, cstate, nil, ideReady, desktopIdeReady)
 
+	portMgmt.P = &ports.ProcfsProcessResolver{
+		Owner: func(pid int) (terminalAlias string, taskID string, ok bool) {
+			terminalAlias, ok = termMux.GetByPID(pid)
+			if !ok {
+				return "", "", false
+			}
+			taskID, _ = taskManager.taskIDByTerminal(terminalAlias)
+			return terminalAlias, taskID, true
+		},
+	}
+
 	willShutdownCtx, fireWillShutdown := context.WithCancel(ctx)
 	apiServices := []RegisterableService{
 		&statusService{
@@ -1353,7 +1371,7 @@ func tunnelOverSSH(ctx context.Context, tunneled *ports.TunneledPortsService, ne
 		return
 	}
 
-	tunnel, err := tunneled.EstablishTunnel(ctx, tunnelReq.ClientId, tunnelReq.Port, tunnelReq.TargetPort)
+	tunnel, err := tunneled.EstablishTunnel(ctx, tunnelReq.ClientId, tunnelReq.Port, tunnelReq.TargetPort, tunnelReq.TransportProtocol)
 	if err != nil {
 		log.WithError(err).Error("tunnel: failed to establish")
 		_ = newCh.Reject(ssh.Prohibited, err.Error())
@@ -1654,7 +1672,7 @@ func analysePerfChanges(ctx context.Context, wscfg *Config, w analytics.Writer,
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			data := topService.data
+			data := topService.Status(&api.ResourcesStatuRequest{})
 			if data == nil {
 				continue
 			}
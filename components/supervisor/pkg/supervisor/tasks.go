@@ -11,13 +11,19 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net"
+	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/proto"
+
 	"github.com/gitpod-io/gitpod/common-go/log"
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/content-service/pkg/logs"
@@ -70,6 +76,36 @@ type task struct {
 	successChan chan taskSuccess
 	title       string
 	lastOutput  string
+
+	// dependencies are the tasks which have to be ready before this task is started
+	dependencies []*task
+
+	// ready is closed once the task's readyWhen condition is met, or it is clear it never will be.
+	// In the latter case readyErr is set.
+	ready     chan struct{}
+	readyOnce sync.Once
+	readyErr  error
+
+	// exited is closed once the task terminal has exited, exitResult holds the outcome
+	exited     chan struct{}
+	exitResult taskSuccess
+
+	// startedAt is when the current task terminal was started, backoffExponent determines the delay
+	// of the next restart and retries counts the restarts since the task last ran stably. Unlike
+	// RestartCount, which counts all restarts, retries is checked against the max retries.
+	// All are guarded by tasksManager.mu.
+	startedAt       time.Time
+	backoffExponent uint32
+	retries         uint32
+}
+
+// markReady unblocks the tasks depending on this task. A non-nil err signals
+// that the task will never become ready.
+func (t *task) markReady(err error) {
+	t.readyOnce.Do(func() {
+		t.readyErr = err
+		close(t.ready)
+	})
 }
 
 type headlessTaskProgressReporter interface {
@@ -132,12 +168,26 @@ func (tm *tasksManager) Status() []*api.TaskStatus {
 	return tm.getStatus()
 }
 
+// taskIDByTerminal returns the ID of the task running in the terminal with the given alias.
+func (tm *tasksManager) taskIDByTerminal(alias string) (id string, ok bool) {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	for _, t := range tm.tasks {
+		if t.Terminal == alias {
+			return t.Id, true
+		}
+	}
+	return "", false
+}
+
 // getStatus produces an API compatible task status list.
 // Callers are expected to hold mu.
 func (tm *tasksManager) getStatus() []*api.TaskStatus {
 	status := make([]*api.TaskStatus, 0, len(tm.tasks))
 	for _, t := range tm.tasks {
-		status = append(status, &t.TaskStatus)
+		// subscribers read the status without holding mu
+		status = append(status, proto.Clone(&t.TaskStatus).(*api.TaskStatus))
 	}
 	return status
 }
@@ -173,16 +223,16 @@ func (tm *tasksManager) setTaskState(t *task, newState api.TaskState) {
 	})
 }
 
-func (tm *tasksManager) init(ctx context.Context) {
+func (tm *tasksManager) init(ctx context.Context) error {
 	defer close(tm.ready)
 
 	tasks, err := tm.config.getGitpodTasks()
 	if err != nil {
 		log.WithError(err).Error()
-		return
+		return err
 	}
 	if tasks == nil && tm.config.isHeadless() {
-		return
+		return nil
 	}
 	if tasks == nil {
 		tasks = &[]TaskConfig{{}}
@@ -190,7 +240,7 @@ func (tm *tasksManager) init(ctx context.Context) {
 
 	select {
 	case <-ctx.Done():
-		return
+		return nil
 	case <-tm.contentState.ContentReady():
 	}
 
@@ -223,14 +273,153 @@ func (tm *tasksManager) init(ctx context.Context) {
 			config:      config,
 			successChan: make(chan taskSuccess, 1),
 			title:       presentation.Name,
+			ready:       make(chan struct{}),
+			exited:      make(chan struct{}),
 		}
 		task.command = getCommand(task, tm.config.isHeadless(), tm.config.isPrebuild(), tm.contentSource, tm.storeLocation)
 		if tm.config.isHeadless() && task.command == "exit" {
 			task.State = api.TaskState_closed
 			task.successChan <- taskSuccessful
+			task.markReady(nil)
 		}
 		tm.tasks = append(tm.tasks, task)
 	}
+
+	// invalid tasks are closed right away, their dependents are closed once they notice
+	dependencies, errs := validateTasks(*tasks)
+	for i, deps := range dependencies {
+		t := tm.tasks[i]
+		if errs[i] != nil && t.State != api.TaskState_closed {
+			log.WithField("task", t.title).WithError(errs[i]).Error("invalid task")
+			tm.closeTask(t, errs[i].Error())
+			continue
+		}
+		for _, dep := range deps {
+			t.dependencies = append(t.dependencies, tm.tasks[dep])
+		}
+		if len(t.dependencies) > 0 && t.State != api.TaskState_closed {
+			t.State = api.TaskState_waiting
+		}
+	}
+	return nil
+}
+
+// validateTasks checks the configuration of each task and resolves their dependencies.
+// errs holds an error for each invalid task. Tasks which depend on an invalid task are not
+// reported, they are closed once their dependency was closed.
+func validateTasks(tasks []TaskConfig) (dependencies [][]int, errs []error) {
+	dependencies, errs = resolveTaskDependencies(tasks)
+	for i, t := range tasks {
+		if err := validateTask(i, t); err != nil {
+			errs[i] = err
+		}
+	}
+	return dependencies, errs
+}
+
+// validateTask checks the restart policy and health check of the i-th task.
+func validateTask(i int, t TaskConfig) error {
+	if t.Restart != nil {
+		switch *t.Restart {
+		case taskRestartNever, taskRestartOnFailure, taskRestartAlways:
+		default:
+			return xerrors.Errorf("task %d has invalid restart policy %q", i+1, *t.Restart)
+		}
+	}
+	if t.MaxRetries != nil && *t.MaxRetries < 0 {
+		return xerrors.Errorf("task %d has negative maxRetries", i+1)
+	}
+	if t.HealthCheck != nil && !(0 < t.HealthCheck.Port && t.HealthCheck.Port <= math.MaxUint16) {
+		return xerrors.Errorf("task %d has invalid health check port %d", i+1, t.HealthCheck.Port)
+	}
+	return nil
+}
+
+// resolveTaskDependencies maps the dependsOn names of each task to the indices of the tasks it depends on.
+// Tasks with an unknown or ambiguous dependency, which is left out, and tasks which are part of a
+// dependency cycle get an error in errs.
+func resolveTaskDependencies(tasks []TaskConfig) (res [][]int, errs []error) {
+	idx := make(map[string]int, len(tasks))
+	ambiguous := make(map[string]struct{})
+	for i, t := range tasks {
+		if t.Name == nil {
+			continue
+		}
+		if _, exists := idx[*t.Name]; exists {
+			ambiguous[*t.Name] = struct{}{}
+		}
+		idx[*t.Name] = i
+	}
+
+	res = make([][]int, len(tasks))
+	errs = make([]error, len(tasks))
+	for i, t := range tasks {
+		for _, name := range t.DependsOn {
+			if _, ok := ambiguous[name]; ok {
+				errs[i] = xerrors.Errorf("task %d depends on %q, but more than one task has this name", i+1, name)
+				continue
+			}
+			dep, ok := idx[name]
+			if !ok {
+				errs[i] = xerrors.Errorf("task %d depends on unknown task %q", i+1, name)
+				continue
+			}
+			res[i] = append(res[i], dep)
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var (
+		state = make([]int, len(tasks))
+		path  []int
+		visit func(i int)
+	)
+	visit = func(i int) {
+		switch state[i] {
+		case visited:
+			return
+		case visiting:
+			start := len(path) - 1
+			for path[start] != i {
+				start--
+			}
+			cycle := make([]string, 0, len(path)-start+1)
+			for _, j := range path[start:] {
+				cycle = append(cycle, taskDisplayName(tasks, j))
+			}
+			cycle = append(cycle, taskDisplayName(tasks, i))
+			err := xerrors.Errorf("tasks have a dependency cycle: %s", strings.Join(cycle, " -> "))
+			for _, j := range path[start:] {
+				if errs[j] == nil {
+					errs[j] = err
+				}
+			}
+			return
+		}
+
+		state[i] = visiting
+		path = append(path, i)
+		for _, dep := range res[i] {
+			visit(dep)
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+	}
+	for i := range tasks {
+		visit(i)
+	}
+	return res, errs
+}
+
+func taskDisplayName(tasks []TaskConfig, i int) string {
+	if tasks[i].Name != nil {
+		return *tasks[i].Name
+	}
+	return "task " + strconv.Itoa(i+1)
 }
 
 func (tm *tasksManager) waitForIde(parent context.Context, timeout time.Duration) {
@@ -259,106 +448,369 @@ func (tm *tasksManager) Run(ctx context.Context, wg *sync.WaitGroup, successChan
 	defer wg.Done()
 	defer log.Debug("tasksManager shutdown")
 
-	tm.init(ctx)
+	if err := tm.init(ctx); err != nil {
+		success := taskFailed(err.Error())
+		if tm.config.isPrebuild() && tm.reporter != nil {
+			tm.reporter.done(success)
+		}
+		successChan <- success
+		return
+	}
 
 	for _, t := range tm.tasks {
 		if t.State == api.TaskState_closed {
 			continue
 		}
-		taskLog := log.WithField("command", t.command)
-		taskLog.Info("starting a task terminal...")
-		openRequest := &api.OpenTerminalRequest{}
-		if t.config.Env != nil {
-			openRequest.Env = make(map[string]string, len(*t.config.Env))
-			for key, value := range *t.config.Env {
-				// Required check because a string is considered valid JSON (e.g. "hello")
-				// We don't want to marshall basic strings otherwise we get a double quoted environment variable
-				// See: https://github.com/gitpod-io/gitpod/issues/5887
-				if val, ok := value.(string); ok {
-					openRequest.Env[key] = val
-				} else {
-					v, err := json.Marshal(value)
-					if err != nil {
-						taskLog.WithError(err).WithField("key", key).Error("cannot marshal env var")
-					} else {
-						openRequest.Env[key] = string(v)
-					}
-				}
-			}
-		}
-		resp, err := tm.terminalService.OpenWithOptions(ctx, openRequest, terminal.TermOptions{
-			ReadTimeout: 5 * time.Second,
-			Title:       t.title,
-		})
-		if err != nil {
-			taskLog.WithError(err).Error("cannot open new task terminal")
-			t.successChan <- taskFailed("cannot open new task terminal")
-			tm.setTaskState(t, api.TaskState_closed)
+		if len(t.dependencies) > 0 {
+			go tm.startWhenDependenciesReady(ctx, t)
 			continue
 		}
+		tm.startTask(ctx, t)
+	}
 
-		taskLog = taskLog.WithField("terminal", resp.Terminal.Alias)
-		term, ok := tm.terminalService.Mux.Get(resp.Terminal.Alias)
-		if !ok {
-			taskLog.Error("cannot find a task terminal")
-			t.successChan <- taskFailed("cannot find a task terminal")
-			tm.setTaskState(t, api.TaskState_closed)
-			continue
+	var success taskSuccess
+	for _, task := range tm.tasks {
+		select {
+		case <-ctx.Done():
+			success = taskFailed(ctx.Err().Error())
+		case taskResult := <-task.successChan:
+			if taskResult.Failed() {
+				success = success.Fail(string(taskResult))
+			}
 		}
+	}
 
-		taskLog = taskLog.WithField("pid", term.Command.Process.Pid)
-		taskLog.Info("task terminal has been started")
-		tm.updateState(func() bool {
-			t.Terminal = resp.Terminal.Alias
-			t.State = api.TaskState_running
-			return true
-		})
+	if tm.config.isPrebuild() && tm.reporter != nil {
+		tm.reporter.done(success)
+	}
+	successChan <- success
+}
 
-		go func(t *task, term *terminal.Term) {
-			state, err := term.Wait()
-			if state != nil {
-				if state.Success() {
-					t.successChan <- taskSuccessful
-				} else {
-					t.successChan <- taskFailed(state.String())
-				}
-			} else if err != nil {
-				t.successChan <- taskSuccessful
+// startWhenDependenciesReady blocks until all dependencies of a task are ready and starts the task.
+// If a dependency can never become ready, the task is closed without being started.
+func (tm *tasksManager) startWhenDependenciesReady(ctx context.Context, t *task) {
+	for _, dep := range t.dependencies {
+		select {
+		case <-ctx.Done():
+			tm.closeTask(t, ctx.Err().Error())
+			return
+		case <-dep.ready:
+		}
+		if dep.readyErr != nil {
+			log.WithField("task", t.title).WithField("dependency", dep.title).WithError(dep.readyErr).Warn("task dependency did not become ready")
+			tm.closeTask(t, fmt.Sprintf("dependency %q did not become ready: %v", dep.title, dep.readyErr))
+			return
+		}
+	}
+	tm.startTask(ctx, t)
+}
+
+// closeTask marks a task which could not run as failed.
+func (tm *tasksManager) closeTask(t *task, msg string) {
+	t.exitResult = taskFailed(msg)
+	close(t.exited)
+	t.successChan <- t.exitResult
+	t.markReady(xerrors.New(msg))
+	tm.setTaskState(t, api.TaskState_closed)
+}
+
+func (tm *tasksManager) startTask(ctx context.Context, t *task) {
+	if !tm.openTaskTerminal(ctx, t, t.command) {
+		return
+	}
+	go tm.awaitReady(ctx, t)
+}
+
+// openTaskTerminal opens a new terminal for a task and runs command in it.
+// If the terminal cannot be opened, the task is closed.
+func (tm *tasksManager) openTaskTerminal(ctx context.Context, t *task, command string) bool {
+	taskLog := log.WithField("command", command)
+	taskLog.Info("starting a task terminal...")
+	openRequest := &api.OpenTerminalRequest{
+		// task output can be replayed from an offset using gp tasks attach, hence we keep its scrollback
+		Scrollback: tm.terminalService.Scrollback != nil,
+	}
+	if t.config.Env != nil {
+		openRequest.Env = make(map[string]string, len(*t.config.Env))
+		for key, value := range *t.config.Env {
+			// Required check because a string is considered valid JSON (e.g. "hello")
+			// We don't want to marshall basic strings otherwise we get a double quoted environment variable
+			// See: https://github.com/gitpod-io/gitpod/issues/5887
+			if val, ok := value.(string); ok {
+				openRequest.Env[key] = val
 			} else {
-				msg := "cannot wait for task"
+				v, err := json.Marshal(value)
 				if err != nil {
-					msg = err.Error()
+					taskLog.WithError(err).WithField("key", key).Error("cannot marshal env var")
+				} else {
+					openRequest.Env[key] = string(v)
 				}
+			}
+		}
+	}
+	resp, err := tm.terminalService.OpenWithOptions(ctx, openRequest, terminal.TermOptions{
+		ReadTimeout: 5 * time.Second,
+		Title:       t.title,
+	})
+	if err != nil {
+		taskLog.WithError(err).Error("cannot open new task terminal")
+		tm.closeTask(t, "cannot open new task terminal")
+		return false
+	}
+
+	taskLog = taskLog.WithField("terminal", resp.Terminal.Alias)
+	term, ok := tm.terminalService.Mux.Get(resp.Terminal.Alias)
+	if !ok {
+		taskLog.Error("cannot find a task terminal")
+		tm.closeTask(t, "cannot find a task terminal")
+		return false
+	}
 
-				t.successChan <- taskFailed(fmt.Sprintf("%s: %s", msg, t.lastOutput))
+	taskLog = taskLog.WithField("pid", term.Command.Process.Pid)
+	taskLog.Info("task terminal has been started")
+	tm.updateState(func() bool {
+		t.Terminal = resp.Terminal.Alias
+		t.State = api.TaskState_running
+		t.startedAt = time.Now()
+		return true
+	})
+
+	go func(t *task, term *terminal.Term) {
+		state, err := term.Wait()
+		var result taskSuccess
+		if state != nil {
+			if state.Success() {
+				result = taskSuccessful
+			} else {
+				result = taskFailed(state.String())
+			}
+		} else if err != nil {
+			result = taskSuccessful
+		} else {
+			msg := "cannot wait for task"
+			if err != nil {
+				msg = err.Error()
 			}
-			taskLog.Info("task terminal has been closed")
-			tm.setTaskState(t, api.TaskState_closed)
-		}(t, term)
 
-		tm.watch(t, term)
+			result = taskFailed(fmt.Sprintf("%s: %s", msg, t.lastOutput))
+		}
+		taskLog.Info("task terminal has been closed")
+		if state != nil {
+			exitCode := int32(state.ExitCode())
+			tm.updateState(func() bool {
+				t.LastExitCode = &exitCode
+				return true
+			})
+		}
 
-		if t.command != "" {
-			term.PTY.Write([]byte(t.command + "\n"))
+		if tm.shouldRestart(t, result) {
+			tm.restartTask(ctx, t)
+			return
 		}
+
+		t.exitResult = result
+		close(t.exited)
+		t.successChan <- result
+		tm.setTaskState(t, api.TaskState_closed)
+	}(t, term)
+
+	tm.watch(ctx, t, term)
+
+	if command != "" {
+		term.PTY.Write([]byte(command + "\n"))
 	}
+	return true
+}
 
-	var success taskSuccess
-	for _, task := range tm.tasks {
+const (
+	taskRestartNever     = "never"
+	taskRestartOnFailure = "on-failure"
+	taskRestartAlways    = "always"
+
+	defaultTaskMaxRetries = 5
+)
+
+var (
+	// taskRestartBackoff is the delay before the first restart of a task. It doubles with every restart until the task runs stably.
+	taskRestartBackoff = 1 * time.Second
+	// maxTaskRestartBackoff caps the delay between restarts of a task.
+	maxTaskRestartBackoff = 1 * time.Minute
+	// taskStableRunThreshold is the time after which a running task is considered stable. Tasks which ran
+	// at least that long before they exited are restarted with the initial backoff again.
+	taskStableRunThreshold = 10 * time.Minute
+)
+
+// restartPolicy returns the effective restart policy of a task.
+// Only the main command of non-headless tasks is ever restarted.
+func (t *task) restartPolicy(isHeadless bool) string {
+	if isHeadless || t.config.Restart == nil || t.config.Command == nil || strings.TrimSpace(*t.config.Command) == "" {
+		return taskRestartNever
+	}
+	return *t.config.Restart
+}
+
+func (tm *tasksManager) shouldRestart(t *task, result taskSuccess) bool {
+	maxRetries := defaultTaskMaxRetries
+	if t.config.MaxRetries != nil {
+		maxRetries = *t.config.MaxRetries
+	}
+	if int(tm.retriesSinceStableRun(t)) >= maxRetries {
+		return false
+	}
+
+	switch t.restartPolicy(tm.config.isHeadless()) {
+	case taskRestartAlways:
+		return true
+	case taskRestartOnFailure:
+		return result.Failed()
+	default:
+		return false
+	}
+}
+
+// retriesSinceStableRun returns how often a task has been restarted since it last ran stably.
+func (tm *tasksManager) retriesSinceStableRun(t *task) uint32 {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	t.resetAfterStableRun(time.Now())
+	return t.retries
+}
+
+// resetAfterStableRun forgets the previous restarts of a task which exited at now if it ran stably.
+// Callers must hold tasksManager.mu.
+func (t *task) resetAfterStableRun(now time.Time) {
+	if now.Sub(t.startedAt) >= taskStableRunThreshold {
+		t.backoffExponent = 0
+		t.retries = 0
+	}
+}
+
+// restartBackoff returns the delay before the next restart of a task which exited at now.
+// Callers must hold tasksManager.mu.
+func (t *task) restartBackoff(now time.Time) time.Duration {
+	t.resetAfterStableRun(now)
+	backoff := taskRestartBackoff << t.backoffExponent
+	if backoff > maxTaskRestartBackoff || backoff <= 0 {
+		backoff = maxTaskRestartBackoff
+	} else {
+		t.backoffExponent++
+	}
+	return backoff
+}
+
+// restartTask runs the command of a task in a new terminal after a backoff.
+func (tm *tasksManager) restartTask(ctx context.Context, t *task) {
+	var (
+		restartCount uint32
+		backoff      time.Duration
+	)
+	tm.updateState(func() bool {
+		restartCount = t.RestartCount
+		backoff = t.restartBackoff(time.Now())
+		t.RestartCount++
+		t.retries++
+		t.State = api.TaskState_opening
+		return true
+	})
+	log.WithField("task", t.title).WithField("restartCount", restartCount).WithField("backoff", backoff).Info("restarting task")
+
+	select {
+	case <-ctx.Done():
+		tm.closeTask(t, ctx.Err().Error())
+		return
+	case <-time.After(backoff):
+	}
+	tm.openTaskTerminal(ctx, t, getRestartCommand(t, tm.config.isHeadless()))
+}
+
+// getRestartCommand returns the command to run when a task is restarted.
+// Like on a workspace restart, only before and the main command are run.
+func getRestartCommand(task *task, isHeadless bool) string {
+	return composeCommand(composeCommandOptions{
+		commands: []*string{task.config.Before, task.config.Command},
+		format:   "{\n%s\n}",
+		sep:      " && ",
+	}) + restartExitSuffix(task.restartPolicy(isHeadless))
+}
+
+// restartExitSuffix returns what has to follow the command of a task such that its terminal exits
+// whenever the restart policy wants to restart it. Otherwise the terminal stays open as an interactive shell.
+func restartExitSuffix(policy string) string {
+	switch policy {
+	case taskRestartAlways:
+		return "; exit"
+	case taskRestartOnFailure:
+		return " || exit $?"
+	default:
+		return ""
+	}
+}
+
+// readyPollInterval is the interval in which port and file readiness conditions are checked.
+var readyPollInterval = 1 * time.Second
+
+// awaitReady marks a started task as ready once its readyWhen condition is met.
+func (tm *tasksManager) awaitReady(ctx context.Context, t *task) {
+	cond := t.config.ReadyWhen
+	if tm.config.isHeadless() {
+		// headless tasks are expected to terminate, their dependents must not start before they succeeded
+		cond = &TaskReadyWhen{ExitSuccess: true}
+	}
+	if cond == nil {
+		t.markReady(nil)
+		return
+	}
+
+	if cond.ExitSuccess {
 		select {
 		case <-ctx.Done():
-			success = taskFailed(ctx.Err().Error())
-		case taskResult := <-task.successChan:
-			if taskResult.Failed() {
-				success = success.Fail(string(taskResult))
+			t.markReady(ctx.Err())
+			return
+		case <-t.exited:
+		}
+		if t.exitResult.Failed() {
+			t.markReady(xerrors.Errorf("task failed: %s", string(t.exitResult)))
+			return
+		}
+	}
+
+	for {
+		if tm.isReadyConditionMet(cond) {
+			t.markReady(nil)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			t.markReady(ctx.Err())
+			return
+		case <-t.exited:
+			if !tm.isReadyConditionMet(cond) {
+				t.markReady(xerrors.New("task terminal exited before the task became ready"))
+				return
 			}
+		case <-time.After(readyPollInterval):
 		}
 	}
+}
 
-	if tm.config.isPrebuild() && tm.reporter != nil {
-		tm.reporter.done(success)
+func (tm *tasksManager) isReadyConditionMet(cond *TaskReadyWhen) bool {
+	if cond.Port != nil {
+		conn, err := net.DialTimeout("tcp", "localhost:"+strconv.Itoa(*cond.Port), readyPollInterval)
+		if err != nil {
+			return false
+		}
+		conn.Close()
 	}
-	successChan <- success
+	if cond.File != nil {
+		fn := *cond.File
+		if !filepath.IsAbs(fn) {
+			fn = filepath.Join(tm.config.RepoRoot, fn)
+		}
+		if _, err := os.Stat(fn); err != nil {
+			return false
+		}
+	}
+	return true
 }
 
 func getCommand(task *task, isHeadless bool, isPrebuild bool, contentSource csapi.WorkspaceInitSource, storeLocation string) string {
@@ -378,6 +830,10 @@ func getCommand(task *task, isHeadless bool, isPrebuild bool, contentSource csap
 		return command + "; exit"
 	}
 
+	if strings.TrimSpace(command) != "" {
+		command += restartExitSuffix(task.restartPolicy(isHeadless))
+	}
+
 	histfileCommand := getHistfileCommand(task, commands, contentSource, storeLocation)
 	if strings.TrimSpace(command) == "" {
 		return histfileCommand
@@ -437,7 +893,11 @@ func prebuildLogFileName(task *task, storeLocation string) string {
 	return logs.PrebuildLogFileName(storeLocation, task.Id)
 }
 
-func (tm *tasksManager) watch(task *task, term *terminal.Term) {
+func (tm *tasksManager) watch(ctx context.Context, task *task, term *terminal.Term) {
+	if task.config.HealthCheck != nil && !tm.config.isHeadless() {
+		go tm.probeHealth(ctx, task, task.Terminal)
+	}
+
 	if !tm.config.isPrebuild() {
 		return
 	}
@@ -514,6 +974,94 @@ func (tm *tasksManager) watch(task *task, term *terminal.Term) {
 	}()
 }
 
+const (
+	defaultHealthCheckInitialDelay     = 10 * time.Second
+	defaultHealthCheckInterval         = 10 * time.Second
+	defaultHealthCheckFailureThreshold = 3
+)
+
+// probeHealth periodically probes a task's health check while its terminal is open.
+// Once the probe failed too often in a row, the terminal is closed which makes the
+// restart policy of the task kick in.
+func (tm *tasksManager) probeHealth(ctx context.Context, t *task, alias string) {
+	var (
+		hc               = t.config.HealthCheck
+		initialDelay     = defaultHealthCheckInitialDelay
+		interval         = defaultHealthCheckInterval
+		failureThreshold = defaultHealthCheckFailureThreshold
+	)
+	if hc.InitialDelay != nil {
+		initialDelay = time.Duration(*hc.InitialDelay) * time.Second
+	}
+	if hc.Interval != nil && *hc.Interval > 0 {
+		interval = time.Duration(*hc.Interval) * time.Second
+	}
+	if hc.FailureThreshold != nil && *hc.FailureThreshold > 0 {
+		failureThreshold = *hc.FailureThreshold
+	}
+	probeLog := log.WithField("task", t.title).WithField("terminal", alias)
+
+	delay := initialDelay
+	var failures int
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = interval
+
+		if _, ok := tm.terminalService.Mux.Get(alias); !ok {
+			return
+		}
+
+		err := probeTaskHealth(ctx, hc, interval)
+		if err == nil {
+			failures = 0
+			continue
+		}
+		failures++
+		probeLog.WithError(err).WithField("failures", failures).Debug("task health check failed")
+		if failures < failureThreshold {
+			continue
+		}
+
+		probeLog.WithError(err).Warn("task is unhealthy, closing its terminal")
+		err = tm.terminalService.Mux.CloseTerminal(ctx, alias)
+		if err != nil && err != terminal.ErrNotFound {
+			probeLog.WithError(err).Error("cannot close unhealthy task terminal")
+		}
+		return
+	}
+}
+
+func probeTaskHealth(ctx context.Context, hc *TaskHealthCheck, timeout time.Duration) error {
+	addr := "localhost:" + strconv.Itoa(hc.Port)
+	if hc.Path == nil {
+		conn, err := net.DialTimeout("tcp", addr, timeout)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+"/"+strings.TrimPrefix(*hc.Path, "/"), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return xerrors.Errorf("health check returned %s", resp.Status)
+	}
+	return nil
+}
+
 func importParentLogAndGetDuration(fn string, out io.Writer) time.Duration {
 	if _, err := os.Stat(fn); err != nil {
 		return 0
@@ -7,10 +7,15 @@ package supervisor
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
@@ -240,6 +245,357 @@ func TestTaskManager(t *testing.T) {
 	}
 }
 
+func TestTaskManagerDependencies(t *testing.T) {
+	log.Log.Logger.SetLevel(logrus.FatalLevel)
+	p := func(v string) *string { return &v }
+	tests := []struct {
+		Desc        string
+		GitpodTasks []TaskConfig
+
+		ExpectedReporter testHeadlessTaskProgressReporter
+		// ExpectedRuns are the names of the files which must have been created by the tasks
+		ExpectedRuns []string
+	}{
+		{
+			Desc: "dependent task runs after its dependency",
+			GitpodTasks: []TaskConfig{
+				{Name: p("b"), Init: p("test -f $TASK_DIR/a"), DependsOn: []string{"a"}},
+				{Name: p("a"), Init: p("sleep 0.5 && touch $TASK_DIR/a")},
+			},
+			ExpectedReporter: testHeadlessTaskProgressReporter{
+				Done:    true,
+				Success: true,
+			},
+		},
+		{
+			Desc: "dependent task does not run if its dependency failed",
+			GitpodTasks: []TaskConfig{
+				{Name: p("a"), Init: &failCommand},
+				{Name: p("b"), Init: p("touch $TASK_DIR/b"), DependsOn: []string{"a"}},
+			},
+			ExpectedReporter: testHeadlessTaskProgressReporter{
+				Done:    true,
+				Success: false,
+			},
+		},
+		{
+			Desc: "dependency cycle fails the tasks",
+			GitpodTasks: []TaskConfig{
+				{Name: p("a"), Init: &skipCommand, DependsOn: []string{"b"}},
+				{Name: p("b"), Init: &skipCommand, DependsOn: []string{"a"}},
+			},
+			ExpectedReporter: testHeadlessTaskProgressReporter{
+				Done:    true,
+				Success: false,
+			},
+		},
+		{
+			Desc: "invalid dependency does not prevent unrelated tasks",
+			GitpodTasks: []TaskConfig{
+				{Name: p("b"), Init: p("touch $TASK_DIR/b"), DependsOn: []string{"typo"}},
+				{Name: p("c"), Init: p("touch $TASK_DIR/c")},
+			},
+			ExpectedReporter: testHeadlessTaskProgressReporter{
+				Done:    true,
+				Success: false,
+			},
+			ExpectedRuns: []string{"c"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			storeLocation, err := os.MkdirTemp("", "tasktest")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(storeLocation)
+
+			for i := range test.GitpodTasks {
+				test.GitpodTasks[i].Env = &map[string]interface{}{"TASK_DIR": storeLocation}
+			}
+			gitpodTasks, err := json.Marshal(test.GitpodTasks)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var (
+				terminalService = terminal.NewMuxTerminalService(terminal.NewMux())
+				contentState    = NewInMemoryContentState("")
+				reporter        = testHeadlessTaskProgressReporter{}
+				taskManager     = newTasksManager(&Config{
+					WorkspaceConfig: WorkspaceConfig{
+						GitpodTasks:    string(gitpodTasks),
+						GitpodHeadless: "true",
+					},
+				}, terminalService, contentState, &reporter, nil, nil)
+			)
+			taskManager.storeLocation = storeLocation
+			contentState.MarkContentReady(csapi.WorkspaceInitFromOther)
+			var wg sync.WaitGroup
+			wg.Add(1)
+			tasksSuccessChan := make(chan taskSuccess, 1)
+			go taskManager.Run(context.Background(), &wg, tasksSuccessChan)
+			wg.Wait()
+			if diff := cmp.Diff(test.ExpectedReporter, reporter); diff != "" {
+				t.Errorf("unexpected output (-want +got):\n%s", diff)
+			}
+			if _, err := os.Stat(filepath.Join(storeLocation, "b")); err == nil {
+				t.Errorf("dependent task must not run")
+			}
+			for _, f := range test.ExpectedRuns {
+				if _, err := os.Stat(filepath.Join(storeLocation, f)); err != nil {
+					t.Errorf("task %s must run: %v", f, err)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskManagerRestart(t *testing.T) {
+	log.Log.Logger.SetLevel(logrus.FatalLevel)
+	defer func(backoff time.Duration) { taskRestartBackoff = backoff }(taskRestartBackoff)
+	taskRestartBackoff = 10 * time.Millisecond
+
+	p := func(v string) *string { return &v }
+	i := func(v int) *int { return &v }
+	type Expectation struct {
+		Failed       bool
+		RestartCount uint32
+		LastExitCode int32
+	}
+	tests := []struct {
+		Desc        string
+		Task        TaskConfig
+		Expectation Expectation
+	}{
+		{
+			Desc:        "never restarts",
+			Task:        TaskConfig{Command: p("exit 3"), Restart: p(taskRestartNever)},
+			Expectation: Expectation{Failed: true, LastExitCode: 3},
+		},
+		{
+			Desc:        "restarts on failure until max retries",
+			Task:        TaskConfig{Command: p("exit 3"), Restart: p(taskRestartOnFailure), MaxRetries: i(2)},
+			Expectation: Expectation{Failed: true, RestartCount: 2, LastExitCode: 3},
+		},
+		{
+			Desc:        "does not restart on success",
+			Task:        TaskConfig{Command: p("true; exit"), Restart: p(taskRestartOnFailure), MaxRetries: i(2)},
+			Expectation: Expectation{RestartCount: 0, LastExitCode: 0},
+		},
+		{
+			Desc:        "always restarts",
+			Task:        TaskConfig{Command: p("true"), Restart: p(taskRestartAlways), MaxRetries: i(1)},
+			Expectation: Expectation{RestartCount: 1, LastExitCode: 0},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			storeLocation, err := os.MkdirTemp("", "tasktest")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(storeLocation)
+
+			gitpodTasks, err := json.Marshal([]TaskConfig{test.Task})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var (
+				terminalService = terminal.NewMuxTerminalService(terminal.NewMux())
+				contentState    = NewInMemoryContentState("")
+				taskManager     = newTasksManager(&Config{
+					WorkspaceConfig: WorkspaceConfig{
+						GitpodTasks: string(gitpodTasks),
+					},
+				}, terminalService, contentState, nil, nil, nil)
+			)
+			taskManager.storeLocation = storeLocation
+			contentState.MarkContentReady(csapi.WorkspaceInitFromOther)
+			var wg sync.WaitGroup
+			wg.Add(1)
+			tasksSuccessChan := make(chan taskSuccess, 1)
+			go taskManager.Run(context.Background(), &wg, tasksSuccessChan)
+			wg.Wait()
+
+			status := taskManager.Status()
+			if len(status) != 1 {
+				t.Fatalf("expected one task, got %d", len(status))
+			}
+			act := Expectation{
+				Failed:       (<-tasksSuccessChan).Failed(),
+				RestartCount: status[0].RestartCount,
+				LastExitCode: status[0].GetLastExitCode(),
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected task status (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTaskRestartBackoff(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		Desc        string
+		RunTimes    []time.Duration
+		Expectation []time.Duration
+	}{
+		{
+			Desc:        "crash loop",
+			RunTimes:    []time.Duration{0, time.Second, 0, 0, 0, 0, 0, 0},
+			Expectation: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 32 * time.Second, time.Minute, time.Minute},
+		},
+		{
+			Desc:        "stable runs reset the backoff",
+			RunTimes:    []time.Duration{0, 0, 0, taskStableRunThreshold, 0, 24 * time.Hour},
+			Expectation: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, time.Second, 2 * time.Second, time.Second},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			var (
+				tsk task
+				act []time.Duration
+			)
+			for _, rt := range test.RunTimes {
+				tsk.startedAt = now.Add(-rt)
+				act = append(act, tsk.restartBackoff(now))
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected backoff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTaskRetriesSinceStableRun(t *testing.T) {
+	var (
+		tm  tasksManager
+		tsk task
+		act []uint32
+	)
+	for _, rt := range []time.Duration{0, 0, taskStableRunThreshold, 0, 24 * time.Hour} {
+		tsk.startedAt = time.Now().Add(-rt)
+		act = append(act, tm.retriesSinceStableRun(&tsk))
+		tsk.restartBackoff(time.Now())
+		tsk.RestartCount++
+		tsk.retries++
+	}
+	if diff := cmp.Diff([]uint32{0, 1, 0, 1, 0}, act); diff != "" {
+		t.Errorf("unexpected retries (-want +got):\n%s", diff)
+	}
+	if tsk.RestartCount != 5 {
+		t.Errorf("expected a restart count of 5, got %d", tsk.RestartCount)
+	}
+}
+
+func TestProbeTaskHealth(t *testing.T) {
+	healthy := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy || r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := func(v string) *string { return &v }
+	tests := []struct {
+		Name        string
+		HealthCheck TaskHealthCheck
+		Healthy     bool
+		Expectation bool
+	}{
+		{Name: "tcp", HealthCheck: TaskHealthCheck{Port: port}, Healthy: false, Expectation: true},
+		{Name: "http healthy", HealthCheck: TaskHealthCheck{Port: port, Path: p("health")}, Healthy: true, Expectation: true},
+		{Name: "http unhealthy", HealthCheck: TaskHealthCheck{Port: port, Path: p("/health")}, Healthy: false, Expectation: false},
+		{Name: "http wrong path", HealthCheck: TaskHealthCheck{Port: port, Path: p("/")}, Healthy: true, Expectation: false},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			healthy = test.Healthy
+			err := probeTaskHealth(context.Background(), &test.HealthCheck, time.Second)
+			if act := err == nil; act != test.Expectation {
+				t.Errorf("unexpected health: want %v, got %v (%v)", test.Expectation, act, err)
+			}
+		})
+	}
+}
+
+func TestResolveTaskDependencies(t *testing.T) {
+	p := func(v string) *string { return &v }
+	tests := []struct {
+		Name        string
+		Tasks       []TaskConfig
+		Expectation [][]int
+		Errors      []string
+	}{
+		{
+			Name:        "no dependencies",
+			Tasks:       []TaskConfig{{Name: p("a")}, {}},
+			Expectation: [][]int{nil, nil},
+		},
+		{
+			Name:        "diamond",
+			Tasks:       []TaskConfig{{Name: p("a")}, {Name: p("b"), DependsOn: []string{"a"}}, {Name: p("c"), DependsOn: []string{"a"}}, {DependsOn: []string{"b", "c"}}},
+			Expectation: [][]int{nil, {0}, {0}, {1, 2}},
+		},
+		{
+			Name:        "unknown dependency",
+			Tasks:       []TaskConfig{{Name: p("a"), DependsOn: []string{"b"}}, {Name: p("c")}},
+			Expectation: [][]int{nil, nil},
+			Errors:      []string{`task 1 depends on unknown task "b"`, ""},
+		},
+		{
+			Name:        "ambiguous dependency",
+			Tasks:       []TaskConfig{{Name: p("a")}, {Name: p("a")}, {DependsOn: []string{"a"}}},
+			Expectation: [][]int{nil, nil, nil},
+			Errors:      []string{"", "", `task 3 depends on "a", but more than one task has this name`},
+		},
+		{
+			Name:        "self dependency",
+			Tasks:       []TaskConfig{{Name: p("a"), DependsOn: []string{"a"}}},
+			Expectation: [][]int{{0}},
+			Errors:      []string{"tasks have a dependency cycle: a -> a"},
+		},
+		{
+			Name:        "cycle",
+			Tasks:       []TaskConfig{{DependsOn: []string{"a"}}, {Name: p("a"), DependsOn: []string{"b"}}, {Name: p("b"), DependsOn: []string{"c"}}, {Name: p("c"), DependsOn: []string{"a"}}},
+			Expectation: [][]int{{1}, {2}, {3}, {1}},
+			Errors:      []string{"", "tasks have a dependency cycle: a -> b -> c -> a", "tasks have a dependency cycle: a -> b -> c -> a", "tasks have a dependency cycle: a -> b -> c -> a"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, errs := resolveTaskDependencies(test.Tasks)
+			errMsgs := make([]string, len(errs))
+			for i, err := range errs {
+				if err != nil {
+					errMsgs[i] = err.Error()
+				}
+			}
+			if test.Errors == nil {
+				test.Errors = make([]string, len(test.Tasks))
+			}
+			if diff := cmp.Diff(test.Errors, errMsgs); diff != "" {
+				t.Errorf("unexpected errors (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected dependencies (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 type testHeadlessTaskProgressReporter struct {
 	Done    bool
 	Success bool
@@ -306,6 +662,29 @@ func TestGetTask(t *testing.T) {
 	}
 }
 
+func TestGetRestartCommand(t *testing.T) {
+	p := func(v string) *string { return &v }
+	tests := []struct {
+		Name        string
+		Restart     string
+		IsHeadless  bool
+		Expectation string
+	}{
+		{Name: "never", Restart: taskRestartNever, Expectation: "{\nbefore\n} && {\ncommand\n}"},
+		{Name: "on failure", Restart: taskRestartOnFailure, Expectation: "{\nbefore\n} && {\ncommand\n} || exit $?"},
+		{Name: "always", Restart: taskRestartAlways, Expectation: "{\nbefore\n} && {\ncommand\n}; exit"},
+		{Name: "headless", Restart: taskRestartAlways, IsHeadless: true, Expectation: "{\nbefore\n} && {\ncommand\n}"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			command := getRestartCommand(&task{config: TaskConfig{Before: p("before"), Init: p("init"), Command: p("command"), Restart: p(test.Restart)}}, test.IsHeadless)
+			if diff := cmp.Diff(test.Expectation, command); diff != "" {
+				t.Errorf("unexpected getRestartCommand() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestTaskSuccess(t *testing.T) {
 	type Expectation struct {
 		Failed bool
@@ -6,6 +6,7 @@ package supervisor
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"strconv"
@@ -24,17 +25,117 @@ import (
 	daemonapi "github.com/gitpod-io/gitpod/ws-daemon/api"
 )
 
+const (
+	// historyResolution is the minimum time between two samples in the resource history
+	historyResolution = 5 * time.Second
+	// historyDuration is how long samples are kept in the resource history
+	historyDuration = 1 * time.Hour
+)
+
 type TopService struct {
 	data      *api.ResourcesStatusResponse
+	history   []*api.ResourcesSample
+	events    []*api.ResourceEvent
+	mu        sync.RWMutex
 	ready     chan struct{}
 	readyOnce sync.Once
 	top       func(ctx context.Context) (*api.ResourcesStatusResponse, error)
+	sampler   *resourceSampler
+
+	// notifier alerts the user about OOM kills and CPU throttling, if set
+	notifier interface {
+		Notify(ctx context.Context, req *api.NotifyRequest) (*api.NotifyResponse, error)
+	}
 }
 
 func NewTopService() *TopService {
 	log.Debug("gitpod top service: initialized")
 	return &TopService{
-		top: Top,
+		top:     Top,
+		sampler: newResourceSampler(),
+	}
+}
+
+// Status returns the latest resources status, or nil if there is none yet.
+// The history of the last hour, the processes and the events are only included if requested.
+func (t *TopService) Status(req *api.ResourcesStatuRequest) *api.ResourcesStatusResponse {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.data == nil {
+		return nil
+	}
+	res := &api.ResourcesStatusResponse{
+		Memory:   t.data.Memory,
+		Cpu:      t.data.Cpu,
+		Disk:     t.data.Disk,
+		Io:       t.data.Io,
+		Pressure: t.data.Pressure,
+	}
+	if req.IncludeHistory {
+		res.History = append([]*api.ResourcesSample(nil), t.history...)
+	}
+	if req.IncludeProcesses {
+		res.Processes = t.data.Processes
+	}
+	if req.IncludeEvents {
+		res.Events = append([]*api.ResourceEvent(nil), t.events...)
+	}
+	return res
+}
+
+// record makes data the current status and adds it to the history.
+func (t *TopService) record(now time.Time, data *api.ResourcesStatusResponse) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.data = data
+
+	for _, event := range data.Events {
+		if data.Memory != nil {
+			event.MemoryLimit = data.Memory.Limit
+		}
+		if data.Cpu != nil {
+			event.CpuLimit = data.Cpu.Limit
+		}
+		t.events = append(t.events, event)
+		if t.notifier != nil {
+			go t.notify(event)
+		}
+	}
+	var expiredEvents int
+	for expiredEvents < len(t.events) && now.Sub(time.Unix(t.events[expiredEvents].Timestamp, 0)) > historyDuration {
+		expiredEvents++
+	}
+	if expiredEvents > 0 {
+		t.events = append([]*api.ResourceEvent(nil), t.events[expiredEvents:]...)
+	}
+
+	if l := len(t.history); l > 0 && now.Sub(time.Unix(t.history[l-1].Timestamp, 0)) < historyResolution {
+		return
+	}
+	sample := &api.ResourcesSample{
+		Timestamp: now.Unix(),
+		Io:        data.Io,
+		Pressure:  data.Pressure,
+	}
+	if data.Cpu != nil {
+		sample.CpuUsed = data.Cpu.Used
+	}
+	if data.Memory != nil {
+		sample.MemoryUsed = data.Memory.Used
+	}
+	if data.Disk != nil {
+		sample.DiskUsed = data.Disk.Used
+	}
+	t.history = append(t.history, sample)
+
+	var expired int
+	for expired < len(t.history) && now.Sub(time.Unix(t.history[expired].Timestamp, 0)) > historyDuration {
+		expired++
+	}
+	if expired > 0 {
+		t.history = append([]*api.ResourcesSample(nil), t.history[expired:]...)
 	}
 }
 
@@ -54,7 +155,11 @@ func (t *TopService) Observe(ctx context.Context) {
 			data, err := t.top(ctx)
 			if err == nil {
 				delay = minReconnectionDelay
-				t.data = data
+				now := time.Now()
+				if t.sampler != nil {
+					t.sampler.Sample(now, data)
+				}
+				t.record(now, data)
 
 				t.readyOnce.Do(func() {
 					close(t.ready)
@@ -76,6 +181,32 @@ func (t *TopService) Observe(ctx context.Context) {
 	}()
 }
 
+func (t *TopService) notify(event *api.ResourceEvent) {
+	req := &api.NotifyRequest{
+		Level: api.NotifyRequest_WARNING,
+	}
+	switch event.Type {
+	case api.ResourceEventType_oom_kill:
+		req.Level = api.NotifyRequest_ERROR
+		process := "A process"
+		if event.Process != "" {
+			process = fmt.Sprintf("A process (probably %s, PID %d)", event.Process, event.Pid)
+		}
+		req.Message = fmt.Sprintf("%s was killed because the workspace ran out of memory (limit: %dMi). Run `gp top --processes` to see what uses memory.", process, event.MemoryLimit/(1024*1024))
+	case api.ResourceEventType_cpu_throttling:
+		req.Message = fmt.Sprintf("The workspace is slowed down because it exceeds its CPU limit of %dm in %.0f%% of the time. Run `gp top --processes` to see what uses CPU.", event.CpuLimit, event.ThrottledPercentage)
+	default:
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := t.notifier.Notify(ctx, req)
+	if err != nil {
+		log.WithError(err).WithField("event", event.Type.String()).Warn("cannot notify about resource event")
+	}
+}
+
 func calcSeverity(value int64) api.ResourceStatusSeverity {
 	switch {
 	case value >= 95:
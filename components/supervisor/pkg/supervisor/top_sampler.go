@@ -0,0 +1,356 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/cgroups"
+	cgroups_v2 "github.com/gitpod-io/gitpod/common-go/cgroups/v2"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+const (
+	// clockTicks is the number of clock ticks per second used by /proc/<pid>/stat (USER_HZ),
+	// which is 100 on all architectures we support.
+	clockTicks = 100
+
+	// throttlingThreshold is the share of CPU periods which must be throttled to raise a throttling event
+	throttlingThreshold = 0.5
+	// throttlingEventCooldown is the minimum time between two throttling events
+	throttlingEventCooldown = 5 * time.Minute
+)
+
+// resourceSampler samples the workspace resources in addition to CPU and memory,
+// i.e. disk usage, IO throughput, pressure stall information and processes.
+// Rates are computed from the difference to the previous sample.
+type resourceSampler struct {
+	CgroupPath   string
+	ProcPath     string
+	DiskLocation string
+
+	last      time.Time
+	lastIO    *cgroups.IOStats
+	lastPSI   map[string]cgroups.PSI
+	lastTicks map[int]uint64
+
+	lastProcesses       []*api.ProcessStatus
+	lastMemoryEvents    *cgroups.MemoryEvents
+	lastCPUStats        *cgroups.CpuStats
+	lastThrottlingEvent time.Time
+}
+
+func newResourceSampler() *resourceSampler {
+	return &resourceSampler{
+		CgroupPath:   cgroups.DefaultMountPoint,
+		ProcPath:     "/proc",
+		DiskLocation: "/workspace",
+	}
+}
+
+// Sample adds disk, IO, pressure, process information and the events since the previous sample
+// to a resources status. Resources which cannot be read are left out.
+func (s *resourceSampler) Sample(now time.Time, status *api.ResourcesStatusResponse) {
+	var elapsed time.Duration
+	if !s.last.IsZero() {
+		elapsed = now.Sub(s.last)
+	}
+	s.last = now
+
+	disk, err := s.sampleDisk()
+	if err != nil {
+		log.WithError(err).Debug("cannot sample disk usage")
+	}
+	status.Disk = disk
+
+	io, err := s.sampleIO(elapsed)
+	if err != nil {
+		log.WithError(err).Debug("cannot sample io")
+	}
+	status.Io = io
+
+	status.Pressure = s.samplePressure(elapsed)
+
+	processes, err := s.sampleProcesses(elapsed)
+	if err != nil {
+		log.WithError(err).Debug("cannot sample processes")
+	}
+	status.Processes = processes
+
+	status.Events = s.sampleEvents(now, processes)
+	if err == nil {
+		s.lastProcesses = processes
+	}
+}
+
+// sampleEvents detects OOM kills and CPU throttling since the previous sample.
+func (s *resourceSampler) sampleEvents(now time.Time, processes []*api.ProcessStatus) (events []*api.ResourceEvent) {
+	memoryEvents, err := cgroups_v2.NewMemoryController(s.CgroupPath).Events()
+	if err != nil {
+		log.WithError(err).Debug("cannot sample memory events")
+	} else {
+		if last := s.lastMemoryEvents; last != nil && memoryEvents.OOMKill > last.OOMKill {
+			// the kernel does not tell us which process it killed. Processes which are gone could have exited
+			// on their own, hence we only name them if exactly as many disappeared as were killed.
+			victims := disappearedProcesses(s.lastProcesses, processes)
+			sort.Slice(victims, func(i, j int) bool { return victims[i].Rss > victims[j].Rss })
+			kills := int(memoryEvents.OOMKill - last.OOMKill)
+			if len(victims) != kills {
+				victims = nil
+			}
+			for i := 0; i < kills && (i == 0 || i < len(victims)); i++ {
+				event := &api.ResourceEvent{
+					Type:      api.ResourceEventType_oom_kill,
+					Timestamp: now.Unix(),
+				}
+				if i < len(victims) {
+					event.Pid = victims[i].Pid
+					event.Process = victims[i].Name
+				}
+				events = append(events, event)
+			}
+		}
+		s.lastMemoryEvents = memoryEvents
+	}
+
+	cpuStats, err := cgroups_v2.NewCpuController(s.CgroupPath).Stat()
+	if err != nil {
+		log.WithError(err).Debug("cannot sample cpu stats")
+	} else {
+		if last := s.lastCPUStats; last != nil {
+			periods := delta(cpuStats.NrPeriods, last.NrPeriods)
+			throttled := delta(cpuStats.NrThrottled, last.NrThrottled)
+			share := 0.0
+			if periods > 0 {
+				share = float64(throttled) / float64(periods)
+			}
+			if share >= throttlingThreshold && now.Sub(s.lastThrottlingEvent) >= throttlingEventCooldown {
+				events = append(events, &api.ResourceEvent{
+					Type:                api.ResourceEventType_cpu_throttling,
+					Timestamp:           now.Unix(),
+					ThrottledPercentage: share * 100,
+				})
+				s.lastThrottlingEvent = now
+			}
+		}
+		s.lastCPUStats = cpuStats
+	}
+	return events
+}
+
+// disappearedProcesses returns the processes which are in before, but not in after.
+func disappearedProcesses(before, after []*api.ProcessStatus) []*api.ProcessStatus {
+	present := make(map[int64]struct{}, len(after))
+	for _, p := range after {
+		present[p.Pid] = struct{}{}
+	}
+	var res []*api.ProcessStatus
+	for _, p := range before {
+		if _, ok := present[p.Pid]; !ok {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+func (s *resourceSampler) sampleDisk() (*api.ResourceStatus, error) {
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(s.DiskLocation, &stat)
+	if err != nil {
+		return nil, err
+	}
+	limit := int64(stat.Blocks) * stat.Bsize
+	used := int64(stat.Blocks-stat.Bfree) * stat.Bsize
+	var percentage int64
+	if limit > 0 {
+		percentage = int64(float64(used) / float64(limit) * 100)
+	}
+	return &api.ResourceStatus{
+		Used:     used,
+		Limit:    limit,
+		Severity: calcSeverity(percentage),
+	}, nil
+}
+
+func (s *resourceSampler) sampleIO(elapsed time.Duration) (*api.IOStatus, error) {
+	stats, err := cgroups_v2.NewIOController(s.CgroupPath).Stat()
+	if err != nil {
+		return nil, err
+	}
+	last := s.lastIO
+	s.lastIO = stats
+
+	res := &api.IOStatus{}
+	if last == nil || elapsed <= 0 {
+		return res, nil
+	}
+	res.ReadBytesPerSecond = int64(float64(delta(stats.ReadBytes, last.ReadBytes)) / elapsed.Seconds())
+	res.WriteBytesPerSecond = int64(float64(delta(stats.WriteBytes, last.WriteBytes)) / elapsed.Seconds())
+	return res, nil
+}
+
+func (s *resourceSampler) samplePressure(elapsed time.Duration) *api.PressureStatus {
+	readers := map[string]func() (cgroups.PSI, error){
+		"cpu":    cgroups_v2.NewCpuController(s.CgroupPath).PSI,
+		"memory": cgroups_v2.NewMemoryController(s.CgroupPath).PSI,
+		"io":     cgroups_v2.NewIOController(s.CgroupPath).PSI,
+	}
+	current := make(map[string]cgroups.PSI, len(readers))
+	for resource, read := range readers {
+		psi, err := read()
+		if err != nil {
+			log.WithError(err).WithField("resource", resource).Debug("cannot sample pressure")
+			continue
+		}
+		current[resource] = psi
+	}
+	last := s.lastPSI
+	s.lastPSI = current
+	if len(current) == 0 {
+		return nil
+	}
+
+	// PSI totals are the stalled time in microseconds
+	share := func(resource string, full bool) float64 {
+		c, ok := current[resource]
+		if !ok || elapsed <= 0 {
+			return 0
+		}
+		l, ok := last[resource]
+		if !ok {
+			return 0
+		}
+		stalled := delta(c.Some, l.Some)
+		if full {
+			stalled = delta(c.Full, l.Full)
+		}
+		return float64(stalled) / float64(elapsed.Microseconds()) * 100
+	}
+	return &api.PressureStatus{
+		CpuSome:    share("cpu", false),
+		MemorySome: share("memory", false),
+		MemoryFull: share("memory", true),
+		IoSome:     share("io", false),
+		IoFull:     share("io", true),
+	}
+}
+
+// sampleProcesses lists the processes with their parents preceding their children.
+func (s *resourceSampler) sampleProcesses(elapsed time.Duration) ([]*api.ProcessStatus, error) {
+	entries, err := os.ReadDir(s.ProcPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		pageSize = int64(os.Getpagesize())
+		procs    = make(map[int64]*api.ProcessStatus)
+		ticks    = make(map[int]uint64)
+	)
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		stat, err := readProcessStat(filepath.Join(s.ProcPath, e.Name(), "stat"))
+		if err != nil {
+			// the process has exited in the meantime
+			continue
+		}
+		p := &api.ProcessStatus{
+			Pid:  int64(pid),
+			Ppid: stat.PPID,
+			Name: stat.Name,
+			Rss:  stat.RSSPages * pageSize,
+		}
+		cmdline, err := os.ReadFile(filepath.Join(s.ProcPath, e.Name(), "cmdline"))
+		if err == nil {
+			p.Cmdline = strings.TrimSpace(string(bytes.ReplaceAll(cmdline, []byte{0}, []byte{' '})))
+		}
+		ticks[pid] = stat.Ticks
+		if last, ok := s.lastTicks[pid]; ok && elapsed > 0 {
+			p.CpuPercentage = float64(delta(stat.Ticks, last)) / clockTicks / elapsed.Seconds() * 100
+		}
+		procs[p.Pid] = p
+	}
+	s.lastTicks = ticks
+
+	children := make(map[int64][]*api.ProcessStatus)
+	var roots []*api.ProcessStatus
+	for _, p := range procs {
+		if _, ok := procs[p.Ppid]; ok && p.Ppid != p.Pid {
+			children[p.Ppid] = append(children[p.Ppid], p)
+		} else {
+			roots = append(roots, p)
+		}
+	}
+	byPID := func(ps []*api.ProcessStatus) {
+		sort.Slice(ps, func(i, j int) bool { return ps[i].Pid < ps[j].Pid })
+	}
+
+	res := make([]*api.ProcessStatus, 0, len(procs))
+	var visit func(ps []*api.ProcessStatus)
+	visit = func(ps []*api.ProcessStatus) {
+		byPID(ps)
+		for _, p := range ps {
+			res = append(res, p)
+			visit(children[p.Pid])
+		}
+	}
+	visit(roots)
+	return res, nil
+}
+
+// delta returns the increase of a counter, or zero if the counter was reset.
+func delta(current, last uint64) uint64 {
+	if current < last {
+		return 0
+	}
+	return current - last
+}
+
+type processStat struct {
+	Name     string
+	PPID     int64
+	Ticks    uint64
+	RSSPages int64
+}
+
+// readProcessStat parses /proc/<pid>/stat, see proc(5).
+func readProcessStat(fn string) (*processStat, error) {
+	content, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	// the name is enclosed in parentheses and may contain spaces and parentheses itself
+	start := bytes.IndexByte(content, '(')
+	end := bytes.LastIndexByte(content, ')')
+	if start == -1 || end < start {
+		return nil, os.ErrInvalid
+	}
+	// fields start with the state, which is the third field in proc(5)
+	fields := strings.Fields(string(content[end+1:]))
+	if len(fields) < 22 {
+		return nil, os.ErrInvalid
+	}
+	field := func(n int) int64 {
+		v, _ := strconv.ParseInt(fields[n-3], 10, 64)
+		return v
+	}
+	return &processStat{
+		Name:     string(content[start+1 : end]),
+		PPID:     field(4),
+		Ticks:    uint64(field(14) + field(15)),
+		RSSPages: field(24),
+	}, nil
+}
@@ -6,10 +6,17 @@ package supervisor
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
 	"github.com/gitpod-io/gitpod/supervisor/api"
 	"golang.org/x/xerrors"
 )
@@ -92,3 +99,228 @@ func TestTopServiceWithUpstreamFailure(t *testing.T) {
 		t.Errorf("Total Cpu should be 5")
 	}
 }
+
+func TestTopServiceHistory(t *testing.T) {
+	topService := NewTopService()
+	start := time.Unix(1000, 0)
+	for i := 0; i < 3*int(historyDuration/time.Second); i++ {
+		now := start.Add(time.Duration(i) * time.Second)
+		topService.record(now, &api.ResourcesStatusResponse{
+			Memory: &api.ResourceStatus{Used: int64(i), Limit: 10},
+			Cpu:    &api.ResourceStatus{Used: int64(i), Limit: 5},
+		})
+	}
+
+	status := topService.Status(&api.ResourcesStatuRequest{})
+	if status.History != nil {
+		t.Errorf("history should only be included if requested")
+	}
+
+	history := topService.Status(&api.ResourcesStatuRequest{IncludeHistory: true}).History
+	if exp := int(historyDuration/historyResolution) + 1; len(history) != exp {
+		t.Fatalf("expected %d samples, got %d", exp, len(history))
+	}
+	for i := 1; i < len(history); i++ {
+		if d := history[i].Timestamp - history[i-1].Timestamp; d != int64(historyResolution/time.Second) {
+			t.Errorf("expected samples %v apart, got %ds", historyResolution, d)
+		}
+	}
+	last := history[len(history)-1]
+	if latest := status.Memory.Used; last.MemoryUsed > latest || latest-last.MemoryUsed >= int64(historyResolution/time.Second) {
+		t.Errorf("last sample is outdated: %d, latest %d", last.MemoryUsed, latest)
+	}
+	if age := last.Timestamp - history[0].Timestamp; age > int64(historyDuration/time.Second) {
+		t.Errorf("history exceeds %v: %ds", historyDuration, age)
+	}
+}
+
+func TestResourceSampler(t *testing.T) {
+	procPath := t.TempDir()
+	writeProc := func(pid int, stat, cmdline string) {
+		dir := filepath.Join(procPath, strconv.Itoa(pid))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "cmdline"), []byte(cmdline), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cgroupPath := t.TempDir()
+	writeCgroup := func(fn, content string) {
+		if err := os.WriteFile(filepath.Join(cgroupPath, fn), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	procStat := func(pid, ppid int, name string, ticks, rssPages int) string {
+		return fmt.Sprintf("%d (%s) S %d 1 1 0 -1 4194560 100 0 0 0 %d 0 0 0 20 0 1 0 100 1000 %d 18446744073709551615", pid, name, ppid, ticks, rssPages)
+	}
+
+	writeProc(1, procStat(1, 0, "supervisor", 100, 10), "/.supervisor/supervisor\x00run\x00")
+	writeProc(20, procStat(20, 1, "bash", 10, 1), "/bin/bash\x00")
+	writeProc(30, procStat(30, 20, "node (main)", 500, 100), "node\x00server.js\x00")
+	writeProc(10, procStat(10, 1, "sleep", 0, 1), "sleep\x00100\x00")
+	writeCgroup("io.stat", "8:0 rbytes=1000 wbytes=2000 rios=1 wios=1 dbytes=0 dios=0\n")
+	writeCgroup("cpu.pressure", "some avg10=0.00 avg60=0.00 avg300=0.00 total=1000\nfull avg10=0.00 avg60=0.00 avg300=0.00 total=0\n")
+	writeCgroup("memory.pressure", "some avg10=0.00 avg60=0.00 avg300=0.00 total=1000\nfull avg10=0.00 avg60=0.00 avg300=0.00 total=500\n")
+
+	sampler := &resourceSampler{
+		CgroupPath:   cgroupPath,
+		ProcPath:     procPath,
+		DiskLocation: cgroupPath,
+	}
+	start := time.Unix(1000, 0)
+	var status api.ResourcesStatusResponse
+	sampler.Sample(start, &status)
+
+	writeProc(30, procStat(30, 20, "node (main)", 700, 200), "node\x00server.js\x00")
+	writeCgroup("io.stat", "8:0 rbytes=5000 wbytes=2000 rios=1 wios=1 dbytes=0 dios=0\n")
+	writeCgroup("cpu.pressure", "some avg10=0.00 avg60=0.00 avg300=0.00 total=1001000\nfull avg10=0.00 avg60=0.00 avg300=0.00 total=0\n")
+	writeCgroup("memory.pressure", "some avg10=0.00 avg60=0.00 avg300=0.00 total=501000\nfull avg10=0.00 avg60=0.00 avg300=0.00 total=200500\n")
+	sampler.Sample(start.Add(2*time.Second), &status)
+
+	pageSize := int64(os.Getpagesize())
+	expectation := &api.ResourcesStatusResponse{
+		Io: &api.IOStatus{ReadBytesPerSecond: 2000},
+		Pressure: &api.PressureStatus{
+			CpuSome:    50,
+			MemorySome: 25,
+			MemoryFull: 10,
+		},
+		Processes: []*api.ProcessStatus{
+			{Pid: 1, Name: "supervisor", Cmdline: "/.supervisor/supervisor run", Rss: 10 * pageSize},
+			{Pid: 10, Ppid: 1, Name: "sleep", Cmdline: "sleep 100", Rss: pageSize},
+			{Pid: 20, Ppid: 1, Name: "bash", Cmdline: "/bin/bash", Rss: pageSize},
+			{Pid: 30, Ppid: 20, Name: "node (main)", Cmdline: "node server.js", CpuPercentage: 100, Rss: 200 * pageSize},
+		},
+	}
+	if status.Disk == nil || status.Disk.Limit == 0 {
+		t.Errorf("expected disk usage, got %v", status.Disk)
+	}
+	status.Disk = nil
+	if diff := cmp.Diff(expectation, &status, cmpopts.IgnoreUnexported(api.ResourcesStatusResponse{}, api.IOStatus{}, api.PressureStatus{}, api.ProcessStatus{})); diff != "" {
+		t.Errorf("unexpected status (-want +got):\n%s", diff)
+	}
+}
+
+func TestResourceSamplerEvents(t *testing.T) {
+	procPath := t.TempDir()
+	writeProc := func(pid int, name string, rssPages int) {
+		dir := filepath.Join(procPath, strconv.Itoa(pid))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		stat := fmt.Sprintf("%d (%s) S 1 1 1 0 -1 4194560 100 0 0 0 0 0 0 0 20 0 1 0 100 1000 %d 18446744073709551615", pid, name, rssPages)
+		if err := os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cgroupPath := t.TempDir()
+	writeCgroup := func(fn, content string) {
+		if err := os.WriteFile(filepath.Join(cgroupPath, fn), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sampler := &resourceSampler{
+		CgroupPath:   cgroupPath,
+		ProcPath:     procPath,
+		DiskLocation: cgroupPath,
+	}
+	sample := func(now time.Time) []*api.ResourceEvent {
+		var status api.ResourcesStatusResponse
+		sampler.Sample(now, &status)
+		return status.Events
+	}
+	ignoreUnexported := cmpopts.IgnoreUnexported(api.ResourceEvent{})
+
+	writeProc(1, "bash", 1)
+	writeProc(2, "java", 1000)
+	writeProc(3, "node", 100)
+	writeCgroup("memory.events", "low 0\nhigh 0\nmax 3\noom 1\noom_kill 0\n")
+	writeCgroup("cpu.stat", "usage_usec 100\nnr_periods 100\nnr_throttled 10\nthrottled_usec 1000\n")
+	start := time.Unix(1000, 0)
+	if events := sample(start); len(events) != 0 {
+		t.Errorf("expected no events on first sample, got %v", events)
+	}
+
+	if err := os.RemoveAll(filepath.Join(procPath, "2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(procPath, "3")); err != nil {
+		t.Fatal(err)
+	}
+	writeCgroup("memory.events", "low 0\nhigh 0\nmax 5\noom 2\noom_kill 1\n")
+	writeCgroup("cpu.stat", "usage_usec 200\nnr_periods 200\nnr_throttled 80\nthrottled_usec 9000\n")
+	expectation := []*api.ResourceEvent{
+		// two processes disappeared, but only one was killed: we cannot tell which one
+		{Type: api.ResourceEventType_oom_kill, Timestamp: 1002},
+		{Type: api.ResourceEventType_cpu_throttling, Timestamp: 1002, ThrottledPercentage: 70},
+	}
+	if diff := cmp.Diff(expectation, sample(start.Add(2*time.Second)), ignoreUnexported); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+
+	// throttling continues, but is only reported again after the cooldown
+	writeCgroup("cpu.stat", "usage_usec 300\nnr_periods 300\nnr_throttled 180\nthrottled_usec 19000\n")
+	if events := sample(start.Add(4 * time.Second)); len(events) != 0 {
+		t.Errorf("expected no events during cooldown, got %v", events)
+	}
+	if err := os.RemoveAll(filepath.Join(procPath, "1")); err != nil {
+		t.Fatal(err)
+	}
+	writeCgroup("memory.events", "low 0\nhigh 0\nmax 7\noom 3\noom_kill 2\n")
+	writeCgroup("cpu.stat", "usage_usec 400\nnr_periods 400\nnr_throttled 280\nthrottled_usec 29000\n")
+	expectation = []*api.ResourceEvent{
+		{Type: api.ResourceEventType_oom_kill, Timestamp: 1000 + int64(throttlingEventCooldown/time.Second) + 2, Pid: 1, Process: "bash"},
+		{Type: api.ResourceEventType_cpu_throttling, Timestamp: 1000 + int64(throttlingEventCooldown/time.Second) + 2, ThrottledPercentage: 100},
+	}
+	if diff := cmp.Diff(expectation, sample(start.Add(throttlingEventCooldown+2*time.Second)), ignoreUnexported); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
+
+type testNotifier struct {
+	requests chan *api.NotifyRequest
+}
+
+func (n *testNotifier) Notify(ctx context.Context, req *api.NotifyRequest) (*api.NotifyResponse, error) {
+	n.requests <- req
+	return &api.NotifyResponse{}, nil
+}
+
+func TestTopServiceNotifiesEvents(t *testing.T) {
+	notifier := &testNotifier{requests: make(chan *api.NotifyRequest, 1)}
+	topService := NewTopService()
+	topService.notifier = notifier
+
+	now := time.Unix(1000, 0)
+	topService.record(now, &api.ResourcesStatusResponse{
+		Memory: &api.ResourceStatus{Used: 1 << 30, Limit: 2 << 30},
+		Cpu:    &api.ResourceStatus{Used: 2000, Limit: 4000},
+		Events: []*api.ResourceEvent{
+			{Type: api.ResourceEventType_oom_kill, Timestamp: now.Unix(), Pid: 42, Process: "java"},
+		},
+	})
+
+	select {
+	case req := <-notifier.requests:
+		if req.Level != api.NotifyRequest_ERROR {
+			t.Errorf("expected error level, got %v", req.Level)
+		}
+		if exp := "A process (probably java, PID 42) was killed because the workspace ran out of memory (limit: 2048Mi)."; !strings.HasPrefix(req.Message, exp) {
+			t.Errorf("expected message starting with %q, got %q", exp, req.Message)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification received")
+	}
+
+	events := topService.Status(&api.ResourcesStatuRequest{IncludeEvents: true}).Events
+	expectation := []*api.ResourceEvent{
+		{Type: api.ResourceEventType_oom_kill, Timestamp: now.Unix(), Pid: 42, Process: "java", MemoryLimit: 2 << 30, CpuLimit: 4000},
+	}
+	if diff := cmp.Diff(expectation, events, cmpopts.IgnoreUnexported(api.ResourceEvent{})); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
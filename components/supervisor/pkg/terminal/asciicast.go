@@ -0,0 +1,157 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// RecordAnnotation toggles the recording of a terminal session if set to "true".
+	RecordAnnotation = "gitpod.supervisor.record"
+	// RecordErrorAnnotation explains why recording a terminal session failed.
+	// The RecordAnnotation is removed when recording fails.
+	RecordErrorAnnotation = "gitpod.supervisor.record.error"
+)
+
+const (
+	recordingExt        = ".cast"
+	recordingPartialExt = ".part"
+)
+
+// asciicastHeader is the first line of an asciicast v2 file.
+// See https://github.com/asciinema/asciinema/blob/develop/doc/asciicast-v2.md
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     uint16            `json:"width"`
+	Height    uint16            `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// asciicastRecorder writes output and resize events of a terminal to an asciicast v2 file.
+// The recording is written to a partial file and moved in place once it's finished.
+type asciicastRecorder struct {
+	f     *os.File
+	path  string
+	start time.Time
+
+	// pending holds the beginning of a UTF-8 sequence that was split across writes
+	pending []byte
+}
+
+func newAsciicastRecorder(location, alias string, header asciicastHeader) (*asciicastRecorder, error) {
+	err := os.MkdirAll(location, 0755)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create recording location: %w", err)
+	}
+
+	start := time.Now()
+	path := filepath.Join(location, recordingID(alias, start)+recordingExt)
+	f, err := os.OpenFile(path+recordingPartialExt, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create recording: %w", err)
+	}
+
+	header.Version = 2
+	header.Timestamp = start.Unix()
+	rec := &asciicastRecorder{f: f, path: path, start: start}
+	err = rec.writeLine(header)
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (r *asciicastRecorder) Output(p []byte) error {
+	data := append(r.pending, p...)
+	data, r.pending = splitIncompleteRune(data)
+	if len(data) == 0 {
+		return nil
+	}
+	return r.event("o", string(data))
+}
+
+func (r *asciicastRecorder) Resize(cols, rows uint16) error {
+	return r.event("r", fmt.Sprintf("%dx%d", cols, rows))
+}
+
+func (r *asciicastRecorder) event(code, data string) error {
+	ts := math.Round(time.Since(r.start).Seconds()*1e6) / 1e6
+	return r.writeLine([]interface{}{ts, code, data})
+}
+
+func (r *asciicastRecorder) writeLine(v interface{}) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = r.f.Write(append(line, '\n'))
+	if err != nil {
+		return xerrors.Errorf("cannot write recording: %w", err)
+	}
+	return nil
+}
+
+// Close finishes the recording.
+func (r *asciicastRecorder) Close() error {
+	var err error
+	if len(r.pending) > 0 {
+		err = r.event("o", string(r.pending))
+		r.pending = nil
+	}
+	cerr := r.f.Close()
+	if err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(r.f.Name(), r.path)
+}
+
+// splitIncompleteRune splits off an incomplete UTF-8 sequence at the end of p.
+func splitIncompleteRune(p []byte) (complete, rest []byte) {
+	for i := 1; i <= utf8.UTFMax && i <= len(p); i++ {
+		if !utf8.RuneStart(p[len(p)-i]) {
+			continue
+		}
+		if !utf8.FullRune(p[len(p)-i:]) {
+			return p[:len(p)-i], append([]byte(nil), p[len(p)-i:]...)
+		}
+		break
+	}
+	return p, nil
+}
+
+func recordingID(alias string, start time.Time) string {
+	return fmt.Sprintf("%s-%d", alias, start.UnixMilli())
+}
+
+// parseRecordingID returns the terminal alias and start time encoded in a recording ID.
+func parseRecordingID(id string) (alias string, start time.Time, ok bool) {
+	idx := strings.LastIndex(id, "-")
+	if idx <= 0 {
+		return "", time.Time{}, false
+	}
+	ms, err := strconv.ParseInt(id[idx+1:], 10, 64)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return id[:idx], time.UnixMilli(ms), true
+}
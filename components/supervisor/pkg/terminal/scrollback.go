@@ -0,0 +1,425 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// DefaultScrollbackMaxSize is the number of bytes of output we retain on disk for each terminal.
+	DefaultScrollbackMaxSize = 16 << 20
+	// DefaultScrollbackSegmentSize is the size of a single scrollback segment file.
+	// Rotation drops whole segments, hence this is the granularity in which old output is discarded.
+	DefaultScrollbackSegmentSize = 1 << 20
+
+	// maxScrollbackSearchLine is the longest line Search reads at once. Longer lines are searched in chunks.
+	maxScrollbackSearchLine = 64 << 10
+	// maxScrollbackMatchLength is the length matched lines returned by Search are shortened to.
+	maxScrollbackMatchLength = 1 << 10
+	// maxScrollbackSearchResultSize limits the total size of the lines returned by Search,
+	// keeping search responses well below the gRPC message size limit.
+	maxScrollbackSearchResultSize = 1 << 20
+
+	scrollbackSegmentExt = ".log"
+)
+
+// ScrollbackConfig configures the on-disk scrollback of terminals.
+type ScrollbackConfig struct {
+	// Location is the directory in which each terminal gets its own scrollback directory.
+	Location string
+	// MaxSize is the maximum number of bytes retained per terminal. Use 0 for the default.
+	MaxSize int64
+	// SegmentSize is the maximum size of a single segment file. Use 0 for the default.
+	SegmentSize int64
+}
+
+// Dir returns the scrollback directory of the terminal with the given alias.
+func (c *ScrollbackConfig) Dir(alias string) string {
+	return filepath.Join(c.Location, alias)
+}
+
+// Open opens the scrollback of the terminal with the given alias.
+func (c *ScrollbackConfig) Open(alias string) (*Scrollback, error) {
+	return OpenScrollback(c.Dir(alias), c.MaxSize, c.SegmentSize)
+}
+
+// Scrollback persists terminal output in size-capped, rotated segment files.
+// Positions in the output are addressed by absolute offsets, i.e. the number
+// of bytes the terminal had written before. Offsets remain stable across rotation.
+type Scrollback struct {
+	dir         string
+	maxSize     int64
+	segmentSize int64
+
+	mu       sync.Mutex
+	segments []int64
+	current  *os.File
+	written  int64
+	closed   bool
+}
+
+// ErrScrollbackClosed is returned when writing to a closed scrollback.
+var ErrScrollbackClosed = errors.New("scrollback is closed")
+
+// OpenScrollback opens the scrollback stored in dir, creating it if it does not exist yet.
+// Writes append to the existing output.
+func OpenScrollback(dir string, maxSize, segmentSize int64) (*Scrollback, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultScrollbackMaxSize
+	}
+	if segmentSize <= 0 {
+		segmentSize = DefaultScrollbackSegmentSize
+	}
+	if segmentSize > maxSize {
+		segmentSize = maxSize
+	}
+
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create scrollback directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read scrollback directory: %w", err)
+	}
+
+	res := &Scrollback{
+		dir:         dir,
+		maxSize:     maxSize,
+		segmentSize: segmentSize,
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, scrollbackSegmentExt) {
+			continue
+		}
+		start, err := strconv.ParseInt(strings.TrimSuffix(name, scrollbackSegmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		res.segments = append(res.segments, start)
+	}
+	sort.Slice(res.segments, func(i, j int) bool { return res.segments[i] < res.segments[j] })
+
+	if n := len(res.segments); n > 0 {
+		last := res.segments[n-1]
+		stat, err := os.Stat(res.segmentPath(last))
+		if err != nil {
+			return nil, xerrors.Errorf("cannot stat scrollback segment: %w", err)
+		}
+		res.written = last + stat.Size()
+	}
+	return res, nil
+}
+
+func (s *Scrollback) segmentPath(start int64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", start, scrollbackSegmentExt))
+}
+
+// Write appends p to the scrollback, rotating segments if necessary.
+func (s *Scrollback) Write(p []byte) (n int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0, ErrScrollbackClosed
+	}
+
+	for len(p) > 0 {
+		if s.current == nil || s.written-s.segments[len(s.segments)-1] >= s.segmentSize {
+			err = s.rotate()
+			if err != nil {
+				return n, err
+			}
+		}
+
+		chunk := p
+		if remaining := s.segmentSize - (s.written - s.segments[len(s.segments)-1]); int64(len(chunk)) > remaining {
+			chunk = chunk[:remaining]
+		}
+		c, err := s.current.Write(chunk)
+		n += c
+		s.written += int64(c)
+		if err != nil {
+			return n, err
+		}
+		p = p[c:]
+	}
+	return n, nil
+}
+
+// rotate starts a new segment unless the last one can still be appended to,
+// and drops the oldest segments once the retained output exceeds maxSize.
+// Callers must hold s.mu.
+func (s *Scrollback) rotate() error {
+	if s.current != nil {
+		err := s.current.Close()
+		s.current = nil
+		if err != nil {
+			return xerrors.Errorf("cannot close scrollback segment: %w", err)
+		}
+	}
+
+	start := s.written
+	if n := len(s.segments); n > 0 && s.written-s.segments[n-1] < s.segmentSize {
+		// continue the last segment, e.g. after the scrollback was reopened
+		start = s.segments[n-1]
+	} else {
+		s.segments = append(s.segments, start)
+	}
+
+	f, err := os.OpenFile(s.segmentPath(start), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return xerrors.Errorf("cannot open scrollback segment: %w", err)
+	}
+	s.current = f
+
+	for len(s.segments) > 1 && s.written+s.segmentSize-s.segments[0] > s.maxSize {
+		err = os.Remove(s.segmentPath(s.segments[0]))
+		if err != nil && !os.IsNotExist(err) {
+			return xerrors.Errorf("cannot remove scrollback segment: %w", err)
+		}
+		s.segments = s.segments[1:]
+	}
+	return nil
+}
+
+// Bounds returns the oldest retained offset and the offset at which the next write will land.
+func (s *Scrollback) Bounds() (start, end int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.bounds()
+}
+
+func (s *Scrollback) bounds() (start, end int64) {
+	if len(s.segments) == 0 {
+		return s.written, s.written
+	}
+	return s.segments[0], s.written
+}
+
+// ReadAt reads up to limit bytes starting at the absolute offset. If the offset
+// has already been rotated out, reading starts at the oldest retained offset instead.
+// It returns the offset of the first byte returned. Use a limit <= 0 to read until the end.
+func (s *Scrollback) ReadAt(offset, limit int64) (data []byte, start int64, err error) {
+	r, start, end, err := s.Reader(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer r.Close()
+
+	if limit <= 0 || start+limit > end {
+		limit = end - start
+	}
+	data = make([]byte, limit)
+	n, err := io.ReadFull(r, data)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, 0, xerrors.Errorf("cannot read scrollback: %w", err)
+	}
+	return data[:n], start, nil
+}
+
+// Search scans the scrollback for lines matching expr, starting at offset. Terminal control
+// sequences are removed from lines before they are matched. Use maxResults <= 0 for no limit.
+// Lines longer than maxScrollbackSearchLine are searched in chunks, matched lines are shortened
+// to maxScrollbackMatchLength around the match, and the search stops once the matches exceed
+// maxScrollbackSearchResultSize.
+func (s *Scrollback) Search(expr *regexp.Regexp, offset int64, maxResults int) ([]ScrollbackMatch, error) {
+	r, start, _, err := s.Reader(offset)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var (
+		res  []ScrollbackMatch
+		size int
+		br   = bufio.NewReaderSize(r, maxScrollbackSearchLine)
+		pos  = start
+	)
+	for {
+		// ReadSlice fails with bufio.ErrBufferFull on lines longer than the buffer, which we search chunk by chunk
+		line, err := br.ReadSlice('\n')
+		if len(line) > 0 {
+			text := SanitizeTerminalOutput(line)
+			if loc := expr.FindStringIndex(text); loc != nil {
+				m := ScrollbackMatch{Offset: pos, Line: excerpt(text, loc, maxScrollbackMatchLength)}
+				res = append(res, m)
+				size += len(m.Line)
+				if maxResults > 0 && len(res) >= maxResults {
+					return res, nil
+				}
+				if size >= maxScrollbackSearchResultSize {
+					return res, nil
+				}
+			}
+			pos += int64(len(line))
+		}
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+		if err != nil && !errors.Is(err, bufio.ErrBufferFull) {
+			return nil, xerrors.Errorf("cannot read scrollback: %w", err)
+		}
+	}
+}
+
+// excerpt shortens text to at most limit bytes around the match at loc.
+func excerpt(text string, loc []int, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+
+	start := loc[0]
+	if l := loc[1] - loc[0]; l < limit {
+		start -= (limit - l) / 2
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + limit
+	if end > len(text) {
+		end = len(text)
+		start = end - limit
+	}
+	// don't cut runes in half
+	for start < end && !utf8.RuneStart(text[start]) {
+		start++
+	}
+	for end > start && end < len(text) && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	return text[start:end]
+}
+
+// ScrollbackMatch is a line in the scrollback found by Search.
+type ScrollbackMatch struct {
+	// Offset is the absolute offset of the beginning of the line.
+	Offset int64
+	// Line is the sanitized content of the line, shortened to maxScrollbackMatchLength around the match.
+	Line string
+}
+
+// Reader returns a reader over the retained output from offset up to the current end.
+// Only opening the segments holds the lock, reading from the returned reader does not block writes.
+// Callers must close the reader.
+func (s *Scrollback) Reader(offset int64) (r io.ReadCloser, start, end int64, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.reader(offset)
+}
+
+// reader implements Reader. The segments are opened eagerly so that concurrent rotation cannot
+// remove them underneath us. Callers must hold s.mu.
+func (s *Scrollback) reader(offset int64) (r io.ReadCloser, start, end int64, err error) {
+	first, end := s.bounds()
+	start = offset
+	if start < first {
+		start = first
+	}
+	if start > end {
+		start = end
+	}
+
+	var (
+		files   multiFileReader
+		readers []io.Reader
+	)
+	for i, seg := range s.segments {
+		segEnd := end
+		if i+1 < len(s.segments) {
+			segEnd = s.segments[i+1]
+		}
+		if segEnd <= start {
+			continue
+		}
+
+		f, err := os.Open(s.segmentPath(seg))
+		if err != nil {
+			files.Close()
+			return nil, 0, 0, xerrors.Errorf("cannot open scrollback segment: %w", err)
+		}
+		files = append(files, f)
+
+		from := seg
+		if start > seg {
+			from = start
+		}
+		readers = append(readers, io.NewSectionReader(f, from-seg, segEnd-from))
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(readers...), files}, start, end, nil
+}
+
+type multiFileReader []*os.File
+
+func (m multiFileReader) Close() error {
+	var err error
+	for _, f := range m {
+		cerr := f.Close()
+		if cerr != nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Close closes the current segment. The scrollback remains on disk and can be read after reopening it.
+func (s *Scrollback) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	if s.current == nil {
+		return nil
+	}
+	err := s.current.Close()
+	s.current = nil
+	return err
+}
+
+// Remove closes the scrollback and deletes it from disk.
+func (s *Scrollback) Remove() error {
+	err := s.Close()
+	rerr := os.RemoveAll(s.dir)
+	if rerr != nil {
+		return xerrors.Errorf("cannot remove scrollback: %w", rerr)
+	}
+	return err
+}
+
+// terminalControlSequence matches CSI and OSC escape sequences as well as other two-byte escapes.
+var terminalControlSequence = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// SanitizeTerminalOutput removes terminal control sequences and line endings from a line of terminal output.
+func SanitizeTerminalOutput(line []byte) string {
+	line = terminalControlSequence.ReplaceAll(line, nil)
+	line = bytes.TrimRight(line, "\r\n")
+	if i := bytes.LastIndexByte(line, '\r'); i >= 0 {
+		// a carriage return moves the cursor back to the start of the line,
+		// so what's visible is what has been written last.
+		line = line[i+1:]
+	}
+	return string(line)
+}
@@ -11,6 +11,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"syscall"
 	"time"
 
@@ -52,6 +54,11 @@ type MuxTerminalService struct {
 	Env          []string
 	DefaultCreds *syscall.Credential
 
+	// Scrollback configures the on-disk scrollback of terminals opened with scrollback. If nil, no scrollback is persisted.
+	Scrollback *ScrollbackConfig
+	// RecordingLocation is the directory terminal recordings are stored in. Terminals cannot be recorded if empty.
+	RecordingLocation string
+
 	api.UnimplementedTerminalServiceServer
 }
 
@@ -98,9 +105,24 @@ func (srv *MuxTerminalService) OpenWithOptions(ctx context.Context, req *api.Ope
 	for key, value := range req.Env {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("%v=%v", key, value))
 	}
+	if options.Annotations == nil {
+		options.Annotations = make(map[string]string)
+	}
 	for k, v := range req.Annotations {
 		options.Annotations[k] = v
 	}
+	if options.RecordingLocation == "" {
+		options.RecordingLocation = srv.RecordingLocation
+	}
+	if req.ResizePolicy != api.TerminalResizePolicy_last_writer {
+		options.ResizePolicy = req.ResizePolicy
+	}
+	if req.Record {
+		if options.RecordingLocation == "" {
+			return nil, status.Error(codes.FailedPrecondition, ErrRecordingUnavailable.Error())
+		}
+		options.Annotations[RecordAnnotation] = "true"
+	}
 	if req.Size != nil {
 		options.Size = &pty.Winsize{
 			Cols: uint16(req.Size.Cols),
@@ -109,6 +131,12 @@ func (srv *MuxTerminalService) OpenWithOptions(ctx context.Context, req *api.Ope
 			Y:    uint16(req.Size.HeightPx),
 		}
 	}
+	if req.Scrollback {
+		if srv.Scrollback == nil {
+			return nil, status.Error(codes.FailedPrecondition, "terminal scrollback is not available")
+		}
+		options.Scrollback = srv.Scrollback
+	}
 	alias, err := srv.Mux.Start(cmd, options)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -120,6 +148,15 @@ func (srv *MuxTerminalService) OpenWithOptions(ctx context.Context, req *api.Ope
 	if term != nil {
 		starterToken = term.StarterToken
 	}
+	if req.Record && term != nil {
+		if reason, failed := term.GetAnnotations()[RecordErrorAnnotation]; failed {
+			// the request context has no deadline, but the shell might ignore SIGTERM
+			closeCtx, cancel := context.WithTimeout(ctx, failedRecordingCloseTimeout)
+			_ = srv.Mux.CloseTerminal(closeCtx, alias)
+			cancel()
+			return nil, status.Errorf(codes.Internal, "cannot record terminal session: %s", reason)
+		}
+	}
 
 	terminal, found := srv.get(alias)
 	if !found {
@@ -131,6 +168,10 @@ func (srv *MuxTerminalService) OpenWithOptions(ctx context.Context, req *api.Ope
 	}, nil
 }
 
+// failedRecordingCloseTimeout is the time the shell of a terminal whose recording could not be started
+// has to exit before it's killed.
+const failedRecordingCloseTimeout = 5 * time.Second
+
 // Close closes a terminal for the given alias.
 func (srv *MuxTerminalService) Shutdown(ctx context.Context, req *api.ShutdownTerminalRequest) (*api.ShutdownTerminalResponse, error) {
 	err := srv.Mux.CloseTerminal(ctx, req.Alias)
@@ -207,6 +248,8 @@ func (srv *MuxTerminalService) get(alias string) (*api.Terminal, bool) {
 		Annotations:    term.GetAnnotations(),
 		Title:          title,
 		TitleSource:    titleSource,
+		ResizePolicy:   term.ResizePolicy(),
+		Clients:        term.Clients(),
 	}, true
 }
 
@@ -218,11 +261,40 @@ func (srv *MuxTerminalService) Listen(req *api.ListenTerminalRequest, resp api.T
 	if !ok {
 		return status.Error(codes.NotFound, "terminal not found")
 	}
-	stdout := term.Stdout.Listen()
+	presence, writeToken, leave, err := term.Join(req.ClientId, req.ClientName, req.Role, req.Token)
+	if err == ErrClientExists {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err == ErrOwnerExists {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err == ErrNotOwner {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer leave()
+
+	// the client needs its write token before anything else
+	if writeToken != "" {
+		err = resp.Send(&api.ListenTerminalResponse{Output: &api.ListenTerminalResponse_WriteToken{WriteToken: writeToken}})
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	stdout := term.Stdout.ListenWithOptions(TermListenOptions{
+		Offset: req.Offset,
+	})
 	defer stdout.Close()
 
-	log.WithField("alias", req.Alias).Info("new terminal client")
-	defer log.WithField("alias", req.Alias).Info("terminal client left")
+	log.WithField("alias", req.Alias).WithField("client", req.ClientId).WithField("role", req.Role.String()).Info("new terminal client")
+	defer log.WithField("alias", req.Alias).WithField("client", req.ClientId).Info("terminal client left")
+
+	// the goroutines below stop once we return
+	ctx, cancel := context.WithCancel(resp.Context())
+	defer cancel()
 
 	errchan := make(chan error, 1)
 	messages := make(chan *api.ListenTerminalResponse, 1)
@@ -257,7 +329,7 @@ func (srv *MuxTerminalService) Listen(req *api.ListenTerminalRequest, resp api.T
 		defer t.Stop()
 		for {
 			select {
-			case <-resp.Context().Done():
+			case <-ctx.Done():
 				return
 			case <-t.C:
 				newTitle, newTitleSource, _ := term.GetTitle()
@@ -270,6 +342,20 @@ func (srv *MuxTerminalService) Listen(req *api.ListenTerminalRequest, resp api.T
 			}
 		}
 	}()
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case p := <-presence:
+				select {
+				case messages <- &api.ListenTerminalResponse{Output: &api.ListenTerminalResponse_Presence{Presence: p}}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
 	for {
 		var err error
 		select {
@@ -297,6 +383,9 @@ func (srv *MuxTerminalService) Write(ctx context.Context, req *api.WriteTerminal
 	if !ok {
 		return nil, status.Error(codes.NotFound, "terminal not found")
 	}
+	if err := term.CanWrite(req.ClientId, req.WriteToken); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
 
 	n, err := term.PTY.Write(req.Stdin)
 	if err != nil {
@@ -305,6 +394,30 @@ func (srv *MuxTerminalService) Write(ctx context.Context, req *api.WriteTerminal
 	return &api.WriteTerminalResponse{BytesWritten: uint32(n)}, nil
 }
 
+// SetClientRole changes the role of a client listening to the terminal.
+func (srv *MuxTerminalService) SetClientRole(ctx context.Context, req *api.SetTerminalClientRoleRequest) (*api.SetTerminalClientRoleResponse, error) {
+	srv.Mux.mu.RLock()
+	term, ok := srv.Mux.terms[req.Alias]
+	srv.Mux.mu.RUnlock()
+	if !ok {
+		return nil, status.Error(codes.NotFound, "terminal not found")
+	}
+
+	err := term.SetClientRole(req.Token, req.ClientId, req.Role)
+	switch err {
+	case nil:
+		return &api.SetTerminalClientRoleResponse{}, nil
+	case ErrNotOwner:
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case ErrUnknownClient:
+		return nil, status.Error(codes.NotFound, err.Error())
+	case ErrOwnerRole:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+}
+
 // SetSize sets the terminal's size.
 func (srv *MuxTerminalService) SetSize(ctx context.Context, req *api.SetTerminalSizeRequest) (*api.SetTerminalSizeResponse, error) {
 	srv.Mux.mu.RLock()
@@ -314,18 +427,27 @@ func (srv *MuxTerminalService) SetSize(ctx context.Context, req *api.SetTerminal
 		return nil, status.Error(codes.NotFound, "terminal not found")
 	}
 
+	size := &pty.Winsize{
+		Cols: uint16(req.Size.Cols),
+		Rows: uint16(req.Size.Rows),
+		X:    uint16(req.Size.WidthPx),
+		Y:    uint16(req.Size.HeightPx),
+	}
+	if req.ClientId != "" && term.ResizePolicy() != api.TerminalResizePolicy_last_writer {
+		err := term.SetClientSize(req.ClientId, req.WriteToken, size)
+		if err != nil {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return &api.SetTerminalSizeResponse{}, nil
+	}
+
 	// Setting the size only works with the starter token or when forcing it.
 	// This protects us from multiple listener mangling the terminal.
 	if !(req.GetForce() || req.GetToken() == term.StarterToken) {
 		return nil, status.Error(codes.FailedPrecondition, "wrong token or force not set")
 	}
 
-	err := pty.Setsize(term.PTY, &pty.Winsize{
-		Cols: uint16(req.Size.Cols),
-		Rows: uint16(req.Size.Rows),
-		X:    uint16(req.Size.WidthPx),
-		Y:    uint16(req.Size.HeightPx),
-	})
+	err := term.SetSize(size)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -353,6 +475,162 @@ func (srv *MuxTerminalService) UpdateAnnotations(ctx context.Context, req *api.U
 	if !ok {
 		return nil, status.Error(codes.NotFound, "terminal not found")
 	}
-	term.UpdateAnnotations(req.Changed, req.Deleted)
+	err := term.UpdateAnnotations(req.Changed, req.Deleted)
+	if err == ErrRecordingUnavailable {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 	return &api.UpdateTerminalAnnotationsResponse{}, nil
 }
+
+const (
+	defaultScrollbackReadLimit     = 1 << 20
+	defaultScrollbackSearchResults = 1000
+)
+
+// ReadScrollback reads a range of the terminal's output from its on-disk scrollback.
+func (srv *MuxTerminalService) ReadScrollback(ctx context.Context, req *api.ReadTerminalScrollbackRequest) (*api.ReadTerminalScrollbackResponse, error) {
+	scrollback, err := srv.scrollback(req.Alias)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > defaultScrollbackReadLimit {
+		limit = defaultScrollbackReadLimit
+	}
+	data, offset, err := scrollback.ReadAt(req.Offset, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	first, end := scrollback.Bounds()
+	return &api.ReadTerminalScrollbackResponse{
+		Data:        data,
+		Offset:      offset,
+		FirstOffset: first,
+		EndOffset:   end,
+	}, nil
+}
+
+// SearchScrollback searches the terminal's on-disk scrollback for lines matching a regular expression.
+func (srv *MuxTerminalService) SearchScrollback(ctx context.Context, req *api.SearchTerminalScrollbackRequest) (*api.SearchTerminalScrollbackResponse, error) {
+	pattern := req.Pattern
+	if req.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	expr, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid pattern: %v", err)
+	}
+
+	scrollback, err := srv.scrollback(req.Alias)
+	if err != nil {
+		return nil, err
+	}
+
+	maxResults := int(req.MaxResults)
+	if maxResults == 0 {
+		maxResults = defaultScrollbackSearchResults
+	}
+	matches, err := scrollback.Search(expr, req.Offset, maxResults)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &api.SearchTerminalScrollbackResponse{
+		Matches: make([]*api.TerminalScrollbackMatch, 0, len(matches)),
+	}
+	for _, m := range matches {
+		res.Matches = append(res.Matches, &api.TerminalScrollbackMatch{
+			Offset: m.Offset,
+			Line:   m.Line,
+		})
+	}
+	return res, nil
+}
+
+// scrollback returns the scrollback of an open terminal. Scrollbacks synchronise their own reads and writes.
+func (srv *MuxTerminalService) scrollback(alias string) (*Scrollback, error) {
+	srv.Mux.mu.RLock()
+	term, ok := srv.Mux.terms[alias]
+	srv.Mux.mu.RUnlock()
+	if !ok {
+		return nil, status.Error(codes.NotFound, "terminal not found")
+	}
+	if term.Stdout.scrollback == nil {
+		return nil, status.Error(codes.FailedPrecondition, "terminal has no scrollback")
+	}
+	return term.Stdout.scrollback, nil
+}
+
+// ListRecordings lists finished terminal recordings.
+func (srv *MuxTerminalService) ListRecordings(ctx context.Context, req *api.ListTerminalRecordingsRequest) (*api.ListTerminalRecordingsResponse, error) {
+	res := &api.ListTerminalRecordingsResponse{}
+	if srv.RecordingLocation == "" {
+		return res, nil
+	}
+
+	entries, err := os.ReadDir(srv.RecordingLocation)
+	if os.IsNotExist(err) {
+		return res, nil
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, recordingExt) {
+			continue
+		}
+		id := strings.TrimSuffix(name, recordingExt)
+		alias, start, ok := parseRecordingID(id)
+		if !ok || (req.Alias != "" && req.Alias != alias) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		res.Recordings = append(res.Recordings, &api.TerminalRecording{
+			Id:        id,
+			Alias:     alias,
+			StartTime: start.UnixMilli(),
+			Size:      info.Size(),
+		})
+	}
+	return res, nil
+}
+
+// DownloadRecording downloads a finished terminal recording in the asciicast v2 format.
+func (srv *MuxTerminalService) DownloadRecording(req *api.DownloadTerminalRecordingRequest, resp api.TerminalService_DownloadRecordingServer) error {
+	if srv.RecordingLocation == "" || req.Id == "" || filepath.Base(req.Id) != req.Id {
+		return status.Error(codes.NotFound, "recording not found")
+	}
+	f, err := os.Open(filepath.Join(srv.RecordingLocation, req.Id+recordingExt))
+	if os.IsNotExist(err) {
+		return status.Error(codes.NotFound, "recording not found")
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer f.Close()
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			serr := resp.Send(&api.DownloadTerminalRecordingResponse{Data: buf[:n]})
+			if serr != nil {
+				return serr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+}
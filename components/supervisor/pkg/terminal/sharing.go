@@ -0,0 +1,284 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+
+	_pty "github.com/creack/pty"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+var (
+	// ErrClientExists means a client with the same ID is already listening to the terminal.
+	ErrClientExists = errors.New("client is already listening to the terminal")
+	// ErrOwnerExists means a client tried to join as owner, but the terminal already has an owner.
+	ErrOwnerExists = errors.New("terminal already has an owner")
+	// ErrReadOnly means a read-only client tried to write to the terminal.
+	ErrReadOnly = errors.New("read-only clients cannot write to the terminal")
+	// ErrUnknownClient means a client which isn't listening to the terminal, or which does not present its write token, tried to write to it.
+	ErrUnknownClient = errors.New("only clients listening to the terminal can write to it")
+	// ErrAnonymousWrite means a client without ID tried to write to a terminal with owner.
+	ErrAnonymousWrite = errors.New("only clients listening to the terminal can write to a terminal with owner")
+	// ErrNotOwner means a client tried to act as owner without the terminal's starter token.
+	ErrNotOwner = errors.New("only the terminal's owner can do this")
+	// ErrOwnerRole means SetClientRole tried to grant or revoke the owner role.
+	ErrOwnerRole = errors.New("the owner role cannot be granted or revoked")
+)
+
+// presenceBufferSize is the number of presence events buffered per listener.
+// Listeners which fall behind miss events, but every event carries all current clients.
+const presenceBufferSize = 16
+
+type termClient struct {
+	ID   string
+	Name string
+	Role api.TerminalClientRole
+	Size *_pty.Winsize
+
+	// WriteToken authenticates the client's writes. Unlike the ID it's known to the client only.
+	WriteToken string
+}
+
+func (c *termClient) toAPI() *api.TerminalClient {
+	return &api.TerminalClient{
+		Id:   c.ID,
+		Name: c.Name,
+		Role: c.Role,
+	}
+}
+
+// Join registers a client listening to the terminal and returns a channel of presence events,
+// starting with the current clients. Anonymous clients (without ID) receive presence events
+// but are not announced to others. Callers must call leave once the client is gone.
+//
+// The role is only what the client asks for: joining as owner requires the starter token,
+// and once the terminal has an owner, everyone else joins read-only until the owner grants them write access.
+// Clients with ID receive a write token which they have to present when they write to the terminal.
+func (term *Term) Join(id, name string, role api.TerminalClientRole, token string) (presence <-chan *api.TerminalPresence, writeToken string, leave func(), err error) {
+	if id != "" {
+		writeToken, err = newWriteToken()
+		if err != nil {
+			return nil, "", nil, err
+		}
+	}
+
+	term.mu.Lock()
+	defer term.mu.Unlock()
+
+	var client *termClient
+	if id != "" {
+		if _, exists := term.clients[id]; exists {
+			return nil, "", nil, ErrClientExists
+		}
+		switch role {
+		case api.TerminalClientRole_owner:
+			if !term.isStarterToken(token) {
+				return nil, "", nil, ErrNotOwner
+			}
+			if term.owner() != nil {
+				return nil, "", nil, ErrOwnerExists
+			}
+		case api.TerminalClientRole_writer:
+			if term.owner() != nil {
+				role = api.TerminalClientRole_read_only
+			}
+		}
+		client = &termClient{ID: id, Name: name, Role: role, WriteToken: writeToken}
+		term.clients[id] = client
+		term.clientOrder = append(term.clientOrder, id)
+		term.broadcastPresence(client, true)
+	}
+
+	events := make(chan *api.TerminalPresence, presenceBufferSize)
+	events <- &api.TerminalPresence{Clients: term.clientList()}
+	term.presence[events] = struct{}{}
+
+	leave = func() {
+		term.mu.Lock()
+		defer term.mu.Unlock()
+
+		delete(term.presence, events)
+		if client == nil {
+			return
+		}
+		delete(term.clients, client.ID)
+		for i, cid := range term.clientOrder {
+			if cid == client.ID {
+				term.clientOrder = append(term.clientOrder[:i], term.clientOrder[i+1:]...)
+				break
+			}
+		}
+		term.broadcastPresence(client, false)
+		term.applyClientSizes()
+	}
+	return events, writeToken, leave, nil
+}
+
+func newWriteToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Clients returns the identified clients listening to the terminal.
+func (term *Term) Clients() []*api.TerminalClient {
+	term.mu.RLock()
+	defer term.mu.RUnlock()
+	return term.clientList()
+}
+
+// ResizePolicy returns the terminal's resize policy.
+func (term *Term) ResizePolicy() api.TerminalResizePolicy {
+	return term.resizePolicy
+}
+
+// CanWrite returns an error if the client must not write to the terminal. Clients which listen to the terminal
+// with an ID can write using their write token unless they're read-only. Anonymous clients can write to terminals
+// without owner only, i.e. to terminals which aren't shared.
+func (term *Term) CanWrite(clientID, writeToken string) error {
+	term.mu.RLock()
+	defer term.mu.RUnlock()
+	if clientID == "" {
+		if term.owner() != nil {
+			return ErrAnonymousWrite
+		}
+		return nil
+	}
+
+	c, ok := term.clients[clientID]
+	if !ok || writeToken == "" || subtle.ConstantTimeCompare([]byte(writeToken), []byte(c.WriteToken)) != 1 {
+		return ErrUnknownClient
+	}
+	if c.Role == api.TerminalClientRole_read_only {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// SetClientRole changes the role of a client on behalf of the owner, who proves to be the owner using the starter token.
+func (term *Term) SetClientRole(token, clientID string, role api.TerminalClientRole) error {
+	if role == api.TerminalClientRole_owner {
+		return ErrOwnerRole
+	}
+
+	term.mu.Lock()
+	defer term.mu.Unlock()
+	if !term.isStarterToken(token) {
+		return ErrNotOwner
+	}
+	c, ok := term.clients[clientID]
+	if !ok {
+		return ErrUnknownClient
+	}
+	if c.Role == role {
+		return nil
+	}
+	if c.Role == api.TerminalClientRole_owner {
+		return ErrOwnerRole
+	}
+	c.Role = role
+	term.broadcastPresence(c, true)
+	return nil
+}
+
+func (term *Term) isStarterToken(token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(term.StarterToken)) == 1
+}
+
+// SetClientSize records the size of a client and resizes the terminal according to its resize policy.
+// The client proves its identity with the write token it received on Join, otherwise ErrUnknownClient is returned.
+func (term *Term) SetClientSize(clientID, writeToken string, size *_pty.Winsize) error {
+	term.mu.Lock()
+	defer term.mu.Unlock()
+
+	c, ok := term.clients[clientID]
+	if !ok || writeToken == "" || subtle.ConstantTimeCompare([]byte(writeToken), []byte(c.WriteToken)) != 1 {
+		return ErrUnknownClient
+	}
+	c.Size = size
+	term.applyClientSizes()
+	return nil
+}
+
+// applyClientSizes resizes the terminal to the size derived from its clients. Callers must hold term.mu.
+func (term *Term) applyClientSizes() {
+	var size *_pty.Winsize
+	switch term.resizePolicy {
+	case api.TerminalResizePolicy_owner_size:
+		if owner := term.owner(); owner != nil {
+			size = owner.Size
+		}
+	case api.TerminalResizePolicy_smallest:
+		for _, id := range term.clientOrder {
+			cs := term.clients[id].Size
+			if cs == nil {
+				continue
+			}
+			if size == nil {
+				s := *cs
+				size = &s
+				continue
+			}
+			if cs.Cols < size.Cols {
+				size.Cols, size.X = cs.Cols, cs.X
+			}
+			if cs.Rows < size.Rows {
+				size.Rows, size.Y = cs.Rows, cs.Y
+			}
+		}
+	}
+	if size == nil {
+		return
+	}
+
+	current, err := _pty.GetsizeFull(term.PTY)
+	if err == nil && current.Cols == size.Cols && current.Rows == size.Rows {
+		return
+	}
+	err = term.SetSize(size)
+	if err != nil {
+		log.WithError(err).WithField("alias", term.alias).Warn("cannot apply terminal size")
+	}
+}
+
+// owner returns the terminal's owner, if any. Callers must hold term.mu.
+func (term *Term) owner() *termClient {
+	for _, c := range term.clients {
+		if c.Role == api.TerminalClientRole_owner {
+			return c
+		}
+	}
+	return nil
+}
+
+// clientList returns the identified clients in the order they joined. Callers must hold term.mu.
+func (term *Term) clientList() []*api.TerminalClient {
+	res := make([]*api.TerminalClient, 0, len(term.clientOrder))
+	for _, id := range term.clientOrder {
+		res = append(res, term.clients[id].toAPI())
+	}
+	return res
+}
+
+// broadcastPresence notifies all listeners that a client joined or left. Callers must hold term.mu.
+func (term *Term) broadcastPresence(client *termClient, joined bool) {
+	clients := term.clientList()
+	for events := range term.presence {
+		select {
+		case events <- &api.TerminalPresence{Client: client.toAPI(), Joined: joined, Clients: clients}:
+		default:
+			// the listener isn't keeping up - it will learn about the current clients with the next event
+		}
+	}
+}
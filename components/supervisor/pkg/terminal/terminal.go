@@ -60,6 +60,18 @@ func (m *Mux) Get(alias string) (*Term, bool) {
 	return term, ok
 }
 
+// GetByPID returns the alias of the terminal whose command runs in the process with the given PID.
+func (m *Mux) GetByPID(pid int) (alias string, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for alias, term := range m.terms {
+		if term.Command.Process != nil && term.Command.Process.Pid == pid {
+			return alias, true
+		}
+	}
+	return "", false
+}
+
 // Start starts a new command in its own pseudo-terminal and returns an alias
 // for that pseudo terminal.
 func (m *Mux) Start(cmd *exec.Cmd, options TermOptions) (alias string, err error) {
@@ -171,6 +183,15 @@ func newTerm(alias string, cmd *exec.Cmd, options TermOptions) (*Term, error) {
 		timeout = NoTimeout
 	}
 
+	var scrollback *Scrollback
+	if options.Scrollback != nil {
+		scrollback, err = options.Scrollback.Open(alias)
+		if err != nil {
+			log.WithError(err).WithField("alias", alias).Warn("cannot open terminal scrollback - output is not persisted")
+			scrollback = nil
+		}
+	}
+
 	annotations := options.Annotations
 	if annotations == nil {
 		annotations = make(map[string]string)
@@ -247,28 +268,54 @@ func newTerm(alias string, cmd *exec.Cmd, options TermOptions) (*Term, error) {
 	if err := cmd.Start(); err != nil {
 		pts.Close()
 		pty.Close()
+		if scrollback != nil {
+			scrollback.Close()
+		}
 		return nil, err
 	}
 
 	res := &Term{
+		alias:   alias,
 		PTY:     pty,
 		pts:     pts,
 		Command: cmd,
 		Stdout: &multiWriter{
-			timeout:   timeout,
-			listener:  make(map[*multiWriterListener]struct{}),
-			recorder:  recorder,
-			logStdout: options.LogToStdout,
-			logLabel:  alias,
+			timeout:    timeout,
+			listener:   make(map[*multiWriterListener]struct{}),
+			recorder:   recorder,
+			scrollback: scrollback,
+			logStdout:  options.LogToStdout,
+			logLabel:   alias,
 		},
-		annotations:  annotations,
-		defaultTitle: options.Title,
+		annotations:       annotations,
+		defaultTitle:      options.Title,
+		recordingLocation: options.RecordingLocation,
+		resizePolicy:      options.ResizePolicy,
+		clients:           make(map[string]*termClient),
+		presence:          make(map[chan *api.TerminalPresence]struct{}),
 
 		StarterToken: token.String(),
 
 		waitDone: make(chan struct{}),
 	}
 
+	res.Stdout.recordingFailed = func(err error) {
+		res.mu.Lock()
+		defer res.mu.Unlock()
+		if res.Stdout.isRecording() {
+			// recording has been restarted in the meantime
+			return
+		}
+		res.recordingFailed(err)
+	}
+	if annotations[RecordAnnotation] == "true" {
+		err = res.updateRecording()
+		if err != nil {
+			log.WithError(err).WithField("alias", alias).Warn("cannot record terminal session")
+			res.recordingFailed(err)
+		}
+	}
+
 	//nolint:errcheck
 	go io.Copy(res.Stdout, pty)
 	return res, nil
@@ -293,10 +340,22 @@ type TermOptions struct {
 
 	// LogToStdout forwards the terminal's stdout to supervisor's stdout
 	LogToStdout bool
+
+	// Scrollback persists the terminal's output on disk if set
+	Scrollback *ScrollbackConfig
+
+	// RecordingLocation is the directory session recordings are stored in.
+	// Sessions cannot be recorded if empty.
+	RecordingLocation string
+
+	// ResizePolicy determines how the terminal size is derived from the sizes of its clients.
+	ResizePolicy api.TerminalResizePolicy
 }
 
 // Term is a pseudo-terminal.
 type Term struct {
+	alias string
+
 	PTY *os.File
 	pts *os.File
 
@@ -310,6 +369,13 @@ type Term struct {
 	defaultTitle string
 	title        string
 
+	recordingLocation string
+
+	resizePolicy api.TerminalResizePolicy
+	clients      map[string]*termClient
+	clientOrder  []string
+	presence     map[chan *api.TerminalPresence]struct{}
+
 	Stdout *multiWriter
 
 	waitErr  error
@@ -350,15 +416,74 @@ func (term *Term) GetAnnotations() map[string]string {
 	return annotations
 }
 
-func (term *Term) UpdateAnnotations(changed map[string]string, deleted []string) {
+// UpdateAnnotations changes the terminal's annotations. Changing the RecordAnnotation starts or stops recording.
+func (term *Term) UpdateAnnotations(changed map[string]string, deleted []string) error {
 	term.mu.Lock()
 	defer term.mu.Unlock()
+	recording := term.annotations[RecordAnnotation]
 	for k, v := range changed {
 		term.annotations[k] = v
 	}
 	for _, k := range deleted {
 		delete(term.annotations, k)
 	}
+	if term.annotations[RecordAnnotation] == recording {
+		return nil
+	}
+	err := term.updateRecording()
+	if err != nil && term.annotations[RecordAnnotation] == "true" {
+		term.recordingFailed(err)
+	}
+	return err
+}
+
+// ErrRecordingUnavailable means the terminal cannot be recorded.
+var ErrRecordingUnavailable = errors.New("recording is not available")
+
+// updateRecording starts or stops recording the terminal session depending on the RecordAnnotation.
+// Callers must hold term.mu or have exclusive access to term.
+func (term *Term) updateRecording() error {
+	if term.annotations[RecordAnnotation] != "true" {
+		return term.Stdout.stopRecording()
+	}
+	if term.recordingLocation == "" {
+		return ErrRecordingUnavailable
+	}
+
+	header := asciicastHeader{
+		Title: term.defaultTitle,
+		Env: map[string]string{
+			"SHELL": term.Command.Path,
+			"TERM":  "xterm-256color",
+		},
+	}
+	if size, err := _pty.GetsizeFull(term.PTY); err == nil {
+		header.Width = size.Cols
+		header.Height = size.Rows
+	}
+	err := term.Stdout.startRecording(term.recordingLocation, header)
+	if err != nil {
+		return err
+	}
+	delete(term.annotations, RecordErrorAnnotation)
+	return nil
+}
+
+// recordingFailed resets the RecordAnnotation s.t. clients don't assume the session is still recorded,
+// and reports the reason using the RecordErrorAnnotation. Callers must hold term.mu or have exclusive access to term.
+func (term *Term) recordingFailed(err error) {
+	delete(term.annotations, RecordAnnotation)
+	term.annotations[RecordErrorAnnotation] = err.Error()
+}
+
+// SetSize sets the terminal's size and records the resize event.
+func (term *Term) SetSize(size *_pty.Winsize) error {
+	err := _pty.Setsize(term.PTY, size)
+	if err != nil {
+		return err
+	}
+	term.Stdout.recordResize(size.Cols, size.Rows)
+	return nil
 }
 
 func (term *Term) resolveForegroundCommand() (string, error) {
@@ -443,6 +568,12 @@ type multiWriter struct {
 	// ring buffer to record last 256kb of pty output
 	// new listener is initialized with the latest recodring first
 	recorder *RingBuffer
+	// scrollback persists the pty output on disk, may be nil
+	scrollback *Scrollback
+	// cast records the terminal session while it's being recorded
+	cast *asciicastRecorder
+	// recordingFailed is called when recording fails midway
+	recordingFailed func(err error)
 
 	logStdout bool
 	logLabel  string
@@ -500,6 +631,9 @@ var closedListener = io.NopCloser(closedTerminalListener{})
 type TermListenOptions struct {
 	// timeout after which a listener is dropped. Use 0 for default timeout.
 	ReadTimeout time.Duration
+
+	// Offset is the absolute output offset to replay from. If nil, the latest recording is replayed.
+	Offset *int64
 }
 
 // Listen listens in on the multi-writer stream.
@@ -532,9 +666,11 @@ func (mw *multiWriter) ListenWithOptions(options TermListenOptions) io.ReadClose
 		timeout:   timeout,
 	}
 
-	recording := mw.recorder.Bytes()
+	replay := mw.replay(options.Offset)
 	go func() {
-		_, _ = w.Write(recording)
+		// the replay is read outside of mw.mu s.t. reading the scrollback from disk doesn't block the terminal output.
+		_, _ = io.Copy(w, replay)
+		_ = replay.Close()
 
 		// copy bytes from channel to writer.
 		// Note: we close the writer independently of the write operation s.t. we don't
@@ -571,11 +707,52 @@ func (mw *multiWriter) ListenWithOptions(options TermListenOptions) io.ReadClose
 	return res
 }
 
+// replay returns the output a new listener starts with. It captures the output written so far,
+// but reading from it does not require mw.mu. Callers must hold mw.mu and close the reader.
+func (mw *multiWriter) replay(offset *int64) io.ReadCloser {
+	if offset == nil {
+		return io.NopCloser(bytes.NewReader(mw.recorder.Bytes()))
+	}
+	if mw.scrollback != nil {
+		r, _, _, err := mw.scrollback.Reader(*offset)
+		if err == nil {
+			return r
+		}
+		log.WithError(err).WithField("alias", mw.logLabel).Warn("cannot replay terminal scrollback")
+	}
+
+	// without a scrollback we can only replay what's left in the recording
+	recording := mw.recorder.Bytes()
+	start := mw.recorder.TotalWritten() - int64(len(recording))
+	if skip := *offset - start; skip > 0 {
+		if skip >= int64(len(recording)) {
+			return io.NopCloser(bytes.NewReader(nil))
+		}
+		recording = recording[skip:]
+	}
+	return io.NopCloser(bytes.NewReader(recording))
+}
+
 func (mw *multiWriter) Write(p []byte) (n int, err error) {
 	mw.mu.Lock()
 	defer mw.mu.Unlock()
 
 	mw.recorder.Write(p)
+	if mw.cast != nil {
+		err := mw.cast.Output(p)
+		if err != nil {
+			log.WithError(err).WithField("alias", mw.logLabel).Warn("cannot record terminal session - stopping recording")
+			mw.abortRecording(err)
+		}
+	}
+	if mw.scrollback != nil {
+		_, err := mw.scrollback.Write(p)
+		if err != nil && !errors.Is(err, ErrScrollbackClosed) {
+			// we stop persisting the output to keep offsets consistent with what's on disk
+			log.WithError(err).WithField("alias", mw.logLabel).Warn("cannot write terminal scrollback - output is no longer persisted")
+			_ = mw.scrollback.Close()
+		}
+	}
 	if mw.logStdout {
 		log.WithFields(logrus.Fields{
 			"terminalOutput": true,
@@ -616,9 +793,89 @@ func (mw *multiWriter) Close() error {
 			err = cerr
 		}
 	}
+	mw.finishRecording()
+	if mw.scrollback != nil {
+		// the scrollback is only available while the terminal is open
+		cerr := mw.scrollback.Remove()
+		if cerr != nil {
+			err = cerr
+		}
+	}
 	return err
 }
 
+// startRecording starts recording the terminal session unless it's recorded already.
+func (mw *multiWriter) startRecording(location string, header asciicastHeader) error {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	if mw.closed || mw.cast != nil {
+		return nil
+	}
+	cast, err := newAsciicastRecorder(location, mw.logLabel, header)
+	if err != nil {
+		return err
+	}
+	mw.cast = cast
+	return nil
+}
+
+// stopRecording finishes the current recording, if any.
+func (mw *multiWriter) stopRecording() error {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	if mw.cast == nil {
+		return nil
+	}
+	err := mw.cast.Close()
+	mw.cast = nil
+	return err
+}
+
+// finishRecording is like stopRecording but logs errors. Callers must hold mw.mu.
+func (mw *multiWriter) finishRecording() {
+	if mw.cast == nil {
+		return
+	}
+	err := mw.cast.Close()
+	mw.cast = nil
+	if err != nil {
+		log.WithError(err).WithField("alias", mw.logLabel).Warn("cannot finish terminal recording")
+	}
+}
+
+func (mw *multiWriter) recordResize(cols, rows uint16) {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	if mw.cast == nil {
+		return
+	}
+	err := mw.cast.Resize(cols, rows)
+	if err != nil {
+		log.WithError(err).WithField("alias", mw.logLabel).Warn("cannot record terminal session - stopping recording")
+		mw.abortRecording(err)
+	}
+}
+
+// abortRecording finishes the current recording after it failed and notifies the terminal. Callers must hold mw.mu.
+func (mw *multiWriter) abortRecording(err error) {
+	mw.finishRecording()
+	if mw.recordingFailed != nil {
+		// the terminal's lock is acquired before mw.mu, hence we must not wait for it here
+		go mw.recordingFailed(err)
+	}
+}
+
+// isRecording returns true if the terminal session is being recorded.
+func (mw *multiWriter) isRecording() bool {
+	mw.mu.RLock()
+	defer mw.mu.RUnlock()
+
+	return mw.cast != nil
+}
+
 func (mw *multiWriter) ListenerCount() int {
 	mw.mu.Lock()
 	defer mw.mu.Unlock()
@@ -7,16 +7,22 @@ package terminal
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/creack/pty"
 	"github.com/google/go-cmp/cmp"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/gitpod-io/gitpod/supervisor/api"
 )
@@ -379,3 +385,610 @@ func TestWorkDirProvider(t *testing.T) {
 		expectedWorkDir: providedWorkDir,
 	})
 }
+
+func TestScrollback(t *testing.T) {
+	type read struct {
+		Offset int64
+		Limit  int64
+	}
+	type readResult struct {
+		Data   string
+		Offset int64
+	}
+	tests := []struct {
+		Desc        string
+		MaxSize     int64
+		SegmentSize int64
+		Writes      []string
+		Reads       []read
+		Expectation []readResult
+	}{
+		{
+			Desc:        "no rotation",
+			MaxSize:     100,
+			SegmentSize: 10,
+			Writes:      []string{"hello ", "world"},
+			Reads:       []read{{Offset: 0}, {Offset: 6, Limit: 3}, {Offset: 20}},
+			Expectation: []readResult{{Data: "hello world"}, {Data: "wor", Offset: 6}, {Data: "", Offset: 11}},
+		},
+		{
+			Desc:        "rotated out",
+			MaxSize:     8,
+			SegmentSize: 4,
+			Writes:      []string{"0123456789", "abcd"},
+			Reads:       []read{{Offset: 0}, {Offset: 9, Limit: 2}},
+			Expectation: []readResult{{Data: "89abcd", Offset: 8}, {Data: "9a", Offset: 9}},
+		},
+		{
+			Desc:        "segment boundaries",
+			MaxSize:     100,
+			SegmentSize: 3,
+			Writes:      []string{"ab", "cdefg", "h"},
+			Reads:       []read{{Offset: 1, Limit: 6}},
+			Expectation: []readResult{{Data: "bcdefg", Offset: 1}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			sb, err := OpenScrollback(t.TempDir(), test.MaxSize, test.SegmentSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sb.Close()
+			for _, w := range test.Writes {
+				_, err := sb.Write([]byte(w))
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var act []readResult
+			for _, r := range test.Reads {
+				data, offset, err := sb.ReadAt(r.Offset, r.Limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				act = append(act, readResult{Data: string(data), Offset: offset})
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestScrollbackSearch(t *testing.T) {
+	dir := t.TempDir()
+	sb, err := OpenScrollback(dir, 1024, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sb.Write([]byte("$ make\r\n\x1b[32mbuilding\x1b[0m foo\r\nerror: foo failed\r\n10%\r100%\r\nERROR: bar failed\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sb.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// search must work on a reopened scrollback, e.g. after the terminal has been closed
+	sb, err = OpenScrollback(dir, 1024, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sb.Close()
+
+	tests := []struct {
+		Desc        string
+		Pattern     string
+		Offset      int64
+		MaxResults  int
+		Expectation []ScrollbackMatch
+	}{
+		{
+			Desc:    "control sequences",
+			Pattern: "^building foo$",
+			Expectation: []ScrollbackMatch{
+				{Offset: 8, Line: "building foo"},
+			},
+		},
+		{
+			Desc:    "ignore case",
+			Pattern: "(?i)error",
+			Expectation: []ScrollbackMatch{
+				{Offset: 31, Line: "error: foo failed"},
+				{Offset: 60, Line: "ERROR: bar failed"},
+			},
+		},
+		{
+			Desc:        "max results",
+			Pattern:     "failed",
+			MaxResults:  1,
+			Expectation: []ScrollbackMatch{{Offset: 31, Line: "error: foo failed"}},
+		},
+		{
+			Desc:        "from offset",
+			Pattern:     "failed",
+			Offset:      32,
+			Expectation: []ScrollbackMatch{{Offset: 32, Line: "rror: foo failed"}, {Offset: 60, Line: "ERROR: bar failed"}},
+		},
+		{
+			Desc:        "carriage return",
+			Pattern:     "%",
+			Expectation: []ScrollbackMatch{{Offset: 50, Line: "100%"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act, err := sb.Search(regexp.MustCompile(test.Pattern), test.Offset, test.MaxResults)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected matches (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestScrollbackSearchLongLines(t *testing.T) {
+	sb, err := OpenScrollback(t.TempDir(), 4*maxScrollbackSearchLine, maxScrollbackSearchLine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sb.Close()
+
+	long := strings.Repeat("x", 2*maxScrollbackSearchLine) + "needle" + strings.Repeat("y", maxScrollbackSearchLine) + "\n"
+	_, err = sb.Write([]byte(long))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matches, err := sb.Search(regexp.MustCompile("needle"), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("expected one match, got %d", len(matches))
+	}
+	m := matches[0]
+	if m.Offset != 2*maxScrollbackSearchLine {
+		t.Errorf("expected the match in the chunk at offset %d, got %d", 2*maxScrollbackSearchLine, m.Offset)
+	}
+	if len(m.Line) != maxScrollbackMatchLength || !strings.Contains(m.Line, "needle") {
+		t.Errorf("expected a %d byte excerpt around the match, got %d bytes", maxScrollbackMatchLength, len(m.Line))
+	}
+}
+
+func TestScrollbackLifecycle(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Scrollback  bool
+		Expectation codes.Code
+	}{
+		{Desc: "opt-in", Scrollback: true, Expectation: codes.OK},
+		{Desc: "no scrollback by default", Expectation: codes.FailedPrecondition},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			terminalService := NewMuxTerminalService(NewMux())
+			terminalService.DefaultWorkdir = t.TempDir()
+			terminalService.Scrollback = &ScrollbackConfig{Location: t.TempDir()}
+
+			resp, err := terminalService.Open(context.Background(), &api.OpenTerminalRequest{Scrollback: test.Scrollback})
+			if err != nil {
+				t.Fatal(err)
+			}
+			alias := resp.Terminal.Alias
+			_, err = terminalService.ReadScrollback(context.Background(), &api.ReadTerminalScrollbackRequest{Alias: alias})
+			if code := status.Code(err); code != test.Expectation {
+				t.Fatalf("unexpected status reading the scrollback: want %v, got %v", test.Expectation, code)
+			}
+			_, err = os.Stat(terminalService.Scrollback.Dir(alias))
+			if exists := err == nil; exists != test.Scrollback {
+				t.Fatalf("unexpected scrollback directory: want exists=%v, got %v", test.Scrollback, err)
+			}
+
+			// the shell might ignore SIGTERM - it's killed once ctx is done
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			_, err = terminalService.Shutdown(ctx, &api.ShutdownTerminalRequest{Alias: alias})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(terminalService.Scrollback.Dir(alias)); !os.IsNotExist(err) {
+				t.Errorf("scrollback was not removed when the terminal closed: %v", err)
+			}
+			_, err = terminalService.ReadScrollback(context.Background(), &api.ReadTerminalScrollbackRequest{Alias: alias})
+			if code := status.Code(err); code != codes.NotFound {
+				t.Errorf("expected NotFound reading the scrollback of a closed terminal, got %v", code)
+			}
+		})
+	}
+}
+
+func TestAsciicastRecorder(t *testing.T) {
+	location := t.TempDir()
+	rec, err := newAsciicastRecorder(location, "alias", asciicastHeader{Width: 80, Height: 24, Title: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hello := []byte("héllo\r\n")
+	for _, p := range [][]byte{hello[:2], hello[2:]} {
+		err = rec.Output(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = rec.Resize(120, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rec.Output([]byte{0xe2, 0x82})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rec.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected exactly one finished recording, got %d entries", len(entries))
+	}
+	id := strings.TrimSuffix(entries[0].Name(), recordingExt)
+	if alias, _, ok := parseRecordingID(id); !ok || alias != "alias" {
+		t.Errorf("unexpected recording ID %s", id)
+	}
+	content, err := os.ReadFile(filepath.Join(location, entries[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	var header asciicastHeader
+	err = json.Unmarshal([]byte(lines[0]), &header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.Timestamp = 0
+	if diff := cmp.Diff(asciicastHeader{Version: 2, Width: 80, Height: 24, Title: "test"}, header); diff != "" {
+		t.Errorf("unexpected header (-want +got):\n%s", diff)
+	}
+
+	var events [][]string
+	for _, line := range lines[1:] {
+		var ev []interface{}
+		err = json.Unmarshal([]byte(line), &ev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := ev[0].(float64); !ok {
+			t.Errorf("event time is not a number: %s", line)
+		}
+		events = append(events, []string{ev[1].(string), ev[2].(string)})
+	}
+	expectation := [][]string{
+		{"o", "h"},
+		{"o", "éllo\r\n"},
+		{"r", "120x40"},
+		{"o", "\ufffd\ufffd"},
+	}
+	if diff := cmp.Diff(expectation, events); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
+
+func TestRecording(t *testing.T) {
+	terminalService := NewMuxTerminalService(NewMux())
+	terminalService.DefaultWorkdir = t.TempDir()
+	terminalService.RecordingLocation = t.TempDir()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		terminalService.Mux.Close(ctx)
+	}()
+
+	resp, err := terminalService.Open(context.Background(), &api.OpenTerminalRequest{Record: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	alias := resp.Terminal.Alias
+	_, err = terminalService.SetSize(context.Background(), &api.SetTerminalSizeRequest{
+		Alias:    alias,
+		Priority: &api.SetTerminalSizeRequest_Force{Force: true},
+		Size:     &api.TerminalSize{Cols: 100, Rows: 30},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = terminalService.UpdateAnnotations(context.Background(), &api.UpdateTerminalAnnotationsRequest{
+		Alias:   alias,
+		Deleted: []string{RecordAnnotation},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := terminalService.ListRecordings(context.Background(), &api.ListTerminalRecordingsRequest{Alias: alias})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Recordings) != 1 {
+		t.Fatalf("expected one recording, got %d", len(list.Recordings))
+	}
+	content, err := os.ReadFile(filepath.Join(terminalService.RecordingLocation, list.Recordings[0].Id+recordingExt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), `"r","100x30"]`) {
+		t.Errorf("recording does not contain the resize event:\n%s", content)
+	}
+
+	_, err = terminalService.UpdateAnnotations(context.Background(), &api.UpdateTerminalAnnotationsRequest{
+		Alias:   alias,
+		Changed: map[string]string{RecordAnnotation: "true"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err = terminalService.ListRecordings(context.Background(), &api.ListTerminalRecordingsRequest{Alias: alias})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Recordings) != 1 {
+		t.Errorf("recordings in progress must not be listed, got %d recordings", len(list.Recordings))
+	}
+}
+
+func TestRecordingFailure(t *testing.T) {
+	terminalService := NewMuxTerminalService(NewMux())
+	terminalService.DefaultWorkdir = t.TempDir()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		terminalService.Mux.Close(ctx)
+	}()
+
+	// a file as recording location makes starting the recording fail
+	terminalService.RecordingLocation = filepath.Join(t.TempDir(), "file")
+	err := os.WriteFile(terminalService.RecordingLocation, nil, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = terminalService.Open(context.Background(), &api.OpenTerminalRequest{Record: true})
+	if code := status.Code(err); code != codes.Internal {
+		t.Errorf("expected opening a recorded terminal to fail, got %v", err)
+	}
+
+	resp, err := terminalService.Open(context.Background(), &api.OpenTerminalRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	alias := resp.Terminal.Alias
+	term, _ := terminalService.Mux.Get(alias)
+	_, err = terminalService.UpdateAnnotations(context.Background(), &api.UpdateTerminalAnnotationsRequest{
+		Alias:   alias,
+		Changed: map[string]string{RecordAnnotation: "true"},
+	})
+	if err == nil {
+		t.Error("expected starting the recording to fail")
+	}
+	if annotations := term.GetAnnotations(); annotations[RecordAnnotation] != "" || annotations[RecordErrorAnnotation] == "" {
+		t.Errorf("record annotation was not reset after starting the recording failed: %v", annotations)
+	}
+
+	// recording fails midway
+	terminalService.RecordingLocation = t.TempDir()
+	term.recordingLocation = terminalService.RecordingLocation
+	_, err = terminalService.UpdateAnnotations(context.Background(), &api.UpdateTerminalAnnotationsRequest{
+		Alias:   alias,
+		Changed: map[string]string{RecordAnnotation: "true"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if annotations := term.GetAnnotations(); annotations[RecordAnnotation] != "true" || annotations[RecordErrorAnnotation] != "" {
+		t.Fatalf("unexpected annotations after starting the recording: %v", annotations)
+	}
+	term.Stdout.mu.Lock()
+	term.Stdout.cast.f.Close()
+	term.Stdout.mu.Unlock()
+	err = term.SetSize(&pty.Winsize{Cols: 80, Rows: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		annotations := term.GetAnnotations()
+		if annotations[RecordAnnotation] == "" && annotations[RecordErrorAnnotation] != "" {
+			break
+		}
+		if i == 50 {
+			t.Fatalf("record annotation was not reset after the recording failed: %v", annotations)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSharing(t *testing.T) {
+	newTerminal := func(t *testing.T, policy api.TerminalResizePolicy) (*MuxTerminalService, *Term) {
+		terminalService := NewMuxTerminalService(NewMux())
+		terminalService.DefaultWorkdir = t.TempDir()
+		t.Cleanup(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			terminalService.Mux.Close(ctx)
+		})
+
+		resp, err := terminalService.Open(context.Background(), &api.OpenTerminalRequest{ResizePolicy: policy})
+		if err != nil {
+			t.Fatal(err)
+		}
+		term, ok := terminalService.Mux.Get(resp.Terminal.Alias)
+		if !ok {
+			t.Fatal("no terminal")
+		}
+		return terminalService, term
+	}
+	clientIDs := func(clients []*api.TerminalClient) []string {
+		var res []string
+		for _, c := range clients {
+			res = append(res, c.Id)
+		}
+		return res
+	}
+
+	t.Run("roles and presence", func(t *testing.T) {
+		terminalService, term := newTerminal(t, api.TerminalResizePolicy_last_writer)
+
+		// terminals which aren't shared accept anonymous writes
+		_, err := terminalService.Write(context.Background(), &api.WriteTerminalRequest{Alias: term.alias, Stdin: []byte("\r\n")})
+		if err != nil {
+			t.Errorf("expected anonymous write to a terminal without owner to succeed, got %v", err)
+		}
+
+		_, _, _, err = term.Join("alice", "Alice", api.TerminalClientRole_owner, "")
+		if err != ErrNotOwner {
+			t.Errorf("expected ErrNotOwner joining as owner without the starter token, got %v", err)
+		}
+		ownerEvents, ownerToken, leaveOwner, err := term.Join("alice", "Alice", api.TerminalClientRole_owner, term.StarterToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer leaveOwner()
+		if ownerToken == "" {
+			t.Fatal("expected a write token")
+		}
+		if diff := cmp.Diff([]string{"alice"}, clientIDs((<-ownerEvents).Clients)); diff != "" {
+			t.Errorf("unexpected initial clients (-want +got):\n%s", diff)
+		}
+
+		_, _, _, err = term.Join("bob", "Bob", api.TerminalClientRole_owner, term.StarterToken)
+		if err != ErrOwnerExists {
+			t.Errorf("expected ErrOwnerExists, got %v", err)
+		}
+		_, _, _, err = term.Join("alice", "Alice", api.TerminalClientRole_writer, "")
+		if err != ErrClientExists {
+			t.Errorf("expected ErrClientExists, got %v", err)
+		}
+
+		// once there's an owner, writers need to be granted write access
+		_, bobToken, leaveObserver, err := term.Join("bob", "Bob", api.TerminalClientRole_writer, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bobToken == ownerToken {
+			t.Error("expected clients to receive distinct write tokens")
+		}
+		joined := <-ownerEvents
+		if !joined.Joined || joined.Client.Id != "bob" || joined.Client.Role != api.TerminalClientRole_read_only {
+			t.Errorf("unexpected join event: %v", joined)
+		}
+		for _, c := range append(joined.Clients, joined.Client) {
+			if strings.Contains(c.String(), ownerToken) || strings.Contains(c.String(), bobToken) {
+				t.Errorf("presence event leaks a write token: %v", c)
+			}
+		}
+
+		for _, req := range []*api.WriteTerminalRequest{
+			{Alias: term.alias, Stdin: []byte("ls\r\n"), ClientId: "bob", WriteToken: bobToken},
+			{Alias: term.alias, Stdin: []byte("ls\r\n")},
+			{Alias: term.alias, Stdin: []byte("ls\r\n"), ClientId: "mallory"},
+			{Alias: term.alias, Stdin: []byte("ls\r\n"), ClientId: "alice"},
+			{Alias: term.alias, Stdin: []byte("ls\r\n"), ClientId: "alice", WriteToken: bobToken},
+		} {
+			_, err = terminalService.Write(context.Background(), req)
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("expected write of client %q to be denied, got %v", req.ClientId, err)
+			}
+		}
+		_, err = terminalService.Write(context.Background(), &api.WriteTerminalRequest{Alias: term.alias, Stdin: []byte("\r\n"), ClientId: "alice", WriteToken: ownerToken})
+		if err != nil {
+			t.Errorf("expected owner write to succeed, got %v", err)
+		}
+
+		_, err = terminalService.SetClientRole(context.Background(), &api.SetTerminalClientRoleRequest{Alias: term.alias, ClientId: "bob", Role: api.TerminalClientRole_writer})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("expected granting write access without the starter token to be denied, got %v", err)
+		}
+		_, err = terminalService.SetClientRole(context.Background(), &api.SetTerminalClientRoleRequest{Alias: term.alias, Token: term.StarterToken, ClientId: "bob", Role: api.TerminalClientRole_writer})
+		if err != nil {
+			t.Fatal(err)
+		}
+		changed := <-ownerEvents
+		if !changed.Joined || changed.Client.Id != "bob" || changed.Client.Role != api.TerminalClientRole_writer {
+			t.Errorf("unexpected role change event: %v", changed)
+		}
+		_, err = terminalService.Write(context.Background(), &api.WriteTerminalRequest{Alias: term.alias, Stdin: []byte("\r\n"), ClientId: "bob", WriteToken: bobToken})
+		if err != nil {
+			t.Errorf("expected write of granted writer to succeed, got %v", err)
+		}
+
+		leaveObserver()
+		left := <-ownerEvents
+		if left.Joined || left.Client.Id != "bob" {
+			t.Errorf("unexpected leave event: %v", left)
+		}
+		if diff := cmp.Diff([]string{"alice"}, clientIDs(left.Clients)); diff != "" {
+			t.Errorf("unexpected clients after leave (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("resize policies", func(t *testing.T) {
+		tests := []struct {
+			Policy      api.TerminalResizePolicy
+			Expectation [2]uint16
+		}{
+			{Policy: api.TerminalResizePolicy_smallest, Expectation: [2]uint16{80, 20}},
+			{Policy: api.TerminalResizePolicy_owner_size, Expectation: [2]uint16{100, 20}},
+		}
+		for _, test := range tests {
+			t.Run(test.Policy.String(), func(t *testing.T) {
+				terminalService, term := newTerminal(t, test.Policy)
+				_, ownerToken, leaveOwner, err := term.Join("alice", "Alice", api.TerminalClientRole_owner, term.StarterToken)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer leaveOwner()
+				_, writerToken, leaveWriter, err := term.Join("bob", "Bob", api.TerminalClientRole_writer, "")
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer leaveWriter()
+
+				for _, req := range []*api.SetTerminalSizeRequest{
+					{Alias: term.alias, ClientId: "alice", WriteToken: ownerToken, Size: &api.TerminalSize{Cols: 100, Rows: 20}},
+					{Alias: term.alias, ClientId: "bob", WriteToken: writerToken, Size: &api.TerminalSize{Cols: 80, Rows: 40}},
+				} {
+					_, err = terminalService.SetSize(context.Background(), req)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				for _, req := range []*api.SetTerminalSizeRequest{
+					{Alias: term.alias, ClientId: "alice", Size: &api.TerminalSize{Cols: 10, Rows: 5}},
+					{Alias: term.alias, ClientId: "alice", WriteToken: writerToken, Size: &api.TerminalSize{Cols: 10, Rows: 5}},
+				} {
+					_, err = terminalService.SetSize(context.Background(), req)
+					if status.Code(err) != codes.PermissionDenied {
+						t.Errorf("expected resizing on behalf of another client to be denied, got %v", err)
+					}
+				}
+
+				size, err := pty.GetsizeFull(term.PTY)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(test.Expectation, [2]uint16{size.Cols, size.Rows}); diff != "" {
+					t.Errorf("unexpected terminal size (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+}
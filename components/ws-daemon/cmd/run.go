@@ -31,7 +31,12 @@ import (
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/daemon"
 )
 
-const grpcServerName = "wsdaemon"
+const (
+	grpcServerName = "wsdaemon"
+
+	// prePullStatusPath is the path under which ws-daemon serves the warm status of the node
+	prePullStatusPath = "/prepull/status"
+)
 
 // serveCmd represents the serve command
 var runCmd = &cobra.Command{
@@ -54,15 +59,26 @@ var runCmd = &cobra.Command{
 		}
 
 		health := healthcheck.NewHandler()
-		srv, err := baseserver.New(grpcServerName,
+		opts := []baseserver.Option{
 			baseserver.WithGRPC(&cfg.Service),
 			baseserver.WithHealthHandler(health),
 			baseserver.WithMetricsRegistry(dmn.MetricsRegistry()),
 			baseserver.WithVersion(Version),
-		)
+		}
+		prePullStatus := dmn.PrePullStatus()
+		if prePullStatus != nil {
+			if cfg.HTTP == nil {
+				log.Fatal("Pre-pulling images requires an HTTP server config.")
+			}
+			opts = append(opts, baseserver.WithHTTP(cfg.HTTP))
+		}
+		srv, err := baseserver.New(grpcServerName, opts...)
 		if err != nil {
 			log.WithError(err).Fatal("Cannot set up server.")
 		}
+		if prePullStatus != nil {
+			srv.HTTPMux().Handle(prePullStatusPath, prePullStatus)
+		}
 
 		health.AddReadinessCheck("grpc-server", grpcProbe(cfg.Service))
 		health.AddReadinessCheck("ws-daemon", dmn.ReadinessProbe())
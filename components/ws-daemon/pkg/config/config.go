@@ -35,4 +35,6 @@ func Read(fn string) (*Config, error) {
 type Config struct {
 	Daemon  daemon.Config                  `json:"daemon"`
 	Service baseserver.ServerConfiguration `json:"service"`
+	// HTTP serves the warm status of the node. Required if pre-pulling images is enabled.
+	HTTP *baseserver.ServerConfiguration `json:"http,omitempty"`
 }
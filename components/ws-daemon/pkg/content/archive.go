@@ -11,12 +11,15 @@ import (
 
 	"github.com/containers/storage/pkg/archive"
 	"github.com/containers/storage/pkg/idtools"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/xerrors"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/common-go/tracing"
 	carchive "github.com/gitpod-io/gitpod/content-service/pkg/archive"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 )
 
 // BuildTarbal creates an OCI compatible tar file dst from the folder src, expecting the overlay whiteout format
@@ -40,6 +43,111 @@ func BuildTarbal(ctx context.Context, src string, dst string, fullWorkspaceBacku
 		log.Warn("Full workspace backup is disabled.")
 	}
 
+	tarReader, err := createTarStream(src, cfg)
+	if err != nil {
+		return
+	}
+	defer tarReader.Close()
+
+	tarFile, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY, 0755)
+	if err != nil {
+		return xerrors.Errorf("Unable to create tar file: %v", err.Error())
+	}
+
+	_, err = io.Copy(tarFile, tarReader)
+	if err != nil {
+		return xerrors.Errorf("Unable create tar file: %v", err.Error())
+	}
+
+	return
+}
+
+// StreamTarbal writes an OCI compatible tar stream of the folder src to dst, expecting the overlay whiteout format.
+// The stream is compressed using the given compression and level, where level zero selects the algorithm's default.
+// Returns the descriptor of what was written to dst and the digest of the uncompressed tar stream, i.e. the layer's DiffID.
+func StreamTarbal(ctx context.Context, src string, dst io.Writer, compression carchive.Compression, level int, opts ...carchive.TarOption) (desc *ociv1.Descriptor, diffID digest.Digest, err error) {
+	var cfg carchive.TarConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	//nolint:staticcheck,ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "streamTarbal")
+	span.LogKV("src", src, "compression", compression)
+	defer tracing.FinishSpan(span, &err)
+
+	if _, err := os.Stat(src); err != nil {
+		return nil, "", xerrors.Errorf("Unable to tar files: %v", err.Error())
+	}
+
+	tarReader, err := createTarStream(src, cfg)
+	if err != nil {
+		return nil, "", err
+	}
+	defer tarReader.Close()
+
+	var (
+		uncompressed = digest.Canonical.Digester()
+		compressed   = digest.Canonical.Digester()
+		out          = &countingWriter{W: io.MultiWriter(dst, compressed.Hash())}
+	)
+	cw, err := carchive.Compress(out, compression, level)
+	if err != nil {
+		return nil, "", err
+	}
+	_, err = io.Copy(cw, io.TeeReader(tarReader, uncompressed.Hash()))
+	if err != nil {
+		return nil, "", xerrors.Errorf("Unable to stream tar: %v", err.Error())
+	}
+	// closing the compressor flushes the remaining compressed data
+	err = cw.Close()
+	if err != nil {
+		return nil, "", xerrors.Errorf("Unable to stream tar: %v", err.Error())
+	}
+	span.LogKV("size", out.N)
+
+	return &ociv1.Descriptor{
+		MediaType: compression.MediaType(),
+		Digest:    compressed.Digest(),
+		Size:      out.N,
+	}, uncompressed.Digest(), nil
+}
+
+// UploadTarbal streams a tar of the folder src into the remote storage without buffering it on disk.
+// See StreamTarbal for details on the tar stream.
+func UploadTarbal(ctx context.Context, rs storage.DirectAccess, src string, name string, compression carchive.Compression, level int, tarOpts []carchive.TarOption, opts ...storage.UploadOption) (desc *ociv1.Descriptor, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "uploadTarbal")
+	defer tracing.FinishSpan(span, &err)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	streamed := make(chan error, 1)
+	go func() {
+		var err error
+		desc, _, err = StreamTarbal(ctx, src, pw, compression, level, tarOpts...)
+		pw.CloseWithError(err)
+		streamed <- err
+	}()
+
+	opts = append([]storage.UploadOption{storage.WithContentType(compression.ContentType())}, opts...)
+	_, _, err = rs.UploadStream(ctx, pr, name, opts...)
+	// unblock the tar stream in case the upload stopped reading early
+	pr.CloseWithError(err)
+	serr := <-streamed
+	if serr != nil {
+		return nil, xerrors.Errorf("cannot create archive: %w", serr)
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("cannot upload archive: %w", err)
+	}
+
+	return desc, nil
+}
+
+func createTarStream(src string, cfg carchive.TarConfig) (io.ReadCloser, error) {
 	uidMaps := make([]idtools.IDMap, len(cfg.UIDMaps))
 	for i, m := range cfg.UIDMaps {
 		uidMaps[i] = idtools.IDMap{
@@ -57,25 +165,20 @@ func BuildTarbal(ctx context.Context, src string, dst string, fullWorkspaceBacku
 		}
 	}
 
-	tarReader, err := archive.TarWithOptions(src, &archive.TarOptions{
+	return archive.TarWithOptions(src, &archive.TarOptions{
 		UIDMaps:     uidMaps,
 		GIDMaps:     gidMaps,
 		Compression: archive.Uncompressed,
 	})
-	if err != nil {
-		return
-	}
-	defer tarReader.Close()
-
-	tarFile, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY, 0755)
-	if err != nil {
-		return xerrors.Errorf("Unable to create tar file: %v", err.Error())
-	}
+}
 
-	_, err = io.Copy(tarFile, tarReader)
-	if err != nil {
-		return xerrors.Errorf("Unable create tar file: %v", err.Error())
-	}
+type countingWriter struct {
+	W io.Writer
+	N int64
+}
 
+func (c *countingWriter) Write(p []byte) (n int, err error) {
+	n, err = c.W.Write(p)
+	c.N += int64(n)
 	return
 }
@@ -0,0 +1,149 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package content_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/opencontainers/go-digest"
+
+	carchive "github.com/gitpod-io/gitpod/content-service/pkg/archive"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage/mock"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/content"
+)
+
+func TestUploadTarbal(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Compression carchive.Compression
+		MediaType   string
+		ContentType string
+		UploadErr   error
+		ExpectedErr bool
+	}{
+		{
+			Name:        "uncompressed",
+			Compression: carchive.CompressionNone,
+			MediaType:   "application/vnd.oci.image.layer.v1.tar",
+			ContentType: "application/x-tar",
+		},
+		{
+			Name:        "gzip",
+			Compression: carchive.CompressionGzip,
+			MediaType:   "application/vnd.oci.image.layer.v1.tar+gzip",
+			ContentType: "application/gzip",
+		},
+		{
+			Name:        "zstd",
+			Compression: carchive.CompressionZstd,
+			MediaType:   "application/vnd.oci.image.layer.v1.tar+zstd",
+			ContentType: "application/zstd",
+		},
+		{
+			Name:        "upload failure",
+			Compression: carchive.CompressionZstd,
+			ContentType: "application/zstd",
+			UploadErr:   errors.New("upload failed"),
+			ExpectedErr: true,
+		},
+	}
+
+	src := t.TempDir()
+	files := map[string]string{
+		"hello.txt":        "hello world",
+		"nested/other.txt": "some other content",
+	}
+	for name, content := range files {
+		fn := filepath.Join(src, name)
+		err := os.MkdirAll(filepath.Dir(fn), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(fn, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var uploaded []byte
+			ctrl := gomock.NewController(t)
+			rs := mock.NewMockDirectAccess(ctrl)
+			rs.EXPECT().UploadStream(gomock.Any(), gomock.Any(), storage.DefaultBackup, gomock.Any()).DoAndReturn(func(ctx context.Context, src io.Reader, name string, opts ...storage.UploadOption) (string, string, error) {
+				options, err := storage.GetUploadOptions(opts)
+				if err != nil {
+					return "", "", err
+				}
+				if options.ContentType != test.ContentType {
+					t.Errorf("unexpected content type: %s", options.ContentType)
+				}
+				if test.UploadErr != nil {
+					return "", "", test.UploadErr
+				}
+				uploaded, err = io.ReadAll(src)
+				return "bucket", name, err
+			})
+
+			desc, err := content.UploadTarbal(context.Background(), rs, src, storage.DefaultBackup, test.Compression, 0, nil)
+			if test.ExpectedErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if desc.MediaType != test.MediaType {
+				t.Errorf("unexpected media type: %s", desc.MediaType)
+			}
+			if desc.Digest != digest.FromBytes(uploaded) || desc.Size != int64(len(uploaded)) {
+				t.Errorf("descriptor does not describe the upload: %s (%d bytes)", desc.Digest, desc.Size)
+			}
+
+			r, compression, err := carchive.Decompress(bytes.NewReader(uploaded))
+			if err != nil {
+				t.Fatalf("cannot decompress upload: %v", err)
+			}
+			if compression != test.Compression {
+				t.Errorf("unexpected compression: %q", compression)
+			}
+			extracted := make(map[string]string)
+			tr := tar.NewReader(r)
+			for {
+				hdr, err := tr.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("cannot read tar: %v", err)
+				}
+				if hdr.Typeflag != tar.TypeReg {
+					continue
+				}
+				content, err := io.ReadAll(tr)
+				if err != nil {
+					t.Fatalf("cannot read tar: %v", err)
+				}
+				extracted[hdr.Name] = string(content)
+			}
+			if diff := cmp.Diff(files, extracted); diff != "" {
+				t.Errorf("unexpected archive content (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
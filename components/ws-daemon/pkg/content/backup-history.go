@@ -0,0 +1,169 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package content
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+// backupTimeFormat is the time format of backup IDs
+const backupTimeFormat = "20060102T150405Z"
+
+// RecordBackupVersion adds the backup which was just uploaded to storage.DefaultBackup to the backup history of
+// a workspace. The history keeps at most keep backups, the oldest ones drop out of it. The backup is copied
+// within the remote storage, s.t. it can be streamed into the remote storage in the first place.
+func RecordBackupVersion(ctx context.Context, rs storage.DirectAccess, keep int, size int64, tmpDir string) (*csapi.BackupVersion, error) {
+	return recordBackupVersion(ctx, rs, keep, false, size, time.Now(), tmpDir, func(ctx context.Context, obj string) error {
+		return rs.CopyObject(ctx, storage.DefaultBackup, obj)
+	})
+}
+
+// RecordChunkedBackupVersion adds a chunked backup to the backup history of a workspace. Only the manifest is
+// copied, the chunks are shared with all other backups of the workspace.
+func RecordChunkedBackupVersion(ctx context.Context, rs storage.DirectAccess, keep int, mf *csapi.ChunkedBackupManifest, tmpDir string) (*csapi.BackupVersion, error) {
+	rawmf, err := json.Marshal(mf)
+	if err != nil {
+		return nil, err
+	}
+	tmpmf, err := writeTempFile(tmpDir, "chunks-*.json", rawmf)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmpmf)
+
+	created := mf.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+	return recordBackupVersion(ctx, rs, keep, true, mf.Size, created, tmpDir, func(ctx context.Context, obj string) error {
+		_, _, err := rs.Upload(ctx, tmpmf, obj, storage.WithContentType(csapi.ContentTypeChunkedBackup))
+		return err
+	})
+}
+
+// recordBackupVersion adds a backup to the history. store writes the backup to the object of its slot.
+func recordBackupVersion(ctx context.Context, rs storage.DirectAccess, keep int, chunked bool, size int64, created time.Time, tmpDir string, store func(ctx context.Context, obj string) error) (version *csapi.BackupVersion, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "recordBackupVersion")
+	span.SetTag("chunked", chunked)
+	defer tracing.FinishSpan(span, &err)
+
+	if keep <= 0 {
+		return nil, xerrors.Errorf("backup history must keep at least one backup")
+	}
+
+	var buf bytes.Buffer
+	found, err := rs.DownloadObject(ctx, storage.BackupHistoryManifest, &buf)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot download backup history: %w", err)
+	}
+	var history csapi.BackupHistory
+	if found {
+		err = json.Unmarshal(buf.Bytes(), &history)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot unmarshal backup history: %w", err)
+		}
+	}
+
+	retained := history.Backups
+	if len(retained) > keep-1 {
+		retained = retained[:keep-1]
+	}
+	slot := freeBackupSlot(retained, keep)
+	version = &csapi.BackupVersion{
+		// the slot makes the ID unique within the history, even if two backups were made at the same time
+		ID:      fmt.Sprintf("%s-%d", created.UTC().Format(backupTimeFormat), slot),
+		Created: created,
+		Object:  storage.BackupVersionName(slot, chunked),
+		Chunked: chunked,
+		Size:    size,
+	}
+	span.LogKV("id", version.ID, "object", version.Object)
+
+	// The slot may belong to a backup which drops out of the history. That backup leaves the history before
+	// its slot is overwritten, and the new version enters it only once it's stored. This way the history never
+	// references a slot with different or missing content.
+	if len(retained) < len(history.Backups) {
+		history.Backups = retained
+		err = uploadBackupHistory(ctx, rs, &history, tmpDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = store(ctx, version.Object)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot store backup version: %w", err)
+	}
+
+	history.Backups = append([]csapi.BackupVersion{*version}, retained...)
+	err = uploadBackupHistory(ctx, rs, &history, tmpDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+func uploadBackupHistory(ctx context.Context, rs storage.DirectAccess, history *csapi.BackupHistory, tmpDir string) error {
+	rawhist, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	tmphist, err := writeTempFile(tmpDir, "backups-*.json", rawhist)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmphist)
+	_, _, err = rs.Upload(ctx, tmphist, storage.BackupHistoryManifest, storage.WithContentType(csapi.ContentTypeBackupHistory))
+	if err != nil {
+		return xerrors.Errorf("cannot upload backup history: %w", err)
+	}
+	return nil
+}
+
+// freeBackupSlot finds a slot which none of the retained backups occupies. There are keep slots in total,
+// hence there's always one left for the new backup.
+func freeBackupSlot(retained []csapi.BackupVersion, keep int) int {
+	used := make(map[string]struct{}, len(retained))
+	for _, v := range retained {
+		used[v.Object] = struct{}{}
+	}
+	for slot := 0; slot < keep; slot++ {
+		_, full := used[storage.BackupVersionName(slot, false)]
+		_, chunked := used[storage.BackupVersionName(slot, true)]
+		if !full && !chunked {
+			return slot
+		}
+	}
+	// unreachable as there are fewer retained backups than slots
+	return 0
+}
+
+func writeTempFile(dir, pattern string, content []byte) (name string, err error) {
+	f, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(content)
+	f.Close()
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package content_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage/mock"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/content"
+)
+
+func TestRecordBackupVersion(t *testing.T) {
+	type version struct {
+		Object  string
+		Content string
+	}
+	tests := []struct {
+		Name        string
+		Keep        int
+		Backups     []string
+		Expectation []version
+	}{
+		{
+			Name:    "first backup",
+			Keep:    3,
+			Backups: []string{"one"},
+			Expectation: []version{
+				{Object: storage.BackupVersionName(0, false), Content: "one"},
+			},
+		},
+		{
+			Name:    "fills history",
+			Keep:    3,
+			Backups: []string{"one", "two", "three"},
+			Expectation: []version{
+				{Object: storage.BackupVersionName(2, false), Content: "three"},
+				{Object: storage.BackupVersionName(1, false), Content: "two"},
+				{Object: storage.BackupVersionName(0, false), Content: "one"},
+			},
+		},
+		{
+			Name:    "reuses slots of dropped backups",
+			Keep:    2,
+			Backups: []string{"one", "two", "three", "four"},
+			Expectation: []version{
+				{Object: storage.BackupVersionName(1, false), Content: "four"},
+				{Object: storage.BackupVersionName(0, false), Content: "three"},
+			},
+		},
+		{
+			Name:    "keep latest only",
+			Keep:    1,
+			Backups: []string{"one", "two"},
+			Expectation: []version{
+				{Object: storage.BackupVersionName(0, false), Content: "two"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			objects := make(map[string][]byte)
+			ctrl := gomock.NewController(t)
+			rs := mock.NewMockDirectAccess(ctrl)
+			rs.EXPECT().DownloadObject(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, name string, dst io.Writer) (bool, error) {
+				content, ok := objects[name]
+				if !ok {
+					return false, nil
+				}
+				_, err := dst.Write(content)
+				return true, err
+			}).AnyTimes()
+			rs.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, source string, name string, opts ...storage.UploadOption) (string, string, error) {
+				content, err := os.ReadFile(source)
+				if err != nil {
+					return "", "", err
+				}
+				objects[name] = content
+				return "bucket", name, nil
+			}).AnyTimes()
+			rs.EXPECT().CopyObject(gomock.Any(), storage.DefaultBackup, gomock.Any()).DoAndReturn(func(ctx context.Context, src, dst string) error {
+				// the history must never reference a slot while its content is replaced
+				var history csapi.BackupHistory
+				if raw, ok := objects[storage.BackupHistoryManifest]; ok {
+					err := json.Unmarshal(raw, &history)
+					if err != nil {
+						return err
+					}
+				}
+				for _, b := range history.Backups {
+					if b.Object == dst {
+						t.Errorf("%s is overwritten while the backup history references it", dst)
+					}
+				}
+				objects[dst] = objects[src]
+				return nil
+			}).AnyTimes()
+
+			for _, b := range test.Backups {
+				objects[storage.DefaultBackup] = []byte(b)
+				_, err := content.RecordBackupVersion(context.Background(), rs, test.Keep, int64(len(b)), t.TempDir())
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			var history csapi.BackupHistory
+			err := json.Unmarshal(objects[storage.BackupHistoryManifest], &history)
+			if err != nil {
+				t.Fatalf("cannot unmarshal backup history: %v", err)
+			}
+			var act []version
+			ids := make(map[string]struct{})
+			for _, b := range history.Backups {
+				act = append(act, version{Object: b.Object, Content: string(objects[b.Object])})
+				if _, exists := ids[b.ID]; exists {
+					t.Errorf("duplicate backup ID %s", b.ID)
+				}
+				ids[b.ID] = struct{}{}
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected backup history (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
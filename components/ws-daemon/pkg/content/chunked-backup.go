@@ -0,0 +1,228 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/chunker"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+)
+
+// chunkUploadConcurrency is the number of backup chunks we upload in parallel
+const chunkUploadConcurrency = 8
+
+// UploadChunkedBackup splits a backup tarball into content-defined chunks, uploads the chunks which are not
+// present in the remote storage yet and finally the manifest referencing them. Chunks are shared by all backups
+// of a workspace, hence only the parts of the workspace which changed since the last backup are uploaded.
+func UploadChunkedBackup(ctx context.Context, rs storage.DirectAccess, tarball, tmpDir string) (mf *csapi.ChunkedBackupManifest, err error) {
+	//nolint:ineffassign
+	span, ctx := opentracing.StartSpanFromContext(ctx, "UploadChunkedBackup")
+	defer tracing.FinishSpan(span, &err)
+
+	existing, err := rs.ListObjects(ctx, rs.BackupObject(storage.BackupChunkPrefix))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot list backup chunks: %w", err)
+	}
+	present := make(map[string]struct{}, len(existing))
+	for _, obj := range existing {
+		present[obj] = struct{}{}
+	}
+
+	f, err := os.Open(tarball)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	chunks, err := chunker.New(f, chunker.DefaultOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		digester     = digest.Canonical.Digester()
+		reused       = make(map[digest.Digest]struct{})
+		uploaded     int
+		uploadedSize int64
+	)
+	mf = &csapi.ChunkedBackupManifest{Created: time.Now()}
+	eg, egctx := errgroup.WithContext(ctx)
+	eg.SetLimit(chunkUploadConcurrency)
+	for egctx.Err() == nil {
+		chunk, err := chunks.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// wait for the running uploads before we return
+			_ = eg.Wait()
+			return nil, xerrors.Errorf("cannot chunk backup: %w", err)
+		}
+
+		_, _ = digester.Hash().Write(chunk)
+		dgst := digest.FromBytes(chunk)
+		mf.Chunks = append(mf.Chunks, csapi.BackupChunk{Digest: dgst, Size: int64(len(chunk))})
+		mf.Size += int64(len(chunk))
+
+		name := storage.BackupChunkName(dgst)
+		if _, exists := present[rs.BackupObject(name)]; exists {
+			reused[dgst] = struct{}{}
+			continue
+		}
+		present[rs.BackupObject(name)] = struct{}{}
+		uploaded++
+		uploadedSize += int64(len(chunk))
+
+		// the chunker reuses its buffer
+		content := make([]byte, len(chunk))
+		copy(content, chunk)
+		eg.Go(func() error {
+			return uploadBackupChunk(egctx, rs, dgst, content, tmpDir)
+		})
+	}
+	// if an upload failed we have stopped chunking early, and Wait returns that error
+	err = eg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	mf.Digest = digester.Digest()
+
+	restored, err := uploadMissingChunks(ctx, rs, tarball, tmpDir, reused)
+	if err != nil {
+		return nil, err
+	}
+	uploaded += restored
+	span.LogKV("chunks", len(mf.Chunks), "uploadedChunks", uploaded, "uploadedSize", uploadedSize)
+
+	rawmf, err := json.Marshal(mf)
+	if err != nil {
+		return nil, err
+	}
+	tmpmf, err := os.CreateTemp(tmpDir, "chunks-*.json")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmpmf.Name())
+	_, err = tmpmf.Write(rawmf)
+	tmpmf.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// The manifest is uploaded last s.t. it never references chunks which are missing.
+	_, _, err = rs.Upload(ctx, tmpmf.Name(), storage.DefaultChunkedBackupManifest, storage.WithContentType(csapi.ContentTypeChunkedBackup))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot upload chunked backup manifest: %w", err)
+	}
+
+	log.WithFields(map[string]interface{}{
+		"size":           mf.Size,
+		"chunks":         len(mf.Chunks),
+		"uploadedChunks": uploaded,
+		"uploadedSize":   uploadedSize,
+	}).Debug("uploaded chunked backup")
+
+	return mf, nil
+}
+
+// uploadMissingChunks uploads the reused chunks again which were deleted while we uploaded the backup, e.g. by the
+// retention sweeper because no manifest referenced them. Chunking is deterministic, hence we chunk the tarball again
+// to get their content back. Returns the number of uploaded chunks.
+func uploadMissingChunks(ctx context.Context, rs storage.DirectAccess, tarball, tmpDir string, reused map[digest.Digest]struct{}) (int, error) {
+	if len(reused) == 0 {
+		return 0, nil
+	}
+
+	existing, err := rs.ListObjects(ctx, rs.BackupObject(storage.BackupChunkPrefix))
+	if err != nil {
+		return 0, xerrors.Errorf("cannot list backup chunks: %w", err)
+	}
+	present := make(map[string]struct{}, len(existing))
+	for _, obj := range existing {
+		present[obj] = struct{}{}
+	}
+	missing := make(map[digest.Digest]struct{})
+	for dgst := range reused {
+		if _, exists := present[rs.BackupObject(storage.BackupChunkName(dgst))]; !exists {
+			missing[dgst] = struct{}{}
+		}
+	}
+	if len(missing) == 0 {
+		return 0, nil
+	}
+	log.WithField("chunks", len(missing)).Warn("backup chunks were deleted during the upload, uploading them again")
+
+	f, err := os.Open(tarball)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	chunks, err := chunker.New(f, chunker.DefaultOptions)
+	if err != nil {
+		return 0, err
+	}
+	var uploaded int
+	for len(missing) > 0 {
+		chunk, err := chunks.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return uploaded, xerrors.Errorf("cannot chunk backup: %w", err)
+		}
+
+		dgst := digest.FromBytes(chunk)
+		if _, ok := missing[dgst]; !ok {
+			continue
+		}
+		delete(missing, dgst)
+		err = uploadBackupChunk(ctx, rs, dgst, chunk, tmpDir)
+		if err != nil {
+			return uploaded, err
+		}
+		uploaded++
+	}
+	if len(missing) > 0 {
+		return uploaded, xerrors.Errorf("cannot find %d deleted backup chunks in the tarball", len(missing))
+	}
+	return uploaded, nil
+}
+
+func uploadBackupChunk(ctx context.Context, rs storage.DirectAccess, dgst digest.Digest, content []byte, tmpDir string) error {
+	tmpf, err := os.CreateTemp(tmpDir, "chunk-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpf.Name())
+	_, err = tmpf.Write(content)
+	tmpf.Close()
+	if err != nil {
+		return err
+	}
+
+	_, _, err = rs.Upload(ctx, tmpf.Name(), storage.BackupChunkName(dgst), storage.WithAnnotations(map[string]string{
+		storage.ObjectAnnotationDigest: dgst.String(),
+	}))
+	if err != nil {
+		return xerrors.Errorf("cannot upload backup chunk %s: %w", dgst, err)
+	}
+	return nil
+}
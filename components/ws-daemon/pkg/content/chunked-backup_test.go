@@ -0,0 +1,164 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package content_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/opencontainers/go-digest"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+	"github.com/gitpod-io/gitpod/content-service/pkg/storage/mock"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/content"
+)
+
+func TestUploadChunkedBackup(t *testing.T) {
+	initial := make([]byte, 16*1024*1024)
+	rand.New(rand.NewSource(42)).Read(initial)
+
+	tests := []struct {
+		Name     string
+		Previous []byte
+		Current  []byte
+		// MaxUploadedChunks is the maximum number of chunks we expect to be uploaded, -1 for all
+		MaxUploadedChunks int
+		// DeleteChunks deletes all chunks right after the backup listed them, as the retention sweeper might
+		DeleteChunks bool
+	}{
+		{
+			Name:              "initial backup",
+			Current:           initial,
+			MaxUploadedChunks: -1,
+		},
+		{
+			Name:     "unchanged",
+			Previous: initial,
+			Current:  initial,
+		},
+		{
+			Name:     "change in the middle",
+			Previous: initial,
+			Current: func() []byte {
+				res := append([]byte{}, initial[:8*1024*1024]...)
+				res = append(res, []byte("some new content")...)
+				return append(res, initial[8*1024*1024:]...)
+			}(),
+			MaxUploadedChunks: 2,
+		},
+		{
+			Name:              "chunks deleted during the upload",
+			Previous:          initial,
+			Current:           initial,
+			MaxUploadedChunks: -1,
+			DeleteChunks:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				mu           sync.Mutex
+				objects      = make(map[string][]byte)
+				uploads      []string
+				deleteChunks bool
+			)
+			ctrl := gomock.NewController(t)
+			rs := mock.NewMockDirectAccess(ctrl)
+			rs.EXPECT().BackupObject(gomock.Any()).DoAndReturn(func(name string) string {
+				return "workspaces/ws/" + name
+			}).AnyTimes()
+			rs.EXPECT().ListObjects(gomock.Any(), "workspaces/ws/"+storage.BackupChunkPrefix).DoAndReturn(func(ctx context.Context, prefix string) ([]string, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				var res []string
+				for obj := range objects {
+					if strings.HasPrefix(obj, prefix) {
+						res = append(res, obj)
+					}
+				}
+				if deleteChunks {
+					for _, obj := range res {
+						delete(objects, obj)
+					}
+					deleteChunks = false
+				}
+				return res, nil
+			}).AnyTimes()
+			rs.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, source string, name string, opts ...storage.UploadOption) (string, string, error) {
+				content, err := os.ReadFile(source)
+				if err != nil {
+					return "", "", err
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				objects["workspaces/ws/"+name] = content
+				uploads = append(uploads, name)
+				return "bucket", "workspaces/ws/" + name, nil
+			}).AnyTimes()
+
+			upload := func(tarball []byte) *csapi.ChunkedBackupManifest {
+				fn := filepath.Join(t.TempDir(), "backup.tar")
+				err := os.WriteFile(fn, tarball, 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+				mf, err := content.UploadChunkedBackup(context.Background(), rs, fn, t.TempDir())
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return mf
+			}
+			if test.Previous != nil {
+				upload(test.Previous)
+			}
+			uploads = nil
+			deleteChunks = test.DeleteChunks
+
+			mf := upload(test.Current)
+
+			if mf.Digest != digest.FromBytes(test.Current) || mf.Size != int64(len(test.Current)) {
+				t.Errorf("manifest does not describe the tarball: %s (%d bytes)", mf.Digest, mf.Size)
+			}
+			var assembled []byte
+			for _, c := range mf.Chunks {
+				assembled = append(assembled, objects["workspaces/ws/"+storage.BackupChunkName(c.Digest)]...)
+			}
+			if !bytes.Equal(assembled, test.Current) {
+				t.Error("uploaded chunks do not reassemble the tarball")
+			}
+
+			var uploadedMF csapi.ChunkedBackupManifest
+			err := json.Unmarshal(objects["workspaces/ws/"+storage.DefaultChunkedBackupManifest], &uploadedMF)
+			if err != nil {
+				t.Fatalf("cannot unmarshal uploaded manifest: %v", err)
+			}
+			if uploadedMF.Digest != mf.Digest {
+				t.Errorf("uploaded manifest differs: %s", uploadedMF.Digest)
+			}
+			if last := uploads[len(uploads)-1]; last != storage.DefaultChunkedBackupManifest {
+				t.Errorf("expected the manifest to be uploaded last, got %s", last)
+			}
+
+			uploadedChunks := len(uploads) - 1
+			if test.MaxUploadedChunks == -1 {
+				if uploadedChunks != len(mf.Chunks) {
+					t.Errorf("expected all %d chunks to be uploaded, got %d", len(mf.Chunks), uploadedChunks)
+				}
+			} else if uploadedChunks > test.MaxUploadedChunks {
+				t.Errorf("expected at most %d chunks to be uploaded, got %d", test.MaxUploadedChunks, uploadedChunks)
+			}
+		})
+	}
+}
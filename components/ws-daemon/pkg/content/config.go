@@ -10,6 +10,7 @@ import (
 
 	"github.com/gitpod-io/gitpod/common-go/util"
 	cntntcfg "github.com/gitpod-io/gitpod/content-service/api/config"
+	carchive "github.com/gitpod-io/gitpod/content-service/pkg/archive"
 	"github.com/gitpod-io/gitpod/ws-daemon/api"
 	"golang.org/x/xerrors"
 )
@@ -52,6 +53,22 @@ type BackupConfig struct {
 
 	// Period is the time between regular workspace backups
 	Period util.Duration `json:"period"`
+
+	// Chunked enables content-defined chunking for regular backups. Only chunks which are not in
+	// the remote storage yet are uploaded. If a workspace has both a chunked and a tar backup, e.g. because
+	// this was disabled again, the one which was uploaded last is restored.
+	Chunked bool `json:"chunked,omitempty"`
+
+	// History is the number of regular backups we keep per workspace, including the latest one. Previous backups
+	// can be restored by their ID. Defaults to keeping the latest backup only, without a history.
+	History int `json:"history,omitempty"`
+
+	// Compression is the algorithm regular backups and snapshots are compressed with, either "gzip" or "zstd".
+	// Defaults to no compression. Chunked backups are never compressed as a whole.
+	Compression carchive.Compression `json:"compression,omitempty"`
+
+	// CompressionLevel is the algorithm specific compression level. Defaults to the algorithm's default level.
+	CompressionLevel int `json:"compressionLevel,omitempty"`
 }
 
 type UserNamespacesConfig struct {
@@ -88,4 +105,11 @@ type InitializerConfig struct {
 
 	// Args are additional arguments to pass to the CI runtime
 	Args []string `json:"args"`
+
+	// RegistryAuth is the path to a Docker config file providing the credentials OCI initializers pull with
+	RegistryAuth string `json:"registryAuth,omitempty"`
+
+	// RegistryAuthRepositories are the repositories OCI initializers pull from using RegistryAuth,
+	// including the repositories below them. Everything else is pulled anonymously.
+	RegistryAuthRepositories []string `json:"registryAuthRepositories,omitempty"`
 }
@@ -9,7 +9,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -31,6 +33,7 @@ import (
 	"github.com/gitpod-io/gitpod/content-service/pkg/archive"
 	wsinit "github.com/gitpod-io/gitpod/content-service/pkg/initializer"
 	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/gitcache"
 )
 
 // RunInitializerOpts configure RunInitializer
@@ -46,6 +49,15 @@ type RunInitializerOpts struct {
 	GID uint32
 
 	OWI OWI
+
+	// GitCache provides mirrors Git initializers borrow objects from. May be nil.
+	GitCache *gitcache.Cache
+
+	// RegistryAuth is the path to a Docker config file providing the credentials OCI initializers pull with.
+	// If empty, OCI initializers access registries anonymously.
+	RegistryAuth string
+	// RegistryAuthRepositories are the repositories OCI initializers may use the RegistryAuth credentials for.
+	RegistryAuthRepositories []string
 }
 
 type OWI struct {
@@ -67,13 +79,36 @@ var (
 func CollectRemoteContent(ctx context.Context, rs storage.DirectAccess, ps storage.PresignedAccess, workspaceOwner string, initializer *csapi.WorkspaceInitializer) (rc map[string]storage.DownloadInfo, err error) {
 	rc = make(map[string]storage.DownloadInfo)
 
-	backup, err := ps.SignDownload(ctx, rs.Bucket(workspaceOwner), rs.BackupObject(storage.DefaultBackup), &storage.SignedURLOptions{})
-	if err == storage.ErrNotFound {
-		// no backup found - that's fine
-	} else if err != nil {
+	if backupID := initializer.GetBackup().GetBackupId(); backupID != "" {
+		// a specific backup version replaces the latest backup
+		err = collectBackupVersion(ctx, rs, ps, workspaceOwner, backupID, rc)
+		if err != nil {
+			return nil, err
+		}
+		return rc, nil
+	}
+
+	// only the newer of the chunked and the tar backup is restored
+	latest, err := storage.LatestBackup(ctx, ps, rs.Bucket(workspaceOwner), rs.BackupObject(storage.DefaultBackup), rs.BackupObject(storage.DefaultChunkedBackupManifest))
+	if err != nil {
 		return nil, err
-	} else {
-		rc[storage.DefaultBackup] = *backup
+	}
+	var chunked bool
+	if latest == storage.DefaultChunkedBackupManifest {
+		chunked, err = collectChunkedBackup(ctx, rs, ps, workspaceOwner, storage.DefaultChunkedBackupManifest, rc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !chunked {
+		backup, err := ps.SignDownload(ctx, rs.Bucket(workspaceOwner), rs.BackupObject(storage.DefaultBackup), &storage.SignedURLOptions{})
+		if err == storage.ErrNotFound {
+			// no backup found - that's fine
+		} else if err != nil {
+			return nil, err
+		} else {
+			rc[storage.DefaultBackup] = *backup
+		}
 	}
 
 	si := initializer.GetSnapshot()
@@ -121,6 +156,114 @@ func CollectRemoteContent(ctx context.Context, rs storage.DirectAccess, ps stora
 	return rc, nil
 }
 
+// collectChunkedBackup adds the manifest of a chunked backup and all its chunks to the remote content
+func collectChunkedBackup(ctx context.Context, rs storage.DirectAccess, ps storage.PresignedAccess, workspaceOwner string, manifest string, rc map[string]storage.DownloadInfo) (found bool, err error) {
+	var buf bytes.Buffer
+	found, err = rs.DownloadObject(ctx, manifest, &buf)
+	if err != nil {
+		return found, xerrors.Errorf("cannot download chunked backup manifest: %w", err)
+	}
+	if !found {
+		return false, nil
+	}
+
+	var mf csapi.ChunkedBackupManifest
+	err = json.Unmarshal(buf.Bytes(), &mf)
+	if err != nil {
+		return true, xerrors.Errorf("cannot unmarshal chunked backup manifest: %w", err)
+	}
+
+	bucket := rs.Bucket(workspaceOwner)
+	names := []string{manifest}
+	seen := map[string]struct{}{manifest: {}}
+	for _, c := range mf.Chunks {
+		name := storage.BackupChunkName(c.Digest)
+		if _, exists := seen[name]; exists {
+			continue
+		}
+		if _, exists := rc[name]; exists {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+
+	objs := make([]string, len(names))
+	for i, name := range names {
+		objs[i] = rs.BackupObject(name)
+	}
+	infos, err := storage.SignDownloads(ctx, ps, bucket, objs, &storage.SignedURLOptions{})
+	if err != nil {
+		return true, err
+	}
+	for i, name := range names {
+		rc[name] = *infos[i]
+	}
+	return true, nil
+}
+
+// collectBackupVersion signs the download of the backup history and the previous backup it lists under backupID.
+// Workspaces without a backup history have nothing to collect.
+func collectBackupVersion(ctx context.Context, rs storage.DirectAccess, ps storage.PresignedAccess, workspaceOwner string, backupID string, rc map[string]storage.DownloadInfo) error {
+	var buf bytes.Buffer
+	found, err := rs.DownloadObject(ctx, storage.BackupHistoryManifest, &buf)
+	if err != nil {
+		return xerrors.Errorf("cannot download backup history: %w", err)
+	}
+	if !found {
+		return nil
+	}
+
+	var history csapi.BackupHistory
+	err = json.Unmarshal(buf.Bytes(), &history)
+	if err != nil {
+		return xerrors.Errorf("cannot unmarshal backup history: %w", err)
+	}
+	version, ok := history.Find(backupID)
+	if !ok {
+		return xerrors.Errorf("backup %s is not part of the backup history", backupID)
+	}
+
+	bucket := rs.Bucket(workspaceOwner)
+	info, err := ps.SignDownload(ctx, bucket, rs.BackupObject(storage.BackupHistoryManifest), &storage.SignedURLOptions{})
+	if err != nil {
+		return xerrors.Errorf("cannot sign download of %s: %w", storage.BackupHistoryManifest, err)
+	}
+	rc[storage.BackupHistoryManifest] = *info
+
+	if version.Chunked {
+		found, err = collectChunkedBackup(ctx, rs, ps, workspaceOwner, version.Object, rc)
+		if err == nil && !found {
+			err = xerrors.Errorf("backup %s is missing", backupID)
+		}
+		return err
+	}
+
+	info, err = ps.SignDownload(ctx, bucket, rs.BackupObject(version.Object), &storage.SignedURLOptions{})
+	if err != nil {
+		return xerrors.Errorf("cannot sign download of %s: %w", version.Object, err)
+	}
+	rc[version.Object] = *info
+	return nil
+}
+
+// gitRemotes returns the remote URIs of all Git initializers which are part of the initializer
+func gitRemotes(initializer *csapi.WorkspaceInitializer) (res []string) {
+	switch spec := initializer.GetSpec().(type) {
+	case *csapi.WorkspaceInitializer_Git:
+		res = append(res, spec.Git.GetRemoteUri())
+	case *csapi.WorkspaceInitializer_Prebuild:
+		for _, gi := range spec.Prebuild.GetGit() {
+			res = append(res, gi.GetRemoteUri())
+		}
+	case *csapi.WorkspaceInitializer_Composite:
+		for _, c := range spec.Composite.GetInitializer() {
+			res = append(res, gitRemotes(c)...)
+		}
+	}
+	return res
+}
+
 // RunInitializer runs a content initializer in a user, PID and mount namespace to isolate it from ws-daemon
 func RunInitializer(ctx context.Context, destination string, initializer *csapi.WorkspaceInitializer, remoteContent map[string]storage.DownloadInfo, opts RunInitializerOpts) (err error) {
 	//nolint:ineffassign,staticcheck
@@ -157,6 +300,38 @@ func RunInitializer(ctx context.Context, destination string, initializer *csapi.
 		return err
 	}
 
+	// Git initializers clone with a reference to the mirrors of their remotes which we mount read-only
+	var (
+		gitReferences = make(map[string]string)
+		gitMounts     []specs.Mount
+	)
+	for _, remote := range gitRemotes(initializer) {
+		if _, exists := gitReferences[remote]; exists {
+			continue
+		}
+		dir, release := opts.GitCache.Lookup(remote)
+		defer release()
+		if dir == "" {
+			continue
+		}
+
+		mnt := filepath.Join("/git-cache", filepath.Base(dir))
+		gitReferences[remote] = mnt
+		gitMounts = append(gitMounts, specs.Mount{
+			Destination: mnt,
+			Source:      dir,
+			Type:        "bind",
+			Options:     []string{"bind", "rprivate", "ro"},
+		})
+	}
+	span.LogKV("gitReferences", len(gitReferences))
+
+	// OCI initializers never carry credentials themselves. We resolve them for the registries they pull from only.
+	registryAuth, err := resolveRegistryAuth(opts.RegistryAuth, opts.RegistryAuthRepositories, ociRefs(initializer))
+	if err != nil {
+		return err
+	}
+
 	msg := msgInitContent{
 		Destination:   "/dst",
 		Initializer:   init,
@@ -166,6 +341,8 @@ func RunInitializer(ctx context.Context, destination string, initializer *csapi.
 		GID:           int(opts.GID),
 		UID:           int(opts.UID),
 		OWI:           opts.OWI.Fields(),
+		GitReferences: gitReferences,
+		RegistryAuth:  registryAuth,
 	}
 	fc, err := json.MarshalIndent(msg, "", "  ")
 	if err != nil {
@@ -193,6 +370,7 @@ func RunInitializer(ctx context.Context, destination string, initializer *csapi.
 		Type:        "bind",
 		Options:     []string{"bind", "rprivate"},
 	})
+	spec.Mounts = append(spec.Mounts, gitMounts...)
 
 	spec.Hostname = "content-init"
 	spec.Process.Terminal = false
@@ -329,7 +507,11 @@ func RunInitializerChild() (err error) {
 	rs := &remoteContentStorage{RemoteContent: initmsg.RemoteContent}
 
 	dst := initmsg.Destination
-	initializer, err := wsinit.NewFromRequest(ctx, dst, rs, &req, wsinit.NewFromRequestOpts{ForceGitpodUserForGit: false})
+	initializer, err := wsinit.NewFromRequest(ctx, dst, rs, &req, wsinit.NewFromRequestOpts{
+		ForceGitpodUserForGit: false,
+		GitReferences:         initmsg.GitReferences,
+		OCIResolver:           newOCIResolver(initmsg.RegistryAuth),
+	})
 	if err != nil {
 		return err
 	}
@@ -421,7 +603,11 @@ func (rs *remoteContentStorage) Download(ctx context.Context, destination string
 	defer os.Remove(tempFile.Name())
 	defer tempFile.Close()
 
-	err = archive.ExtractTarbal(ctx, tempFile, destination, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
+	src, err := storage.Decrypt(tempFile, info.DataKey)
+	if err != nil {
+		return true, xerrors.Errorf("cannot decrypt %s: %w", name, err)
+	}
+	err = archive.ExtractTarbal(ctx, src, destination, archive.WithUIDMapping(mappings), archive.WithGIDMapping(mappings))
 	if err != nil {
 		return true, xerrors.Errorf("tar %s: %s", destination, err.Error())
 	}
@@ -434,6 +620,37 @@ func (rs *remoteContentStorage) DownloadSnapshot(ctx context.Context, destinatio
 	return rs.Download(ctx, destination, name, mappings)
 }
 
+// DownloadObject writes the content of a single object to dst without extracting it
+func (rs *remoteContentStorage) DownloadObject(ctx context.Context, name string, dst io.Writer) (found bool, err error) {
+	info, exists := rs.RemoteContent[name]
+	if !exists {
+		return false, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, info.URL, nil)
+	if err != nil {
+		return true, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return true, xerrors.Errorf("cannot download %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return true, xerrors.Errorf("cannot download %s: status %d", name, resp.StatusCode)
+	}
+
+	src, err := storage.Decrypt(resp.Body, info.DataKey)
+	if err != nil {
+		return true, xerrors.Errorf("cannot decrypt %s: %w", name, err)
+	}
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		return true, xerrors.Errorf("cannot download %s: %w", name, err)
+	}
+	return true, nil
+}
+
 // ListObjects returns all objects found with the given prefix. Returns an empty list if the bucket does not exuist (yet).
 func (rs *remoteContentStorage) ListObjects(ctx context.Context, prefix string) (objects []string, err error) {
 	return []string{}, nil
@@ -449,6 +666,16 @@ func (rs *remoteContentStorage) Upload(ctx context.Context, source string, name
 	return "", "", xerrors.Errorf("not implemented")
 }
 
+// UploadStream does nothing
+func (rs *remoteContentStorage) UploadStream(ctx context.Context, src io.Reader, name string, opts ...storage.UploadOption) (string, string, error) {
+	return "", "", xerrors.Errorf("not implemented")
+}
+
+// CopyObject does nothing
+func (rs *remoteContentStorage) CopyObject(ctx context.Context, src, dst string) error {
+	return xerrors.Errorf("not implemented")
+}
+
 // UploadInstance takes all files from a local location and uploads it to the remote storage
 func (rs *remoteContentStorage) UploadInstance(ctx context.Context, source string, name string, options ...storage.UploadOption) (bucket, obj string, err error) {
 	return "", "", xerrors.Errorf("not implemented")
@@ -483,4 +710,10 @@ type msgInitContent struct {
 
 	TraceInfo string
 	OWI       map[string]interface{}
+
+	// GitReferences maps remote URIs to the mirrors Git initializers clone with a reference to
+	GitReferences map[string]string
+
+	// RegistryAuth maps registry hosts to the credentials OCI initializers pull with
+	RegistryAuth map[string]registryCredentials
 }
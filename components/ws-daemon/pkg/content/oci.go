@@ -0,0 +1,168 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package content
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	refdocker "github.com/containerd/containerd/reference/docker"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/xerrors"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	wsinit "github.com/gitpod-io/gitpod/content-service/pkg/initializer"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/registryauth"
+)
+
+// registryCredentials authenticate the content initializer with a registry
+type registryCredentials struct {
+	Username string
+	Password string
+}
+
+// ociRefs returns the references of all OCI initializers which are part of the initializer
+func ociRefs(initializer *csapi.WorkspaceInitializer) (res []string) {
+	switch spec := initializer.GetSpec().(type) {
+	case *csapi.WorkspaceInitializer_Oci:
+		res = append(res, spec.Oci.GetRef())
+	case *csapi.WorkspaceInitializer_Composite:
+		for _, c := range spec.Composite.GetInitializer() {
+			res = append(res, ociRefs(c)...)
+		}
+	}
+	return res
+}
+
+// registryHost returns the host containerd talks to for a reference
+func registryHost(ref string) (string, error) {
+	named, err := refdocker.ParseDockerRef(ref)
+	if err != nil {
+		return "", err
+	}
+	host := refdocker.Domain(named)
+	if host == "docker.io" {
+		host = registryauth.DockerHubHost
+	}
+	return host, nil
+}
+
+// isAllowedRepository returns true if the repository of ref is one of repos or below one of them
+func isAllowedRepository(ref string, repos []string) (bool, error) {
+	named, err := refdocker.ParseDockerRef(ref)
+	if err != nil {
+		return false, err
+	}
+	name := named.Name()
+	for _, repo := range repos {
+		repo = strings.TrimSuffix(repo, "/")
+		if repo == "" {
+			continue
+		}
+		if name == repo || strings.HasPrefix(name, repo+"/") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// resolveRegistryAuth resolves the credentials for the registries of refs from the Docker config file fn,
+// the same way registry-facade authenticates with registries. Registries without credentials are accessed anonymously.
+//
+// The credentials belong to the installation, not the user who asks for the refs. Hence we hand them out only if all refs
+// of a registry are part of repos. Credentials apply to the whole registry, so a single other ref means we pull anonymously.
+func resolveRegistryAuth(fn string, repos []string, refs []string) (map[string]registryCredentials, error) {
+	if fn == "" || len(repos) == 0 || len(refs) == 0 {
+		return nil, nil
+	}
+
+	allowed := make(map[string]bool)
+	for _, ref := range refs {
+		host, err := registryHost(ref)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid reference %s: %w", ref, err)
+		}
+		ok, err := isAllowedRepository(ref, repos)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid reference %s: %w", ref, err)
+		}
+		if prev, exists := allowed[host]; exists {
+			ok = ok && prev
+		}
+		allowed[host] = ok
+	}
+
+	cfg, err := registryauth.Load(fn)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot read registry auth: %w", err)
+	}
+
+	res := make(map[string]registryCredentials)
+	for host, ok := range allowed {
+		if !ok {
+			continue
+		}
+
+		username, password, err := cfg.Credentials(host)
+		if err != nil {
+			return nil, err
+		}
+		if username == "" && password == "" {
+			continue
+		}
+		res[host] = registryCredentials{Username: username, Password: password}
+	}
+	return res, nil
+}
+
+// newOCIResolver produces the resolver OCI initializers fetch their content with
+func newOCIResolver(auth map[string]registryCredentials) wsinit.OCIResolver {
+	return &ociResolver{
+		Resolver: docker.NewResolver(docker.ResolverOptions{
+			Hosts: docker.ConfigureDefaultRegistries(
+				docker.WithAuthorizer(docker.NewDockerAuthorizer(docker.WithAuthCreds(func(host string) (string, string, error) {
+					creds := auth[host]
+					return creds.Username, creds.Password, nil
+				}))),
+			),
+		}),
+	}
+}
+
+// ociResolver adapts a containerd resolver to wsinit.OCIResolver
+type ociResolver struct {
+	Resolver remotes.Resolver
+}
+
+// Resolve resolves a reference to the descriptor of its manifest or index
+func (r *ociResolver) Resolve(ctx context.Context, ref string) (ociv1.Descriptor, error) {
+	named, err := refdocker.ParseDockerRef(ref)
+	if err != nil {
+		return ociv1.Descriptor{}, xerrors.Errorf("invalid reference %s: %w", ref, err)
+	}
+	_, desc, err := r.Resolver.Resolve(ctx, named.String())
+	return desc, err
+}
+
+// Fetch fetches the content desc describes from the repository of ref
+func (r *ociResolver) Fetch(ctx context.Context, ref string, desc ociv1.Descriptor) (io.ReadCloser, error) {
+	named, err := refdocker.ParseDockerRef(ref)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid reference %s: %w", ref, err)
+	}
+	fetcher, err := r.Resolver.Fetcher(ctx, named.String())
+	if err != nil {
+		return nil, err
+	}
+	return fetcher.Fetch(ctx, desc)
+}
+
+// String keeps the credentials out of logs and traces
+func (r *ociResolver) String() string {
+	return "ociResolver"
+}
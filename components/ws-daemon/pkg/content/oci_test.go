@@ -0,0 +1,115 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+)
+
+func TestResolveRegistryAuth(t *testing.T) {
+	const dockerCfg = `{"auths": {
+		"registry.example.com": {"auth": "dXNlcjpwYXNzd29yZA=="},
+		"https://index.docker.io/v1/": {"auth": "aHViOnNlY3JldA=="}
+	}}`
+	repos := []string{"registry.example.com/seeds", "docker.io/library/seed", "other.example.com"}
+
+	oci := func(ref string) *csapi.WorkspaceInitializer {
+		return &csapi.WorkspaceInitializer{Spec: &csapi.WorkspaceInitializer_Oci{Oci: &csapi.OCIInitializer{Ref: ref}}}
+	}
+
+	tests := []struct {
+		Name          string
+		Initializer   *csapi.WorkspaceInitializer
+		Expectation   map[string]registryCredentials
+		ExpectedError bool
+	}{
+		{
+			Name:        "private registry",
+			Initializer: oci("registry.example.com/seeds/a:v1"),
+			Expectation: map[string]registryCredentials{"registry.example.com": {Username: "user", Password: "password"}},
+		},
+		{
+			Name:        "repository which isn't allowed",
+			Initializer: oci("registry.example.com/users/a:v1"),
+			Expectation: map[string]registryCredentials{},
+		},
+		{
+			Name:        "repository sharing a prefix with an allowed one",
+			Initializer: oci("registry.example.com/seeds-private:v1"),
+			Expectation: map[string]registryCredentials{},
+		},
+		{
+			Name:        "docker hub",
+			Initializer: oci("seed"),
+			Expectation: map[string]registryCredentials{"registry-1.docker.io": {Username: "hub", Password: "secret"}},
+		},
+		{
+			Name:        "registry without credentials",
+			Initializer: oci("other.example.com/seed:v1"),
+			Expectation: map[string]registryCredentials{},
+		},
+		{
+			Name: "composite",
+			Initializer: &csapi.WorkspaceInitializer{Spec: &csapi.WorkspaceInitializer_Composite{Composite: &csapi.CompositeInitializer{
+				Initializer: []*csapi.WorkspaceInitializer{
+					oci("registry.example.com/seeds/a:v1"),
+					oci("registry.example.com/seeds/b:v1"),
+					{Spec: &csapi.WorkspaceInitializer_Empty{Empty: &csapi.EmptyInitializer{}}},
+				},
+			}}},
+			Expectation: map[string]registryCredentials{"registry.example.com": {Username: "user", Password: "password"}},
+		},
+		{
+			Name: "composite with a repository which isn't allowed",
+			Initializer: &csapi.WorkspaceInitializer{Spec: &csapi.WorkspaceInitializer_Composite{Composite: &csapi.CompositeInitializer{
+				Initializer: []*csapi.WorkspaceInitializer{
+					oci("registry.example.com/seeds/a:v1"),
+					oci("registry.example.com/users/b:v1"),
+					oci("seed"),
+				},
+			}}},
+			Expectation: map[string]registryCredentials{"registry-1.docker.io": {Username: "hub", Password: "secret"}},
+		},
+		{
+			Name:        "no OCI initializer",
+			Initializer: &csapi.WorkspaceInitializer{Spec: &csapi.WorkspaceInitializer_Empty{Empty: &csapi.EmptyInitializer{}}},
+		},
+		{
+			Name:          "invalid reference",
+			Initializer:   oci("UPPERCASE"),
+			ExpectedError: true,
+		},
+	}
+
+	fn := filepath.Join(t.TempDir(), "config.json")
+	err := os.WriteFile(fn, []byte(dockerCfg), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			act, err := resolveRegistryAuth(fn, repos, ociRefs(test.Initializer))
+			if test.ExpectedError {
+				if err == nil {
+					t.Fatal("expected an error, got nothing")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected registry auth (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 	"github.com/gitpod-io/gitpod/ws-daemon/api"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/container"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/gitcache"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/internal/session"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/iws"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/quota"
@@ -55,6 +56,7 @@ type WorkspaceService struct {
 	ctx         context.Context
 	stopService context.CancelFunc
 	runtime     container.Runtime
+	gitCache    *gitcache.Cache
 
 	metrics *Metrics
 
@@ -69,7 +71,7 @@ type WorkspaceService struct {
 type WorkspaceExistenceCheck func(instanceID string) bool
 
 // NewWorkspaceService creates a new workspce initialization service, starts housekeeping and the Prometheus integration
-func NewWorkspaceService(ctx context.Context, cfg Config, runtime container.Runtime, wec WorkspaceExistenceCheck, uidmapper *iws.Uidmapper, cgroupMountPoint string, reg prometheus.Registerer, workspaceCIDR string) (res *WorkspaceService, err error) {
+func NewWorkspaceService(ctx context.Context, cfg Config, runtime container.Runtime, wec WorkspaceExistenceCheck, uidmapper *iws.Uidmapper, cgroupMountPoint string, reg prometheus.Registerer, workspaceCIDR string, gitCache *gitcache.Cache) (res *WorkspaceService, err error) {
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "NewWorkspaceService")
 	defer tracing.FinishSpan(span, &err)
@@ -120,6 +122,7 @@ func NewWorkspaceService(ctx context.Context, cfg Config, runtime container.Runt
 		ctx:         ctx,
 		stopService: stopService,
 		runtime:     runtime,
+		gitCache:    gitCache,
 
 		metrics: &Metrics{
 			BackupWaitingTimeHist:       waitingTimeHist,
@@ -291,6 +294,9 @@ func (s *WorkspaceService) InitWorkspace(ctx context.Context, req *api.InitWorks
 				WorkspaceID: req.Metadata.MetaId,
 				InstanceID:  req.Id,
 			},
+			GitCache:                 s.gitCache,
+			RegistryAuth:             s.config.Initializer.RegistryAuth,
+			RegistryAuthRepositories: s.config.Initializer.RegistryAuthRepositories,
 		}
 
 		err = RunInitializer(ctx, workspace.Location, req.Initializer, remoteContent, opts)
@@ -548,54 +554,75 @@ func (s *WorkspaceService) uploadWorkspaceContent(ctx context.Context, sess *ses
 		return xerrors.Errorf("no remote storage configured")
 	}
 
+	var tarOpts []archive.TarOption
+	if !sess.FullWorkspaceBackup {
+		mappings := []archive.IDMapping{
+			{ContainerID: 0, HostID: wsinit.GitpodUID, Size: 1},
+			{ContainerID: 1, HostID: 100000, Size: 65534},
+		}
+		tarOpts = append(tarOpts,
+			archive.WithUIDMapping(mappings),
+			archive.WithGIDMapping(mappings),
+		)
+	}
+
 	var (
-		tmpf       *os.File
-		tmpfSize   int64
-		tmpfDigest digest.Digest
+		regular = !sess.FullWorkspaceBackup && backupName == storage.DefaultBackup
+		chunked = s.config.Backup.Chunked && regular
+		history = s.config.Backup.History > 0 && regular
+		// Full workspace backups need the layer digest as object annotation, which must be known before the upload.
+		// Chunks are cut from a local archive. Everything else is streamed straight into the remote storage.
+		streamed    = !chunked && !sess.FullWorkspaceBackup
+		compression = s.config.Backup.Compression
 	)
-	err = retryIfErr(ctx, s.config.Backup.Attempts, log.WithFields(sess.OWI()).WithField("op", "create archive"), func(ctx context.Context) (err error) {
-		tmpf, err = os.CreateTemp(s.config.TmpDir, fmt.Sprintf("wsbkp-%s-*.tar", sess.InstanceID))
-		if err != nil {
-			return
-		}
-		defer tmpf.Close()
+	if chunked {
+		// compressing the archive as a whole would change all chunks whenever a single file changes
+		compression = archive.CompressionNone
+	}
 
-		var opts []archive.TarOption
-		opts = append(opts)
-		if !sess.FullWorkspaceBackup {
-			mappings := []archive.IDMapping{
-				{ContainerID: 0, HostID: wsinit.GitpodUID, Size: 1},
-				{ContainerID: 1, HostID: 100000, Size: 65534},
+	if streamed {
+		var desc *ociv1.Descriptor
+		err = retryIfErr(ctx, s.config.Backup.Attempts, log.WithFields(sess.OWI()).WithField("op", "upload layer"), func(ctx context.Context) (err error) {
+			desc, err = UploadTarbal(ctx, rs, loc, backupName, compression, s.config.Backup.CompressionLevel, tarOpts, opts...)
+			if err != nil {
+				return
 			}
-			opts = append(opts,
-				archive.WithUIDMapping(mappings),
-				archive.WithGIDMapping(mappings),
-			)
-		}
-
-		err = BuildTarbal(ctx, loc, tmpf.Name(), sess.FullWorkspaceBackup, opts...)
-		if err != nil {
+			log.WithField("size", desc.Size).WithField("mediaType", desc.MediaType).WithFields(sess.OWI()).Debug("uploaded workspace backup")
 			return
-		}
-		err = tmpf.Sync()
+		})
 		if err != nil {
-			return
+			return xerrors.Errorf("cannot upload workspace content: %w", err)
+		}
+		if history {
+			s.recordBackupVersion(ctx, sess, func(ctx context.Context) error {
+				_, err := RecordBackupVersion(ctx, rs, s.config.Backup.History, desc.Size, s.config.TmpDir)
+				return err
+			})
 		}
-		_, err = tmpf.Seek(0, 0)
+		return nil
+	}
+
+	var (
+		tmpf   *os.File
+		desc   *ociv1.Descriptor
+		diffID digest.Digest
+	)
+	err = retryIfErr(ctx, s.config.Backup.Attempts, log.WithFields(sess.OWI()).WithField("op", "create archive"), func(ctx context.Context) (err error) {
+		tmpf, err = os.CreateTemp(s.config.TmpDir, fmt.Sprintf("wsbkp-%s-*.tar", sess.InstanceID))
 		if err != nil {
 			return
 		}
-		tmpfDigest, err = digest.FromReader(tmpf)
+		defer tmpf.Close()
+
+		desc, diffID, err = StreamTarbal(ctx, loc, tmpf, compression, s.config.Backup.CompressionLevel, tarOpts...)
 		if err != nil {
 			return
 		}
-
-		stat, err := tmpf.Stat()
+		err = tmpf.Sync()
 		if err != nil {
 			return
 		}
-		tmpfSize = stat.Size()
-		log.WithField("size", tmpfSize).WithField("location", tmpf.Name()).WithFields(sess.OWI()).Debug("created temp file for workspace backup upload")
+		log.WithField("size", desc.Size).WithField("location", tmpf.Name()).WithFields(sess.OWI()).Debug("created temp file for workspace backup upload")
 
 		return
 	})
@@ -609,21 +636,37 @@ func (s *WorkspaceService) uploadWorkspaceContent(ctx context.Context, sess *ses
 		}
 	}()
 
+	if chunked {
+		var cmf *csapi.ChunkedBackupManifest
+		err = retryIfErr(ctx, s.config.Backup.Attempts, log.WithFields(sess.OWI()).WithField("op", "upload chunks"), func(ctx context.Context) (err error) {
+			cmf, err = UploadChunkedBackup(ctx, rs, tmpf.Name(), s.config.TmpDir)
+			return
+		})
+		if err != nil {
+			return xerrors.Errorf("cannot upload workspace content: %w", err)
+		}
+		if history {
+			s.recordBackupVersion(ctx, sess, func(ctx context.Context) error {
+				_, err := RecordChunkedBackupVersion(ctx, rs, s.config.Backup.History, cmf, s.config.TmpDir)
+				return err
+			})
+		}
+		return nil
+	}
+
 	var (
 		layerBucket string
 		layerObject string
 	)
 	err = retryIfErr(ctx, s.config.Backup.Attempts, log.WithFields(sess.OWI()).WithField("op", "upload layer"), func(ctx context.Context) (err error) {
-		layerUploadOpts := opts
-		if sess.FullWorkspaceBackup {
-			// we deliberately ignore the other opload options here as FWB workspace trailing doesn't make sense
-			layerUploadOpts = []storage.UploadOption{
-				storage.WithAnnotations(map[string]string{
-					storage.ObjectAnnotationDigest:             tmpfDigest.String(),
-					storage.ObjectAnnotationUncompressedDigest: tmpfDigest.String(),
-					storage.ObjectAnnotationOCIContentType:     csapi.MediaTypeUncompressedLayer,
-				}),
-			}
+		// we deliberately ignore the other opload options here as FWB workspace trailing doesn't make sense
+		layerUploadOpts := []storage.UploadOption{
+			storage.WithContentType(compression.ContentType()),
+			storage.WithAnnotations(map[string]string{
+				storage.ObjectAnnotationDigest:             desc.Digest.String(),
+				storage.ObjectAnnotationUncompressedDigest: diffID.String(),
+				storage.ObjectAnnotationOCIContentType:     desc.MediaType,
+			}),
 		}
 
 		layerBucket, layerObject, err = rs.Upload(ctx, tmpf.Name(), backupName, layerUploadOpts...)
@@ -647,13 +690,9 @@ func (s *WorkspaceService) uploadWorkspaceContent(ctx context.Context, sess *ses
 		ls = append(ls, csapi.WorkspaceContentLayer{
 			Bucket:     layerBucket,
 			Object:     layerObject,
-			DiffID:     tmpfDigest,
+			DiffID:     diffID,
 			InstanceID: sess.InstanceID,
-			Descriptor: ociv1.Descriptor{
-				MediaType: csapi.MediaTypeUncompressedLayer,
-				Digest:    tmpfDigest,
-				Size:      tmpfSize,
-			},
+			Descriptor: *desc,
 		})
 
 		mf, err := json.Marshal(csapi.WorkspaceContentManifest{
@@ -692,6 +731,15 @@ func (s *WorkspaceService) uploadWorkspaceContent(ctx context.Context, sess *ses
 	return nil
 }
 
+// recordBackupVersion adds the backup which was just uploaded to the backup history. The latest backup is in place
+// already, hence failing to record it in the history must not fail the backup.
+func (s *WorkspaceService) recordBackupVersion(ctx context.Context, sess *session.Workspace, record func(ctx context.Context) error) {
+	err := retryIfErr(ctx, s.config.Backup.Attempts, log.WithFields(sess.OWI()).WithField("op", "record backup version"), record)
+	if err != nil {
+		log.WithError(err).WithFields(sess.OWI()).Warn("cannot add backup to the backup history")
+	}
+}
+
 func (s *WorkspaceService) uploadWorkspaceLogs(ctx context.Context, sess *session.Workspace) (err error) {
 	rs, ok := sess.NonPersistentAttrs[session.AttrRemoteStorage].(storage.DirectAccess)
 	if rs == nil || !ok {
@@ -21,6 +21,7 @@ import (
 	"github.com/gitpod-io/gitpod/content-service/pkg/logs"
 	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/content"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/gitcache"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/internal/session"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
@@ -49,6 +50,7 @@ type DefaultWorkspaceOperations struct {
 	provider               *WorkspaceProvider
 	backupWorkspaceLimiter chan struct{}
 	metrics                *content.Metrics
+	gitCache               *gitcache.Cache
 }
 
 var _ WorkspaceOperations = (*DefaultWorkspaceOperations)(nil)
@@ -74,7 +76,7 @@ type BackupOptions struct {
 	SnapshotName      string
 }
 
-func NewWorkspaceOperations(config content.Config, provider *WorkspaceProvider, reg prometheus.Registerer) (WorkspaceOperations, error) {
+func NewWorkspaceOperations(config content.Config, provider *WorkspaceProvider, reg prometheus.Registerer, gitCache *gitcache.Cache) (WorkspaceOperations, error) {
 	waitingTimeHist, waitingTimeoutCounter, err := content.RegisterConcurrentBackupMetrics(reg, "_mk2")
 	if err != nil {
 		return nil, err
@@ -89,6 +91,7 @@ func NewWorkspaceOperations(config content.Config, provider *WorkspaceProvider,
 		},
 		// we permit five concurrent backups at any given time, hence the five in the channel
 		backupWorkspaceLimiter: make(chan struct{}, 5),
+		gitCache:               gitCache,
 	}, nil
 }
 
@@ -135,6 +138,9 @@ func (wso *DefaultWorkspaceOperations) InitWorkspace(ctx context.Context, option
 			WorkspaceID: options.Meta.WorkspaceID,
 			InstanceID:  options.Meta.InstanceID,
 		},
+		GitCache:                 wso.gitCache,
+		RegistryAuth:             wso.config.Initializer.RegistryAuth,
+		RegistryAuthRepositories: wso.config.Initializer.RegistryAuthRepositories,
 	}
 
 	err = ensureCleanSlate(ws.Location)
@@ -404,11 +410,48 @@ func (wso *DefaultWorkspaceOperations) uploadWorkspaceContent(ctx context.Contex
 		return xerrors.Errorf("no remote storage configured")
 	}
 
+	var tarOpts []archive.TarOption
+	if !sess.FullWorkspaceBackup {
+		mappings := []archive.IDMapping{
+			{ContainerID: 0, HostID: wsinit.GitpodUID, Size: 1},
+			{ContainerID: 1, HostID: 100000, Size: 65534},
+		}
+		tarOpts = append(tarOpts,
+			archive.WithUIDMapping(mappings),
+			archive.WithGIDMapping(mappings),
+		)
+	}
+
 	var (
-		tmpf     *os.File
-		tmpfSize int64
+		regular = !sess.FullWorkspaceBackup && backupName == storage.DefaultBackup
+		chunked = wso.config.Backup.Chunked && regular
+		history = wso.config.Backup.History > 0 && regular
 	)
+	if !chunked {
+		// stream the archive straight into the remote storage
+		var size int64
+		err = retryIfErr(ctx, wso.config.Backup.Attempts, glog.WithFields(sess.OWI()).WithField("op", "upload layer"), func(ctx context.Context) (err error) {
+			desc, err := content.UploadTarbal(ctx, rs, loc, backupName, wso.config.Backup.Compression, wso.config.Backup.CompressionLevel, tarOpts, opts...)
+			if err != nil {
+				return
+			}
+			size = desc.Size
+			glog.WithField("size", desc.Size).WithField("mediaType", desc.MediaType).WithFields(sess.OWI()).Debug("uploaded workspace backup")
+			return
+		})
+		if err != nil {
+			return xerrors.Errorf("cannot upload workspace content: %w", err)
+		}
+		if history {
+			wso.recordBackupVersion(ctx, sess, func(ctx context.Context) error {
+				_, err := content.RecordBackupVersion(ctx, rs, wso.config.Backup.History, size, wso.config.TmpDir)
+				return err
+			})
+		}
+		return nil
+	}
 
+	var tmpf *os.File
 	defer func() {
 		if tmpf != nil {
 			os.Remove(tmpf.Name())
@@ -428,20 +471,8 @@ func (wso *DefaultWorkspaceOperations) uploadWorkspaceContent(ctx context.Contex
 			}
 		}()
 
-		var opts []archive.TarOption
-		opts = append(opts)
-		if !sess.FullWorkspaceBackup {
-			mappings := []archive.IDMapping{
-				{ContainerID: 0, HostID: wsinit.GitpodUID, Size: 1},
-				{ContainerID: 1, HostID: 100000, Size: 65534},
-			}
-			opts = append(opts,
-				archive.WithUIDMapping(mappings),
-				archive.WithGIDMapping(mappings),
-			)
-		}
-
-		err = content.BuildTarbal(ctx, loc, tmpf.Name(), sess.FullWorkspaceBackup, opts...)
+		// chunks are cut from the uncompressed archive, s.t. unchanged files result in unchanged chunks
+		err = content.BuildTarbal(ctx, loc, tmpf.Name(), sess.FullWorkspaceBackup, tarOpts...)
 		if err != nil {
 			return
 		}
@@ -449,17 +480,12 @@ func (wso *DefaultWorkspaceOperations) uploadWorkspaceContent(ctx context.Contex
 		if err != nil {
 			return
 		}
-		_, err = tmpf.Seek(0, 0)
-		if err != nil {
-			return
-		}
 
 		stat, err := tmpf.Stat()
 		if err != nil {
 			return
 		}
-		tmpfSize = stat.Size()
-		glog.WithField("size", tmpfSize).WithField("location", tmpf.Name()).WithFields(sess.OWI()).Debug("created temp file for workspace backup upload")
+		glog.WithField("size", stat.Size()).WithField("location", tmpf.Name()).WithFields(sess.OWI()).Debug("created temp file for workspace backup upload")
 
 		return
 	})
@@ -467,21 +493,32 @@ func (wso *DefaultWorkspaceOperations) uploadWorkspaceContent(ctx context.Contex
 		return xerrors.Errorf("cannot create archive: %w", err)
 	}
 
-	err = retryIfErr(ctx, wso.config.Backup.Attempts, glog.WithFields(sess.OWI()).WithField("op", "upload layer"), func(ctx context.Context) (err error) {
-		_, _, err = rs.Upload(ctx, tmpf.Name(), backupName, opts...)
-		if err != nil {
-			return
-		}
-
+	var mf *csapi.ChunkedBackupManifest
+	err = retryIfErr(ctx, wso.config.Backup.Attempts, glog.WithFields(sess.OWI()).WithField("op", "upload chunks"), func(ctx context.Context) (err error) {
+		mf, err = content.UploadChunkedBackup(ctx, rs, tmpf.Name(), wso.config.TmpDir)
 		return
 	})
 	if err != nil {
 		return xerrors.Errorf("cannot upload workspace content: %w", err)
 	}
-
+	if history {
+		wso.recordBackupVersion(ctx, sess, func(ctx context.Context) error {
+			_, err := content.RecordChunkedBackupVersion(ctx, rs, wso.config.Backup.History, mf, wso.config.TmpDir)
+			return err
+		})
+	}
 	return nil
 }
 
+// recordBackupVersion adds the backup which was just uploaded to the backup history. The latest backup is in place
+// already, hence failing to record it in the history must not fail the backup.
+func (wso *DefaultWorkspaceOperations) recordBackupVersion(ctx context.Context, sess *session.Workspace, record func(ctx context.Context) error) {
+	err := retryIfErr(ctx, wso.config.Backup.Attempts, glog.WithFields(sess.OWI()).WithField("op", "record backup version"), record)
+	if err != nil {
+		glog.WithError(err).WithFields(sess.OWI()).Warn("cannot add backup to the backup history")
+	}
+}
+
 func retryIfErr(ctx context.Context, attempts int, log *logrus.Entry, op func(ctx context.Context) error) (err error) {
 	//nolint:ineffassign
 	span, ctx := opentracing.StartSpanFromContext(ctx, "retryIfErr")
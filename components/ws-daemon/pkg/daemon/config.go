@@ -12,8 +12,10 @@ import (
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/content"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/cpulimit"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/diskguard"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/gitcache"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/iws"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/netlimit"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/prepull"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -29,6 +31,8 @@ type Config struct {
 	NetLimit            netlimit.Config           `json:"netlimit"`
 	OOMScores           cgroup.OOMScoreAdjConfig  `json:"oomScores"`
 	DiskSpaceGuard      diskguard.Config          `json:"disk"`
+	GitCache            gitcache.Config           `json:"gitCache"`
+	PrePull             prepull.Config            `json:"prePull"`
 	WorkspaceController WorkspaceControllerConfig `json:"workspaceController"`
 }
 
@@ -7,6 +7,7 @@ package daemon
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"time"
 
@@ -35,8 +36,10 @@ import (
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/cpulimit"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/diskguard"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/dispatch"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/gitcache"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/iws"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/netlimit"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/prepull"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/quota"
 )
 
@@ -167,6 +170,33 @@ func NewDaemon(config Config) (*Daemon, error) {
 		return nil
 	}))
 
+	var gitCache *gitcache.Cache
+	if config.GitCache.Enabled {
+		gitCache, err = gitcache.New(config.GitCache, wrappedReg)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create Git cache: %w", err)
+		}
+	}
+
+	var warmer *prepull.Warmer
+	if config.PrePull.Enabled {
+		if config.Runtime.Container.Containerd == nil {
+			return nil, xerrors.Errorf("pre-pulling images requires containerd")
+		}
+		store, err := prepull.NewContainerdImageStore(config.Runtime.Container.Containerd.SocketPath)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create pre-pull image store: %w", err)
+		}
+		popularity, err := prepull.NewRegistryFacadePopularity(config.PrePull.Popularity)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create pre-pull popularity client: %w", err)
+		}
+		warmer, err = prepull.NewWarmer(config.PrePull, store, prepull.NewResolverProvider(config.PrePull.RegistryAuth), popularity, wrappedReg)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot create pre-pull warmer: %w", err)
+		}
+	}
+
 	var mgr manager.Manager
 	if config.WorkspaceController.Enabled {
 		mgr, err = ctrl.NewManager(restCfg, ctrl.Options{
@@ -201,7 +231,7 @@ func NewDaemon(config Config) (*Daemon, error) {
 			config.CPULimit.CGroupBasePath,
 		)
 
-		workspaceOps, err := controller.NewWorkspaceOperations(contentCfg, controller.NewWorkspaceProvider(hooks, contentCfg.WorkingArea), wrappedReg)
+		workspaceOps, err := controller.NewWorkspaceOperations(contentCfg, controller.NewWorkspaceProvider(hooks, contentCfg.WorkingArea), wrappedReg, gitCache)
 		if err != nil {
 			return nil, err
 		}
@@ -241,18 +271,25 @@ func NewDaemon(config Config) (*Daemon, error) {
 		config.CPULimit.CGroupBasePath,
 		wrappedReg,
 		config.Runtime.WorkspaceCIDR,
+		gitCache,
 	)
 	if err != nil {
 		return nil, xerrors.Errorf("cannot create content service: %w", err)
 	}
 
-	dsk := diskguard.FromConfig(config.DiskSpaceGuard, clientset, nodename)
+	var reclaimers []diskguard.Reclaimer
+	if gitCache != nil {
+		reclaimers = append(reclaimers, gitCache)
+	}
+	dsk := diskguard.FromConfig(config.DiskSpaceGuard, clientset, nodename, reclaimers...)
 
 	return &Daemon{
 		Config:          config,
 		dispatch:        dsptch,
 		content:         contentService,
 		diskGuards:      dsk,
+		gitCache:        gitCache,
+		warmer:          warmer,
 		configReloader:  configReloader,
 		mgr:             mgr,
 		metricsRegistry: registry,
@@ -274,6 +311,8 @@ type Daemon struct {
 	dispatch        *dispatch.Dispatch
 	content         *content.WorkspaceService
 	diskGuards      []*diskguard.Guard
+	gitCache        *gitcache.Cache
+	warmer          *prepull.Warmer
 	configReloader  ConfigReloader
 	mgr             ctrl.Manager
 	metricsRegistry *prometheus.Registry
@@ -300,6 +339,12 @@ func (d *Daemon) Start() error {
 	var ctx context.Context
 	ctx, d.cancel = context.WithCancel(context.Background())
 
+	go d.gitCache.Start(ctx)
+	if d.warmer != nil {
+		go d.warmer.Run(ctx)
+		log.WithField("maxImages", d.warmer.MaxImages).WithField("maxBytes", d.warmer.MaxBytes).Info("warming node with popular images")
+	}
+
 	if d.Config.WorkspaceController.Enabled {
 		go func() {
 			err := d.mgr.Start(ctx)
@@ -356,6 +401,14 @@ func (d *Daemon) ReadinessProbe() func() error {
 	}
 }
 
+// PrePullStatus serves the warm status of the node. It returns nil if pre-pulling images is disabled.
+func (d *Daemon) PrePullStatus() http.Handler {
+	if d.warmer == nil {
+		return nil
+	}
+	return d.warmer
+}
+
 func (d *Daemon) MetricsRegistry() *prometheus.Registry {
 	return d.metricsRegistry
 }
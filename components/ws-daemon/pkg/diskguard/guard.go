@@ -36,8 +36,15 @@ type LocationConfig struct {
 	MinBytesAvail uint64 `json:"minBytesAvail"`
 }
 
+// Reclaimer can free disk space on demand, e.g. by evicting cached content
+type Reclaimer interface {
+	// Reclaim attempts to free at least bytes on the disk path resides on.
+	// Returns the number of bytes actually freed.
+	Reclaim(ctx context.Context, path string, bytes uint64) (freed uint64, err error)
+}
+
 // FromConfig produces a set of disk space guards from the configuration
-func FromConfig(cfg Config, clientset kubernetes.Interface, nodeName string) []*Guard {
+func FromConfig(cfg Config, clientset kubernetes.Interface, nodeName string, reclaimers ...Reclaimer) []*Guard {
 	if !cfg.Enabled {
 		return nil
 	}
@@ -50,6 +57,7 @@ func FromConfig(cfg Config, clientset kubernetes.Interface, nodeName string) []*
 			Interval:      time.Duration(cfg.Interval),
 			Clientset:     clientset,
 			Nodename:      nodeName,
+			Reclaimers:    reclaimers,
 		}
 	}
 
@@ -58,7 +66,8 @@ func FromConfig(cfg Config, clientset kubernetes.Interface, nodeName string) []*
 
 // Guard regularly checks how much free space is left on a path/disk.
 // If the percentage of used space goes above a certain threshold,
-// we'll label the node accordingly - and remove the label once that condition
+// we'll first ask the reclaimers to free up space, and if that doesn't suffice
+// label the node accordingly - and remove the label once that condition
 // subsides.
 type Guard struct {
 	Path          string
@@ -66,6 +75,7 @@ type Guard struct {
 	Interval      time.Duration
 	Clientset     kubernetes.Interface
 	Nodename      string
+	Reclaimers    []Reclaimer
 }
 
 // Start starts the disk guard
@@ -79,6 +89,10 @@ func (g *Guard) Start() {
 		}
 		log.WithField("bvail", bvail).WithField("minBytesAvail", g.MinBytesAvail).Debug("checked for available disk space")
 
+		if bvail <= g.MinBytesAvail && len(g.Reclaimers) > 0 {
+			bvail = g.reclaim(bvail)
+		}
+
 		addLabel := bvail <= g.MinBytesAvail
 		err = g.setLabel(LabelDiskPressure, addLabel)
 		if err != nil {
@@ -89,6 +103,34 @@ func (g *Guard) Start() {
 	}
 }
 
+// reclaim asks the reclaimers to free the space missing to MinBytesAvail and returns the available bytes afterwards
+func (g *Guard) reclaim(bvail uint64) uint64 {
+	ctx, cancel := context.WithTimeout(context.Background(), g.Interval)
+	defer cancel()
+
+	for _, r := range g.Reclaimers {
+		// we free one byte more than strictly necessary as the node is under pressure at exactly MinBytesAvail
+		freed, err := r.Reclaim(ctx, g.Path, g.MinBytesAvail-bvail+1)
+		if err != nil {
+			log.WithError(err).WithField("path", g.Path).Warn("cannot reclaim disk space")
+		}
+		if freed == 0 {
+			continue
+		}
+		log.WithField("path", g.Path).WithField("freed", freed).Info("reclaimed disk space")
+
+		bvail, err = getAvailableBytes(g.Path)
+		if err != nil {
+			log.WithError(err).WithField("path", g.Path).Error("cannot check how much space is available")
+			return 0
+		}
+		if bvail > g.MinBytesAvail {
+			break
+		}
+	}
+	return bvail
+}
+
 // setLabel adds or removes the label from the node
 func (g *Guard) setLabel(label string, add bool) error {
 	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
@@ -0,0 +1,493 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package gitcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+const (
+	// metaFile is the file within a mirror which holds its metadata
+	metaFile = "gitpod-mirror.json"
+
+	// tmpPrefix is the prefix of mirrors which are still being cloned
+	tmpPrefix = ".tmp-"
+
+	// failureBackoff is the time we wait before we try to mirror a remote again which could not be mirrored
+	failureBackoff = 1 * time.Hour
+
+	// maxConcurrentMirrors limits the number of mirrors which are cloned at the same time
+	maxConcurrentMirrors = 2
+)
+
+// Cache keeps bare mirrors of Git remotes on the node. Content initializers clone from those mirrors using
+// `--reference` and fetch only what's missing from the remote.
+//
+// Mirrors are cloned anonymously, hence only remotes which are accessible without credentials
+// end up in the cache. A nil cache is valid and never has a mirror.
+type Cache struct {
+	Config Config
+
+	mu      sync.Mutex
+	mirrors map[string]*mirror
+	pending map[string]struct{}
+	failed  map[string]time.Time
+	limiter chan struct{}
+
+	lookups   *prometheus.CounterVec
+	timeSaved prometheus.Counter
+	evictions *prometheus.CounterVec
+}
+
+type mirrorMeta struct {
+	RemoteURI string    `json:"remoteURI"`
+	LastUsed  time.Time `json:"lastUsed"`
+	// FetchDuration is the time it took to clone the mirror, i.e. the time a clone which
+	// does not borrow objects from the mirror would roughly take.
+	FetchDuration time.Duration `json:"fetchDuration"`
+}
+
+type mirror struct {
+	mirrorMeta
+
+	Dir  string
+	Size int64
+
+	// inUse counts the clones currently borrowing objects from this mirror. Mirrors in use are never evicted.
+	inUse int
+}
+
+// New produces a new cache and loads the mirrors present in its location
+func New(cfg Config, reg prometheus.Registerer) (*Cache, error) {
+	if cfg.Location == "" {
+		return nil, xerrors.Errorf("Git cache location is missing")
+	}
+	err := os.MkdirAll(cfg.Location, 0755)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create Git cache location: %w", err)
+	}
+
+	c := &Cache{
+		Config:  cfg,
+		mirrors: make(map[string]*mirror),
+		pending: make(map[string]struct{}),
+		failed:  make(map[string]time.Time),
+		limiter: make(chan struct{}, maxConcurrentMirrors),
+		lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gitcache_lookups_total",
+			Help: "Lookups of Git mirrors by content initializers",
+		}, []string{"result"}),
+		timeSaved: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "gitcache_time_saved_seconds_total",
+			Help: "Estimated time saved by cloning from a Git mirror, based on the time it took to clone the mirror itself",
+		}),
+		evictions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gitcache_evictions_total",
+			Help: "Git mirrors evicted from the cache",
+		}, []string{"reason"}),
+	}
+	size := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "gitcache_size_bytes",
+		Help: "Disk space used by all Git mirrors",
+	}, func() float64 { return float64(c.size()) })
+	for _, m := range []prometheus.Collector{c.lookups, c.timeSaved, c.evictions, size} {
+		err = reg.Register(m)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot register Git cache metrics: %w", err)
+		}
+	}
+
+	err = c.load()
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// load reads all mirrors from the cache location and removes what's left of clones which never finished
+func (c *Cache) load() error {
+	entries, err := os.ReadDir(c.Config.Location)
+	if err != nil {
+		return xerrors.Errorf("cannot read Git cache location: %w", err)
+	}
+	for _, e := range entries {
+		dir := filepath.Join(c.Config.Location, e.Name())
+		if strings.HasPrefix(e.Name(), tmpPrefix) {
+			_ = os.RemoveAll(dir)
+			continue
+		}
+		if !e.IsDir() || !strings.HasSuffix(e.Name(), ".git") {
+			continue
+		}
+
+		fc, err := os.ReadFile(filepath.Join(dir, metaFile))
+		if err != nil {
+			log.WithError(err).WithField("dir", dir).Warn("Git mirror has no metadata - removing it")
+			_ = os.RemoveAll(dir)
+			continue
+		}
+		var meta mirrorMeta
+		err = json.Unmarshal(fc, &meta)
+		if err != nil {
+			log.WithError(err).WithField("dir", dir).Warn("Git mirror has invalid metadata - removing it")
+			_ = os.RemoveAll(dir)
+			continue
+		}
+		size, err := dirSize(dir)
+		if err != nil {
+			log.WithError(err).WithField("dir", dir).Warn("cannot determine size of Git mirror")
+		}
+		c.mirrors[strings.TrimSuffix(e.Name(), ".git")] = &mirror{
+			mirrorMeta: meta,
+			Dir:        dir,
+			Size:       size,
+		}
+	}
+	return nil
+}
+
+// Lookup returns the directory of the mirror of a remote, or an empty string if there is none yet. Remotes
+// without a mirror are mirrored in the background. Callers must call release once they no longer use the mirror.
+func (c *Cache) Lookup(remoteURI string) (dir string, release func()) {
+	release = func() {}
+	if c == nil {
+		return "", release
+	}
+
+	key, remote, ok := mirrorKey(remoteURI)
+	if !ok {
+		return "", release
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m, exists := c.mirrors[key]
+	if !exists {
+		c.lookups.WithLabelValues("miss").Inc()
+		c.scheduleMirror(key, remote)
+		return "", release
+	}
+
+	c.lookups.WithLabelValues("hit").Inc()
+	c.timeSaved.Add(m.FetchDuration.Seconds())
+	m.LastUsed = time.Now()
+	m.inUse++
+
+	var once sync.Once
+	release = func() {
+		once.Do(func() {
+			c.mu.Lock()
+			m.inUse--
+			c.mu.Unlock()
+		})
+	}
+	return m.Dir, release
+}
+
+// scheduleMirror clones a mirror of the remote in the background. Callers must hold mu.
+func (c *Cache) scheduleMirror(key, remote string) {
+	if _, pending := c.pending[key]; pending {
+		return
+	}
+	if t, failed := c.failed[key]; failed && time.Since(t) < failureBackoff {
+		return
+	}
+	c.pending[key] = struct{}{}
+
+	go func() {
+		c.limiter <- struct{}{}
+		defer func() { <-c.limiter }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.Config.mirrorTimeout())
+		defer cancel()
+
+		err := c.createMirror(ctx, key, remote)
+		if err != nil {
+			log.WithError(err).WithField("remote", remote).Debug("cannot mirror Git remote")
+		} else {
+			c.enforceBudget()
+		}
+
+		c.mu.Lock()
+		delete(c.pending, key)
+		if err != nil {
+			c.failed[key] = time.Now()
+		} else {
+			delete(c.failed, key)
+		}
+		c.mu.Unlock()
+	}()
+}
+
+// createMirror clones a bare mirror of the remote into the cache
+func (c *Cache) createMirror(ctx context.Context, key, remote string) error {
+	tmp := filepath.Join(c.Config.Location, tmpPrefix+key)
+	_ = os.RemoveAll(tmp)
+
+	start := time.Now()
+	err := git(ctx, "",
+		"clone", "--mirror", "--quiet",
+		// Clones borrow objects from the mirror while it's refreshed. Automatic garbage
+		// collection could remove the packs those clones are reading.
+		"--config", "gc.auto=0",
+		remote, tmp,
+	)
+	if err != nil {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+
+	m := &mirror{
+		mirrorMeta: mirrorMeta{
+			RemoteURI:     remote,
+			LastUsed:      time.Now(),
+			FetchDuration: time.Since(start),
+		},
+		Dir: filepath.Join(c.Config.Location, key+".git"),
+	}
+	err = writeMeta(tmp, m.mirrorMeta)
+	if err != nil {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+	m.Size, err = dirSize(tmp)
+	if err != nil {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err = os.Rename(tmp, m.Dir)
+	if err != nil {
+		_ = os.RemoveAll(tmp)
+		return xerrors.Errorf("cannot move Git mirror into place: %w", err)
+	}
+	c.mirrors[key] = m
+	log.WithField("remote", remote).WithField("size", m.Size).WithField("duration", m.FetchDuration).Info("mirrored Git remote")
+	return nil
+}
+
+// Start refreshes the mirrors regularly until the context is canceled
+func (c *Cache) Start(ctx context.Context) {
+	if c == nil {
+		return
+	}
+
+	t := time.NewTicker(c.Config.refreshInterval())
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		c.Refresh(ctx)
+		c.enforceBudget()
+	}
+}
+
+// Refresh fetches the latest changes of all remotes into their mirrors
+func (c *Cache) Refresh(ctx context.Context) {
+	c.mu.Lock()
+	mirrors := make(map[string]mirror, len(c.mirrors))
+	for key, m := range c.mirrors {
+		mirrors[key] = *m
+	}
+	c.mu.Unlock()
+
+	for key, m := range mirrors {
+		if ctx.Err() != nil {
+			return
+		}
+
+		fctx, cancel := context.WithTimeout(ctx, c.Config.mirrorTimeout())
+		err := git(fctx, m.Dir, "fetch", "--prune", "--quiet")
+		cancel()
+		if err != nil {
+			log.WithError(err).WithField("remote", m.RemoteURI).Warn("cannot refresh Git mirror")
+			continue
+		}
+		size, err := dirSize(m.Dir)
+		if err != nil {
+			log.WithError(err).WithField("remote", m.RemoteURI).Warn("cannot determine size of Git mirror")
+			continue
+		}
+
+		c.mu.Lock()
+		cur, exists := c.mirrors[key]
+		if exists {
+			cur.Size = size
+			err = writeMeta(cur.Dir, cur.mirrorMeta)
+		}
+		c.mu.Unlock()
+		if err != nil {
+			log.WithError(err).WithField("remote", m.RemoteURI).Warn("cannot update Git mirror metadata")
+		}
+	}
+}
+
+// Reclaim evicts least recently used mirrors until at least bytes are freed. Mirrors are only evicted
+// if the cache resides on the same filesystem as path. Implements diskguard.Reclaimer.
+func (c *Cache) Reclaim(ctx context.Context, path string, bytes uint64) (freed uint64, err error) {
+	if c == nil {
+		return 0, nil
+	}
+
+	same, err := sameFilesystem(path, c.Config.Location)
+	if err != nil {
+		return 0, err
+	}
+	if !same {
+		return 0, nil
+	}
+
+	return uint64(c.evict("disk_pressure", func(freed int64) bool { return uint64(freed) >= bytes })), nil
+}
+
+// enforceBudget evicts least recently used mirrors until the cache fits its disk budget
+func (c *Cache) enforceBudget() {
+	if c.Config.MaxBytes <= 0 {
+		return
+	}
+
+	excess := c.size() - c.Config.MaxBytes
+	if excess <= 0 {
+		return
+	}
+	c.evict("budget", func(freed int64) bool { return freed >= excess })
+}
+
+// evict removes unused mirrors in least recently used order until done returns true
+func (c *Cache) evict(reason string, done func(freed int64) bool) (freed int64) {
+	c.mu.Lock()
+	keys := make([]string, 0, len(c.mirrors))
+	for key := range c.mirrors {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return c.mirrors[keys[i]].LastUsed.Before(c.mirrors[keys[j]].LastUsed) })
+
+	var evicted []*mirror
+	for _, key := range keys {
+		if done(freed) {
+			break
+		}
+		m := c.mirrors[key]
+		if m.inUse > 0 {
+			continue
+		}
+		delete(c.mirrors, key)
+		evicted = append(evicted, m)
+		freed += m.Size
+	}
+	c.mu.Unlock()
+
+	for _, m := range evicted {
+		err := os.RemoveAll(m.Dir)
+		if err != nil {
+			log.WithError(err).WithField("dir", m.Dir).Warn("cannot remove evicted Git mirror")
+		}
+		c.evictions.WithLabelValues(reason).Inc()
+		log.WithField("remote", m.RemoteURI).WithField("size", m.Size).WithField("reason", reason).Info("evicted Git mirror")
+	}
+	return freed
+}
+
+func (c *Cache) size() (res int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, m := range c.mirrors {
+		res += m.Size
+	}
+	return res
+}
+
+// mirrorKey identifies the mirror of a remote independently of the credentials in its URI.
+// Returns the remote URI without credentials. Only HTTP(S) remotes can be mirrored.
+func mirrorKey(remoteURI string) (key, remote string, ok bool) {
+	u, err := url.Parse(remoteURI)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "", "", false
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	remote = u.String()
+
+	path := strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), ".git")
+	h := sha256.Sum256([]byte(strings.ToLower(u.Host) + path))
+	return hex.EncodeToString(h[:16]), remote, true
+}
+
+// git runs a Git command which never prompts for credentials
+func git(ctx context.Context, dir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-c", "credential.helper="}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return xerrors.Errorf("git %s: %w: %s", args[0], err, string(out))
+	}
+	return nil
+}
+
+func writeMeta(dir string, meta mirrorMeta) error {
+	fc, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, metaFile), fc, 0644)
+}
+
+func dirSize(dir string) (size int64, err error) {
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return
+}
+
+func sameFilesystem(a, b string) (bool, error) {
+	var sa, sb syscall.Stat_t
+	err := syscall.Stat(a, &sa)
+	if err != nil {
+		return false, xerrors.Errorf("cannot stat %s: %w", a, err)
+	}
+	err = syscall.Stat(b, &sb)
+	if err != nil {
+		return false, xerrors.Errorf("cannot stat %s: %w", b, err)
+	}
+	return sa.Dev == sb.Dev, nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2022 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package gitcache
+
+import (
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/util"
+)
+
+// Config configures the node-local cache of Git mirrors
+type Config struct {
+	Enabled bool `json:"enabled"`
+
+	// Location is the directory the bare mirrors are kept in. It should reside on a disk watched by
+	// a disk guard, s.t. the cache gives up space when the disk runs full.
+	Location string `json:"location"`
+
+	// MaxBytes is the disk budget of all mirrors together. Least recently used mirrors are evicted
+	// once the cache grows beyond it. Zero means no budget.
+	MaxBytes int64 `json:"maxBytes,omitempty"`
+
+	// RefreshInterval is the time between fetching updates into the mirrors. Defaults to 10 minutes.
+	RefreshInterval util.Duration `json:"refreshInterval,omitempty"`
+
+	// MirrorTimeout limits the time it may take to create or refresh a single mirror. Defaults to 30 minutes.
+	MirrorTimeout util.Duration `json:"mirrorTimeout,omitempty"`
+}
+
+func (c Config) refreshInterval() time.Duration {
+	if c.RefreshInterval == 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(c.RefreshInterval)
+}
+
+func (c Config) mirrorTimeout() time.Duration {
+	if c.MirrorTimeout == 0 {
+		return 30 * time.Minute
+	}
+	return time.Duration(c.MirrorTimeout)
+}
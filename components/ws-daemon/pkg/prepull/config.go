@@ -0,0 +1,63 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package prepull
+
+import (
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/util"
+)
+
+// Config configures the warm-up of the node's containerd content store with the images workspaces
+// request most frequently. Workspaces which use those images do not have to wait for them to be pulled.
+type Config struct {
+	Enabled bool `json:"enabled"`
+
+	// Popularity configures where we learn which images are requested most frequently
+	Popularity PopularityConfig `json:"popularity"`
+
+	// RegistryAuth is the path to a Docker config file providing the credentials images are pulled with.
+	// Images are pulled anonymously if it is empty.
+	RegistryAuth string `json:"registryAuth,omitempty"`
+
+	// Images are kept warm regardless of how often they are requested, e.g. the default workspace image.
+	// New nodes warm them before they have served any workspace.
+	Images []string `json:"images,omitempty"`
+
+	// Interval is the time between two warm-ups. Defaults to 5 minutes.
+	Interval util.Duration `json:"interval,omitempty"`
+
+	// MaxImages is the number of images which are kept warm. Defaults to 10.
+	MaxImages int `json:"maxImages,omitempty"`
+
+	// MaxBytes is the budget of image content the warm-up keeps in the content store. Zero means no budget.
+	MaxBytes int64 `json:"maxBytes,omitempty"`
+}
+
+// PopularityConfig configures access to the image popularity registry-facade serves
+type PopularityConfig struct {
+	// URL is the popularity endpoint of registry-facade
+	URL string `json:"url"`
+
+	// CAPath is the CA registry-facade's certificate is verified with. Defaults to the system's CAs.
+	CAPath string `json:"caPath,omitempty"`
+
+	// ServerName is the name registry-facade's certificate is verified for, if it differs from the URL's host
+	ServerName string `json:"serverName,omitempty"`
+}
+
+func (c Config) interval() time.Duration {
+	if c.Interval == 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.Interval)
+}
+
+func (c Config) maxImages() int {
+	if c.MaxImages <= 0 {
+		return 10
+	}
+	return c.MaxImages
+}
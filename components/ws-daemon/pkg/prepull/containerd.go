@@ -0,0 +1,71 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package prepull
+
+import (
+	"context"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/remotes"
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/xerrors"
+)
+
+// Namespace is the containerd namespace warm images are kept in. containerd shares content across namespaces,
+// hence pulls in the Kubernetes namespace do not download blobs which are present in this one already.
+// Keeping our images apart means the kubelet's image GC does not remove them, and we do not remove its images.
+const Namespace = "gitpod-prepull"
+
+// ContainerdImageStore keeps images in a containerd content store
+type ContainerdImageStore struct {
+	Client *containerd.Client
+}
+
+var _ ImageStore = &ContainerdImageStore{}
+
+// NewContainerdImageStore connects to containerd
+func NewContainerdImageStore(socket string) (*ContainerdImageStore, error) {
+	client, err := containerd.New(socket, containerd.WithDefaultNamespace(Namespace))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot connect to containerd: %w", err)
+	}
+	return &ContainerdImageStore{Client: client}, nil
+}
+
+// Pull downloads the content of an image for the node's platform. It does not unpack the image.
+func (s *ContainerdImageStore) Pull(ctx context.Context, ref string, resolver remotes.Resolver) (digest.Digest, error) {
+	img, err := s.Client.Fetch(ctx, ref,
+		containerd.WithResolver(resolver),
+		containerd.WithPlatformMatcher(platforms.Default()),
+	)
+	if err != nil {
+		return "", err
+	}
+	return img.Target.Digest, nil
+}
+
+// List returns the digests of all images pulled previously by their reference
+func (s *ContainerdImageStore) List(ctx context.Context) (map[string]digest.Digest, error) {
+	imgs, err := s.Client.ImageService().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]digest.Digest, len(imgs))
+	for _, img := range imgs {
+		res[img.Name] = img.Target.Digest
+	}
+	return res, nil
+}
+
+// Delete removes the image. containerd's garbage collection removes its content unless other images use it.
+func (s *ContainerdImageStore) Delete(ctx context.Context, ref string) error {
+	err := s.Client.ImageService().Delete(ctx, ref)
+	if errdefs.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package prepull
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/registryauth"
+)
+
+const (
+	// popularityTimeout limits the time it may take to ask registry-facade for the popular images
+	popularityTimeout = 10 * time.Second
+)
+
+// RegistryFacadePopularity asks registry-facade which images workspaces request most frequently
+type RegistryFacadePopularity struct {
+	URL    string
+	Client *http.Client
+}
+
+var _ Popularity = &RegistryFacadePopularity{}
+
+// NewRegistryFacadePopularity creates a client for the popularity endpoint of registry-facade
+func NewRegistryFacadePopularity(cfg PopularityConfig) (*RegistryFacadePopularity, error) {
+	if cfg.URL == "" {
+		return nil, xerrors.Errorf("popularity URL is missing")
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName: cfg.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if cfg.CAPath != "" {
+		ca, err := os.ReadFile(cfg.CAPath)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot read registry-facade CA: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, xerrors.Errorf("cannot load registry-facade CA from %s", cfg.CAPath)
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &RegistryFacadePopularity{
+		URL: cfg.URL,
+		Client: &http.Client{
+			Transport: transport,
+			Timeout:   popularityTimeout,
+		},
+	}, nil
+}
+
+// Popular returns the references of the n most frequently requested images, most popular first
+func (p *RegistryFacadePopularity) Popular(ctx context.Context, n int) ([]string, error) {
+	u, err := url.Parse(p.URL)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid popularity URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("limit", strconv.Itoa(n))
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, xerrors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var popular []struct {
+		Ref string `json:"ref"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&popular)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot decode image popularity: %w", err)
+	}
+	res := make([]string, 0, len(popular))
+	for _, p := range popular {
+		res = append(res, p.Ref)
+	}
+	return res, nil
+}
+
+// NewResolverProvider produces resolvers which authenticate with the credentials of the Docker config file fn.
+// The file is read anew for every resolver, s.t. rotated credentials are picked up. Empty fn means anonymous pulls.
+func NewResolverProvider(fn string) func() remotes.Resolver {
+	return func() remotes.Resolver {
+		var opts docker.ResolverOptions
+		if fn == "" {
+			return docker.NewResolver(opts)
+		}
+
+		cfg, err := registryauth.Load(fn)
+		if err != nil {
+			log.WithError(err).Warn("cannot read registry auth - pulling images anonymously")
+			return docker.NewResolver(opts)
+		}
+		opts.Hosts = docker.ConfigureDefaultRegistries(
+			docker.WithAuthorizer(docker.NewDockerAuthorizer(docker.WithAuthCreds(cfg.Credentials))),
+		)
+		return docker.NewResolver(opts)
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package prepull
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/remotes"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/xerrors"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// maxManifestSize limits the size of the manifests and indexes we resolve images with
+const maxManifestSize = 4 * 1024 * 1024
+
+// ImageStore keeps the content of images on the node
+type ImageStore interface {
+	// Pull downloads an image and keeps its content until the image is deleted.
+	// Pull returns the digest of the image's manifest or index.
+	Pull(ctx context.Context, ref string, resolver remotes.Resolver) (digest.Digest, error)
+
+	// List returns the digests of all images pulled previously by their reference
+	List(ctx context.Context) (map[string]digest.Digest, error)
+
+	// Delete releases the content of an image
+	Delete(ctx context.Context, ref string) error
+}
+
+// Popularity knows which images workspaces request most frequently
+type Popularity interface {
+	// Popular returns the references of the n most frequently requested images, most popular first
+	Popular(ctx context.Context, n int) ([]string, error)
+}
+
+// Status describes how warm the node is
+type Status struct {
+	// Warm is true if all images which ought to be warm are in the content store
+	Warm bool `json:"warm"`
+
+	Images     int       `json:"images"`
+	WarmImages int       `json:"warmImages"`
+	Bytes      int64     `json:"bytes"`
+	Updated    time.Time `json:"updated"`
+}
+
+// Warmer keeps the most frequently requested images in the node's content store
+type Warmer struct {
+	Store      ImageStore
+	Resolver   func() remotes.Resolver
+	Popularity Popularity
+
+	Images    []string
+	Interval  time.Duration
+	MaxImages int
+	MaxBytes  int64
+
+	mu     sync.RWMutex
+	status Status
+
+	pulls      *prometheus.CounterVec
+	warmImages prometheus.Gauge
+}
+
+// NewWarmer creates a new warmer
+func NewWarmer(cfg Config, store ImageStore, resolver func() remotes.Resolver, popularity Popularity, reg prometheus.Registerer) (*Warmer, error) {
+	res := &Warmer{
+		Store:      store,
+		Resolver:   resolver,
+		Popularity: popularity,
+		Images:     cfg.Images,
+		Interval:   cfg.interval(),
+		MaxImages:  cfg.maxImages(),
+		MaxBytes:   cfg.MaxBytes,
+		pulls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prepull_pulls_total",
+			Help: "number of images pulled to warm the node",
+		}, []string{"ok"}),
+		warmImages: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "prepull_warm_images",
+			Help: "number of images which are warm on the node",
+		}),
+	}
+	for _, c := range []prometheus.Collector{res.pulls, res.warmImages} {
+		err := reg.Register(c)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot register pre-pull metrics: %w", err)
+		}
+	}
+	return res, nil
+}
+
+// Run warms the node until the context is canceled
+func (w *Warmer) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := w.Warm(ctx)
+		if err != nil {
+			log.WithError(err).Warn("cannot warm node")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Status returns the warm status of the node
+func (w *Warmer) Status() Status {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.status
+}
+
+// ServeHTTP serves the warm status of the node
+func (w *Warmer) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(rw).Encode(w.Status())
+	if err != nil {
+		log.WithError(err).Warn("cannot serve pre-pull status")
+	}
+}
+
+type warmupTarget struct {
+	Ref    string
+	Digest digest.Digest
+	Size   int64
+}
+
+// Warm pulls the images which ought to be warm and releases those which are not popular anymore
+func (w *Warmer) Warm(ctx context.Context) error {
+	resolver := w.Resolver()
+	targets, err := w.targets(ctx, resolver)
+	if err != nil {
+		return err
+	}
+	present, err := w.Store.List(ctx)
+	if err != nil {
+		return xerrors.Errorf("cannot list warm images: %w", err)
+	}
+
+	status := Status{Images: len(targets)}
+	wanted := make(map[string]struct{}, len(targets))
+	for _, t := range targets {
+		wanted[t.Ref] = struct{}{}
+		status.Bytes += t.Size
+
+		if present[t.Ref] == t.Digest {
+			status.WarmImages++
+			continue
+		}
+
+		log.WithField("ref", t.Ref).WithField("size", t.Size).Info("pulling image to warm node")
+		dgst, err := w.Store.Pull(ctx, t.Ref, resolver)
+		w.pulls.WithLabelValues(okLabel(err)).Inc()
+		if err != nil {
+			log.WithError(err).WithField("ref", t.Ref).Warn("cannot pull image to warm node")
+			continue
+		}
+		present[t.Ref] = dgst
+		status.WarmImages++
+	}
+
+	for ref := range present {
+		if _, ok := wanted[ref]; ok {
+			continue
+		}
+		err := w.Store.Delete(ctx, ref)
+		if err != nil {
+			log.WithError(err).WithField("ref", ref).Warn("cannot release image")
+			continue
+		}
+		log.WithField("ref", ref).Debug("released image which is not popular anymore")
+	}
+
+	status.Warm = status.Images > 0 && status.WarmImages == status.Images
+	status.Updated = time.Now()
+	w.warmImages.Set(float64(status.WarmImages))
+
+	w.mu.Lock()
+	w.status = status
+	w.mu.Unlock()
+
+	return nil
+}
+
+// targets returns the images which ought to be warm. The configured images come first, followed by the
+// most popular ones for as long as they fit into the budget.
+func (w *Warmer) targets(ctx context.Context, resolver remotes.Resolver) ([]warmupTarget, error) {
+	popular, err := w.Popularity.Popular(ctx, w.MaxImages)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot get image popularity: %w", err)
+	}
+	refs := append(append([]string{}, w.Images...), popular...)
+
+	var (
+		res  []warmupTarget
+		size int64
+		seen = make(map[string]struct{}, len(refs))
+	)
+	for _, ref := range refs {
+		if len(res) >= w.MaxImages {
+			break
+		}
+		if _, ok := seen[ref]; ok {
+			continue
+		}
+		seen[ref] = struct{}{}
+
+		t, err := resolve(ctx, resolver, ref)
+		if err != nil {
+			log.WithError(err).WithField("ref", ref).Warn("cannot resolve image to warm node")
+			continue
+		}
+		if w.MaxBytes > 0 && size+t.Size > w.MaxBytes {
+			// a less popular, smaller image might still fit
+			log.WithField("ref", ref).WithField("size", t.Size).Debug("image does not fit the pre-pull budget")
+			continue
+		}
+		size += t.Size
+		res = append(res, t)
+	}
+	return res, nil
+}
+
+// resolve determines the digest of an image and the size of its content for the node's platform
+func resolve(ctx context.Context, resolver remotes.Resolver, ref string) (res warmupTarget, err error) {
+	name, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return
+	}
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return
+	}
+
+	res = warmupTarget{Ref: ref, Digest: desc.Digest}
+	if images.IsIndexType(desc.MediaType) {
+		var index ociv1.Index
+		err = fetchJSON(ctx, fetcher, desc, &index)
+		if err != nil {
+			return
+		}
+		matcher := platforms.Default()
+		var found bool
+		for _, m := range index.Manifests {
+			if m.Platform == nil || matcher.Match(*m.Platform) {
+				desc, found = m, true
+				break
+			}
+		}
+		if !found {
+			err = xerrors.Errorf("image has no manifest for %s", platforms.DefaultString())
+			return
+		}
+	}
+
+	var mf ociv1.Manifest
+	err = fetchJSON(ctx, fetcher, desc, &mf)
+	if err != nil {
+		return
+	}
+	res.Size = mf.Config.Size
+	for _, l := range mf.Layers {
+		res.Size += l.Size
+	}
+	return res, nil
+}
+
+func fetchJSON(ctx context.Context, fetcher remotes.Fetcher, desc ociv1.Descriptor, dst interface{}) error {
+	if desc.Size > maxManifestSize {
+		return xerrors.Errorf("manifest %s is too large (%d bytes)", desc.Digest, desc.Size)
+	}
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return xerrors.Errorf("cannot fetch %s: %w", desc.Digest, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, maxManifestSize))
+	if err != nil {
+		return xerrors.Errorf("cannot fetch %s: %w", desc.Digest, err)
+	}
+	err = json.Unmarshal(content, dst)
+	if err != nil {
+		return xerrors.Errorf("cannot unmarshal %s: %w", desc.Digest, err)
+	}
+	return nil
+}
+
+func okLabel(err error) string {
+	if err != nil {
+		return "false"
+	}
+	return "true"
+}
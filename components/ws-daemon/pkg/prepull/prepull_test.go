@@ -0,0 +1,330 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package prepull
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/remotes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/xerrors"
+)
+
+func TestWarmer(t *testing.T) {
+	reg := newTestRegistry()
+	images := map[string]int64{
+		"base:a":  100,
+		"base:b":  1000,
+		"base:c":  10,
+		"ide:1":   50,
+		"seed:v1": 20,
+	}
+	for ref, size := range images {
+		reg.image(t, ref, size)
+	}
+	reg.index(t, "multi:latest", map[string]int64{"linux/other": 1000, platforms.DefaultString(): 30})
+
+	type Expectation struct {
+		Pulled  []string
+		Deleted []string
+		Status  Status
+	}
+	tests := []struct {
+		Name        string
+		Config      Config
+		Popular     []string
+		Present     map[string]digest.Digest
+		FailPull    string
+		Expectation Expectation
+	}{
+		{
+			Name:    "popular images are pulled",
+			Popular: []string{"base:a", "ide:1"},
+			Expectation: Expectation{
+				Pulled: []string{"base:a", "ide:1"},
+				Status: Status{Warm: true, Images: 2, WarmImages: 2, Bytes: 150},
+			},
+		},
+		{
+			Name:    "configured images come first",
+			Config:  Config{Images: []string{"seed:v1", "base:a"}, MaxImages: 3},
+			Popular: []string{"base:a", "ide:1", "base:c"},
+			Expectation: Expectation{
+				Pulled: []string{"base:a", "ide:1", "seed:v1"},
+				Status: Status{Warm: true, Images: 3, WarmImages: 3, Bytes: 170},
+			},
+		},
+		{
+			Name:    "images which exceed the budget are skipped",
+			Config:  Config{MaxBytes: 200},
+			Popular: []string{"base:a", "base:b", "base:c"},
+			Expectation: Expectation{
+				Pulled: []string{"base:a", "base:c"},
+				Status: Status{Warm: true, Images: 2, WarmImages: 2, Bytes: 110},
+			},
+		},
+		{
+			Name:    "unresolvable images are skipped",
+			Popular: []string{"base:a", "does-not-exist:latest"},
+			Expectation: Expectation{
+				Pulled: []string{"base:a"},
+				Status: Status{Warm: true, Images: 1, WarmImages: 1, Bytes: 100},
+			},
+		},
+		{
+			Name:    "warm images are not pulled again",
+			Popular: []string{"base:a", "ide:1"},
+			Present: map[string]digest.Digest{"base:a": reg.refs["base:a"].Digest},
+			Expectation: Expectation{
+				Pulled: []string{"ide:1"},
+				Status: Status{Warm: true, Images: 2, WarmImages: 2, Bytes: 150},
+			},
+		},
+		{
+			Name:    "outdated images are pulled again",
+			Popular: []string{"base:a"},
+			Present: map[string]digest.Digest{"base:a": digest.FromString("outdated")},
+			Expectation: Expectation{
+				Pulled: []string{"base:a"},
+				Status: Status{Warm: true, Images: 1, WarmImages: 1, Bytes: 100},
+			},
+		},
+		{
+			Name:    "images which are not popular anymore are released",
+			Popular: []string{"base:a"},
+			Present: map[string]digest.Digest{"base:a": reg.refs["base:a"].Digest, "base:c": reg.refs["base:c"].Digest},
+			Expectation: Expectation{
+				Deleted: []string{"base:c"},
+				Status:  Status{Warm: true, Images: 1, WarmImages: 1, Bytes: 100},
+			},
+		},
+		{
+			Name:    "multi-platform images are sized for the node's platform",
+			Popular: []string{"multi:latest"},
+			Expectation: Expectation{
+				Pulled: []string{"multi:latest"},
+				Status: Status{Warm: true, Images: 1, WarmImages: 1, Bytes: 30},
+			},
+		},
+		{
+			Name:     "failed pull",
+			Popular:  []string{"base:a", "ide:1"},
+			FailPull: "ide:1",
+			Expectation: Expectation{
+				Pulled: []string{"base:a"},
+				Status: Status{Warm: false, Images: 2, WarmImages: 1, Bytes: 150},
+			},
+		},
+		{
+			Name: "nothing to warm",
+			Expectation: Expectation{
+				Status: Status{Warm: false},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			store := &fakeImageStore{Images: make(map[string]digest.Digest), Registry: reg, FailPull: test.FailPull}
+			for ref, dgst := range test.Present {
+				store.Images[ref] = dgst
+			}
+			w, err := NewWarmer(test.Config, store, func() remotes.Resolver { return reg }, fixedPopularity(test.Popular), prometheus.NewRegistry())
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = w.Warm(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sort.Strings(store.Pulled)
+			sort.Strings(store.Deleted)
+			act := Expectation{Pulled: store.Pulled, Deleted: store.Deleted, Status: w.Status()}
+			if diff := cmp.Diff(test.Expectation, act, cmpopts.IgnoreFields(Status{}, "Updated")); diff != "" {
+				t.Errorf("unexpected warm-up (-want +got):\n%s", diff)
+			}
+
+			// the status is served as JSON for node-labeler
+			rec := httptest.NewRecorder()
+			w.ServeHTTP(rec, httptest.NewRequest("GET", "/prepull/status", nil))
+			var served Status
+			err = json.Unmarshal(rec.Body.Bytes(), &served)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation.Status.Warm, served.Warm); diff != "" {
+				t.Errorf("unexpected served status (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type fixedPopularity []string
+
+func (p fixedPopularity) Popular(ctx context.Context, n int) ([]string, error) {
+	if len(p) > n {
+		return p[:n], nil
+	}
+	return p, nil
+}
+
+type fakeImageStore struct {
+	Images   map[string]digest.Digest
+	Registry *testRegistry
+	FailPull string
+
+	Pulled  []string
+	Deleted []string
+}
+
+func (s *fakeImageStore) Pull(ctx context.Context, ref string, resolver remotes.Resolver) (digest.Digest, error) {
+	if ref == s.FailPull {
+		return "", xerrors.Errorf("cannot pull %s", ref)
+	}
+	_, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	s.Images[ref] = desc.Digest
+	s.Pulled = append(s.Pulled, ref)
+	return desc.Digest, nil
+}
+
+func (s *fakeImageStore) List(ctx context.Context) (map[string]digest.Digest, error) {
+	res := make(map[string]digest.Digest, len(s.Images))
+	for ref, dgst := range s.Images {
+		res[ref] = dgst
+	}
+	return res, nil
+}
+
+func (s *fakeImageStore) Delete(ctx context.Context, ref string) error {
+	delete(s.Images, ref)
+	s.Deleted = append(s.Deleted, ref)
+	return nil
+}
+
+// testRegistry serves image manifests from memory. It implements remotes.Resolver and remotes.Fetcher.
+type testRegistry struct {
+	refs  map[string]ociv1.Descriptor
+	blobs map[digest.Digest][]byte
+}
+
+func newTestRegistry() *testRegistry {
+	return &testRegistry{
+		refs:  make(map[string]ociv1.Descriptor),
+		blobs: make(map[digest.Digest][]byte),
+	}
+}
+
+// image adds an image whose layers have the given total size. The layers themselves are never fetched.
+func (reg *testRegistry) image(t *testing.T, ref string, size int64) {
+	content, err := json.Marshal(ociv1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ociv1.MediaTypeImageManifest,
+		Config:    ociv1.Descriptor{MediaType: ociv1.MediaTypeImageConfig, Digest: digest.FromString(ref + "-config"), Size: 0},
+		Layers: []ociv1.Descriptor{
+			{MediaType: ociv1.MediaTypeImageLayerGzip, Digest: digest.FromString(ref + "-layer"), Size: size},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc := ociv1.Descriptor{MediaType: ociv1.MediaTypeImageManifest, Digest: digest.FromBytes(content), Size: int64(len(content))}
+	reg.blobs[desc.Digest] = content
+	reg.refs[ref] = desc
+}
+
+// index adds a multi-platform image with an image of the given size per platform
+func (reg *testRegistry) index(t *testing.T, ref string, sizes map[string]int64) {
+	idx := ociv1.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ociv1.MediaTypeImageIndex,
+	}
+	for platform, size := range sizes {
+		p, err := platforms.Parse(platform)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reg.image(t, ref+"@"+platform, size)
+		desc := reg.refs[ref+"@"+platform]
+		desc.Platform = &p
+		idx.Manifests = append(idx.Manifests, desc)
+	}
+	sort.Slice(idx.Manifests, func(i, j int) bool { return idx.Manifests[i].Digest < idx.Manifests[j].Digest })
+
+	content, err := json.Marshal(idx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc := ociv1.Descriptor{MediaType: ociv1.MediaTypeImageIndex, Digest: digest.FromBytes(content), Size: int64(len(content))}
+	reg.blobs[desc.Digest] = content
+	reg.refs[ref] = desc
+}
+
+func (reg *testRegistry) Resolve(ctx context.Context, ref string) (name string, desc ociv1.Descriptor, err error) {
+	desc, ok := reg.refs[ref]
+	if !ok {
+		return "", ociv1.Descriptor{}, errdefs.ErrNotFound
+	}
+	return ref, desc, nil
+}
+
+func (reg *testRegistry) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	return reg, nil
+}
+
+func (reg *testRegistry) Pusher(ctx context.Context, ref string) (remotes.Pusher, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (reg *testRegistry) Fetch(ctx context.Context, desc ociv1.Descriptor) (io.ReadCloser, error) {
+	content, ok := reg.blobs[desc.Digest]
+	if !ok {
+		return nil, errdefs.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func TestRegistryFacadePopularity(t *testing.T) {
+	var limit string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit = r.URL.Query().Get("limit")
+		_, _ = w.Write([]byte(`[{"ref":"ide:1","requests":3},{"ref":"base:a","requests":2}]`))
+	}))
+	defer srv.Close()
+
+	p, err := NewRegistryFacadePopularity(PopularityConfig{URL: srv.URL + "/popularity"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Client = srv.Client()
+
+	act, err := p.Popular(context.Background(), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"ide:1", "base:a"}, act); diff != "" {
+		t.Errorf("unexpected popular images (-want +got):\n%s", diff)
+	}
+	if limit != "5" {
+		t.Errorf("unexpected limit: %s", limit)
+	}
+}
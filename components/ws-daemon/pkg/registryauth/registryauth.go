@@ -0,0 +1,55 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+// Package registryauth reads registry credentials from a Docker config file the same way registry-facade does.
+package registryauth
+
+import (
+	"os"
+
+	"github.com/docker/cli/cli/config/configfile"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// DockerHubHost is the host containerd talks to for docker.io references
+	DockerHubHost = "registry-1.docker.io"
+	// dockerHubAuthKey is the key of the Docker Hub credentials in a Docker config
+	dockerHubAuthKey = "https://index.docker.io/v1/"
+)
+
+// Config provides the registry credentials of a Docker config file
+type Config struct {
+	cfg *configfile.ConfigFile
+}
+
+// Load reads the Docker config file fn
+func Load(fn string) (*Config, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cfg := configfile.New(fn)
+	err = cfg.LoadFromReader(f)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{cfg: cfg}, nil
+}
+
+// Credentials returns the credentials for host, the registry host containerd talks to.
+// Empty credentials mean the registry is accessed anonymously.
+func (c *Config) Credentials(host string) (username, password string, err error) {
+	key := host
+	if host == DockerHubHost {
+		key = dockerHubAuthKey
+	}
+	auth, err := c.cfg.GetAuthConfig(key)
+	if err != nil {
+		return "", "", xerrors.Errorf("cannot get registry auth for %s: %w", host, err)
+	}
+	return auth.Username, auth.Password, nil
+}
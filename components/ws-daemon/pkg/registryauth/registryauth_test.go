@@ -0,0 +1,48 @@
+// Copyright (c) 2023 Gitpod GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License.AGPL.txt in the project root for license information.
+
+package registryauth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCredentials(t *testing.T) {
+	const dockerCfg = `{"auths": {
+		"registry.example.com": {"auth": "dXNlcjpwYXNzd29yZA=="},
+		"https://index.docker.io/v1/": {"auth": "aHViOnNlY3JldA=="}
+	}}`
+	fn := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(fn, []byte(dockerCfg), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Host        string
+		Expectation [2]string
+	}{
+		{Host: "registry.example.com", Expectation: [2]string{"user", "password"}},
+		{Host: DockerHubHost, Expectation: [2]string{"hub", "secret"}},
+		{Host: "other.example.com"},
+	}
+	for _, test := range tests {
+		t.Run(test.Host, func(t *testing.T) {
+			username, password, err := cfg.Credentials(test.Host)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, [2]string{username, password}); diff != "" {
+				t.Errorf("unexpected credentials (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
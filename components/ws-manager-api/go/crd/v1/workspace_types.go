@@ -143,6 +143,10 @@ type PortSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:default=Http
 	Protocol PortProtocol `json:"protocol"`
+
+	// TokenEpoch is set for protected ports, which can also be accessed using share tokens minted for this epoch.
+	// +kubebuilder:validation:Optional
+	TokenEpoch uint64 `json:"tokenEpoch,omitempty"`
 }
 
 // WorkspaceStatus defines the observed state of Workspace
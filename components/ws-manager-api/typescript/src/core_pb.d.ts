@@ -791,6 +791,8 @@ export class PortSpec extends jspb.Message {
     setUrl(value: string): PortSpec;
     getProtocol(): PortProtocol;
     setProtocol(value: PortProtocol): PortSpec;
+    getTokenEpoch(): number;
+    setTokenEpoch(value: number): PortSpec;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): PortSpec.AsObject;
@@ -808,6 +810,7 @@ export namespace PortSpec {
         visibility: PortVisibility,
         url: string,
         protocol: PortProtocol,
+        tokenEpoch: number,
     }
 }
 
@@ -1282,6 +1285,7 @@ export enum AdmissionLevel {
 export enum PortVisibility {
     PORT_VISIBILITY_PRIVATE = 0,
     PORT_VISIBILITY_PUBLIC = 1,
+    PORT_VISIBILITY_PROTECTED = 2,
 }
 
 export enum PortProtocol {
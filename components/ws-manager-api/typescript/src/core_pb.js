@@ -6510,7 +6510,8 @@ proto.wsman.PortSpec.toObject = function(includeInstance, msg) {
     port: jspb.Message.getFieldWithDefault(msg, 1, 0),
     visibility: jspb.Message.getFieldWithDefault(msg, 3, 0),
     url: jspb.Message.getFieldWithDefault(msg, 4, ""),
-    protocol: jspb.Message.getFieldWithDefault(msg, 5, 0)
+    protocol: jspb.Message.getFieldWithDefault(msg, 5, 0),
+    tokenEpoch: jspb.Message.getFieldWithDefault(msg, 6, 0)
   };
 
   if (includeInstance) {
@@ -6563,6 +6564,10 @@ proto.wsman.PortSpec.deserializeBinaryFromReader = function(msg, reader) {
       var value = /** @type {!proto.wsman.PortProtocol} */ (reader.readEnum());
       msg.setProtocol(value);
       break;
+    case 6:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setTokenEpoch(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -6620,6 +6625,13 @@ proto.wsman.PortSpec.serializeBinaryToWriter = function(message, writer) {
       f
     );
   }
+  f = message.getTokenEpoch();
+  if (f !== 0) {
+    writer.writeUint64(
+      6,
+      f
+    );
+  }
 };
 
 
@@ -6695,6 +6707,24 @@ proto.wsman.PortSpec.prototype.setProtocol = function(value) {
 };
 
 
+/**
+ * optional uint64 token_epoch = 6;
+ * @return {number}
+ */
+proto.wsman.PortSpec.prototype.getTokenEpoch = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.wsman.PortSpec} returns this
+ */
+proto.wsman.PortSpec.prototype.setTokenEpoch = function(value) {
+  return jspb.Message.setProto3IntField(this, 6, value);
+};
+
+
 
 
 
@@ -10174,7 +10204,8 @@ proto.wsman.AdmissionLevel = {
  */
 proto.wsman.PortVisibility = {
   PORT_VISIBILITY_PRIVATE: 0,
-  PORT_VISIBILITY_PUBLIC: 1
+  PORT_VISIBILITY_PUBLIC: 1,
+  PORT_VISIBILITY_PROTECTED: 2
 };
 
 /**
@@ -245,6 +245,7 @@ export class WorkspaceManagerBridge implements Disposable {
                         visibility: mapPortVisibility(p.visibility),
                         protocol: mapPortProcotol(p.protocol),
                         url: p.url,
+                        tokenEpoch: p.tokenEpoch || undefined,
                     };
                 });
             }
@@ -405,6 +406,8 @@ const mapPortVisibility = (visibility: WsManPortVisibility | undefined): PortVis
             return "private";
         case WsManPortVisibility.PORT_VISIBILITY_PUBLIC:
             return "public";
+        case WsManPortVisibility.PORT_VISIBILITY_PROTECTED:
+            return "protected";
     }
 };
 
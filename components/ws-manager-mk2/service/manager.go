@@ -179,6 +179,7 @@ func (wsm *WorkspaceManagerServer) StartWorkspace(ctx context.Context, req *wsma
 			Port:       p.Port,
 			Visibility: v,
 			Protocol:   protocol,
+			TokenEpoch: portTokenEpoch(p),
 		})
 	}
 
@@ -588,6 +589,7 @@ func (wsm *WorkspaceManagerServer) ControlPort(ctx context.Context, req *wsmanap
 				Port:       port,
 				Visibility: visibility,
 				Protocol:   protocol,
+				TokenEpoch: portTokenEpoch(req.Spec),
 			})
 		}
 
@@ -599,6 +601,15 @@ func (wsm *WorkspaceManagerServer) ControlPort(ctx context.Context, req *wsmanap
 	return &wsmanapi.ControlPortResponse{}, nil
 }
 
+// portTokenEpoch returns the token epoch of protected ports. Protected ports are private ports
+// which can also be accessed with share tokens, hence their visibility remains AdmissionLevelOwner.
+func portTokenEpoch(p *wsmanapi.PortSpec) uint64 {
+	if p.Visibility != wsmanapi.PortVisibility_PORT_VISIBILITY_PROTECTED {
+		return 0
+	}
+	return p.TokenEpoch
+}
+
 func (wsm *WorkspaceManagerServer) TakeSnapshot(ctx context.Context, req *wsmanapi.TakeSnapshotRequest) (res *wsmanapi.TakeSnapshotResponse, err error) {
 	span, ctx := tracing.FromContext(ctx, "TakeSnapshot")
 	tracing.ApplyOWI(span, log.OWI("", "", req.Id))
@@ -1019,6 +1030,8 @@ func (wsm *WorkspaceManagerServer) extractWorkspaceStatus(ws *workspacev1.Worksp
 		v := wsmanapi.PortVisibility_PORT_VISIBILITY_PRIVATE
 		if p.Visibility == workspacev1.AdmissionLevelEveryone {
 			v = wsmanapi.PortVisibility_PORT_VISIBILITY_PUBLIC
+		} else if p.TokenEpoch != 0 {
+			v = wsmanapi.PortVisibility_PORT_VISIBILITY_PROTECTED
 		}
 		url, err := config.RenderWorkspacePortURL(wsm.Config.WorkspacePortURLTemplate, config.PortURLContext{
 			Host:          wsm.Config.GitpodHostURL,
@@ -1035,6 +1048,7 @@ func (wsm *WorkspaceManagerServer) extractWorkspaceStatus(ws *workspacev1.Worksp
 			Port:       p.Port,
 			Visibility: v,
 			Url:        url,
+			TokenEpoch: p.TokenEpoch,
 		})
 	}
 
@@ -10,9 +10,11 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
+	"github.com/gitpod-io/gitpod/common-go/porttoken"
 	"github.com/gitpod-io/gitpod/ws-manager/api"
 )
 
@@ -58,7 +60,11 @@ func WorkspaceAuthHandler(domain string, info WorkspaceInfoProvider) mux.Middlew
 				// this is a workspace port request and ports can be public or private.
 				// For public ports no tokens or cookies matter, private ports are subject
 				// to the same access policies as the workspace itself is.
-				var isPublic bool
+				var (
+					isPublic    bool
+					isProtected bool
+					tokenEpoch  uint64
+				)
 
 				prt, err := strconv.ParseUint(port, 10, 16)
 				if err != nil {
@@ -67,6 +73,8 @@ func WorkspaceAuthHandler(domain string, info WorkspaceInfoProvider) mux.Middlew
 					for _, p := range ws.Ports {
 						if p.Port == uint32(prt) {
 							isPublic = p.Visibility == api.PortVisibility_PORT_VISIBILITY_PUBLIC
+							isProtected = p.Visibility == api.PortVisibility_PORT_VISIBILITY_PROTECTED
+							tokenEpoch = p.TokenEpoch
 
 							break
 						}
@@ -80,6 +88,16 @@ func WorkspaceAuthHandler(domain string, info WorkspaceInfoProvider) mux.Middlew
 					return
 				}
 
+				// protected ports can be accessed by everyone holding a share token minted by supervisor
+				if isProtected && ws.Auth != nil {
+					cookieName := fmt.Sprintf("%s%s_%d_port_auth_", cookiePrefix, ws.InstanceID, prt)
+					if portTokenAuth(resp, req, ws, uint32(prt), tokenEpoch, cookieName) {
+						h.ServeHTTP(resp, req)
+
+						return
+					}
+				}
+
 				// port seems to be private - subject it to the same access policy as the workspace itself
 			}
 
@@ -115,3 +133,56 @@ func WorkspaceAuthHandler(domain string, info WorkspaceInfoProvider) mux.Middlew
 		})
 	}
 }
+
+// portTokenAuth checks if the request carries a valid port share token, either as query parameter
+// of a signed URL, as cookie set by a previous request, or as bearer token. The token is removed
+// from the request before it's passed on to the workspace. If the token came from the query,
+// a cookie is set s.t. browsers can keep navigating the port.
+func portTokenAuth(resp http.ResponseWriter, req *http.Request, ws *WorkspaceInfo, port uint32, epoch uint64, cookieName string) bool {
+	var (
+		log       = getLog(req.Context())
+		tkn       string
+		fromQuery bool
+	)
+	query := req.URL.Query()
+	if t := query.Get(porttoken.QueryParam); t != "" {
+		tkn, fromQuery = t, true
+		query.Del(porttoken.QueryParam)
+		req.URL.RawQuery = query.Encode()
+	}
+	if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") && porttoken.IsToken(strings.TrimPrefix(auth, "Bearer ")) {
+		if tkn == "" {
+			tkn = strings.TrimPrefix(auth, "Bearer ")
+		}
+		req.Header.Del("Authorization")
+	}
+	if tkn == "" {
+		for _, c := range readCookies(req.Header, cookieName) {
+			tkn = c.Value
+		}
+	}
+	if tkn == "" {
+		return false
+	}
+
+	err := porttoken.Verify(ws.Auth.OwnerToken, ws.InstanceID, port, epoch, tkn, time.Now())
+	if err != nil {
+		log.WithError(err).WithField("port", port).Debug("rejected port token")
+		return false
+	}
+
+	if fromQuery {
+		expiry, _ := porttoken.ExpiresAt(tkn)
+		// no domain attribute makes this a host-only cookie which is limited to this port
+		http.SetCookie(resp, &http.Cookie{
+			Name:     cookieName,
+			Value:    tkn,
+			Path:     "/",
+			Expires:  expiry,
+			Secure:   true,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+	return true
+}
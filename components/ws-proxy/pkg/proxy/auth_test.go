@@ -8,14 +8,17 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/porttoken"
 	"github.com/gitpod-io/gitpod/ws-manager/api"
 )
 
@@ -32,6 +35,7 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 		instanceID  = "instance-fce1-4ff6-9364-cf6dff0c4ecf"
 		ownerToken  = "owner-token"
 		testPort    = 8080
+		tokenEpoch  = 2
 	)
 	var (
 		ownerOnlyInfos = map[string]*WorkspaceInfo{
@@ -45,6 +49,17 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 				Ports: []*api.PortSpec{{Port: testPort, Visibility: api.PortVisibility_PORT_VISIBILITY_PRIVATE}},
 			},
 		}
+		protectedPortInfos = map[string]*WorkspaceInfo{
+			workspaceID: {
+				WorkspaceID: workspaceID,
+				InstanceID:  instanceID,
+				Auth: &api.WorkspaceAuthentication{
+					Admission:  api.AdmissionLevel_ADMIT_OWNER_ONLY,
+					OwnerToken: ownerToken,
+				},
+				Ports: []*api.PortSpec{{Port: testPort, Visibility: api.PortVisibility_PORT_VISIBILITY_PROTECTED, TokenEpoch: tokenEpoch}},
+			},
+		}
 		publicPortInfos = map[string]*WorkspaceInfo{
 			workspaceID: {
 				WorkspaceID: workspaceID,
@@ -70,6 +85,8 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 		OwnerCookie string
 		WorkspaceID string
 		Port        string
+		PortToken   string
+		BearerToken string
 		Expected    testResult
 	}{
 		{
@@ -187,6 +204,82 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 				StatusCode:    http.StatusForbidden,
 			},
 		},
+		{
+			Name:        "protected port with port token",
+			Infos:       protectedPortInfos,
+			WorkspaceID: workspaceID,
+			Port:        strconv.Itoa(testPort),
+			PortToken:   porttoken.Mint(ownerToken, instanceID, testPort, tokenEpoch, time.Now().Add(time.Hour)),
+			Expected: testResult{
+				HandlerCalled: true,
+				StatusCode:    http.StatusOK,
+			},
+		},
+		{
+			Name:        "protected port with port bearer token",
+			Infos:       protectedPortInfos,
+			WorkspaceID: workspaceID,
+			Port:        strconv.Itoa(testPort),
+			BearerToken: porttoken.Mint(ownerToken, instanceID, testPort, tokenEpoch, time.Now().Add(time.Hour)),
+			Expected: testResult{
+				HandlerCalled: true,
+				StatusCode:    http.StatusOK,
+			},
+		},
+		{
+			Name:        "protected port with expired port token",
+			Infos:       protectedPortInfos,
+			WorkspaceID: workspaceID,
+			Port:        strconv.Itoa(testPort),
+			PortToken:   porttoken.Mint(ownerToken, instanceID, testPort, tokenEpoch, time.Now().Add(-time.Minute)),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusUnauthorized,
+			},
+		},
+		{
+			Name:        "protected port with port token of another port",
+			Infos:       protectedPortInfos,
+			WorkspaceID: workspaceID,
+			Port:        strconv.Itoa(testPort),
+			PortToken:   porttoken.Mint(ownerToken, instanceID, testPort+1, tokenEpoch, time.Now().Add(time.Hour)),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusUnauthorized,
+			},
+		},
+		{
+			Name:        "workspace with port token",
+			Infos:       protectedPortInfos,
+			WorkspaceID: workspaceID,
+			PortToken:   porttoken.Mint(ownerToken, instanceID, testPort, tokenEpoch, time.Now().Add(time.Hour)),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusUnauthorized,
+			},
+		},
+		{
+			Name:        "protected port with port token of a previous epoch",
+			Infos:       protectedPortInfos,
+			WorkspaceID: workspaceID,
+			Port:        strconv.Itoa(testPort),
+			PortToken:   porttoken.Mint(ownerToken, instanceID, testPort, tokenEpoch-1, time.Now().Add(time.Hour)),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusUnauthorized,
+			},
+		},
+		{
+			Name:        "private port with port token",
+			Infos:       ownerOnlyInfos,
+			WorkspaceID: workspaceID,
+			Port:        strconv.Itoa(testPort),
+			PortToken:   porttoken.Mint(ownerToken, instanceID, testPort, tokenEpoch, time.Now().Add(time.Hour)),
+			Expected: testResult{
+				HandlerCalled: false,
+				StatusCode:    http.StatusUnauthorized,
+			},
+		},
 		{
 			Name:        "public port",
 			Infos:       publicPortInfos,
@@ -233,6 +326,12 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 			if test.OwnerCookie != "" {
 				setOwnerTokenCookie(req, instanceID, test.OwnerCookie)
 			}
+			if test.PortToken != "" {
+				req.URL.RawQuery = url.Values{porttoken.QueryParam: []string{test.PortToken}}.Encode()
+			}
+			if test.BearerToken != "" {
+				req.Header.Set("Authorization", "Bearer "+test.BearerToken)
+			}
 			vars := map[string]string{
 				workspaceIDIdentifier: test.WorkspaceID,
 			}
@@ -251,6 +350,65 @@ func TestWorkspaceAuthHandler(t *testing.T) {
 	}
 }
 
+func TestWorkspaceAuthHandlerPortToken(t *testing.T) {
+	log.Log.Logger.SetLevel(logrus.PanicLevel)
+
+	const (
+		domain      = "test-domain.com"
+		workspaceID = "workspac-65f4-43c9-bf46-3541b89dca85"
+		instanceID  = "instance-fce1-4ff6-9364-cf6dff0c4ecf"
+		ownerToken  = "owner-token"
+		testPort    = 8080
+		tokenEpoch  = 2
+	)
+	infos := map[string]*WorkspaceInfo{
+		workspaceID: {
+			WorkspaceID: workspaceID,
+			InstanceID:  instanceID,
+			Auth: &api.WorkspaceAuthentication{
+				Admission:  api.AdmissionLevel_ADMIT_OWNER_ONLY,
+				OwnerToken: ownerToken,
+			},
+			Ports: []*api.PortSpec{{Port: testPort, Visibility: api.PortVisibility_PORT_VISIBILITY_PROTECTED, TokenEpoch: tokenEpoch}},
+		},
+	}
+	var forwarded *http.Request
+	handler := WorkspaceAuthHandler(domain, &fixedInfoProvider{Infos: infos})(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		forwarded = req
+	}))
+	vars := map[string]string{
+		workspaceIDIdentifier:   workspaceID,
+		workspacePortIdentifier: strconv.Itoa(testPort),
+	}
+
+	tkn := porttoken.Mint(ownerToken, instanceID, testPort, tokenEpoch, time.Now().Add(time.Hour))
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://%s/foo?bar=baz&%s=%s", domain, porttoken.QueryParam, tkn), nil)
+	handler.ServeHTTP(rr, mux.SetURLVars(req, vars))
+	if forwarded == nil {
+		t.Fatalf("request with port token was not forwarded")
+	}
+	if diff := cmp.Diff("bar=baz", forwarded.URL.RawQuery); diff != "" {
+		t.Errorf("port token was not removed from query (-want +got):\n%s", diff)
+	}
+	cookies := rr.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Value != tkn || !cookies[0].HttpOnly || cookies[0].Domain != "" {
+		t.Fatalf("unexpected cookies: %v", cookies)
+	}
+
+	// the browser keeps navigating with the cookie
+	forwarded = nil
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://%s/other", domain), nil)
+	req.AddCookie(&http.Cookie{Name: cookies[0].Name, Value: cookies[0].Value})
+	handler.ServeHTTP(httptest.NewRecorder(), mux.SetURLVars(req, vars))
+	if forwarded == nil {
+		t.Fatalf("request with port token cookie was not forwarded")
+	}
+	if cookies := removeSensitiveCookies(readCookies(forwarded.Header, ""), domain); len(cookies) != 0 {
+		t.Errorf("port token cookie is not considered sensitive: %v", cookies)
+	}
+}
+
 func setOwnerTokenCookie(r *http.Request, instanceID, token string) {
 	r.AddCookie(&http.Cookie{Name: "_test_domain_com_ws_" + instanceID + "_owner_", Value: token})
 }
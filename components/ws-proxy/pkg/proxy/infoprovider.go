@@ -270,6 +270,8 @@ func (r *CRDWorkspaceInfoProvider) Reconcile(ctx context.Context, req ctrl.Reque
 		protocol := wsapi.PortProtocol_PORT_PROTOCOL_HTTP
 		if p.Visibility == workspacev1.AdmissionLevelEveryone {
 			v = wsapi.PortVisibility_PORT_VISIBILITY_PUBLIC
+		} else if p.TokenEpoch != 0 {
+			v = wsapi.PortVisibility_PORT_VISIBILITY_PROTECTED
 		}
 		if p.Protocol == workspacev1.PortProtocolHttps {
 			protocol = wsapi.PortProtocol_PORT_PROTOCOL_HTTPS
@@ -278,6 +280,7 @@ func (r *CRDWorkspaceInfoProvider) Reconcile(ctx context.Context, req ctrl.Reque
 			Port:       p.Port,
 			Visibility: v,
 			Protocol:   protocol,
+			TokenEpoch: p.TokenEpoch,
 		})
 	}
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gitpod-io/gitpod/content-service/api/config"
 	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -21,7 +22,8 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 		Service: baseserver.ServerConfiguration{
 			Address: fmt.Sprintf("0.0.0.0:%d", RPCPort),
 		},
-		Storage: common.StorageConfig(ctx),
+		Storage:   common.StorageConfig(ctx),
+		Retention: retentionConfig(ctx),
 	}
 
 	fc, err := common.ToJSONString(cscfg)
@@ -42,3 +44,15 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 		},
 	}}, nil
 }
+
+// retentionConfig returns the retention config of the content-service, or nil if retention is disabled
+func retentionConfig(ctx *common.RenderContext) *config.RetentionConfig {
+	var res *config.RetentionConfig
+	_ = ctx.WithExperimental(func(cfg *experimental.Config) error {
+		if cfg.Workspace != nil {
+			res = cfg.Workspace.ContentService.Retention
+		}
+		return nil
+	})
+	return res
+}
@@ -25,6 +25,12 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 		return nil, err
 	}
 
+	// only the retention engine connects to the database
+	var dbEnv []corev1.EnvVar
+	if retentionConfig(ctx) != nil {
+		dbEnv = common.DatabaseEnv(&ctx.Config)
+	}
+
 	podSpec := corev1.PodSpec{
 		Affinity:                      cluster.WithNodeAffinityHostnameAntiAffinity(Component, cluster.AffinityLabelMeta),
 		TopologySpreadConstraints:     cluster.WithHostnameTopologySpread(Component),
@@ -73,6 +79,7 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 			Env: common.CustomizeEnvvar(ctx, Component, common.MergeEnv(
 				common.DefaultEnv(&ctx.Config),
 				common.WorkspaceTracingEnv(ctx, Component),
+				dbEnv,
 				[]corev1.EnvVar{{
 					Name:  "GRPC_GO_RETRY",
 					Value: "on",
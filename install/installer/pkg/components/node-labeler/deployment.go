@@ -10,6 +10,7 @@ import (
 	"github.com/gitpod-io/gitpod/installer/pkg/cluster"
 	"github.com/gitpod-io/gitpod/installer/pkg/common"
 	wsdaemon "github.com/gitpod-io/gitpod/installer/pkg/components/ws-daemon"
+	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -22,6 +23,19 @@ import (
 func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 	labels := common.CustomizeLabel(ctx, Component, common.TypeMetaDeployment)
 
+	args := []string{
+		"run",
+		fmt.Sprintf("--registry-facade-port=%v", common.RegistryFacadeServicePort),
+		fmt.Sprintf("--ws-daemon-port=%v", wsdaemon.ServicePort),
+		fmt.Sprintf("--namespace=%v", ctx.Namespace),
+	}
+	_ = ctx.WithExperimental(func(cfg *experimental.Config) error {
+		if cfg.Workspace != nil && cfg.Workspace.WSDaemon.PrePull.Enabled {
+			args = append(args, fmt.Sprintf("--ws-daemon-prepull-port=%v", wsdaemon.PrePullStatusPort))
+		}
+		return nil
+	})
+
 	podSpec := corev1.PodSpec{
 		PriorityClassName:         common.SystemNodeCritical,
 		Affinity:                  cluster.WithNodeAffinityHostnameAntiAffinity(Component, cluster.AffinityLabelServices),
@@ -39,12 +53,7 @@ func deployment(ctx *common.RenderContext) ([]runtime.Object, error) {
 						"memory": resource.MustParse("32Mi"),
 					},
 				}),
-				Args: []string{
-					"run",
-					fmt.Sprintf("--registry-facade-port=%v", common.RegistryFacadeServicePort),
-					fmt.Sprintf("--ws-daemon-port=%v", wsdaemon.ServicePort),
-					fmt.Sprintf("--namespace=%v", ctx.Namespace),
-				},
+				Args: args,
 				Env: common.CustomizeEnvvar(ctx, Component, common.MergeEnv(
 					common.DefaultEnv(&ctx.Config),
 				)),
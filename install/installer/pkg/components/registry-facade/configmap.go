@@ -23,6 +23,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 	var (
 		ipfsCache  *regfac.IPFSCacheConfig
 		redisCache *regfac.RedisCacheConfig
+		popularity *regfac.PopularityConfig
 	)
 
 	remoteSpecProviders := []*regfac.RSProvider{
@@ -59,6 +60,11 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 			}
 		}
 
+		// ws-daemon warms the nodes with the images registry-facade serves most frequently
+		if ucfg.Workspace.WSDaemon.PrePull.Enabled {
+			popularity = &regfac.PopularityConfig{Enabled: true}
+		}
+
 		if ucfg.Workspace.UseWsmanagerMk2 {
 			remoteSpecProviders = []*regfac.RSProvider{
 				{
@@ -101,6 +107,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 			},
 			IPFSCache:  ipfsCache,
 			RedisCache: redisCache,
+			Popularity: popularity,
 		},
 		AuthCfg:            "/mnt/pull-secret/pull-secret.json",
 		PProfAddr:          common.LocalhostAddressFromPort(baseserver.BuiltinDebugPort),
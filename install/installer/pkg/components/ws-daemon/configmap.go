@@ -23,6 +23,7 @@ import (
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/diskguard"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/iws"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/netlimit"
+	"github.com/gitpod-io/gitpod/ws-daemon/pkg/prepull"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,6 +41,15 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 		return nil, fmt.Errorf("unknown fs shift method: %s", ctx.Config.Workspace.Runtime.FSShiftMethod)
 	}
 
+	// OCI initializers pull anonymously unless the repositories are explicitly allowed to use the registry credentials
+	var (
+		registryAuth             string
+		registryAuthRepositories []string
+	)
+	if pullSecretName(ctx) != "" {
+		registryAuth = "/mnt/pull-secret/pull-secret.json"
+	}
+
 	cpuLimitConfig := cpulimit.Config{
 		Enabled:        false,
 		CGroupBasePath: "/mnt/node-cgroups",
@@ -69,6 +79,10 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 
 	// default workspace network CIDR (and fallback)
 	workspaceCIDR := "10.0.5.0/30"
+	var (
+		prePull prepull.Config
+		httpCfg *baseserver.ServerConfiguration
+	)
 
 	ctx.WithExperimental(func(ucfg *experimental.Config) error {
 		if ucfg.Workspace == nil {
@@ -103,6 +117,27 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 		}
 
 		procLimit = ucfg.Workspace.ProcLimit
+		registryAuthRepositories = ucfg.Workspace.WSDaemon.RegistryAuthRepositories
+
+		if ucfg.Workspace.WSDaemon.PrePull.Enabled {
+			prePullCfg := ucfg.Workspace.WSDaemon.PrePull
+			prePull = prepull.Config{
+				Enabled: true,
+				Popularity: prepull.PopularityConfig{
+					URL:        fmt.Sprintf("https://%s.%s.svc.cluster.local:%d/popularity", common.RegistryFacadeComponent, ctx.Namespace, common.RegistryFacadeServicePort),
+					CAPath:     "/certs/ca.crt",
+					ServerName: fmt.Sprintf("reg.%s", ctx.Config.Domain),
+				},
+				RegistryAuth: registryAuth,
+				Images:       prePullCfg.Images,
+				MaxImages:    prePullCfg.MaxImages,
+				MaxBytes:     prePullCfg.MaxBytes,
+			}
+			// node-labeler polls the warm status of the node
+			httpCfg = &baseserver.ServerConfiguration{
+				Address: fmt.Sprintf("0.0.0.0:%d", PrePullStatusPort),
+			}
+		}
 
 		wscontroller.Enabled = ucfg.Workspace.UseWsmanagerMk2
 		wscontroller.WorkingAreaSuffix = "-mk2"
@@ -145,7 +180,9 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 					Attempts: 3,
 				},
 				Initializer: content.InitializerConfig{
-					Command: "/app/content-initializer",
+					Command:                  "/app/content-initializer",
+					RegistryAuth:             registryAuth,
+					RegistryAuthRepositories: registryAuthRepositories,
 				},
 			},
 			Uidmapper: iws.UidmapperConfig{
@@ -173,6 +210,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 				}},
 			},
 			WorkspaceController: wscontroller,
+			PrePull:             prePull,
 		},
 		Service: baseserver.ServerConfiguration{
 			Address: fmt.Sprintf("0.0.0.0:%d", ServicePort),
@@ -182,6 +220,7 @@ func configmap(ctx *common.RenderContext) ([]runtime.Object, error) {
 				KeyPath:  "/certs/tls.key",
 			},
 		},
+		HTTP: httpCfg,
 	}
 	fc, err := common.ToJSONString(wsdcfg)
 	if err != nil {
@@ -17,4 +17,5 @@ const (
 	TLSSecretName           = "ws-daemon-tls"
 	VolumeTLSCerts          = "ws-daemon-tls-certs"
 	ReadinessPort           = baseserver.BuiltinHealthPort
+	PrePullStatusPort       = 8081
 )
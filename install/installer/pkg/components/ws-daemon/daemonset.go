@@ -9,6 +9,7 @@ import (
 
 	"github.com/gitpod-io/gitpod/installer/pkg/cluster"
 	"github.com/gitpod-io/gitpod/installer/pkg/common"
+	dockerregistry "github.com/gitpod-io/gitpod/installer/pkg/components/docker-registry"
 	"github.com/gitpod-io/gitpod/installer/pkg/config/v1/experimental"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -188,7 +189,37 @@ func daemonset(ctx *common.RenderContext) ([]runtime.Object, error) {
 		common.CAVolumeMount(),
 	}
 
+	// OCI initializers pull with the credentials of the container registry
+	if secretName := pullSecretName(ctx); secretName != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "pull-secret",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secretName,
+					Items:      []corev1.KeyToPath{{Key: ".dockerconfigjson", Path: "pull-secret.json"}},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "pull-secret",
+			MountPath: "/mnt/pull-secret",
+			ReadOnly:  true,
+		})
+	}
+
+	ports := []corev1.ContainerPort{{
+		Name:          "rpc",
+		ContainerPort: ServicePort,
+	}}
+
 	_ = ctx.WithExperimental(func(cfg *experimental.Config) error {
+		if cfg.Workspace != nil && cfg.Workspace.WSDaemon.PrePull.Enabled {
+			ports = append(ports, corev1.ContainerPort{
+				Name:          "prepull",
+				ContainerPort: PrePullStatusPort,
+			})
+		}
+
 		if cfg.Workspace != nil && cfg.Workspace.UseWsmanagerMk2 {
 			mk2WorkingAreaVolume := corev1.Volume{
 				Name: "working-area-mk2",
@@ -243,10 +274,7 @@ func daemonset(ctx *common.RenderContext) ([]runtime.Object, error) {
 					"--config",
 					"/config/config.json",
 				},
-				Ports: []corev1.ContainerPort{{
-					Name:          "rpc",
-					ContainerPort: ServicePort,
-				}},
+				Ports: ports,
 				Env: common.CustomizeEnvvar(ctx, Component, common.MergeEnv(
 					common.DefaultEnv(&cfg),
 					common.WorkspaceTracingEnv(ctx, Component),
@@ -331,3 +359,14 @@ func daemonset(ctx *common.RenderContext) ([]runtime.Object, error) {
 		},
 	}}, nil
 }
+
+// pullSecretName returns the name of the secret holding the credentials of the container registry, if there is one
+func pullSecretName(ctx *common.RenderContext) string {
+	if pointer.BoolDeref(ctx.Config.ContainerRegistry.InCluster, false) {
+		return dockerregistry.BuiltInRegistryAuth
+	}
+	if ctx.Config.ContainerRegistry.External != nil && ctx.Config.ContainerRegistry.External.Certificate != nil {
+		return ctx.Config.ContainerRegistry.External.Certificate.Name
+	}
+	return ""
+}
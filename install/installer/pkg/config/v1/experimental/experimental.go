@@ -16,6 +16,7 @@ import (
 	agentSmith "github.com/gitpod-io/gitpod/agent-smith/pkg/config"
 	"github.com/gitpod-io/gitpod/common-go/grpc"
 	db "github.com/gitpod-io/gitpod/components/gitpod-db/go"
+	csconfig "github.com/gitpod-io/gitpod/content-service/api/config"
 	"github.com/gitpod-io/gitpod/ws-daemon/pkg/cpulimit"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -115,6 +116,15 @@ type WorkspaceConfig struct {
 		Runtime struct {
 			NodeToContainerMapping []NodeToContainerMappingValues `json:"nodeToContainerMapping"`
 		} `json:"runtime"`
+		// RegistryAuthRepositories are the repositories OCI initializers may pull from with the container registry credentials
+		RegistryAuthRepositories []string `json:"registryAuthRepositories"`
+		// PrePull warms the nodes with the images workspaces request most frequently, as tracked by registry-facade
+		PrePull struct {
+			Enabled   bool     `json:"enabled"`
+			Images    []string `json:"images,omitempty"`
+			MaxImages int      `json:"maxImages,omitempty"`
+			MaxBytes  int64    `json:"maxBytes,omitempty"`
+		} `json:"prePull"`
 	} `json:"wsDaemon"`
 
 	WorkspaceClasses map[string]WorkspaceClass `json:"classes,omitempty"`
@@ -130,6 +140,8 @@ type WorkspaceConfig struct {
 	ContentService struct {
 		// Deprecated
 		UsageReportBucketName string `json:"usageReportBucketName"`
+		// Retention configures the garbage collection of workspace content. Disabled if nil.
+		Retention *csconfig.RetentionConfig `json:"retention,omitempty"`
 	} `json:"contentService"`
 
 	EnableProtectedSecrets *bool `json:"enableProtectedSecrets"`
@@ -235,3 +235,5 @@ replace k8s.io/kubectl => k8s.io/kubectl v0.26.2 // leeway indirect from compone
 replace k8s.io/mount-utils => k8s.io/mount-utils v0.26.2 // leeway indirect from components/common-go:lib
 
 replace k8s.io/pod-security-admission => k8s.io/pod-security-admission v0.26.2 // leeway indirect from components/common-go:lib
+
+replace github.com/gitpod-io/gitpod/components/gitpod-db/go => ../components/gitpod-db/go // leeway